@@ -0,0 +1,139 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BudgetScopeType selects what a LiteLLMBudget limits.
+// +kubebuilder:validation:Enum=Gateway;Team;Model
+type BudgetScopeType string
+
+const (
+	// BudgetScopeGateway limits the total spend of the gateway.
+	BudgetScopeGateway BudgetScopeType = "Gateway"
+	// BudgetScopeTeam limits the spend of a LiteLLMTeam.
+	BudgetScopeTeam BudgetScopeType = "Team"
+	// BudgetScopeModel limits the spend of one model of the gateway.
+	BudgetScopeModel BudgetScopeType = "Model"
+)
+
+// BudgetScope is the target of a LiteLLMBudget.
+// +kubebuilder:validation:XValidation:rule="self.type == 'Team' ? has(self.team) : !has(self.team)",message="team is required for, and only allowed with, type Team"
+// +kubebuilder:validation:XValidation:rule="self.type == 'Model' ? has(self.model) : !has(self.model)",message="model is required for, and only allowed with, type Model"
+type BudgetScope struct {
+	// Type of the scope.
+	// +required
+	Type BudgetScopeType `json:"type"`
+
+	// Team names the LiteLLMTeam in the same namespace the budget applies
+	// to. Only for type Team.
+	// +optional
+	Team string `json:"team,omitempty"`
+
+	// Model names the aiModels entry of the gateway the budget applies to.
+	// Only for type Model.
+	// +optional
+	Model string `json:"model,omitempty"`
+}
+
+// LiteLLMBudgetSpec defines the desired state of LiteLLMBudget.
+type LiteLLMBudgetSpec struct {
+	// AiGatewayRef names the AiGateway in the same namespace the budget is
+	// enforced on.
+	// +required
+	AiGatewayRef corev1.LocalObjectReference `json:"aiGatewayRef"`
+
+	// Amount is the spend limit in USD, e.g. "500" or "99.50".
+	// +required
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	Amount string `json:"amount"`
+
+	// Period resets the spend at this interval, in LiteLLM's duration
+	// format, e.g. "30d" or "1mo". Empty budgets never reset.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+(s|m|h|d|mo)$`
+	Period string `json:"period,omitempty"`
+
+	// Scope selects what the budget limits.
+	// +required
+	Scope BudgetScope `json:"scope"`
+}
+
+// LiteLLMBudgetStatus defines the observed state of LiteLLMBudget.
+type LiteLLMBudgetStatus struct {
+	// Conditions describe the state of the budget. Ready is True while the
+	// budget is enforced; BudgetExceeded is True once Spend reaches Amount.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration is the generation the status was computed for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Spend is the spend of the scope in USD as last reported by the proxy.
+	// +optional
+	Spend string `json:"spend,omitempty"`
+
+	// LastSpendTime is when Spend was read.
+	// +optional
+	LastSpendTime *metav1.Time `json:"lastSpendTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Gateway",type=string,JSONPath=`.spec.aiGatewayRef.name`
+// +kubebuilder:printcolumn:name="Scope",type=string,JSONPath=`.spec.scope.type`
+// +kubebuilder:printcolumn:name="Amount",type=string,JSONPath=`.spec.amount`
+// +kubebuilder:printcolumn:name="Spend",type=string,JSONPath=`.status.spend`
+// +kubebuilder:printcolumn:name="Exceeded",type=string,JSONPath=`.status.conditions[?(@.type=="BudgetExceeded")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// LiteLLMBudget sets a spend limit on an AiGateway, one of its teams or one
+// of its models, and reports the spend against it.
+type LiteLLMBudget struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// spec defines the desired state of LiteLLMBudget
+	// +required
+	Spec LiteLLMBudgetSpec `json:"spec"`
+
+	// status defines the observed state of LiteLLMBudget
+	// +optional
+	Status LiteLLMBudgetStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// LiteLLMBudgetList contains a list of LiteLLMBudget.
+type LiteLLMBudgetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LiteLLMBudget `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&LiteLLMBudget{}, &LiteLLMBudgetList{})
+}
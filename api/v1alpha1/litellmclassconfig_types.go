@@ -0,0 +1,92 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// LiteLLMClassConfigSpec defines the desired state of LiteLLMClassConfig.
+// Fields left empty keep the operator's defaults.
+type LiteLLMClassConfigSpec struct {
+	// Image is the LiteLLM image of the gateways, in place of the
+	// operator's --litellm-image. The image policy of the class resolves
+	// newer tags of it.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Resources of the litellm container, in place of the operator's
+	// defaults.
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// LiteLLMSettings is layered onto the litellm_settings of the generated
+	// config. The config-patch ConfigMap of a gateway wins on conflicts.
+	// +optional
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	LiteLLMSettings *runtime.RawExtension `json:"litellmSettings,omitempty"`
+
+	// APIKeySecretName is the Secret in each gateway's namespace the
+	// provider API keys are read from, in place of the operator's
+	// --api-key-secret.
+	// +optional
+	APIKeySecretName string `json:"apiKeySecretName,omitempty"`
+
+	// Callbacks are LiteLLM callbacks, such as "langsmith", added to
+	// litellm_settings.callbacks next to the operator's otel and
+	// prometheus callbacks.
+	// +optional
+	// +listType=set
+	Callbacks []string `json:"callbacks,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,shortName=classconfig
+// +kubebuilder:printcolumn:name="Image",type=string,JSONPath=`.spec.image`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// LiteLLMClassConfig holds the defaults of every AiGateway of an
+// AiGatewayClass: image, resources, litellm_settings, the API key Secret
+// and callbacks. An AiGatewayClass uses it by naming it in its
+// class-config annotation.
+type LiteLLMClassConfig struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// spec defines the desired state of LiteLLMClassConfig
+	// +required
+	Spec LiteLLMClassConfigSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// LiteLLMClassConfigList contains a list of LiteLLMClassConfig.
+type LiteLLMClassConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LiteLLMClassConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&LiteLLMClassConfig{}, &LiteLLMClassConfigList{})
+}
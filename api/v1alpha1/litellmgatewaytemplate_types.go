@@ -0,0 +1,184 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GatewayPodMetadata holds labels and annotations added to gateway pods.
+type GatewayPodMetadata struct {
+	// Labels are added to the pods.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations are added to the pods.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// LiteLLMGatewayTemplateSpec defines the desired state of
+// LiteLLMGatewayTemplate. Every field is layered onto the pod template the
+// operator generates; fields left empty keep the generated values.
+type LiteLLMGatewayTemplateSpec struct {
+	// Metadata is added to the pods. Labels and annotations of the
+	// gateway's own commonMetadata and podMetadata win on conflicts.
+	// +optional
+	Metadata *GatewayPodMetadata `json:"metadata,omitempty"`
+
+	// Sidecars are added next to the litellm container.
+	// The containers are validated when the Deployment is applied; their
+	// schema is left out to keep the CRD small.
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=array
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Sidecars []corev1.Container `json:"sidecars,omitempty"`
+
+	// InitContainers run before the litellm container starts.
+	// The containers are validated when the Deployment is applied; their
+	// schema is left out to keep the CRD small.
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=array
+	// +kubebuilder:pruning:PreserveUnknownFields
+	InitContainers []corev1.Container `json:"initContainers,omitempty"`
+
+	// Volumes are added to the pods.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Volumes []corev1.Volume `json:"volumes,omitempty"`
+
+	// VolumeMounts are added to the litellm container.
+	// +optional
+	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
+
+	// SecurityContext is the pod security context.
+	// +optional
+	SecurityContext *corev1.PodSecurityContext `json:"securityContext,omitempty"`
+
+	// ContainerSecurityContext is the security context of the litellm
+	// container.
+	// +optional
+	ContainerSecurityContext *corev1.SecurityContext `json:"containerSecurityContext,omitempty"`
+
+	// ServiceAccountName is the ServiceAccount the pods run as.
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+
+	// ImagePullSecrets are used to pull the images of the pods.
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// NodeSelector constrains the nodes the pods run on.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations of the pods.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Affinity of the pods.
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// PriorityClassName of the pods.
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// Overrides patch the generated Deployment, Service and ConfigMap after
+	// everything else is rendered, in order. They reach fields the operator
+	// does not expose, and stay applied on every reconcile.
+	// +optional
+	Overrides []ResourceOverride `json:"overrides,omitempty"`
+}
+
+// OverrideTarget is the generated object a ResourceOverride patches.
+// +kubebuilder:validation:Enum=Deployment;Service;ConfigMap
+type OverrideTarget string
+
+// Override targets.
+const (
+	OverrideTargetDeployment OverrideTarget = "Deployment"
+	OverrideTargetService    OverrideTarget = "Service"
+	OverrideTargetConfigMap  OverrideTarget = "ConfigMap"
+)
+
+// OverridePatchType is the format of a ResourceOverride patch.
+// +kubebuilder:validation:Enum=StrategicMerge;JSONPatch
+type OverridePatchType string
+
+// Override patch types.
+const (
+	// OverridePatchStrategicMerge merges a partial object, with the list
+	// semantics of kubectl patch --type strategic.
+	OverridePatchStrategicMerge OverridePatchType = "StrategicMerge"
+	// OverridePatchJSONPatch applies a list of RFC 6902 operations.
+	OverridePatchJSONPatch OverridePatchType = "JSONPatch"
+)
+
+// ResourceOverride patches one generated object of a gateway.
+type ResourceOverride struct {
+	// Target is the generated object patched.
+	// +required
+	Target OverrideTarget `json:"target"`
+
+	// Type is the format of Patch.
+	// +optional
+	// +kubebuilder:default=StrategicMerge
+	Type OverridePatchType `json:"type,omitempty"`
+
+	// Patch is the patch, in YAML or JSON: a partial object for
+	// StrategicMerge, a list of operations for JSONPatch.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Patch string `json:"patch"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=gwtemplate
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// LiteLLMGatewayTemplate captures pod standards, such as sidecars, volumes
+// and security contexts, shared by several AiGateways. AiGateways in the
+// same namespace use it by naming it in their gateway-template annotation.
+type LiteLLMGatewayTemplate struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// spec defines the desired state of LiteLLMGatewayTemplate
+	// +required
+	Spec LiteLLMGatewayTemplateSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// LiteLLMGatewayTemplateList contains a list of LiteLLMGatewayTemplate.
+type LiteLLMGatewayTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LiteLLMGatewayTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&LiteLLMGatewayTemplate{}, &LiteLLMGatewayTemplateList{})
+}
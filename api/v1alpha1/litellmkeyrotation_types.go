@@ -0,0 +1,204 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// KeyRotationSwap takes each new provider key from the next of two keys of a
+// Secret, blue/green style. Between rotations, the key not in use can be
+// regenerated at the provider.
+type KeyRotationSwap struct {
+	// SecretName is the Secret holding both keys. Defaults to
+	// spec.secretRef.name.
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// Keys names the two data keys of the Secret, used in turn.
+	// +required
+	// +kubebuilder:validation:MinItems=2
+	// +kubebuilder:validation:MaxItems=2
+	Keys []string `json:"keys"`
+}
+
+// KeyRotationHook takes each new provider key from an HTTP endpoint that
+// rotates it at the provider.
+type KeyRotationHook struct {
+	// URLSecretRef selects the Secret key holding the hook URL. The operator
+	// POSTs {"namespace", "name", "secret", "key"} to it and expects
+	// {"apiKey": "..."} back.
+	// +required
+	URLSecretRef corev1.SecretKeySelector `json:"urlSecretRef"`
+}
+
+// LiteLLMKeyRotationSpec defines the desired state of LiteLLMKeyRotation.
+// +kubebuilder:validation:XValidation:rule="has(self.swap) != has(self.hook)",message="exactly one of swap or hook is required"
+type LiteLLMKeyRotationSpec struct {
+	// AiGatewayRef names the AiGateway in the same namespace that reads the
+	// provider key. Its health is checked after each rotation.
+	// +required
+	AiGatewayRef corev1.LocalObjectReference `json:"aiGatewayRef"`
+
+	// SecretRef selects the Secret key the gateway reads the provider key
+	// from, e.g. through a secretKeyRef in spec.env. Each rotation writes the
+	// new key to it.
+	// +required
+	SecretRef corev1.SecretKeySelector `json:"secretRef"`
+
+	// Schedule is a cron schedule of the rotations, e.g. "0 3 1 * *" for
+	// 03:00 on the first of every month.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Schedule string `json:"schedule"`
+
+	// TimeZone is the IANA time zone Schedule is read in. Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// Swap rotates between two keys of a Secret.
+	// +optional
+	Swap *KeyRotationSwap `json:"swap,omitempty"`
+
+	// Hook rotates through an HTTP endpoint.
+	// +optional
+	Hook *KeyRotationHook `json:"hook,omitempty"`
+
+	// VerifyTimeout bounds how long a rotation waits for the gateway to roll
+	// out the new key and report healthy before it is rolled back. Defaults
+	// to 10m.
+	// +optional
+	VerifyTimeout *metav1.Duration `json:"verifyTimeout,omitempty"`
+}
+
+// Results of a key rotation.
+const (
+	KeyRotationSucceeded  = "Succeeded"
+	KeyRotationRolledBack = "RolledBack"
+	KeyRotationFailed     = "Failed"
+)
+
+// KeyRotationRecord is one rotation in the history of a LiteLLMKeyRotation.
+type KeyRotationRecord struct {
+	// Time is when the rotation started.
+	Time metav1.Time `json:"time"`
+
+	// Result is Succeeded, RolledBack or Failed.
+	// +kubebuilder:validation:Enum=Succeeded;RolledBack;Failed
+	Result string `json:"result"`
+
+	// Key is the swap key the rotation switched to.
+	// +optional
+	Key string `json:"key,omitempty"`
+
+	// Message explains a rollback or failure.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// KeyRotationVerification is a rotation whose new key is being verified.
+type KeyRotationVerification struct {
+	// StartedAt is when the new key was written.
+	StartedAt metav1.Time `json:"startedAt"`
+
+	// Key is the swap key the rotation switched to.
+	// +optional
+	Key string `json:"key,omitempty"`
+
+	// SecretHash is the secret-hash the gateway ran with before the
+	// rotation. The rollout of the new key changes it.
+	// +optional
+	SecretHash string `json:"secretHash,omitempty"`
+}
+
+// LiteLLMKeyRotationStatus defines the observed state of LiteLLMKeyRotation.
+type LiteLLMKeyRotationStatus struct {
+	// Conditions describe the state of the rotation. Ready is True while the
+	// schedule is valid, the gateway exists and the last rotation did not
+	// fail.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration is the generation the status was computed for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// ActiveKey is the swap key currently in use.
+	// +optional
+	ActiveKey string `json:"activeKey,omitempty"`
+
+	// LastRotationTime is when the last rotation started.
+	// +optional
+	LastRotationTime *metav1.Time `json:"lastRotationTime,omitempty"`
+
+	// NextRotationTime is when the next rotation is due.
+	// +optional
+	NextRotationTime *metav1.Time `json:"nextRotationTime,omitempty"`
+
+	// Verifying is the rotation in progress, if any.
+	// +optional
+	Verifying *KeyRotationVerification `json:"verifying,omitempty"`
+
+	// History lists the last rotations, newest first.
+	// +optional
+	// +kubebuilder:validation:MaxItems=10
+	History []KeyRotationRecord `json:"history,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=keyrotation
+// +kubebuilder:printcolumn:name="Gateway",type=string,JSONPath=`.spec.aiGatewayRef.name`
+// +kubebuilder:printcolumn:name="Secret",type=string,JSONPath=`.spec.secretRef.name`
+// +kubebuilder:printcolumn:name="Schedule",type=string,JSONPath=`.spec.schedule`
+// +kubebuilder:printcolumn:name="Last",type=date,JSONPath=`.status.lastRotationTime`
+// +kubebuilder:printcolumn:name="Next",type=date,JSONPath=`.status.nextRotationTime`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// LiteLLMKeyRotation rotates the provider key of an AiGateway on a schedule.
+// A rotation whose new key leaves the gateway unhealthy is rolled back.
+type LiteLLMKeyRotation struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// spec defines the desired state of LiteLLMKeyRotation
+	// +required
+	Spec LiteLLMKeyRotationSpec `json:"spec"`
+
+	// status defines the observed state of LiteLLMKeyRotation
+	// +optional
+	Status LiteLLMKeyRotationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// LiteLLMKeyRotationList contains a list of LiteLLMKeyRotation.
+type LiteLLMKeyRotationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LiteLLMKeyRotation `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&LiteLLMKeyRotation{}, &LiteLLMKeyRotationList{})
+}
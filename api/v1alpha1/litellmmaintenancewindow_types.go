@@ -0,0 +1,125 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// LiteLLMMaintenanceWindowSpec defines the desired state of
+// LiteLLMMaintenanceWindow.
+// +kubebuilder:validation:XValidation:rule="has(self.models) || has(self.providers)",message="at least one of models or providers is required"
+type LiteLLMMaintenanceWindowSpec struct {
+	// AiGatewayRef names the AiGateway in the same namespace whose models
+	// are taken out of service.
+	// +required
+	AiGatewayRef corev1.LocalObjectReference `json:"aiGatewayRef"`
+
+	// Schedule is a cron schedule of the window starts, e.g. "0 22 * * 1-5"
+	// for 22:00 on weekdays.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Schedule string `json:"schedule"`
+
+	// Duration is how long each window lasts, at most 168h.
+	// +required
+	Duration metav1.Duration `json:"duration"`
+
+	// TimeZone is the IANA time zone Schedule is read in, e.g.
+	// "Europe/Berlin". Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// Models lists the aiModels entries, by name, removed during the
+	// window.
+	// +optional
+	Models []string `json:"models,omitempty"`
+
+	// Providers lists providers whose aiModels entries are removed during
+	// the window.
+	// +optional
+	Providers []string `json:"providers,omitempty"`
+}
+
+// LiteLLMMaintenanceWindowStatus defines the observed state of
+// LiteLLMMaintenanceWindow.
+type LiteLLMMaintenanceWindowStatus struct {
+	// Conditions describe the state of the window. Ready is True while the
+	// schedule is valid and the gateway exists.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration is the generation the status was computed for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Active is true while a window is open.
+	// +optional
+	Active bool `json:"active,omitempty"`
+
+	// ActiveUntil is when the open window closes.
+	// +optional
+	ActiveUntil *metav1.Time `json:"activeUntil,omitempty"`
+
+	// NextStart is when the next window opens, while none is open.
+	// +optional
+	NextStart *metav1.Time `json:"nextStart,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=maintenance
+// +kubebuilder:printcolumn:name="Gateway",type=string,JSONPath=`.spec.aiGatewayRef.name`
+// +kubebuilder:printcolumn:name="Schedule",type=string,JSONPath=`.spec.schedule`
+// +kubebuilder:printcolumn:name="Duration",type=string,JSONPath=`.spec.duration`
+// +kubebuilder:printcolumn:name="Active",type=boolean,JSONPath=`.status.active`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// LiteLLMMaintenanceWindow removes models of an AiGateway from service on
+// a schedule, e.g. during planned provider maintenance or outside business
+// hours.
+type LiteLLMMaintenanceWindow struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// spec defines the desired state of LiteLLMMaintenanceWindow
+	// +required
+	Spec LiteLLMMaintenanceWindowSpec `json:"spec"`
+
+	// status defines the observed state of LiteLLMMaintenanceWindow
+	// +optional
+	Status LiteLLMMaintenanceWindowStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// LiteLLMMaintenanceWindowList contains a list of LiteLLMMaintenanceWindow.
+type LiteLLMMaintenanceWindowList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LiteLLMMaintenanceWindow `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&LiteLLMMaintenanceWindow{}, &LiteLLMMaintenanceWindowList{})
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PassThroughAuthScheme selects how the auth header value is formed from
+// the Secret.
+// +kubebuilder:validation:Enum=Raw;Bearer
+type PassThroughAuthScheme string
+
+const (
+	// PassThroughAuthRaw sends the Secret value as the header value.
+	PassThroughAuthRaw PassThroughAuthScheme = "Raw"
+	// PassThroughAuthBearer sends "Bearer <Secret value>".
+	PassThroughAuthBearer PassThroughAuthScheme = "Bearer"
+)
+
+// PassThroughAuth is a header LiteLLM adds to every forwarded request,
+// with its value read from a Secret.
+type PassThroughAuth struct {
+	// Header is the name of the header.
+	// +optional
+	// +kubebuilder:default=Authorization
+	Header string `json:"header,omitempty"`
+
+	// Scheme selects how the header value is formed from the Secret.
+	// +optional
+	// +kubebuilder:default=Raw
+	Scheme PassThroughAuthScheme `json:"scheme,omitempty"`
+
+	// SecretKeyRef selects the key of a Secret in the same namespace
+	// holding the credential.
+	// +required
+	SecretKeyRef corev1.SecretKeySelector `json:"secretKeyRef"`
+}
+
+// LiteLLMPassThroughEndpointSpec defines the desired state of
+// LiteLLMPassThroughEndpoint.
+type LiteLLMPassThroughEndpointSpec struct {
+	// Path is the route on the proxy, e.g. "/v1/assistants". Requests to it
+	// and below it are forwarded to Target.
+	// +required
+	// +kubebuilder:validation:Pattern=`^/[^\s]*$`
+	Path string `json:"path"`
+
+	// Target is the URL requests are forwarded to.
+	// +required
+	// +kubebuilder:validation:Pattern=`^https?://[^\s]+$`
+	Target string `json:"target"`
+
+	// Auth adds a credential header to forwarded requests.
+	// +optional
+	Auth *PassThroughAuth `json:"auth,omitempty"`
+
+	// ForwardHeaders forwards the headers of the incoming request to
+	// Target.
+	// +optional
+	ForwardHeaders bool `json:"forwardHeaders,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=passthrough
+// +kubebuilder:printcolumn:name="Path",type=string,JSONPath=`.spec.path`
+// +kubebuilder:printcolumn:name="Target",type=string,JSONPath=`.spec.target`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// LiteLLMPassThroughEndpoint forwards a path of the proxy to a provider
+// API LiteLLM does not model, such as assistants or fine-tuning. AiGateways
+// in the same namespace serve it by listing its name in their
+// pass-through-endpoints annotation.
+type LiteLLMPassThroughEndpoint struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// spec defines the desired state of LiteLLMPassThroughEndpoint
+	// +required
+	Spec LiteLLMPassThroughEndpointSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// LiteLLMPassThroughEndpointList contains a list of LiteLLMPassThroughEndpoint.
+type LiteLLMPassThroughEndpointList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LiteLLMPassThroughEndpoint `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&LiteLLMPassThroughEndpoint{}, &LiteLLMPassThroughEndpointList{})
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RateLimitScopeType selects what a LiteLLMRateLimitPolicy limits.
+// +kubebuilder:validation:Enum=Team;Key;Model
+type RateLimitScopeType string
+
+const (
+	// RateLimitScopeTeam limits the requests of a LiteLLMTeam.
+	RateLimitScopeTeam RateLimitScopeType = "Team"
+	// RateLimitScopeKey limits the requests made with a LiteLLMVirtualKey.
+	RateLimitScopeKey RateLimitScopeType = "Key"
+	// RateLimitScopeModel limits the requests to one model of the gateway.
+	RateLimitScopeModel RateLimitScopeType = "Model"
+)
+
+// RateLimitScope is the target of a LiteLLMRateLimitPolicy.
+// +kubebuilder:validation:XValidation:rule="self.type == 'Team' ? has(self.team) : !has(self.team)",message="team is required for, and only allowed with, type Team"
+// +kubebuilder:validation:XValidation:rule="self.type == 'Key' ? has(self.key) : !has(self.key)",message="key is required for, and only allowed with, type Key"
+// +kubebuilder:validation:XValidation:rule="self.type == 'Model' ? has(self.model) : !has(self.model)",message="model is required for, and only allowed with, type Model"
+type RateLimitScope struct {
+	// Type of the scope.
+	// +required
+	Type RateLimitScopeType `json:"type"`
+
+	// Team names the LiteLLMTeam in the same namespace the limits apply
+	// to. Only for type Team.
+	// +optional
+	Team string `json:"team,omitempty"`
+
+	// Key names the LiteLLMVirtualKey in the same namespace the limits
+	// apply to. Only for type Key.
+	// +optional
+	Key string `json:"key,omitempty"`
+
+	// Model names the aiModels entry of the gateway the limits apply to.
+	// Only for type Model.
+	// +optional
+	Model string `json:"model,omitempty"`
+}
+
+// RateLimits are request limits enforced by LiteLLM. Unset limits do not
+// apply.
+// +kubebuilder:validation:XValidation:rule="has(self.rpm) || has(self.tpm) || has(self.maxParallelRequests)",message="at least one limit is required"
+type RateLimits struct {
+	// RPM is the maximum number of requests per minute.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	RPM *int64 `json:"rpm,omitempty"`
+
+	// TPM is the maximum number of tokens per minute.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	TPM *int64 `json:"tpm,omitempty"`
+
+	// MaxParallelRequests is the maximum number of requests in flight.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxParallelRequests *int64 `json:"maxParallelRequests,omitempty"`
+}
+
+// LiteLLMRateLimitPolicySpec defines the desired state of
+// LiteLLMRateLimitPolicy.
+type LiteLLMRateLimitPolicySpec struct {
+	// AiGatewayRef names the AiGateway in the same namespace the limits are
+	// enforced on.
+	// +required
+	AiGatewayRef corev1.LocalObjectReference `json:"aiGatewayRef"`
+
+	// Limits to enforce on the scope.
+	// +required
+	Limits RateLimits `json:"limits"`
+
+	// Scope selects what the limits apply to.
+	// +required
+	Scope RateLimitScope `json:"scope"`
+}
+
+// LiteLLMRateLimitPolicyStatus defines the observed state of
+// LiteLLMRateLimitPolicy.
+type LiteLLMRateLimitPolicyStatus struct {
+	// Conditions describe the state of the policy. Ready is True while the
+	// limits are enforced on the scope.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration is the generation the status was computed for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Effective are the limits in effect on the scope: for each limit, the
+	// lowest value among the policies of the scope.
+	// +optional
+	Effective *RateLimits `json:"effective,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=ratelimit
+// +kubebuilder:printcolumn:name="Gateway",type=string,JSONPath=`.spec.aiGatewayRef.name`
+// +kubebuilder:printcolumn:name="Scope",type=string,JSONPath=`.spec.scope.type`
+// +kubebuilder:printcolumn:name="RPM",type=integer,JSONPath=`.spec.limits.rpm`
+// +kubebuilder:printcolumn:name="TPM",type=integer,JSONPath=`.spec.limits.tpm`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// LiteLLMRateLimitPolicy sets request limits on a team, a virtual key or a
+// model of an AiGateway.
+type LiteLLMRateLimitPolicy struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// spec defines the desired state of LiteLLMRateLimitPolicy
+	// +required
+	Spec LiteLLMRateLimitPolicySpec `json:"spec"`
+
+	// status defines the observed state of LiteLLMRateLimitPolicy
+	// +optional
+	Status LiteLLMRateLimitPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// LiteLLMRateLimitPolicyList contains a list of LiteLLMRateLimitPolicy.
+type LiteLLMRateLimitPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LiteLLMRateLimitPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&LiteLLMRateLimitPolicy{}, &LiteLLMRateLimitPolicyList{})
+}
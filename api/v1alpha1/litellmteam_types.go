@@ -0,0 +1,130 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// LiteLLMTeamSpec defines the desired state of LiteLLMTeam.
+type LiteLLMTeamSpec struct {
+	// AiGatewayRef names the AiGateway in the same namespace the team is
+	// managed on. The gateway must be database-backed (DATABASE_URL in
+	// spec.env).
+	// +required
+	AiGatewayRef corev1.LocalObjectReference `json:"aiGatewayRef"`
+
+	// Models restricts the team to these model names. Empty allows every
+	// model of the gateway.
+	// +optional
+	// +listType=set
+	Models []string `json:"models,omitempty"`
+
+	// MaxBudget is the spend limit of the team in USD, e.g. "250" or "99.50".
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	MaxBudget string `json:"maxBudget,omitempty"`
+
+	// BudgetDuration resets the spend of the team at this interval, in
+	// LiteLLM's duration format, e.g. "30d" or "1mo".
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+(s|m|h|d|mo)$`
+	BudgetDuration string `json:"budgetDuration,omitempty"`
+
+	// Members of the team. Members added in the admin UI that are not listed
+	// here are removed.
+	// +optional
+	Members []LiteLLMTeamMember `json:"members,omitempty"`
+}
+
+// LiteLLMTeamMember is a user of a LiteLLMTeam, identified by userID or
+// userEmail.
+// +kubebuilder:validation:XValidation:rule="has(self.userID) || has(self.userEmail)",message="one of userID or userEmail is required"
+type LiteLLMTeamMember struct {
+	// UserID is the LiteLLM user ID of the member.
+	// +optional
+	UserID string `json:"userID,omitempty"`
+
+	// UserEmail is the email of the member. LiteLLM creates the user on
+	// first use.
+	// +optional
+	UserEmail string `json:"userEmail,omitempty"`
+
+	// Role of the member within the team.
+	// +optional
+	// +kubebuilder:validation:Enum=admin;user
+	// +kubebuilder:default=user
+	Role string `json:"role,omitempty"`
+}
+
+// LiteLLMTeamStatus defines the observed state of LiteLLMTeam.
+type LiteLLMTeamStatus struct {
+	// Conditions describe the state of the team. The Ready condition is True
+	// once the team exists on the gateway with the current spec.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration is the generation the team was last reconciled at.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// TeamID is the team_id of the team on the gateway, to be used e.g. when
+	// generating keys for the team.
+	// +optional
+	TeamID string `json:"teamID,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Gateway",type=string,JSONPath=`.spec.aiGatewayRef.name`
+// +kubebuilder:printcolumn:name="Budget",type=string,JSONPath=`.spec.maxBudget`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// LiteLLMTeam manages a LiteLLM team, with its budget, allowed models and
+// members, on an AiGateway. The team is deleted when the object is deleted.
+type LiteLLMTeam struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// spec defines the desired state of LiteLLMTeam
+	// +required
+	Spec LiteLLMTeamSpec `json:"spec"`
+
+	// status defines the observed state of LiteLLMTeam
+	// +optional
+	Status LiteLLMTeamStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// LiteLLMTeamList contains a list of LiteLLMTeam.
+type LiteLLMTeamList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LiteLLMTeam `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&LiteLLMTeam{}, &LiteLLMTeamList{})
+}
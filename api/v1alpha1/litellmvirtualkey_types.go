@@ -0,0 +1,125 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// LiteLLMVirtualKeySpec defines the desired state of LiteLLMVirtualKey.
+type LiteLLMVirtualKeySpec struct {
+	// AiGatewayRef names the AiGateway in the same namespace that issues the
+	// key. The gateway must be database-backed (DATABASE_URL in spec.env).
+	// +required
+	AiGatewayRef corev1.LocalObjectReference `json:"aiGatewayRef"`
+
+	// Models restricts the key to these model names. Empty allows every
+	// model of the gateway.
+	// +optional
+	// +listType=set
+	Models []string `json:"models,omitempty"`
+
+	// MaxBudget is the spend limit of the key in USD, e.g. "25" or "12.50".
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	MaxBudget string `json:"maxBudget,omitempty"`
+
+	// BudgetDuration resets the spend of the key at this interval, in
+	// LiteLLM's duration format, e.g. "30d" or "1mo".
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+(s|m|h|d|mo)$`
+	BudgetDuration string `json:"budgetDuration,omitempty"`
+
+	// Duration after which the key expires, in LiteLLM's duration format.
+	// Empty keys never expire.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+(s|m|h|d|mo)$`
+	Duration string `json:"duration,omitempty"`
+
+	// SecretName is the Secret the key is written to. Defaults to the name of
+	// the LiteLLMVirtualKey.
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+}
+
+// LiteLLMVirtualKeyStatus defines the observed state of LiteLLMVirtualKey.
+type LiteLLMVirtualKeyStatus struct {
+	// Conditions describe the state of the key. The Ready condition is True
+	// once the key is provisioned and written to SecretName.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration is the generation whose limits the key carries.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// SecretName is the Secret holding the key.
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// Expires is when the key stops working. Unset for keys without a duration.
+	// +optional
+	Expires *metav1.Time `json:"expires,omitempty"`
+
+	// RateLimits are the limits of the LiteLLMRateLimitPolicies the key
+	// carries. Unset when no policy applies to the key.
+	// +optional
+	RateLimits *RateLimits `json:"rateLimits,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=vkey
+// +kubebuilder:printcolumn:name="Gateway",type=string,JSONPath=`.spec.aiGatewayRef.name`
+// +kubebuilder:printcolumn:name="Secret",type=string,JSONPath=`.status.secretName`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Expires",type=date,JSONPath=`.status.expires`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// LiteLLMVirtualKey provisions a LiteLLM virtual key on an AiGateway and
+// stores it in a Secret. The key is revoked when the object is deleted.
+type LiteLLMVirtualKey struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// spec defines the desired state of LiteLLMVirtualKey
+	// +required
+	Spec LiteLLMVirtualKeySpec `json:"spec"`
+
+	// status defines the observed state of LiteLLMVirtualKey
+	// +optional
+	Status LiteLLMVirtualKeyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// LiteLLMVirtualKeyList contains a list of LiteLLMVirtualKey.
+type LiteLLMVirtualKeyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LiteLLMVirtualKey `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&LiteLLMVirtualKey{}, &LiteLLMVirtualKeyList{})
+}
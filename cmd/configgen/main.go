@@ -0,0 +1,209 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command configgen prints the LiteLLM config.yaml the operator generates
+// for each AiGateway in the given manifests, with its config hash, so
+// configs can be validated in CI before the CRs are applied.
+//
+//	configgen [-namespace ns] [file ...]
+//
+// Files are read as multi-document YAML; "-" or no file reads stdin. Objects
+// the config depends on (Guards, GuardrailProviders, LiteLLMBudgets,
+// LiteLLMRateLimitPolicies, LiteLLMPassThroughEndpoints,
+// LiteLLMMaintenanceWindows, config-patch ConfigMaps, upstream AiGateways,
+// AiGatewayClasses, LiteLLMClassConfigs and model server Services) are taken
+// from the same input.
+// Without an AiGatewayClass in the input, a default class served by the
+// operator is assumed. Maintenance windows are evaluated at the current time.
+//
+// Gateways of the operator are defaulted and validated as the admission
+// webhook would, so the printed config is the one the operator renders once
+// the gateway is applied. Webhook warnings are printed as comments.
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	litellmv1alpha1 "github.com/agentic-layer/ai-gateway-litellm/api/v1alpha1"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/controller"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/litellm"
+	webhookv1alpha1 "github.com/agentic-layer/ai-gateway-litellm/internal/webhook/v1alpha1"
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(gatewayv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(litellmv1alpha1.AddToScheme(scheme))
+}
+
+func main() {
+	namespace := flag.String("namespace", "default", "Namespace of objects that do not set one.")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [-namespace ns] [file ...]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	logf.SetLogger(logr.Discard())
+
+	files := flag.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+	var objs []client.Object
+	for _, name := range files {
+		read, err := readObjects(name, *namespace)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "configgen: %s: %v\n", name, err)
+			os.Exit(2)
+		}
+		objs = append(objs, read...)
+	}
+
+	if err := run(context.Background(), os.Stdout, objs); err != nil {
+		fmt.Fprintf(os.Stderr, "configgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run prints the config of every AiGateway in objs, in input order.
+// Failing gateways are reported together once all have been tried.
+func run(ctx context.Context, out io.Writer, objs []client.Object) error {
+	hasClass := false
+	var gateways []*gatewayv1alpha1.AiGateway
+	for _, obj := range objs {
+		switch o := obj.(type) {
+		case *gatewayv1alpha1.AiGatewayClass:
+			hasClass = true
+		case *gatewayv1alpha1.AiGateway:
+			gateways = append(gateways, o)
+		}
+	}
+	if len(gateways) == 0 {
+		return errors.New("no AiGateway in input")
+	}
+	if !hasClass {
+		objs = append(objs, &gatewayv1alpha1.AiGatewayClass{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "litellm",
+				Annotations: map[string]string{litellm.AiGatewayClassDefaultAnnotation: "true"},
+			},
+			Spec: gatewayv1alpha1.AiGatewayClassSpec{Controller: controller.ControllerName},
+		})
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+
+	var errs []error
+	printed := 0
+	for _, gw := range gateways {
+		warnings, err := admit(ctx, c, gw)
+		var configYAML string
+		if err == nil {
+			configYAML, err = controller.GenerateAiGatewayConfig(ctx, c, c, gw)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("AiGateway %s/%s: %w", gw.Namespace, gw.Name, err))
+			continue
+		}
+		if printed > 0 {
+			fmt.Fprintln(out, "---")
+		}
+		printed++
+		fmt.Fprintf(out, "# AiGateway %s/%s\n", gw.Namespace, gw.Name)
+		for _, warning := range warnings {
+			fmt.Fprintf(out, "# warning: %s\n", warning)
+		}
+		fmt.Fprintf(out, "# config-hash: %s\n%s", litellm.ConfigHash(configYAML), configYAML)
+	}
+	return errors.Join(errs...)
+}
+
+// admit defaults and validates gw in place like the admission webhook does
+// on create. Gateways of another controller's class are left as they are.
+func admit(ctx context.Context, c client.Reader, gw *gatewayv1alpha1.AiGateway) (admission.Warnings, error) {
+	owned, err := litellm.IsAiGatewayOwnedByController(ctx, c, gw, controller.ControllerName)
+	if err != nil || !owned {
+		return nil, err
+	}
+	if err := (&webhookv1alpha1.AiGatewayCustomDefaulter{Client: c}).Default(ctx, gw); err != nil {
+		return nil, err
+	}
+	return webhookv1alpha1.ValidateAiGateway(gw)
+}
+
+// readObjects decodes every document of the file name ("-" for stdin) into
+// a typed object. Kinds unknown to the scheme are skipped.
+func readObjects(name, namespace string) ([]client.Object, error) {
+	in := os.Stdin
+	if name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = f.Close() }()
+		in = f
+	}
+
+	decoder := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(in))
+	var objs []client.Object
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return objs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		obj, gvk, err := decoder.Decode(doc, nil, nil)
+		if runtime.IsNotRegisteredError(err) || runtime.IsMissingKind(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		cobj, ok := obj.(client.Object)
+		if !ok {
+			return nil, fmt.Errorf("unsupported object %s", gvk)
+		}
+		// AiGatewayClasses and LiteLLMClassConfigs are cluster-scoped.
+		_, class := cobj.(*gatewayv1alpha1.AiGatewayClass)
+		_, classConfig := cobj.(*litellmv1alpha1.LiteLLMClassConfig)
+		if !class && !classConfig && cobj.GetNamespace() == "" {
+			cobj.SetNamespace(namespace)
+		}
+		objs = append(objs, cobj)
+	}
+}
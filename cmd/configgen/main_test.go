@@ -0,0 +1,152 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func writeManifest(t *testing.T, content string) string {
+	t.Helper()
+	name := filepath.Join(t.TempDir(), "manifest.yaml")
+	if err := os.WriteFile(name, []byte(content), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	return name
+}
+
+func TestReadObjects(t *testing.T) {
+	name := writeManifest(t, `apiVersion: runtime.agentic-layer.ai/v1alpha1
+kind: AiGateway
+metadata:
+  name: gw
+spec:
+  port: 4000
+---
+apiVersion: example.com/v1
+kind: Unknown
+metadata:
+  name: skipped
+---
+apiVersion: runtime.agentic-layer.ai/v1alpha1
+kind: AiGatewayClass
+metadata:
+  name: litellm
+spec:
+  controller: aigateway.agentic-layer.ai/ai-gateway-litellm-controller
+---
+apiVersion: litellm.agentic-layer.ai/v1alpha1
+kind: LiteLLMClassConfig
+metadata:
+  name: platform
+spec:
+  image: ghcr.io/berriai/litellm:v1.83.14-stable
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: patch
+  namespace: other
+`)
+	objs, err := readObjects(name, "team-a")
+	if err != nil {
+		t.Fatalf("readObjects: %v", err)
+	}
+	if len(objs) != 4 {
+		t.Fatalf("got %d objects, want 4 (the unknown kind skipped)", len(objs))
+	}
+	want := []string{"team-a/gw", "/litellm", "/platform", "other/patch"}
+	for i, obj := range objs {
+		if got := obj.GetNamespace() + "/" + obj.GetName(); got != want[i] {
+			t.Errorf("objs[%d] = %s, want %s", i, got, want[i])
+		}
+	}
+}
+
+func TestReadObjects_Errors(t *testing.T) {
+	if _, err := readObjects(filepath.Join(t.TempDir(), "missing.yaml"), "default"); err == nil {
+		t.Error("missing file: want an error")
+	}
+	name := writeManifest(t, "apiVersion: v1\nkind: ConfigMap\nmetadata: [\n")
+	if _, err := readObjects(name, "default"); err == nil {
+		t.Error("malformed YAML: want an error")
+	}
+}
+
+func gateway(name string, models ...gatewayv1alpha1.AiModel) *gatewayv1alpha1.AiGateway {
+	gw := &gatewayv1alpha1.AiGateway{Spec: gatewayv1alpha1.AiGatewaySpec{Port: 4000, AiModels: models}}
+	gw.Name, gw.Namespace = name, "default"
+	return gw
+}
+
+func TestRun_ImplicitDefaultClass(t *testing.T) {
+	var out bytes.Buffer
+	objs := []client.Object{
+		gateway("a", gatewayv1alpha1.AiModel{Provider: "openai", Name: "gpt-4o"}),
+		gateway("b", gatewayv1alpha1.AiModel{Provider: "anthropic", Name: "claude-sonnet-4"}),
+	}
+	if err := run(context.Background(), &out, objs); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	got := out.String()
+	for _, want := range []string{"# AiGateway default/a\n", "---\n# AiGateway default/b\n", "# config-hash: ",
+		"model: openai/gpt-4o", "model: anthropic/claude-sonnet-4"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output lacks %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestRun_DefaultsGateways(t *testing.T) {
+	var out bytes.Buffer
+	gw := gateway("gw",
+		gatewayv1alpha1.AiModel{Name: " openai/gpt-4o "},
+		gatewayv1alpha1.AiModel{Provider: "OpenAI", Name: "gpt-4o"},
+	)
+	if err := run(context.Background(), &out, []client.Object{gw}); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if got := strings.Count(out.String(), "model: openai/gpt-4o"); got != 1 {
+		t.Errorf("want the model listed once after defaulting, got %d:\n%s", got, out.String())
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	if err := run(context.Background(), &bytes.Buffer{}, nil); err == nil || err.Error() != "no AiGateway in input" {
+		t.Errorf("err = %v, want no AiGateway in input", err)
+	}
+
+	var out bytes.Buffer
+	invalid := gateway("bad", gatewayv1alpha1.AiModel{Provider: "opneai", Name: "gpt-4o"})
+	valid := gateway("good", gatewayv1alpha1.AiModel{Provider: "openai", Name: "gpt-4o"})
+	err := run(context.Background(), &out, []client.Object{invalid, valid})
+	if err == nil || !strings.Contains(err.Error(), "AiGateway default/bad") ||
+		!strings.Contains(err.Error(), `Unsupported value: "opneai"`) {
+		t.Errorf("err = %v, want the unknown provider of default/bad", err)
+	}
+	if !strings.Contains(out.String(), "# AiGateway default/good\n") {
+		t.Errorf("the valid gateway is still printed, got:\n%s", out.String())
+	}
+}
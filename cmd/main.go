@@ -17,10 +17,21 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
+	"errors"
 	"flag"
+	"fmt"
+	"hash/fnv"
+	"maps"
+	"net"
+	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -28,16 +39,34 @@ import (
 	"k8s.io/klog/v2"
 
 	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	litellmv1alpha1 "github.com/agentic-layer/ai-gateway-litellm/api/v1alpha1"
 	"github.com/agentic-layer/ai-gateway-litellm/internal/controller"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/litellm"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/operatorconfig"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/webhook/certs"
+	webhookv1alpha1 "github.com/agentic-layer/ai-gateway-litellm/internal/webhook/v1alpha1"
+	"golang.org/x/time/rate"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/discovery"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	// +kubebuilder:scaffold:imports
 )
@@ -51,6 +80,7 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(gatewayv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(litellmv1alpha1.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 }
 
@@ -59,10 +89,45 @@ func main() {
 	var metricsAddr string
 	var metricsCertPath, metricsCertName, metricsCertKey string
 	var webhookCertPath, webhookCertName, webhookCertKey string
+	var webhookCertSelfManaged bool
+	var webhookCertSecret, webhookService string
 	var enableLeaderElection bool
+	var leaseDuration, renewDeadline, retryPeriod time.Duration
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var healthCheckInterval time.Duration
+	var spendSyncInterval time.Duration
+	var imageResolveInterval time.Duration
+	var registryMirror string
+	var httpProxy, httpsProxy, noProxy, clusterCIDRs string
+	var capabilityDetectionInterval time.Duration
+	var safeToEvict, gatewayPriorityClass string
+	var overprovisioningReplicas int
+	var overprovisioningPriorityClass string
+	var maxConcurrentReconciles int
+	var watchNamespace string
+	var gatewaySelector string
+	var kubeAPIQPS float64
+	var kubeAPIBurst int
+	var rateLimiterBaseDelay, rateLimiterMaxDelay time.Duration
+	var rateLimiterQPS float64
+	var rateLimiterBurst int
+	var dryRun bool
+	var enableModelDiscovery bool
+	var enableAgentIntegration bool
+	var tenantGateway string
+	var replicationNamespace string
+	var alertReceiverAddr, alertReceiverURL string
+	var dnsDomain, otlpEndpoint string
+	var syncPeriod, resyncInterval time.Duration
+	var policyMaxModels int
+	var policyAllowedProviders string
+	var policyRequireBudget bool
+	var configFile, featureGates string
+	var litellmImage, apiKeySecret string
+	var requestTimeout time.Duration
+	var preflightInterval time.Duration
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -70,17 +135,145 @@ func main() {
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.DurationVar(&leaseDuration, "leader-elect-lease-duration", 15*time.Second,
+		"How long standby replicas wait before taking over a lease the leader stopped renewing.")
+	flag.DurationVar(&renewDeadline, "leader-elect-renew-deadline", 10*time.Second,
+		"How long the leader keeps retrying to renew its lease before giving up leadership.")
+	flag.DurationVar(&retryPeriod, "leader-elect-retry-period", 2*time.Second,
+		"How often replicas try to acquire or renew the lease.")
 	flag.BoolVar(&secureMetrics, "metrics-secure", true,
 		"If set, the metrics endpoint is served securely via HTTPS. Use --metrics-secure=false to use HTTP instead.")
 	flag.StringVar(&webhookCertPath, "webhook-cert-path", "", "The directory that contains the webhook certificate.")
 	flag.StringVar(&webhookCertName, "webhook-cert-name", "tls.crt", "The name of the webhook certificate file.")
 	flag.StringVar(&webhookCertKey, "webhook-cert-key", "tls.key", "The name of the webhook key file.")
+	flag.BoolVar(&webhookCertSelfManaged, "webhook-cert-self-managed", false,
+		"Issue and rotate the webhook certificate with a self-signed CA, and inject the CA into the webhook "+
+			"configurations, instead of reading it from --webhook-cert-path. For clusters without cert-manager.")
+	flag.StringVar(&webhookCertSecret, "webhook-cert-secret", "ai-gateway-litellm-webhook-server-cert",
+		"The Secret, in the operator's namespace, holding the self-managed webhook certificate.")
+	flag.StringVar(&webhookService, "webhook-service", "ai-gateway-litellm-webhook-service",
+		"The Service, in the operator's namespace, the API server calls the webhooks through. The "+
+			"self-managed certificate is issued for it.")
 	flag.StringVar(&metricsCertPath, "metrics-cert-path", "",
 		"The directory that contains the metrics server certificate.")
 	flag.StringVar(&metricsCertName, "metrics-cert-name", "tls.crt", "The name of the metrics server certificate file.")
 	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.DurationVar(&healthCheckInterval, "health-check-interval", 5*time.Minute,
+		"How often the LiteLLM /health endpoint of each ready AiGateway is polled and mirrored into "+
+			"the AiGatewayHealthy condition. Each poll sends one request per configured model. Set to 0 to disable.")
+	flag.DurationVar(&spendSyncInterval, "spend-sync-interval", 10*time.Minute,
+		"How often LiteLLM spend of each ready, database-backed AiGateway is read and published as the "+
+			"AiGatewaySpend condition and as controller metrics, and how often LiteLLMBudget spend is refreshed. "+
+			"Set to 0 to disable.")
+	flag.DurationVar(&imageResolveInterval, "image-resolve-interval", time.Hour,
+		"How often the LiteLLM image is resolved again, and newer releases looked for, for AiGatewayClasses with the "+
+			litellm.ImagePolicyAnnotation+" or "+litellm.UpgradePolicyAnnotation+" annotation. "+
+			"Set to 0 to run images by tag whatever the policy.")
+	flag.StringVar(&registryMirror, "registry-mirror", "",
+		"Registry, optionally followed by a path, that every image of the gateways is pulled from instead of its "+
+			"own registry, e.g. registry.example.com/mirror. For air-gapped clusters.")
+	flag.StringVar(&httpProxy, "http-proxy", "",
+		"Proxy URL gateways send plain HTTP requests to providers through, as HTTP_PROXY. Overridable per gateway "+
+			"with the "+litellm.HTTPProxyAnnotation+" annotation.")
+	flag.StringVar(&httpsProxy, "https-proxy", "",
+		"Proxy URL gateways send HTTPS requests to providers through, as HTTPS_PROXY. Overridable per gateway "+
+			"with the "+litellm.HTTPSProxyAnnotation+" annotation.")
+	flag.StringVar(&noProxy, "no-proxy", "",
+		"Comma-separated hosts, domains and CIDRs gateways reach without the proxy, added to NO_PROXY.")
+	flag.StringVar(&clusterCIDRs, "cluster-cidrs", "",
+		"Comma-separated pod and Service CIDRs of the cluster, added to NO_PROXY so in-cluster traffic bypasses "+
+			"the proxy.")
+	flag.DurationVar(&capabilityDetectionInterval, "capability-detection-interval", 5*time.Minute,
+		"How often the operator checks which optional integrations (Argo Rollouts, Flagger, CloudNativePG, "+
+			"the Gateway API Inference Extension, ...) the cluster serves. Set to 0 to check only at startup.")
+	flag.IntVar(&maxConcurrentReconciles, "max-concurrent-reconciles", 1,
+		"Number of gateways each controller (AiGateway, ToolGateway) reconciles in parallel.")
+	flag.StringVar(&watchNamespace, "watch-namespace", os.Getenv("WATCH_NAMESPACE"),
+		"Comma-separated list of namespaces the operator watches and reconciles. "+
+			"Defaults to the WATCH_NAMESPACE env var; empty watches all namespaces.")
+	flag.StringVar(&gatewaySelector, "gateway-label-selector", "",
+		"Label selector restricting which AiGateways and ToolGateways this instance reconciles, "+
+			"e.g. 'shard=a'. Lets several instances split the gateways between them; each selector "+
+			"gets its own leader election lease.")
+	flag.Float64Var(&kubeAPIQPS, "kube-api-qps", 0,
+		"Client-side QPS limit for requests to the Kubernetes API server. 0 keeps the client-go default.")
+	flag.IntVar(&kubeAPIBurst, "kube-api-burst", 0,
+		"Client-side burst limit for requests to the Kubernetes API server. 0 keeps the client-go default.")
+	flag.DurationVar(&rateLimiterBaseDelay, "rate-limiter-base-delay", 5*time.Millisecond,
+		"Initial per-gateway requeue delay after a failed reconcile; doubles on every further failure.")
+	flag.DurationVar(&rateLimiterMaxDelay, "rate-limiter-max-delay", 1000*time.Second,
+		"Upper bound of the per-gateway requeue delay after repeated failures.")
+	flag.Float64Var(&rateLimiterQPS, "rate-limiter-qps", 10,
+		"Overall rate at which each controller's workqueue hands out requeued gateways.")
+	flag.IntVar(&rateLimiterBurst, "rate-limiter-burst", 100,
+		"Burst size of each controller's overall workqueue rate limit.")
+	flag.DurationVar(&syncPeriod, "sync-period", 10*time.Hour,
+		"How often the informer caches are resynced, re-reconciling every watched gateway.")
+	flag.DurationVar(&resyncInterval, "resync-interval", 0,
+		"How often each successfully reconciled gateway is reconciled again without a triggering event, "+
+			"correcting drift of its workload. Overridable per gateway with the "+
+			litellm.ResyncIntervalAnnotation+" annotation. Set to 0 to rely on --sync-period alone.")
+	flag.BoolVar(&enableModelDiscovery, "enable-model-discovery", false,
+		"Add the models of in-cluster model server Services labelled "+litellm.ModelServerLabel+"=true "+
+			"to AiGateways with the "+litellm.ModelDiscoveryAnnotation+" annotation.")
+	flag.BoolVar(&enableAgentIntegration, "enable-agent-integration", false,
+		"Annotate Agents with the URL of the AiGateway they use and the gateway model their spec.model maps to. "+
+			"Requires the Agent CRD of the agent runtime operator.")
+	flag.StringVar(&tenantGateway, "tenant-gateway", "",
+		"<namespace>/<name> of the AiGateway tenants are onboarded onto. Every namespace labelled "+
+			controller.TenantLabel+"=<tenant> gets a key of that gateway in the "+controller.TenantKeySecretName+
+			" Secret. Empty disables tenant onboarding.")
+	flag.StringVar(&replicationNamespace, "replication-namespace", "",
+		"The namespace holding the kubeconfig Secrets AiGateways name in the "+litellm.ReplicateToAnnotation+
+			" annotation. Empty disables multi-cluster replication.")
+	flag.StringVar(&alertReceiverAddr, "alert-receiver-bind-address", "0",
+		"The address the receiver of LiteLLM alerts binds to. Set to 0 to disable it.")
+	flag.StringVar(&alertReceiverURL, "alert-receiver-url", "",
+		"The URL gateways reach the alert receiver at, e.g. the URL of its Service. AiGateways with the "+
+			litellm.AlertingAnnotation+" annotation send their alerts there. Empty disables alerting.")
+	flag.StringVar(&dnsDomain, "dns-domain", "",
+		"Domain single-label values of the "+litellm.HostnameAnnotation+" annotation are published under, "+
+			"e.g. gateways.example.com. Empty requires fully qualified hostnames.")
+	flag.StringVar(&otlpEndpoint, "otlp-endpoint", "",
+		"OTLP/HTTP endpoint the collector sidecars of AiGateways with the "+litellm.OTelCollectorAnnotation+
+			" annotation export to, e.g. http://otel-collector.observability:4318. Gateways can override it.")
+	flag.StringVar(&safeToEvict, "safe-to-evict", "",
+		"Value of the "+litellm.SafeToEvictAnnotation+" annotation on AiGateway pods. Set to false so the cluster "+
+			"autoscaler does not remove nodes running gateway pods, which may be streaming long completions. "+
+			"Empty leaves the annotation unset.")
+	flag.StringVar(&gatewayPriorityClass, "gateway-priority-class", "",
+		"PriorityClass of AiGateway pods, unless their gateway template sets one. Empty uses the cluster default.")
+	flag.IntVar(&overprovisioningReplicas, "overprovisioning-replicas", 0,
+		"Number of placeholder pods per AiGateway, each requesting what one gateway pod requests, keeping room "+
+			"for new gateway pods so they start without waiting for a node. Set to 0 to disable overprovisioning.")
+	flag.StringVar(&overprovisioningPriorityClass, "overprovisioning-priority-class", "",
+		"PriorityClass of the placeholder pods, with a lower (usually negative) priority than the gateway pods. "+
+			"Required with --overprovisioning-replicas.")
+	flag.BoolVar(&dryRun, "dry-run", false,
+		"Reconcile without writing to the cluster: every create, update and status change is sent as a "+
+			"server-side dry run and the resulting diff is logged. Use to preview the effect of an operator upgrade.")
+	flag.IntVar(&policyMaxModels, "policy-max-models", 0,
+		"Maximum number of aiModels per AiGateway, enforced by the admission webhook. Set to 0 for no limit.")
+	flag.StringVar(&policyAllowedProviders, "policy-allowed-providers", "",
+		"Comma-separated providers AiGateways may use, enforced by the admission webhook. Namespaces can narrow it "+
+			"further with the "+webhookv1alpha1.AllowedProvidersAnnotation+" annotation. Empty allows every provider.")
+	flag.BoolVar(&policyRequireBudget, "policy-require-budget", false,
+		"Reject AiGateways whose config patch does not set litellm_settings.max_budget.")
+	flag.StringVar(&configFile, "config", "",
+		"Path of an OperatorConfiguration file setting these flags; flags on the command line win. "+
+			"Its feature gates are reloaded when the file changes.")
+	flag.StringVar(&featureGates, "feature-gates", "",
+		"Comma-separated Feature=true|false pairs turning optional features on or off, over those of --config.")
+	flag.StringVar(&litellmImage, "litellm-image", litellm.Image, "The LiteLLM image of the gateways.")
+	flag.StringVar(&apiKeySecret, "api-key-secret", litellm.ApiKeySecretName,
+		"The Secret in a gateway's namespace its provider API keys are read from.")
+	flag.DurationVar(&requestTimeout, "request-timeout", litellm.DefaultRequestTimeout*time.Second,
+		"The request_timeout of the generated LiteLLM configs.")
+	flag.DurationVar(&preflightInterval, "preflight-interval", time.Hour,
+		"How long the provider checks of AiGateways with the "+litellm.PreflightAnnotation+
+			" annotation are reused while their API keys stay the same.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -91,6 +284,33 @@ func main() {
 	ctrl.SetLogger(logger)
 	klog.SetLogger(logger)
 
+	var operatorConfig *operatorconfig.Configuration
+	if configFile != "" {
+		var err error
+		if operatorConfig, err = operatorconfig.Load(configFile); err == nil {
+			err = operatorconfig.ApplyFlags(flag.CommandLine, operatorConfig)
+		}
+		if err != nil {
+			setupLog.Error(err, "invalid --config", "path", configFile)
+			os.Exit(1)
+		}
+	}
+	featureGateOverrides, err := operatorconfig.ParseFeatureGates(featureGates)
+	if err != nil {
+		setupLog.Error(err, "invalid --feature-gates")
+		os.Exit(1)
+	}
+	gates := &operatorconfig.FeatureGates{}
+	var fileGates map[string]bool
+	if operatorConfig != nil {
+		fileGates = operatorConfig.FeatureGates
+	}
+	if err := gates.Set(fileGates, featureGateOverrides); err != nil {
+		setupLog.Error(err, "invalid feature gates")
+		os.Exit(1)
+	}
+	setupLog.Info("Feature gates", "featureGates", gates.String())
+
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
 	// prevent from being vulnerable to the HTTP/2 Stream Cancellation and
@@ -112,7 +332,24 @@ func main() {
 	// Initial webhook TLS options
 	webhookTLSOpts := tlsOpts
 
-	if len(webhookCertPath) > 0 {
+	var certRotator *certs.Rotator
+	if webhookCertSelfManaged {
+		namespace, err := certs.Namespace()
+		if err != nil {
+			setupLog.Error(err, "unable to determine the operator namespace for the webhook certificate")
+			os.Exit(1)
+		}
+		// The client is set once the manager's config is known.
+		certRotator = &certs.Rotator{
+			Secret:  types.NamespacedName{Namespace: namespace, Name: webhookCertSecret},
+			Service: types.NamespacedName{Namespace: namespace, Name: webhookService},
+		}
+		setupLog.Info("Using a self-managed webhook certificate",
+			"secret", certRotator.Secret, "service", certRotator.Service)
+		webhookTLSOpts = append(webhookTLSOpts, func(config *tls.Config) {
+			config.GetCertificate = certRotator.GetCertificate
+		})
+	} else if len(webhookCertPath) > 0 {
 		setupLog.Info("Initializing webhook certificate watcher using provided certificates",
 			"webhook-cert-path", webhookCertPath, "webhook-cert-name", webhookCertName, "webhook-cert-key", webhookCertKey)
 
@@ -179,44 +416,322 @@ func main() {
 		})
 	}
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+	// Only Deployments and Services the operator applied are cached. ConfigMaps
+	// cannot be filtered: user-owned config-patch ConfigMaps are watched too.
+	cacheOptions := cache.Options{
+		DefaultNamespaces: watchNamespaces(watchNamespace),
+		DefaultTransform:  litellm.TrimForCache,
+		SyncPeriod:        &syncPeriod,
+		ByObject: map[client.Object]cache.ByObject{
+			&appsv1.Deployment{}:     {Label: litellm.ManagedSelector()},
+			&corev1.Service{}:        {Label: litellm.ManagedSelector()},
+			&batchv1.CronJob{}:       {Label: litellm.ManagedSelector()},
+			&batchv1.Job{}:           {Label: litellm.ManagedSelector()},
+			&corev1.ServiceAccount{}: {Label: litellm.ManagedSelector()},
+			&rbacv1.Role{}:           {Label: litellm.ManagedSelector()},
+			&rbacv1.RoleBinding{}:    {Label: litellm.ManagedSelector()},
+			&networkingv1.Ingress{}:  {Label: litellm.ManagedSelector()},
+		},
+	}
+	leaderElectionID := "4b1f9b08.agentic-layer.ai"
+	if gatewaySelector != "" {
+		selector, err := labels.Parse(gatewaySelector)
+		if err != nil {
+			setupLog.Error(err, "invalid --gateway-label-selector")
+			os.Exit(1)
+		}
+		cacheOptions.ByObject[&gatewayv1alpha1.AiGateway{}] = cache.ByObject{Label: selector}
+		cacheOptions.ByObject[&gatewayv1alpha1.ToolGateway{}] = cache.ByObject{Label: selector}
+		// Shards must not compete for one lease, or only one of them would run.
+		h := fnv.New32a()
+		_, _ = h.Write([]byte(selector.String()))
+		leaderElectionID = fmt.Sprintf("%08x.%s", h.Sum32(), leaderElectionID)
+		setupLog.Info("Restricting operator to gateways matching selector", "selector", selector.String())
+	}
+
+	restConfig := ctrl.GetConfigOrDie()
+	if kubeAPIQPS > 0 {
+		restConfig.QPS = float32(kubeAPIQPS)
+	}
+	if kubeAPIBurst > 0 {
+		restConfig.Burst = kubeAPIBurst
+	}
+	// Each controller gets its own limiter: AiGateway and ToolGateway requests
+	// can share a namespace/name and must not share failure counts.
+	newRateLimiter := func() workqueue.TypedRateLimiter[reconcile.Request] {
+		return workqueue.NewTypedMaxOfRateLimiter(
+			workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](rateLimiterBaseDelay, rateLimiterMaxDelay),
+			&workqueue.TypedBucketRateLimiter[reconcile.Request]{
+				Limiter: rate.NewLimiter(rate.Limit(rateLimiterQPS), rateLimiterBurst),
+			},
+		)
+	}
+
+	mgr, err := ctrl.NewManager(restConfig, ctrl.Options{
 		Scheme:                 scheme,
 		Metrics:                metricsServerOptions,
 		WebhookServer:          webhookServer,
 		HealthProbeBindAddress: probeAddr,
+		Cache:                  cacheOptions,
 		LeaderElection:         enableLeaderElection,
-		LeaderElectionID:       "4b1f9b08.agentic-layer.ai",
-		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
-		// when the Manager ends. This requires the binary to immediately end when the
-		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
-		// speeds up voluntary leader transitions as the new leader don't have to wait
-		// LeaseDuration time first.
-		//
-		// In the default scaffold provided, the program ends immediately after
-		// the manager stops, so would be fine to enable this option. However,
-		// if you are doing or is intended to do any operation such as perform cleanups
-		// after the manager stops then its usage might be unsafe.
-		// LeaderElectionReleaseOnCancel: true,
+		LeaderElectionID:       leaderElectionID,
+		LeaseDuration:          &leaseDuration,
+		RenewDeadline:          &renewDeadline,
+		RetryPeriod:            &retryPeriod,
+		// The process exits right after the manager stops, so releasing the
+		// lease on shutdown is safe and lets a standby replica take over
+		// immediately during rolling upgrades instead of waiting LeaseDuration.
+		LeaderElectionReleaseOnCancel: true,
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
+	if watchNamespace != "" {
+		setupLog.Info("Restricting operator to namespaces", "namespaces", watchNamespace)
+	}
+	reconcileClient := mgr.GetClient()
+	if dryRun {
+		setupLog.Info("Dry-run mode: changes are logged, nothing is written to the cluster")
+		reconcileClient = client.NewDryRunClient(reconcileClient)
+	}
+
+	// Model server Services carry no managed-by label, so the manager's
+	// Service cache never sees them. They get a cache of their own.
+	var modelServerCache cache.Cache
+	if enableModelDiscovery {
+		modelServerCache, err = cache.New(mgr.GetConfig(), cache.Options{
+			Scheme:            mgr.GetScheme(),
+			Mapper:            mgr.GetRESTMapper(),
+			DefaultNamespaces: watchNamespaces(watchNamespace),
+			DefaultTransform:  litellm.TrimForCache,
+			SyncPeriod:        &syncPeriod,
+			ByObject: map[client.Object]cache.ByObject{
+				&corev1.Service{}: {Label: litellm.ModelServerSelector()},
+			},
+		})
+		if err == nil {
+			err = mgr.Add(modelServerCache)
+		}
+		if err != nil {
+			setupLog.Error(err, "unable to set up model server cache")
+			os.Exit(1)
+		}
+		setupLog.Info("Model discovery enabled", "label", litellm.ModelServerLabel)
+	}
+
+	var clusterAutoscaler *litellm.ClusterAutoscaler
+	if safeToEvict != "" || gatewayPriorityClass != "" || overprovisioningReplicas > 0 {
+		clusterAutoscaler = &litellm.ClusterAutoscaler{
+			PriorityClassName:                 gatewayPriorityClass,
+			OverprovisioningReplicas:          int32(overprovisioningReplicas),
+			OverprovisioningPriorityClassName: overprovisioningPriorityClass,
+		}
+		if safeToEvict != "" {
+			evict, err := strconv.ParseBool(safeToEvict)
+			if err != nil {
+				setupLog.Error(err, "invalid --safe-to-evict")
+				os.Exit(1)
+			}
+			clusterAutoscaler.SafeToEvict = &evict
+		}
+		if overprovisioningReplicas > 0 && overprovisioningPriorityClass == "" {
+			setupLog.Error(errors.New("--overprovisioning-priority-class is required"), "invalid --overprovisioning-replicas")
+			os.Exit(1)
+		}
+	}
+
+	egressProxy := litellm.EgressProxy{
+		HTTPProxy:  httpProxy,
+		HTTPSProxy: httpsProxy,
+		NoProxy:    litellm.SplitNoProxy(noProxy),
+	}
+	for _, cidr := range litellm.SplitNoProxy(clusterCIDRs) {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			setupLog.Error(err, "invalid --cluster-cidrs")
+			os.Exit(1)
+		}
+		egressProxy.NoProxy = append(egressProxy.NoProxy, cidr)
+	}
+	if _, err := litellm.ParseEgressProxy(nil, egressProxy); err != nil {
+		setupLog.Error(err, "invalid --http-proxy or --https-proxy")
+		os.Exit(1)
+	}
+
+	var imageResolver *litellm.ImageResolver
+	if imageResolveInterval > 0 {
+		imageResolver = &litellm.ImageResolver{Interval: imageResolveInterval}
+	}
+
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create discovery client")
+		os.Exit(1)
+	}
+	capabilities := &litellm.CapabilityDetector{Discovery: discoveryClient, Interval: capabilityDetectionInterval}
+	if err := mgr.Add(capabilities); err != nil {
+		setupLog.Error(err, "unable to set up capability detection")
+		os.Exit(1)
+	}
+	if configFile != "" {
+		if err := mgr.Add(&operatorconfig.Watcher{
+			Path:      configFile,
+			Gates:     gates,
+			Overrides: featureGateOverrides,
+			Loaded:    operatorConfig,
+		}); err != nil {
+			setupLog.Error(err, "unable to set up the configuration file watcher")
+			os.Exit(1)
+		}
+	}
 
 	if err := (&controller.AiGatewayReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:                  reconcileClient,
+		Scheme:                  mgr.GetScheme(),
+		HealthCheckInterval:     healthCheckInterval,
+		SpendSyncInterval:       spendSyncInterval,
+		MaxConcurrentReconciles: maxConcurrentReconciles,
+		RateLimiter:             newRateLimiter(),
+		ResyncInterval:          resyncInterval,
+		DryRun:                  dryRun,
+		APIReader:               mgr.GetAPIReader(),
+		ModelServerCache:        modelServerCache,
+		AlertReceiverURL:        alertReceiverURL,
+		DNSDomain:               dnsDomain,
+		OTLPEndpoint:            otlpEndpoint,
+		ImageResolver:           imageResolver,
+		RegistryMirror:          registryMirror,
+		EgressProxy:             egressProxy,
+		Capabilities:            capabilities,
+		ClusterAutoscaler:       clusterAutoscaler,
+		Image:                   litellmImage,
+		APIKeySecretName:        apiKeySecret,
+		RequestTimeout:          requestTimeout,
+		FeatureGates:            gates,
+		PreflightInterval:       preflightInterval,
+		ReplicationNamespace:    replicationNamespace,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AiGateway")
 		os.Exit(1)
 	}
 	if err := (&controller.ToolGatewayReconciler{
+		Client:                  reconcileClient,
+		Scheme:                  mgr.GetScheme(),
+		MaxConcurrentReconciles: maxConcurrentReconciles,
+		RateLimiter:             newRateLimiter(),
+		ResyncInterval:          resyncInterval,
+		DryRun:                  dryRun,
+		APIReader:               mgr.GetAPIReader(),
+		RegistryMirror:          registryMirror,
+		EgressProxy:             egressProxy,
+		Image:                   litellmImage,
+		RequestTimeout:          requestTimeout,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ToolGateway")
+		os.Exit(1)
+	}
+	// Virtual keys, teams and budget spend go through the proxy's API, which
+	// a dry-run client cannot intercept; key rotations and the alert receiver
+	// write Secrets, ConfigMaps and Events directly.
+	if dryRun {
+		setupLog.Info("Dry-run mode: LiteLLMVirtualKey, LiteLLMTeam, LiteLLMBudget, LiteLLMKeyRotation, " +
+			"tenant controllers and alert receiver disabled")
+	} else {
+		if err := (&controller.LiteLLMVirtualKeyReconciler{
+			Client: mgr.GetClient(),
+			Scheme: mgr.GetScheme(),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "LiteLLMVirtualKey")
+			os.Exit(1)
+		}
+		if err := (&controller.LiteLLMTeamReconciler{
+			Client: mgr.GetClient(),
+			Scheme: mgr.GetScheme(),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "LiteLLMTeam")
+			os.Exit(1)
+		}
+		if err := (&controller.LiteLLMBudgetReconciler{
+			Client:            mgr.GetClient(),
+			Scheme:            mgr.GetScheme(),
+			SpendSyncInterval: spendSyncInterval,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "LiteLLMBudget")
+			os.Exit(1)
+		}
+		if err := (&controller.LiteLLMKeyRotationReconciler{
+			Client: mgr.GetClient(),
+			Scheme: mgr.GetScheme(),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "LiteLLMKeyRotation")
+			os.Exit(1)
+		}
+		if alertReceiverAddr != "0" {
+			if err := mgr.Add(&controller.AlertReceiver{
+				Client:      mgr.GetClient(),
+				Scheme:      mgr.GetScheme(),
+				Recorder:    mgr.GetEventRecorder("ai-gateway-litellm-alerts"),
+				BindAddress: alertReceiverAddr,
+			}); err != nil {
+				setupLog.Error(err, "unable to add alert receiver")
+				os.Exit(1)
+			}
+		}
+		if tenantGateway != "" {
+			namespace, name, ok := strings.Cut(tenantGateway, "/")
+			if !ok || namespace == "" || name == "" {
+				setupLog.Error(fmt.Errorf("want <namespace>/<name>, got %q", tenantGateway), "invalid --tenant-gateway")
+				os.Exit(1)
+			}
+			if err := (&controller.TenantReconciler{
+				Client:  mgr.GetClient(),
+				Gateway: types.NamespacedName{Namespace: namespace, Name: name},
+			}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "Tenant")
+				os.Exit(1)
+			}
+			setupLog.Info("Tenant onboarding enabled", "aigateway", tenantGateway)
+		}
+	}
+	if err := (&controller.LiteLLMRateLimitPolicyReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
 	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "ToolGateway")
+		setupLog.Error(err, "unable to create controller", "controller", "LiteLLMRateLimitPolicy")
 		os.Exit(1)
 	}
+	if err := (&controller.LiteLLMMaintenanceWindowReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "LiteLLMMaintenanceWindow")
+		os.Exit(1)
+	}
+	if enableAgentIntegration {
+		if err := (&controller.AgentReconciler{
+			Client: reconcileClient,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "Agent")
+			os.Exit(1)
+		}
+	}
+	// nolint:goconst
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		policy := webhookv1alpha1.Policy{
+			MaxModels:        policyMaxModels,
+			AllowedProviders: webhookv1alpha1.ParseProviderList(policyAllowedProviders),
+			RequireBudget:    policyRequireBudget,
+		}
+		namespaces := slices.Sorted(maps.Keys(watchNamespaces(watchNamespace)))
+		if err := webhookv1alpha1.SetupAiGatewayWebhookWithManager(mgr, policy, apiKeySecret, namespaces); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "AiGateway")
+			os.Exit(1)
+		}
+		if err := webhookv1alpha1.SetupAiGatewayClassWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "AiGatewayClass")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
 	if metricsCertWatcher != nil {
@@ -227,6 +742,21 @@ func main() {
 		}
 	}
 
+	if certRotator != nil {
+		// Secrets in the operator's namespace may be outside the cache.
+		certRotator.Client, err = client.New(restConfig, client.Options{Scheme: mgr.GetScheme()})
+		if err == nil {
+			err = mgr.Add(certRotator)
+		}
+		if err == nil {
+			err = mgr.AddReadyzCheck("webhook-cert", certRotator.ReadyCheck)
+		}
+		if err != nil {
+			setupLog.Error(err, "unable to set up the self-managed webhook certificate")
+			os.Exit(1)
+		}
+	}
+
 	if webhookCertWatcher != nil {
 		setupLog.Info("Adding webhook certificate watcher to manager")
 		if err := mgr.Add(webhookCertWatcher); err != nil {
@@ -243,6 +773,21 @@ func main() {
 		setupLog.Error(err, "unable to set up ready check")
 		os.Exit(1)
 	}
+	// Informers run on every replica, leader or not, so a standby that reports
+	// ready can take over with a warm cache. Gating readiness on leadership
+	// instead would stall rolling upgrades: the new pod could never become
+	// ready while the old one still holds the lease.
+	if err := mgr.AddReadyzCheck("informer-sync", func(req *http.Request) error {
+		ctx, cancel := context.WithTimeout(req.Context(), time.Second)
+		defer cancel()
+		if !mgr.GetCache().WaitForCacheSync(ctx) {
+			return errors.New("informer caches not synced")
+		}
+		return nil
+	}); err != nil {
+		setupLog.Error(err, "unable to set up informer sync check")
+		os.Exit(1)
+	}
 
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
@@ -250,3 +795,20 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// watchNamespaces turns the comma-separated --watch-namespace value into the
+// cache's per-namespace configuration. nil means all namespaces.
+// Cluster-scoped objects (the gateway classes) are cached regardless.
+func watchNamespaces(value string) map[string]cache.Config {
+	var namespaces map[string]cache.Config
+	for ns := range strings.SplitSeq(value, ",") {
+		if ns = strings.TrimSpace(ns); ns == "" {
+			continue
+		}
+		if namespaces == nil {
+			namespaces = make(map[string]cache.Config)
+		}
+		namespaces[ns] = cache.Config{}
+	}
+	return namespaces
+}
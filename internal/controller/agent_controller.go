@@ -0,0 +1,191 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/litellm"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Annotations the operator maintains on Agents that use one of its
+// gateways, so the endpoint and model an agent ends up with are visible on
+// the Agent itself.
+const (
+	// AgentGatewayURLAnnotation is the base URL of the agent's gateway.
+	AgentGatewayURLAnnotation = "ai-gateway-litellm.agentic-layer.ai/gateway-url"
+	// AgentModelAnnotation is the gateway model_name the agent's spec.model
+	// resolves to; empty when the gateway does not serve it.
+	AgentModelAnnotation = "ai-gateway-litellm.agentic-layer.ai/model"
+	// AgentModelStatusAnnotation is "Served", or a message saying why the
+	// agent's model cannot be served.
+	AgentModelStatusAnnotation = "ai-gateway-litellm.agentic-layer.ai/model-status"
+)
+
+// AgentModelServed is the value of AgentModelStatusAnnotation for an agent
+// whose model its gateway serves.
+const AgentModelServed = "Served"
+
+// AgentReconciler annotates Agents with the endpoint of the AiGateway they
+// use and the gateway model their spec.model maps to. The agent runtime
+// operator resolves the gateway and wires its URL into the agent; this
+// closes the loop by checking that the gateway actually serves the model.
+type AgentReconciler struct {
+	client.Client
+}
+
+// +kubebuilder:rbac:groups=runtime.agentic-layer.ai,resources=agents,verbs=get;list;watch;patch
+
+// Reconcile resolves the AiGateway of an Agent, from status.aiGatewayRef as
+// set by the agent runtime operator or else from spec.aiGatewayRef, and
+// brings the agent annotations in line with it. Agents using no gateway lose
+// the annotations; those using a gateway of another operator are left alone.
+func (r *AgentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var agent gatewayv1alpha1.Agent
+	if err := r.Get(ctx, req.NamespacedName, &agent); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	want := map[string]string{}
+	if key, ok := agentGatewayKey(&agent); ok {
+		// A gateway this operator does not see may be served by another
+		// operator or shard, which then owns the annotations.
+		var gw gatewayv1alpha1.AiGateway
+		if err := r.Get(ctx, key, &gw); err != nil {
+			return ctrl.Result{}, client.IgnoreNotFound(err)
+		}
+		owned, err := litellm.IsAiGatewayOwnedByController(ctx, r, &gw, ControllerName)
+		if err != nil || !owned {
+			return ctrl.Result{}, err
+		}
+		want[AgentGatewayURLAnnotation] = litellm.ServiceURL(gw.Name, gw.Namespace, gw.Spec.Port)
+		model, status := resolveAgentModel(&gw, agent.Spec.Model)
+		want[AgentModelAnnotation] = model
+		want[AgentModelStatusAnnotation] = status
+	}
+
+	current := map[string]string{}
+	for _, k := range []string{AgentGatewayURLAnnotation, AgentModelAnnotation, AgentModelStatusAnnotation} {
+		if v, ok := agent.Annotations[k]; ok {
+			current[k] = v
+		}
+	}
+	if maps.Equal(current, want) {
+		return ctrl.Result{}, nil
+	}
+
+	original := agent.DeepCopy()
+	if agent.Annotations == nil {
+		agent.Annotations = map[string]string{}
+	}
+	for k := range current {
+		delete(agent.Annotations, k)
+	}
+	maps.Copy(agent.Annotations, want)
+	if err := r.Patch(ctx, &agent, client.MergeFrom(original)); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	logf.FromContext(ctx).Info("Updated Agent gateway annotations", "annotations", want)
+	return ctrl.Result{}, nil
+}
+
+// agentGatewayKey returns the AiGateway an Agent uses: the one the agent
+// runtime operator resolved, or else the one the spec names.
+func agentGatewayKey(agent *gatewayv1alpha1.Agent) (types.NamespacedName, bool) {
+	ref := agent.Status.AiGatewayRef
+	if ref == nil {
+		ref = agent.Spec.AiGatewayRef
+	}
+	if ref == nil || ref.Name == "" {
+		return types.NamespacedName{}, false
+	}
+	key := types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}
+	if key.Namespace == "" {
+		key.Namespace = agent.Namespace
+	}
+	return key, true
+}
+
+// resolveAgentModel maps an agent's spec.model onto a model of gw. The model
+// may be given as the gateway model_name ("gpt-4o") or with its provider
+// ("openai/gpt-4o"). It returns the model_name and the model status.
+func resolveAgentModel(gw *gatewayv1alpha1.AiGateway, model string) (string, string) {
+	if model == "" {
+		return "", "Agent sets no spec.model; the agent's default model is used"
+	}
+	for _, m := range gw.Spec.AiModels {
+		if model == m.Name || model == m.Provider+"/"+m.Name {
+			return m.Name, AgentModelServed
+		}
+	}
+	return "", fmt.Sprintf("AiGateway %s does not serve model %s", gw.Name, model)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *AgentReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Indexer key used to locate Agents by the "<namespace>/<name>" of the
+	// AiGateway they use.
+	const agentGatewayIndex = "status.aiGatewayRef"
+
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &gatewayv1alpha1.Agent{}, agentGatewayIndex,
+		func(obj client.Object) []string {
+			agent, ok := obj.(*gatewayv1alpha1.Agent)
+			if !ok {
+				return nil
+			}
+			key, ok := agentGatewayKey(agent)
+			if !ok {
+				return nil
+			}
+			return []string{key.String()}
+		},
+	); err != nil {
+		return fmt.Errorf("failed to register Agent gateway indexer: %w", err)
+	}
+
+	// enqueueAgentsForGateway re-checks the agents of a gateway when its
+	// models or port change.
+	enqueueAgentsForGateway := handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		log := logf.FromContext(ctx)
+		var agentList gatewayv1alpha1.AgentList
+		key := types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}.String()
+		if err := r.List(ctx, &agentList, client.MatchingFields{agentGatewayIndex: key}); err != nil {
+			log.Error(err, "Failed to list Agents for AiGateway watch", "aigateway", key)
+			return nil
+		}
+		requests := make([]reconcile.Request, len(agentList.Items))
+		for i, agent := range agentList.Items {
+			requests[i] = reconcile.Request{NamespacedName: types.NamespacedName{Name: agent.Name, Namespace: agent.Namespace}}
+		}
+		return requests
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&gatewayv1alpha1.Agent{}).
+		Watches(&gatewayv1alpha1.AiGateway{}, enqueueAgentsForGateway).
+		Named("agent").
+		Complete(r)
+}
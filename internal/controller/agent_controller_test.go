@@ -0,0 +1,100 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestAgentReconciler_AnnotatesGatewayAndModel(t *testing.T) {
+	s := runtime.NewScheme()
+	if err := gatewayv1alpha1.AddToScheme(s); err != nil {
+		t.Fatalf("AddToScheme: %v", err)
+	}
+	class := &gatewayv1alpha1.AiGatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "litellm"},
+		Spec:       gatewayv1alpha1.AiGatewayClassSpec{Controller: ControllerName},
+	}
+	gw := &gatewayv1alpha1.AiGateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "ai-gateway"},
+		Spec: gatewayv1alpha1.AiGatewaySpec{
+			AiGatewayClassName: "litellm",
+			Port:               4000,
+			AiModels:           []gatewayv1alpha1.AiModel{{Name: "gpt-4o", Provider: "openai"}},
+		},
+	}
+	agent := &gatewayv1alpha1.Agent{
+		ObjectMeta: metav1.ObjectMeta{Name: "helper", Namespace: "team-a"},
+		Spec:       gatewayv1alpha1.AgentSpec{Model: "openai/gpt-4o"},
+		Status: gatewayv1alpha1.AgentStatus{
+			AiGatewayRef: &corev1.ObjectReference{Name: "gw", Namespace: "ai-gateway"},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(class, gw, agent).WithStatusSubresource(agent).Build()
+	r := &AgentReconciler{Client: c}
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "helper", Namespace: "team-a"}
+
+	reconcileAgent := func() *gatewayv1alpha1.Agent {
+		t.Helper()
+		if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+			t.Fatalf("Reconcile: %v", err)
+		}
+		var got gatewayv1alpha1.Agent
+		if err := c.Get(ctx, key, &got); err != nil {
+			t.Fatalf("get Agent: %v", err)
+		}
+		return &got
+	}
+
+	got := reconcileAgent()
+	if got.Annotations[AgentGatewayURLAnnotation] != "http://gw.ai-gateway.svc.cluster.local:4000" ||
+		got.Annotations[AgentModelAnnotation] != "gpt-4o" ||
+		got.Annotations[AgentModelStatusAnnotation] != AgentModelServed {
+		t.Fatalf("unexpected annotations: %v", got.Annotations)
+	}
+
+	// A model the gateway does not serve is reported.
+	got.Spec.Model = "anthropic/claude"
+	if err := c.Update(ctx, got); err != nil {
+		t.Fatalf("update Agent: %v", err)
+	}
+	got = reconcileAgent()
+	if got.Annotations[AgentModelAnnotation] != "" ||
+		got.Annotations[AgentModelStatusAnnotation] != "AiGateway gw does not serve model anthropic/claude" {
+		t.Fatalf("unexpected annotations: %v", got.Annotations)
+	}
+
+	// Without a gateway the annotations are removed.
+	got.Status.AiGatewayRef = nil
+	if err := c.Status().Update(ctx, got); err != nil {
+		t.Fatalf("update Agent status: %v", err)
+	}
+	got = reconcileAgent()
+	if _, ok := got.Annotations[AgentGatewayURLAnnotation]; ok {
+		t.Fatalf("annotations not removed: %v", got.Annotations)
+	}
+}
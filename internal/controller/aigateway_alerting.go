@@ -0,0 +1,258 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/litellm"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// AiGatewayAlerting reports whether LiteLLM raised an alert for the gateway
+// recently. It is only set on gateways with the alerting annotation.
+const AiGatewayAlerting = "AiGatewayAlerting"
+
+// Alerting condition reasons. While an alert is recent, the reason is
+// derived from its LiteLLM event, e.g. BudgetCrossed.
+const (
+	ReasonNoRecentAlerts      = "NoRecentAlerts"
+	ReasonAlertingUnavailable = "AlertingUnavailable"
+	ReasonAlert               = "Alert"
+)
+
+// alertHold is how long an alert keeps AiGatewayAlerting True.
+const alertHold = 24 * time.Hour
+
+// maxAlertBody bounds the alert payloads the receiver reads.
+const maxAlertBody = 64 << 10
+
+// informationalAlertEvents are LiteLLM webhook events that are recorded as
+// Normal Events and do not raise the condition.
+var informationalAlertEvents = map[string]bool{
+	"spend_tracked":         true,
+	"key_created":           true,
+	"internal_user_created": true,
+}
+
+// alertReceiverURL returns the receiver URL the alerting Secret of gw points
+// at, or "" when gw does not ask for alerting or no receiver runs.
+func (r *AiGatewayReconciler) alertReceiverURL(gw *gatewayv1alpha1.AiGateway) string {
+	if enabled, _ := litellm.Alerting(gw.Annotations); !enabled {
+		return ""
+	}
+	return r.AlertReceiverURL
+}
+
+// syncAlerting mirrors the latest alert of gw into the alerting condition.
+// The alert receiver writes alerts to a ConfigMap controlled by gw, so they
+// reach the status through the owned-ConfigMap watch and this controller
+// stays the only writer of the status. Returns when the condition next
+// changes without an event.
+func (r *AiGatewayReconciler) syncAlerting(ctx context.Context, gw *gatewayv1alpha1.AiGateway, workload litellm.GatewayWorkload) (time.Duration, error) {
+	if enabled, _ := litellm.Alerting(gw.Annotations); !enabled {
+		if err := litellm.DeleteAlerts(ctx, r.Client, workload); err != nil {
+			logf.FromContext(ctx).Error(err, "Failed to delete alert ConfigMap")
+			return 0, err
+		}
+		apimeta.RemoveStatusCondition(&gw.Status.Conditions, AiGatewayAlerting)
+		return 0, nil
+	}
+	if r.AlertReceiverURL == "" {
+		r.updateCondition(gw, AiGatewayAlerting, metav1.ConditionFalse, ReasonAlertingUnavailable,
+			"The operator runs without --alert-receiver-url; alerts are not delivered")
+		return 0, nil
+	}
+
+	alert, err := litellm.LoadAlert(ctx, r.Client, gw.Namespace, gw.Name)
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "Ignoring alert ConfigMap")
+	}
+	if alert != nil {
+		if remaining := time.Until(alert.Time.Add(alertHold)); remaining > 0 {
+			r.updateCondition(gw, AiGatewayAlerting, metav1.ConditionTrue, alertReason(alert.Event),
+				fmt.Sprintf("%s at %s", alert.Message, alert.Time.Format(time.RFC3339)))
+			return remaining, nil
+		}
+	}
+	r.updateCondition(gw, AiGatewayAlerting, metav1.ConditionFalse, ReasonNoRecentAlerts,
+		fmt.Sprintf("No alerts in the last %s", alertHold))
+	return 0, nil
+}
+
+// alertReason turns a LiteLLM event such as budget_crossed into a condition
+// and Event reason such as BudgetCrossed.
+func alertReason(event string) string {
+	var b strings.Builder
+	for part := range strings.FieldsFuncSeq(event, func(r rune) bool { return r == '_' || r == '-' || r == ' ' }) {
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	if b.Len() == 0 {
+		return ReasonAlert
+	}
+	return b.String()
+}
+
+// liteLLMAlert is the payload of LiteLLM's webhook alerting. Budget alerts
+// carry the event fields; other alerts only a Slack-style text.
+type liteLLMAlert struct {
+	Event        string   `json:"event"`
+	EventGroup   string   `json:"event_group"`
+	EventMessage string   `json:"event_message"`
+	Text         string   `json:"text"`
+	Spend        *float64 `json:"spend"`
+	MaxBudget    *float64 `json:"max_budget"`
+	KeyAlias     string   `json:"key_alias"`
+	TeamAlias    string   `json:"team_alias"`
+	UserEmail    string   `json:"user_email"`
+}
+
+// message condenses the alert into an Event note.
+func (a liteLLMAlert) message() string {
+	message := a.EventMessage
+	if message == "" {
+		message = a.Text
+	}
+	if message == "" {
+		message = "LiteLLM raised an alert"
+	}
+	var subjects []string
+	for _, s := range []struct{ kind, name string }{{"key", a.KeyAlias}, {"team", a.TeamAlias}, {"user", a.UserEmail}} {
+		if s.name != "" {
+			subjects = append(subjects, s.kind+" "+s.name)
+		}
+	}
+	if len(subjects) > 0 {
+		message += " (" + strings.Join(subjects, ", ") + ")"
+	}
+	if a.Spend != nil && a.MaxBudget != nil {
+		message += fmt.Sprintf(": spend %.2f of %.2f", *a.Spend, *a.MaxBudget)
+	}
+	return message
+}
+
+// AlertReceiver serves the webhook LiteLLM's alerting posts to. Each alert
+// becomes an Event on the gateway; alerts that are not informational are
+// also kept as the gateway's latest alert for the AiGatewayAlerting
+// condition. It runs on every replica, so any of them can take alerts.
+type AlertReceiver struct {
+	Client   client.Client
+	Scheme   *runtime.Scheme
+	Recorder events.EventRecorder
+
+	// BindAddress is the address the receiver listens on, e.g. ":8082".
+	BindAddress string
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable.
+func (a *AlertReceiver) NeedLeaderElection() bool { return false }
+
+// Start serves alerts until ctx is done.
+func (a *AlertReceiver) Start(ctx context.Context) error {
+	mux := http.NewServeMux()
+	mux.Handle(litellm.AlertPathPrefix, a)
+	server := &http.Server{
+		Addr:              a.BindAddress,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+	go func() {
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdown)
+	}()
+	logf.FromContext(ctx).Info("Starting alert receiver", "address", a.BindAddress)
+	if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// ServeHTTP takes an alert posted to <prefix><namespace>/<name>/<token>.
+func (a *AlertReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	log := logf.FromContext(req.Context())
+	if req.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	parts := strings.Split(strings.TrimPrefix(req.URL.Path, litellm.AlertPathPrefix), "/")
+	if len(parts) != 3 {
+		http.NotFound(w, req)
+		return
+	}
+	namespace, name, token := parts[0], parts[1], parts[2]
+
+	ok, err := litellm.VerifyAlertToken(req.Context(), a.Client, namespace, name, token)
+	if err != nil {
+		log.Error(err, "Failed to verify alert token", "namespace", namespace, "aigateway", name)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	if !ok {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+	var gw gatewayv1alpha1.AiGateway
+	if err := a.Client.Get(req.Context(), client.ObjectKey{Namespace: namespace, Name: name}, &gw); err != nil {
+		if apierrors.IsNotFound(err) {
+			http.NotFound(w, req)
+			return
+		}
+		log.Error(err, "Failed to get AiGateway for alert", "namespace", namespace, "aigateway", name)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	var alert liteLLMAlert
+	if err := json.NewDecoder(io.LimitReader(req.Body, maxAlertBody)).Decode(&alert); err != nil {
+		http.Error(w, "invalid alert: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	reason, message := alertReason(alert.Event), alert.message()
+	if informationalAlertEvents[alert.Event] {
+		a.Recorder.Eventf(&gw, nil, corev1.EventTypeNormal, reason, "Alert", "%s", message)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	a.Recorder.Eventf(&gw, nil, corev1.EventTypeWarning, reason, "Alert", "%s", message)
+	if err := litellm.StoreAlert(req.Context(), a.Client, a.Scheme, &gw, litellm.Alert{
+		Event: alert.Event, Group: alert.EventGroup, Message: message, Time: time.Now(),
+	}); err != nil {
+		log.Error(err, "Failed to store alert", "namespace", namespace, "aigateway", name)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
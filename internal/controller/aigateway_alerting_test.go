@@ -0,0 +1,186 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	litellmv1alpha1 "github.com/agentic-layer/ai-gateway-litellm/api/v1alpha1"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/litellm"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func alertingFixtures(t *testing.T) (client.Client, *runtime.Scheme, *gatewayv1alpha1.AiGateway, litellm.GatewayWorkload) {
+	t.Helper()
+	s := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{gatewayv1alpha1.AddToScheme, litellmv1alpha1.AddToScheme, corev1.AddToScheme} {
+		if err := add(s); err != nil {
+			t.Fatalf("AddToScheme: %v", err)
+		}
+	}
+	gw := &gatewayv1alpha1.AiGateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "team-a", UID: "gw-uid",
+			Annotations: map[string]string{litellm.AlertingAnnotation: "true"}},
+		Spec: gatewayv1alpha1.AiGatewaySpec{AiModels: []gatewayv1alpha1.AiModel{{Name: "gpt-4o", Provider: "openai"}}},
+	}
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(gw).Build()
+	return c, s, gw, litellm.GatewayWorkload{Name: gw.Name, Namespace: gw.Namespace, Owner: gw}
+}
+
+func TestAlertReason(t *testing.T) {
+	for event, want := range map[string]string{
+		"budget_crossed":           "BudgetCrossed",
+		"projected_limit_exceeded": "ProjectedLimitExceeded",
+		"":                         ReasonAlert,
+	} {
+		if got := alertReason(event); got != want {
+			t.Errorf("alertReason(%q) = %q, want %q", event, got, want)
+		}
+	}
+}
+
+func TestGenerateAiGatewayConfig_Alerting(t *testing.T) {
+	c, _, gw, _ := alertingFixtures(t)
+	config, err := GenerateAiGatewayConfig(context.Background(), c, nil, gw)
+	if err != nil {
+		t.Fatalf("GenerateAiGatewayConfig: %v", err)
+	}
+	if !strings.Contains(config, "alerting:\n        - webhook") || !strings.Contains(config, "budget_alerts") {
+		t.Errorf("want webhook alerting in general_settings, got\n%s", config)
+	}
+}
+
+func TestGenerateAiGatewayConfig_AlertingSettings(t *testing.T) {
+	c, _, gw, _ := alertingFixtures(t)
+	gw.Annotations[litellm.AlertingSlackSecretAnnotation] = "slack"
+	gw.Annotations[litellm.AlertTypesAnnotation] = "llm_exceptions,llm_too_slow"
+	gw.Annotations[litellm.AlertingThresholdAnnotation] = "90s"
+	config, err := GenerateAiGatewayConfig(context.Background(), c, nil, gw)
+	if err != nil {
+		t.Fatalf("GenerateAiGatewayConfig: %v", err)
+	}
+	for _, want := range []string{
+		"alerting:\n        - webhook\n        - slack",
+		"alert_types:\n        - llm_exceptions\n        - llm_too_slow",
+		"alerting_threshold: 90",
+	} {
+		if !strings.Contains(config, want) {
+			t.Errorf("want %q in general_settings, got\n%s", want, config)
+		}
+	}
+
+	r := &AiGatewayReconciler{Client: c}
+	var slack *corev1.EnvVar
+	for _, e := range r.buildEnvironmentVariables(gw, nil, nil, nil, nil) {
+		if e.Name == litellm.SlackWebhookURLKey {
+			slack = &e
+		}
+	}
+	if slack == nil || slack.ValueFrom.SecretKeyRef.Name != "slack" {
+		t.Errorf("want %s from Secret slack, got %+v", litellm.SlackWebhookURLKey, slack)
+	}
+}
+
+func TestAlertReceiver(t *testing.T) {
+	c, s, gw, w := alertingFixtures(t)
+	ctx := context.Background()
+	if err := litellm.ReconcileAlerting(ctx, c, s, w, "http://receiver"); err != nil {
+		t.Fatalf("ReconcileAlerting: %v", err)
+	}
+	var secret corev1.Secret
+	if err := c.Get(ctx, client.ObjectKey{Namespace: "team-a", Name: "gw-alerting"}, &secret); err != nil {
+		t.Fatalf("get Secret: %v", err)
+	}
+	path := strings.TrimPrefix(string(secret.Data[litellm.WebhookURLKey]), "http://receiver")
+	recorder := events.NewFakeRecorder(10)
+	receiver := &AlertReceiver{Client: c, Scheme: s, Recorder: recorder}
+	post := func(path, body string) int {
+		rec := httptest.NewRecorder()
+		receiver.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)))
+		return rec.Code
+	}
+
+	if code := post("/alerts/team-a/gw/wrong-token", `{}`); code != http.StatusForbidden {
+		t.Errorf("wrong token: got %d", code)
+	}
+	if code := post(path, `{"event":"key_created","event_message":"Key created","key_alias":"ci"}`); code != http.StatusNoContent {
+		t.Fatalf("key_created: got %d", code)
+	}
+	if got := <-recorder.Events; got != "Normal KeyCreated Key created (key ci)" {
+		t.Errorf("event = %q", got)
+	}
+	if alert, _ := litellm.LoadAlert(ctx, c, "team-a", "gw"); alert != nil {
+		t.Errorf("informational events must not be kept, got %+v", alert)
+	}
+
+	body := `{"event":"budget_crossed","event_group":"team","event_message":"Budget Crossed","team_alias":"agents","spend":120,"max_budget":100}`
+	if code := post(path, body); code != http.StatusNoContent {
+		t.Fatalf("budget_crossed: got %d", code)
+	}
+	if got := <-recorder.Events; got != "Warning BudgetCrossed Budget Crossed (team agents): spend 120.00 of 100.00" {
+		t.Errorf("event = %q", got)
+	}
+
+	r := &AiGatewayReconciler{Client: c, Scheme: s, AlertReceiverURL: "http://receiver"}
+	requeue, err := r.syncAlerting(ctx, gw, w)
+	if err != nil {
+		t.Fatalf("syncAlerting: %v", err)
+	}
+	cond := apimeta.FindStatusCondition(gw.Status.Conditions, AiGatewayAlerting)
+	if cond == nil || cond.Status != metav1.ConditionTrue || cond.Reason != "BudgetCrossed" {
+		t.Errorf("condition = %+v", cond)
+	}
+	if requeue <= alertHold-time.Minute || requeue > alertHold {
+		t.Errorf("requeue = %s, want about %s", requeue, alertHold)
+	}
+
+	// Turning alerting off drops the condition and the kept alert.
+	gw.Annotations = nil
+	if _, err := r.syncAlerting(ctx, gw, w); err != nil {
+		t.Fatalf("syncAlerting: %v", err)
+	}
+	if apimeta.FindStatusCondition(gw.Status.Conditions, AiGatewayAlerting) != nil {
+		t.Error("condition must be removed")
+	}
+	if alert, _ := litellm.LoadAlert(ctx, c, "team-a", "gw"); alert != nil {
+		t.Errorf("alert ConfigMap must be deleted, got %+v", alert)
+	}
+}
+
+func TestSyncAlerting_WithoutReceiver(t *testing.T) {
+	c, s, gw, w := alertingFixtures(t)
+	r := &AiGatewayReconciler{Client: c, Scheme: s}
+	if _, err := r.syncAlerting(context.Background(), gw, w); err != nil {
+		t.Fatalf("syncAlerting: %v", err)
+	}
+	cond := apimeta.FindStatusCondition(gw.Status.Conditions, AiGatewayAlerting)
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != ReasonAlertingUnavailable {
+		t.Errorf("condition = %+v", cond)
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/litellm"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// AiGatewayBackupScheduled reports whether the backup CronJob requested via
+// the backup-schedule annotation is in place.
+const AiGatewayBackupScheduled = "AiGatewayBackupScheduled"
+
+// AiGatewayRestored reports the state of the restore requested via the
+// restore-from annotation.
+const AiGatewayRestored = "AiGatewayRestored"
+
+// Backup and restore condition reasons
+const (
+	ReasonBackupScheduled   = "BackupScheduled"
+	ReasonBackupInvalid     = "BackupInvalid"
+	ReasonRestoreRunning    = "RestoreRunning"
+	ReasonRestoreSucceeded  = "RestoreSucceeded"
+	ReasonRestoreFailed     = "RestoreFailed"
+	ReasonBackupApplyFailed = "BackupApplyFailed"
+)
+
+// syncBackup reconciles the backup CronJob and restore Job of gw and stamps
+// the backup and restore conditions. Invalid annotations are reported on the
+// conditions only: a broken backup setup must not take the gateway down.
+// Apiserver errors are returned for a retry with backoff.
+func (r *AiGatewayReconciler) syncBackup(ctx context.Context, gw *gatewayv1alpha1.AiGateway, workload litellm.GatewayWorkload) error {
+	env, err := litellm.ResolveGatewayEnv(ctx, r, gw)
+	if err != nil {
+		return err
+	}
+	backup, err := litellm.ParseBackup(gw.Name, gw.Annotations)
+	if err == nil && backup != nil && !litellm.DatabaseModeEnabled(env) {
+		err = fmt.Errorf("backups need %s in spec.env, spec.envFrom or a provisioned database", litellm.DatabaseURLEnvVar)
+	}
+	if err != nil {
+		r.updateCondition(gw, AiGatewayBackupScheduled, metav1.ConditionFalse, ReasonBackupInvalid, err.Error())
+		apimeta.RemoveStatusCondition(&gw.Status.Conditions, AiGatewayRestored)
+		backup = nil
+	}
+
+	var databaseURL corev1.EnvVar
+	for _, e := range env {
+		if e.Name == litellm.DatabaseURLEnvVar {
+			databaseURL = e
+		}
+	}
+	restore, applyErr := litellm.ReconcileBackup(ctx, r.Client, r.Scheme, workload, backup, databaseURL)
+	if applyErr != nil {
+		logf.FromContext(ctx).Error(applyErr, "Failed to reconcile backup")
+		r.updateCondition(gw, AiGatewayBackupScheduled, metav1.ConditionFalse, ReasonBackupApplyFailed, applyErr.Error())
+		return applyErr
+	}
+	if err != nil {
+		return nil
+	}
+
+	if backup != nil && backup.Schedule != "" {
+		r.updateCondition(gw, AiGatewayBackupScheduled, metav1.ConditionTrue, ReasonBackupScheduled,
+			fmt.Sprintf("Backing up on schedule %q to the location in Secret %s, keeping %d dumps",
+				backup.Schedule, backup.SecretName, backup.Retention))
+	} else {
+		apimeta.RemoveStatusCondition(&gw.Status.Conditions, AiGatewayBackupScheduled)
+	}
+
+	switch {
+	case restore.Waiting:
+		r.updateCondition(gw, AiGatewayRestored, metav1.ConditionUnknown, ReasonRestoreRunning,
+			fmt.Sprintf("Waiting for the pods of Deployment %s to stop before restoring %s", gw.Name, backup.RestoreFrom))
+	case restore.Job == nil:
+		apimeta.RemoveStatusCondition(&gw.Status.Conditions, AiGatewayRestored)
+	case restore.Complete:
+		r.updateCondition(gw, AiGatewayRestored, metav1.ConditionTrue, ReasonRestoreSucceeded,
+			fmt.Sprintf("Restored %s", backup.RestoreFrom))
+	case restore.Failed:
+		r.updateCondition(gw, AiGatewayRestored, metav1.ConditionFalse, ReasonRestoreFailed,
+			fmt.Sprintf("Restore of %s failed; see Job %s", backup.RestoreFrom, restore.Job.Name))
+	default:
+		r.updateCondition(gw, AiGatewayRestored, metav1.ConditionUnknown, ReasonRestoreRunning,
+			fmt.Sprintf("Restoring %s", backup.RestoreFrom))
+	}
+	return nil
+}
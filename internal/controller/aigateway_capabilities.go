@@ -0,0 +1,104 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/litellm"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AiGatewayCapabilities reports whether the cluster serves the optional
+// integrations the gateway's annotations ask for.
+const AiGatewayCapabilities = "AiGatewayCapabilities"
+
+// Capability condition reasons
+const (
+	ReasonCapabilitiesAvailable = "CapabilitiesAvailable"
+	ReasonCapabilitiesMissing   = "CapabilitiesMissing"
+
+	// ReasonCapabilityMissing indicates the gateway is not rolled out
+	// because an integration its workload needs is not installed.
+	ReasonCapabilityMissing = "CapabilityMissing"
+)
+
+// workloadCapabilities are the capabilities the gateway's workload cannot be
+// rolled out without. Missing others only disables their integration.
+var workloadCapabilities = []litellm.Capability{litellm.CapabilityArgoRollouts, litellm.CapabilityCloudNativePG}
+
+// requiredCapabilities returns the capabilities the parsed features of a
+// gateway need.
+func requiredCapabilities(argoRollout *litellm.ArgoRollout, flagger *litellm.Flagger, database *litellm.Database,
+	inferencePool *litellm.InferencePool) []litellm.Capability {
+	var required []litellm.Capability
+	if argoRollout != nil {
+		required = append(required, litellm.CapabilityArgoRollouts)
+	}
+	if flagger != nil {
+		required = append(required, litellm.CapabilityFlagger)
+	}
+	if database != nil {
+		required = append(required, litellm.CapabilityCloudNativePG)
+	}
+	if inferencePool != nil {
+		required = append(required, litellm.CapabilityInferencePool)
+	}
+	return required
+}
+
+// checkCapabilities stamps the capabilities condition of gw for the
+// capabilities it requires and returns the missing ones. The condition is
+// removed when gw requires none or capabilities are not detected.
+func (r *AiGatewayReconciler) checkCapabilities(gw *gatewayv1alpha1.AiGateway, required []litellm.Capability) []litellm.Capability {
+	if len(required) == 0 || r.Capabilities == nil {
+		apimeta.RemoveStatusCondition(&gw.Status.Conditions, AiGatewayCapabilities)
+		return nil
+	}
+	missing := r.Capabilities.Missing(required...)
+	if len(missing) == 0 {
+		r.updateCondition(gw, AiGatewayCapabilities, metav1.ConditionTrue, ReasonCapabilitiesAvailable,
+			capabilityList(required)+" served by the cluster")
+		return nil
+	}
+	r.updateCondition(gw, AiGatewayCapabilities, metav1.ConditionFalse, ReasonCapabilitiesMissing,
+		fmt.Sprintf("%s not installed; the gateway is reconciled again once the cluster serves them", capabilityList(missing)))
+	return missing
+}
+
+// missingWorkloadCapabilities returns those of missing the workload needs.
+func missingWorkloadCapabilities(missing []litellm.Capability) []litellm.Capability {
+	var blocking []litellm.Capability
+	for _, capability := range missing {
+		if slices.Contains(workloadCapabilities, capability) {
+			blocking = append(blocking, capability)
+		}
+	}
+	return blocking
+}
+
+func capabilityList(capabilities []litellm.Capability) string {
+	names := make([]string, len(capabilities))
+	for i, capability := range capabilities {
+		names[i] = string(capability)
+	}
+	return strings.Join(names, ", ")
+}
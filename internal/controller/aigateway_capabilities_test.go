@@ -0,0 +1,72 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"reflect"
+	"testing"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/litellm"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+func TestCheckCapabilities(t *testing.T) {
+	detector := &litellm.CapabilityDetector{Discovery: &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{
+		Resources: []*metav1.APIResourceList{{
+			GroupVersion: "flagger.app/v1beta1",
+			APIResources: []metav1.APIResource{{Kind: "Canary"}, {Kind: "MetricTemplate"}},
+		}},
+	}}}
+	if err := detector.Detect(); err != nil {
+		t.Fatalf("Detect: %v", err)
+	}
+	r := &AiGatewayReconciler{Capabilities: detector}
+	gw := &gatewayv1alpha1.AiGateway{}
+
+	required := requiredCapabilities(nil, &litellm.Flagger{}, nil, nil)
+	if missing := r.checkCapabilities(gw, required); missing != nil {
+		t.Errorf("Flagger: missing = %v", missing)
+	}
+	if cond := apimeta.FindStatusCondition(gw.Status.Conditions, AiGatewayCapabilities); cond == nil ||
+		cond.Status != metav1.ConditionTrue || cond.Reason != ReasonCapabilitiesAvailable {
+		t.Errorf("Flagger: condition = %+v", cond)
+	}
+
+	required = requiredCapabilities(&litellm.ArgoRollout{}, &litellm.Flagger{}, &litellm.Database{}, &litellm.InferencePool{})
+	missing := r.checkCapabilities(gw, required)
+	want := []litellm.Capability{litellm.CapabilityArgoRollouts, litellm.CapabilityCloudNativePG, litellm.CapabilityInferencePool}
+	if !reflect.DeepEqual(missing, want) {
+		t.Errorf("missing = %v, want %v", missing, want)
+	}
+	if cond := apimeta.FindStatusCondition(gw.Status.Conditions, AiGatewayCapabilities); cond == nil ||
+		cond.Status != metav1.ConditionFalse || cond.Reason != ReasonCapabilitiesMissing {
+		t.Errorf("missing: condition = %+v", cond)
+	}
+	if blocking := missingWorkloadCapabilities(missing); !reflect.DeepEqual(blocking,
+		[]litellm.Capability{litellm.CapabilityArgoRollouts, litellm.CapabilityCloudNativePG}) {
+		t.Errorf("blocking = %v", blocking)
+	}
+
+	r.checkCapabilities(gw, nil)
+	if cond := apimeta.FindStatusCondition(gw.Status.Conditions, AiGatewayCapabilities); cond != nil {
+		t.Errorf("without required capabilities the condition must be removed, got %+v", cond)
+	}
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	litellmv1alpha1 "github.com/agentic-layer/ai-gateway-litellm/api/v1alpha1"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/litellm"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGenerateAiGatewayConfig_ClassConfig(t *testing.T) {
+	s := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{gatewayv1alpha1.AddToScheme, litellmv1alpha1.AddToScheme, corev1.AddToScheme} {
+		if err := add(s); err != nil {
+			t.Fatalf("AddToScheme: %v", err)
+		}
+	}
+	class := &gatewayv1alpha1.AiGatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "litellm", Annotations: map[string]string{
+			litellm.ClassConfigAnnotation: "platform",
+		}},
+		Spec: gatewayv1alpha1.AiGatewayClassSpec{Controller: ControllerName},
+	}
+	classConfig := &litellmv1alpha1.LiteLLMClassConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "platform"},
+		Spec: litellmv1alpha1.LiteLLMClassConfigSpec{
+			LiteLLMSettings: &runtime.RawExtension{Raw: []byte(`{"request_timeout":1200,"drop_params":true}`)},
+			Callbacks:       []string{"langsmith"},
+		},
+	}
+	patch := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "patch", Namespace: "team-a"},
+		Data:       map[string]string{litellm.PatchYAMLKey: "litellm_settings:\n  request_timeout: 300\n"},
+	}
+	gw := &gatewayv1alpha1.AiGateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "team-a"},
+		Spec: gatewayv1alpha1.AiGatewaySpec{
+			AiGatewayClassName: "litellm",
+			AiModels:           []gatewayv1alpha1.AiModel{{Name: "gpt-4o", Provider: "openai"}},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(class, classConfig, patch, gw).Build()
+
+	config, err := GenerateAiGatewayConfig(context.Background(), c, nil, gw)
+	if err != nil {
+		t.Fatalf("GenerateAiGatewayConfig: %v", err)
+	}
+	for _, want := range []string{"request_timeout: 1200", "drop_params: true", "- langsmith"} {
+		if !strings.Contains(config, want) {
+			t.Errorf("want %q in the config, got\n%s", want, config)
+		}
+	}
+
+	// The gateway's own patch wins over its class.
+	gw.Annotations = map[string]string{litellm.ConfigPatchAnnotation: "patch"}
+	config, err = GenerateAiGatewayConfig(context.Background(), c, nil, gw)
+	if err != nil {
+		t.Fatalf("GenerateAiGatewayConfig: %v", err)
+	}
+	if !strings.Contains(config, "request_timeout: 300") || !strings.Contains(config, "drop_params: true") {
+		t.Errorf("want the patch layered onto the class config, got\n%s", config)
+	}
+
+	// A class naming a missing class config fails every gateway of the class.
+	class.Annotations[litellm.ClassConfigAnnotation] = "missing"
+	if err := c.Update(context.Background(), class); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	_, err = GenerateAiGatewayConfig(context.Background(), c, nil, gw)
+	if reason := configFailureReason(err); reason != ReasonClassConfigInvalid {
+		t.Errorf("got reason %s (%v), want %s", reason, err, ReasonClassConfigInvalid)
+	}
+	var pe *litellm.PhaseError
+	if !errors.As(err, &pe) || isTransientPhaseError(err) {
+		t.Errorf("want a permanent PhaseError, got %v", err)
+	}
+}
+
+func TestBuildEnvironmentVariables_ClassAPIKeySecret(t *testing.T) {
+	r := &AiGatewayReconciler{APIKeySecretName: "operator-keys"}
+	gw := &gatewayv1alpha1.AiGateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "team-a"},
+		Spec:       gatewayv1alpha1.AiGatewaySpec{AiModels: []gatewayv1alpha1.AiModel{{Name: "gpt-4o", Provider: "openai"}}},
+	}
+	for _, tc := range []struct {
+		classConfig *litellmv1alpha1.LiteLLMClassConfigSpec
+		want        string
+	}{
+		{nil, "operator-keys"},
+		{&litellmv1alpha1.LiteLLMClassConfigSpec{}, "operator-keys"},
+		{&litellmv1alpha1.LiteLLMClassConfigSpec{APIKeySecretName: "platform-keys"}, "platform-keys"},
+	} {
+		var got string
+		for _, e := range r.buildEnvironmentVariables(gw, nil, nil, tc.classConfig, nil) {
+			if e.Name == "OPENAI_API_KEY" {
+				got = e.ValueFrom.SecretKeyRef.Name
+			}
+		}
+		if got != tc.want {
+			t.Errorf("class config %+v: got Secret %q, want %q", tc.classConfig, got, tc.want)
+		}
+	}
+}
+
+func TestAiGatewaysForClassSecret(t *testing.T) {
+	s := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{gatewayv1alpha1.AddToScheme, litellmv1alpha1.AddToScheme, corev1.AddToScheme} {
+		if err := add(s); err != nil {
+			t.Fatalf("AddToScheme: %v", err)
+		}
+	}
+	objs := []client.Object{
+		&gatewayv1alpha1.AiGatewayClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "platform", Annotations: map[string]string{litellm.ClassConfigAnnotation: "platform"}},
+			Spec:       gatewayv1alpha1.AiGatewayClassSpec{Controller: ControllerName},
+		},
+		&gatewayv1alpha1.AiGatewayClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "traced", Annotations: map[string]string{litellm.LangfuseSecretAnnotation: "langfuse"}},
+			Spec:       gatewayv1alpha1.AiGatewayClassSpec{Controller: ControllerName},
+		},
+		&litellmv1alpha1.LiteLLMClassConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "platform"},
+			Spec:       litellmv1alpha1.LiteLLMClassConfigSpec{APIKeySecretName: "platform-keys"},
+		},
+		&gatewayv1alpha1.AiGateway{
+			ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "team-a"},
+			Spec:       gatewayv1alpha1.AiGatewaySpec{AiGatewayClassName: "platform"},
+		},
+		&gatewayv1alpha1.AiGateway{
+			ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "team-a"},
+			Spec:       gatewayv1alpha1.AiGatewaySpec{AiGatewayClassName: "traced"},
+		},
+		&gatewayv1alpha1.AiGateway{
+			ObjectMeta: metav1.ObjectMeta{Name: "c", Namespace: "team-b"},
+			Spec:       gatewayv1alpha1.AiGatewaySpec{AiGatewayClassName: "platform"},
+		},
+	}
+	r := &AiGatewayReconciler{Client: fake.NewClientBuilder().WithScheme(s).WithObjects(objs...).Build(), Scheme: s}
+
+	for _, tc := range []struct {
+		secret string
+		want   []string
+	}{
+		{"platform-keys", []string{"a"}},
+		{"langfuse", []string{"b"}},
+		{"unrelated", nil},
+	} {
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: tc.secret, Namespace: "team-a"}}
+		var got []string
+		for _, req := range r.aiGatewaysForClassSecret(context.Background(), secret) {
+			if req.Namespace != "team-a" {
+				t.Errorf("Secret %s: enqueued %v outside its namespace", tc.secret, req.NamespacedName)
+			}
+			got = append(got, req.Name)
+		}
+		if !slices.Equal(got, tc.want) {
+			t.Errorf("Secret %s: got %v, want %v", tc.secret, got, tc.want)
+		}
+	}
+}
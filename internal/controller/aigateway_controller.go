@@ -17,25 +17,41 @@ limitations under the License.
 package controller
 
 import (
+	"cmp"
 	"context"
 	stderrors "errors"
 	"fmt"
+	"slices"
 	"strings"
+	"sync"
+	"time"
 
 	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	litellmv1alpha1 "github.com/agentic-layer/ai-gateway-litellm/api/v1alpha1"
 	"github.com/agentic-layer/ai-gateway-litellm/internal/litellm"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/operatorconfig"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 // Status condition types
@@ -45,6 +61,17 @@ const (
 
 	// AiGatewayReady indicates if the AiGateway is ready to serve traffic
 	AiGatewayReady = "AiGatewayReady"
+
+	// AiGatewayProgressing indicates if a config rollout of the Deployment is underway
+	AiGatewayProgressing = "AiGatewayProgressing"
+
+	// AiGatewayHostname reports the DNS name the litellm.HostnameAnnotation
+	// publishes the gateway's Service under.
+	AiGatewayHostname = "AiGatewayHostname"
+
+	// AiGatewaySuspended reports a gateway parked by the
+	// litellm.SuspendAnnotation.
+	AiGatewaySuspended = "AiGatewaySuspended"
 )
 
 // Condition reasons
@@ -58,7 +85,7 @@ const (
 	// ReasonAiGatewayRollingOut indicates the Deployment has not yet finished its rollout.
 	ReasonAiGatewayRollingOut = "DeploymentRollingOut"
 
-	// ReasonConfigGenerationFailed is the default reason for failures inside generateAiGatewayConfig.
+	// ReasonConfigGenerationFailed is the default reason for failures inside GenerateAiGatewayConfig.
 	ReasonConfigGenerationFailed = "ConfigGenerationFailed"
 
 	// ReasonGuardrailsResolutionFailed indicates a Guard / GuardrailProvider could not be resolved.
@@ -67,14 +94,288 @@ const (
 	// ReasonConfigPatchInvalid indicates the config-patch annotation referenced a missing or
 	// malformed ConfigMap.
 	ReasonConfigPatchInvalid = "ConfigPatchInvalid"
+
+	// ReasonLogLevelInvalid indicates the log-level annotation holds an unsupported value.
+	ReasonLogLevelInvalid = "LogLevelInvalid"
+
+	// ReasonRolloutStrategyInvalid indicates the rollout-strategy,
+	// blue-green-soak or argo-rollouts-* annotations hold an unsupported value.
+	ReasonRolloutStrategyInvalid = "RolloutStrategyInvalid"
+
+	// ReasonUpstreamInvalid indicates the upstream annotation names an AiGateway
+	// that is missing, not served by this operator, lacks one of the gateway's
+	// models, or leads back to the gateway.
+	ReasonUpstreamInvalid = "UpstreamInvalid"
+
+	// ReasonModelDiscoveryFailed indicates the model-discovery annotation holds
+	// an invalid label selector or model server Services could not be listed.
+	ReasonModelDiscoveryFailed = "ModelDiscoveryFailed"
+
+	// ReasonManagedCacheInvalid indicates the managed-cache annotation is not
+	// a boolean.
+	ReasonManagedCacheInvalid = "ManagedCacheInvalid"
+
+	// ReasonManagedCacheFailed indicates the managed Redis could not be
+	// applied or deleted.
+	ReasonManagedCacheFailed = "ManagedCacheFailed"
+
+	// ReasonDatabaseInvalid indicates the database annotations are invalid
+	// or conflict with DATABASE_URL in spec.env.
+	ReasonDatabaseInvalid = "DatabaseInvalid"
+
+	// ReasonDatabaseProvisioningFailed indicates the CNPG Cluster could not
+	// be applied, usually because CloudNativePG is not installed.
+	ReasonDatabaseProvisioningFailed = "DatabaseProvisioningFailed"
+
+	// ReasonPassThroughResolutionFailed indicates a LiteLLMPassThroughEndpoint
+	// listed by the gateway is missing or serves a path another one serves.
+	ReasonPassThroughResolutionFailed = "PassThroughResolutionFailed"
+
+	// ReasonAdminUIInvalid indicates the admin UI annotations are invalid or
+	// the gateway is not database-backed.
+	ReasonAdminUIInvalid = "AdminUIInvalid"
+
+	// ReasonAdminUIFailed indicates the admin UI Service or Ingress could not
+	// be applied or deleted.
+	ReasonAdminUIFailed = "AdminUIFailed"
+
+	// ReasonConfigHistoryInvalid indicates the config-history or rollback-to
+	// annotation is invalid, or the snapshot to roll back to does not exist.
+	ReasonConfigHistoryInvalid = "ConfigHistoryInvalid"
+
+	// ReasonConfigRolledBack indicates the gateway serves a config snapshot
+	// named by the rollback-to annotation instead of its generated config.
+	ReasonConfigRolledBack = "ConfigRolledBack"
+
+	// ReasonGatewayTemplateInvalid indicates the LiteLLMGatewayTemplate named
+	// by the gateway-template annotation is missing or clashes with the
+	// generated pod.
+	ReasonGatewayTemplateInvalid = "GatewayTemplateInvalid"
+
+	// ReasonAlertingInvalid indicates an alerting annotation is invalid,
+	// such as an alerting annotation that is not a boolean or an unknown
+	// alert type.
+	ReasonAlertingInvalid = "AlertingInvalid"
+
+	// ReasonAlertingFailed indicates the alerting Secret could not be
+	// written or deleted.
+	ReasonAlertingFailed = "AlertingFailed"
+
+	// ReasonArgoRolloutsFailed indicates the Argo Rollout or its
+	// AnalysisTemplate could not be applied, usually because Argo Rollouts is
+	// not installed.
+	ReasonArgoRolloutsFailed = "ArgoRolloutsFailed"
+
+	// ReasonFlaggerInvalid indicates the flagger annotations are invalid or
+	// conflict with the rollout strategy.
+	ReasonFlaggerInvalid = "FlaggerInvalid"
+
+	// ReasonHostnameInvalid indicates the hostname annotation is not a DNS
+	// name, or a single label while the operator has no DNS domain.
+	ReasonHostnameInvalid = "HostnameInvalid"
+
+	// ReasonOTelCollectorInvalid indicates the otel-collector annotations
+	// are invalid, or ask for a sidecar without an OTLP endpoint.
+	ReasonOTelCollectorInvalid = "OTelCollectorInvalid"
+
+	// ReasonLangfuseInvalid indicates the Langfuse annotations of the
+	// gateway or its class are invalid or incomplete.
+	ReasonLangfuseInvalid = "LangfuseInvalid"
+
+	// ReasonVeleroInvalid indicates the velero annotation is not a boolean.
+	ReasonVeleroInvalid = "VeleroInvalid"
+
+	// ReasonClassConfigInvalid indicates the LiteLLMClassConfig named by the
+	// class-config annotation of the gateway's class is missing or invalid.
+	ReasonClassConfigInvalid = "ClassConfigInvalid"
+
+	// ReasonImagePolicyInvalid indicates the image-policy annotation of the
+	// gateway's class is not a known policy.
+	ReasonImagePolicyInvalid = "ImagePolicyInvalid"
+
+	// ReasonInferencePoolInvalid indicates the inference-pool annotations
+	// are invalid or conflict with Flagger.
+	ReasonInferencePoolInvalid = "InferencePoolInvalid"
+
+	// ReasonEgressProxyInvalid indicates a proxy annotation is not an
+	// http, https or socks5 URL.
+	ReasonEgressProxyInvalid = "EgressProxyInvalid"
+
+	// ReasonPreCallChecksInvalid indicates the pre-call-checks annotation is
+	// not a boolean, or a context window fallback names no model of the
+	// gateway.
+	ReasonPreCallChecksInvalid = "PreCallChecksInvalid"
+
+	// ReasonCORSInvalid indicates a CORS annotation lists an origin or
+	// header that is not one.
+	ReasonCORSInvalid = "CORSInvalid"
+
+	// ReasonOverrideInvalid indicates an override of the gateway template
+	// does not apply to the generated objects.
+	ReasonOverrideInvalid = "OverrideInvalid"
+
+	// ReasonHostnamePublished indicates the gateway's Service carries the
+	// external-dns annotation for its hostname.
+	ReasonHostnamePublished = "HostnamePublished"
+
+	// ReasonFlaggerFailed indicates the Flagger Services or MetricTemplates
+	// could not be applied or deleted, usually because Flagger is not
+	// installed.
+	ReasonFlaggerFailed = "FlaggerFailed"
+
+	// ReasonInferencePoolFailed indicates the InferencePool or InferenceModels
+	// could not be applied or deleted, usually because the Gateway API
+	// Inference Extension is not installed.
+	ReasonInferencePoolFailed = "InferencePoolFailed"
+
+	// ReasonOverprovisioningFailed indicates the overprovisioning Deployment
+	// could not be applied or deleted.
+	ReasonOverprovisioningFailed = "OverprovisioningFailed"
+
+	// ReasonManifestsRendered indicates the render-only annotation is set and the
+	// workload manifests were written to the rendered ConfigMap.
+	ReasonManifestsRendered = "ManifestsRendered"
+
+	// ReasonRenderOnly indicates the gateway is not served because its
+	// manifests are only rendered.
+	ReasonRenderOnly = "RenderOnly"
+
+	// ReasonSuspended indicates the gateway's Deployment is scaled to zero
+	// by the suspend annotation.
+	ReasonSuspended = "Suspended"
+
+	// ReasonResourceConflict indicates a child object with the gateway's name exists
+	// and cannot be adopted.
+	ReasonResourceConflict = "ResourceConflict"
 )
 
 const ControllerName = "aigateway.agentic-layer.ai/ai-gateway-litellm-controller"
 
+// rolloutPollInterval is how often a gateway is reconciled while a rollout
+// the operator does not watch, of an Argo Rollout or Flagger's primary
+// Deployment, is in progress.
+const rolloutPollInterval = 15 * time.Second
+
 // AiGatewayReconciler reconciles an AiGateway object
 type AiGatewayReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+
+	// HealthCheckInterval is how often the LiteLLM /health endpoint of a ready
+	// gateway is polled and mirrored into the AiGatewayHealthy condition.
+	// Zero disables health syncing.
+	HealthCheckInterval time.Duration
+
+	// SpendSyncInterval is how often total and per-model spend of a
+	// database-backed gateway is read from LiteLLM and published as the
+	// AiGatewaySpend condition and as controller metrics. Zero disables it.
+	SpendSyncInterval time.Duration
+
+	// MaxConcurrentReconciles is the number of AiGateways reconciled in
+	// parallel. Zero uses the controller-runtime default of one.
+	MaxConcurrentReconciles int
+
+	// RateLimiter paces requeues of failed reconciles. Nil uses the
+	// controller-runtime default.
+	RateLimiter workqueue.TypedRateLimiter[reconcile.Request]
+
+	// ResyncInterval re-reconciles a successfully reconciled gateway this
+	// often even without a triggering event, correcting drift of its
+	// workload. Zero disables it. Overridable per gateway via
+	// litellm.ResyncIntervalAnnotation.
+	ResyncInterval time.Duration
+
+	// APIReader reads owned objects the label-filtered cache does not hold
+	// (see litellm.ManagedSelector). Nil skips the lookup.
+	APIReader client.Reader
+
+	// ModelServerCache holds the Services labelled litellm.ModelServerLabel,
+	// which the cache behind Client filters out. Gateways with the
+	// litellm.ModelDiscoveryAnnotation get the models of these Services. Nil
+	// disables model discovery.
+	ModelServerCache cache.Cache
+
+	// RemoteClient builds clients for the clusters of the
+	// litellm.ReplicateToAnnotation. Nil uses litellm.NewRemoteClient.
+	RemoteClient litellm.RemoteClientFunc
+
+	// ReplicationNamespace holds the kubeconfig Secrets named by the
+	// litellm.ReplicateToAnnotation. Gateway owners cannot point the operator
+	// at kubeconfigs of their own. Empty disables replication.
+	ReplicationNamespace string
+
+	// AlertReceiverURL is the base URL gateways reach the AlertReceiver at.
+	// Empty leaves the gateways with the litellm.AlertingAnnotation without
+	// a webhook.
+	AlertReceiverURL string
+
+	// DNSDomain is the domain single-label values of the
+	// litellm.HostnameAnnotation are published under. Empty requires fully
+	// qualified names.
+	DNSDomain string
+
+	// OTLPEndpoint is the OTLP/HTTP endpoint the collector sidecars of the
+	// litellm.OTelCollectorAnnotation export to by default.
+	OTLPEndpoint string
+
+	// ImageResolver resolves the LiteLLM image under the
+	// litellm.ImagePolicyAnnotation of the gateway's class. Nil runs the
+	// image by tag whatever the policy.
+	ImageResolver *litellm.ImageResolver
+
+	// RegistryMirror is the registry every image of the gateways is pulled
+	// through; see litellm.MirrorImage. Empty pulls from the images' own
+	// registries.
+	RegistryMirror string
+
+	// EgressProxy is the default egress proxy of the gateways, overridable
+	// per gateway with the litellm.HTTPProxyAnnotation family.
+	EgressProxy litellm.EgressProxy
+
+	// Image is the LiteLLM image of the gateways. Empty uses litellm.Image.
+	Image string
+
+	// APIKeySecretName is the Secret in a gateway's namespace its provider
+	// API keys are read from. Empty uses litellm.ApiKeySecretName.
+	APIKeySecretName string
+
+	// RequestTimeout is the request_timeout of the generated config. Zero
+	// uses litellm.DefaultRequestTimeout.
+	RequestTimeout time.Duration
+
+	// FeatureGates turns optional features off; a gateway asking for one is
+	// served without it. Nil enables every feature.
+	FeatureGates *operatorconfig.FeatureGates
+
+	// Capabilities reports the optional integrations the cluster serves.
+	// Gateways asking for a missing one report it in their
+	// AiGatewayCapabilities condition instead of failing. Nil assumes every
+	// integration is installed.
+	Capabilities *litellm.CapabilityDetector
+
+	// ClusterAutoscaler sets the safe-to-evict annotation and priority class
+	// of the gateway pods and runs their overprovisioning placeholders. Nil
+	// leaves the pods to the cluster autoscaler's defaults.
+	ClusterAutoscaler *litellm.ClusterAutoscaler
+
+	// DryRun logs the change each owned object would receive instead of
+	// applying it. Client must then be a dry-run client so status writes are
+	// discarded too.
+	DryRun bool
+
+	// PreflightInterval is how long the preflight results of a gateway with
+	// the litellm.PreflightAnnotation are reused while its API keys stay
+	// the same. Zero uses one hour.
+	PreflightInterval time.Duration
+
+	// PreflightChecker checks the providers of those gateways. Nil uses a
+	// checker calling the providers' public endpoints.
+	PreflightChecker *litellm.PreflightChecker
+
+	probeMu    sync.Mutex
+	health     probeTracker
+	spend      probeTracker
+	preflights map[types.NamespacedName]preflightRun
 }
 
 // +kubebuilder:rbac:groups=runtime.agentic-layer.ai,resources=aigateways,verbs=get;list;watch;create;update;patch;delete
@@ -83,10 +384,25 @@ type AiGatewayReconciler struct {
 // +kubebuilder:rbac:groups=runtime.agentic-layer.ai,resources=aigatewayclasses,verbs=get;list;watch
 // +kubebuilder:rbac:groups=runtime.agentic-layer.ai,resources=guards,verbs=get;list;watch
 // +kubebuilder:rbac:groups=runtime.agentic-layer.ai,resources=guardrailproviders,verbs=get;list;watch
+// +kubebuilder:rbac:groups=litellm.agentic-layer.ai,resources=litellmbudgets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=litellm.agentic-layer.ai,resources=litellmratelimitpolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=litellm.agentic-layer.ai,resources=litellmmaintenancewindows,verbs=get;list;watch
+// +kubebuilder:rbac:groups=litellm.agentic-layer.ai,resources=litellmgatewaytemplates,verbs=get;list;watch
+// +kubebuilder:rbac:groups=litellm.agentic-layer.ai,resources=litellmclassconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=litellm.agentic-layer.ai,resources=litellmpassthroughendpoints,verbs=get;list;watch
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=batch,resources=cronjobs;jobs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;rolebindings,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=clusters,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=argoproj.io,resources=rollouts;analysistemplates,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=flagger.app,resources=metrictemplates,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=inference.networking.k8s.io,resources=inferencepools,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=inference.networking.x-k8s.io,resources=inferencepools;inferencemodels,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
 
@@ -98,6 +414,7 @@ func (r *AiGatewayReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	if err := r.Get(ctx, req.NamespacedName, &aiGateway); err != nil {
 		if apierrors.IsNotFound(err) {
 			log.Info("AiGateway resource not found")
+			r.forget(req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
 		log.Error(err, "Failed to get AiGateway")
@@ -105,14 +422,25 @@ func (r *AiGatewayReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	}
 	original := aiGateway.DeepCopy()
 
-	owned, err := litellm.IsAiGatewayOwnedByController(ctx, r, &aiGateway, ControllerName)
+	// Owned objects are garbage collected with the gateway; only replicas
+	// in remote clusters hold it. They are deleted even when the gateway
+	// is no longer served by this operator.
+	if !aiGateway.DeletionTimestamp.IsZero() {
+		if controllerutil.ContainsFinalizer(&aiGateway, litellm.ReplicaFinalizer) {
+			workload := litellm.GatewayWorkload{Name: aiGateway.Name, Namespace: aiGateway.Namespace, Owner: &aiGateway}
+			return ctrl.Result{}, r.syncReplicas(ctx, &aiGateway, workload)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	class, err := litellm.AiGatewayClassOf(ctx, r, &aiGateway, ControllerName)
 	if err != nil {
 		// Surface so controller-runtime requeues with backoff. Swallowing the
 		// error left spec edits unobserved during transient API outages.
 		log.Error(err, "Failed to determine controller ownership")
 		return ctrl.Result{}, err
 	}
-	if !owned {
+	if class == nil {
 		return ctrl.Result{}, nil
 	}
 
@@ -124,167 +452,1005 @@ func (r *AiGatewayReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	}
 
 	// Step 1: Generate configuration
-	configData, err := r.generateAiGatewayConfig(ctx, &aiGateway)
+	plan, err := r.planAiGateway(ctx, &aiGateway, class)
 	if err != nil {
-		reason := ReasonConfigGenerationFailed
-		if pe, ok := stderrors.AsType[*litellm.PhaseError](err); ok {
-			switch pe.Phase {
-			case "Guardrails":
-				reason = ReasonGuardrailsResolutionFailed
-			case "ConfigPatch":
-				reason = ReasonConfigPatchInvalid
-			}
-		}
-		log.Error(err, "Failed to generate configuration")
-		r.updateCondition(&aiGateway, AiGatewayConfigured, metav1.ConditionFalse, reason, err.Error())
-		r.updateCondition(&aiGateway, AiGatewayReady, metav1.ConditionFalse, reason, err.Error())
-		if err := r.patchStatus(ctx, original, &aiGateway); err != nil {
-			return ctrl.Result{}, err
+		return r.configFailed(ctx, original, &aiGateway, err)
+	}
+
+	if err := r.planRestore(ctx, &aiGateway, plan); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Step 2: Assemble the workload
+	env, err := r.gatewayEnv(ctx, &aiGateway, plan)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	// A gateway whose providers fail their preflight check keeps running its
+	// current config until they pass.
+	if passed, retry := r.preflight(ctx, &aiGateway, env); !passed {
+		message := "Preflight check failed: " + apimeta.FindStatusCondition(aiGateway.Status.Conditions, AiGatewayPreflight).Message
+		_, err := r.notConfigured(ctx, original, &aiGateway, ReasonPreflightFailed, message)
+		return ctrl.Result{RequeueAfter: retry}, err
+	}
+	if err := r.publishEgressHosts(ctx, &aiGateway, plan.configData); err != nil {
+		log.Error(err, "Failed to publish the egress hosts")
+		return r.patchStatusAndRetry(ctx, original, &aiGateway, err)
+	}
+	workload, imageRequeue := r.gatewayWorkload(ctx, &aiGateway, plan, env)
+	if err := litellm.CheckOverrides(workload); err != nil {
+		// Waits for the template or the gateway to change, like any other
+		// invalid configuration.
+		log.Error(err, "Failed to apply the gateway template overrides")
+		return r.notConfigured(ctx, original, &aiGateway, ReasonOverrideInvalid, err.Error())
+	}
+	if litellm.RenderOnly(aiGateway.Annotations) {
+		return r.reconcileRenderOnly(ctx, original, &aiGateway, workload)
+	}
+
+	// Step 3: Reconcile ConfigMap, Deployment, Service and their companions
+	missing, blocking := r.requireCapabilities(&aiGateway, plan)
+	if len(blocking) > 0 {
+		// Not an error: the capability detector enqueues the gateway once
+		// the cluster serves them.
+		log.Info("Gateway waits for missing capabilities", "missing", blocking)
+		return r.notConfigured(ctx, original, &aiGateway, ReasonCapabilityMissing,
+			fmt.Sprintf("The gateway needs %s, which the cluster does not serve", capabilityList(blocking)))
+	}
+	applied, err := r.applyWorkload(ctx, &aiGateway, plan, workload, missing)
+	if err != nil {
+		return r.workloadFailed(ctx, original, &aiGateway, err)
+	}
+	if plan.history.RollbackTo != "" {
+		r.updateCondition(&aiGateway, AiGatewayConfigured, metav1.ConditionTrue, ReasonConfigRolledBack,
+			fmt.Sprintf("AiGateway configuration rolled back to snapshot %s", plan.history.RollbackTo))
+	} else {
+		r.updateCondition(&aiGateway, AiGatewayConfigured, metav1.ConditionTrue,
+			ReasonConfigurationApplied, "AiGateway configuration successfully applied")
+	}
+	if err := litellm.DeleteRenderedManifests(ctx, r.Client, workload); err != nil {
+		log.Error(err, "Failed to delete rendered manifests")
+		return ctrl.Result{}, err
+	}
+	if err := r.syncCompanions(ctx, &aiGateway, workload); err != nil {
+		return r.patchStatusAndRetry(ctx, original, &aiGateway, err)
+	}
+
+	// Step 4: Report the rollout and sync what depends on serving pods
+	rollout, err := r.syncRollout(ctx, &aiGateway, plan, workload, applied)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	result := ctrl.Result{RequeueAfter: minRequeue(rollout.requeue, imageRequeue)}
+	probeRequeue, err := r.syncReady(ctx, &aiGateway, plan, workload, env, rollout)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	result.RequeueAfter = minRequeue(result.RequeueAfter, probeRequeue)
+	alertExpiry, err := r.syncServing(ctx, &aiGateway, plan, workload, env, rollout)
+	if err != nil {
+		return r.patchStatusAndRetry(ctx, original, &aiGateway, err)
+	}
+	result.RequeueAfter = minRequeue(result.RequeueAfter, alertExpiry)
+
+	log.Info("Successfully reconciled AiGateway", "name", aiGateway.Name,
+		"aiModels", len(aiGateway.Spec.AiModels))
+
+	result.RequeueAfter = minRequeue(result.RequeueAfter, r.resyncAfter(ctx, &aiGateway))
+	if err := r.patchStatus(ctx, original, &aiGateway); err != nil {
+		return ctrl.Result{}, err
+	}
+	return result, nil
+}
+
+// forget drops what the reconciler remembers about a deleted gateway.
+func (r *AiGatewayReconciler) forget(key types.NamespacedName) {
+	r.probeMu.Lock()
+	r.health.forget(key)
+	r.spend.forget(key)
+	delete(r.preflights, key)
+	r.probeMu.Unlock()
+	forgetSpendMetrics(key)
+}
+
+// notConfigured flips AiGatewayConfigured and AiGatewayReady to False for a
+// gateway that waits for a change before it can be configured.
+func (r *AiGatewayReconciler) notConfigured(ctx context.Context, original, aiGateway *gatewayv1alpha1.AiGateway, reason, message string) (ctrl.Result, error) {
+	r.updateCondition(aiGateway, AiGatewayConfigured, metav1.ConditionFalse, reason, message)
+	r.updateCondition(aiGateway, AiGatewayReady, metav1.ConditionFalse, reason, message)
+	return ctrl.Result{}, r.patchStatus(ctx, original, aiGateway)
+}
+
+// patchStatusAndRetry persists the conditions set so far and returns err, so
+// controller-runtime retries with backoff.
+func (r *AiGatewayReconciler) patchStatusAndRetry(ctx context.Context, original, aiGateway *gatewayv1alpha1.AiGateway, err error) (ctrl.Result, error) {
+	if e := r.patchStatus(ctx, original, aiGateway); e != nil {
+		return ctrl.Result{}, e
+	}
+	return ctrl.Result{}, err
+}
+
+// aiGatewayPlan is what a gateway asks for through its annotations, class
+// and template, resolved before anything is written to the cluster.
+type aiGatewayPlan struct {
+	configData    string
+	logLevel      string
+	blueGreen     *litellm.BlueGreen
+	argoRollout   *litellm.ArgoRollout
+	flagger       *litellm.Flagger
+	hostname      string
+	collector     *litellm.OTelCollector
+	velero        bool
+	inferencePool *litellm.InferencePool
+	imagePolicy   string
+	classConfig   *litellmv1alpha1.LiteLLMClassConfigSpec
+	history       litellm.ConfigHistory
+	template      *litellmv1alpha1.LiteLLMGatewayTemplateSpec
+	adminUI       *litellm.AdminUI
+	suspended     bool
+	// restoring scales the gateway to zero while a backup is restored.
+	restoring bool
+}
+
+// planAiGateway validates the annotations of the gateway and renders its
+// config. Errors are *litellm.PhaseError for invalid input.
+func (r *AiGatewayReconciler) planAiGateway(ctx context.Context, aiGateway *gatewayv1alpha1.AiGateway, class *gatewayv1alpha1.AiGatewayClass) (*aiGatewayPlan, error) {
+	var plan aiGatewayPlan
+	var err error
+	plan.logLevel, err = litellm.ParseLogLevel(aiGateway.Annotations)
+	if err == nil {
+		plan.blueGreen, err = litellm.ParseRolloutStrategy(aiGateway.Annotations)
+	}
+	if err == nil {
+		plan.argoRollout, err = litellm.ParseArgoRollout(aiGateway.Annotations)
+	}
+	if err == nil {
+		plan.flagger, err = litellm.ParseFlagger(aiGateway.Annotations)
+	}
+	if err == nil {
+		plan.hostname, err = litellm.ParseHostname(aiGateway.Annotations, r.DNSDomain)
+	}
+	if err == nil {
+		plan.collector, err = litellm.ParseOTelCollector(aiGateway.Annotations, r.OTLPEndpoint)
+	}
+	if err == nil {
+		plan.velero, err = litellm.Velero(aiGateway.Annotations)
+	}
+	if err == nil {
+		_, err = litellm.ParseEgressProxy(aiGateway.Annotations, r.EgressProxy)
+	}
+	if err == nil {
+		_, err = litellm.ParseCORS(aiGateway.Annotations)
+	}
+	if err == nil {
+		plan.inferencePool, err = litellm.ParseInferencePool(aiGateway.Annotations)
+	}
+	if err == nil {
+		// Parsed here as well as during config generation, which a
+		// rollback skips.
+		plan.adminUI, err = litellm.ResolveAdminUI(ctx, r, aiGateway)
+	}
+	if err == nil {
+		plan.imagePolicy, err = litellm.ParseImagePolicy(class.Annotations)
+	}
+	if err == nil {
+		plan.classConfig, err = litellm.ClassConfig(ctx, r, class)
+	}
+	if err == nil {
+		plan.history, err = litellm.ParseConfigHistory(aiGateway.Annotations)
+	}
+	if err == nil {
+		plan.template, err = gatewayTemplate(ctx, r, aiGateway)
+	}
+	switch {
+	case err != nil:
+		return nil, err
+	case plan.history.RollbackTo != "":
+		// A rollback bypasses config generation, so it also recovers from
+		// a spec or referenced object that no longer renders.
+		plan.configData, err = litellm.LoadConfigSnapshot(ctx, r, aiGateway, plan.history.RollbackTo)
+	default:
+		var modelServers client.Reader
+		if r.ModelServerCache != nil {
+			modelServers = r.ModelServerCache
 		}
-		return ctrl.Result{}, nil
+		plan.configData, err = generateAiGatewayConfig(ctx, r, modelServers, aiGateway, requestTimeoutSeconds(r.RequestTimeout))
+	}
+	if err != nil {
+		return nil, err
 	}
+	plan.suspended = litellm.Suspended(aiGateway.Annotations)
+	r.dropDisabledFeatures(aiGateway, &plan)
+	return &plan, nil
+}
 
-	// Step 2: Reconcile ConfigMap, Deployment, and Service
-	workload := litellm.GatewayWorkload{
-		Name:           aiGateway.Name,
-		Namespace:      aiGateway.Namespace,
-		Owner:          &aiGateway,
-		ContainerPort:  aiGateway.Spec.Port,
-		ServicePort:    aiGateway.Spec.Port,
-		Env:            r.buildEnvironmentVariables(&aiGateway),
-		EnvFrom:        aiGateway.Spec.EnvFrom,
-		CommonMetadata: aiGateway.Spec.CommonMetadata,
-		PodMetadata:    aiGateway.Spec.PodMetadata,
-		ConfigYAML:     configData,
-	}
-
-	if err := litellm.ReconcileWorkload(ctx, r.Client, r.Scheme, workload); err != nil {
-		// Add a case here whenever a new PhaseError.Phase is introduced in
-		// internal/litellm. Unrecognized phases fall through to "WorkloadFailed"
-		// — degraded but never silent.
-		reason := "WorkloadFailed"
-		if pe, ok := stderrors.AsType[*litellm.PhaseError](err); ok {
-			switch pe.Phase {
-			case "ConfigMap":
-				reason = "ConfigMapFailed"
-			case "Secret":
-				reason = "SecretFailed"
-			case "Deployment":
-				reason = "DeploymentFailed"
-			case "Service":
-				reason = "ServiceFailed"
+// dropDisabledFeatures serves features the operator turned off as if they
+// were not asked for.
+func (r *AiGatewayReconciler) dropDisabledFeatures(aiGateway *gatewayv1alpha1.AiGateway, plan *aiGatewayPlan) {
+	disabled := r.disabledFeatures(aiGateway, map[operatorconfig.Feature]bool{
+		operatorconfig.FeatureCanary:        plan.blueGreen != nil || plan.argoRollout != nil || plan.flagger != nil,
+		operatorconfig.FeatureIngress:       plan.adminUI != nil && plan.adminUI.Host != "",
+		operatorconfig.FeatureInferencePool: plan.inferencePool != nil,
+	})
+	if slices.Contains(disabled, operatorconfig.FeatureCanary) {
+		plan.blueGreen, plan.argoRollout, plan.flagger = nil, nil, nil
+	}
+	if slices.Contains(disabled, operatorconfig.FeatureIngress) {
+		plan.adminUI = &litellm.AdminUI{CredentialsSecret: plan.adminUI.CredentialsSecret}
+	}
+	if slices.Contains(disabled, operatorconfig.FeatureInferencePool) {
+		plan.inferencePool = nil
+	}
+	// A suspended gateway runs no pods, so there is no rollout to canary and
+	// no node to keep spare for it.
+	if plan.suspended {
+		plan.blueGreen, plan.argoRollout, plan.flagger = nil, nil, nil
+	}
+}
+
+// planRestore marks plan as restoring while a backup is restored into the
+// database of aiGateway, so the gateway runs no pods that write to it.
+// Invalid backup annotations are reported by syncBackup.
+func (r *AiGatewayReconciler) planRestore(ctx context.Context, aiGateway *gatewayv1alpha1.AiGateway, plan *aiGatewayPlan) error {
+	backup, err := litellm.ParseBackup(aiGateway.Name, aiGateway.Annotations)
+	if err != nil || backup == nil || backup.RestoreFrom == "" {
+		return nil
+	}
+	env, err := litellm.ResolveGatewayEnv(ctx, r, aiGateway)
+	if err != nil || !litellm.DatabaseModeEnabled(env) {
+		return err
+	}
+	plan.restoring, err = litellm.Restoring(ctx, r, aiGateway.Namespace, aiGateway.Name, backup)
+	if plan.restoring {
+		plan.blueGreen, plan.argoRollout, plan.flagger = nil, nil, nil
+	}
+	return err
+}
+
+// configFailed reports a gateway whose config cannot be generated on
+// AiGatewayConfigured and AiGatewayReady.
+func (r *AiGatewayReconciler) configFailed(ctx context.Context, original, aiGateway *gatewayv1alpha1.AiGateway, err error) (ctrl.Result, error) {
+	logf.FromContext(ctx).Error(err, "Failed to generate configuration")
+	result, patchErr := r.notConfigured(ctx, original, aiGateway, configFailureReason(err), err.Error())
+	// Invalid user input waits for the edit that fixes it; a transient
+	// failure reading a referenced Guard or patch ConfigMap is retried with
+	// backoff, since no watch event would otherwise bring us back.
+	if patchErr == nil && isTransientPhaseError(err) {
+		return result, err
+	}
+	return result, patchErr
+}
+
+// configFailureReason maps a config generation error to its condition reason.
+func configFailureReason(err error) string {
+	pe, ok := stderrors.AsType[*litellm.PhaseError](err)
+	if !ok {
+		return ReasonConfigGenerationFailed
+	}
+	switch pe.Phase {
+	case "Guardrails":
+		return ReasonGuardrailsResolutionFailed
+	case "ConfigPatch":
+		return ReasonConfigPatchInvalid
+	case litellm.LogLevelPhase:
+		return ReasonLogLevelInvalid
+	case litellm.RolloutStrategyPhase:
+		return ReasonRolloutStrategyInvalid
+	case litellm.UpstreamPhase:
+		return ReasonUpstreamInvalid
+	case litellm.ModelDiscoveryPhase:
+		return ReasonModelDiscoveryFailed
+	case litellm.ManagedCachePhase:
+		return ReasonManagedCacheInvalid
+	case litellm.DatabasePhase:
+		return ReasonDatabaseInvalid
+	case litellm.PassThroughPhase:
+		return ReasonPassThroughResolutionFailed
+	case litellm.AdminUIPhase:
+		return ReasonAdminUIInvalid
+	case litellm.ConfigHistoryPhase:
+		return ReasonConfigHistoryInvalid
+	case litellm.GatewayTemplatePhase:
+		return ReasonGatewayTemplateInvalid
+	case litellm.AlertingPhase:
+		return ReasonAlertingInvalid
+	case litellm.FlaggerPhase:
+		return ReasonFlaggerInvalid
+	case litellm.HostnamePhase:
+		return ReasonHostnameInvalid
+	case litellm.OTelCollectorPhase:
+		return ReasonOTelCollectorInvalid
+	case litellm.LangfusePhase:
+		return ReasonLangfuseInvalid
+	case litellm.VeleroPhase:
+		return ReasonVeleroInvalid
+	case litellm.ImagePolicyPhase:
+		return ReasonImagePolicyInvalid
+	case litellm.ClassConfigPhase:
+		return ReasonClassConfigInvalid
+	case litellm.InferencePoolPhase:
+		return ReasonInferencePoolInvalid
+	case litellm.EgressProxyPhase:
+		return ReasonEgressProxyInvalid
+	case litellm.PreCallChecksPhase:
+		return ReasonPreCallChecksInvalid
+	case litellm.CORSPhase:
+		return ReasonCORSInvalid
+	}
+	return ReasonConfigGenerationFailed
+}
+
+// gatewayEnv resolves the objects the env vars of the gateway refer to and
+// builds them.
+func (r *AiGatewayReconciler) gatewayEnv(ctx context.Context, aiGateway *gatewayv1alpha1.AiGateway, plan *aiGatewayPlan) ([]corev1.EnvVar, error) {
+	log := logf.FromContext(ctx)
+	passThrough, err := passThroughEndpoints(ctx, r, aiGateway)
+	if err != nil {
+		log.Error(err, "Failed to resolve pass-through endpoints")
+		return nil, err
+	}
+	// The annotations were validated during config generation, unless a
+	// rollback skipped it; an invalid project then just logs nothing.
+	langfuse, err := litellm.ResolveLangfuse(ctx, r, aiGateway, ControllerName)
+	if _, invalid := stderrors.AsType[*litellm.PhaseError](err); err != nil && !invalid {
+		log.Error(err, "Failed to resolve the Langfuse project")
+		return nil, err
+	}
+	return r.buildEnvironmentVariables(aiGateway, passThrough, langfuse, plan.classConfig, plan.adminUI), nil
+}
+
+// gatewayWorkload assembles the workload of the gateway from plan. It also
+// returns when the image policy wants to look for a newer image.
+func (r *AiGatewayReconciler) gatewayWorkload(ctx context.Context, aiGateway *gatewayv1alpha1.AiGateway, plan *aiGatewayPlan, env []corev1.EnvVar) (litellm.GatewayWorkload, time.Duration) {
+	if plan.inferencePool != nil {
+		for _, model := range aiGateway.Spec.AiModels {
+			if !slices.Contains(plan.inferencePool.Models, model.Name) {
+				plan.inferencePool.Models = append(plan.inferencePool.Models, model.Name)
 			}
 		}
-		log.Error(err, "Failed to reconcile workload")
-		r.updateCondition(&aiGateway, AiGatewayConfigured, metav1.ConditionFalse, reason, err.Error())
-		r.updateCondition(&aiGateway, AiGatewayReady, metav1.ConditionFalse, reason, err.Error())
-		if e := r.patchStatus(ctx, original, &aiGateway); e != nil {
-			return ctrl.Result{}, e
+	}
+	defaultImage := r.image()
+	if plan.classConfig != nil {
+		defaultImage = cmp.Or(plan.classConfig.Image, defaultImage)
+	}
+	image, imageRequeue := r.resolveImage(ctx, aiGateway, plan.imagePolicy, defaultImage)
+	workload := litellm.GatewayWorkload{
+		Name:              aiGateway.Name,
+		Namespace:         aiGateway.Namespace,
+		Owner:             aiGateway,
+		ContainerPort:     aiGateway.Spec.Port,
+		ServicePort:       aiGateway.Spec.Port,
+		Env:               env,
+		EnvFrom:           aiGateway.Spec.EnvFrom,
+		CommonMetadata:    aiGateway.Spec.CommonMetadata,
+		PodMetadata:       aiGateway.Spec.PodMetadata,
+		ConfigYAML:        plan.configData,
+		LogLevel:          plan.logLevel,
+		DryRun:            r.DryRun,
+		APIReader:         r.APIReader,
+		BlueGreen:         plan.blueGreen,
+		ArgoRollout:       plan.argoRollout,
+		Flagger:           plan.flagger,
+		Hostname:          plan.hostname,
+		OTelCollector:     plan.collector,
+		Velero:            plan.velero,
+		Image:             cmp.Or(image, defaultImage),
+		RegistryMirror:    r.RegistryMirror,
+		InferencePool:     plan.inferencePool,
+		ClusterAutoscaler: r.ClusterAutoscaler,
+		GatewayTemplate:   plan.template,
+		Suspended:         plan.suspended || plan.restoring,
+	}
+	if plan.classConfig != nil {
+		workload.Resources = plan.classConfig.Resources
+	}
+	if plan.suspended || plan.restoring {
+		workload.ClusterAutoscaler = nil
+	}
+	if plan.velero {
+		workload.CommonMetadata = litellm.VeleroCommonMetadata(workload.CommonMetadata)
+	}
+	return workload, imageRequeue
+}
+
+// requireCapabilities checks the cluster serves the APIs plan needs. It
+// returns the missing ones, and among them those the workload cannot be
+// applied without.
+func (r *AiGatewayReconciler) requireCapabilities(aiGateway *gatewayv1alpha1.AiGateway, plan *aiGatewayPlan) (missing, blocking []litellm.Capability) {
+	// Its annotations were validated during config generation.
+	database, _ := litellm.ParseDatabase(aiGateway)
+	missing = r.checkCapabilities(aiGateway, requiredCapabilities(plan.argoRollout, plan.flagger, database, plan.inferencePool))
+	return missing, missingWorkloadCapabilities(missing)
+}
+
+// appliedWorkload is what applying the workload of a gateway reports about
+// the strategy that rolls it out. Both are nil for a rolling update.
+type appliedWorkload struct {
+	blueGreen *litellm.BlueGreenStatus
+	rollout   *appsv1.Deployment
+}
+
+// applyWorkload writes the objects of the gateway: the provisioned database
+// and managed Redis first, so new LiteLLM pods find them, then the workload
+// and its companions. Objects whose API is missing are skipped.
+func (r *AiGatewayReconciler) applyWorkload(ctx context.Context, aiGateway *gatewayv1alpha1.AiGateway, plan *aiGatewayPlan, workload litellm.GatewayWorkload, missing []litellm.Capability) (appliedWorkload, error) {
+	var applied appliedWorkload
+	// The annotations were validated during config generation.
+	database, _ := litellm.ParseDatabase(aiGateway)
+	err := litellm.ReconcileDatabase(ctx, r.Client, r.Scheme, workload, database)
+	if err == nil {
+		managedCache, _ := litellm.ManagedCache(aiGateway.Annotations)
+		err = litellm.ReconcileManagedCache(ctx, r.Client, r.Scheme, workload, managedCache)
+	}
+	if err == nil {
+		err = litellm.ReconcileAlerting(ctx, r.Client, r.Scheme, workload, r.alertReceiverURL(aiGateway))
+	}
+	if err == nil {
+		switch {
+		case plan.blueGreen != nil:
+			applied.blueGreen, err = litellm.ReconcileBlueGreenWorkload(ctx, r.Client, r.Scheme, workload)
+		case plan.argoRollout != nil:
+			applied.rollout, err = litellm.ReconcileArgoRolloutWorkload(ctx, r.Client, r.Scheme, workload)
+		default:
+			err = litellm.ReconcileWorkload(ctx, r.Client, r.Scheme, workload)
 		}
-		// All ReconcileWorkload phases (ConfigMap / Secret / Deployment / Service)
-		// are apiserver calls — surface the error so controller-runtime requeues
-		// with exponential backoff. Permanent config-generation errors are handled
-		// in the generateAiGatewayConfig branch above.
-		return ctrl.Result{}, err
 	}
+	if err == nil {
+		err = litellm.ReconcileAdminUI(ctx, r.Client, r.Scheme, workload, plan.adminUI)
+	}
+	if err == nil {
+		err = litellm.ReconcileOverprovisioning(ctx, r.Client, r.Scheme, workload)
+	}
+	if err == nil && !slices.Contains(missing, litellm.CapabilityFlagger) {
+		err = litellm.ReconcileFlagger(ctx, r.Client, r.Scheme, workload)
+	}
+	if err == nil && !slices.Contains(missing, litellm.CapabilityInferencePool) {
+		err = litellm.ReconcileInferencePool(ctx, r.Client, r.Scheme, workload)
+	}
+	return applied, err
+}
+
+// workloadFailed reports a workload that could not be applied on
+// AiGatewayConfigured and AiGatewayReady. All ReconcileWorkload phases
+// (ConfigMap / Secret / Deployment / Service) are apiserver calls, so the
+// error is surfaced for controller-runtime to requeue with exponential
+// backoff. Permanent config-generation errors are handled by configFailed.
+func (r *AiGatewayReconciler) workloadFailed(ctx context.Context, original, aiGateway *gatewayv1alpha1.AiGateway, err error) (ctrl.Result, error) {
+	reason := workloadFailureReason(err)
+	logf.FromContext(ctx).Error(err, "Failed to reconcile workload")
+	r.updateCondition(aiGateway, AiGatewayConfigured, metav1.ConditionFalse, reason, err.Error())
+	r.updateCondition(aiGateway, AiGatewayReady, metav1.ConditionFalse, reason, err.Error())
+	return r.patchStatusAndRetry(ctx, original, aiGateway, err)
+}
+
+// workloadFailureReason maps a workload error to its condition reason. Add a
+// case here whenever a new PhaseError.Phase is introduced in
+// internal/litellm. Unrecognized phases fall through to "WorkloadFailed" —
+// degraded but never silent.
+func workloadFailureReason(err error) string {
+	if _, ok := stderrors.AsType[*litellm.ConflictError](err); ok {
+		return ReasonResourceConflict
+	}
+	pe, ok := stderrors.AsType[*litellm.PhaseError](err)
+	if !ok {
+		return "WorkloadFailed"
+	}
+	switch pe.Phase {
+	case "ConfigMap":
+		return "ConfigMapFailed"
+	case "Secret":
+		return "SecretFailed"
+	case "Deployment":
+		return "DeploymentFailed"
+	case "Service":
+		return "ServiceFailed"
+	case litellm.ManagedCachePhase:
+		return ReasonManagedCacheFailed
+	case litellm.DatabasePhase:
+		return ReasonDatabaseProvisioningFailed
+	case litellm.AdminUIPhase:
+		return ReasonAdminUIFailed
+	case litellm.AlertingPhase:
+		return ReasonAlertingFailed
+	case litellm.ArgoRolloutsPhase:
+		return ReasonArgoRolloutsFailed
+	case litellm.FlaggerPhase:
+		return ReasonFlaggerFailed
+	case litellm.InferencePoolPhase:
+		return ReasonInferencePoolFailed
+	case litellm.OverprovisioningPhase:
+		return ReasonOverprovisioningFailed
+	}
+	return "WorkloadFailed"
+}
+
+// syncCompanions reconciles what runs next to an applied workload: backups,
+// usage reports and replicas in remote clusters.
+func (r *AiGatewayReconciler) syncCompanions(ctx context.Context, aiGateway *gatewayv1alpha1.AiGateway, workload litellm.GatewayWorkload) error {
+	if err := r.syncBackup(ctx, aiGateway, workload); err != nil {
+		return err
+	}
+	if err := r.syncUsageReport(ctx, aiGateway, workload); err != nil {
+		return err
+	}
+	return r.syncReplicas(ctx, aiGateway, workload)
+}
 
-	r.updateCondition(&aiGateway, AiGatewayConfigured, metav1.ConditionTrue,
-		ReasonConfigurationApplied, "AiGateway configuration successfully applied")
+// rolloutState is how far the workload of a gateway is rolled out.
+type rolloutState struct {
+	// serving is the Deployment traffic goes to.
+	serving   *appsv1.Deployment
+	rolledOut bool
+	// message explains a rollout still in progress.
+	message string
+	requeue time.Duration
+}
 
-	// Ready reflects pod-level availability, not just "we created the API objects".
-	// The Owns(&appsv1.Deployment{}) watch re-fires Reconcile when the deployment-
-	// controller publishes status changes, so we don't need a manual requeue.
+// syncRollout stamps AiGatewayProgressing and finds the Deployment serving
+// traffic. Ready reflects pod-level availability, not just "we created the
+// API objects". The Owns(&appsv1.Deployment{}) watch re-fires Reconcile when
+// the deployment-controller publishes status changes, so we don't need a
+// manual requeue. With blue/green, Ready follows the color the Service routes
+// to and Progressing the color being brought up. With Argo Rollouts, both
+// follow the Rollout, which is not watched since its CRD may be missing. With
+// Flagger, Ready follows the primary once the Canary created it, and
+// Progressing the gateway's Deployment, which Flagger analyses.
+func (r *AiGatewayReconciler) syncRollout(ctx context.Context, aiGateway *gatewayv1alpha1.AiGateway, plan *aiGatewayPlan, workload litellm.GatewayWorkload, applied appliedWorkload) (rolloutState, error) {
+	log := logf.FromContext(ctx)
+	var state rolloutState
 	deployment := &appsv1.Deployment{}
-	if err := r.Get(ctx, types.NamespacedName{Namespace: aiGateway.Namespace, Name: aiGateway.Name}, deployment); err != nil {
+	progressing := deployment
+	if applied.blueGreen != nil {
+		deployment, progressing = applied.blueGreen.Serving, applied.blueGreen.Pending
+		if deployment == nil {
+			deployment = progressing
+		} else if progressing == nil {
+			progressing = deployment
+		}
+		state.requeue = applied.blueGreen.RequeueAfter
+	} else if applied.rollout != nil {
+		deployment, progressing = applied.rollout, applied.rollout
+	} else if err := r.Get(ctx, types.NamespacedName{Namespace: aiGateway.Namespace, Name: aiGateway.Name}, deployment); err != nil {
 		log.Error(err, "Failed to get Deployment for rollout check")
-		return ctrl.Result{}, err
+		return state, err
+	}
+	var primary *appsv1.Deployment
+	if plan.flagger != nil {
+		var err error
+		if primary, err = litellm.FlaggerPrimary(ctx, r.Client, workload); err != nil {
+			log.Error(err, "Failed to get Flagger primary Deployment")
+			return state, err
+		}
+		if primary != nil {
+			deployment = primary
+		}
+	}
+	status, reason, msg := progressingCondition(progressing)
+	r.updateCondition(aiGateway, AiGatewayProgressing, status, reason, msg)
+
+	state.serving = deployment
+	state.rolledOut, state.message = litellm.IsDeploymentRolledOut(deployment)
+	if (applied.rollout != nil || primary != nil) && !state.rolledOut {
+		state.requeue = minRequeue(state.requeue, rolloutPollInterval)
+	}
+	if !state.rolledOut {
+		return state, nil
 	}
-	if rolledOut, msg := litellm.IsDeploymentRolledOut(deployment); rolledOut {
-		r.updateCondition(&aiGateway, AiGatewayReady, metav1.ConditionTrue,
-			ReasonAiGatewayReady, "AiGateway is ready and serving traffic")
+	// Once a rolling update is live, the Deployments of an earlier
+	// blue/green rollout are no longer selected by the Service.
+	if applied.blueGreen == nil {
+		if err := litellm.DeleteBlueGreenWorkload(ctx, r.Client, workload); err != nil {
+			log.Error(err, "Failed to delete blue/green Deployments")
+			return state, err
+		}
+	}
+	if applied.rollout == nil {
+		if err := litellm.DeleteArgoRollout(ctx, r.Client, workload); err != nil {
+			log.Error(err, "Failed to delete Argo Rollout")
+			return state, err
+		}
+	}
+	return state, nil
+}
+
+// syncReady stamps AiGatewayReady and AiGatewaySuspended from the rollout.
+// Once the gateway serves traffic, it records the config snapshot and
+// probes health and spend. Returns when the next probe is due.
+func (r *AiGatewayReconciler) syncReady(ctx context.Context, aiGateway *gatewayv1alpha1.AiGateway, plan *aiGatewayPlan, workload litellm.GatewayWorkload, env []corev1.EnvVar, rollout rolloutState) (time.Duration, error) {
+	if plan.suspended {
+		r.updateCondition(aiGateway, AiGatewaySuspended, metav1.ConditionTrue, ReasonSuspended,
+			"Deployment "+aiGateway.Name+" is scaled to zero replicas; remove the suspend annotation to resume")
 	} else {
-		r.updateCondition(&aiGateway, AiGatewayReady, metav1.ConditionFalse,
-			ReasonAiGatewayRollingOut, msg)
+		apimeta.RemoveStatusCondition(&aiGateway.Status.Conditions, AiGatewaySuspended)
+	}
+	switch {
+	case !rollout.rolledOut:
+		r.updateCondition(aiGateway, AiGatewayReady, metav1.ConditionFalse,
+			ReasonAiGatewayRollingOut, rollout.message)
+		return 0, nil
+	case plan.suspended:
+		r.updateCondition(aiGateway, AiGatewayReady, metav1.ConditionFalse, ReasonSuspended,
+			"AiGateway is suspended and serves no traffic")
+		return 0, nil
+	case plan.restoring:
+		r.updateCondition(aiGateway, AiGatewayReady, metav1.ConditionFalse, ReasonRestoreRunning,
+			"AiGateway is scaled to zero while a backup is restored into its database")
+		return 0, nil
+	}
+	// A config is only kept as a snapshot once it serves traffic.
+	if litellm.DeployedConfigHash(rollout.serving) == litellm.ConfigHash(plan.configData) {
+		if err := litellm.ReconcileConfigSnapshots(ctx, r.Client, r.Scheme, workload, plan.history.Keep); err != nil {
+			logf.FromContext(ctx).Error(err, "Failed to reconcile config snapshots")
+			return 0, err
+		}
+	}
+	r.updateCondition(aiGateway, AiGatewayReady, metav1.ConditionTrue,
+		ReasonAiGatewayReady, "AiGateway is ready and serving traffic")
+	// Only probe /health once pods are serving; before that the probe
+	// would just report connection errors.
+	var requeue time.Duration
+	if r.HealthCheckInterval > 0 {
+		requeue = minRequeue(requeue, r.syncHealth(ctx, aiGateway, env))
 	}
+	if r.SpendSyncInterval > 0 {
+		databaseEnv, err := litellm.ResolveGatewayEnv(ctx, r, aiGateway)
+		if err != nil {
+			return 0, err
+		}
+		if litellm.DatabaseModeEnabled(databaseEnv) {
+			requeue = minRequeue(requeue, r.syncSpend(ctx, aiGateway, env))
+		}
+	}
+	return requeue, nil
+}
 
-	log.Info("Successfully reconciled AiGateway", "name", aiGateway.Name,
-		"aiModels", len(aiGateway.Spec.AiModels))
+// syncServing warms up a gateway whose config went live, publishes its
+// hostname and syncs its alerting. Returns when the next alert expires.
+func (r *AiGatewayReconciler) syncServing(ctx context.Context, aiGateway *gatewayv1alpha1.AiGateway, plan *aiGatewayPlan, workload litellm.GatewayWorkload, env []corev1.EnvVar, rollout rolloutState) (time.Duration, error) {
+	live := rollout.rolledOut && !plan.suspended && !plan.restoring &&
+		litellm.DeployedConfigHash(rollout.serving) == litellm.ConfigHash(plan.configData)
+	if err := r.syncWarmUp(ctx, aiGateway, workload, env, live); err != nil {
+		return 0, err
+	}
+	if plan.hostname != "" {
+		r.updateCondition(aiGateway, AiGatewayHostname, metav1.ConditionTrue, ReasonHostnamePublished,
+			"Service "+aiGateway.Name+" is published as "+plan.hostname+" through external-dns")
+	} else {
+		apimeta.RemoveStatusCondition(&aiGateway.Status.Conditions, AiGatewayHostname)
+	}
+	return r.syncAlerting(ctx, aiGateway, workload)
+}
 
-	if err := r.patchStatus(ctx, original, &aiGateway); err != nil {
+// resyncAfter returns when the gateway is reconciled again without an event:
+// after its resync interval, or when a maintenance window opens or closes,
+// whichever comes first.
+func (r *AiGatewayReconciler) resyncAfter(ctx context.Context, aiGateway *gatewayv1alpha1.AiGateway) time.Duration {
+	log := logf.FromContext(ctx)
+	resync, err := litellm.ParseResyncInterval(aiGateway.Annotations, r.ResyncInterval)
+	if err != nil {
+		log.Error(err, "Ignoring resync-interval annotation")
+	}
+	// Re-render when a maintenance window opens or closes.
+	if _, next, err := openMaintenanceWindows(ctx, r, aiGateway.Namespace, aiGateway.Name, time.Now()); err != nil {
+		log.Error(err, "Failed to list maintenance windows")
+	} else if !next.IsZero() {
+		resync = minRequeue(resync, time.Until(next))
+	}
+	return resync
+}
+
+// reconcileRenderOnly writes the manifests of workload to the rendered
+// ConfigMap instead of applying them. Objects applied before the gateway
+// switched to render-only are left running as they are.
+func (r *AiGatewayReconciler) reconcileRenderOnly(ctx context.Context, original, aiGateway *gatewayv1alpha1.AiGateway, workload litellm.GatewayWorkload) (ctrl.Result, error) {
+	name := litellm.RenderedConfigMapName(aiGateway.Name)
+	if err := litellm.ReconcileRenderOnly(ctx, r.Client, r.Scheme, workload); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to render manifests")
+		r.updateCondition(aiGateway, AiGatewayConfigured, metav1.ConditionFalse, "RenderFailed", err.Error())
+		if e := r.patchStatus(ctx, original, aiGateway); e != nil {
+			return ctrl.Result{}, e
+		}
 		return ctrl.Result{}, err
 	}
-	return ctrl.Result{}, nil
+	r.updateCondition(aiGateway, AiGatewayConfigured, metav1.ConditionTrue, ReasonManifestsRendered,
+		fmt.Sprintf("Manifests rendered to ConfigMap %s", name))
+	r.updateCondition(aiGateway, AiGatewayReady, metav1.ConditionFalse, ReasonRenderOnly,
+		fmt.Sprintf("Render-only mode: manifests are written to ConfigMap %s and not applied", name))
+	apimeta.RemoveStatusCondition(&aiGateway.Status.Conditions, AiGatewayProgressing)
+	return ctrl.Result{}, r.patchStatus(ctx, original, aiGateway)
 }
 
-// generateAiGatewayConfig renders the LiteLLM config for aiGateway, optionally
-// layering a user-supplied patch on top. Returns *litellm.PhaseError tagged
-// with the failing phase. The Reconcile config-failure branch maps "Guardrails"
-// and "ConfigPatch" to dedicated reasons; all other phases (e.g. "ConfigRender")
-// fall through to ReasonConfigGenerationFailed.
-func (r *AiGatewayReconciler) generateAiGatewayConfig(ctx context.Context, aiGateway *gatewayv1alpha1.AiGateway) (string, error) {
+// GenerateAiGatewayConfig renders the LiteLLM config for aiGateway, optionally
+// layering a user-supplied patch on top. Referenced objects (Guards, budgets,
+// the patch ConfigMap, an upstream gateway) are read through c, discovered
+// model server Services through modelServers; nil disables discovery. Returns
+// *litellm.PhaseError tagged with the failing phase, which
+// configFailureReason maps to a condition reason; unknown phases (e.g.
+// "ConfigRender") fall through to ReasonConfigGenerationFailed.
+func GenerateAiGatewayConfig(ctx context.Context, c, modelServers client.Reader, aiGateway *gatewayv1alpha1.AiGateway) (string, error) {
+	return generateAiGatewayConfig(ctx, c, modelServers, aiGateway, litellm.DefaultRequestTimeout)
+}
+
+// generateAiGatewayConfig is GenerateAiGatewayConfig with the request_timeout
+// of the operator, in seconds.
+func generateAiGatewayConfig(ctx context.Context, c, modelServers client.Reader, aiGateway *gatewayv1alpha1.AiGateway, requestTimeout int) (string, error) {
 
 	log := logf.FromContext(ctx)
 
-	// Build model list with proper provider prefixes and environment variable API keys
-	modelList := make([]litellm.ModelConfig, len(aiGateway.Spec.AiModels))
-	for i, model := range aiGateway.Spec.AiModels {
-		modelList[i] = litellm.ModelConfig{
-			ModelName: model.Name,
-			LiteLLMParams: litellm.LiteLLMParams{
-				Model:  fmt.Sprintf("%s/%s", model.Provider, model.Name),
-				ApiKey: fmt.Sprintf("os.environ/%s", r.getProviderApiKeyEnvVar(model)),
-			},
+	budgets, err := effectiveBudgets(ctx, c, aiGateway.Namespace, aiGateway.Name)
+	if err != nil {
+		return "", err
+	}
+
+	rateLimits, err := effectiveRateLimits(ctx, c, aiGateway.Namespace, aiGateway.Name)
+	if err != nil {
+		return "", err
+	}
+
+	upstream, err := litellm.ResolveUpstream(ctx, c, aiGateway, ControllerName)
+	if err != nil {
+		return "", err
+	}
+
+	maintenance, _, err := openMaintenanceWindows(ctx, c, aiGateway.Namespace, aiGateway.Name, time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	// Build model list with proper provider prefixes and environment variable API keys,
+	// or route every model through the upstream gateway when one is set. Models
+	// in an open maintenance window are left out.
+	modelList := make([]litellm.ModelConfig, 0, len(aiGateway.Spec.AiModels))
+	for _, model := range aiGateway.Spec.AiModels {
+		if inMaintenance(model, maintenance) {
+			log.Info("Model in maintenance window, leaving it out of config", "model", model.Name)
+			continue
+		}
+		var entry litellm.ModelConfig
+		if upstream != nil {
+			entry = litellm.UpstreamModel(model, upstream)
+		} else {
+			entry = litellm.ModelConfig{
+				ModelName: model.Name,
+				LiteLLMParams: litellm.LiteLLMParams{
+					Model:  fmt.Sprintf("%s/%s", model.Provider, model.Name),
+					ApiKey: fmt.Sprintf("os.environ/%s", providerApiKeyEnvVar(model)),
+				},
+			}
 		}
+		entry.LiteLLMParams.MaxBudget, entry.LiteLLMParams.BudgetDuration = budgetLimits(
+			budgets[litellmv1alpha1.BudgetScope{Type: litellmv1alpha1.BudgetScopeModel, Model: model.Name}])
+		limits := rateLimits[litellmv1alpha1.RateLimitScope{Type: litellmv1alpha1.RateLimitScopeModel, Model: model.Name}]
+		entry.LiteLLMParams.RPM = limits.RPM
+		entry.LiteLLMParams.TPM = limits.TPM
+		entry.LiteLLMParams.MaxParallelRequests = limits.MaxParallelRequests
+		modelList = append(modelList, entry)
+	}
+
+	if modelServers != nil {
+		discovered, err := discoverModels(ctx, modelServers, aiGateway)
+		if err != nil {
+			return "", err
+		}
+		modelList = append(modelList, discovered...)
+	}
+
+	// Resolve guardrails from referenced Guard and GuardrailProvider resources
+	guardrails, err := litellm.ResolveGuardrails(ctx, c, aiGateway.Namespace, aiGateway.Spec.Guardrails, litellm.GuardrailTargetLLM)
+	if err != nil {
+		return "", &litellm.PhaseError{Phase: "Guardrails", Err: err}
+	}
+
+	passThrough, err := passThroughEndpoints(ctx, c, aiGateway)
+	if err != nil {
+		return "", err
+	}
+
+	config := litellm.LiteLLMConfig{
+		ModelList: modelList,
+		LiteLLMSettings: litellm.LiteLLMSettings{
+			RequestTimeout: requestTimeout,
+			// 'callbacks: ["otel"]' is required to send traces to otel after handling incoming requests
+			// (see https://docs.litellm.ai/docs/proxy/logging#opentelemetry)
+			Callbacks: []string{"otel", "prometheus"},
+		},
+		Guardrails: guardrails,
+	}
+	config.LiteLLMSettings.MaxBudget, config.LiteLLMSettings.BudgetDuration = budgetLimits(
+		budgets[litellmv1alpha1.BudgetScope{Type: litellmv1alpha1.BudgetScopeGateway}])
+	adminUI, err := litellm.ResolveAdminUI(ctx, c, aiGateway)
+	if err != nil {
+		return "", err
+	}
+	alerting, err := litellm.Alerting(aiGateway.Annotations)
+	if err != nil {
+		return "", err
+	}
+	alertingSettings, err := litellm.ParseAlertingSettings(aiGateway.Annotations)
+	if err != nil {
+		return "", err
+	}
+	alertDestinations := alertingSettings.Destinations(alerting)
+	langfuse, err := litellm.ResolveLangfuse(ctx, c, aiGateway, ControllerName)
+	if err != nil {
+		return "", err
+	}
+	if langfuse != nil {
+		config.LiteLLMSettings.SuccessCallback = []string{litellm.LangfuseCallback}
+		config.LiteLLMSettings.FailureCallback = []string{litellm.LangfuseCallback}
+		config.LiteLLMSettings.LangfuseDefaultTags = litellm.LangfuseDefaultTags
+	}
+	config.GeneralSettings = generalSettings(passThrough, adminUI, alertDestinations, alertingSettings)
+
+	if _, err := litellm.ParseDatabase(aiGateway); err != nil {
+		return "", err
+	}
+	managedCache, err := litellm.ManagedCache(aiGateway.Annotations)
+	if err != nil {
+		return "", err
+	}
+	if managedCache {
+		config.LiteLLMSettings.Cache = true
+		config.LiteLLMSettings.CacheParams = litellm.RedisCacheSettings(aiGateway.Name, aiGateway.Namespace)
 	}
 
-	// Resolve guardrails from referenced Guard and GuardrailProvider resources
-	guardrails, err := litellm.ResolveGuardrails(ctx, r, aiGateway.Namespace, aiGateway.Spec.Guardrails, litellm.GuardrailTargetLLM)
+	// Fallbacks may name models in a maintenance window: LiteLLM skips
+	// fallbacks it has no deployment for.
+	modelNames := make([]string, 0, len(aiGateway.Spec.AiModels)+len(modelList))
+	for _, model := range aiGateway.Spec.AiModels {
+		modelNames = append(modelNames, model.Name)
+	}
+	for _, model := range modelList {
+		modelNames = append(modelNames, model.ModelName)
+	}
+	config.RouterSettings, err = litellm.ParseRouterSettings(aiGateway.Annotations, modelNames)
 	if err != nil {
-		return "", &litellm.PhaseError{Phase: "Guardrails", Err: err}
+		return "", err
 	}
 
-	config := litellm.LiteLLMConfig{
-		ModelList: modelList,
-		LiteLLMSettings: litellm.LiteLLMSettings{
-			RequestTimeout: litellm.DefaultRequestTimeout,
-			// 'callbacks: ["otel"]' is required to send traces to otel after handling incoming requests
-			// (see https://docs.litellm.ai/docs/proxy/logging#opentelemetry)
-			Callbacks: []string{"otel", "prometheus"},
-		},
-		Guardrails: guardrails,
+	// The class config is layered onto the generated config before the
+	// gateway's own patch, which wins on conflicts.
+	classConfig, err := litellm.ResolveClassConfig(ctx, c, aiGateway, ControllerName)
+	if err != nil {
+		return "", err
 	}
+	classPatch := litellm.ApplyClassConfig(&config, classConfig)
 
-	patch, err := litellm.LoadPatch(ctx, r.Client, aiGateway.Namespace, aiGateway.Annotations[litellm.ConfigPatchAnnotation])
+	patch, err := litellm.LoadPatch(ctx, c, aiGateway.Namespace, aiGateway.Annotations[litellm.ConfigPatchAnnotation])
 	if err != nil {
 		return "", err
 	}
 
-	configYAML, err := litellm.RenderConfigWithPatch(config, patch)
+	configYAML, err := litellm.RenderConfigWithPatch(config, classPatch, patch)
 	if err != nil {
 		return "", &litellm.PhaseError{Phase: "ConfigRender", Err: err}
 	}
 
 	log.Info("Generated LiteLLM configuration",
 		"aiGateway", aiGateway.Name,
-		"models", len(aiGateway.Spec.AiModels),
+		"models", len(modelList),
 		"guardrails", len(guardrails),
+		"budgets", len(budgets),
+		"passThroughEndpoints", len(passThrough),
+		"upstream", upstream != nil,
+		"classConfig", classConfig != nil,
 		"patched", patch != nil,
 	)
 
 	return configYAML, nil
 }
 
-func (r *AiGatewayReconciler) getProviderApiKeyEnvVar(model gatewayv1alpha1.AiModel) string {
+// generalSettings returns the general_settings block of the pass-through
+// endpoints, admin UI and alerting of a gateway, or nil when it has none.
+func generalSettings(passThrough []litellmv1alpha1.LiteLLMPassThroughEndpoint, adminUI *litellm.AdminUI, alertDestinations []string, alerting litellm.AlertingSettings) *litellm.GeneralSettings {
+	endpoints := passThroughConfig(passThrough)
+	if endpoints == nil && adminUI == nil && len(alertDestinations) == 0 {
+		return nil
+	}
+	settings := &litellm.GeneralSettings{
+		PassThroughEndpoints: endpoints,
+		StoreModelInDB:       adminUI != nil,
+	}
+	if len(alertDestinations) > 0 {
+		settings.Alerting = alertDestinations
+		settings.AlertTypes = litellm.AlertTypes
+		if len(alerting.AlertTypes) > 0 {
+			settings.AlertTypes = alerting.AlertTypes
+		}
+		settings.AlertingThreshold = alerting.Threshold
+	}
+	return settings
+}
+
+// discoverModels returns the model_list entries of the model server Services
+// in aiGateway's namespace selected by its litellm.ModelDiscoveryAnnotation.
+// An invalid selector is a *litellm.PhaseError tagged
+// litellm.ModelDiscoveryPhase; Services with unusable annotations are logged
+// and skipped so one bad Service does not stall every gateway.
+func discoverModels(ctx context.Context, c client.Reader, aiGateway *gatewayv1alpha1.AiGateway) ([]litellm.ModelConfig, error) {
+	selector, ok, err := litellm.ModelDiscoverySelector(aiGateway.Annotations)
+	if err != nil {
+		return nil, &litellm.PhaseError{Phase: litellm.ModelDiscoveryPhase, Err: err}
+	}
+	if !ok {
+		return nil, nil
+	}
+	requirements, _ := litellm.ModelServerSelector().Requirements()
+	var services corev1.ServiceList
+	if err := c.List(ctx, &services,
+		client.InNamespace(aiGateway.Namespace),
+		client.MatchingLabelsSelector{Selector: selector.Add(requirements...)},
+	); err != nil {
+		return nil, &litellm.PhaseError{Phase: litellm.ModelDiscoveryPhase, Err: err}
+	}
+	slices.SortFunc(services.Items, func(a, b corev1.Service) int { return strings.Compare(a.Name, b.Name) })
+
+	own := make([]string, len(aiGateway.Spec.AiModels))
+	for i, model := range aiGateway.Spec.AiModels {
+		own[i] = model.Name
+	}
+	models, problems := litellm.DiscoveredModels(services.Items, own)
+	for _, problem := range problems {
+		logf.FromContext(ctx).Info("Skipping model server", "problem", problem)
+	}
+	return models, nil
+}
+
+func providerApiKeyEnvVar(model gatewayv1alpha1.AiModel) string {
 	return strings.ToUpper(model.Provider) + "_API_KEY"
 }
 
-// buildEnvironmentVariables creates environment variables for the deployment
-func (r *AiGatewayReconciler) buildEnvironmentVariables(aiGateway *gatewayv1alpha1.AiGateway) []corev1.EnvVar {
+// buildEnvironmentVariables creates environment variables for the deployment.
+// adminUI is the parsed litellm.AdminUIAnnotation, nil without an admin UI.
+func (r *AiGatewayReconciler) buildEnvironmentVariables(aiGateway *gatewayv1alpha1.AiGateway, passThrough []litellmv1alpha1.LiteLLMPassThroughEndpoint, langfuse *litellm.Langfuse, classConfig *litellmv1alpha1.LiteLLMClassConfigSpec, adminUI *litellm.AdminUI) []corev1.EnvVar {
 	envMap := make(map[string]corev1.EnvVar, len(aiGateway.Spec.Env)+len(aiGateway.Spec.AiModels))
 
-	// Generated API-key env vars first; user spec.env wins on conflict.
-	r.generateApiKeyEnvVars(aiGateway, envMap)
-	for _, e := range aiGateway.Spec.Env {
+	// Generated API-key env vars first; user spec.env wins on conflict. A
+	// gateway with an upstream only needs the key for that upstream.
+	if _, ok := litellm.UpstreamRef(aiGateway); ok {
+		upstreamKey := litellm.UpstreamKeyEnvVar(aiGateway)
+		envMap[upstreamKey.Name] = upstreamKey
+	} else {
+		r.generateApiKeyEnvVars(aiGateway, r.apiKeySecretName(classConfig), envMap)
+	}
+	if managedCache, _ := litellm.ManagedCache(aiGateway.Annotations); managedCache {
+		envMap[litellm.RedisPasswordKey] = litellm.RedisPasswordEnvVar(aiGateway.Name)
+	}
+	for _, e := range passThroughEnvVars(passThrough) {
+		envMap[e.Name] = e
+	}
+	for _, e := range litellm.AdminUIEnvVars(adminUI) {
+		envMap[e.Name] = e
+	}
+	if alerting, _ := litellm.Alerting(aiGateway.Annotations); alerting {
+		envMap[litellm.WebhookURLKey] = litellm.AlertingEnvVar(aiGateway.Name)
+	}
+	alertingSettings, _ := litellm.ParseAlertingSettings(aiGateway.Annotations)
+	for _, e := range alertingSettings.EnvVars() {
+		envMap[e.Name] = e
+	}
+	collector, _ := litellm.ParseOTelCollector(aiGateway.Annotations, r.OTLPEndpoint)
+	for _, e := range litellm.OTelCollectorEnvVars(collector) {
+		envMap[e.Name] = e
+	}
+	// The admin UI needs PROXY_BASE_URL to be its own URL, so its traces
+	// carry whatever the user sets there.
+	var proxyBaseURL string
+	if adminUI == nil {
+		proxyBaseURL = litellm.ServiceURL(aiGateway.Name, aiGateway.Namespace, aiGateway.Spec.Port)
+	}
+	for _, e := range litellm.LangfuseEnvVars(langfuse, proxyBaseURL) {
+		envMap[e.Name] = e
+	}
+	egressProxy, _ := litellm.ParseEgressProxy(aiGateway.Annotations, r.EgressProxy)
+	for _, e := range litellm.EgressProxyEnvVars(egressProxy) {
+		envMap[e.Name] = e
+	}
+	cors, _ := litellm.ParseCORS(aiGateway.Annotations)
+	for _, e := range litellm.CORSEnvVars(cors) {
+		envMap[e.Name] = e
+	}
+	for _, e := range litellm.GatewayEnv(aiGateway) {
 		envMap[e.Name] = e
 	}
 
@@ -295,14 +1461,35 @@ func (r *AiGatewayReconciler) buildEnvironmentVariables(aiGateway *gatewayv1alph
 	return envs
 }
 
-func (r *AiGatewayReconciler) generateApiKeyEnvVars(aiGateway *gatewayv1alpha1.AiGateway, envMap map[string]corev1.EnvVar) {
+// requestTimeoutSeconds returns the request_timeout of the generated config
+// for the operator's request timeout d.
+func requestTimeoutSeconds(d time.Duration) int {
+	if d <= 0 {
+		return litellm.DefaultRequestTimeout
+	}
+	return int(d.Round(time.Second) / time.Second)
+}
+
+// apiKeySecretName returns the Secret provider API keys are read from: that
+// of the class config, if any, else that of the operator.
+func (r *AiGatewayReconciler) apiKeySecretName(classConfig *litellmv1alpha1.LiteLLMClassConfigSpec) string {
+	if classConfig != nil && classConfig.APIKeySecretName != "" {
+		return classConfig.APIKeySecretName
+	}
+	if r.APIKeySecretName != "" {
+		return r.APIKeySecretName
+	}
+	return litellm.ApiKeySecretName
+}
+
+func (r *AiGatewayReconciler) generateApiKeyEnvVars(aiGateway *gatewayv1alpha1.AiGateway, secretName string, envMap map[string]corev1.EnvVar) {
 	// Add API key environment variables for each model
 	// We need to determine what API keys are needed based on the models
 	apiKeyEnvVars := make(map[string]bool)
 
 	// Collect unique API key environment variables needed
 	for _, model := range aiGateway.Spec.AiModels {
-		apiKeyEnvVar := r.getProviderApiKeyEnvVar(model)
+		apiKeyEnvVar := providerApiKeyEnvVar(model)
 		if apiKeyEnvVar != "" {
 			apiKeyEnvVars[apiKeyEnvVar] = true
 		}
@@ -315,7 +1502,7 @@ func (r *AiGatewayReconciler) generateApiKeyEnvVars(aiGateway *gatewayv1alpha1.A
 			ValueFrom: &corev1.EnvVarSource{
 				SecretKeyRef: &corev1.SecretKeySelector{
 					LocalObjectReference: corev1.LocalObjectReference{
-						Name: litellm.ApiKeySecretName,
+						Name: secretName,
 					},
 					Key:      envVarName,
 					Optional: &[]bool{true}[0], // Make optional so deployment doesn't fail if secret missing
@@ -348,13 +1535,146 @@ func (r *AiGatewayReconciler) patchStatus(ctx context.Context, original, aiGatew
 	return nil
 }
 
-// SetupWithManager sets up the controller with the Manager.
-func (r *AiGatewayReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	// Indexer key used to locate AiGateways by their config-patch annotation
-	// value. Used by the ConfigMap watch below to enqueue only the gateways in
-	// the namespace whose patch reference matches the changed ConfigMap.
-	const aiGatewayConfigPatchIndex = "metadata.annotations.config-patch"
+// aiGatewaysForClass returns reconcile requests for the gateways that name
+// className or rely on the default class.
+func aiGatewaysForClass(gateways []gatewayv1alpha1.AiGateway, className string) []reconcile.Request {
+	var requests []reconcile.Request
+	for _, gw := range gateways {
+		if gw.Spec.AiGatewayClassName != "" && gw.Spec.AiGatewayClassName != className {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: gw.Name, Namespace: gw.Namespace}})
+	}
+	return requests
+}
 
+// aiGatewaysForModelServer returns the gateways in the namespace of a model
+// server Service that opted into discovery.
+func (r *AiGatewayReconciler) aiGatewaysForModelServer(ctx context.Context, svc *corev1.Service) []reconcile.Request {
+	var gwList gatewayv1alpha1.AiGatewayList
+	if err := r.List(ctx, &gwList, client.InNamespace(svc.Namespace)); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list AiGateways for model server watch", "namespace", svc.Namespace, "service", svc.Name)
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, gw := range gwList.Items {
+		if _, ok := gw.Annotations[litellm.ModelDiscoveryAnnotation]; ok {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: gw.Name, Namespace: gw.Namespace}})
+		}
+	}
+	return requests
+}
+
+// aiGatewaysForClassConfig returns the gateways of the classes of this
+// controller naming a changed LiteLLMClassConfig.
+func (r *AiGatewayReconciler) aiGatewaysForClassConfig(ctx context.Context, config client.Object) []reconcile.Request {
+	log := logf.FromContext(ctx)
+	var classList gatewayv1alpha1.AiGatewayClassList
+	if err := r.List(ctx, &classList); err != nil {
+		log.Error(err, "Failed to list AiGatewayClasses for LiteLLMClassConfig watch", "classConfig", config.GetName())
+		return nil
+	}
+	var gwList gatewayv1alpha1.AiGatewayList
+	var requests []reconcile.Request
+	for _, cls := range classList.Items {
+		if cls.Spec.Controller != ControllerName || cls.Annotations[litellm.ClassConfigAnnotation] != config.GetName() {
+			continue
+		}
+		if gwList.Items == nil {
+			if err := r.List(ctx, &gwList); err != nil {
+				log.Error(err, "Failed to list AiGateways for LiteLLMClassConfig watch", "classConfig", config.GetName())
+				return nil
+			}
+		}
+		requests = append(requests, aiGatewaysForClass(gwList.Items, cls.Name)...)
+	}
+	return requests
+}
+
+// aiGatewaysForClassSecret returns the gateways in the namespace of secret
+// whose class reads it: as the API key Secret of the class's
+// LiteLLMClassConfig, or as the Langfuse Secret of the class annotations.
+// The gateway Secret index cannot see either.
+func (r *AiGatewayReconciler) aiGatewaysForClassSecret(ctx context.Context, secret client.Object) []reconcile.Request {
+	log := logf.FromContext(ctx)
+	var classList gatewayv1alpha1.AiGatewayClassList
+	if err := r.List(ctx, &classList); err != nil {
+		log.Error(err, "Failed to list AiGatewayClasses for Secret watch", "secret", secret.GetName())
+		return nil
+	}
+	var classes []string
+	for _, cls := range classList.Items {
+		if cls.Spec.Controller != ControllerName {
+			continue
+		}
+		if cls.Annotations[litellm.LangfuseSecretAnnotation] == secret.GetName() {
+			classes = append(classes, cls.Name)
+			continue
+		}
+		name := cls.Annotations[litellm.ClassConfigAnnotation]
+		if name == "" {
+			continue
+		}
+		var config litellmv1alpha1.LiteLLMClassConfig
+		if err := r.Get(ctx, types.NamespacedName{Name: name}, &config); err != nil {
+			continue
+		}
+		if config.Spec.APIKeySecretName == secret.GetName() {
+			classes = append(classes, cls.Name)
+		}
+	}
+	if len(classes) == 0 {
+		return nil
+	}
+	var gwList gatewayv1alpha1.AiGatewayList
+	if err := r.List(ctx, &gwList, client.InNamespace(secret.GetNamespace())); err != nil {
+		log.Error(err, "Failed to list AiGateways for Secret watch", "namespace", secret.GetNamespace(), "secret", secret.GetName())
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, cls := range classes {
+		requests = append(requests, aiGatewaysForClass(gwList.Items, cls)...)
+	}
+	return requests
+}
+
+// Field indexes registered by registerIndexes for the watches of
+// SetupWithManager.
+const (
+	// aiGatewayConfigPatchIndex locates AiGateways by their config-patch
+	// annotation value. Used by the patch ConfigMap watch to enqueue only the
+	// gateways in the namespace whose patch reference matches the changed
+	// ConfigMap.
+	aiGatewayConfigPatchIndex = "metadata.annotations.config-patch"
+
+	// aiGatewaySecretIndex locates AiGateways by the Secrets their container
+	// reads (api-key secret, master key, spec.env / spec.envFrom references).
+	aiGatewaySecretIndex = "spec.secretRefs"
+
+	// aiGatewayUpstreamIndex locates AiGateways by the "<namespace>/<name>" of
+	// their upstream gateway.
+	aiGatewayUpstreamIndex = "metadata.annotations.upstream"
+
+	// aiGatewayPassThroughIndex locates AiGateways by the
+	// LiteLLMPassThroughEndpoints they list.
+	aiGatewayPassThroughIndex = "metadata.annotations.pass-through-endpoints"
+
+	// passThroughSecretIndex locates LiteLLMPassThroughEndpoints by the Secret
+	// holding their auth credential.
+	passThroughSecretIndex = "spec.auth.secretKeyRef.name"
+
+	// aiGatewayTemplateIndex locates AiGateways by the LiteLLMGatewayTemplate
+	// they use.
+	aiGatewayTemplateIndex = "metadata.annotations.gateway-template"
+
+	// aiGatewayReplicaIndex locates AiGateways by the kubeconfig Secrets of
+	// their replication targets, which live in the replication namespace.
+	aiGatewayReplicaIndex = "metadata.annotations.replicate-to"
+)
+
+// registerIndexes registers the field indexes the watches of the AiGateway
+// controller look up their gateways by.
+func (r *AiGatewayReconciler) registerIndexes(mgr ctrl.Manager) error {
 	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &gatewayv1alpha1.AiGateway{}, aiGatewayConfigPatchIndex,
 		func(obj client.Object) []string {
 			gw, ok := obj.(*gatewayv1alpha1.AiGateway)
@@ -371,6 +1691,148 @@ func (r *AiGatewayReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return fmt.Errorf("failed to register AiGateway config-patch indexer: %w", err)
 	}
 
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &gatewayv1alpha1.AiGateway{}, aiGatewaySecretIndex,
+		func(obj client.Object) []string {
+			gw, ok := obj.(*gatewayv1alpha1.AiGateway)
+			if !ok {
+				return nil
+			}
+			// Secrets named only by the class or its class config are
+			// matched by aiGatewaysForClassSecret.
+			names := append(litellm.ReferencedSecretNames(gw.Spec.Env, gw.Spec.EnvFrom), r.apiKeySecretName(nil))
+			if _, ok := litellm.UpstreamRef(gw); ok {
+				names = append(names, litellm.UpstreamKeySecretName(gw))
+			}
+			if managedCache, _ := litellm.ManagedCache(gw.Annotations); managedCache {
+				names = append(names, litellm.RedisName(gw.Name))
+			}
+			if database, _ := litellm.ParseDatabase(gw); database != nil {
+				names = append(names, litellm.DatabaseSecretName(gw.Name))
+			}
+			if adminUI, _ := litellm.ParseAdminUI(gw); adminUI != nil && adminUI.CredentialsSecret != "" {
+				names = append(names, adminUI.CredentialsSecret)
+			}
+			if name := gw.Annotations[litellm.LangfuseSecretAnnotation]; name != "" {
+				names = append(names, name)
+			}
+			if alerting, err := litellm.ParseAlertingSettings(gw.Annotations); err == nil {
+				for _, name := range []string{alerting.SlackSecret, alerting.WebhookSecret} {
+					if name != "" {
+						names = append(names, name)
+					}
+				}
+			}
+			return names
+		},
+	); err != nil {
+		return fmt.Errorf("failed to register AiGateway secret indexer: %w", err)
+	}
+
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &gatewayv1alpha1.AiGateway{}, aiGatewayUpstreamIndex,
+		func(obj client.Object) []string {
+			gw, ok := obj.(*gatewayv1alpha1.AiGateway)
+			if !ok {
+				return nil
+			}
+			ref, ok := litellm.UpstreamRef(gw)
+			if !ok {
+				return nil
+			}
+			return []string{ref.String()}
+		},
+	); err != nil {
+		return fmt.Errorf("failed to register AiGateway upstream indexer: %w", err)
+	}
+
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &gatewayv1alpha1.AiGateway{}, aiGatewayPassThroughIndex,
+		func(obj client.Object) []string {
+			return litellm.PassThroughEndpointNames(obj.GetAnnotations())
+		},
+	); err != nil {
+		return fmt.Errorf("failed to register AiGateway pass-through indexer: %w", err)
+	}
+
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &litellmv1alpha1.LiteLLMPassThroughEndpoint{}, passThroughSecretIndex,
+		func(obj client.Object) []string {
+			endpoint, ok := obj.(*litellmv1alpha1.LiteLLMPassThroughEndpoint)
+			if !ok || endpoint.Spec.Auth == nil {
+				return nil
+			}
+			return []string{endpoint.Spec.Auth.SecretKeyRef.Name}
+		},
+	); err != nil {
+		return fmt.Errorf("failed to register LiteLLMPassThroughEndpoint secret indexer: %w", err)
+	}
+
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &gatewayv1alpha1.AiGateway{}, aiGatewayTemplateIndex,
+		func(obj client.Object) []string {
+			if name := obj.GetAnnotations()[litellm.GatewayTemplateAnnotation]; name != "" {
+				return []string{name}
+			}
+			return nil
+		},
+	); err != nil {
+		return fmt.Errorf("failed to register AiGateway gateway-template indexer: %w", err)
+	}
+
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &gatewayv1alpha1.AiGateway{}, aiGatewayReplicaIndex,
+		func(obj client.Object) []string {
+			return litellm.ReplicationTargets(obj.GetAnnotations(), litellm.ReplicateToAnnotation)
+		},
+	); err != nil {
+		return fmt.Errorf("failed to register AiGateway replicate-to indexer: %w", err)
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *AiGatewayReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := r.registerIndexes(mgr); err != nil {
+		return err
+	}
+
+	// aiGatewaysForPassThrough returns the AiGateways listing the named
+	// LiteLLMPassThroughEndpoint.
+	aiGatewaysForPassThrough := func(ctx context.Context, namespace, name string) []reconcile.Request {
+		var gwList gatewayv1alpha1.AiGatewayList
+		if err := r.List(ctx, &gwList,
+			client.InNamespace(namespace),
+			client.MatchingFields{aiGatewayPassThroughIndex: name},
+		); err != nil {
+			logf.FromContext(ctx).Error(err, "Failed to list AiGateways for LiteLLMPassThroughEndpoint watch", "namespace", namespace, "endpoint", name)
+			return nil
+		}
+		requests := make([]reconcile.Request, len(gwList.Items))
+		for i, gw := range gwList.Items {
+			requests[i] = reconcile.Request{NamespacedName: types.NamespacedName{Name: gw.Name, Namespace: gw.Namespace}}
+		}
+		return requests
+	}
+
+	// enqueueAiGatewaysForPassThrough re-renders the gateways serving a
+	// changed LiteLLMPassThroughEndpoint.
+	enqueueAiGatewaysForPassThrough := handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		return aiGatewaysForPassThrough(ctx, obj.GetNamespace(), obj.GetName())
+	})
+
+	// enqueueAiGatewaysForTemplate rolls the gateways using a changed
+	// LiteLLMGatewayTemplate.
+	enqueueAiGatewaysForTemplate := handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		var gwList gatewayv1alpha1.AiGatewayList
+		if err := r.List(ctx, &gwList,
+			client.InNamespace(obj.GetNamespace()),
+			client.MatchingFields{aiGatewayTemplateIndex: obj.GetName()},
+		); err != nil {
+			logf.FromContext(ctx).Error(err, "Failed to list AiGateways for LiteLLMGatewayTemplate watch", "namespace", obj.GetNamespace(), "template", obj.GetName())
+			return nil
+		}
+		requests := make([]reconcile.Request, len(gwList.Items))
+		for i, gw := range gwList.Items {
+			requests[i] = reconcile.Request{NamespacedName: types.NamespacedName{Name: gw.Name, Namespace: gw.Namespace}}
+		}
+		return requests
+	})
+
 	// enqueueAiGatewaysInNamespace enqueues reconcile requests for all AiGateway objects in
 	// the namespace of the triggering object.
 	enqueueAiGatewaysInNamespace := handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
@@ -392,22 +1854,18 @@ func (r *AiGatewayReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return requests
 	})
 
-	// enqueueAllAiGateways fans out to every AiGateway in the cluster. Used
-	// for AiGatewayClass changes (cluster-scoped) — the default-class
-	// annotation toggling has to re-evaluate gateways that don't name a class
-	// explicitly.
-	enqueueAllAiGateways := handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, _ client.Object) []reconcile.Request {
+	// enqueueAiGatewaysForClass fans out an AiGatewayClass change (cluster-
+	// scoped) to the gateways it can affect: those naming the class, and
+	// those naming no class at all, since toggling the default-class
+	// annotation changes which controller claims them.
+	enqueueAiGatewaysForClass := handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
 		log := logf.FromContext(ctx)
 		var aiGatewayList gatewayv1alpha1.AiGatewayList
 		if err := r.List(ctx, &aiGatewayList); err != nil {
 			log.Error(err, "Failed to list AiGateways for AiGatewayClass watch")
 			return nil
 		}
-		requests := make([]reconcile.Request, len(aiGatewayList.Items))
-		for i, gw := range aiGatewayList.Items {
-			requests[i] = reconcile.Request{NamespacedName: types.NamespacedName{Name: gw.Name, Namespace: gw.Namespace}}
-		}
-		return requests
+		return aiGatewaysForClass(aiGatewayList.Items, obj.GetName())
 	})
 
 	// enqueueAiGatewaysForPatchConfigMap enqueues reconcile requests for all
@@ -433,42 +1891,166 @@ func (r *AiGatewayReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return requests
 	})
 
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&gatewayv1alpha1.AiGateway{}).
-		Owns(&appsv1.Deployment{}).
+	// enqueueAiGatewaysForSecret enqueues exactly the AiGateways in the
+	// namespace whose container reads the changed Secret, so credential
+	// rotations roll the affected gateways (via the secret-hash annotation).
+	enqueueAiGatewaysForSecret := handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		log := logf.FromContext(ctx)
+		var gwList gatewayv1alpha1.AiGatewayList
+		if err := r.List(ctx, &gwList,
+			client.InNamespace(obj.GetNamespace()),
+			client.MatchingFields{aiGatewaySecretIndex: obj.GetName()},
+		); err != nil {
+			log.Error(err, "Failed to list AiGateways for Secret watch", "namespace", obj.GetNamespace(), "secret", obj.GetName())
+			return nil
+		}
+		if obj.GetNamespace() == r.ReplicationNamespace {
+			var replicated gatewayv1alpha1.AiGatewayList
+			if err := r.List(ctx, &replicated, client.MatchingFields{aiGatewayReplicaIndex: obj.GetName()}); err != nil {
+				log.Error(err, "Failed to list replicated AiGateways for Secret watch", "secret", obj.GetName())
+			}
+			gwList.Items = append(gwList.Items, replicated.Items...)
+		}
+		requests := make([]reconcile.Request, len(gwList.Items))
+		for i, gw := range gwList.Items {
+			requests[i] = reconcile.Request{NamespacedName: types.NamespacedName{Name: gw.Name, Namespace: gw.Namespace}}
+		}
+		requests = append(requests, r.aiGatewaysForClassSecret(ctx, obj)...)
+		// Pass-through auth Secrets are read through the endpoints, which
+		// the gateway index cannot see.
+		var endpoints litellmv1alpha1.LiteLLMPassThroughEndpointList
+		if err := r.List(ctx, &endpoints,
+			client.InNamespace(obj.GetNamespace()),
+			client.MatchingFields{passThroughSecretIndex: obj.GetName()},
+		); err != nil {
+			log.Error(err, "Failed to list LiteLLMPassThroughEndpoints for Secret watch", "namespace", obj.GetNamespace(), "secret", obj.GetName())
+			return requests
+		}
+		for _, endpoint := range endpoints.Items {
+			requests = append(requests, aiGatewaysForPassThrough(ctx, endpoint.Namespace, endpoint.Name)...)
+		}
+		return requests
+	})
+
+	// enqueueAiGatewayForBudget enqueues the AiGateway a LiteLLMBudget
+	// applies to. Team-scoped budgets are applied by the LiteLLMTeam
+	// controller instead.
+	enqueueAiGatewayForBudget := handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		budget, ok := obj.(*litellmv1alpha1.LiteLLMBudget)
+		if !ok || budget.Spec.Scope.Type == litellmv1alpha1.BudgetScopeTeam {
+			return nil
+		}
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: budget.Spec.AiGatewayRef.Name, Namespace: budget.Namespace}}}
+	})
+
+	// enqueueAiGatewayForRateLimit enqueues the AiGateway of a Model-scoped
+	// LiteLLMRateLimitPolicy. Team and Key scopes are applied by their own
+	// controllers.
+	enqueueAiGatewayForRateLimit := handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		policy, ok := obj.(*litellmv1alpha1.LiteLLMRateLimitPolicy)
+		if !ok || policy.Spec.Scope.Type != litellmv1alpha1.RateLimitScopeModel {
+			return nil
+		}
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: policy.Spec.AiGatewayRef.Name, Namespace: policy.Namespace}}}
+	})
+
+	enqueueAiGatewayForMaintenance := handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		window, ok := obj.(*litellmv1alpha1.LiteLLMMaintenanceWindow)
+		if !ok {
+			return nil
+		}
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: window.Spec.AiGatewayRef.Name, Namespace: window.Namespace}}}
+	})
+
+	// enqueueDownstreamAiGateways enqueues the gateways using the changed
+	// AiGateway as their upstream, so their api_base and model checks follow
+	// renames of its Service port and edits to its model list.
+	enqueueDownstreamAiGateways := handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		log := logf.FromContext(ctx)
+		var gwList gatewayv1alpha1.AiGatewayList
+		key := types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}.String()
+		if err := r.List(ctx, &gwList, client.MatchingFields{aiGatewayUpstreamIndex: key}); err != nil {
+			log.Error(err, "Failed to list downstream AiGateways", "upstream", key)
+			return nil
+		}
+		requests := make([]reconcile.Request, len(gwList.Items))
+		for i, gw := range gwList.Items {
+			requests[i] = reconcile.Request{NamespacedName: types.NamespacedName{Name: gw.Name, Namespace: gw.Namespace}}
+		}
+		return requests
+	})
+
+	bldr := ctrl.NewControllerManagedBy(mgr).
+		For(&gatewayv1alpha1.AiGateway{}, builder.WithPredicates(gatewayChangedPredicate())).
+		Owns(&appsv1.Deployment{}, builder.WithPredicates(deploymentChangedPredicate())).
 		Owns(&corev1.Service{}).
 		Owns(&corev1.ConfigMap{}).
-		Watches(&gatewayv1alpha1.AiGatewayClass{}, enqueueAllAiGateways).
-		Watches(
-			&corev1.Secret{},
-			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
-				// Only react to the default API-keys secret
-				if obj.GetName() != litellm.ApiKeySecretName {
-					return nil
-				}
-				// Enqueue reconcile requests for all AiGateway objects in the same namespace
-				var aiGatewayList gatewayv1alpha1.AiGatewayList
-				if err := r.List(ctx, &aiGatewayList, client.InNamespace(obj.GetNamespace())); err != nil {
-					return nil
-				}
-				requests := make([]reconcile.Request, len(aiGatewayList.Items))
-				for i, gw := range aiGatewayList.Items {
-					requests[i] = reconcile.Request{
-						NamespacedName: types.NamespacedName{
-							Name:      gw.Name,
-							Namespace: gw.Namespace,
-						},
-					}
-				}
-				return requests
-			}),
-		).
+		Owns(&batchv1.CronJob{}).
+		Owns(&batchv1.Job{}).
+		Owns(&corev1.ServiceAccount{}).
+		Owns(&rbacv1.Role{}).
+		Owns(&rbacv1.RoleBinding{}).
+		Owns(&networkingv1.Ingress{}).
+		Watches(&gatewayv1alpha1.AiGatewayClass{}, enqueueAiGatewaysForClass).
+		Watches(&gatewayv1alpha1.AiGateway{}, enqueueDownstreamAiGateways,
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&corev1.Secret{}, enqueueAiGatewaysForSecret).
 		Watches(&corev1.ConfigMap{}, enqueueAiGatewaysForPatchConfigMap).
 		// Watch Guard changes so that updates to a Guard trigger re-reconciliation of all
 		// AiGateway resources in the same namespace that may reference it.
 		Watches(&gatewayv1alpha1.Guard{}, enqueueAiGatewaysInNamespace).
 		// Watch GuardrailProvider changes for the same reason.
 		Watches(&gatewayv1alpha1.GuardrailProvider{}, enqueueAiGatewaysInNamespace).
+		// Gateway- and Model-scoped LiteLLMBudgets are rendered into the config.
+		Watches(&litellmv1alpha1.LiteLLMBudget{}, enqueueAiGatewayForBudget,
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// Model-scoped LiteLLMRateLimitPolicies are rendered into the config.
+		Watches(&litellmv1alpha1.LiteLLMRateLimitPolicy{}, enqueueAiGatewayForRateLimit,
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// Listed LiteLLMPassThroughEndpoints are rendered into the config.
+		Watches(&litellmv1alpha1.LiteLLMPassThroughEndpoint{}, enqueueAiGatewaysForPassThrough).
+		// The LiteLLMGatewayTemplate of a gateway is part of its pod template.
+		Watches(&litellmv1alpha1.LiteLLMGatewayTemplate{}, enqueueAiGatewaysForTemplate).
+		// The LiteLLMClassConfig of a class is merged into its gateways.
+		Watches(&litellmv1alpha1.LiteLLMClassConfig{}, handler.EnqueueRequestsFromMapFunc(r.aiGatewaysForClassConfig),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// Open LiteLLMMaintenanceWindows take models out of the config.
+		Watches(&litellmv1alpha1.LiteLLMMaintenanceWindow{}, enqueueAiGatewayForMaintenance,
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+
+	if r.ModelServerCache != nil {
+		// Model server Services live in their own cache; a change re-renders
+		// every gateway in the namespace that opted into discovery.
+		enqueueAiGatewaysForModelServer := handler.TypedEnqueueRequestsFromMapFunc(r.aiGatewaysForModelServer)
+		bldr = bldr.WatchesRawSource(source.Kind(r.ModelServerCache, &corev1.Service{}, enqueueAiGatewaysForModelServer))
+	}
+
+	// A capability appearing or disappearing, or a feature gate flipping,
+	// can change any gateway.
+	enqueueAllAiGateways := handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, _ client.Object) []reconcile.Request {
+		var gwList gatewayv1alpha1.AiGatewayList
+		if err := r.List(ctx, &gwList); err != nil {
+			logf.FromContext(ctx).Error(err, "Failed to list AiGateways for capability or feature gate change")
+			return nil
+		}
+		requests := make([]reconcile.Request, len(gwList.Items))
+		for i, gw := range gwList.Items {
+			requests[i] = reconcile.Request{NamespacedName: types.NamespacedName{Name: gw.Name, Namespace: gw.Namespace}}
+		}
+		return requests
+	})
+	if r.Capabilities != nil {
+		bldr = bldr.WatchesRawSource(source.Channel(r.Capabilities.Changes(), enqueueAllAiGateways))
+	}
+	if r.FeatureGates != nil {
+		bldr = bldr.WatchesRawSource(source.Channel(r.FeatureGates.Changes(), enqueueAllAiGateways))
+	}
+
+	return bldr.
 		Named(ControllerName).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: r.MaxConcurrentReconciles,
+			RateLimiter:             r.RateLimiter,
+		}).
 		Complete(r)
 }
@@ -1157,3 +1157,24 @@ func findCondition(conditions []metav1.Condition, conditionType string) *metav1.
 	}
 	return nil
 }
+
+var _ = Describe("AiGatewayClass watch mapping", func() {
+	It("enqueues gateways naming the class or relying on the default class", func() {
+		gw := func(name, class string) gatewayv1alpha1.AiGateway {
+			return gatewayv1alpha1.AiGateway{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+				Spec:       gatewayv1alpha1.AiGatewaySpec{AiGatewayClassName: class},
+			}
+		}
+		requests := aiGatewaysForClass([]gatewayv1alpha1.AiGateway{
+			gw("named", "litellm"),
+			gw("defaulted", ""),
+			gw("other", "envoy"),
+		}, "litellm")
+
+		Expect(requests).To(ConsistOf(
+			reconcile.Request{NamespacedName: types.NamespacedName{Name: "named", Namespace: "default"}},
+			reconcile.Request{NamespacedName: types.NamespacedName{Name: "defaulted", Namespace: "default"}},
+		))
+	})
+})
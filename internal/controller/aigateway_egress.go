@@ -0,0 +1,81 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/litellm"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// AiGatewayEgress lists the hosts outside the cluster the gateway's models
+// are called at, as the litellm.EgressHostsAnnotation does.
+const AiGatewayEgress = "AiGatewayEgress"
+
+// Egress condition reasons
+const (
+	ReasonEgressHostsResolved   = "EgressHostsResolved"
+	ReasonEgressHostsIncomplete = "EgressHostsIncomplete"
+)
+
+// publishEgressHosts stamps the egress condition of gw from the config it
+// rolls out, and sets its egress hosts annotation. The annotation is written
+// with a patch of its own, so gw keeps the status changes of this reconcile.
+func (r *AiGatewayReconciler) publishEgressHosts(ctx context.Context, gw *gatewayv1alpha1.AiGateway, configYAML string) error {
+	hosts, unknown, err := litellm.EgressHosts(configYAML)
+	if err != nil {
+		return fmt.Errorf("reading egress hosts from config: %w", err)
+	}
+	message := "Models are called at " + strings.Join(hosts, ", ")
+	if len(hosts) == 0 {
+		message = "Models are called at no host outside the cluster"
+	}
+	if len(unknown) > 0 {
+		r.updateCondition(gw, AiGatewayEgress, metav1.ConditionFalse, ReasonEgressHostsIncomplete,
+			message+"; the hosts of "+strings.Join(unknown, ", ")+" models are not known, set their api_base in the config patch")
+	} else {
+		r.updateCondition(gw, AiGatewayEgress, metav1.ConditionTrue, ReasonEgressHostsResolved, message)
+	}
+
+	value := strings.Join(hosts, ",")
+	current, ok := gw.Annotations[litellm.EgressHostsAnnotation]
+	if current == value && ok == (value != "") {
+		return nil
+	}
+	var annotation any
+	if value != "" {
+		annotation = value
+	}
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{"annotations": map[string]any{litellm.EgressHostsAnnotation: annotation}},
+	})
+	if err != nil {
+		return err
+	}
+	target := &gatewayv1alpha1.AiGateway{ObjectMeta: metav1.ObjectMeta{Name: gw.Name, Namespace: gw.Namespace}}
+	if err := r.Patch(ctx, target, client.RawPatch(types.MergePatchType, patch)); err != nil {
+		return fmt.Errorf("setting the egress hosts annotation: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/litellm"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestAiGatewayReconciler_PublishEgressHosts(t *testing.T) {
+	s := runtime.NewScheme()
+	if err := gatewayv1alpha1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	gw := &gatewayv1alpha1.AiGateway{ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "ai-gateway"}}
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(gw).Build()
+	r := &AiGatewayReconciler{Client: c}
+	ctx := context.Background()
+
+	config := "model_list:\n- model_name: gpt-4o\n  litellm_params: {model: openai/gpt-4o}\n" +
+		"- model_name: claude\n  litellm_params: {model: anthropic/claude-sonnet-4}\n"
+	if err := r.publishEgressHosts(ctx, gw, config); err != nil {
+		t.Fatalf("publishEgressHosts: %v", err)
+	}
+	var got gatewayv1alpha1.AiGateway
+	if err := c.Get(ctx, client.ObjectKeyFromObject(gw), &got); err != nil {
+		t.Fatal(err)
+	}
+	if v := got.Annotations[litellm.EgressHostsAnnotation]; v != "api.anthropic.com,api.openai.com" {
+		t.Errorf("annotation = %q", v)
+	}
+	cond := apimeta.FindStatusCondition(gw.Status.Conditions, AiGatewayEgress)
+	if cond == nil || cond.Status != metav1.ConditionTrue || cond.Message != "Models are called at api.anthropic.com, api.openai.com" {
+		t.Errorf("condition = %+v", cond)
+	}
+
+	// Only an in-cluster and an unknown host left: the annotation goes.
+	config = "model_list:\n- model_name: llama\n  litellm_params: {model: hosted_vllm/llama, api_base: 'http://vllm.models.svc:8000'}\n" +
+		"- model_name: gpt-4o\n  litellm_params: {model: azure/gpt-4o}\n"
+	if err := r.publishEgressHosts(ctx, &got, config); err != nil {
+		t.Fatalf("publishEgressHosts: %v", err)
+	}
+	if cond := apimeta.FindStatusCondition(got.Status.Conditions, AiGatewayEgress); cond == nil || cond.Reason != ReasonEgressHostsIncomplete {
+		t.Errorf("condition = %+v", cond)
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(gw), &got); err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := got.Annotations[litellm.EgressHostsAnnotation]; ok {
+		t.Errorf("annotation = %q, want none", v)
+	}
+	if cond := apimeta.FindStatusCondition(got.Status.Conditions, AiGatewayEgress); cond != nil {
+		t.Errorf("status must not be written by the annotation patch, got %+v", cond)
+	}
+}
+
+var _ = Describe("AiGateway Controller — egress hosts", func() {
+	Context("When reconciling an AiGateway calling two providers", func() {
+		gatewayKey := types.NamespacedName{Name: "ai-egress", Namespace: "default"}
+		classKey := types.NamespacedName{Name: aiGatewayClassName}
+
+		BeforeEach(func() {
+			createDefaultClass(classKey)
+			Expect(k8sClient.Create(ctx, &gatewayv1alpha1.AiGateway{
+				ObjectMeta: metav1.ObjectMeta{Name: gatewayKey.Name, Namespace: gatewayKey.Namespace},
+				Spec: gatewayv1alpha1.AiGatewaySpec{
+					Port: 8000,
+					AiModels: []gatewayv1alpha1.AiModel{
+						{Name: "gpt-4", Provider: "openai"},
+						{Name: "claude-3-opus", Provider: "anthropic"},
+					},
+				},
+			})).To(Succeed())
+		})
+
+		AfterEach(func() {
+			cleanupAiGateway(gatewayKey)
+			cleanupAiGatewayClass(classKey)
+		})
+
+		It("publishes the provider hosts in the annotation and the condition", func() {
+			rec := &AiGatewayReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+
+			_, err := rec.Reconcile(ctx, reconcile.Request{NamespacedName: gatewayKey})
+			Expect(err).NotTo(HaveOccurred())
+
+			gw := &gatewayv1alpha1.AiGateway{}
+			Expect(k8sClient.Get(ctx, gatewayKey, gw)).To(Succeed())
+			Expect(gw.Annotations).To(HaveKeyWithValue(litellm.EgressHostsAnnotation, "api.anthropic.com,api.openai.com"))
+			cond := findCondition(gw.Status.Conditions, AiGatewayEgress)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+			Expect(cond.Reason).To(Equal(ReasonEgressHostsResolved))
+			Expect(cond.Message).To(Equal("Models are called at api.anthropic.com, api.openai.com"))
+			configured := findCondition(gw.Status.Conditions, AiGatewayConfigured)
+			Expect(configured).NotTo(BeNil())
+			Expect(configured.Status).To(Equal(metav1.ConditionTrue), "the annotation patch must not drop the status")
+
+			By("Dropping a provider")
+			gw.Spec.AiModels = gw.Spec.AiModels[:1]
+			Expect(k8sClient.Update(ctx, gw)).To(Succeed())
+			_, err = rec.Reconcile(ctx, reconcile.Request{NamespacedName: gatewayKey})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, gatewayKey, gw)).To(Succeed())
+			Expect(gw.Annotations).To(HaveKeyWithValue(litellm.EgressHostsAnnotation, "api.openai.com"))
+			cond = findCondition(gw.Status.Conditions, AiGatewayEgress)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Message).To(Equal("Models are called at api.openai.com"))
+		})
+	})
+})
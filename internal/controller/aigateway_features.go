@@ -0,0 +1,59 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"slices"
+	"strings"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/operatorconfig"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AiGatewayFeatures reports features the gateway's annotations ask for that
+// the operator's feature gates turn off.
+const AiGatewayFeatures = "AiGatewayFeatures"
+
+// ReasonFeaturesDisabled indicates the gateway is served without features
+// it asks for, because their feature gates are off.
+const ReasonFeaturesDisabled = "FeaturesDisabled"
+
+// disabledFeatures returns those of the requested features whose gates are
+// off, sorted, and stamps the features condition of gw. The condition is
+// removed when none are.
+func (r *AiGatewayReconciler) disabledFeatures(gw *gatewayv1alpha1.AiGateway, requested map[operatorconfig.Feature]bool) []operatorconfig.Feature {
+	var disabled []operatorconfig.Feature
+	for feature, asked := range requested {
+		if asked && !r.FeatureGates.Enabled(feature) {
+			disabled = append(disabled, feature)
+		}
+	}
+	if len(disabled) == 0 {
+		apimeta.RemoveStatusCondition(&gw.Status.Conditions, AiGatewayFeatures)
+		return nil
+	}
+	slices.Sort(disabled)
+	names := make([]string, len(disabled))
+	for i, feature := range disabled {
+		names[i] = string(feature)
+	}
+	r.updateCondition(gw, AiGatewayFeatures, metav1.ConditionFalse, ReasonFeaturesDisabled,
+		strings.Join(names, ", ")+" turned off by the operator's feature gates; the gateway is served without them")
+	return disabled
+}
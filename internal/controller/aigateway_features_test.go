@@ -0,0 +1,100 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/litellm"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/operatorconfig"
+)
+
+var _ = Describe("AiGateway Controller — feature gates", func() {
+	const (
+		testNS   = "default"
+		testPort = int32(8000)
+	)
+
+	Context("When reconciling a blue/green AiGateway with the Canary feature turned off", func() {
+		gatewayKey := types.NamespacedName{Name: "ai-features", Namespace: testNS}
+		classKey := types.NamespacedName{Name: aiGatewayClassName}
+		blueKey := types.NamespacedName{Name: gatewayKey.Name + "-" + litellm.ColorBlue, Namespace: testNS}
+
+		BeforeEach(func() {
+			createDefaultClass(classKey)
+			Expect(k8sClient.Create(ctx, &gatewayv1alpha1.AiGateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      gatewayKey.Name,
+					Namespace: testNS,
+					Annotations: map[string]string{
+						litellm.RolloutStrategyAnnotation: litellm.RolloutStrategyBlueGreen,
+					},
+				},
+				Spec: gatewayv1alpha1.AiGatewaySpec{
+					Port:     testPort,
+					AiModels: []gatewayv1alpha1.AiModel{{Name: "gpt-4", Provider: "openai"}},
+				},
+			})).To(Succeed())
+		})
+
+		AfterEach(func() {
+			cleanupAiGateway(gatewayKey)
+			cleanupAiGatewayClass(classKey)
+		})
+
+		featuresCondition := func() *metav1.Condition {
+			gw := &gatewayv1alpha1.AiGateway{}
+			Expect(k8sClient.Get(ctx, gatewayKey, gw)).To(Succeed())
+			return findCondition(gw.Status.Conditions, AiGatewayFeatures)
+		}
+
+		It("serves the gateway with a rolling update until the gate is turned on", func() {
+			gates := &operatorconfig.FeatureGates{}
+			Expect(gates.Set(map[string]bool{string(operatorconfig.FeatureCanary): false})).To(Succeed())
+			rec := &AiGatewayReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(), FeatureGates: gates}
+
+			_, err := rec.Reconcile(ctx, reconcile.Request{NamespacedName: gatewayKey})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Verifying the gateway runs a single Deployment")
+			Expect(k8sClient.Get(ctx, gatewayKey, &appsv1.Deployment{})).To(Succeed())
+			err = k8sClient.Get(ctx, blueKey, &appsv1.Deployment{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue(), "no blue Deployment while Canary is off, got %v", err)
+
+			cond := featuresCondition()
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+			Expect(cond.Reason).To(Equal(ReasonFeaturesDisabled))
+			Expect(cond.Message).To(HavePrefix(string(operatorconfig.FeatureCanary) + " turned off"))
+
+			By("Turning the gate on")
+			Expect(gates.Set(map[string]bool{string(operatorconfig.FeatureCanary): true})).To(Succeed())
+			_, err = rec.Reconcile(ctx, reconcile.Request{NamespacedName: gatewayKey})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, blueKey, &appsv1.Deployment{})).To(Succeed())
+			Expect(featuresCondition()).To(BeNil())
+		})
+	})
+})
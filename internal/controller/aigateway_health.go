@@ -0,0 +1,138 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/litellm"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// AiGatewayHealthy mirrors the per-deployment result of LiteLLM's /health
+// endpoint, so a dead provider key shows up on the CR instead of only in
+// proxy logs.
+const AiGatewayHealthy = "AiGatewayHealthy"
+
+// Health condition reasons
+const (
+	ReasonAllDeploymentsHealthy = "AllDeploymentsHealthy"
+	ReasonUnhealthyDeployments  = "UnhealthyDeployments"
+	ReasonHealthCheckFailed     = "HealthCheckFailed"
+)
+
+// probeTracker remembers when each gateway was last probed through the LiteLLM
+// management API. /health issues a real completion request per model and the
+// spend endpoints run aggregate queries against the proxy database, so probing
+// on every reconcile (which fires on every owned-object event) is too costly.
+type probeTracker struct {
+	last map[types.NamespacedName]time.Time
+}
+
+// due reports whether key should be probed now, and otherwise how long until
+// the next probe is due.
+func (t *probeTracker) due(key types.NamespacedName, interval time.Duration, now time.Time) (bool, time.Duration) {
+	last, ok := t.last[key]
+	if !ok || now.Sub(last) >= interval {
+		return true, 0
+	}
+	return false, interval - now.Sub(last)
+}
+
+func (t *probeTracker) record(key types.NamespacedName, now time.Time) {
+	if t.last == nil {
+		t.last = make(map[types.NamespacedName]time.Time)
+	}
+	t.last[key] = now
+}
+
+func (t *probeTracker) forget(key types.NamespacedName) {
+	delete(t.last, key)
+}
+
+// syncHealth probes the gateway's /health endpoint when the configured
+// interval has elapsed and stamps the AiGatewayHealthy condition. Probe
+// failures are reported on the condition rather than returned: an unreachable
+// /health must not flip Configured or block the rest of the reconcile.
+// Returns the delay until the next probe is due.
+func (r *AiGatewayReconciler) syncHealth(ctx context.Context, gw *gatewayv1alpha1.AiGateway, env []corev1.EnvVar) time.Duration {
+	log := logf.FromContext(ctx)
+	key := types.NamespacedName{Name: gw.Name, Namespace: gw.Namespace}
+
+	r.probeMu.Lock()
+	due, wait := r.health.due(key, r.HealthCheckInterval, time.Now())
+	r.probeMu.Unlock()
+	if !due {
+		return wait
+	}
+
+	masterKey, err := litellm.ResolveMasterKey(ctx, r, gw.Namespace, env)
+	if err != nil {
+		r.updateCondition(gw, AiGatewayHealthy, metav1.ConditionUnknown, ReasonHealthCheckFailed, err.Error())
+		return r.HealthCheckInterval
+	}
+
+	admin := &litellm.AdminClient{
+		BaseURL:   litellm.ServiceURL(gw.Name, gw.Namespace, gw.Spec.Port),
+		MasterKey: masterKey,
+	}
+	// The admin client bounds the call with its own, generous timeout: /health
+	// makes a real completion per model and slow providers are not failures.
+	report, err := admin.Health(ctx)
+
+	r.probeMu.Lock()
+	r.health.record(key, time.Now())
+	r.probeMu.Unlock()
+
+	if err != nil {
+		log.Info("LiteLLM health check failed", "error", err.Error())
+		r.updateCondition(gw, AiGatewayHealthy, metav1.ConditionUnknown, ReasonHealthCheckFailed, err.Error())
+		return r.HealthCheckInterval
+	}
+
+	status, reason, message := healthCondition(report)
+	r.updateCondition(gw, AiGatewayHealthy, status, reason, message)
+	return r.HealthCheckInterval
+}
+
+// healthCondition condenses a /health report into a condition. The message
+// lists the unhealthy models in sorted order so it is stable across probes
+// and SetStatusCondition does not bump LastTransitionTime for no reason.
+func healthCondition(report *litellm.HealthReport) (metav1.ConditionStatus, string, string) {
+	total := report.HealthyCount + report.UnhealthyCount
+	if report.UnhealthyCount == 0 {
+		return metav1.ConditionTrue, ReasonAllDeploymentsHealthy,
+			fmt.Sprintf("%d/%d model deployments healthy", report.HealthyCount, total)
+	}
+
+	unhealthy := make([]string, 0, len(report.UnhealthyEndpoints))
+	for _, ep := range report.UnhealthyEndpoints {
+		unhealthy = append(unhealthy, ep.Model)
+	}
+	sort.Strings(unhealthy)
+	return metav1.ConditionFalse, ReasonUnhealthyDeployments,
+		fmt.Sprintf("%d/%d model deployments healthy; unhealthy: %s",
+			report.HealthyCount, total, strings.Join(unhealthy, ", "))
+}
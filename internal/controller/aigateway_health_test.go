@@ -0,0 +1,68 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/agentic-layer/ai-gateway-litellm/internal/litellm"
+)
+
+var _ = Describe("AiGateway health sync", func() {
+	It("reports True when every deployment is healthy", func() {
+		status, reason, msg := healthCondition(&litellm.HealthReport{HealthyCount: 2})
+		Expect(status).To(Equal(metav1.ConditionTrue))
+		Expect(reason).To(Equal(ReasonAllDeploymentsHealthy))
+		Expect(msg).To(Equal("2/2 model deployments healthy"))
+	})
+
+	It("lists unhealthy models in a stable order", func() {
+		status, reason, msg := healthCondition(&litellm.HealthReport{
+			HealthyCount:   1,
+			UnhealthyCount: 2,
+			UnhealthyEndpoints: []litellm.HealthEndpoint{
+				{Model: "openai/gpt-4o"},
+				{Model: "anthropic/claude-3-opus"},
+			},
+		})
+		Expect(status).To(Equal(metav1.ConditionFalse))
+		Expect(reason).To(Equal(ReasonUnhealthyDeployments))
+		Expect(msg).To(Equal("1/3 model deployments healthy; unhealthy: anthropic/claude-3-opus, openai/gpt-4o"))
+	})
+
+	It("throttles probes to the configured interval", func() {
+		var tracker probeTracker
+		key := types.NamespacedName{Name: "gw", Namespace: "default"}
+		now := time.Now()
+
+		due, _ := tracker.due(key, time.Minute, now)
+		Expect(due).To(BeTrue())
+
+		tracker.record(key, now)
+		due, wait := tracker.due(key, time.Minute, now.Add(20*time.Second))
+		Expect(due).To(BeFalse())
+		Expect(wait).To(Equal(40 * time.Second))
+
+		due, _ = tracker.due(key, time.Minute, now.Add(time.Minute))
+		Expect(due).To(BeTrue())
+	})
+})
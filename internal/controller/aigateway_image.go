@@ -0,0 +1,129 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/litellm"
+	appsv1 "k8s.io/api/apps/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// AiGatewayImage reports the LiteLLM image the image policy of the
+// gateway's class resolved to.
+const AiGatewayImage = "AiGatewayImage"
+
+// Image condition reasons
+const (
+	ReasonImageResolved         = "ImageResolved"
+	ReasonImageResolutionFailed = "ImageResolutionFailed"
+)
+
+// resolveImage returns the LiteLLM image defaultImage resolves to for gw
+// under policy, "" for defaultImage itself, and stamps the image condition.
+// It also returns when to resolve again, zero if never. When the registry
+// cannot be reached, the gateway keeps the image it last resolved to, or
+// else the one it runs.
+func (r *AiGatewayReconciler) resolveImage(ctx context.Context, gw *gatewayv1alpha1.AiGateway, policy, defaultImage string) (string, time.Duration) {
+	if policy == litellm.ImagePolicyTag || r.ImageResolver == nil {
+		apimeta.RemoveStatusCondition(&gw.Status.Conditions, AiGatewayImage)
+		return "", 0
+	}
+	defaultImage = litellm.MirrorImage(r.RegistryMirror, defaultImage)
+	image, err := r.ImageResolver.Resolve(ctx, defaultImage, policy)
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to resolve the LiteLLM image", "policy", policy)
+		if image == "" {
+			image = r.deployedImage(ctx, gw, defaultImage)
+		}
+		running := image
+		if running == "" {
+			running = defaultImage
+		}
+		r.updateCondition(gw, AiGatewayImage, metav1.ConditionFalse, ReasonImageResolutionFailed,
+			err.Error()+"; running "+running)
+		return image, r.ImageResolver.Interval
+	}
+	r.updateCondition(gw, AiGatewayImage, metav1.ConditionTrue, ReasonImageResolved,
+		defaultImage+" resolved to "+image)
+	r.recordUpgrade(ctx, gw, image)
+	return image, r.ImageResolver.Interval
+}
+
+// recordUpgrade adds image to the upgrade history annotation of gw unless
+// it is the image last recorded. The annotation is written with a patch of
+// its own, so gw keeps the status changes of this reconcile. A failed write
+// is only logged: the next reconcile records the upgrade again.
+func (r *AiGatewayReconciler) recordUpgrade(ctx context.Context, gw *gatewayv1alpha1.AiGateway, image string) {
+	history, changed := litellm.RecordUpgrade(litellm.UpgradeHistory(gw.Annotations), image, time.Now())
+	if !changed || r.DryRun {
+		return
+	}
+	log := logf.FromContext(ctx)
+	value, err := json.Marshal(history)
+	if err == nil {
+		var patch []byte
+		patch, err = json.Marshal(map[string]any{
+			"metadata": map[string]any{"annotations": map[string]any{litellm.UpgradeHistoryAnnotation: string(value)}},
+		})
+		if err == nil {
+			target := &gatewayv1alpha1.AiGateway{ObjectMeta: metav1.ObjectMeta{Name: gw.Name, Namespace: gw.Namespace}}
+			err = r.Patch(ctx, target, client.RawPatch(types.MergePatchType, patch))
+		}
+	}
+	if err != nil {
+		log.Error(err, "Failed to record the LiteLLM upgrade", "image", image)
+		return
+	}
+	if upgrade := history[len(history)-1]; upgrade.From != "" {
+		log.Info("Upgrading LiteLLM", "from", upgrade.From, "to", upgrade.To)
+	}
+}
+
+// image returns the default LiteLLM image of the gateways whose class config
+// sets none.
+func (r *AiGatewayReconciler) image() string {
+	if r.Image != "" {
+		return r.Image
+	}
+	return litellm.Image
+}
+
+// deployedImage returns the LiteLLM image of gw's Deployment if it is one of
+// the repository of defaultImage, or "".
+func (r *AiGatewayReconciler) deployedImage(ctx context.Context, gw *gatewayv1alpha1.AiGateway, defaultImage string) string {
+	deployment := &appsv1.Deployment{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: gw.Namespace, Name: gw.Name}, deployment); err != nil {
+		return ""
+	}
+	repository, _, _ := strings.Cut(defaultImage, ":")
+	for _, c := range deployment.Spec.Template.Spec.Containers {
+		if c.Name == litellm.ContainerName && strings.HasPrefix(c.Image, repository+":") {
+			return c.Image
+		}
+	}
+	return ""
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/litellm"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestAiGatewayReconciler_RecordUpgrade(t *testing.T) {
+	s := runtime.NewScheme()
+	if err := gatewayv1alpha1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	gw := &gatewayv1alpha1.AiGateway{ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "ai-gateway"}}
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(gw).Build()
+	r := &AiGatewayReconciler{Client: c}
+	ctx := context.Background()
+
+	get := func() *gatewayv1alpha1.AiGateway {
+		var got gatewayv1alpha1.AiGateway
+		if err := c.Get(ctx, client.ObjectKeyFromObject(gw), &got); err != nil {
+			t.Fatal(err)
+		}
+		return &got
+	}
+	r.recordUpgrade(ctx, gw, "litellm:v1.83.14@sha256:a")
+	r.recordUpgrade(ctx, get(), "litellm:v1.83.14@sha256:a")
+	r.recordUpgrade(ctx, get(), "litellm:v1.84.0@sha256:b")
+
+	history := litellm.UpgradeHistory(get().Annotations)
+	if len(history) != 2 || history[1].From != "litellm:v1.83.14@sha256:a" || history[1].To != "litellm:v1.84.0@sha256:b" {
+		t.Errorf("history = %+v", history)
+	}
+
+	r.DryRun = true
+	r.recordUpgrade(ctx, get(), "litellm:v1.85.0@sha256:c")
+	if got := litellm.UpgradeHistory(get().Annotations); len(got) != 2 {
+		t.Errorf("dry-run must not record upgrades, got %+v", got)
+	}
+}
+
+var _ = Describe("AiGateway Controller — LiteLLM upgrades", func() {
+	const repository = "berriai/litellm"
+
+	Context("When reconciling an AiGateway whose class upgrades to new patch releases", func() {
+		gatewayKey := types.NamespacedName{Name: "ai-upgrade", Namespace: "default"}
+		classKey := types.NamespacedName{Name: aiGatewayClassName}
+
+		var (
+			registry *httptest.Server
+			mu       sync.Mutex
+			digests  map[string]string
+		)
+
+		publish := func(tag, digest string) {
+			mu.Lock()
+			defer mu.Unlock()
+			digests[tag] = digest
+		}
+
+		BeforeEach(func() {
+			digests = map[string]string{"v1.83.14": "sha256:" + strings.Repeat("a", 64)}
+			registry = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+				switch {
+				case r.URL.Path == "/v2/"+repository+"/tags/list":
+					var tags []string
+					for tag := range digests {
+						tags = append(tags, tag)
+					}
+					_ = json.NewEncoder(w).Encode(map[string]any{"name": repository, "tags": tags})
+				case strings.HasPrefix(r.URL.Path, "/v2/"+repository+"/manifests/"):
+					digest, ok := digests[strings.TrimPrefix(r.URL.Path, "/v2/"+repository+"/manifests/")]
+					if !ok {
+						http.NotFound(w, r)
+						return
+					}
+					w.Header().Set("Docker-Content-Digest", digest)
+					_, _ = w.Write([]byte("{}"))
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+
+			Expect(k8sClient.Create(ctx, &gatewayv1alpha1.AiGatewayClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: classKey.Name,
+					Annotations: map[string]string{
+						"aigatewayclass.kubernetes.io/is-default-class": "true",
+						litellm.UpgradePolicyAnnotation:                 litellm.UpgradePolicyPatch,
+					},
+				},
+				Spec: gatewayv1alpha1.AiGatewayClassSpec{Controller: ControllerName},
+			})).To(Succeed())
+			Expect(k8sClient.Create(ctx, &gatewayv1alpha1.AiGateway{
+				ObjectMeta: metav1.ObjectMeta{Name: gatewayKey.Name, Namespace: gatewayKey.Namespace},
+				Spec: gatewayv1alpha1.AiGatewaySpec{
+					Port:     8000,
+					AiModels: []gatewayv1alpha1.AiModel{{Name: "gpt-4", Provider: "openai"}},
+				},
+			})).To(Succeed())
+		})
+
+		AfterEach(func() {
+			registry.Close()
+			cleanupAiGateway(gatewayKey)
+			cleanupAiGatewayClass(classKey)
+		})
+
+		It("runs the latest patch release and records each upgrade on the gateway", func() {
+			image := strings.TrimPrefix(registry.URL, "https://") + "/" + repository
+			rec := &AiGatewayReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				Image:         image + ":v1.83.14",
+				ImageResolver: &litellm.ImageResolver{Client: registry.Client()},
+			}
+			deployedImage := func() string {
+				deployment := &appsv1.Deployment{}
+				Expect(k8sClient.Get(ctx, gatewayKey, deployment)).To(Succeed())
+				return deployment.Spec.Template.Spec.Containers[0].Image
+			}
+			first := image + ":v1.83.14@sha256:" + strings.Repeat("a", 64)
+
+			_, err := rec.Reconcile(ctx, reconcile.Request{NamespacedName: gatewayKey})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(deployedImage()).To(Equal(first))
+			gw := &gatewayv1alpha1.AiGateway{}
+			Expect(k8sClient.Get(ctx, gatewayKey, gw)).To(Succeed())
+			history := litellm.UpgradeHistory(gw.Annotations)
+			Expect(history).To(HaveLen(1))
+			Expect(history[0].From).To(BeEmpty())
+			Expect(history[0].To).To(Equal(first))
+			cond := findCondition(gw.Status.Conditions, AiGatewayImage)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+			Expect(cond.Reason).To(Equal(ReasonImageResolved))
+
+			By("Reconciling again without a new release")
+			_, err = rec.Reconcile(ctx, reconcile.Request{NamespacedName: gatewayKey})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, gatewayKey, gw)).To(Succeed())
+			Expect(litellm.UpgradeHistory(gw.Annotations)).To(HaveLen(1))
+
+			By("Publishing a patch release")
+			publish("v1.83.15", "sha256:"+strings.Repeat("b", 64))
+			second := image + ":v1.83.15@sha256:" + strings.Repeat("b", 64)
+			_, err = rec.Reconcile(ctx, reconcile.Request{NamespacedName: gatewayKey})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(deployedImage()).To(Equal(second))
+			Expect(k8sClient.Get(ctx, gatewayKey, gw)).To(Succeed())
+			history = litellm.UpgradeHistory(gw.Annotations)
+			Expect(history).To(HaveLen(2))
+			Expect(history[1].From).To(Equal(first))
+			Expect(history[1].To).To(Equal(second))
+			cond = findCondition(gw.Status.Conditions, AiGatewayImage)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Message).To(HaveSuffix("resolved to " + second))
+		})
+	})
+})
@@ -0,0 +1,75 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	litellmv1alpha1 "github.com/agentic-layer/ai-gateway-litellm/api/v1alpha1"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/litellm"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGenerateAiGatewayConfig_LangfuseFromClass(t *testing.T) {
+	s := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{gatewayv1alpha1.AddToScheme, litellmv1alpha1.AddToScheme, corev1.AddToScheme} {
+		if err := add(s); err != nil {
+			t.Fatalf("AddToScheme: %v", err)
+		}
+	}
+	class := &gatewayv1alpha1.AiGatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "litellm", Annotations: map[string]string{
+			litellm.LangfuseHostAnnotation:   "https://langfuse.example.com",
+			litellm.LangfuseSecretAnnotation: "langfuse-keys",
+		}},
+		Spec: gatewayv1alpha1.AiGatewayClassSpec{Controller: ControllerName},
+	}
+	gw := &gatewayv1alpha1.AiGateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "team-a"},
+		Spec: gatewayv1alpha1.AiGatewaySpec{
+			AiGatewayClassName: "litellm",
+			AiModels:           []gatewayv1alpha1.AiModel{{Name: "gpt-4o", Provider: "openai"}},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(class, gw).Build()
+
+	config, err := GenerateAiGatewayConfig(context.Background(), c, nil, gw)
+	if err != nil {
+		t.Fatalf("GenerateAiGatewayConfig: %v", err)
+	}
+	for _, want := range []string{"success_callback:\n        - langfuse", "failure_callback:\n        - langfuse", "langfuse_default_tags:\n        - proxy_base_url"} {
+		if !strings.Contains(config, want) {
+			t.Errorf("want %q in the config, got\n%s", want, config)
+		}
+	}
+
+	// The gateway opts out of its class's project.
+	gw.Annotations = map[string]string{litellm.LangfuseHostAnnotation: ""}
+	config, err = GenerateAiGatewayConfig(context.Background(), c, nil, gw)
+	if err != nil {
+		t.Fatalf("GenerateAiGatewayConfig: %v", err)
+	}
+	if strings.Contains(config, "langfuse") {
+		t.Errorf("want no Langfuse callback, got\n%s", config)
+	}
+}
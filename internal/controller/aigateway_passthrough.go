@@ -0,0 +1,101 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	litellmv1alpha1 "github.com/agentic-layer/ai-gateway-litellm/api/v1alpha1"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/litellm"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// passThroughEndpoints returns the LiteLLMPassThroughEndpoints aiGateway
+// lists in its litellm.PassThroughEndpointsAnnotation. Missing endpoints
+// and paths served twice are a *litellm.PhaseError tagged
+// litellm.PassThroughPhase.
+func passThroughEndpoints(ctx context.Context, c client.Reader, aiGateway *gatewayv1alpha1.AiGateway) ([]litellmv1alpha1.LiteLLMPassThroughEndpoint, error) {
+	names := litellm.PassThroughEndpointNames(aiGateway.Annotations)
+	endpoints := make([]litellmv1alpha1.LiteLLMPassThroughEndpoint, 0, len(names))
+	paths := make(map[string]string, len(names))
+	for _, name := range names {
+		var endpoint litellmv1alpha1.LiteLLMPassThroughEndpoint
+		if err := c.Get(ctx, types.NamespacedName{Namespace: aiGateway.Namespace, Name: name}, &endpoint); err != nil {
+			if apierrors.IsNotFound(err) {
+				err = fmt.Errorf("LiteLLMPassThroughEndpoint %s not found", name)
+			}
+			return nil, &litellm.PhaseError{Phase: litellm.PassThroughPhase, Err: err}
+		}
+		if other, ok := paths[endpoint.Spec.Path]; ok {
+			return nil, &litellm.PhaseError{Phase: litellm.PassThroughPhase, Err: fmt.Errorf(
+				"LiteLLMPassThroughEndpoints %s and %s both serve path %s", other, name, endpoint.Spec.Path)}
+		}
+		paths[endpoint.Spec.Path] = name
+		endpoints = append(endpoints, endpoint)
+	}
+	return endpoints, nil
+}
+
+// passThroughConfig renders endpoints as general_settings entries. Auth
+// credentials are referenced by env var; see passThroughEnvVars.
+func passThroughConfig(endpoints []litellmv1alpha1.LiteLLMPassThroughEndpoint) []litellm.PassThroughEndpoint {
+	if len(endpoints) == 0 {
+		return nil
+	}
+	config := make([]litellm.PassThroughEndpoint, len(endpoints))
+	for i, endpoint := range endpoints {
+		config[i] = litellm.PassThroughEndpoint{
+			Path:           endpoint.Spec.Path,
+			Target:         endpoint.Spec.Target,
+			ForwardHeaders: endpoint.Spec.ForwardHeaders,
+		}
+		if auth := endpoint.Spec.Auth; auth != nil {
+			value := "os.environ/" + litellm.PassThroughAuthEnvVar(endpoint.Name)
+			if auth.Scheme == litellmv1alpha1.PassThroughAuthBearer {
+				value = "bearer " + value
+			}
+			header := auth.Header
+			if header == "" {
+				header = "Authorization"
+			}
+			config[i].Headers = map[string]string{header: value}
+		}
+	}
+	return config
+}
+
+// passThroughEnvVars returns the env vars carrying the auth credentials of
+// endpoints from their Secrets.
+func passThroughEnvVars(endpoints []litellmv1alpha1.LiteLLMPassThroughEndpoint) []corev1.EnvVar {
+	var envs []corev1.EnvVar
+	for _, endpoint := range endpoints {
+		if endpoint.Spec.Auth == nil {
+			continue
+		}
+		ref := endpoint.Spec.Auth.SecretKeyRef
+		envs = append(envs, corev1.EnvVar{
+			Name:      litellm.PassThroughAuthEnvVar(endpoint.Name),
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &ref},
+		})
+	}
+	return envs
+}
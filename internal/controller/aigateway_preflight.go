@@ -0,0 +1,149 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/litellm"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// AiGatewayPreflight reports the preflight check of each provider of a
+// gateway with the litellm.PreflightAnnotation.
+const AiGatewayPreflight = "AiGatewayPreflight"
+
+// Preflight condition reasons. ReasonPreflightFailed also holds back
+// AiGatewayConfigured.
+const (
+	ReasonPreflightPassed = "PreflightPassed"
+	ReasonPreflightFailed = "PreflightFailed"
+)
+
+// defaultPreflightInterval is how long preflight results are reused when
+// the reconciler sets no PreflightInterval.
+const defaultPreflightInterval = time.Hour
+
+// preflightRun is the last preflight check of a gateway. It is reused until
+// the providers or their keys change, or the preflight interval elapses.
+type preflightRun struct {
+	fingerprint string
+	at          time.Time
+	results     []litellm.ProviderResult
+}
+
+// preflight checks the providers of gw with the API keys of env, unless the
+// last check of the same keys is recent, and stamps the preflight
+// condition. It reports whether every check passed, and otherwise when to
+// check again. The condition is removed when gw does not ask for preflight
+// checks or calls no provider directly.
+func (r *AiGatewayReconciler) preflight(ctx context.Context, gw *gatewayv1alpha1.AiGateway, env []corev1.EnvVar) (bool, time.Duration) {
+	key := types.NamespacedName{Name: gw.Name, Namespace: gw.Namespace}
+	_, upstream := litellm.UpstreamRef(gw)
+	if !litellm.Preflight(gw.Annotations) || upstream {
+		r.forgetPreflight(key)
+		apimeta.RemoveStatusCondition(&gw.Status.Conditions, AiGatewayPreflight)
+		return true, 0
+	}
+
+	var providers []string
+	for _, model := range gw.Spec.AiModels {
+		if !litellm.KeylessProviders.Has(model.Provider) && !slices.Contains(providers, model.Provider) {
+			providers = append(providers, model.Provider)
+		}
+	}
+	if len(providers) == 0 {
+		r.forgetPreflight(key)
+		apimeta.RemoveStatusCondition(&gw.Status.Conditions, AiGatewayPreflight)
+		return true, 0
+	}
+	slices.Sort(providers)
+
+	apiKeys := make([]string, len(providers))
+	fingerprint := sha256.New()
+	for i, provider := range providers {
+		apiKey, err := litellm.EnvValue(ctx, r, gw.Namespace, env, providerApiKeyEnvVar(gatewayv1alpha1.AiModel{Provider: provider}))
+		if err != nil {
+			r.updateCondition(gw, AiGatewayPreflight, metav1.ConditionFalse, ReasonPreflightFailed, err.Error())
+			return false, time.Minute
+		}
+		apiKeys[i] = apiKey
+		fmt.Fprintf(fingerprint, "%s=%s\n", provider, apiKey)
+	}
+
+	interval := cmp.Or(r.PreflightInterval, defaultPreflightInterval)
+	now := time.Now()
+	run := preflightRun{fingerprint: fmt.Sprintf("%x", fingerprint.Sum(nil))}
+	r.probeMu.Lock()
+	last, ok := r.preflights[key]
+	r.probeMu.Unlock()
+	if ok && last.fingerprint == run.fingerprint && now.Sub(last.at) < interval {
+		run = last
+	} else {
+		checker := r.PreflightChecker
+		if checker == nil {
+			checker = &litellm.PreflightChecker{}
+		}
+		run.at = now
+		for i, provider := range providers {
+			run.results = append(run.results, checker.Check(ctx, provider, apiKeys[i]))
+		}
+		r.probeMu.Lock()
+		if r.preflights == nil {
+			r.preflights = map[types.NamespacedName]preflightRun{}
+		}
+		r.preflights[key] = run
+		r.probeMu.Unlock()
+		logf.FromContext(ctx).Info("Checked providers", "results", preflightMessage(run.results))
+	}
+
+	message := preflightMessage(run.results)
+	for _, result := range run.results {
+		if result.Err != nil {
+			r.updateCondition(gw, AiGatewayPreflight, metav1.ConditionFalse, ReasonPreflightFailed, message)
+			return false, max(interval-now.Sub(run.at), time.Second)
+		}
+	}
+	r.updateCondition(gw, AiGatewayPreflight, metav1.ConditionTrue, ReasonPreflightPassed, message)
+	return true, 0
+}
+
+func (r *AiGatewayReconciler) forgetPreflight(key types.NamespacedName) {
+	r.probeMu.Lock()
+	delete(r.preflights, key)
+	r.probeMu.Unlock()
+}
+
+// preflightMessage lists the result of each provider, in provider order.
+func preflightMessage(results []litellm.ProviderResult) string {
+	parts := make([]string, len(results))
+	for i, result := range results {
+		parts[i] = result.String()
+	}
+	return strings.Join(parts, "; ")
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/litellm"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestAiGatewayReconciler_Preflight(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Header.Get("Authorization") != "Bearer sk-good" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer srv.Close()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: litellm.ApiKeySecretName, Namespace: "ai-gateway"},
+		Data:       map[string][]byte{"OPENAI_API_KEY": []byte("sk-bad")},
+	}
+	c := fake.NewClientBuilder().WithObjects(secret).Build()
+	r := &AiGatewayReconciler{
+		Client:           c,
+		PreflightChecker: &litellm.PreflightChecker{URLs: map[string]string{"openai": srv.URL}},
+	}
+	gw := &gatewayv1alpha1.AiGateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "gw",
+			Namespace:   "ai-gateway",
+			Annotations: map[string]string{litellm.PreflightAnnotation: "true"},
+		},
+		Spec: gatewayv1alpha1.AiGatewaySpec{AiModels: []gatewayv1alpha1.AiModel{
+			{Name: "gpt-4o", Provider: "openai"},
+			{Name: "llama3", Provider: "ollama"},
+			{Name: "sonar", Provider: "perplexity"},
+		}},
+	}
+	env := r.buildEnvironmentVariables(gw, nil, nil, nil, nil)
+	ctx := context.Background()
+
+	passed, retry := r.preflight(ctx, gw, env)
+	cond := apimeta.FindStatusCondition(gw.Status.Conditions, AiGatewayPreflight)
+	if passed || retry <= 0 || cond == nil || cond.Reason != ReasonPreflightFailed ||
+		cond.Message != "openai: API key rejected with status 401; perplexity: not checked" {
+		t.Fatalf("got %v, %v, %+v", passed, retry, cond)
+	}
+
+	// The same keys are not checked again within the interval.
+	r.preflight(ctx, gw, env)
+	if calls != 1 {
+		t.Errorf("want 1 call, got %d", calls)
+	}
+
+	secret.Data["OPENAI_API_KEY"] = []byte("sk-good")
+	if err := c.Update(ctx, secret); err != nil {
+		t.Fatal(err)
+	}
+	passed, _ = r.preflight(ctx, gw, env)
+	cond = apimeta.FindStatusCondition(gw.Status.Conditions, AiGatewayPreflight)
+	if !passed || calls != 2 || cond.Status != metav1.ConditionTrue {
+		t.Errorf("a changed key must be checked again: got %v after %d calls, %+v", passed, calls, cond)
+	}
+
+	r.PreflightInterval = time.Nanosecond
+	r.preflight(ctx, gw, env)
+	if calls != 3 {
+		t.Errorf("want a check after the interval, got %d calls", calls)
+	}
+
+	delete(gw.Annotations, litellm.PreflightAnnotation)
+	if passed, _ := r.preflight(ctx, gw, env); !passed || apimeta.FindStatusCondition(gw.Status.Conditions, AiGatewayPreflight) != nil {
+		t.Error("without the annotation the condition must be removed")
+	}
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/litellm"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// AiGatewayReplicated reports whether the gateway is replicated to the
+// clusters listed in the replicate-to annotation.
+const AiGatewayReplicated = "AiGatewayReplicated"
+
+// Replication condition reasons
+const (
+	ReasonReplicated        = "Replicated"
+	ReasonReplicationFailed = "ReplicationFailed"
+)
+
+// errReplicationDisabled is returned for every cluster while the reconciler
+// has no ReplicationNamespace to read kubeconfigs from.
+var errReplicationDisabled = errors.New("replication is disabled: no namespace for kubeconfig Secrets is configured")
+
+// syncReplicas applies the workload of gw to the clusters listed in its
+// litellm.ReplicateToAnnotation and deletes the replicas of clusters no
+// longer listed, or of all clusters once gw is deleted. The clusters
+// holding replicas are recorded on gw before they are written to, so a
+// replica is never lost track of. Failures are reported on the
+// AiGatewayReplicated condition and returned for a retry with backoff.
+func (r *AiGatewayReconciler) syncReplicas(ctx context.Context, gw *gatewayv1alpha1.AiGateway, workload litellm.GatewayWorkload) error {
+	log := logf.FromContext(ctx)
+	var targets []string
+	if gw.DeletionTimestamp.IsZero() {
+		targets = litellm.ReplicationTargets(gw.Annotations, litellm.ReplicateToAnnotation)
+	}
+	recorded := litellm.ReplicationTargets(gw.Annotations, litellm.ReplicatedToAnnotation)
+	if r.DryRun {
+		if len(targets) > 0 {
+			log.Info("Dry-run: not replicating to remote clusters", "clusters", targets)
+		}
+		return nil
+	}
+	if err := r.recordReplicas(ctx, gw, union(targets, recorded)); err != nil {
+		return err
+	}
+
+	var failures []string
+	for _, target := range targets {
+		remote, err := r.replicaClient(ctx, target)
+		if err == nil {
+			err = litellm.ReplicateWorkload(ctx, r, remote, workload)
+		}
+		if err != nil {
+			log.Error(err, "Failed to replicate AiGateway", "cluster", target)
+			failures = append(failures, fmt.Sprintf("%s: %v", target, err))
+		}
+	}
+	remaining := targets
+	for _, stale := range recorded {
+		if slices.Contains(targets, stale) {
+			continue
+		}
+		remote, err := r.replicaClient(ctx, stale)
+		if apierrors.IsNotFound(err) || errors.Is(err, errReplicationDisabled) {
+			// Without its kubeconfig the cluster cannot be reached again;
+			// its replica is left behind rather than holding the gateway.
+			log.Info("Kubeconfig Secret unavailable, leaving replica behind", "cluster", stale)
+			continue
+		}
+		if err == nil {
+			err = litellm.DeleteReplica(ctx, remote, workload)
+		}
+		if err != nil {
+			log.Error(err, "Failed to delete replica", "cluster", stale)
+			failures = append(failures, fmt.Sprintf("%s: %v", stale, err))
+			remaining = append(remaining, stale)
+		}
+	}
+	if err := r.recordReplicas(ctx, gw, remaining); err != nil {
+		return err
+	}
+
+	switch {
+	case len(failures) > 0:
+		r.updateCondition(gw, AiGatewayReplicated, metav1.ConditionFalse, ReasonReplicationFailed, strings.Join(failures, "; "))
+		return fmt.Errorf("replicating AiGateway: %s", strings.Join(failures, "; "))
+	case len(targets) > 0:
+		r.updateCondition(gw, AiGatewayReplicated, metav1.ConditionTrue, ReasonReplicated,
+			fmt.Sprintf("Replicated to the clusters of %s", strings.Join(targets, ", ")))
+	default:
+		apimeta.RemoveStatusCondition(&gw.Status.Conditions, AiGatewayReplicated)
+	}
+	return nil
+}
+
+// recordReplicas sets litellm.ReplicatedToAnnotation of gw to clusters and
+// holds gw with litellm.ReplicaFinalizer while there are any. The status
+// subresource ignores the metadata, so the later status patch of gw does
+// not write it again.
+func (r *AiGatewayReconciler) recordReplicas(ctx context.Context, gw *gatewayv1alpha1.AiGateway, clusters []string) error {
+	patched := gw.DeepCopy()
+	if len(clusters) > 0 {
+		metav1.SetMetaDataAnnotation(&patched.ObjectMeta, litellm.ReplicatedToAnnotation, strings.Join(clusters, ","))
+		controllerutil.AddFinalizer(patched, litellm.ReplicaFinalizer)
+	} else {
+		delete(patched.Annotations, litellm.ReplicatedToAnnotation)
+		controllerutil.RemoveFinalizer(patched, litellm.ReplicaFinalizer)
+	}
+	if slices.Equal(patched.Finalizers, gw.Finalizers) &&
+		patched.Annotations[litellm.ReplicatedToAnnotation] == gw.Annotations[litellm.ReplicatedToAnnotation] {
+		return nil
+	}
+	if err := r.Patch(ctx, patched, client.MergeFromWithOptions(gw, client.MergeFromWithOptimisticLock{})); err != nil {
+		return fmt.Errorf("recording replicas: %w", err)
+	}
+	gw.Annotations, gw.Finalizers, gw.ResourceVersion = patched.Annotations, patched.Finalizers, patched.ResourceVersion
+	return nil
+}
+
+// replicaClient returns a client for the cluster whose kubeconfig is held
+// by the Secret name in r.ReplicationNamespace. The namespace is the
+// operator's, never the gateway's: a kubeconfig decides what the client
+// connects to and with which credentials. A missing Secret is returned as
+// is, so callers can tell it apart.
+func (r *AiGatewayReconciler) replicaClient(ctx context.Context, name string) (client.Client, error) {
+	if r.ReplicationNamespace == "" {
+		return nil, errReplicationDisabled
+	}
+	var secret corev1.Secret
+	if err := r.Get(ctx, client.ObjectKey{Namespace: r.ReplicationNamespace, Name: name}, &secret); err != nil {
+		return nil, err
+	}
+	kubeconfig, ok := secret.Data[litellm.KubeconfigKey]
+	if !ok {
+		return nil, fmt.Errorf("secret %s has no %s key", name, litellm.KubeconfigKey)
+	}
+	newClient := r.RemoteClient
+	if newClient == nil {
+		newClient = litellm.NewRemoteClient
+	}
+	return newClient(kubeconfig, r.Scheme)
+}
+
+// union returns the sorted, de-duplicated names of a and b.
+func union(a, b []string) []string {
+	names := slices.Concat(a, b)
+	slices.Sort(names)
+	return slices.Compact(names)
+}
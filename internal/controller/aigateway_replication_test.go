@@ -0,0 +1,149 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/litellm"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestSyncReplicas(t *testing.T) {
+	s := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{gatewayv1alpha1.AddToScheme, corev1.AddToScheme, appsv1.AddToScheme} {
+		if err := add(s); err != nil {
+			t.Fatalf("AddToScheme: %v", err)
+		}
+	}
+	gw := &gatewayv1alpha1.AiGateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "gw",
+			Namespace:   "ai-gateway",
+			UID:         "gw-uid",
+			Annotations: map[string]string{litellm.ReplicateToAnnotation: "eu-west"},
+		},
+	}
+	kubeconfig := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "eu-west", Namespace: "ai-gateway-litellm-system"},
+		Data:       map[string][]byte{litellm.KubeconfigKey: []byte("eu-west")},
+	}
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(gw, kubeconfig).Build()
+	remotes := map[string]client.Client{"eu-west": fake.NewClientBuilder().WithScheme(s).Build()}
+	r := &AiGatewayReconciler{Client: c, Scheme: s, ReplicationNamespace: "ai-gateway-litellm-system",
+		RemoteClient: func(kubeconfig []byte, _ *runtime.Scheme) (client.Client, error) {
+			return remotes[string(kubeconfig)], nil
+		},
+	}
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "gw", Namespace: "ai-gateway"}
+	workload := func(gw *gatewayv1alpha1.AiGateway) litellm.GatewayWorkload {
+		return litellm.GatewayWorkload{Name: gw.Name, Namespace: gw.Namespace, Owner: gw, ContainerPort: 4000, ServicePort: 4000, ConfigYAML: "model_list: []\n"}
+	}
+
+	var current gatewayv1alpha1.AiGateway
+	if err := c.Get(ctx, key, &current); err != nil {
+		t.Fatalf("get AiGateway: %v", err)
+	}
+	if err := r.syncReplicas(ctx, &current, workload(&current)); err != nil {
+		t.Fatalf("syncReplicas: %v", err)
+	}
+	var deployment appsv1.Deployment
+	if err := remotes["eu-west"].Get(ctx, key, &deployment); err != nil {
+		t.Fatalf("get replicated Deployment: %v", err)
+	}
+	if deployment.Labels[litellm.ReplicaOfLabel] != "gw" || len(deployment.OwnerReferences) != 0 {
+		t.Errorf("replica Deployment: labels %v, owner references %v", deployment.Labels, deployment.OwnerReferences)
+	}
+	if cond := apimeta.FindStatusCondition(current.Status.Conditions, AiGatewayReplicated); cond == nil || cond.Reason != ReasonReplicated {
+		t.Errorf("AiGatewayReplicated: got %+v", cond)
+	}
+	if err := c.Get(ctx, key, &current); err != nil {
+		t.Fatalf("get AiGateway: %v", err)
+	}
+	if !slices.Contains(current.Finalizers, litellm.ReplicaFinalizer) || current.Annotations[litellm.ReplicatedToAnnotation] != "eu-west" {
+		t.Fatalf("replicas not recorded: finalizers %v, annotations %v", current.Finalizers, current.Annotations)
+	}
+
+	// Dropping the cluster deletes its replica and releases the gateway.
+	delete(current.Annotations, litellm.ReplicateToAnnotation)
+	if err := c.Update(ctx, &current); err != nil {
+		t.Fatalf("update AiGateway: %v", err)
+	}
+	if err := r.syncReplicas(ctx, &current, workload(&current)); err != nil {
+		t.Fatalf("syncReplicas: %v", err)
+	}
+	if err := remotes["eu-west"].Get(ctx, key, &deployment); !apierrors.IsNotFound(err) {
+		t.Errorf("replica Deployment still present: %v", err)
+	}
+	if err := c.Get(ctx, key, &current); err != nil {
+		t.Fatalf("get AiGateway: %v", err)
+	}
+	if len(current.Finalizers) != 0 || current.Annotations[litellm.ReplicatedToAnnotation] != "" {
+		t.Errorf("replicas still recorded: finalizers %v, annotations %v", current.Finalizers, current.Annotations)
+	}
+}
+
+func TestSyncReplicas_IgnoresKubeconfigsInGatewayNamespace(t *testing.T) {
+	s := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{gatewayv1alpha1.AddToScheme, corev1.AddToScheme} {
+		if err := add(s); err != nil {
+			t.Fatalf("AddToScheme: %v", err)
+		}
+	}
+	gw := &gatewayv1alpha1.AiGateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "gw",
+			Namespace:   "ai-gateway",
+			Annotations: map[string]string{litellm.ReplicateToAnnotation: "eu-west"},
+		},
+	}
+	kubeconfig := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "eu-west", Namespace: "ai-gateway"},
+		Data:       map[string][]byte{litellm.KubeconfigKey: []byte("eu-west")},
+	}
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(gw, kubeconfig).Build()
+	for _, namespace := range []string{"", "ai-gateway-litellm-system"} {
+		r := &AiGatewayReconciler{Client: c, Scheme: s, ReplicationNamespace: namespace,
+			RemoteClient: func([]byte, *runtime.Scheme) (client.Client, error) {
+				t.Fatal("kubeconfig of the gateway's namespace used")
+				return nil, nil
+			},
+		}
+		var current gatewayv1alpha1.AiGateway
+		if err := c.Get(context.Background(), client.ObjectKeyFromObject(gw), &current); err != nil {
+			t.Fatalf("get AiGateway: %v", err)
+		}
+		if err := r.syncReplicas(context.Background(), &current, litellm.GatewayWorkload{Name: "gw", Namespace: "ai-gateway"}); err == nil {
+			t.Errorf("namespace %q: syncReplicas succeeded without a kubeconfig", namespace)
+		}
+		if cond := apimeta.FindStatusCondition(current.Status.Conditions, AiGatewayReplicated); cond == nil || cond.Reason != ReasonReplicationFailed {
+			t.Errorf("namespace %q: AiGatewayReplicated: got %+v", namespace, cond)
+		}
+	}
+}
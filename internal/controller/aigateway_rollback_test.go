@@ -0,0 +1,47 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/litellm"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestPlanAiGateway_RollbackReportsInvalidAdminUI(t *testing.T) {
+	s := runtime.NewScheme()
+	if err := gatewayv1alpha1.AddToScheme(s); err != nil {
+		t.Fatalf("AddToScheme: %v", err)
+	}
+	gw := &gatewayv1alpha1.AiGateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "team-a", Annotations: map[string]string{
+			litellm.RollbackToAnnotation: "3",
+			litellm.AdminUIAnnotation:    "maybe",
+		}},
+	}
+	r := &AiGatewayReconciler{Client: fake.NewClientBuilder().WithScheme(s).Build(), Scheme: s}
+
+	_, err := r.planAiGateway(context.Background(), gw, &gatewayv1alpha1.AiGatewayClass{})
+	if reason := configFailureReason(err); reason != ReasonAdminUIInvalid {
+		t.Errorf("got reason %s (%v), want %s", reason, err, ReasonAdminUIInvalid)
+	}
+}
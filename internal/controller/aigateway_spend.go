@@ -0,0 +1,120 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/litellm"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// AiGatewaySpend publishes the aggregate spend LiteLLM has recorded in its
+// database. The AiGateway status is owned by the agent-runtime-operator API,
+// so the figures travel in the condition message.
+const AiGatewaySpend = "AiGatewaySpend"
+
+// Spend condition reasons
+const (
+	ReasonSpendReported    = "SpendReported"
+	ReasonSpendQueryFailed = "SpendQueryFailed"
+)
+
+// spendModelLimit caps how many models are requested from
+// /global/spend/models and listed in the condition message.
+const spendModelLimit = 10
+
+// syncSpend reads total and per-model spend from a database-backed gateway
+// when the configured interval has elapsed, stamps the AiGatewaySpend
+// condition and updates the spend gauges. Like syncHealth, failures are only
+// reported on the condition. Returns the delay until the next sync is due.
+func (r *AiGatewayReconciler) syncSpend(ctx context.Context, gw *gatewayv1alpha1.AiGateway, env []corev1.EnvVar) time.Duration {
+	log := logf.FromContext(ctx)
+	key := types.NamespacedName{Name: gw.Name, Namespace: gw.Namespace}
+
+	r.probeMu.Lock()
+	due, wait := r.spend.due(key, r.SpendSyncInterval, time.Now())
+	r.probeMu.Unlock()
+	if !due {
+		return wait
+	}
+
+	masterKey, err := litellm.ResolveMasterKey(ctx, r, gw.Namespace, env)
+	if err != nil {
+		r.updateCondition(gw, AiGatewaySpend, metav1.ConditionUnknown, ReasonSpendQueryFailed, err.Error())
+		return r.SpendSyncInterval
+	}
+
+	admin := &litellm.AdminClient{
+		BaseURL:   litellm.ServiceURL(gw.Name, gw.Namespace, gw.Spec.Port),
+		MasterKey: masterKey,
+	}
+	total, err := admin.GlobalSpend(ctx)
+	var models []litellm.ModelSpend
+	if err == nil {
+		models, err = admin.GlobalSpendByModel(ctx, spendModelLimit)
+	}
+
+	r.probeMu.Lock()
+	r.spend.record(key, time.Now())
+	r.probeMu.Unlock()
+
+	if err != nil {
+		log.Info("LiteLLM spend query failed", "error", err.Error())
+		r.updateCondition(gw, AiGatewaySpend, metav1.ConditionUnknown, ReasonSpendQueryFailed, err.Error())
+		return r.SpendSyncInterval
+	}
+
+	recordSpendMetrics(key, total, models)
+	r.updateCondition(gw, AiGatewaySpend, metav1.ConditionTrue, ReasonSpendReported, spendMessage(total, models))
+	return r.SpendSyncInterval
+}
+
+// recordSpendMetrics replaces the spend series of one gateway. Per-model
+// series are reset first so models that dropped out of the top list vanish.
+func recordSpendMetrics(key types.NamespacedName, total *litellm.GlobalSpend, models []litellm.ModelSpend) {
+	gatewaySpend.WithLabelValues(key.Namespace, key.Name).Set(total.Spend)
+	gatewayModelSpend.DeletePartialMatch(prometheus.Labels{"namespace": key.Namespace, "name": key.Name})
+	for _, m := range models {
+		gatewayModelSpend.WithLabelValues(key.Namespace, key.Name, m.Model).Set(m.TotalSpend)
+	}
+}
+
+// spendMessage renders total and per-model spend in the order LiteLLM
+// returned them (most expensive first).
+func spendMessage(total *litellm.GlobalSpend, models []litellm.ModelSpend) string {
+	msg := fmt.Sprintf("total spend $%.2f", total.Spend)
+	if total.MaxBudget > 0 {
+		msg += fmt.Sprintf(" of $%.2f budget", total.MaxBudget)
+	}
+	if len(models) == 0 {
+		return msg
+	}
+	parts := make([]string, 0, len(models))
+	for _, m := range models {
+		parts = append(parts, fmt.Sprintf("%s=$%.2f", m.Model, m.TotalSpend))
+	}
+	return msg + "; by model: " + strings.Join(parts, ", ")
+}
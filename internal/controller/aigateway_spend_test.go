@@ -0,0 +1,70 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/agentic-layer/ai-gateway-litellm/internal/litellm"
+)
+
+func TestSpendMessage(t *testing.T) {
+	got := spendMessage(&litellm.GlobalSpend{Spend: 12.5, MaxBudget: 100}, []litellm.ModelSpend{
+		{Model: "gpt-4o", TotalSpend: 10},
+		{Model: "claude-3-opus", TotalSpend: 2.5},
+	})
+	want := "total spend $12.50 of $100.00 budget; by model: gpt-4o=$10.00, claude-3-opus=$2.50"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got := spendMessage(&litellm.GlobalSpend{Spend: 0}, nil); got != "total spend $0.00" {
+		t.Errorf("empty: got %q", got)
+	}
+}
+
+func TestRecordSpendMetrics_ReplacesModelSeries(t *testing.T) {
+	key := types.NamespacedName{Name: "gw", Namespace: "spend-test"}
+	defer forgetSpendMetrics(key)
+
+	recordSpendMetrics(key, &litellm.GlobalSpend{Spend: 3}, []litellm.ModelSpend{
+		{Model: "a", TotalSpend: 2}, {Model: "b", TotalSpend: 1},
+	})
+	recordSpendMetrics(key, &litellm.GlobalSpend{Spend: 5}, []litellm.ModelSpend{
+		{Model: "a", TotalSpend: 5},
+	})
+
+	want := `
+# HELP litellm_gateway_spend_usd Total spend reported by LiteLLM /global/spend for an AiGateway, in USD.
+# TYPE litellm_gateway_spend_usd gauge
+litellm_gateway_spend_usd{name="gw",namespace="spend-test"} 5
+`
+	if err := testutil.CollectAndCompare(gatewaySpend, strings.NewReader(want)); err != nil {
+		t.Errorf("total: %v", err)
+	}
+	if got := testutil.CollectAndCount(gatewayModelSpend); got != 1 {
+		t.Errorf("model series: got %d, want 1", got)
+	}
+
+	forgetSpendMetrics(key)
+	if got := testutil.CollectAndCount(gatewaySpend); got != 0 {
+		t.Errorf("total series after forget: got %d, want 0", got)
+	}
+}
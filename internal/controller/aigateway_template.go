@@ -0,0 +1,51 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	litellmv1alpha1 "github.com/agentic-layer/ai-gateway-litellm/api/v1alpha1"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/litellm"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// gatewayTemplate returns the spec of the LiteLLMGatewayTemplate aiGateway
+// names in its litellm.GatewayTemplateAnnotation, or nil without one. A
+// missing or invalid template is a *litellm.PhaseError tagged
+// litellm.GatewayTemplatePhase.
+func gatewayTemplate(ctx context.Context, c client.Reader, aiGateway *gatewayv1alpha1.AiGateway) (*litellmv1alpha1.LiteLLMGatewayTemplateSpec, error) {
+	name := aiGateway.Annotations[litellm.GatewayTemplateAnnotation]
+	if name == "" {
+		return nil, nil
+	}
+	var template litellmv1alpha1.LiteLLMGatewayTemplate
+	if err := c.Get(ctx, types.NamespacedName{Namespace: aiGateway.Namespace, Name: name}, &template); err != nil {
+		if apierrors.IsNotFound(err) {
+			err = fmt.Errorf("LiteLLMGatewayTemplate %s not found", name)
+		}
+		return nil, &litellm.PhaseError{Phase: litellm.GatewayTemplatePhase, Err: err}
+	}
+	if err := litellm.ValidateGatewayTemplate(&template.Spec); err != nil {
+		return nil, &litellm.PhaseError{Phase: litellm.GatewayTemplatePhase, Err: fmt.Errorf("LiteLLMGatewayTemplate %s: %w", name, err)}
+	}
+	return &template.Spec, nil
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	litellmv1alpha1 "github.com/agentic-layer/ai-gateway-litellm/api/v1alpha1"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/litellm"
+)
+
+var _ = Describe("AiGateway Controller — gateway templates", func() {
+	const (
+		testNS   = "default"
+		testPort = int32(8000)
+	)
+
+	classKey := types.NamespacedName{Name: aiGatewayClassName}
+
+	newGateway := func(key types.NamespacedName, template string) {
+		Expect(k8sClient.Create(ctx, &gatewayv1alpha1.AiGateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        key.Name,
+				Namespace:   key.Namespace,
+				Annotations: map[string]string{litellm.GatewayTemplateAnnotation: template},
+			},
+			Spec: gatewayv1alpha1.AiGatewaySpec{
+				Port:     testPort,
+				AiModels: []gatewayv1alpha1.AiModel{{Name: "gpt-4", Provider: "openai"}},
+			},
+		})).To(Succeed())
+	}
+
+	cleanupTemplate := func(key types.NamespacedName) {
+		template := &litellmv1alpha1.LiteLLMGatewayTemplate{}
+		if err := k8sClient.Get(ctx, key, template); err == nil {
+			Expect(k8sClient.Delete(ctx, template)).To(Succeed())
+		}
+	}
+
+	expectNotConfigured := func(key types.NamespacedName, reason string) {
+		gw := &gatewayv1alpha1.AiGateway{}
+		Expect(k8sClient.Get(ctx, key, gw)).To(Succeed())
+		cond := findCondition(gw.Status.Conditions, AiGatewayConfigured)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Reason).To(Equal(reason))
+		err := k8sClient.Get(ctx, key, &appsv1.Deployment{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue(), "no Deployment should be created, got %v", err)
+	}
+
+	BeforeEach(func() {
+		createDefaultClass(classKey)
+	})
+
+	AfterEach(func() {
+		cleanupAiGatewayClass(classKey)
+	})
+
+	Context("When reconciling an AiGateway naming a template with a sidecar and overrides", func() {
+		gatewayKey := types.NamespacedName{Name: "ai-template", Namespace: testNS}
+		templateKey := types.NamespacedName{Name: "ai-template-standards", Namespace: testNS}
+
+		BeforeEach(func() {
+			Expect(k8sClient.Create(ctx, &litellmv1alpha1.LiteLLMGatewayTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: templateKey.Name, Namespace: templateKey.Namespace},
+				Spec: litellmv1alpha1.LiteLLMGatewayTemplateSpec{
+					Sidecars:     []corev1.Container{{Name: "log-shipper", Image: "busybox:1.36"}},
+					NodeSelector: map[string]string{"pool": "gateways"},
+					Overrides: []litellmv1alpha1.ResourceOverride{
+						{
+							Target: litellmv1alpha1.OverrideTargetService,
+							Type:   litellmv1alpha1.OverridePatchStrategicMerge,
+							Patch:  "metadata:\n  annotations:\n    example.com/load-balancer: internal\n",
+						},
+						{
+							Target: litellmv1alpha1.OverrideTargetDeployment,
+							Type:   litellmv1alpha1.OverridePatchJSONPatch,
+							Patch:  `[{"op": "add", "path": "/spec/revisionHistoryLimit", "value": 3}]`,
+						},
+					},
+				},
+			})).To(Succeed())
+			newGateway(gatewayKey, templateKey.Name)
+		})
+
+		AfterEach(func() {
+			cleanupAiGateway(gatewayKey)
+			cleanupTemplate(templateKey)
+		})
+
+		It("layers the template onto the pods and patches the generated objects", func() {
+			rec := &AiGatewayReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+
+			_, err := rec.Reconcile(ctx, reconcile.Request{NamespacedName: gatewayKey})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Verifying the pod template keeps litellm as its first container")
+			deployment := &appsv1.Deployment{}
+			Expect(k8sClient.Get(ctx, gatewayKey, deployment)).To(Succeed())
+			pod := deployment.Spec.Template.Spec
+			Expect(pod.Containers).To(HaveLen(2))
+			Expect(pod.Containers[0].Name).To(Equal(litellm.ContainerName))
+			Expect(pod.Containers[1].Name).To(Equal("log-shipper"))
+			Expect(pod.NodeSelector).To(HaveKeyWithValue("pool", "gateways"))
+
+			By("Verifying the overrides")
+			Expect(deployment.Spec.RevisionHistoryLimit).NotTo(BeNil())
+			Expect(*deployment.Spec.RevisionHistoryLimit).To(Equal(int32(3)))
+			service := &corev1.Service{}
+			Expect(k8sClient.Get(ctx, gatewayKey, service)).To(Succeed())
+			Expect(service.Annotations).To(HaveKeyWithValue("example.com/load-balancer", "internal"))
+
+			gw := &gatewayv1alpha1.AiGateway{}
+			Expect(k8sClient.Get(ctx, gatewayKey, gw)).To(Succeed())
+			cond := findCondition(gw.Status.Conditions, AiGatewayConfigured)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+		})
+	})
+
+	Context("When reconciling an AiGateway naming a missing template", func() {
+		gatewayKey := types.NamespacedName{Name: "ai-template-missing", Namespace: testNS}
+
+		BeforeEach(func() {
+			newGateway(gatewayKey, "does-not-exist")
+		})
+
+		AfterEach(func() {
+			cleanupAiGateway(gatewayKey)
+		})
+
+		It("reports the template as invalid without creating the workload", func() {
+			rec := &AiGatewayReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+
+			_, err := rec.Reconcile(ctx, reconcile.Request{NamespacedName: gatewayKey})
+			Expect(err).NotTo(HaveOccurred())
+			expectNotConfigured(gatewayKey, ReasonGatewayTemplateInvalid)
+		})
+	})
+
+	Context("When reconciling an AiGateway whose template override does not apply", func() {
+		gatewayKey := types.NamespacedName{Name: "ai-template-bad-override", Namespace: testNS}
+		templateKey := types.NamespacedName{Name: "ai-template-bad-override", Namespace: testNS}
+
+		BeforeEach(func() {
+			Expect(k8sClient.Create(ctx, &litellmv1alpha1.LiteLLMGatewayTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: templateKey.Name, Namespace: templateKey.Namespace},
+				Spec: litellmv1alpha1.LiteLLMGatewayTemplateSpec{
+					Overrides: []litellmv1alpha1.ResourceOverride{{
+						Target: litellmv1alpha1.OverrideTargetDeployment,
+						Type:   litellmv1alpha1.OverridePatchStrategicMerge,
+						Patch:  `{"spec": {"replicaz": 2}}`,
+					}},
+				},
+			})).To(Succeed())
+			newGateway(gatewayKey, templateKey.Name)
+		})
+
+		AfterEach(func() {
+			cleanupAiGateway(gatewayKey)
+			cleanupTemplate(templateKey)
+		})
+
+		It("reports the override as invalid without creating the workload", func() {
+			rec := &AiGatewayReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+
+			_, err := rec.Reconcile(ctx, reconcile.Request{NamespacedName: gatewayKey})
+			Expect(err).NotTo(HaveOccurred())
+			expectNotConfigured(gatewayKey, ReasonOverrideInvalid)
+		})
+	})
+})
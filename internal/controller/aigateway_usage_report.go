@@ -0,0 +1,94 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/litellm"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// AiGatewayUsageReportScheduled reports whether the usage report CronJob
+// requested via the usage-report-schedule annotation is in place.
+const AiGatewayUsageReportScheduled = "AiGatewayUsageReportScheduled"
+
+// Usage report condition reasons
+const (
+	ReasonUsageReportScheduled   = "UsageReportScheduled"
+	ReasonUsageReportInvalid     = "UsageReportInvalid"
+	ReasonUsageReportApplyFailed = "UsageReportApplyFailed"
+)
+
+// syncUsageReport reconciles the usage report CronJob of gw and stamps the
+// usage report condition. Like backups, an invalid report setup is reported
+// on the condition only; apiserver errors are returned for a retry.
+func (r *AiGatewayReconciler) syncUsageReport(ctx context.Context, gw *gatewayv1alpha1.AiGateway, workload litellm.GatewayWorkload) error {
+	env, err := litellm.ResolveGatewayEnv(ctx, r, gw)
+	if err != nil {
+		return err
+	}
+	report, err := litellm.ParseUsageReport(gw.Annotations)
+	if err == nil && report != nil && !litellm.DatabaseModeEnabled(env) {
+		err = fmt.Errorf("usage reports need %s in spec.env, spec.envFrom or a provisioned database: spend is only tracked with a database",
+			litellm.DatabaseURLEnvVar)
+	}
+	if err != nil {
+		r.updateCondition(gw, AiGatewayUsageReportScheduled, metav1.ConditionFalse, ReasonUsageReportInvalid, err.Error())
+		report = nil
+	}
+
+	if report == nil {
+		if delErr := litellm.DeleteUsageReport(ctx, r.Client, workload); delErr != nil {
+			logf.FromContext(ctx).Error(delErr, "Failed to delete usage report")
+			return delErr
+		}
+		if err == nil {
+			apimeta.RemoveStatusCondition(&gw.Status.Conditions, AiGatewayUsageReportScheduled)
+		}
+		return nil
+	}
+
+	var masterKey corev1.EnvVar
+	for _, e := range gw.Spec.Env {
+		if e.Name == litellm.MasterKeyEnvVar {
+			masterKey = e
+		}
+	}
+	if err := litellm.ReconcileUsageReport(ctx, r.Client, r.Scheme, workload, report, masterKey); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to reconcile usage report")
+		r.updateCondition(gw, AiGatewayUsageReportScheduled, metav1.ConditionFalse, ReasonUsageReportApplyFailed, err.Error())
+		return err
+	}
+
+	var sinks []string
+	if report.WebhookSecret != "" {
+		sinks = append(sinks, fmt.Sprintf("the webhook in Secret %s", report.WebhookSecret))
+	}
+	if report.ConfigMap != "" {
+		sinks = append(sinks, fmt.Sprintf("ConfigMap %s", report.ConfigMap))
+	}
+	r.updateCondition(gw, AiGatewayUsageReportScheduled, metav1.ConditionTrue, ReasonUsageReportScheduled,
+		fmt.Sprintf("Reporting usage on schedule %q to %s", report.Schedule, strings.Join(sinks, " and ")))
+	return nil
+}
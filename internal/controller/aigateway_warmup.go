@@ -0,0 +1,85 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/litellm"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// AiGatewayWarmedUp reports whether the warm-up Job requested via the
+// warm-up annotation reached every model of the current config.
+const AiGatewayWarmedUp = "AiGatewayWarmedUp"
+
+// Warm-up condition reasons
+const (
+	ReasonWarmUpPending   = "WarmUpPending"
+	ReasonWarmUpRunning   = "WarmUpRunning"
+	ReasonWarmUpSucceeded = "WarmUpSucceeded"
+	ReasonWarmUpFailed    = "WarmUpFailed"
+)
+
+// syncWarmUp reconciles the warm-up Job of gw and stamps the warm-up
+// condition. The Job only starts once the current config serves traffic,
+// i.e. live is true; until then the condition waits. Owned Jobs re-fire
+// Reconcile when they finish, so no requeue is needed.
+func (r *AiGatewayReconciler) syncWarmUp(ctx context.Context, gw *gatewayv1alpha1.AiGateway, workload litellm.GatewayWorkload, env []corev1.EnvVar, live bool) error {
+	if !litellm.WarmUp(gw.Annotations) {
+		if err := litellm.DeleteWarmUp(ctx, r.Client, workload); err != nil {
+			logf.FromContext(ctx).Error(err, "Failed to delete warm-up Job")
+			return err
+		}
+		apimeta.RemoveStatusCondition(&gw.Status.Conditions, AiGatewayWarmedUp)
+		return nil
+	}
+	if !live {
+		r.updateCondition(gw, AiGatewayWarmedUp, metav1.ConditionFalse, ReasonWarmUpPending,
+			"Waiting for the current config to roll out")
+		return nil
+	}
+
+	var masterKey corev1.EnvVar
+	for _, e := range env {
+		if e.Name == litellm.MasterKeyEnvVar {
+			masterKey = e
+		}
+	}
+	status, err := litellm.ReconcileWarmUp(ctx, r.Client, r.Scheme, workload, masterKey)
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to reconcile warm-up Job")
+		r.updateCondition(gw, AiGatewayWarmedUp, metav1.ConditionFalse, ReasonWarmUpFailed, err.Error())
+		return err
+	}
+	switch {
+	case status.Complete:
+		r.updateCondition(gw, AiGatewayWarmedUp, metav1.ConditionTrue, ReasonWarmUpSucceeded,
+			"Every model of the current config answered a warm-up completion")
+	case status.Failed:
+		r.updateCondition(gw, AiGatewayWarmedUp, metav1.ConditionFalse, ReasonWarmUpFailed,
+			"Warm-up Job "+litellm.WarmUpName(gw.Name)+" failed; its log lists the failing models")
+	default:
+		r.updateCondition(gw, AiGatewayWarmedUp, metav1.ConditionUnknown, ReasonWarmUpRunning,
+			"Warm-up Job "+litellm.WarmUpName(gw.Name)+" is running")
+	}
+	return nil
+}
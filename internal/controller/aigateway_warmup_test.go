@@ -0,0 +1,143 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/litellm"
+)
+
+var _ = Describe("AiGateway Controller — warm-up", func() {
+	const (
+		testNS   = "default"
+		testPort = int32(8000)
+	)
+
+	Context("When reconciling an AiGateway with the warm-up annotation", func() {
+		gatewayKey := types.NamespacedName{Name: "ai-warm-up", Namespace: testNS}
+		classKey := types.NamespacedName{Name: aiGatewayClassName}
+		jobKey := types.NamespacedName{Name: litellm.WarmUpName(gatewayKey.Name), Namespace: testNS}
+
+		BeforeEach(func() {
+			createDefaultClass(classKey)
+			Expect(k8sClient.Create(ctx, &gatewayv1alpha1.AiGateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        gatewayKey.Name,
+					Namespace:   testNS,
+					Annotations: map[string]string{litellm.WarmUpAnnotation: "true"},
+				},
+				Spec: gatewayv1alpha1.AiGatewaySpec{
+					Port:     testPort,
+					AiModels: []gatewayv1alpha1.AiModel{{Name: "gpt-4", Provider: "openai"}},
+				},
+			})).To(Succeed())
+		})
+
+		AfterEach(func() {
+			cleanupAiGateway(gatewayKey)
+			cleanupAiGatewayClass(classKey)
+		})
+
+		warmUpCondition := func() *metav1.Condition {
+			gw := &gatewayv1alpha1.AiGateway{}
+			Expect(k8sClient.Get(ctx, gatewayKey, gw)).To(Succeed())
+			return findCondition(gw.Status.Conditions, AiGatewayWarmedUp)
+		}
+
+		modelsOf := func(job *batchv1.Job) string {
+			for _, e := range job.Spec.Template.Spec.Containers[0].Env {
+				if e.Name == "MODELS" {
+					return e.Value
+				}
+			}
+			return ""
+		}
+
+		It("warms up each config once it is rolled out", func() {
+			rec := &AiGatewayReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+
+			By("Waiting for the rollout before starting the Job")
+			_, err := rec.Reconcile(ctx, reconcile.Request{NamespacedName: gatewayKey})
+			Expect(err).NotTo(HaveOccurred())
+			cond := warmUpCondition()
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+			Expect(cond.Reason).To(Equal(ReasonWarmUpPending))
+			err = k8sClient.Get(ctx, jobKey, &batchv1.Job{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue(), "no Job before the rollout, got %v", err)
+
+			By("Starting the Job once the config serves traffic")
+			markDeploymentRolledOut(gatewayKey.Name, gatewayKey.Namespace)
+			_, err = rec.Reconcile(ctx, reconcile.Request{NamespacedName: gatewayKey})
+			Expect(err).NotTo(HaveOccurred())
+			job := &batchv1.Job{}
+			Expect(k8sClient.Get(ctx, jobKey, job)).To(Succeed())
+			Expect(job.OwnerReferences).To(HaveLen(1))
+			Expect(job.OwnerReferences[0].Name).To(Equal(gatewayKey.Name))
+			Expect(modelsOf(job)).To(Equal("gpt-4"))
+			cond = warmUpCondition()
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionUnknown))
+			Expect(cond.Reason).To(Equal(ReasonWarmUpRunning))
+
+			By("Reporting the finished Job")
+			markJobFinished(jobKey.Name, jobKey.Namespace, true)
+			_, err = rec.Reconcile(ctx, reconcile.Request{NamespacedName: gatewayKey})
+			Expect(err).NotTo(HaveOccurred())
+			cond = warmUpCondition()
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+			Expect(cond.Reason).To(Equal(ReasonWarmUpSucceeded))
+
+			By("Warming up again after the next config rollout")
+			gw := &gatewayv1alpha1.AiGateway{}
+			Expect(k8sClient.Get(ctx, gatewayKey, gw)).To(Succeed())
+			gw.Spec.AiModels = append(gw.Spec.AiModels, gatewayv1alpha1.AiModel{Name: "claude-3-opus", Provider: "anthropic"})
+			Expect(k8sClient.Update(ctx, gw)).To(Succeed())
+			_, err = rec.Reconcile(ctx, reconcile.Request{NamespacedName: gatewayKey})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warmUpCondition().Reason).To(Equal(ReasonWarmUpPending))
+
+			markDeploymentRolledOut(gatewayKey.Name, gatewayKey.Namespace)
+			_, err = rec.Reconcile(ctx, reconcile.Request{NamespacedName: gatewayKey})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = rec.Reconcile(ctx, reconcile.Request{NamespacedName: gatewayKey})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, jobKey, job)).To(Succeed())
+			Expect(modelsOf(job)).To(ContainSubstring("claude-3-opus"))
+			Expect(warmUpCondition().Reason).To(Equal(ReasonWarmUpRunning))
+
+			By("Removing the annotation")
+			Expect(k8sClient.Get(ctx, gatewayKey, gw)).To(Succeed())
+			delete(gw.Annotations, litellm.WarmUpAnnotation)
+			Expect(k8sClient.Update(ctx, gw)).To(Succeed())
+			_, err = rec.Reconcile(ctx, reconcile.Request{NamespacedName: gatewayKey})
+			Expect(err).NotTo(HaveOccurred())
+			err = k8sClient.Get(ctx, jobKey, &batchv1.Job{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue(), "the Job should be deleted, got %v", err)
+			Expect(warmUpCondition()).To(BeNil())
+		})
+	})
+})
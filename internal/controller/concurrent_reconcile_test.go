@@ -0,0 +1,144 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+)
+
+// reconcileInParallel reconciles keys at once with rec, as a controller with
+// MaxConcurrentReconciles of len(keys) does, and returns the errors by key.
+func reconcileInParallel(rec reconcile.Reconciler, keys []types.NamespacedName) []error {
+	errs := make([]error, len(keys))
+	var wg sync.WaitGroup
+	for i, key := range keys {
+		wg.Go(func() {
+			_, errs[i] = rec.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		})
+	}
+	wg.Wait()
+	return errs
+}
+
+var _ = Describe("Concurrent reconciles", func() {
+	const gateways = 4
+
+	Context("When one AiGatewayReconciler reconciles several AiGateways at once", func() {
+		classKey := types.NamespacedName{Name: aiGatewayClassName}
+		var keys []types.NamespacedName
+
+		BeforeEach(func() {
+			createDefaultClass(classKey)
+			keys = nil
+			for i := range gateways {
+				key := types.NamespacedName{Name: fmt.Sprintf("ai-concurrent-%d", i), Namespace: "default"}
+				keys = append(keys, key)
+				Expect(k8sClient.Create(ctx, &gatewayv1alpha1.AiGateway{
+					ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+					Spec: gatewayv1alpha1.AiGatewaySpec{
+						Port:     8000,
+						AiModels: []gatewayv1alpha1.AiModel{{Name: "gpt-4", Provider: "openai"}},
+					},
+				})).To(Succeed())
+			}
+		})
+
+		AfterEach(func() {
+			for _, key := range keys {
+				cleanupAiGateway(key)
+			}
+			cleanupAiGatewayClass(classKey)
+		})
+
+		It("configures each gateway", func() {
+			rec := &AiGatewayReconciler{
+				Client:                  k8sClient,
+				Scheme:                  k8sClient.Scheme(),
+				MaxConcurrentReconciles: gateways,
+			}
+
+			for i, err := range reconcileInParallel(rec, keys) {
+				Expect(err).NotTo(HaveOccurred(), "reconciling %s", keys[i])
+			}
+			for _, key := range keys {
+				checkConfigMapReconciled(ctx, key)
+				Expect(k8sClient.Get(ctx, key, &appsv1.Deployment{})).To(Succeed())
+				gw := &gatewayv1alpha1.AiGateway{}
+				Expect(k8sClient.Get(ctx, key, gw)).To(Succeed())
+				cond := findCondition(gw.Status.Conditions, AiGatewayConfigured)
+				Expect(cond).NotTo(BeNil(), "AiGatewayConfigured of %s", key)
+				Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+			}
+		})
+	})
+
+	Context("When one ToolGatewayReconciler reconciles several ToolGateways at once", func() {
+		classKey := types.NamespacedName{Name: toolGatewayClassName}
+		var keys []types.NamespacedName
+
+		BeforeEach(func() {
+			ensureNamespace(toolGatewayNamespace)
+			createDefaultToolGatewayClass()
+			keys = nil
+			for i := range gateways {
+				key := types.NamespacedName{Name: fmt.Sprintf("tool-concurrent-%d", i), Namespace: toolGatewayNamespace}
+				keys = append(keys, key)
+				Expect(k8sClient.Create(ctx, &gatewayv1alpha1.ToolGateway{
+					ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+				})).To(Succeed())
+			}
+		})
+
+		AfterEach(func() {
+			for _, key := range keys {
+				gw := &gatewayv1alpha1.ToolGateway{}
+				if err := k8sClient.Get(ctx, key, gw); err == nil {
+					Expect(k8sClient.Delete(ctx, gw)).To(Succeed())
+				}
+			}
+			cls := &gatewayv1alpha1.ToolGatewayClass{}
+			if err := k8sClient.Get(ctx, classKey, cls); err == nil {
+				Expect(k8sClient.Delete(ctx, cls)).To(Succeed())
+			}
+		})
+
+		It("creates the workload of each gateway", func() {
+			rec := &ToolGatewayReconciler{
+				Client:                  k8sClient,
+				Scheme:                  k8sClient.Scheme(),
+				MaxConcurrentReconciles: gateways,
+			}
+
+			for i, err := range reconcileInParallel(rec, keys) {
+				Expect(err).NotTo(HaveOccurred(), "reconciling %s", keys[i])
+			}
+			for _, key := range keys {
+				Expect(k8sClient.Get(ctx, key, &appsv1.Deployment{})).To(Succeed())
+			}
+		})
+	})
+})
@@ -0,0 +1,101 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/litellm"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Reasons shared by the controllers of resources that are provisioned
+// through a gateway's management API.
+const (
+	ReasonGatewayUnavailable = "GatewayUnavailable"
+	ReasonGatewayNotDatabase = "GatewayWithoutDatabase"
+)
+
+// adminClientOptions configures how the reconcilers of resources that live
+// in a gateway's database reach its management API. They embed it.
+type adminClientOptions struct {
+	// serviceURL returns the base URL of a gateway's management API.
+	// Nil uses litellm.ServiceURL; tests point it at a fake proxy.
+	serviceURL func(gw *gatewayv1alpha1.AiGateway) string
+}
+
+// baseURL returns the base URL of the management API of gw.
+func (o adminClientOptions) baseURL(gw *gatewayv1alpha1.AiGateway) string {
+	if o.serviceURL != nil {
+		return o.serviceURL(gw)
+	}
+	return litellm.ServiceURL(gw.Name, gw.Namespace, gw.Spec.Port)
+}
+
+// gatewayAdminClient returns a management API client for the AiGateway
+// gatewayName in namespace. Errors with a non-empty reason are problems with
+// the gateway itself that only a change to it can fix; others are transient.
+func gatewayAdminClient(ctx context.Context, c client.Reader, namespace, gatewayName string,
+	opts adminClientOptions) (*litellm.AdminClient, string, error) {
+	var gw gatewayv1alpha1.AiGateway
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: gatewayName}, &gw); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, ReasonGatewayUnavailable, fmt.Errorf("AiGateway %s not found", gatewayName)
+		}
+		return nil, "", err
+	}
+	owned, err := litellm.IsAiGatewayOwnedByController(ctx, c, &gw, ControllerName)
+	if err != nil {
+		return nil, "", err
+	}
+	if !owned {
+		return nil, ReasonGatewayUnavailable, fmt.Errorf("AiGateway %s is not served by this operator", gw.Name)
+	}
+	if !gw.DeletionTimestamp.IsZero() {
+		return nil, ReasonGatewayUnavailable, fmt.Errorf("AiGateway %s is being deleted", gw.Name)
+	}
+	env, err := litellm.ResolveGatewayEnv(ctx, c, &gw)
+	if err != nil {
+		return nil, "", err
+	}
+	if !litellm.DatabaseModeEnabled(env) {
+		return nil, ReasonGatewayNotDatabase, fmt.Errorf("AiGateway %s has no %s; LiteLLM needs a database for this",
+			gw.Name, litellm.DatabaseURLEnvVar)
+	}
+	masterKey, err := litellm.ResolveMasterKey(ctx, c, gw.Namespace, gw.Spec.Env)
+	if err != nil {
+		return nil, "", err
+	}
+	return &litellm.AdminClient{BaseURL: opts.baseURL(&gw), MasterKey: masterKey}, "", nil
+}
+
+// parseBudget parses a USD amount such as "12.50". Empty means no budget.
+func parseBudget(s string) (*float64, error) {
+	if s == "" {
+		return nil, nil
+	}
+	budget, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, fmt.Errorf("parsing maxBudget: %w", err)
+	}
+	return &budget, nil
+}
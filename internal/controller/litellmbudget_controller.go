@@ -0,0 +1,339 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	litellmv1alpha1 "github.com/agentic-layer/ai-gateway-litellm/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// LiteLLMBudget condition types
+const (
+	// BudgetReady reports whether a LiteLLMBudget is enforced on its scope.
+	BudgetReady = "Ready"
+	// BudgetExceeded reports whether the spend of the scope reached the
+	// amount of the budget.
+	BudgetExceeded = "BudgetExceeded"
+)
+
+// LiteLLMBudget condition reasons
+const (
+	ReasonBudgetApplied        = "BudgetApplied"
+	ReasonBudgetSuperseded     = "Superseded"
+	ReasonBudgetTargetNotFound = "TargetNotFound"
+	ReasonSpendExceedsBudget   = "SpendExceedsBudget"
+	ReasonSpendWithinBudget    = "SpendWithinBudget"
+)
+
+// budgetModelSpendLimit caps how many models are requested from
+// /global/spend/models when looking up the spend of a Model-scoped budget.
+// Models outside the top list have spent too little to matter.
+const budgetModelSpendLimit = 100
+
+// LiteLLMBudgetReconciler reports the spend of LiteLLMBudgets. The budgets
+// themselves are enforced by LiteLLM: the AiGateway controller renders
+// Gateway and Model scopes into the proxy config and the LiteLLMTeam
+// controller sets the budget of Team scopes.
+type LiteLLMBudgetReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// SpendSyncInterval is how often the spend of a budget is read. Zero
+	// disables spend reporting and the BudgetExceeded condition.
+	SpendSyncInterval time.Duration
+
+	adminClientOptions
+}
+
+// +kubebuilder:rbac:groups=litellm.agentic-layer.ai,resources=litellmbudgets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=litellm.agentic-layer.ai,resources=litellmbudgets/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=litellm.agentic-layer.ai,resources=litellmteams,verbs=get;list;watch
+// +kubebuilder:rbac:groups=runtime.agentic-layer.ai,resources=aigateways,verbs=get;list;watch
+
+func (r *LiteLLMBudgetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	var budget litellmv1alpha1.LiteLLMBudget
+	if err := r.Get(ctx, req.NamespacedName, &budget); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	original := budget.DeepCopy()
+
+	reason, err := r.checkTarget(ctx, &budget)
+	if err == nil {
+		r.updateCondition(&budget, BudgetReady, metav1.ConditionTrue, ReasonBudgetApplied,
+			fmt.Sprintf("Budget of $%s applied to %s", budget.Spec.Amount, budgetScopeString(budget.Spec.Scope)))
+	} else {
+		if reason == "" {
+			return ctrl.Result{}, err
+		}
+		r.updateCondition(&budget, BudgetReady, metav1.ConditionFalse, reason, err.Error())
+	}
+	budget.Status.ObservedGeneration = budget.Generation
+
+	if r.SpendSyncInterval > 0 && reason != ReasonBudgetTargetNotFound {
+		r.syncBudgetSpend(ctx, &budget)
+	}
+	if err := r.Status().Patch(ctx, &budget, client.MergeFrom(original)); err != nil {
+		log.Error(err, "Failed to patch LiteLLMBudget status")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: r.SpendSyncInterval}, nil
+}
+
+// checkTarget verifies that the scope of budget exists and that no stricter
+// budget of the same scope takes precedence. Errors with a reason are
+// reported on the Ready condition; others are transient.
+func (r *LiteLLMBudgetReconciler) checkTarget(ctx context.Context, budget *litellmv1alpha1.LiteLLMBudget) (string, error) {
+	var gw gatewayv1alpha1.AiGateway
+	if err := r.Get(ctx, types.NamespacedName{Namespace: budget.Namespace, Name: budget.Spec.AiGatewayRef.Name}, &gw); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ReasonGatewayUnavailable, fmt.Errorf("AiGateway %s not found", budget.Spec.AiGatewayRef.Name)
+		}
+		return "", err
+	}
+	switch scope := budget.Spec.Scope; scope.Type {
+	case litellmv1alpha1.BudgetScopeTeam:
+		var team litellmv1alpha1.LiteLLMTeam
+		if err := r.Get(ctx, types.NamespacedName{Namespace: budget.Namespace, Name: scope.Team}, &team); err != nil {
+			if apierrors.IsNotFound(err) {
+				return ReasonBudgetTargetNotFound, fmt.Errorf("LiteLLMTeam %s not found", scope.Team)
+			}
+			return "", err
+		}
+		if team.Spec.AiGatewayRef.Name != gw.Name {
+			return ReasonBudgetTargetNotFound, fmt.Errorf("LiteLLMTeam %s belongs to AiGateway %s", scope.Team, team.Spec.AiGatewayRef.Name)
+		}
+	case litellmv1alpha1.BudgetScopeModel:
+		if !slices.ContainsFunc(gw.Spec.AiModels, func(m gatewayv1alpha1.AiModel) bool { return m.Name == scope.Model }) {
+			return ReasonBudgetTargetNotFound, fmt.Errorf("AiGateway %s has no model %s", gw.Name, scope.Model)
+		}
+	}
+
+	budgets, err := effectiveBudgets(ctx, r, budget.Namespace, gw.Name)
+	if err != nil {
+		return "", err
+	}
+	if winner := budgets[budget.Spec.Scope]; winner != nil && winner.Name != budget.Name {
+		return ReasonBudgetSuperseded, fmt.Errorf("LiteLLMBudget %s sets a lower amount for %s",
+			winner.Name, budgetScopeString(budget.Spec.Scope))
+	}
+	return "", nil
+}
+
+// syncBudgetSpend reads the spend of the budget's scope and stamps Spend and
+// the BudgetExceeded condition. Like syncSpend, failures are only reported
+// on the condition and retried at the next interval.
+func (r *LiteLLMBudgetReconciler) syncBudgetSpend(ctx context.Context, budget *litellmv1alpha1.LiteLLMBudget) {
+	spend, reason, err := r.readSpend(ctx, budget)
+	if err != nil {
+		if reason == "" {
+			reason = ReasonSpendQueryFailed
+		}
+		logf.FromContext(ctx).Info("LiteLLM spend query failed", "error", err.Error())
+		r.updateCondition(budget, BudgetExceeded, metav1.ConditionUnknown, reason, err.Error())
+		return
+	}
+	now := metav1.Now()
+	budget.Status.Spend = strconv.FormatFloat(spend, 'f', 2, 64)
+	budget.Status.LastSpendTime = &now
+
+	amount, err := strconv.ParseFloat(budget.Spec.Amount, 64)
+	if err != nil {
+		r.updateCondition(budget, BudgetExceeded, metav1.ConditionUnknown, ReasonSpendQueryFailed, fmt.Sprintf("parsing amount: %v", err))
+		return
+	}
+	message := fmt.Sprintf("spend $%.2f of $%.2f budget", spend, amount)
+	if spend >= amount {
+		r.updateCondition(budget, BudgetExceeded, metav1.ConditionTrue, ReasonSpendExceedsBudget, message)
+	} else {
+		r.updateCondition(budget, BudgetExceeded, metav1.ConditionFalse, ReasonSpendWithinBudget, message)
+	}
+}
+
+// readSpend returns the spend of the budget's scope as recorded by the
+// proxy's spend API.
+func (r *LiteLLMBudgetReconciler) readSpend(ctx context.Context, budget *litellmv1alpha1.LiteLLMBudget) (float64, string, error) {
+	admin, reason, err := gatewayAdminClient(ctx, r, budget.Namespace, budget.Spec.AiGatewayRef.Name, r.adminClientOptions)
+	if err != nil {
+		return 0, reason, err
+	}
+	switch scope := budget.Spec.Scope; scope.Type {
+	case litellmv1alpha1.BudgetScopeTeam:
+		team := &litellmv1alpha1.LiteLLMTeam{ObjectMeta: metav1.ObjectMeta{Namespace: budget.Namespace, Name: scope.Team}}
+		info, err := admin.TeamInfo(ctx, teamID(team))
+		if err != nil {
+			return 0, "", err
+		}
+		return info.Spend, "", nil
+	case litellmv1alpha1.BudgetScopeModel:
+		models, err := admin.GlobalSpendByModel(ctx, budgetModelSpendLimit)
+		if err != nil {
+			return 0, "", err
+		}
+		for _, m := range models {
+			if m.Model == scope.Model {
+				return m.TotalSpend, "", nil
+			}
+		}
+		return 0, "", nil
+	default:
+		total, err := admin.GlobalSpend(ctx)
+		if err != nil {
+			return 0, "", err
+		}
+		return total.Spend, "", nil
+	}
+}
+
+// effectiveBudgets returns the LiteLLMBudgets in effect on the AiGateway
+// gatewayName, keyed by scope. When several budgets share a scope the one
+// with the lowest amount wins, ties broken by name.
+func effectiveBudgets(ctx context.Context, c client.Reader, namespace, gatewayName string) (map[litellmv1alpha1.BudgetScope]*litellmv1alpha1.LiteLLMBudget, error) {
+	var list litellmv1alpha1.LiteLLMBudgetList
+	if err := c.List(ctx, &list, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("listing LiteLLMBudgets: %w", err)
+	}
+	budgets := make(map[litellmv1alpha1.BudgetScope]*litellmv1alpha1.LiteLLMBudget)
+	for i := range list.Items {
+		b := &list.Items[i]
+		if b.Spec.AiGatewayRef.Name != gatewayName || !b.DeletionTimestamp.IsZero() {
+			continue
+		}
+		amount, err := strconv.ParseFloat(b.Spec.Amount, 64)
+		if err != nil {
+			continue
+		}
+		if cur, ok := budgets[b.Spec.Scope]; ok {
+			curAmount, _ := strconv.ParseFloat(cur.Spec.Amount, 64)
+			if c := cmp.Compare(amount, curAmount); c > 0 || (c == 0 && b.Name > cur.Name) {
+				continue
+			}
+		}
+		budgets[b.Spec.Scope] = b
+	}
+	return budgets, nil
+}
+
+// budgetLimits converts budget to the max_budget / budget_duration pair of
+// the proxy config and management API. A nil budget yields no limit.
+func budgetLimits(budget *litellmv1alpha1.LiteLLMBudget) (*float64, string) {
+	if budget == nil {
+		return nil, ""
+	}
+	amount, err := parseBudget(budget.Spec.Amount)
+	if err != nil {
+		return nil, ""
+	}
+	return amount, budget.Spec.Period
+}
+
+func budgetScopeString(scope litellmv1alpha1.BudgetScope) string {
+	switch scope.Type {
+	case litellmv1alpha1.BudgetScopeTeam:
+		return "LiteLLMTeam " + scope.Team
+	case litellmv1alpha1.BudgetScopeModel:
+		return "model " + scope.Model
+	default:
+		return "the gateway"
+	}
+}
+
+func (r *LiteLLMBudgetReconciler) updateCondition(budget *litellmv1alpha1.LiteLLMBudget, conditionType string, status metav1.ConditionStatus, reason, message string) {
+	apimeta.SetStatusCondition(&budget.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: budget.Generation,
+	})
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *LiteLLMBudgetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Indexer key used to locate LiteLLMBudgets by the AiGateway they apply to.
+	const budgetGatewayIndex = "spec.aiGatewayRef.name"
+
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &litellmv1alpha1.LiteLLMBudget{}, budgetGatewayIndex,
+		func(obj client.Object) []string {
+			budget, ok := obj.(*litellmv1alpha1.LiteLLMBudget)
+			if !ok {
+				return nil
+			}
+			return []string{budget.Spec.AiGatewayRef.Name}
+		},
+	); err != nil {
+		return fmt.Errorf("failed to register LiteLLMBudget gateway indexer: %w", err)
+	}
+
+	// enqueueBudgetsForGateway re-reconciles the budgets of a gateway when
+	// the gateway or one of its teams changes. Budgets of the same gateway
+	// also supersede each other, so a budget change fans out the same way.
+	enqueueBudgetsForGateway := func(gatewayName func(client.Object) string) handler.EventHandler {
+		return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+			log := logf.FromContext(ctx)
+			var budgetList litellmv1alpha1.LiteLLMBudgetList
+			if err := r.List(ctx, &budgetList,
+				client.InNamespace(obj.GetNamespace()),
+				client.MatchingFields{budgetGatewayIndex: gatewayName(obj)},
+			); err != nil {
+				log.Error(err, "Failed to list LiteLLMBudgets for watch", "namespace", obj.GetNamespace(), "trigger", obj.GetName())
+				return nil
+			}
+			requests := make([]reconcile.Request, len(budgetList.Items))
+			for i, budget := range budgetList.Items {
+				requests[i] = reconcile.Request{NamespacedName: types.NamespacedName{Name: budget.Name, Namespace: budget.Namespace}}
+			}
+			return requests
+		})
+	}
+
+	// Status updates are ignored throughout: each reconcile stamps
+	// LastSpendTime, which would otherwise keep the budgets of a gateway
+	// re-triggering each other.
+	specChanged := builder.WithPredicates(predicate.GenerationChangedPredicate{})
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&litellmv1alpha1.LiteLLMBudget{}, specChanged).
+		Watches(&gatewayv1alpha1.AiGateway{}, enqueueBudgetsForGateway(client.Object.GetName), specChanged).
+		Watches(&litellmv1alpha1.LiteLLMTeam{}, enqueueBudgetsForGateway(func(obj client.Object) string {
+			return obj.(*litellmv1alpha1.LiteLLMTeam).Spec.AiGatewayRef.Name
+		}), specChanged).
+		Watches(&litellmv1alpha1.LiteLLMBudget{}, enqueueBudgetsForGateway(func(obj client.Object) string {
+			return obj.(*litellmv1alpha1.LiteLLMBudget).Spec.AiGatewayRef.Name
+		}), specChanged).
+		Named("litellmbudget").
+		Complete(r)
+}
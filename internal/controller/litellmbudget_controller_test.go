@@ -0,0 +1,177 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	litellmv1alpha1 "github.com/agentic-layer/ai-gateway-litellm/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newBudget(name, amount string, scope litellmv1alpha1.BudgetScope) *litellmv1alpha1.LiteLLMBudget {
+	return &litellmv1alpha1.LiteLLMBudget{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team-a", Generation: 1},
+		Spec: litellmv1alpha1.LiteLLMBudgetSpec{
+			AiGatewayRef: corev1.LocalObjectReference{Name: "gw"},
+			Amount:       amount,
+			Period:       "30d",
+			Scope:        scope,
+		},
+	}
+}
+
+func budgetFixtures(t *testing.T, budgets ...client.Object) (client.Client, *LiteLLMBudgetReconciler) {
+	t.Helper()
+	s := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{gatewayv1alpha1.AddToScheme, litellmv1alpha1.AddToScheme, corev1.AddToScheme} {
+		if err := add(s); err != nil {
+			t.Fatalf("AddToScheme: %v", err)
+		}
+	}
+	class := &gatewayv1alpha1.AiGatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "litellm"},
+		Spec:       gatewayv1alpha1.AiGatewayClassSpec{Controller: ControllerName},
+	}
+	gw := &gatewayv1alpha1.AiGateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "team-a"},
+		Spec: gatewayv1alpha1.AiGatewaySpec{
+			AiGatewayClassName: "litellm",
+			Port:               4000,
+			AiModels:           []gatewayv1alpha1.AiModel{{Name: "gpt-4o", Provider: "openai"}},
+			Env:                []corev1.EnvVar{{Name: "DATABASE_URL", Value: "postgres://db"}},
+		},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(s).
+		WithObjects(append([]client.Object{class, gw}, budgets...)...).
+		WithStatusSubresource(&litellmv1alpha1.LiteLLMBudget{}).
+		Build()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/global/spend":
+			_, _ = w.Write([]byte(`{"spend": 612.5, "max_budget": 500}`))
+		case "/global/spend/models":
+			_, _ = w.Write([]byte(`[{"model": "gpt-4o", "total_spend": 40}]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	r := &LiteLLMBudgetReconciler{
+		Client:             c,
+		Scheme:             s,
+		SpendSyncInterval:  10 * time.Minute,
+		adminClientOptions: adminClientOptions{serviceURL: func(*gatewayv1alpha1.AiGateway) string { return srv.URL }},
+	}
+	return c, r
+}
+
+func reconcileBudget(t *testing.T, c client.Client, r *LiteLLMBudgetReconciler, name string) *litellmv1alpha1.LiteLLMBudget {
+	t.Helper()
+	ctx := context.Background()
+	key := types.NamespacedName{Name: name, Namespace: "team-a"}
+	res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	if err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if res.RequeueAfter != r.SpendSyncInterval {
+		t.Errorf("RequeueAfter: got %v, want %v", res.RequeueAfter, r.SpendSyncInterval)
+	}
+	var budget litellmv1alpha1.LiteLLMBudget
+	if err := c.Get(ctx, key, &budget); err != nil {
+		t.Fatalf("get LiteLLMBudget: %v", err)
+	}
+	return &budget
+}
+
+func TestLiteLLMBudget_ReportsSpend(t *testing.T) {
+	gateway := newBudget("gateway", "500", litellmv1alpha1.BudgetScope{Type: litellmv1alpha1.BudgetScopeGateway})
+	model := newBudget("model", "50", litellmv1alpha1.BudgetScope{Type: litellmv1alpha1.BudgetScopeModel, Model: "gpt-4o"})
+	c, r := budgetFixtures(t, gateway, model)
+
+	got := reconcileBudget(t, c, r, "gateway")
+	if !apimeta.IsStatusConditionTrue(got.Status.Conditions, BudgetReady) || got.Status.Spend != "612.50" {
+		t.Errorf("status: got %+v", got.Status)
+	}
+	if cond := apimeta.FindStatusCondition(got.Status.Conditions, BudgetExceeded); cond == nil ||
+		cond.Status != metav1.ConditionTrue || cond.Reason != ReasonSpendExceedsBudget {
+		t.Errorf("BudgetExceeded: got %+v", cond)
+	}
+
+	got = reconcileBudget(t, c, r, "model")
+	if cond := apimeta.FindStatusCondition(got.Status.Conditions, BudgetExceeded); cond == nil ||
+		cond.Status != metav1.ConditionFalse || got.Status.Spend != "40.00" {
+		t.Errorf("model budget: got spend %q and %+v", got.Status.Spend, cond)
+	}
+}
+
+func TestLiteLLMBudget_ReportsTargetProblems(t *testing.T) {
+	scope := litellmv1alpha1.BudgetScope{Type: litellmv1alpha1.BudgetScopeGateway}
+	c, r := budgetFixtures(t,
+		newBudget("loose", "1000", scope),
+		newBudget("strict", "100", scope),
+		newBudget("no-team", "10", litellmv1alpha1.BudgetScope{Type: litellmv1alpha1.BudgetScopeTeam, Team: "research"}),
+	)
+
+	for name, reason := range map[string]string{
+		"loose":   ReasonBudgetSuperseded,
+		"strict":  ReasonBudgetApplied,
+		"no-team": ReasonBudgetTargetNotFound,
+	} {
+		got := reconcileBudget(t, c, r, name)
+		if cond := apimeta.FindStatusCondition(got.Status.Conditions, BudgetReady); cond == nil || cond.Reason != reason {
+			t.Errorf("%s: Ready got %+v, want reason %s", name, cond, reason)
+		}
+	}
+}
+
+func TestEffectiveBudgets_LowestAmountWins(t *testing.T) {
+	scope := litellmv1alpha1.BudgetScope{Type: litellmv1alpha1.BudgetScopeGateway}
+	other := newBudget("other-gateway", "1", scope)
+	other.Spec.AiGatewayRef.Name = "other"
+	c, _ := budgetFixtures(t,
+		newBudget("b", "100", scope),
+		newBudget("a", "100.0", scope),
+		newBudget("c", "250", scope),
+		other,
+	)
+
+	budgets, err := effectiveBudgets(context.Background(), c, "team-a", "gw")
+	if err != nil {
+		t.Fatalf("effectiveBudgets: %v", err)
+	}
+	if len(budgets) != 1 || budgets[scope].Name != "a" {
+		t.Errorf("effectiveBudgets: got %v, want budget a", budgets)
+	}
+	amount, period := budgetLimits(budgets[scope])
+	if amount == nil || *amount != 100 || period != "30d" {
+		t.Errorf("budgetLimits: got %v %q", amount, period)
+	}
+}
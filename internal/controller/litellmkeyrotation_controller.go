@@ -0,0 +1,543 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	litellmv1alpha1 "github.com/agentic-layer/ai-gateway-litellm/api/v1alpha1"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/litellm"
+	"github.com/robfig/cron/v3"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// KeyRotationReady reports whether a LiteLLMKeyRotation is scheduled and its
+// last rotation did not fail.
+const KeyRotationReady = "Ready"
+
+// LiteLLMKeyRotation condition reasons
+const (
+	ReasonRotationScheduled       = "Scheduled"
+	ReasonRotationVerifying       = "Verifying"
+	ReasonRotationScheduleInvalid = "ScheduleInvalid"
+	ReasonRotationFailed          = "RotationFailed"
+	ReasonRotationRolledBack      = "RolledBack"
+)
+
+const (
+	// defaultKeyRotationVerifyTimeout is used when spec.verifyTimeout is unset.
+	defaultKeyRotationVerifyTimeout = 10 * time.Minute
+	// keyRotationPollInterval is how often a rotation being verified checks
+	// the gateway's rollout.
+	keyRotationPollInterval = 15 * time.Second
+	// keyRotationHistoryLimit bounds status.history.
+	keyRotationHistoryLimit = 10
+	// keyRotationHookTimeout bounds a single rotation hook call.
+	keyRotationHookTimeout = 30 * time.Second
+)
+
+// LiteLLMKeyRotationReconciler rotates provider keys on a schedule. It writes
+// the new key to the Secret the gateway reads it from; the AiGateway
+// controller rolls the gateway through the secret-hash annotation. Once the
+// rollout is complete, the gateway's /health decides whether the rotation is
+// kept or rolled back.
+type LiteLLMKeyRotationReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// HTTPClient calls rotation hooks. Nil uses a client with a 30s timeout.
+	HTTPClient *http.Client
+
+	adminClientOptions
+
+	// now returns the current time. Nil uses time.Now.
+	now func() time.Time
+}
+
+// +kubebuilder:rbac:groups=litellm.agentic-layer.ai,resources=litellmkeyrotations,verbs=get;list;watch
+// +kubebuilder:rbac:groups=litellm.agentic-layer.ai,resources=litellmkeyrotations/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=runtime.agentic-layer.ai,resources=aigateways,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch
+
+func (r *LiteLLMKeyRotationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var rotation litellmv1alpha1.LiteLLMKeyRotation
+	if err := r.Get(ctx, req.NamespacedName, &rotation); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	original := rotation.DeepCopy()
+
+	result, err := r.reconcileRotation(ctx, &rotation)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	rotation.Status.ObservedGeneration = rotation.Generation
+
+	if err := r.Status().Patch(ctx, &rotation, client.MergeFrom(original)); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to patch LiteLLMKeyRotation status")
+		return ctrl.Result{}, err
+	}
+	return result, nil
+}
+
+// reconcileRotation advances rotation by one step: it verifies the rotation
+// in progress, or starts one when it is due. Only apiserver errors are
+// returned; everything else is reported on the status.
+func (r *LiteLLMKeyRotationReconciler) reconcileRotation(ctx context.Context, rotation *litellmv1alpha1.LiteLLMKeyRotation) (ctrl.Result, error) {
+	now := r.clock()
+	schedule, err := keyRotationSchedule(rotation.Spec)
+	if err != nil {
+		rotation.Status.NextRotationTime = nil
+		r.updateCondition(rotation, metav1.ConditionFalse, ReasonRotationScheduleInvalid, err.Error())
+		return ctrl.Result{}, nil
+	}
+
+	var gw gatewayv1alpha1.AiGateway
+	if err := r.Get(ctx, types.NamespacedName{Namespace: rotation.Namespace, Name: rotation.Spec.AiGatewayRef.Name}, &gw); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		r.updateCondition(rotation, metav1.ConditionFalse, ReasonGatewayUnavailable,
+			fmt.Sprintf("AiGateway %s not found", rotation.Spec.AiGatewayRef.Name))
+		return ctrl.Result{}, nil
+	}
+
+	if rotation.Status.Verifying != nil {
+		return r.verify(ctx, rotation, &gw, now)
+	}
+
+	last := rotation.CreationTimestamp.Time
+	if rotation.Status.LastRotationTime != nil {
+		last = rotation.Status.LastRotationTime.Time
+	}
+	next := schedule.Next(last)
+	if next.IsZero() {
+		rotation.Status.NextRotationTime = nil
+		r.updateCondition(rotation, metav1.ConditionTrue, ReasonRotationScheduled, "Schedule has no upcoming rotation")
+		return ctrl.Result{}, nil
+	}
+	if next.After(now) {
+		rotation.Status.NextRotationTime = &metav1.Time{Time: next}
+		// A failed or rolled back rotation stays reported until the next one,
+		// unless the spec changed since.
+		ready := apimeta.FindStatusCondition(rotation.Status.Conditions, KeyRotationReady)
+		if ready == nil || (ready.Reason != ReasonRotationFailed && ready.Reason != ReasonRotationRolledBack) ||
+			ready.ObservedGeneration != rotation.Generation {
+			r.updateCondition(rotation, metav1.ConditionTrue, ReasonRotationScheduled,
+				fmt.Sprintf("Next rotation at %s", next.Format(time.RFC3339)))
+		}
+		return ctrl.Result{RequeueAfter: next.Sub(now)}, nil
+	}
+	return r.rotate(ctx, rotation, &gw, now)
+}
+
+// rotate saves the current key, writes the new one and starts verifying it.
+func (r *LiteLLMKeyRotationReconciler) rotate(ctx context.Context, rotation *litellmv1alpha1.LiteLLMKeyRotation, gw *gatewayv1alpha1.AiGateway, now time.Time) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+	ref := rotation.Spec.SecretRef
+
+	var secret corev1.Secret
+	if err := r.Get(ctx, types.NamespacedName{Namespace: rotation.Namespace, Name: ref.Name}, &secret); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		return r.fail(rotation, now, "", fmt.Errorf("secret %s not found", ref.Name))
+	}
+	current := secret.Data[ref.Key]
+
+	newKey, swapKey, err := r.newProviderKey(ctx, rotation)
+	if err != nil {
+		if !isRotationError(err) {
+			return ctrl.Result{}, err
+		}
+		return r.fail(rotation, now, swapKey, err)
+	}
+	if bytes.Equal(newKey, current) {
+		return r.fail(rotation, now, swapKey, fmt.Errorf("the new key equals the key in use"))
+	}
+
+	var secretHash string
+	var deployment appsv1.Deployment
+	if err := r.Get(ctx, types.NamespacedName{Namespace: gw.Namespace, Name: gw.Name}, &deployment); err == nil {
+		secretHash = litellm.DeployedSecretHash(&deployment)
+	} else if !apierrors.IsNotFound(err) {
+		return ctrl.Result{}, err
+	}
+
+	// The previous key is kept until the rotation is verified, so it can be
+	// restored.
+	previous := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: keyRotationPreviousName(rotation.Name), Namespace: rotation.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, previous, func() error {
+		previous.Type = corev1.SecretTypeOpaque
+		previous.Data = map[string][]byte{ref.Key: current}
+		return controllerutil.SetControllerReference(rotation, previous, r.Scheme)
+	}); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.writeProviderKey(ctx, &secret, ref.Key, newKey); err != nil {
+		return ctrl.Result{}, err
+	}
+	log.Info("Rotated provider key", "secret", ref.Name, "key", ref.Key, "swapKey", swapKey)
+	rotation.Status.Verifying = &litellmv1alpha1.KeyRotationVerification{
+		StartedAt:  metav1.Time{Time: now},
+		Key:        swapKey,
+		SecretHash: secretHash,
+	}
+	rotation.Status.NextRotationTime = nil
+	r.updateCondition(rotation, metav1.ConditionTrue, ReasonRotationVerifying,
+		fmt.Sprintf("Waiting for AiGateway %s to roll out the new key", gw.Name))
+	return ctrl.Result{RequeueAfter: keyRotationPollInterval}, nil
+}
+
+// verify keeps the rotation in progress once the gateway rolled out the new
+// key and reports healthy, and rolls it back when the gateway is unhealthy or
+// the rollout does not complete within spec.verifyTimeout.
+func (r *LiteLLMKeyRotationReconciler) verify(ctx context.Context, rotation *litellmv1alpha1.LiteLLMKeyRotation, gw *gatewayv1alpha1.AiGateway, now time.Time) (ctrl.Result, error) {
+	verifying := rotation.Status.Verifying
+	timeout := defaultKeyRotationVerifyTimeout
+	if rotation.Spec.VerifyTimeout != nil {
+		timeout = rotation.Spec.VerifyTimeout.Duration
+	}
+
+	var deployment appsv1.Deployment
+	if err := r.Get(ctx, types.NamespacedName{Namespace: gw.Namespace, Name: gw.Name}, &deployment); client.IgnoreNotFound(err) != nil {
+		return ctrl.Result{}, err
+	}
+	rolledOut, _ := litellm.IsDeploymentRolledOut(&deployment)
+	if deployment.ResourceVersion == "" || litellm.DeployedSecretHash(&deployment) == verifying.SecretHash || !rolledOut {
+		if now.Sub(verifying.StartedAt.Time) >= timeout {
+			return r.rollBack(ctx, rotation, now,
+				fmt.Sprintf("AiGateway %s did not roll out the new key within %s", gw.Name, timeout))
+		}
+		return ctrl.Result{RequeueAfter: keyRotationPollInterval}, nil
+	}
+
+	masterKey, err := litellm.ResolveMasterKey(ctx, r, gw.Namespace, litellm.GatewayEnv(gw))
+	if err != nil {
+		return r.rollBack(ctx, rotation, now, fmt.Sprintf("Resolving the master key: %v", err))
+	}
+	report, err := (&litellm.AdminClient{BaseURL: r.baseURL(gw), MasterKey: masterKey}).Health(ctx)
+	if err != nil {
+		return r.rollBack(ctx, rotation, now, fmt.Sprintf("Health check failed: %v", err))
+	}
+	if status, _, message := healthCondition(report); status != metav1.ConditionTrue {
+		return r.rollBack(ctx, rotation, now, message)
+	}
+
+	if err := r.deletePrevious(ctx, rotation); err != nil {
+		return ctrl.Result{}, err
+	}
+	if verifying.Key != "" {
+		rotation.Status.ActiveKey = verifying.Key
+	}
+	r.finish(rotation, litellmv1alpha1.KeyRotationRecord{
+		Time: verifying.StartedAt, Result: litellmv1alpha1.KeyRotationSucceeded, Key: verifying.Key,
+	})
+	r.updateCondition(rotation, metav1.ConditionTrue, ReasonRotationScheduled,
+		fmt.Sprintf("Rotated the key at %s", verifying.StartedAt.Format(time.RFC3339)))
+	return r.requeueAtNext(rotation, now), nil
+}
+
+// rollBack restores the key saved before the rotation in progress.
+func (r *LiteLLMKeyRotationReconciler) rollBack(ctx context.Context, rotation *litellmv1alpha1.LiteLLMKeyRotation, now time.Time, reason string) (ctrl.Result, error) {
+	ref := rotation.Spec.SecretRef
+	var previous corev1.Secret
+	if err := r.Get(ctx, types.NamespacedName{Namespace: rotation.Namespace, Name: keyRotationPreviousName(rotation.Name)}, &previous); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		reason += "; the previous key is gone and cannot be restored"
+	} else {
+		var secret corev1.Secret
+		if err := r.Get(ctx, types.NamespacedName{Namespace: rotation.Namespace, Name: ref.Name}, &secret); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return ctrl.Result{}, err
+			}
+			reason += fmt.Sprintf("; Secret %s is gone and the previous key cannot be restored", ref.Name)
+		} else if err := r.writeProviderKey(ctx, &secret, ref.Key, previous.Data[ref.Key]); err != nil {
+			return ctrl.Result{}, err
+		}
+		if err := r.deletePrevious(ctx, rotation); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	logf.FromContext(ctx).Info("Rolled back provider key rotation", "secret", ref.Name, "reason", reason)
+
+	verifying := rotation.Status.Verifying
+	r.finish(rotation, litellmv1alpha1.KeyRotationRecord{
+		Time: verifying.StartedAt, Result: litellmv1alpha1.KeyRotationRolledBack, Key: verifying.Key, Message: reason,
+	})
+	r.updateCondition(rotation, metav1.ConditionFalse, ReasonRotationRolledBack, reason)
+	return r.requeueAtNext(rotation, now), nil
+}
+
+// fail records a rotation that could not write a new key. It is retried at
+// the next scheduled time.
+func (r *LiteLLMKeyRotationReconciler) fail(rotation *litellmv1alpha1.LiteLLMKeyRotation, now time.Time, swapKey string, err error) (ctrl.Result, error) {
+	r.finish(rotation, litellmv1alpha1.KeyRotationRecord{
+		Time: metav1.Time{Time: now}, Result: litellmv1alpha1.KeyRotationFailed, Key: swapKey, Message: err.Error(),
+	})
+	r.updateCondition(rotation, metav1.ConditionFalse, ReasonRotationFailed, err.Error())
+	return r.requeueAtNext(rotation, now), nil
+}
+
+// finish ends the rotation in progress and records it in the history.
+func (r *LiteLLMKeyRotationReconciler) finish(rotation *litellmv1alpha1.LiteLLMKeyRotation, record litellmv1alpha1.KeyRotationRecord) {
+	rotation.Status.Verifying = nil
+	rotation.Status.LastRotationTime = record.Time.DeepCopy()
+	rotation.Status.History = append([]litellmv1alpha1.KeyRotationRecord{record}, rotation.Status.History...)
+	if len(rotation.Status.History) > keyRotationHistoryLimit {
+		rotation.Status.History = rotation.Status.History[:keyRotationHistoryLimit]
+	}
+}
+
+// requeueAtNext sets the next rotation time after a finished rotation.
+func (r *LiteLLMKeyRotationReconciler) requeueAtNext(rotation *litellmv1alpha1.LiteLLMKeyRotation, now time.Time) ctrl.Result {
+	rotation.Status.NextRotationTime = nil
+	schedule, err := keyRotationSchedule(rotation.Spec)
+	if err != nil {
+		return ctrl.Result{}
+	}
+	next := schedule.Next(rotation.Status.LastRotationTime.Time)
+	if next.IsZero() {
+		return ctrl.Result{}
+	}
+	rotation.Status.NextRotationTime = &metav1.Time{Time: next}
+	return ctrl.Result{RequeueAfter: max(next.Sub(now), 0)}
+}
+
+// rotationError is a rotation failure that only a change to the spec, the
+// Secrets or the hook can fix. It is recorded instead of retried.
+type rotationError struct{ error }
+
+func isRotationError(err error) bool {
+	_, ok := err.(rotationError)
+	return ok
+}
+
+// newProviderKey returns the new provider key and, for swap rotations, the
+// key of the swap Secret it was taken from.
+func (r *LiteLLMKeyRotationReconciler) newProviderKey(ctx context.Context, rotation *litellmv1alpha1.LiteLLMKeyRotation) ([]byte, string, error) {
+	if swap := rotation.Spec.Swap; swap != nil {
+		name := swap.SecretName
+		if name == "" {
+			name = rotation.Spec.SecretRef.Name
+		}
+		next := swap.Keys[0]
+		if rotation.Status.ActiveKey == swap.Keys[0] {
+			next = swap.Keys[1]
+		}
+		var secret corev1.Secret
+		if err := r.Get(ctx, types.NamespacedName{Namespace: rotation.Namespace, Name: name}, &secret); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, next, rotationError{fmt.Errorf("swap Secret %s not found", name)}
+			}
+			return nil, next, err
+		}
+		value := secret.Data[next]
+		if len(value) == 0 {
+			return nil, next, rotationError{fmt.Errorf("swap Secret %s has no key %s", name, next)}
+		}
+		return value, next, nil
+	}
+
+	hook := rotation.Spec.Hook
+	var urlSecret corev1.Secret
+	if err := r.Get(ctx, types.NamespacedName{Namespace: rotation.Namespace, Name: hook.URLSecretRef.Name}, &urlSecret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, "", rotationError{fmt.Errorf("hook Secret %s not found", hook.URLSecretRef.Name)}
+		}
+		return nil, "", err
+	}
+	url := string(urlSecret.Data[hook.URLSecretRef.Key])
+	if url == "" {
+		return nil, "", rotationError{fmt.Errorf("hook Secret %s has no key %s", hook.URLSecretRef.Name, hook.URLSecretRef.Key)}
+	}
+	key, err := r.callHook(ctx, url, rotation)
+	if err != nil {
+		return nil, "", rotationError{fmt.Errorf("rotation hook: %w", err)}
+	}
+	return key, "", nil
+}
+
+// callHook asks the rotation hook at url for a new provider key.
+func (r *LiteLLMKeyRotationReconciler) callHook(ctx context.Context, url string, rotation *litellmv1alpha1.LiteLLMKeyRotation) ([]byte, error) {
+	body, err := json.Marshal(map[string]string{
+		"namespace": rotation.Namespace,
+		"name":      rotation.Name,
+		"secret":    rotation.Spec.SecretRef.Name,
+		"key":       rotation.Spec.SecretRef.Key,
+	})
+	if err != nil {
+		return nil, err
+	}
+	httpClient := r.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: keyRotationHookTimeout}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("%s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+	var out struct {
+		APIKey string `json:"apiKey"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+	if out.APIKey == "" {
+		return nil, fmt.Errorf("response has no apiKey")
+	}
+	return []byte(out.APIKey), nil
+}
+
+// writeProviderKey sets key of secret to value.
+func (r *LiteLLMKeyRotationReconciler) writeProviderKey(ctx context.Context, secret *corev1.Secret, key string, value []byte) error {
+	patch := client.MergeFromWithOptions(secret.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[key] = value
+	return r.Patch(ctx, secret, patch)
+}
+
+func (r *LiteLLMKeyRotationReconciler) deletePrevious(ctx context.Context, rotation *litellmv1alpha1.LiteLLMKeyRotation) error {
+	previous := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: keyRotationPreviousName(rotation.Name), Namespace: rotation.Namespace}}
+	return client.IgnoreNotFound(r.Delete(ctx, previous))
+}
+
+// keyRotationPreviousName is the Secret the key in use before a rotation is
+// kept in until the rotation is verified.
+func keyRotationPreviousName(name string) string {
+	return name + "-previous"
+}
+
+// keyRotationSchedule parses the schedule of spec in its time zone.
+func keyRotationSchedule(spec litellmv1alpha1.LiteLLMKeyRotationSpec) (cron.Schedule, error) {
+	if spec.Swap != nil && len(spec.Swap.Keys) == 2 && spec.Swap.Keys[0] == spec.Swap.Keys[1] {
+		return nil, fmt.Errorf("swap keys must differ")
+	}
+	schedule := spec.Schedule
+	if spec.TimeZone != "" {
+		if _, err := time.LoadLocation(spec.TimeZone); err != nil {
+			return nil, fmt.Errorf("invalid time zone %q: %w", spec.TimeZone, err)
+		}
+		schedule = "CRON_TZ=" + spec.TimeZone + " " + schedule
+	}
+	s, err := cron.ParseStandard(schedule)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", spec.Schedule, err)
+	}
+	return s, nil
+}
+
+func (r *LiteLLMKeyRotationReconciler) clock() time.Time {
+	if r.now != nil {
+		return r.now()
+	}
+	return time.Now()
+}
+
+func (r *LiteLLMKeyRotationReconciler) updateCondition(rotation *litellmv1alpha1.LiteLLMKeyRotation, status metav1.ConditionStatus, reason, message string) {
+	apimeta.SetStatusCondition(&rotation.Status.Conditions, metav1.Condition{
+		Type:               KeyRotationReady,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: rotation.Generation,
+	})
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *LiteLLMKeyRotationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Indexer key used to locate LiteLLMKeyRotations by the AiGateway whose
+	// key they rotate.
+	const keyRotationGatewayIndex = "spec.aiGatewayRef.name"
+
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &litellmv1alpha1.LiteLLMKeyRotation{}, keyRotationGatewayIndex,
+		func(obj client.Object) []string {
+			rotation, ok := obj.(*litellmv1alpha1.LiteLLMKeyRotation)
+			if !ok {
+				return nil
+			}
+			return []string{rotation.Spec.AiGatewayRef.Name}
+		},
+	); err != nil {
+		return fmt.Errorf("failed to register LiteLLMKeyRotation gateway indexer: %w", err)
+	}
+
+	// enqueueRotationsForGateway re-reconciles the rotations of a gateway
+	// when it appears or goes away.
+	enqueueRotationsForGateway := handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		var list litellmv1alpha1.LiteLLMKeyRotationList
+		if err := r.List(ctx, &list,
+			client.InNamespace(obj.GetNamespace()),
+			client.MatchingFields{keyRotationGatewayIndex: obj.GetName()},
+		); err != nil {
+			logf.FromContext(ctx).Error(err, "Failed to list LiteLLMKeyRotations for AiGateway watch", "namespace", obj.GetNamespace(), "aigateway", obj.GetName())
+			return nil
+		}
+		requests := make([]reconcile.Request, len(list.Items))
+		for i, rotation := range list.Items {
+			requests[i] = reconcile.Request{NamespacedName: types.NamespacedName{Name: rotation.Name, Namespace: rotation.Namespace}}
+		}
+		return requests
+	})
+
+	// Status updates are the controller's own; verification polls the
+	// gateway's rollout instead of watching it.
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&litellmv1alpha1.LiteLLMKeyRotation{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&gatewayv1alpha1.AiGateway{}, enqueueRotationsForGateway, builder.WithPredicates(predicate.Funcs{
+			UpdateFunc: func(event.UpdateEvent) bool { return false },
+		})).
+		Named("litellmkeyrotation").
+		Complete(r)
+}
@@ -0,0 +1,296 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	litellmv1alpha1 "github.com/agentic-layer/ai-gateway-litellm/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// keyRotationNow is a Sunday afternoon, after the 03:00 rotation on the 1st
+// of March was due.
+var keyRotationNow = time.Date(2026, 3, 1, 15, 0, 0, 0, time.UTC)
+
+func newKeyRotation(spec litellmv1alpha1.LiteLLMKeyRotationSpec) *litellmv1alpha1.LiteLLMKeyRotation {
+	spec.AiGatewayRef = corev1.LocalObjectReference{Name: "gw"}
+	spec.SecretRef = corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "api-key-secrets"}, Key: "OPENAI_API_KEY"}
+	spec.Schedule = "0 3 1 * *"
+	return &litellmv1alpha1.LiteLLMKeyRotation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "openai", Namespace: "team-a", Generation: 1, UID: "rotation-uid",
+			CreationTimestamp: metav1.Time{Time: keyRotationNow.Add(-30 * 24 * time.Hour)},
+		},
+		Spec: spec,
+	}
+}
+
+// keyRotationFixtures returns a gateway whose Deployment is rolled out with
+// secret-hash "old", and a fake /health reporting healthy unless *unhealthy
+// is set.
+func keyRotationFixtures(t *testing.T, objs ...client.Object) (client.Client, *LiteLLMKeyRotationReconciler, *bool) {
+	t.Helper()
+	s := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{gatewayv1alpha1.AddToScheme, litellmv1alpha1.AddToScheme, corev1.AddToScheme, appsv1.AddToScheme} {
+		if err := add(s); err != nil {
+			t.Fatalf("AddToScheme: %v", err)
+		}
+	}
+	unhealthy := new(bool)
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := map[string]any{"healthy_count": 1, "unhealthy_count": 0}
+		if *unhealthy {
+			report = map[string]any{"healthy_count": 0, "unhealthy_count": 1,
+				"unhealthy_endpoints": []map[string]string{{"model": "openai/gpt-4o"}}}
+		}
+		_ = json.NewEncoder(w).Encode(report)
+	}))
+	t.Cleanup(proxy.Close)
+
+	gw := &gatewayv1alpha1.AiGateway{ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "team-a"}}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "team-a", Generation: 1},
+		Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{"gateway.agentic-layer.ai/secret-hash": "old"},
+		}}},
+		Status: appsv1.DeploymentStatus{ObservedGeneration: 1, Replicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "api-key-secrets", Namespace: "team-a"},
+		Data:       map[string][]byte{"OPENAI_API_KEY": []byte("sk-old")},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(s).
+		WithObjects(append([]client.Object{gw, deployment, secret}, objs...)...).
+		WithStatusSubresource(&litellmv1alpha1.LiteLLMKeyRotation{}).
+		Build()
+	r := &LiteLLMKeyRotationReconciler{
+		Client:             c,
+		Scheme:             s,
+		adminClientOptions: adminClientOptions{serviceURL: func(*gatewayv1alpha1.AiGateway) string { return proxy.URL }},
+		now:                func() time.Time { return keyRotationNow },
+	}
+	return c, r, unhealthy
+}
+
+func reconcileKeyRotation(t *testing.T, c client.Client, r *LiteLLMKeyRotationReconciler) (*litellmv1alpha1.LiteLLMKeyRotation, ctrl.Result) {
+	t.Helper()
+	result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "openai", Namespace: "team-a"}})
+	if err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	var rotation litellmv1alpha1.LiteLLMKeyRotation
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "openai", Namespace: "team-a"}, &rotation); err != nil {
+		t.Fatalf("get rotation: %v", err)
+	}
+	return &rotation, result
+}
+
+func providerKey(t *testing.T, c client.Client) string {
+	t.Helper()
+	var secret corev1.Secret
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "api-key-secrets", Namespace: "team-a"}, &secret); err != nil {
+		t.Fatalf("get Secret: %v", err)
+	}
+	return string(secret.Data["OPENAI_API_KEY"])
+}
+
+// rollOutNewKey stamps the gateway Deployment with a new secret-hash, as the
+// AiGateway controller does once the provider key changed.
+func rollOutNewKey(t *testing.T, c client.Client) {
+	t.Helper()
+	var deployment appsv1.Deployment
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "gw", Namespace: "team-a"}, &deployment); err != nil {
+		t.Fatalf("get Deployment: %v", err)
+	}
+	deployment.Spec.Template.Annotations["gateway.agentic-layer.ai/secret-hash"] = "new"
+	if err := c.Update(context.Background(), &deployment); err != nil {
+		t.Fatalf("update Deployment: %v", err)
+	}
+}
+
+func swapKeys() (*litellmv1alpha1.KeyRotationSwap, *corev1.Secret) {
+	return &litellmv1alpha1.KeyRotationSwap{SecretName: "openai-keys", Keys: []string{"key-a", "key-b"}},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "openai-keys", Namespace: "team-a"},
+			Data:       map[string][]byte{"key-a": []byte("sk-a"), "key-b": []byte("sk-b")},
+		}
+}
+
+func TestLiteLLMKeyRotation_SwapsAndVerifies(t *testing.T) {
+	swap, keys := swapKeys()
+	c, r, _ := keyRotationFixtures(t, newKeyRotation(litellmv1alpha1.LiteLLMKeyRotationSpec{Swap: swap}), keys)
+
+	rotation, result := reconcileKeyRotation(t, c, r)
+	if got := providerKey(t, c); got != "sk-a" {
+		t.Fatalf("provider key = %q, want sk-a", got)
+	}
+	if rotation.Status.Verifying == nil || rotation.Status.Verifying.Key != "key-a" || rotation.Status.Verifying.SecretHash != "old" {
+		t.Fatalf("want a rotation to key-a being verified, got %+v", rotation.Status.Verifying)
+	}
+	if result.RequeueAfter != keyRotationPollInterval {
+		t.Errorf("RequeueAfter = %s", result.RequeueAfter)
+	}
+
+	// Not rolled out yet: still verifying.
+	rotation, _ = reconcileKeyRotation(t, c, r)
+	if rotation.Status.Verifying == nil {
+		t.Fatal("rotation must wait for the rollout")
+	}
+
+	rollOutNewKey(t, c)
+	rotation, result = reconcileKeyRotation(t, c, r)
+	if rotation.Status.Verifying != nil || rotation.Status.ActiveKey != "key-a" {
+		t.Fatalf("want key-a active, got %+v", rotation.Status)
+	}
+	if len(rotation.Status.History) != 1 || rotation.Status.History[0].Result != litellmv1alpha1.KeyRotationSucceeded {
+		t.Errorf("history = %+v", rotation.Status.History)
+	}
+	if next := rotation.Status.NextRotationTime; next == nil || !next.Time.Equal(time.Date(2026, 4, 1, 3, 0, 0, 0, time.UTC)) {
+		t.Errorf("NextRotationTime = %v", next)
+	}
+	if result.RequeueAfter <= 0 {
+		t.Errorf("want a requeue at the next rotation, got %+v", result)
+	}
+	err := c.Get(context.Background(), types.NamespacedName{Name: "openai-previous", Namespace: "team-a"}, &corev1.Secret{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("previous key Secret: want NotFound, got %v", err)
+	}
+	ready := apimeta.FindStatusCondition(rotation.Status.Conditions, KeyRotationReady)
+	if ready == nil || ready.Status != metav1.ConditionTrue || ready.Reason != ReasonRotationScheduled {
+		t.Errorf("Ready = %+v", ready)
+	}
+}
+
+func TestLiteLLMKeyRotation_RollsBackUnhealthyKey(t *testing.T) {
+	swap, keys := swapKeys()
+	c, r, unhealthy := keyRotationFixtures(t, newKeyRotation(litellmv1alpha1.LiteLLMKeyRotationSpec{Swap: swap}), keys)
+	*unhealthy = true
+
+	reconcileKeyRotation(t, c, r)
+	rollOutNewKey(t, c)
+	rotation, _ := reconcileKeyRotation(t, c, r)
+
+	if got := providerKey(t, c); got != "sk-old" {
+		t.Errorf("provider key = %q, want the old key restored", got)
+	}
+	if rotation.Status.Verifying != nil || rotation.Status.ActiveKey != "" {
+		t.Errorf("want no active swap key after a rollback, got %+v", rotation.Status)
+	}
+	if len(rotation.Status.History) != 1 || rotation.Status.History[0].Result != litellmv1alpha1.KeyRotationRolledBack {
+		t.Errorf("history = %+v", rotation.Status.History)
+	}
+	ready := apimeta.FindStatusCondition(rotation.Status.Conditions, KeyRotationReady)
+	if ready == nil || ready.Status != metav1.ConditionFalse || ready.Reason != ReasonRotationRolledBack {
+		t.Errorf("Ready = %+v", ready)
+	}
+
+	// The rollback stays reported until the next rotation.
+	rotation, _ = reconcileKeyRotation(t, c, r)
+	if ready := apimeta.FindStatusCondition(rotation.Status.Conditions, KeyRotationReady); ready.Reason != ReasonRotationRolledBack {
+		t.Errorf("Ready = %+v", ready)
+	}
+}
+
+func TestLiteLLMKeyRotation_HookAndVerifyTimeout(t *testing.T) {
+	var request map[string]string
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		_ = json.NewEncoder(w).Encode(map[string]string{"apiKey": "sk-hooked"})
+	}))
+	defer hook.Close()
+	hookSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "rotation-hook", Namespace: "team-a"},
+		Data:       map[string][]byte{"url": []byte(hook.URL)},
+	}
+	rotation := newKeyRotation(litellmv1alpha1.LiteLLMKeyRotationSpec{
+		Hook: &litellmv1alpha1.KeyRotationHook{URLSecretRef: corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "rotation-hook"}, Key: "url",
+		}},
+		VerifyTimeout: &metav1.Duration{Duration: time.Minute},
+	})
+	c, r, _ := keyRotationFixtures(t, rotation, hookSecret)
+
+	reconcileKeyRotation(t, c, r)
+	if got := providerKey(t, c); got != "sk-hooked" {
+		t.Fatalf("provider key = %q, want sk-hooked", got)
+	}
+	if request["secret"] != "api-key-secrets" || request["key"] != "OPENAI_API_KEY" {
+		t.Errorf("hook request = %v", request)
+	}
+
+	// The gateway never rolls out the new key.
+	r.now = func() time.Time { return keyRotationNow.Add(2 * time.Minute) }
+	got, _ := reconcileKeyRotation(t, c, r)
+	if key := providerKey(t, c); key != "sk-old" {
+		t.Errorf("provider key = %q, want the old key restored", key)
+	}
+	if len(got.Status.History) != 1 || got.Status.History[0].Result != litellmv1alpha1.KeyRotationRolledBack {
+		t.Errorf("history = %+v", got.Status.History)
+	}
+}
+
+func TestLiteLLMKeyRotation_RecordsFailures(t *testing.T) {
+	swap, _ := swapKeys()
+	c, r, _ := keyRotationFixtures(t, newKeyRotation(litellmv1alpha1.LiteLLMKeyRotationSpec{Swap: swap}))
+
+	rotation, _ := reconcileKeyRotation(t, c, r)
+	if got := providerKey(t, c); got != "sk-old" {
+		t.Errorf("provider key = %q, want it untouched", got)
+	}
+	if len(rotation.Status.History) != 1 || rotation.Status.History[0].Result != litellmv1alpha1.KeyRotationFailed {
+		t.Errorf("history = %+v", rotation.Status.History)
+	}
+	ready := apimeta.FindStatusCondition(rotation.Status.Conditions, KeyRotationReady)
+	if ready == nil || ready.Reason != ReasonRotationFailed {
+		t.Errorf("Ready = %+v", ready)
+	}
+	if rotation.Status.NextRotationTime == nil {
+		t.Error("a failed rotation must be retried at the next scheduled time")
+	}
+}
+
+func TestKeyRotationSchedule(t *testing.T) {
+	for _, spec := range []litellmv1alpha1.LiteLLMKeyRotationSpec{
+		{Schedule: "monthly"},
+		{Schedule: "@monthly", TimeZone: "Mars/Olympus"},
+		{Schedule: "@monthly", Swap: &litellmv1alpha1.KeyRotationSwap{Keys: []string{"a", "a"}}},
+	} {
+		if _, err := keyRotationSchedule(spec); err == nil {
+			t.Errorf("%+v: want an error", spec)
+		}
+	}
+	if _, err := keyRotationSchedule(litellmv1alpha1.LiteLLMKeyRotationSpec{Schedule: "@monthly"}); err != nil {
+		t.Errorf("@monthly: %v", err)
+	}
+}
@@ -0,0 +1,243 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	litellmv1alpha1 "github.com/agentic-layer/ai-gateway-litellm/api/v1alpha1"
+	"github.com/robfig/cron/v3"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// MaintenanceWindowReady reports whether a LiteLLMMaintenanceWindow is
+// scheduled on its gateway.
+const MaintenanceWindowReady = "Ready"
+
+// LiteLLMMaintenanceWindow condition reasons
+const (
+	ReasonMaintenanceScheduled       = "Scheduled"
+	ReasonMaintenanceScheduleInvalid = "ScheduleInvalid"
+)
+
+// maxMaintenanceWindow bounds the duration of a window. It also bounds the
+// schedule activations walked to find an open window.
+const maxMaintenanceWindow = 7 * 24 * time.Hour
+
+// LiteLLMMaintenanceWindowReconciler reports whether LiteLLMMaintenanceWindows
+// are open. The AiGateway controller takes the models out of the rendered
+// config itself and re-renders at every window boundary.
+type LiteLLMMaintenanceWindowReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=litellm.agentic-layer.ai,resources=litellmmaintenancewindows,verbs=get;list;watch
+// +kubebuilder:rbac:groups=litellm.agentic-layer.ai,resources=litellmmaintenancewindows/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=runtime.agentic-layer.ai,resources=aigateways,verbs=get;list;watch
+
+func (r *LiteLLMMaintenanceWindowReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var window litellmv1alpha1.LiteLLMMaintenanceWindow
+	if err := r.Get(ctx, req.NamespacedName, &window); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	original := window.DeepCopy()
+
+	var result ctrl.Result
+	window.Status.Active, window.Status.ActiveUntil, window.Status.NextStart = false, nil, nil
+	now := time.Now()
+	active, change, err := maintenanceWindowState(window.Spec, now)
+	if err != nil {
+		r.updateCondition(&window, metav1.ConditionFalse, ReasonMaintenanceScheduleInvalid, err.Error())
+	} else {
+		var message string
+		switch {
+		case active:
+			window.Status.Active = true
+			window.Status.ActiveUntil = &metav1.Time{Time: change}
+			message = fmt.Sprintf("Window open until %s", change.Format(time.RFC3339))
+		case change.IsZero():
+			message = "Schedule has no upcoming window"
+		default:
+			window.Status.NextStart = &metav1.Time{Time: change}
+			message = fmt.Sprintf("Next window opens at %s", change.Format(time.RFC3339))
+		}
+		if !change.IsZero() {
+			result.RequeueAfter = change.Sub(now)
+		}
+
+		var gw gatewayv1alpha1.AiGateway
+		err := r.Get(ctx, types.NamespacedName{Namespace: window.Namespace, Name: window.Spec.AiGatewayRef.Name}, &gw)
+		switch {
+		case apierrors.IsNotFound(err):
+			r.updateCondition(&window, metav1.ConditionFalse, ReasonGatewayUnavailable,
+				fmt.Sprintf("AiGateway %s not found", window.Spec.AiGatewayRef.Name))
+		case err != nil:
+			return ctrl.Result{}, err
+		default:
+			r.updateCondition(&window, metav1.ConditionTrue, ReasonMaintenanceScheduled, message)
+		}
+	}
+	window.Status.ObservedGeneration = window.Generation
+
+	if err := r.Status().Patch(ctx, &window, client.MergeFrom(original)); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to patch LiteLLMMaintenanceWindow status")
+		return ctrl.Result{}, err
+	}
+	return result, nil
+}
+
+// maintenanceWindowState reports whether a window of spec is open at now,
+// and when that changes: the end of the open window, or the start of the
+// next one. The change is zero when the schedule has no upcoming start.
+func maintenanceWindowState(spec litellmv1alpha1.LiteLLMMaintenanceWindowSpec, now time.Time) (bool, time.Time, error) {
+	duration := spec.Duration.Duration
+	if duration <= 0 || duration > maxMaintenanceWindow {
+		return false, time.Time{}, fmt.Errorf("duration %s must be positive and at most %s", duration, maxMaintenanceWindow)
+	}
+	loc := time.UTC
+	if spec.TimeZone != "" {
+		var err error
+		if loc, err = time.LoadLocation(spec.TimeZone); err != nil {
+			return false, time.Time{}, fmt.Errorf("invalid time zone %q: %w", spec.TimeZone, err)
+		}
+	}
+	schedule, err := cron.ParseStandard(spec.Schedule)
+	if err != nil {
+		return false, time.Time{}, fmt.Errorf("invalid schedule %q: %w", spec.Schedule, err)
+	}
+
+	// A window is open when it started within the last duration; the
+	// latest such start decides when it closes.
+	now = now.In(loc)
+	var latest time.Time
+	for start := schedule.Next(now.Add(-duration)); !start.IsZero() && !start.After(now); start = schedule.Next(start) {
+		latest = start
+	}
+	if !latest.IsZero() {
+		return true, latest.Add(duration), nil
+	}
+	return false, schedule.Next(now), nil
+}
+
+// openMaintenanceWindows returns the specs of the LiteLLMMaintenanceWindows
+// of the AiGateway gatewayName open at now, and the earliest time one of
+// its windows opens or closes. Windows with an invalid schedule are
+// reported on their own status and skipped here.
+func openMaintenanceWindows(ctx context.Context, c client.Reader, namespace, gatewayName string, now time.Time) ([]litellmv1alpha1.LiteLLMMaintenanceWindowSpec, time.Time, error) {
+	var list litellmv1alpha1.LiteLLMMaintenanceWindowList
+	if err := c.List(ctx, &list, client.InNamespace(namespace)); err != nil {
+		return nil, time.Time{}, fmt.Errorf("listing LiteLLMMaintenanceWindows: %w", err)
+	}
+	var open []litellmv1alpha1.LiteLLMMaintenanceWindowSpec
+	var next time.Time
+	for _, w := range list.Items {
+		if w.Spec.AiGatewayRef.Name != gatewayName || !w.DeletionTimestamp.IsZero() {
+			continue
+		}
+		active, change, err := maintenanceWindowState(w.Spec, now)
+		if err != nil {
+			continue
+		}
+		if active {
+			open = append(open, w.Spec)
+		}
+		if !change.IsZero() && (next.IsZero() || change.Before(next)) {
+			next = change
+		}
+	}
+	return open, next, nil
+}
+
+// inMaintenance reports whether one of the open windows covers model.
+func inMaintenance(model gatewayv1alpha1.AiModel, open []litellmv1alpha1.LiteLLMMaintenanceWindowSpec) bool {
+	return slices.ContainsFunc(open, func(spec litellmv1alpha1.LiteLLMMaintenanceWindowSpec) bool {
+		return slices.Contains(spec.Models, model.Name) || slices.Contains(spec.Providers, model.Provider)
+	})
+}
+
+func (r *LiteLLMMaintenanceWindowReconciler) updateCondition(window *litellmv1alpha1.LiteLLMMaintenanceWindow, status metav1.ConditionStatus, reason, message string) {
+	apimeta.SetStatusCondition(&window.Status.Conditions, metav1.Condition{
+		Type:               MaintenanceWindowReady,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: window.Generation,
+	})
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *LiteLLMMaintenanceWindowReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Indexer key used to locate LiteLLMMaintenanceWindows by the AiGateway
+	// they apply to.
+	const maintenanceGatewayIndex = "spec.aiGatewayRef.name"
+
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &litellmv1alpha1.LiteLLMMaintenanceWindow{}, maintenanceGatewayIndex,
+		func(obj client.Object) []string {
+			window, ok := obj.(*litellmv1alpha1.LiteLLMMaintenanceWindow)
+			if !ok {
+				return nil
+			}
+			return []string{window.Spec.AiGatewayRef.Name}
+		},
+	); err != nil {
+		return fmt.Errorf("failed to register LiteLLMMaintenanceWindow gateway indexer: %w", err)
+	}
+
+	// enqueueWindowsForGateway re-reconciles the windows of a gateway when
+	// it appears or goes away.
+	enqueueWindowsForGateway := handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		var list litellmv1alpha1.LiteLLMMaintenanceWindowList
+		if err := r.List(ctx, &list,
+			client.InNamespace(obj.GetNamespace()),
+			client.MatchingFields{maintenanceGatewayIndex: obj.GetName()},
+		); err != nil {
+			logf.FromContext(ctx).Error(err, "Failed to list LiteLLMMaintenanceWindows for AiGateway watch", "namespace", obj.GetNamespace(), "aigateway", obj.GetName())
+			return nil
+		}
+		requests := make([]reconcile.Request, len(list.Items))
+		for i, window := range list.Items {
+			requests[i] = reconcile.Request{NamespacedName: types.NamespacedName{Name: window.Name, Namespace: window.Namespace}}
+		}
+		return requests
+	})
+
+	specChanged := builder.WithPredicates(predicate.GenerationChangedPredicate{})
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&litellmv1alpha1.LiteLLMMaintenanceWindow{}, specChanged).
+		Watches(&gatewayv1alpha1.AiGateway{}, enqueueWindowsForGateway, builder.WithPredicates(predicate.Funcs{
+			UpdateFunc: func(event.UpdateEvent) bool { return false },
+		})).
+		Named("litellmmaintenancewindow").
+		Complete(r)
+}
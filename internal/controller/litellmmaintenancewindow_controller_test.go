@@ -0,0 +1,197 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	litellmv1alpha1 "github.com/agentic-layer/ai-gateway-litellm/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newMaintenanceWindow(name, schedule string, duration time.Duration) *litellmv1alpha1.LiteLLMMaintenanceWindow {
+	return &litellmv1alpha1.LiteLLMMaintenanceWindow{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team-a", Generation: 1},
+		Spec: litellmv1alpha1.LiteLLMMaintenanceWindowSpec{
+			AiGatewayRef: corev1.LocalObjectReference{Name: "gw"},
+			Schedule:     schedule,
+			Duration:     metav1.Duration{Duration: duration},
+		},
+	}
+}
+
+func maintenanceFixtures(t *testing.T, objs ...client.Object) (client.Client, *LiteLLMMaintenanceWindowReconciler) {
+	t.Helper()
+	s := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{gatewayv1alpha1.AddToScheme, litellmv1alpha1.AddToScheme, corev1.AddToScheme} {
+		if err := add(s); err != nil {
+			t.Fatalf("AddToScheme: %v", err)
+		}
+	}
+	gw := &gatewayv1alpha1.AiGateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "team-a"},
+		Spec: gatewayv1alpha1.AiGatewaySpec{
+			AiModels: []gatewayv1alpha1.AiModel{
+				{Name: "gpt-4o", Provider: "openai"},
+				{Name: "claude-sonnet", Provider: "anthropic"},
+				{Name: "gemini-pro", Provider: "gemini"},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(s).
+		WithObjects(append([]client.Object{gw}, objs...)...).
+		WithStatusSubresource(&litellmv1alpha1.LiteLLMMaintenanceWindow{}).
+		Build()
+	return c, &LiteLLMMaintenanceWindowReconciler{Client: c, Scheme: s}
+}
+
+func TestMaintenanceWindowState(t *testing.T) {
+	// Monday 2026-03-02, 21:30 in Berlin.
+	now := time.Date(2026, 3, 2, 20, 30, 0, 0, time.UTC)
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+
+	for name, tc := range map[string]struct {
+		schedule, timeZone string
+		duration           time.Duration
+		active             bool
+		change             time.Time
+		err                string
+	}{
+		"open": {
+			schedule: "0 20 * * 1-5", timeZone: "Europe/Berlin", duration: 12 * time.Hour,
+			active: true, change: time.Date(2026, 3, 3, 8, 0, 0, 0, berlin),
+		},
+		"not yet open in UTC": {
+			schedule: "0 22 * * *", duration: time.Hour,
+			change: time.Date(2026, 3, 2, 22, 0, 0, 0, time.UTC),
+		},
+		"closed": {
+			schedule: "0 19 * * *", duration: time.Hour,
+			change: time.Date(2026, 3, 3, 19, 0, 0, 0, time.UTC),
+		},
+		"overlapping starts end with the latest": {
+			schedule: "*/30 * * * *", duration: 2 * time.Hour,
+			active: true, change: time.Date(2026, 3, 2, 22, 30, 0, 0, time.UTC),
+		},
+		"invalid schedule":  {schedule: "every night", duration: time.Hour, err: "invalid schedule"},
+		"invalid time zone": {schedule: "@daily", timeZone: "Mars/Olympus", duration: time.Hour, err: "invalid time zone"},
+		"too long":          {schedule: "@weekly", duration: 8 * 24 * time.Hour, err: "at most"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			w := newMaintenanceWindow("w", tc.schedule, tc.duration)
+			w.Spec.TimeZone = tc.timeZone
+			active, change, err := maintenanceWindowState(w.Spec, now)
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("got error %v, want %q", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("maintenanceWindowState: %v", err)
+			}
+			if active != tc.active || !change.Equal(tc.change) {
+				t.Errorf("got active=%v change=%s, want active=%v change=%s", active, change, tc.active, tc.change)
+			}
+		})
+	}
+}
+
+func TestGenerateAiGatewayConfig_MaintenanceWindows(t *testing.T) {
+	// A window of every minute lasting a day is always open.
+	byModel := newMaintenanceWindow("by-model", "* * * * *", 24*time.Hour)
+	byModel.Spec.Models = []string{"gpt-4o"}
+	byProvider := newMaintenanceWindow("by-provider", "* * * * *", 24*time.Hour)
+	byProvider.Spec.Providers = []string{"anthropic"}
+	otherGateway := newMaintenanceWindow("other-gateway", "* * * * *", 24*time.Hour)
+	otherGateway.Spec.AiGatewayRef.Name = "other"
+	otherGateway.Spec.Providers = []string{"gemini"}
+	c, _ := maintenanceFixtures(t, byModel, byProvider, otherGateway)
+	ctx := context.Background()
+
+	var gw gatewayv1alpha1.AiGateway
+	if err := c.Get(ctx, types.NamespacedName{Name: "gw", Namespace: "team-a"}, &gw); err != nil {
+		t.Fatalf("get AiGateway: %v", err)
+	}
+	config, err := GenerateAiGatewayConfig(ctx, c, nil, &gw)
+	if err != nil {
+		t.Fatalf("GenerateAiGatewayConfig: %v", err)
+	}
+	for model, want := range map[string]bool{"gpt-4o": false, "claude-sonnet": false, "gemini-pro": true} {
+		if got := strings.Contains(config, "model_name: "+model); got != want {
+			t.Errorf("model %s in config: got %v, want %v\n%s", model, got, want, config)
+		}
+	}
+}
+
+func TestLiteLLMMaintenanceWindow_ReportsSchedule(t *testing.T) {
+	open := newMaintenanceWindow("open", "* * * * *", 24*time.Hour)
+	closed := newMaintenanceWindow("closed", "0 0 1 1 *", time.Minute)
+	invalid := newMaintenanceWindow("invalid", "not a schedule", time.Hour)
+	noGateway := newMaintenanceWindow("no-gateway", "* * * * *", time.Hour)
+	noGateway.Spec.AiGatewayRef.Name = "missing"
+	c, r := maintenanceFixtures(t, open, closed, invalid, noGateway)
+	ctx := context.Background()
+
+	for name, reason := range map[string]string{
+		"open":       ReasonMaintenanceScheduled,
+		"closed":     ReasonMaintenanceScheduled,
+		"invalid":    ReasonMaintenanceScheduleInvalid,
+		"no-gateway": ReasonGatewayUnavailable,
+	} {
+		key := types.NamespacedName{Name: name, Namespace: "team-a"}
+		result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		if err != nil {
+			t.Fatalf("%s: Reconcile: %v", name, err)
+		}
+		var window litellmv1alpha1.LiteLLMMaintenanceWindow
+		if err := c.Get(ctx, key, &window); err != nil {
+			t.Fatalf("get LiteLLMMaintenanceWindow: %v", err)
+		}
+		if cond := apimeta.FindStatusCondition(window.Status.Conditions, MaintenanceWindowReady); cond == nil || cond.Reason != reason {
+			t.Errorf("%s: Ready got %+v, want reason %s", name, cond, reason)
+		}
+		if valid := reason != ReasonMaintenanceScheduleInvalid; valid != (result.RequeueAfter > 0) {
+			t.Errorf("%s: RequeueAfter got %s", name, result.RequeueAfter)
+		}
+		switch name {
+		case "open":
+			if !window.Status.Active || window.Status.ActiveUntil == nil || window.Status.NextStart != nil {
+				t.Errorf("open: status got %+v, want active", window.Status)
+			}
+		case "closed":
+			if window.Status.Active || window.Status.NextStart == nil {
+				t.Errorf("closed: status got %+v, want a next start", window.Status)
+			}
+		}
+	}
+}
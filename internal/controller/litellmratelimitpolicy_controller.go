@@ -0,0 +1,258 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	litellmv1alpha1 "github.com/agentic-layer/ai-gateway-litellm/api/v1alpha1"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/litellm"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// RateLimitReady reports whether a LiteLLMRateLimitPolicy is enforced on its
+// scope.
+const RateLimitReady = "Ready"
+
+// LiteLLMRateLimitPolicy condition reasons
+const (
+	ReasonRateLimitApplied        = "RateLimitApplied"
+	ReasonRateLimitTargetNotFound = "TargetNotFound"
+)
+
+// LiteLLMRateLimitPolicyReconciler reports the state of
+// LiteLLMRateLimitPolicies. Like budgets, the limits are enforced by
+// LiteLLM: the AiGateway controller renders Model scopes into the proxy
+// config, and the LiteLLMTeam and LiteLLMVirtualKey controllers push Team
+// and Key scopes through the management API.
+type LiteLLMRateLimitPolicyReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=litellm.agentic-layer.ai,resources=litellmratelimitpolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=litellm.agentic-layer.ai,resources=litellmratelimitpolicies/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=litellm.agentic-layer.ai,resources=litellmteams;litellmvirtualkeys,verbs=get;list;watch
+// +kubebuilder:rbac:groups=runtime.agentic-layer.ai,resources=aigateways,verbs=get;list;watch
+
+func (r *LiteLLMRateLimitPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var policy litellmv1alpha1.LiteLLMRateLimitPolicy
+	if err := r.Get(ctx, req.NamespacedName, &policy); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	original := policy.DeepCopy()
+
+	reason, err := r.checkTarget(ctx, &policy)
+	switch {
+	case err == nil:
+		limits, err := effectiveRateLimits(ctx, r, policy.Namespace, policy.Spec.AiGatewayRef.Name)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		effective := limits[policy.Spec.Scope]
+		policy.Status.Effective = &effective
+		r.updateCondition(&policy, metav1.ConditionTrue, ReasonRateLimitApplied,
+			fmt.Sprintf("Rate limits applied to %s", rateLimitScopeString(policy.Spec.Scope)))
+	case reason == "":
+		return ctrl.Result{}, err
+	default:
+		policy.Status.Effective = nil
+		r.updateCondition(&policy, metav1.ConditionFalse, reason, err.Error())
+	}
+	policy.Status.ObservedGeneration = policy.Generation
+
+	if err := r.Status().Patch(ctx, &policy, client.MergeFrom(original)); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to patch LiteLLMRateLimitPolicy status")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// checkTarget verifies that the scope of policy exists on its gateway.
+// Errors with a reason are reported on the Ready condition; others are
+// transient.
+func (r *LiteLLMRateLimitPolicyReconciler) checkTarget(ctx context.Context, policy *litellmv1alpha1.LiteLLMRateLimitPolicy) (string, error) {
+	var gw gatewayv1alpha1.AiGateway
+	if err := r.Get(ctx, types.NamespacedName{Namespace: policy.Namespace, Name: policy.Spec.AiGatewayRef.Name}, &gw); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ReasonGatewayUnavailable, fmt.Errorf("AiGateway %s not found", policy.Spec.AiGatewayRef.Name)
+		}
+		return "", err
+	}
+	switch scope := policy.Spec.Scope; scope.Type {
+	case litellmv1alpha1.RateLimitScopeTeam:
+		var team litellmv1alpha1.LiteLLMTeam
+		if err := r.Get(ctx, types.NamespacedName{Namespace: policy.Namespace, Name: scope.Team}, &team); err != nil {
+			if apierrors.IsNotFound(err) {
+				return ReasonRateLimitTargetNotFound, fmt.Errorf("LiteLLMTeam %s not found", scope.Team)
+			}
+			return "", err
+		}
+		if team.Spec.AiGatewayRef.Name != gw.Name {
+			return ReasonRateLimitTargetNotFound, fmt.Errorf("LiteLLMTeam %s belongs to AiGateway %s", scope.Team, team.Spec.AiGatewayRef.Name)
+		}
+	case litellmv1alpha1.RateLimitScopeKey:
+		var vk litellmv1alpha1.LiteLLMVirtualKey
+		if err := r.Get(ctx, types.NamespacedName{Namespace: policy.Namespace, Name: scope.Key}, &vk); err != nil {
+			if apierrors.IsNotFound(err) {
+				return ReasonRateLimitTargetNotFound, fmt.Errorf("LiteLLMVirtualKey %s not found", scope.Key)
+			}
+			return "", err
+		}
+		if vk.Spec.AiGatewayRef.Name != gw.Name {
+			return ReasonRateLimitTargetNotFound, fmt.Errorf("LiteLLMVirtualKey %s belongs to AiGateway %s", scope.Key, vk.Spec.AiGatewayRef.Name)
+		}
+	case litellmv1alpha1.RateLimitScopeModel:
+		if !slices.ContainsFunc(gw.Spec.AiModels, func(m gatewayv1alpha1.AiModel) bool { return m.Name == scope.Model }) {
+			return ReasonRateLimitTargetNotFound, fmt.Errorf("AiGateway %s has no model %s", gw.Name, scope.Model)
+		}
+	}
+	return "", nil
+}
+
+// effectiveRateLimits returns the rate limits in effect on the scopes of the
+// AiGateway gatewayName. When several policies share a scope, each limit
+// takes the lowest value among them.
+func effectiveRateLimits(ctx context.Context, c client.Reader, namespace, gatewayName string) (map[litellmv1alpha1.RateLimitScope]litellmv1alpha1.RateLimits, error) {
+	var list litellmv1alpha1.LiteLLMRateLimitPolicyList
+	if err := c.List(ctx, &list, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("listing LiteLLMRateLimitPolicies: %w", err)
+	}
+	limits := make(map[litellmv1alpha1.RateLimitScope]litellmv1alpha1.RateLimits)
+	for _, p := range list.Items {
+		if p.Spec.AiGatewayRef.Name != gatewayName || !p.DeletionTimestamp.IsZero() {
+			continue
+		}
+		cur := limits[p.Spec.Scope]
+		cur.RPM = minLimit(cur.RPM, p.Spec.Limits.RPM)
+		cur.TPM = minLimit(cur.TPM, p.Spec.Limits.TPM)
+		cur.MaxParallelRequests = minLimit(cur.MaxParallelRequests, p.Spec.Limits.MaxParallelRequests)
+		limits[p.Spec.Scope] = cur
+	}
+	return limits, nil
+}
+
+func minLimit(a, b *int64) *int64 {
+	if a == nil || (b != nil && *b < *a) {
+		return b
+	}
+	return a
+}
+
+// rateLimitsFor returns the rate limits of scope in the management API's
+// form; all nil when no policy applies.
+func rateLimitsFor(limits map[litellmv1alpha1.RateLimitScope]litellmv1alpha1.RateLimits, scope litellmv1alpha1.RateLimitScope) litellm.RateLimits {
+	l := limits[scope]
+	return litellm.RateLimits{RPMLimit: l.RPM, TPMLimit: l.TPM, MaxParallelRequests: l.MaxParallelRequests}
+}
+
+func rateLimitsEqual(a, b litellm.RateLimits) bool {
+	return ptr.Equal(a.RPMLimit, b.RPMLimit) && ptr.Equal(a.TPMLimit, b.TPMLimit) &&
+		ptr.Equal(a.MaxParallelRequests, b.MaxParallelRequests)
+}
+
+func rateLimitScopeString(scope litellmv1alpha1.RateLimitScope) string {
+	switch scope.Type {
+	case litellmv1alpha1.RateLimitScopeTeam:
+		return "LiteLLMTeam " + scope.Team
+	case litellmv1alpha1.RateLimitScopeKey:
+		return "LiteLLMVirtualKey " + scope.Key
+	default:
+		return "model " + scope.Model
+	}
+}
+
+func (r *LiteLLMRateLimitPolicyReconciler) updateCondition(policy *litellmv1alpha1.LiteLLMRateLimitPolicy, status metav1.ConditionStatus, reason, message string) {
+	apimeta.SetStatusCondition(&policy.Status.Conditions, metav1.Condition{
+		Type:               RateLimitReady,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: policy.Generation,
+	})
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *LiteLLMRateLimitPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Indexer key used to locate LiteLLMRateLimitPolicies by the AiGateway
+	// they apply to.
+	const rateLimitGatewayIndex = "spec.aiGatewayRef.name"
+
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &litellmv1alpha1.LiteLLMRateLimitPolicy{}, rateLimitGatewayIndex,
+		func(obj client.Object) []string {
+			policy, ok := obj.(*litellmv1alpha1.LiteLLMRateLimitPolicy)
+			if !ok {
+				return nil
+			}
+			return []string{policy.Spec.AiGatewayRef.Name}
+		},
+	); err != nil {
+		return fmt.Errorf("failed to register LiteLLMRateLimitPolicy gateway indexer: %w", err)
+	}
+
+	// enqueuePoliciesForGateway re-reconciles the policies of a gateway
+	// when the gateway, one of its teams or keys, or another policy of the
+	// gateway changes; the latter changes the effective limits.
+	enqueuePoliciesForGateway := func(gatewayName func(client.Object) string) handler.EventHandler {
+		return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+			var list litellmv1alpha1.LiteLLMRateLimitPolicyList
+			if err := r.List(ctx, &list,
+				client.InNamespace(obj.GetNamespace()),
+				client.MatchingFields{rateLimitGatewayIndex: gatewayName(obj)},
+			); err != nil {
+				logf.FromContext(ctx).Error(err, "Failed to list LiteLLMRateLimitPolicies for watch", "namespace", obj.GetNamespace(), "trigger", obj.GetName())
+				return nil
+			}
+			requests := make([]reconcile.Request, len(list.Items))
+			for i, policy := range list.Items {
+				requests[i] = reconcile.Request{NamespacedName: types.NamespacedName{Name: policy.Name, Namespace: policy.Namespace}}
+			}
+			return requests
+		})
+	}
+
+	specChanged := builder.WithPredicates(predicate.GenerationChangedPredicate{})
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&litellmv1alpha1.LiteLLMRateLimitPolicy{}, specChanged).
+		Watches(&gatewayv1alpha1.AiGateway{}, enqueuePoliciesForGateway(client.Object.GetName), specChanged).
+		Watches(&litellmv1alpha1.LiteLLMTeam{}, enqueuePoliciesForGateway(func(obj client.Object) string {
+			return obj.(*litellmv1alpha1.LiteLLMTeam).Spec.AiGatewayRef.Name
+		}), specChanged).
+		Watches(&litellmv1alpha1.LiteLLMVirtualKey{}, enqueuePoliciesForGateway(func(obj client.Object) string {
+			return obj.(*litellmv1alpha1.LiteLLMVirtualKey).Spec.AiGatewayRef.Name
+		}), specChanged).
+		Watches(&litellmv1alpha1.LiteLLMRateLimitPolicy{}, enqueuePoliciesForGateway(func(obj client.Object) string {
+			return obj.(*litellmv1alpha1.LiteLLMRateLimitPolicy).Spec.AiGatewayRef.Name
+		}), specChanged).
+		Named("litellmratelimitpolicy").
+		Complete(r)
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	litellmv1alpha1 "github.com/agentic-layer/ai-gateway-litellm/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newRateLimitPolicy(name string, limits litellmv1alpha1.RateLimits, scope litellmv1alpha1.RateLimitScope) *litellmv1alpha1.LiteLLMRateLimitPolicy {
+	return &litellmv1alpha1.LiteLLMRateLimitPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team-a", Generation: 1},
+		Spec: litellmv1alpha1.LiteLLMRateLimitPolicySpec{
+			AiGatewayRef: corev1.LocalObjectReference{Name: "gw"},
+			Limits:       limits,
+			Scope:        scope,
+		},
+	}
+}
+
+func rateLimitFixtures(t *testing.T, objs ...client.Object) (client.Client, *LiteLLMRateLimitPolicyReconciler) {
+	t.Helper()
+	s := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{gatewayv1alpha1.AddToScheme, litellmv1alpha1.AddToScheme, corev1.AddToScheme} {
+		if err := add(s); err != nil {
+			t.Fatalf("AddToScheme: %v", err)
+		}
+	}
+	gw := &gatewayv1alpha1.AiGateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "team-a"},
+		Spec: gatewayv1alpha1.AiGatewaySpec{
+			AiModels: []gatewayv1alpha1.AiModel{{Name: "gpt-4o", Provider: "openai"}},
+		},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(s).
+		WithObjects(append([]client.Object{gw}, objs...)...).
+		WithStatusSubresource(&litellmv1alpha1.LiteLLMRateLimitPolicy{}).
+		Build()
+	return c, &LiteLLMRateLimitPolicyReconciler{Client: c, Scheme: s}
+}
+
+func TestEffectiveRateLimits_LowestPerLimit(t *testing.T) {
+	scope := litellmv1alpha1.RateLimitScope{Type: litellmv1alpha1.RateLimitScopeModel, Model: "gpt-4o"}
+	other := newRateLimitPolicy("other-gateway", litellmv1alpha1.RateLimits{RPM: ptr.To[int64](1)}, scope)
+	other.Spec.AiGatewayRef.Name = "other"
+	c, _ := rateLimitFixtures(t,
+		newRateLimitPolicy("a", litellmv1alpha1.RateLimits{RPM: ptr.To[int64](100), TPM: ptr.To[int64](5000)}, scope),
+		newRateLimitPolicy("b", litellmv1alpha1.RateLimits{RPM: ptr.To[int64](50), MaxParallelRequests: ptr.To[int64](4)}, scope),
+		other,
+	)
+
+	limits, err := effectiveRateLimits(context.Background(), c, "team-a", "gw")
+	if err != nil {
+		t.Fatalf("effectiveRateLimits: %v", err)
+	}
+	got := limits[scope]
+	if len(limits) != 1 || *got.RPM != 50 || *got.TPM != 5000 || *got.MaxParallelRequests != 4 {
+		t.Errorf("effectiveRateLimits: got %v", limits)
+	}
+}
+
+func TestLiteLLMRateLimitPolicy_ReportsTarget(t *testing.T) {
+	limits := litellmv1alpha1.RateLimits{RPM: ptr.To[int64](60)}
+	c, r := rateLimitFixtures(t,
+		newRateLimitPolicy("model", limits, litellmv1alpha1.RateLimitScope{Type: litellmv1alpha1.RateLimitScopeModel, Model: "gpt-4o"}),
+		newRateLimitPolicy("no-model", limits, litellmv1alpha1.RateLimitScope{Type: litellmv1alpha1.RateLimitScopeModel, Model: "claude"}),
+		newRateLimitPolicy("no-key", limits, litellmv1alpha1.RateLimitScope{Type: litellmv1alpha1.RateLimitScopeKey, Key: "agents"}),
+	)
+	ctx := context.Background()
+
+	for name, reason := range map[string]string{
+		"model":    ReasonRateLimitApplied,
+		"no-model": ReasonRateLimitTargetNotFound,
+		"no-key":   ReasonRateLimitTargetNotFound,
+	} {
+		key := types.NamespacedName{Name: name, Namespace: "team-a"}
+		if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+			t.Fatalf("%s: Reconcile: %v", name, err)
+		}
+		var policy litellmv1alpha1.LiteLLMRateLimitPolicy
+		if err := c.Get(ctx, key, &policy); err != nil {
+			t.Fatalf("get LiteLLMRateLimitPolicy: %v", err)
+		}
+		if cond := apimeta.FindStatusCondition(policy.Status.Conditions, RateLimitReady); cond == nil || cond.Reason != reason {
+			t.Errorf("%s: Ready got %+v, want reason %s", name, cond, reason)
+		}
+		if applied := reason == ReasonRateLimitApplied; applied != (policy.Status.Effective != nil) {
+			t.Errorf("%s: effective limits got %+v", name, policy.Status.Effective)
+		}
+	}
+}
+
+func TestLiteLLMTeam_RateLimitPolicyApplied(t *testing.T) {
+	policy := newRateLimitPolicy("research-limits",
+		litellmv1alpha1.RateLimits{RPM: ptr.To[int64](60), TPM: ptr.To[int64](100000)},
+		litellmv1alpha1.RateLimitScope{Type: litellmv1alpha1.RateLimitScopeTeam, Team: "research"})
+	_, r, proxy := teamFixtures(t, policy)
+
+	if _, err := r.Reconcile(context.Background(), teamRequest); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if len(proxy.bodies) != 2 || proxy.bodies[1]["rpm_limit"] != 60.0 || proxy.bodies[1]["tpm_limit"] != 100000.0 {
+		t.Errorf("new team body: got %v, want the limits of the policy", proxy.bodies)
+	}
+
+	// The proxy reports no limits, e.g. after they were cleared in the
+	// admin UI: they are pushed again.
+	proxy.calls, proxy.bodies = nil, nil
+	proxy.maxBudget = 250
+	proxy.members = []map[string]string{{"user_email": "lead@example.com", "role": "admin"}, {"user_id": "u2", "role": "user"}}
+	if _, err := r.Reconcile(context.Background(), teamRequest); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if !slices.Equal(proxy.calls, []string{"/team/info", "/team/update"}) || proxy.bodies[1]["rpm_limit"] != 60.0 {
+		t.Errorf("calls: got %v with bodies %v, want a rate limit update", proxy.calls, proxy.bodies)
+	}
+}
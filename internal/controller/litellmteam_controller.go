@@ -0,0 +1,323 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	litellmv1alpha1 "github.com/agentic-layer/ai-gateway-litellm/api/v1alpha1"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/litellm"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// TeamReady is the condition type reporting whether a LiteLLMTeam exists
+// on its gateway with the current spec.
+const TeamReady = "Ready"
+
+// LiteLLMTeam condition reasons
+const (
+	ReasonTeamSynced     = "TeamSynced"
+	ReasonTeamSyncFailed = "TeamSyncFailed"
+)
+
+// teamFinalizer holds a LiteLLMTeam until its team is deleted on the proxy.
+const teamFinalizer = "litellm.agentic-layer.ai/delete-team"
+
+// LiteLLMTeamReconciler reconciles a LiteLLMTeam object
+type LiteLLMTeamReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	adminClientOptions
+}
+
+// +kubebuilder:rbac:groups=litellm.agentic-layer.ai,resources=litellmteams,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=litellm.agentic-layer.ai,resources=litellmteams/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=litellm.agentic-layer.ai,resources=litellmteams/finalizers,verbs=update
+// +kubebuilder:rbac:groups=litellm.agentic-layer.ai,resources=litellmbudgets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=litellm.agentic-layer.ai,resources=litellmratelimitpolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=runtime.agentic-layer.ai,resources=aigateways,verbs=get;list;watch
+
+func (r *LiteLLMTeamReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	var team litellmv1alpha1.LiteLLMTeam
+	if err := r.Get(ctx, req.NamespacedName, &team); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !team.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, r.deleteTeam(ctx, &team)
+	}
+	if controllerutil.AddFinalizer(&team, teamFinalizer) {
+		if err := r.Update(ctx, &team); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	original := team.DeepCopy()
+
+	admin, reason, err := gatewayAdminClient(ctx, r, team.Namespace, team.Spec.AiGatewayRef.Name, r.adminClientOptions)
+	if err == nil {
+		err = r.sync(ctx, &team, admin)
+	}
+	if err != nil {
+		log.Error(err, "Failed to sync team")
+		if reason == "" {
+			reason = ReasonTeamSyncFailed
+		}
+		r.updateCondition(&team, metav1.ConditionFalse, reason, err.Error())
+		if e := r.patchStatus(ctx, original, &team); e != nil {
+			return ctrl.Result{}, e
+		}
+		// Problems with the gateway wait for the watch event that fixes
+		// them; failed calls against the proxy are retried with backoff.
+		if reason != ReasonTeamSyncFailed {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	r.updateCondition(&team, metav1.ConditionTrue, ReasonTeamSynced,
+		fmt.Sprintf("Team synced to AiGateway %s", team.Spec.AiGatewayRef.Name))
+	team.Status.ObservedGeneration = team.Generation
+	return ctrl.Result{}, r.patchStatus(ctx, original, &team)
+}
+
+// sync creates the team or brings its limits and members in line with the
+// spec. Members, the budget and the rate limits are compared on every
+// reconcile so changes made in the admin UI are reverted; the remaining
+// limits are only pushed when the spec changed.
+func (r *LiteLLMTeamReconciler) sync(ctx context.Context, team *litellmv1alpha1.LiteLLMTeam, admin *litellm.AdminClient) error {
+	settings, err := teamSettings(team)
+	if err != nil {
+		return err
+	}
+	// A LiteLLMBudget scoped to the team takes precedence over maxBudget.
+	budgets, err := effectiveBudgets(ctx, r, team.Namespace, team.Spec.AiGatewayRef.Name)
+	if err != nil {
+		return err
+	}
+	if budget := budgets[litellmv1alpha1.BudgetScope{Type: litellmv1alpha1.BudgetScopeTeam, Team: team.Name}]; budget != nil {
+		settings.MaxBudget, settings.BudgetDuration = budgetLimits(budget)
+	}
+	rateLimits, err := effectiveRateLimits(ctx, r, team.Namespace, team.Spec.AiGatewayRef.Name)
+	if err != nil {
+		return err
+	}
+	settings.RateLimits = rateLimitsFor(rateLimits, litellmv1alpha1.RateLimitScope{Type: litellmv1alpha1.RateLimitScopeTeam, Team: team.Name})
+	desired := teamMembers(team.Spec.Members)
+
+	current, err := admin.TeamInfo(ctx, settings.ID)
+	switch {
+	case litellm.IsNotFound(err):
+		if err := admin.NewTeam(ctx, settings, desired); err != nil {
+			return err
+		}
+		team.Status.TeamID = settings.ID
+		return nil
+	case err != nil:
+		return err
+	}
+	team.Status.TeamID = settings.ID
+
+	if team.Status.ObservedGeneration != team.Generation ||
+		!ptr.Equal(current.MaxBudget, settings.MaxBudget) || current.BudgetDuration != settings.BudgetDuration ||
+		!rateLimitsEqual(current.RateLimits, settings.RateLimits) {
+		if err := admin.UpdateTeam(ctx, settings); err != nil {
+			return err
+		}
+	}
+	for _, m := range current.Members {
+		if i := findMember(desired, m); i < 0 || desired[i].Role != m.Role {
+			if err := admin.RemoveTeamMember(ctx, settings.ID, m); err != nil {
+				return fmt.Errorf("removing team member: %w", err)
+			}
+		}
+	}
+	for _, m := range desired {
+		if i := findMember(current.Members, m); i < 0 || current.Members[i].Role != m.Role {
+			if err := admin.AddTeamMember(ctx, settings.ID, m); err != nil {
+				return fmt.Errorf("adding team member: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// deleteTeam deletes the team from the proxy and releases the finalizer.
+// As for virtual keys, the finalizer is released right away when there is
+// no usable gateway left to delete the team on.
+func (r *LiteLLMTeamReconciler) deleteTeam(ctx context.Context, team *litellmv1alpha1.LiteLLMTeam) error {
+	if !controllerutil.ContainsFinalizer(team, teamFinalizer) {
+		return nil
+	}
+	admin, reason, err := gatewayAdminClient(ctx, r, team.Namespace, team.Spec.AiGatewayRef.Name, r.adminClientOptions)
+	switch {
+	case err == nil:
+		if err := admin.DeleteTeam(ctx, teamID(team)); err != nil {
+			return fmt.Errorf("deleting team: %w", err)
+		}
+	case reason == "":
+		return err
+	default:
+		logf.FromContext(ctx).Info("Skipping team deletion", "reason", err.Error())
+	}
+	controllerutil.RemoveFinalizer(team, teamFinalizer)
+	return r.Update(ctx, team)
+}
+
+// teamID is the team_id identifying team on the proxy.
+func teamID(team *litellmv1alpha1.LiteLLMTeam) string {
+	return team.Namespace + "/" + team.Name
+}
+
+// teamSettings converts the spec of team to the management API's form.
+func teamSettings(team *litellmv1alpha1.LiteLLMTeam) (litellm.Team, error) {
+	budget, err := parseBudget(team.Spec.MaxBudget)
+	return litellm.Team{
+		ID:             teamID(team),
+		Alias:          team.Name,
+		Models:         team.Spec.Models,
+		MaxBudget:      budget,
+		BudgetDuration: team.Spec.BudgetDuration,
+	}, err
+}
+
+func teamMembers(members []litellmv1alpha1.LiteLLMTeamMember) []litellm.TeamMember {
+	out := make([]litellm.TeamMember, len(members))
+	for i, m := range members {
+		role := m.Role
+		if role == "" {
+			role = "user"
+		}
+		out[i] = litellm.TeamMember{UserID: m.UserID, UserEmail: m.UserEmail, Role: role}
+	}
+	return out
+}
+
+// findMember returns the index of the member of members that is the same
+// user as m, or -1. Users are matched by ID when both sides have one and by
+// email otherwise, since the proxy fills in the ID of members added by email.
+func findMember(members []litellm.TeamMember, m litellm.TeamMember) int {
+	for i, o := range members {
+		if o.UserID != "" && m.UserID != "" {
+			if o.UserID == m.UserID {
+				return i
+			}
+		} else if o.UserEmail != "" && o.UserEmail == m.UserEmail {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r *LiteLLMTeamReconciler) updateCondition(team *litellmv1alpha1.LiteLLMTeam, status metav1.ConditionStatus, reason, message string) {
+	apimeta.SetStatusCondition(&team.Status.Conditions, metav1.Condition{
+		Type:               TeamReady,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: team.Generation,
+	})
+}
+
+func (r *LiteLLMTeamReconciler) patchStatus(ctx context.Context, original, team *litellmv1alpha1.LiteLLMTeam) error {
+	if err := r.Status().Patch(ctx, team, client.MergeFrom(original)); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to patch LiteLLMTeam status")
+		return err
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *LiteLLMTeamReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Indexer key used to locate LiteLLMTeams by the AiGateway they are
+	// managed on.
+	const teamGatewayIndex = "spec.aiGatewayRef.name"
+
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &litellmv1alpha1.LiteLLMTeam{}, teamGatewayIndex,
+		func(obj client.Object) []string {
+			team, ok := obj.(*litellmv1alpha1.LiteLLMTeam)
+			if !ok {
+				return nil
+			}
+			return []string{team.Spec.AiGatewayRef.Name}
+		},
+	); err != nil {
+		return fmt.Errorf("failed to register LiteLLMTeam gateway indexer: %w", err)
+	}
+
+	enqueueTeamsForGateway := handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		log := logf.FromContext(ctx)
+		var teamList litellmv1alpha1.LiteLLMTeamList
+		if err := r.List(ctx, &teamList,
+			client.InNamespace(obj.GetNamespace()),
+			client.MatchingFields{teamGatewayIndex: obj.GetName()},
+		); err != nil {
+			log.Error(err, "Failed to list LiteLLMTeams for AiGateway watch", "namespace", obj.GetNamespace(), "aigateway", obj.GetName())
+			return nil
+		}
+		requests := make([]reconcile.Request, len(teamList.Items))
+		for i, team := range teamList.Items {
+			requests[i] = reconcile.Request{NamespacedName: types.NamespacedName{Name: team.Name, Namespace: team.Namespace}}
+		}
+		return requests
+	})
+
+	// enqueueTeamForBudget re-reconciles the team a Team-scoped
+	// LiteLLMBudget applies to.
+	enqueueTeamForBudget := handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		budget, ok := obj.(*litellmv1alpha1.LiteLLMBudget)
+		if !ok || budget.Spec.Scope.Type != litellmv1alpha1.BudgetScopeTeam {
+			return nil
+		}
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: budget.Spec.Scope.Team, Namespace: budget.Namespace}}}
+	})
+
+	// enqueueTeamForRateLimit re-reconciles the team a Team-scoped
+	// LiteLLMRateLimitPolicy applies to.
+	enqueueTeamForRateLimit := handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		policy, ok := obj.(*litellmv1alpha1.LiteLLMRateLimitPolicy)
+		if !ok || policy.Spec.Scope.Type != litellmv1alpha1.RateLimitScopeTeam {
+			return nil
+		}
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: policy.Spec.Scope.Team, Namespace: policy.Namespace}}}
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&litellmv1alpha1.LiteLLMTeam{}).
+		Watches(&gatewayv1alpha1.AiGateway{}, enqueueTeamsForGateway).
+		Watches(&litellmv1alpha1.LiteLLMBudget{}, enqueueTeamForBudget,
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&litellmv1alpha1.LiteLLMRateLimitPolicy{}, enqueueTeamForRateLimit,
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Named("litellmteam").
+		Complete(r)
+}
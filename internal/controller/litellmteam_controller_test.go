@@ -0,0 +1,218 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	litellmv1alpha1 "github.com/agentic-layer/ai-gateway-litellm/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// fakeTeamProxy records the management API calls of a team reconcile and
+// answers /team/info with members, or 404 while members is nil.
+type fakeTeamProxy struct {
+	mu      sync.Mutex
+	calls   []string
+	bodies  []map[string]any
+	members []map[string]string
+	// maxBudget is the team budget reported by /team/info.
+	maxBudget float64
+}
+
+func (p *fakeTeamProxy) serve(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		p.calls = append(p.calls, r.URL.Path)
+		p.bodies = append(p.bodies, body)
+		if r.URL.Path != "/team/info" {
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+		if p.members == nil {
+			http.Error(w, "team not found", http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"team_info": map[string]any{"members_with_roles": p.members, "max_budget": p.maxBudget}})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func teamFixtures(t *testing.T, objs ...client.Object) (client.Client, *LiteLLMTeamReconciler, *fakeTeamProxy) {
+	t.Helper()
+	s := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{gatewayv1alpha1.AddToScheme, litellmv1alpha1.AddToScheme, corev1.AddToScheme} {
+		if err := add(s); err != nil {
+			t.Fatalf("AddToScheme: %v", err)
+		}
+	}
+	class := &gatewayv1alpha1.AiGatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "litellm"},
+		Spec:       gatewayv1alpha1.AiGatewayClassSpec{Controller: ControllerName},
+	}
+	gw := &gatewayv1alpha1.AiGateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "team-a"},
+		Spec: gatewayv1alpha1.AiGatewaySpec{
+			AiGatewayClassName: "litellm",
+			Port:               4000,
+			Env:                []corev1.EnvVar{{Name: "DATABASE_URL", Value: "postgres://db"}},
+		},
+	}
+	team := &litellmv1alpha1.LiteLLMTeam{
+		ObjectMeta: metav1.ObjectMeta{Name: "research", Namespace: "team-a", Generation: 1},
+		Spec: litellmv1alpha1.LiteLLMTeamSpec{
+			AiGatewayRef: corev1.LocalObjectReference{Name: "gw"},
+			MaxBudget:    "250",
+			Members: []litellmv1alpha1.LiteLLMTeamMember{
+				{UserEmail: "lead@example.com", Role: "admin"},
+				{UserID: "u2"},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(s).
+		WithObjects(append([]client.Object{class, gw, team}, objs...)...).
+		WithStatusSubresource(&litellmv1alpha1.LiteLLMTeam{}).
+		Build()
+	proxy := &fakeTeamProxy{}
+	srv := proxy.serve(t)
+	r := &LiteLLMTeamReconciler{
+		Client:             c,
+		Scheme:             s,
+		adminClientOptions: adminClientOptions{serviceURL: func(*gatewayv1alpha1.AiGateway) string { return srv.URL }},
+	}
+	return c, r, proxy
+}
+
+var teamRequest = ctrl.Request{NamespacedName: types.NamespacedName{Name: "research", Namespace: "team-a"}}
+
+func TestLiteLLMTeam_CreatesTeamAndConvergesMembers(t *testing.T) {
+	c, r, proxy := teamFixtures(t)
+	ctx := context.Background()
+
+	if _, err := r.Reconcile(ctx, teamRequest); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if !slices.Equal(proxy.calls, []string{"/team/info", "/team/new"}) {
+		t.Fatalf("calls: got %v, want info then new", proxy.calls)
+	}
+	created := proxy.bodies[1]
+	if created["team_id"] != "team-a/research" || created["max_budget"] != 250.0 {
+		t.Errorf("new team body: got %v", created)
+	}
+	if members, _ := created["members_with_roles"].([]any); len(members) != 2 {
+		t.Errorf("new team members: got %v", created["members_with_roles"])
+	}
+	var team litellmv1alpha1.LiteLLMTeam
+	if err := c.Get(ctx, teamRequest.NamespacedName, &team); err != nil {
+		t.Fatalf("get LiteLLMTeam: %v", err)
+	}
+	if !apimeta.IsStatusConditionTrue(team.Status.Conditions, TeamReady) || team.Status.TeamID != "team-a/research" {
+		t.Errorf("status: got %+v", team.Status)
+	}
+
+	// Someone added a member in the admin UI and demoted the lead; the
+	// reconcile removes the extra member and restores the role without
+	// touching the limits of the unchanged spec.
+	proxy.calls, proxy.bodies = nil, nil
+	proxy.maxBudget = 250
+	proxy.members = []map[string]string{
+		{"user_id": "u1", "user_email": "lead@example.com", "role": "user"},
+		{"user_id": "u2", "role": "user"},
+		{"user_id": "u3", "role": "user"},
+	}
+	if _, err := r.Reconcile(ctx, teamRequest); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	want := []string{"/team/info", "/team/member_delete", "/team/member_delete", "/team/member_add"}
+	if !slices.Equal(proxy.calls, want) {
+		t.Fatalf("calls: got %v, want %v", proxy.calls, want)
+	}
+	if proxy.bodies[1]["user_id"] != "u1" || proxy.bodies[2]["user_id"] != "u3" {
+		t.Errorf("removed members: got %v and %v", proxy.bodies[1], proxy.bodies[2])
+	}
+	if added, _ := proxy.bodies[3]["member"].(map[string]any); added["user_email"] != "lead@example.com" || added["role"] != "admin" {
+		t.Errorf("added member: got %v", proxy.bodies[3])
+	}
+}
+
+func TestLiteLLMTeam_BudgetTakesPrecedence(t *testing.T) {
+	budget := newBudget("research-budget", "80", litellmv1alpha1.BudgetScope{Type: litellmv1alpha1.BudgetScopeTeam, Team: "research"})
+	_, r, proxy := teamFixtures(t, budget)
+
+	if _, err := r.Reconcile(context.Background(), teamRequest); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if len(proxy.bodies) != 2 || proxy.bodies[1]["max_budget"] != 80.0 || proxy.bodies[1]["budget_duration"] != "30d" {
+		t.Errorf("new team body: got %v, want the budget of the LiteLLMBudget", proxy.bodies)
+	}
+
+	// The proxy still reports the team's own budget: the LiteLLMBudget is
+	// pushed again although the spec of the team did not change.
+	proxy.calls, proxy.bodies = nil, nil
+	proxy.maxBudget = 250
+	proxy.members = []map[string]string{{"user_email": "lead@example.com", "role": "admin"}, {"user_id": "u2", "role": "user"}}
+	if _, err := r.Reconcile(context.Background(), teamRequest); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if !slices.Equal(proxy.calls, []string{"/team/info", "/team/update"}) || proxy.bodies[1]["max_budget"] != 80.0 {
+		t.Errorf("calls: got %v with bodies %v, want a budget update", proxy.calls, proxy.bodies)
+	}
+}
+
+func TestLiteLLMTeam_DeletesTeamOnDeletion(t *testing.T) {
+	c, r, proxy := teamFixtures(t)
+	ctx := context.Background()
+
+	if _, err := r.Reconcile(ctx, teamRequest); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	team := &litellmv1alpha1.LiteLLMTeam{ObjectMeta: metav1.ObjectMeta{Name: "research", Namespace: "team-a"}}
+	if err := c.Delete(ctx, team); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	proxy.calls = nil
+	if _, err := r.Reconcile(ctx, teamRequest); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if !slices.Equal(proxy.calls, []string{"/team/delete"}) {
+		t.Errorf("calls: got %v, want a single delete", proxy.calls)
+	}
+	if err := c.Get(ctx, teamRequest.NamespacedName, team); !apierrors.IsNotFound(err) {
+		t.Errorf("LiteLLMTeam should be gone once the finalizer is released, got %v", err)
+	}
+}
@@ -0,0 +1,340 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"maps"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	litellmv1alpha1 "github.com/agentic-layer/ai-gateway-litellm/api/v1alpha1"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/litellm"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
+	metav1ac "k8s.io/client-go/applyconfigurations/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// VirtualKeyReady is the condition type reporting whether a
+// LiteLLMVirtualKey is provisioned and written to its Secret.
+const VirtualKeyReady = "Ready"
+
+// LiteLLMVirtualKey condition reasons
+const (
+	ReasonKeyProvisioned        = "KeyProvisioned"
+	ReasonKeyProvisioningFailed = "KeyProvisioningFailed"
+	ReasonSecretConflict        = "SecretConflict"
+)
+
+// Data keys of the Secret a LiteLLMVirtualKey is written to. They are valid
+// env var names so the Secret can be mounted with envFrom.
+const (
+	VirtualKeySecretAPIKey  = "LITELLM_API_KEY"
+	VirtualKeySecretBaseURL = "LITELLM_BASE_URL"
+)
+
+// virtualKeyFinalizer holds a LiteLLMVirtualKey until its key is revoked.
+const virtualKeyFinalizer = "litellm.agentic-layer.ai/revoke-key"
+
+// LiteLLMVirtualKeyReconciler reconciles a LiteLLMVirtualKey object
+type LiteLLMVirtualKeyReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	adminClientOptions
+}
+
+// +kubebuilder:rbac:groups=litellm.agentic-layer.ai,resources=litellmvirtualkeys,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=litellm.agentic-layer.ai,resources=litellmvirtualkeys/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=litellm.agentic-layer.ai,resources=litellmvirtualkeys/finalizers,verbs=update
+// +kubebuilder:rbac:groups=litellm.agentic-layer.ai,resources=litellmratelimitpolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=runtime.agentic-layer.ai,resources=aigateways,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+
+func (r *LiteLLMVirtualKeyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	var vk litellmv1alpha1.LiteLLMVirtualKey
+	if err := r.Get(ctx, req.NamespacedName, &vk); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !vk.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, r.revoke(ctx, &vk)
+	}
+	if controllerutil.AddFinalizer(&vk, virtualKeyFinalizer) {
+		if err := r.Update(ctx, &vk); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	original := vk.DeepCopy()
+
+	admin, reason, err := r.adminClientFor(ctx, &vk)
+	if err == nil {
+		reason, err = r.provision(ctx, &vk, admin)
+	}
+	if err != nil {
+		log.Error(err, "Failed to provision virtual key")
+		if reason == "" {
+			reason = ReasonKeyProvisioningFailed
+		}
+		r.updateCondition(&vk, metav1.ConditionFalse, reason, err.Error())
+		if e := r.patchStatus(ctx, original, &vk); e != nil {
+			return ctrl.Result{}, e
+		}
+		// Problems with the gateway or Secret wait for the watch event that
+		// fixes them; failed calls against the proxy are retried with backoff.
+		if reason != ReasonKeyProvisioningFailed {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	r.updateCondition(&vk, metav1.ConditionTrue, ReasonKeyProvisioned,
+		fmt.Sprintf("Key provisioned on AiGateway %s and stored in Secret %s", vk.Spec.AiGatewayRef.Name, vk.Status.SecretName))
+	vk.Status.ObservedGeneration = vk.Generation
+	return ctrl.Result{}, r.patchStatus(ctx, original, &vk)
+}
+
+// adminClientFor returns a management API client for the gateway vk
+// refers to; see gatewayAdminClient.
+func (r *LiteLLMVirtualKeyReconciler) adminClientFor(ctx context.Context, vk *litellmv1alpha1.LiteLLMVirtualKey) (*litellm.AdminClient, string, error) {
+	return gatewayAdminClient(ctx, r, vk.Namespace, vk.Spec.AiGatewayRef.Name, r.adminClientOptions)
+}
+
+// provision makes sure the key exists with the limits of the current spec
+// and is stored in the Secret. A missing or empty Secret means a new key:
+// any key left under the same alias, e.g. by a Secret write that failed
+// after the key was generated, is revoked first.
+func (r *LiteLLMVirtualKeyReconciler) provision(ctx context.Context, vk *litellmv1alpha1.LiteLLMVirtualKey, admin *litellm.AdminClient) (string, error) {
+	secretName := vk.Spec.SecretName
+	if secretName == "" {
+		secretName = vk.Name
+	}
+	var secret corev1.Secret
+	err := r.Get(ctx, types.NamespacedName{Namespace: vk.Namespace, Name: secretName}, &secret)
+	switch {
+	case apierrors.IsNotFound(err):
+	case err != nil:
+		return "", err
+	case !metav1.IsControlledBy(&secret, vk):
+		return ReasonSecretConflict, fmt.Errorf("secret %s already exists and is not controlled by this LiteLLMVirtualKey", secretName)
+	}
+
+	settings, err := keySettings(vk.Spec)
+	if err != nil {
+		return "", err
+	}
+	rateLimits, err := effectiveRateLimits(ctx, r, vk.Namespace, vk.Spec.AiGatewayRef.Name)
+	if err != nil {
+		return "", err
+	}
+	scope := litellmv1alpha1.RateLimitScope{Type: litellmv1alpha1.RateLimitScopeKey, Key: vk.Name}
+	settings.RateLimits = rateLimitsFor(rateLimits, scope)
+	var appliedLimits *litellmv1alpha1.RateLimits
+	if limits, ok := rateLimits[scope]; ok {
+		appliedLimits = &limits
+	}
+	apiKey := string(secret.Data[VirtualKeySecretAPIKey])
+	switch {
+	case apiKey == "":
+		alias := virtualKeyAlias(vk)
+		if err := admin.DeleteKeyByAlias(ctx, alias); err != nil {
+			return "", fmt.Errorf("revoking previous key: %w", err)
+		}
+		generated, err := admin.GenerateKey(ctx, alias, settings)
+		if err != nil {
+			return "", err
+		}
+		apiKey = generated.Key
+		vk.Status.Expires = expiresTime(generated)
+	case vk.Status.ObservedGeneration != vk.Generation || !apiequality.Semantic.DeepEqual(vk.Status.RateLimits, appliedLimits):
+		updated, err := admin.UpdateKey(ctx, apiKey, settings)
+		if err != nil {
+			return "", err
+		}
+		vk.Status.Expires = expiresTime(updated)
+	}
+
+	data := map[string][]byte{
+		VirtualKeySecretAPIKey:  []byte(apiKey),
+		VirtualKeySecretBaseURL: []byte(admin.BaseURL),
+	}
+	if !maps.EqualFunc(secret.Data, data, bytes.Equal) {
+		if err := r.applySecret(ctx, vk, secretName, data); err != nil {
+			return "", err
+		}
+	}
+	vk.Status.SecretName = secretName
+	vk.Status.RateLimits = appliedLimits
+	return "", nil
+}
+
+// applySecret server-side applies the key Secret, controlled by vk so it is
+// garbage-collected with it.
+func (r *LiteLLMVirtualKeyReconciler) applySecret(ctx context.Context, vk *litellmv1alpha1.LiteLLMVirtualKey, name string, data map[string][]byte) error {
+	gvk := litellmv1alpha1.GroupVersion.WithKind("LiteLLMVirtualKey")
+	secret := corev1ac.Secret(name, vk.Namespace).
+		WithLabels(map[string]string{litellm.ManagedByLabel: litellm.FieldManager}).
+		WithOwnerReferences(metav1ac.OwnerReference().
+			WithAPIVersion(gvk.GroupVersion().String()).
+			WithKind(gvk.Kind).
+			WithName(vk.Name).
+			WithUID(vk.UID).
+			WithController(true).
+			WithBlockOwnerDeletion(true)).
+		WithType(corev1.SecretTypeOpaque).
+		WithData(data)
+	return r.Apply(ctx, secret, client.FieldOwner(litellm.FieldManager), client.ForceOwnership)
+}
+
+// revoke deletes the key from the proxy and releases the finalizer. When the
+// gateway is gone, being deleted or no longer database-backed there is
+// nothing left to revoke against, so the finalizer is released right away;
+// this keeps namespace deletion from hanging on a gateway deleted first.
+func (r *LiteLLMVirtualKeyReconciler) revoke(ctx context.Context, vk *litellmv1alpha1.LiteLLMVirtualKey) error {
+	if !controllerutil.ContainsFinalizer(vk, virtualKeyFinalizer) {
+		return nil
+	}
+	admin, reason, err := r.adminClientFor(ctx, vk)
+	switch {
+	case err == nil:
+		if err := admin.DeleteKeyByAlias(ctx, virtualKeyAlias(vk)); err != nil {
+			return fmt.Errorf("revoking key: %w", err)
+		}
+	case reason == "":
+		return err
+	default:
+		logf.FromContext(ctx).Info("Skipping key revocation", "reason", err.Error())
+	}
+	controllerutil.RemoveFinalizer(vk, virtualKeyFinalizer)
+	return r.Update(ctx, vk)
+}
+
+// virtualKeyAlias is the key_alias identifying vk's key on the proxy.
+func virtualKeyAlias(vk *litellmv1alpha1.LiteLLMVirtualKey) string {
+	return vk.Namespace + "/" + vk.Name
+}
+
+// keySettings converts the limits of spec to the management API's form.
+func keySettings(spec litellmv1alpha1.LiteLLMVirtualKeySpec) (litellm.KeySettings, error) {
+	settings := litellm.KeySettings{
+		Models:         spec.Models,
+		BudgetDuration: spec.BudgetDuration,
+		Duration:       spec.Duration,
+	}
+	budget, err := parseBudget(spec.MaxBudget)
+	settings.MaxBudget = budget
+	return settings, err
+}
+
+func expiresTime(key *litellm.GeneratedKey) *metav1.Time {
+	if key.Expires == nil {
+		return nil
+	}
+	t := metav1.NewTime(*key.Expires)
+	return &t
+}
+
+func (r *LiteLLMVirtualKeyReconciler) updateCondition(vk *litellmv1alpha1.LiteLLMVirtualKey, status metav1.ConditionStatus, reason, message string) {
+	apimeta.SetStatusCondition(&vk.Status.Conditions, metav1.Condition{
+		Type:               VirtualKeyReady,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: vk.Generation,
+	})
+}
+
+func (r *LiteLLMVirtualKeyReconciler) patchStatus(ctx context.Context, original, vk *litellmv1alpha1.LiteLLMVirtualKey) error {
+	if err := r.Status().Patch(ctx, vk, client.MergeFrom(original)); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to patch LiteLLMVirtualKey status")
+		return err
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *LiteLLMVirtualKeyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Indexer key used to locate LiteLLMVirtualKeys by the AiGateway they
+	// provision their key on.
+	const virtualKeyGatewayIndex = "spec.aiGatewayRef.name"
+
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &litellmv1alpha1.LiteLLMVirtualKey{}, virtualKeyGatewayIndex,
+		func(obj client.Object) []string {
+			vk, ok := obj.(*litellmv1alpha1.LiteLLMVirtualKey)
+			if !ok {
+				return nil
+			}
+			return []string{vk.Spec.AiGatewayRef.Name}
+		},
+	); err != nil {
+		return fmt.Errorf("failed to register LiteLLMVirtualKey gateway indexer: %w", err)
+	}
+
+	// enqueueVirtualKeysForGateway re-reconciles the keys of a gateway when
+	// it appears, becomes database-backed or changes its port.
+	enqueueVirtualKeysForGateway := handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		log := logf.FromContext(ctx)
+		var vkList litellmv1alpha1.LiteLLMVirtualKeyList
+		if err := r.List(ctx, &vkList,
+			client.InNamespace(obj.GetNamespace()),
+			client.MatchingFields{virtualKeyGatewayIndex: obj.GetName()},
+		); err != nil {
+			log.Error(err, "Failed to list LiteLLMVirtualKeys for AiGateway watch", "namespace", obj.GetNamespace(), "aigateway", obj.GetName())
+			return nil
+		}
+		requests := make([]reconcile.Request, len(vkList.Items))
+		for i, vk := range vkList.Items {
+			requests[i] = reconcile.Request{NamespacedName: types.NamespacedName{Name: vk.Name, Namespace: vk.Namespace}}
+		}
+		return requests
+	})
+
+	// enqueueVirtualKeyForRateLimit re-reconciles the key a Key-scoped
+	// LiteLLMRateLimitPolicy applies to.
+	enqueueVirtualKeyForRateLimit := handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		policy, ok := obj.(*litellmv1alpha1.LiteLLMRateLimitPolicy)
+		if !ok || policy.Spec.Scope.Type != litellmv1alpha1.RateLimitScopeKey {
+			return nil
+		}
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: policy.Spec.Scope.Key, Namespace: policy.Namespace}}}
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&litellmv1alpha1.LiteLLMVirtualKey{}).
+		Owns(&corev1.Secret{}).
+		Watches(&gatewayv1alpha1.AiGateway{}, enqueueVirtualKeysForGateway).
+		Watches(&litellmv1alpha1.LiteLLMRateLimitPolicy{}, enqueueVirtualKeyForRateLimit,
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Named("litellmvirtualkey").
+		Complete(r)
+}
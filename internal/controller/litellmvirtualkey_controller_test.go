@@ -0,0 +1,213 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	litellmv1alpha1 "github.com/agentic-layer/ai-gateway-litellm/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// fakeKeyProxy records the management API calls of a virtual key reconcile.
+type fakeKeyProxy struct {
+	mu    sync.Mutex
+	calls []string
+	last  map[string]any
+}
+
+func (p *fakeKeyProxy) serve(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		p.mu.Lock()
+		p.calls = append(p.calls, r.URL.Path)
+		p.last = body
+		p.mu.Unlock()
+		switch r.URL.Path {
+		case "/key/generate":
+			_, _ = w.Write([]byte(`{"key": "sk-generated", "expires": null}`))
+		case "/key/delete":
+			http.Error(w, "key not found", http.StatusNotFound)
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func virtualKeyFixtures(t *testing.T, gatewayEnv []corev1.EnvVar) (client.Client, *LiteLLMVirtualKeyReconciler, *fakeKeyProxy) {
+	t.Helper()
+	s := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{gatewayv1alpha1.AddToScheme, litellmv1alpha1.AddToScheme, corev1.AddToScheme} {
+		if err := add(s); err != nil {
+			t.Fatalf("AddToScheme: %v", err)
+		}
+	}
+	class := &gatewayv1alpha1.AiGatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "litellm"},
+		Spec:       gatewayv1alpha1.AiGatewayClassSpec{Controller: ControllerName},
+	}
+	gw := &gatewayv1alpha1.AiGateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "team-a"},
+		Spec: gatewayv1alpha1.AiGatewaySpec{
+			AiGatewayClassName: "litellm",
+			Port:               4000,
+			Env:                gatewayEnv,
+		},
+	}
+	vk := &litellmv1alpha1.LiteLLMVirtualKey{
+		ObjectMeta: metav1.ObjectMeta{Name: "agents", Namespace: "team-a", UID: "vk-uid", Generation: 1},
+		Spec: litellmv1alpha1.LiteLLMVirtualKeySpec{
+			AiGatewayRef: corev1.LocalObjectReference{Name: "gw"},
+			Models:       []string{"gpt-4o"},
+			MaxBudget:    "25",
+		},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(s).
+		WithObjects(class, gw, vk).
+		WithStatusSubresource(&litellmv1alpha1.LiteLLMVirtualKey{}).
+		Build()
+	proxy := &fakeKeyProxy{}
+	srv := proxy.serve(t)
+	r := &LiteLLMVirtualKeyReconciler{
+		Client:             c,
+		Scheme:             s,
+		adminClientOptions: adminClientOptions{serviceURL: func(*gatewayv1alpha1.AiGateway) string { return srv.URL }},
+	}
+	return c, r, proxy
+}
+
+var virtualKeyRequest = ctrl.Request{NamespacedName: types.NamespacedName{Name: "agents", Namespace: "team-a"}}
+
+func TestLiteLLMVirtualKey_ProvisionsKeyIntoSecret(t *testing.T) {
+	c, r, proxy := virtualKeyFixtures(t, []corev1.EnvVar{{Name: "DATABASE_URL", Value: "postgres://db"}})
+	ctx := context.Background()
+
+	if _, err := r.Reconcile(ctx, virtualKeyRequest); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if len(proxy.calls) != 2 || proxy.calls[0] != "/key/delete" || proxy.calls[1] != "/key/generate" {
+		t.Fatalf("calls: got %v, want delete of a stale alias then generate", proxy.calls)
+	}
+	if proxy.last["key_alias"] != "team-a/agents" || proxy.last["max_budget"] != 25.0 {
+		t.Errorf("generate body: got %v", proxy.last)
+	}
+
+	var secret corev1.Secret
+	if err := c.Get(ctx, types.NamespacedName{Name: "agents", Namespace: "team-a"}, &secret); err != nil {
+		t.Fatalf("get Secret: %v", err)
+	}
+	if got := string(secret.Data[VirtualKeySecretAPIKey]); got != "sk-generated" {
+		t.Errorf("%s: got %q", VirtualKeySecretAPIKey, got)
+	}
+	var vk litellmv1alpha1.LiteLLMVirtualKey
+	if err := c.Get(ctx, virtualKeyRequest.NamespacedName, &vk); err != nil {
+		t.Fatalf("get LiteLLMVirtualKey: %v", err)
+	}
+	if !metav1.IsControlledBy(&secret, &vk) {
+		t.Errorf("Secret must be controlled by the LiteLLMVirtualKey: %+v", secret.OwnerReferences)
+	}
+	if !apimeta.IsStatusConditionTrue(vk.Status.Conditions, VirtualKeyReady) || vk.Status.SecretName != "agents" {
+		t.Errorf("status: got %+v", vk.Status)
+	}
+
+	// A second reconcile of the unchanged spec leaves the key alone.
+	proxy.calls = nil
+	if _, err := r.Reconcile(ctx, virtualKeyRequest); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if len(proxy.calls) != 0 {
+		t.Errorf("steady state must not call the proxy, got %v", proxy.calls)
+	}
+
+	// A spec change updates the existing key.
+	if err := c.Get(ctx, virtualKeyRequest.NamespacedName, &vk); err != nil {
+		t.Fatalf("get LiteLLMVirtualKey: %v", err)
+	}
+	vk.Spec.MaxBudget = "50"
+	vk.Generation = 2
+	if err := c.Update(ctx, &vk); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, virtualKeyRequest); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if len(proxy.calls) != 1 || proxy.calls[0] != "/key/update" || proxy.last["key"] != "sk-generated" {
+		t.Errorf("calls: got %v with body %v, want a single update", proxy.calls, proxy.last)
+	}
+}
+
+func TestLiteLLMVirtualKey_RequiresDatabaseBackedGateway(t *testing.T) {
+	c, r, proxy := virtualKeyFixtures(t, nil)
+	ctx := context.Background()
+
+	if _, err := r.Reconcile(ctx, virtualKeyRequest); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if len(proxy.calls) != 0 {
+		t.Errorf("calls: got %v, want none", proxy.calls)
+	}
+	var vk litellmv1alpha1.LiteLLMVirtualKey
+	if err := c.Get(ctx, virtualKeyRequest.NamespacedName, &vk); err != nil {
+		t.Fatalf("get LiteLLMVirtualKey: %v", err)
+	}
+	cond := apimeta.FindStatusCondition(vk.Status.Conditions, VirtualKeyReady)
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != ReasonGatewayNotDatabase {
+		t.Errorf("Ready: got %+v", cond)
+	}
+}
+
+func TestLiteLLMVirtualKey_RevokesKeyOnDeletion(t *testing.T) {
+	c, r, proxy := virtualKeyFixtures(t, []corev1.EnvVar{{Name: "DATABASE_URL", Value: "postgres://db"}})
+	ctx := context.Background()
+
+	if _, err := r.Reconcile(ctx, virtualKeyRequest); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	vk := &litellmv1alpha1.LiteLLMVirtualKey{ObjectMeta: metav1.ObjectMeta{Name: "agents", Namespace: "team-a"}}
+	if err := c.Delete(ctx, vk); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	proxy.calls = nil
+	if _, err := r.Reconcile(ctx, virtualKeyRequest); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if len(proxy.calls) != 1 || proxy.calls[0] != "/key/delete" {
+		t.Errorf("calls: got %v, want a single delete", proxy.calls)
+	}
+	if err := c.Get(ctx, virtualKeyRequest.NamespacedName, vk); !apierrors.IsNotFound(err) {
+		t.Errorf("LiteLLMVirtualKey should be gone once the finalizer is released, got %v", err)
+	}
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	gatewaySpend = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "litellm_gateway_spend_usd",
+		Help: "Total spend reported by LiteLLM /global/spend for an AiGateway, in USD.",
+	}, []string{"namespace", "name"})
+
+	gatewayModelSpend = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "litellm_gateway_model_spend_usd",
+		Help: "Per-model spend reported by LiteLLM /global/spend/models for an AiGateway, in USD.",
+	}, []string{"namespace", "name", "model"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(gatewaySpend, gatewayModelSpend)
+}
+
+// forgetSpendMetrics drops every spend series of a deleted gateway so the
+// exporter does not keep reporting stale values.
+func forgetSpendMetrics(key types.NamespacedName) {
+	labels := prometheus.Labels{"namespace": key.Namespace, "name": key.Name}
+	gatewaySpend.DeletePartialMatch(labels)
+	gatewayModelSpend.DeletePartialMatch(labels)
+}
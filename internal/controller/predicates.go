@@ -0,0 +1,62 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// gatewayChangedPredicate filters update events on the reconciled gateway CR.
+// Spec changes bump metadata.generation; annotations carry operator inputs
+// (config-patch, log-level) and labels may select the gateway. Status-only
+// updates, including the controller's own status patches, are dropped.
+// Create, delete and generic events always pass.
+func gatewayChangedPredicate() predicate.Predicate {
+	return predicate.Or(
+		predicate.GenerationChangedPredicate{},
+		predicate.AnnotationChangedPredicate{},
+		predicate.LabelChangedPredicate{},
+	)
+}
+
+// deploymentChangedPredicate filters update events on the owned Deployment.
+// Besides spec and metadata drift it lets status changes through, because
+// the Ready and Progressing conditions follow the rollout. Updates that only
+// touch resourceVersion or managedFields are dropped.
+func deploymentChangedPredicate() predicate.Predicate {
+	return predicate.Or(
+		predicate.GenerationChangedPredicate{},
+		predicate.AnnotationChangedPredicate{},
+		predicate.LabelChangedPredicate{},
+		predicate.Funcs{UpdateFunc: deploymentStatusChanged},
+	)
+}
+
+func deploymentStatusChanged(e event.UpdateEvent) bool {
+	oldDeploy, ok := e.ObjectOld.(*appsv1.Deployment)
+	if !ok {
+		return false
+	}
+	newDeploy, ok := e.ObjectNew.(*appsv1.Deployment)
+	if !ok {
+		return false
+	}
+	return !equality.Semantic.DeepEqual(oldDeploy.Status, newDeploy.Status)
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/agentic-layer/ai-gateway-litellm/internal/litellm"
+)
+
+// TestGatewayChangedPredicate_Lifecycle replays the update events a typical
+// AiGateway produces and counts how many of them reach the workqueue.
+func TestGatewayChangedPredicate_Lifecycle(t *testing.T) {
+	p := gatewayChangedPredicate()
+	gw := &gatewayv1alpha1.AiGateway{ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "default", Generation: 1}}
+
+	steps := []struct {
+		name    string
+		mutate  func(*gatewayv1alpha1.AiGateway)
+		enqueue bool
+	}{
+		{"Configured condition patched", func(g *gatewayv1alpha1.AiGateway) {
+			g.Status.Conditions = append(g.Status.Conditions, metav1.Condition{Type: AiGatewayConfigured})
+		}, false},
+		{"Ready condition patched", func(g *gatewayv1alpha1.AiGateway) {
+			g.Status.Conditions = append(g.Status.Conditions, metav1.Condition{Type: AiGatewayReady})
+		}, false},
+		{"Healthy condition patched", func(g *gatewayv1alpha1.AiGateway) {
+			g.Status.Conditions = append(g.Status.Conditions, metav1.Condition{Type: AiGatewayHealthy})
+		}, false},
+		{"model added to spec", func(g *gatewayv1alpha1.AiGateway) {
+			g.Spec.AiModels = append(g.Spec.AiModels, gatewayv1alpha1.AiModel{Name: "gpt-4o", Provider: "openai"})
+			g.Generation++
+		}, true},
+		{"log-level annotation set", func(g *gatewayv1alpha1.AiGateway) {
+			g.Annotations = map[string]string{litellm.LogLevelAnnotation: "DEBUG"}
+		}, true},
+		{"status re-patched after spec change", func(g *gatewayv1alpha1.AiGateway) {
+			g.Status.Conditions[0].ObservedGeneration = g.Generation
+		}, false},
+	}
+
+	enqueued := 0
+	for _, step := range steps {
+		next := gw.DeepCopy()
+		step.mutate(next)
+		got := p.Update(event.UpdateEvent{ObjectOld: gw, ObjectNew: next})
+		if got != step.enqueue {
+			t.Errorf("%s: enqueue = %v, want %v", step.name, got, step.enqueue)
+		}
+		if got {
+			enqueued++
+		}
+		gw = next
+	}
+	if enqueued != 2 {
+		t.Errorf("reconciles for lifecycle: got %d, want 2", enqueued)
+	}
+}
+
+func TestDeploymentChangedPredicate(t *testing.T) {
+	p := deploymentChangedPredicate()
+	base := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "gw", Generation: 1, ResourceVersion: "1"}}
+
+	cases := []struct {
+		name   string
+		mutate func(*appsv1.Deployment)
+		want   bool
+	}{
+		{"resourceVersion only", func(d *appsv1.Deployment) { d.ResourceVersion = "2" }, false},
+		{"managedFields only", func(d *appsv1.Deployment) {
+			d.ManagedFields = []metav1.ManagedFieldsEntry{{Manager: "kubectl"}}
+		}, false},
+		{"replicas became available", func(d *appsv1.Deployment) { d.Status.AvailableReplicas = 1 }, true},
+		{"spec drift", func(d *appsv1.Deployment) { d.Generation = 2 }, true},
+		{"label drift", func(d *appsv1.Deployment) { d.Labels = map[string]string{"app": "other"} }, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			next := base.DeepCopy()
+			tc.mutate(next)
+			if got := p.Update(event.UpdateEvent{ObjectOld: base, ObjectNew: next}); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
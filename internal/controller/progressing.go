@@ -0,0 +1,64 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+
+	"github.com/agentic-layer/ai-gateway-litellm/internal/litellm"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Progressing condition reasons, shared by both gateway kinds.
+const (
+	// ReasonRolloutInProgress indicates the Deployment is still rolling out the current config.
+	ReasonRolloutInProgress = "RolloutInProgress"
+
+	// ReasonRolloutComplete indicates the current config is live on all desired replicas.
+	ReasonRolloutComplete = "RolloutComplete"
+
+	// ReasonProgressDeadlineExceeded indicates the Deployment gave up on the rollout.
+	ReasonProgressDeadlineExceeded = "ProgressDeadlineExceeded"
+
+	// ReasonRolloutAborted indicates the analysis of an Argo Rollout failed
+	// and the canary was scaled down.
+	ReasonRolloutAborted = "RolloutAborted"
+)
+
+// progressingCondition maps the child Deployment's rollout state onto the
+// gateway's Progressing condition. It is True while a rollout is underway and
+// False once it has either completed or stalled; the reason tells the two
+// apart, so a pipeline can wait for Progressing=False and then check for
+// RolloutComplete. The message names the config hash being rolled out.
+func progressingCondition(d *appsv1.Deployment) (metav1.ConditionStatus, string, string) {
+	hash := litellm.DeployedConfigHash(d)
+	if aborted, msg := litellm.ArgoRolloutAborted(d); aborted {
+		return metav1.ConditionFalse, ReasonRolloutAborted,
+			fmt.Sprintf("Rollout of config %s aborted: %s", hash, msg)
+	}
+	if stalled, msg := litellm.DeploymentProgressStalled(d); stalled {
+		return metav1.ConditionFalse, ReasonProgressDeadlineExceeded,
+			fmt.Sprintf("Rollout of config %s stalled: %s", hash, msg)
+	}
+	if rolledOut, msg := litellm.IsDeploymentRolledOut(d); !rolledOut {
+		return metav1.ConditionTrue, ReasonRolloutInProgress,
+			fmt.Sprintf("Rolling out config %s: %s", hash, msg)
+	}
+	return metav1.ConditionFalse, ReasonRolloutComplete,
+		fmt.Sprintf("Config %s is live on all replicas", hash)
+}
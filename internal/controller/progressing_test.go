@@ -0,0 +1,86 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestProgressingCondition(t *testing.T) {
+	deployment := func(generation, observed int64, available int32, conds ...appsv1.DeploymentCondition) *appsv1.Deployment {
+		// All pods run the current template; only availability varies.
+		replicas := int32(2)
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Generation: generation},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+				Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{"gateway.agentic-layer.ai/config-hash": "abc123"},
+				}},
+			},
+			Status: appsv1.DeploymentStatus{
+				ObservedGeneration: observed,
+				Replicas:           2,
+				UpdatedReplicas:    2,
+				AvailableReplicas:  available,
+				Conditions:         conds,
+			},
+		}
+	}
+	stalled := appsv1.DeploymentCondition{
+		Type:    appsv1.DeploymentProgressing,
+		Status:  corev1.ConditionFalse,
+		Reason:  "ProgressDeadlineExceeded",
+		Message: `ReplicaSet "gw-1" has timed out progressing.`,
+	}
+	aborted := appsv1.DeploymentCondition{
+		Type:    appsv1.DeploymentProgressing,
+		Status:  corev1.ConditionFalse,
+		Reason:  "RolloutAborted",
+		Message: "Rollout aborted update to revision 2: Metric \"error-rate\" assessed Failed",
+	}
+
+	cases := []struct {
+		name       string
+		d          *appsv1.Deployment
+		wantStatus metav1.ConditionStatus
+		wantReason string
+	}{
+		{name: "generation not observed", d: deployment(2, 1, 2), wantStatus: metav1.ConditionTrue, wantReason: ReasonRolloutInProgress},
+		{name: "replicas unavailable", d: deployment(1, 1, 1), wantStatus: metav1.ConditionTrue, wantReason: ReasonRolloutInProgress},
+		{name: "complete", d: deployment(1, 1, 2), wantStatus: metav1.ConditionFalse, wantReason: ReasonRolloutComplete},
+		{name: "deadline exceeded", d: deployment(1, 1, 1, stalled), wantStatus: metav1.ConditionFalse, wantReason: ReasonProgressDeadlineExceeded},
+		{name: "argo rollout aborted", d: deployment(1, 1, 2, aborted), wantStatus: metav1.ConditionFalse, wantReason: ReasonRolloutAborted},
+		{name: "stale deadline ignored for new generation", d: deployment(2, 1, 1, stalled), wantStatus: metav1.ConditionTrue, wantReason: ReasonRolloutInProgress},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			status, reason, msg := progressingCondition(tc.d)
+			if status != tc.wantStatus || reason != tc.wantReason {
+				t.Errorf("got %s/%s, want %s/%s", status, reason, tc.wantStatus, tc.wantReason)
+			}
+			if !strings.Contains(msg, "abc123") {
+				t.Errorf("message should name the config hash: %q", msg)
+			}
+		})
+	}
+}
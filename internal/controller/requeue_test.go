@@ -0,0 +1,37 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMinRequeue(t *testing.T) {
+	cases := []struct{ a, b, want time.Duration }{
+		{0, 0, 0},
+		{0, time.Minute, time.Minute},
+		{time.Minute, 0, time.Minute},
+		{time.Minute, time.Second, time.Second},
+		{time.Second, time.Minute, time.Second},
+	}
+	for _, tc := range cases {
+		if got := minRequeue(tc.a, tc.b); got != tc.want {
+			t.Errorf("minRequeue(%v, %v) = %v, want %v", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,294 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"maps"
+
+	litellmv1alpha1 "github.com/agentic-layer/ai-gateway-litellm/api/v1alpha1"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/litellm"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// TenantLabel marks a namespace as belonging to a tenant. Every namespace
+// carrying it gets the virtual key of its tenant in TenantKeySecretName.
+const TenantLabel = "ai-gateway-litellm.agentic-layer.ai/tenant"
+
+// TenantKeySecretName is the Secret in each tenant namespace holding the
+// tenant's key, with the same data keys as a LiteLLMVirtualKey Secret.
+const TenantKeySecretName = "ai-gateway-key"
+
+// tenantKeyPrefix prefixes the tenant name to form the name of its
+// LiteLLMVirtualKey next to the central gateway.
+const tenantKeyPrefix = "tenant-"
+
+// TenantReconciler onboards tenants onto a central AiGateway. For every
+// tenant named by a TenantLabel it maintains a LiteLLMVirtualKey next to the
+// gateway, which provisions the key, and copies the key's Secret into each of
+// the tenant's namespaces. Keys of tenants without namespaces are deleted,
+// which revokes them.
+type TenantReconciler struct {
+	client.Client
+
+	// Gateway is the central AiGateway tenant keys are provisioned on.
+	Gateway types.NamespacedName
+}
+
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups=litellm.agentic-layer.ai,resources=litellmvirtualkeys,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile brings the key Secret of a namespace in line with its
+// TenantLabel and then deletes the keys of tenants left without namespaces.
+func (r *TenantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var ns corev1.Namespace
+	if err := r.Get(ctx, req.NamespacedName, &ns); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, r.deleteOrphanedKeys(ctx)
+	}
+	if !ns.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, r.deleteOrphanedKeys(ctx)
+	}
+
+	tenant := ns.Labels[TenantLabel]
+	var err error
+	if tenant == "" {
+		err = r.removeTenantSecret(ctx, ns.Name)
+	} else {
+		err = r.syncTenant(ctx, ns.Name, tenant)
+	}
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, r.deleteOrphanedKeys(ctx)
+}
+
+// syncTenant makes sure the tenant's key exists and, once it is provisioned,
+// copies its Secret into namespace.
+func (r *TenantReconciler) syncTenant(ctx context.Context, namespace, tenant string) error {
+	log := logf.FromContext(ctx)
+
+	name := tenantKeyName(tenant)
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		// Waits for the label to be fixed; retrying cannot help.
+		log.Error(fmt.Errorf("tenant %q does not form a valid LiteLLMVirtualKey name: %v", tenant, errs),
+			"Skipping tenant namespace")
+		return nil
+	}
+
+	vk := &litellmv1alpha1.LiteLLMVirtualKey{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: r.Gateway.Namespace},
+	}
+	// Only the labels and the gateway are set, so administrators can narrow
+	// the models and limits of a tenant's key on the LiteLLMVirtualKey.
+	result, err := controllerutil.CreateOrUpdate(ctx, r, vk, func() error {
+		if vk.Labels == nil {
+			vk.Labels = map[string]string{}
+		}
+		vk.Labels[TenantLabel] = tenant
+		vk.Labels[litellm.ManagedByLabel] = litellm.FieldManager
+		vk.Spec.AiGatewayRef.Name = r.Gateway.Name
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("applying LiteLLMVirtualKey %s: %w", name, err)
+	}
+	if result != controllerutil.OperationResultNone {
+		log.Info("Applied tenant LiteLLMVirtualKey", "tenant", tenant, "operation", result)
+	}
+
+	// The key's Secret does not exist until the key is provisioned; the
+	// LiteLLMVirtualKey and Secret watches bring us back then.
+	if vk.Status.SecretName == "" {
+		return nil
+	}
+	var source corev1.Secret
+	err = r.Get(ctx, types.NamespacedName{Namespace: vk.Namespace, Name: vk.Status.SecretName}, &source)
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	return r.applyTenantSecret(ctx, namespace, tenant, source.Data)
+}
+
+// applyTenantSecret server-side applies the tenant's copy of its key. A
+// Secret of the same name the operator did not create is left alone.
+func (r *TenantReconciler) applyTenantSecret(ctx context.Context, namespace, tenant string, data map[string][]byte) error {
+	var existing corev1.Secret
+	err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: TenantKeySecretName}, &existing)
+	switch {
+	case apierrors.IsNotFound(err):
+	case err != nil:
+		return err
+	case existing.Labels[TenantLabel] == "":
+		logf.FromContext(ctx).Info("Secret already exists and is not managed by the operator; not writing tenant key",
+			"secret", TenantKeySecretName)
+		return nil
+	case existing.Labels[TenantLabel] == tenant && maps.EqualFunc(existing.Data, data, bytes.Equal):
+		return nil
+	}
+
+	secret := corev1ac.Secret(TenantKeySecretName, namespace).
+		WithLabels(map[string]string{
+			TenantLabel:            tenant,
+			litellm.ManagedByLabel: litellm.FieldManager,
+		}).
+		WithType(corev1.SecretTypeOpaque).
+		WithData(data)
+	if err := r.Apply(ctx, secret, client.FieldOwner(litellm.FieldManager), client.ForceOwnership); err != nil {
+		return fmt.Errorf("applying Secret %s: %w", TenantKeySecretName, err)
+	}
+	logf.FromContext(ctx).Info("Synced tenant key Secret", "tenant", tenant, "secret", TenantKeySecretName)
+	return nil
+}
+
+// removeTenantSecret deletes the key copy of a namespace that lost its
+// TenantLabel.
+func (r *TenantReconciler) removeTenantSecret(ctx context.Context, namespace string) error {
+	var secret corev1.Secret
+	err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: TenantKeySecretName}, &secret)
+	if err != nil || secret.Labels[TenantLabel] == "" {
+		return client.IgnoreNotFound(err)
+	}
+	if err := r.Delete(ctx, &secret); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	logf.FromContext(ctx).Info("Deleted tenant key Secret", "secret", TenantKeySecretName)
+	return nil
+}
+
+// deleteOrphanedKeys deletes the LiteLLMVirtualKeys of tenants no live
+// namespace belongs to any more, revoking their keys on the gateway.
+func (r *TenantReconciler) deleteOrphanedKeys(ctx context.Context) error {
+	var nsList corev1.NamespaceList
+	if err := r.List(ctx, &nsList, client.HasLabels{TenantLabel}); err != nil {
+		return err
+	}
+	tenants := map[string]bool{}
+	for _, ns := range nsList.Items {
+		if ns.DeletionTimestamp.IsZero() {
+			tenants[ns.Labels[TenantLabel]] = true
+		}
+	}
+
+	var vkList litellmv1alpha1.LiteLLMVirtualKeyList
+	if err := r.List(ctx, &vkList,
+		client.InNamespace(r.Gateway.Namespace),
+		client.HasLabels{TenantLabel},
+		client.MatchingLabels{litellm.ManagedByLabel: litellm.FieldManager},
+	); err != nil {
+		return err
+	}
+	for i := range vkList.Items {
+		vk := &vkList.Items[i]
+		if tenants[vk.Labels[TenantLabel]] || !vk.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if err := r.Delete(ctx, vk); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("deleting LiteLLMVirtualKey %s: %w", vk.Name, err)
+		}
+		logf.FromContext(ctx).Info("Deleted LiteLLMVirtualKey of tenant without namespaces",
+			"tenant", vk.Labels[TenantLabel], "litellmvirtualkey", vk.Name)
+	}
+	return nil
+}
+
+// tenantKeyName is the name of the LiteLLMVirtualKey of tenant.
+func tenantKeyName(tenant string) string {
+	return tenantKeyPrefix + tenant
+}
+
+// hasTenantLabel matches namespaces that carry a TenantLabel, or carried one
+// before an update, so removing the label still cleans up.
+var hasTenantLabel = predicate.Funcs{
+	CreateFunc: func(e event.CreateEvent) bool { return e.Object.GetLabels()[TenantLabel] != "" },
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return e.ObjectOld.GetLabels()[TenantLabel] != "" || e.ObjectNew.GetLabels()[TenantLabel] != ""
+	},
+	DeleteFunc:  func(e event.DeleteEvent) bool { return e.Object.GetLabels()[TenantLabel] != "" },
+	GenericFunc: func(e event.GenericEvent) bool { return e.Object.GetLabels()[TenantLabel] != "" },
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *TenantReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// enqueueTenantNamespaces re-reconciles the namespaces of a tenant.
+	enqueueTenantNamespaces := func(ctx context.Context, tenant string) []reconcile.Request {
+		if tenant == "" {
+			return nil
+		}
+		var nsList corev1.NamespaceList
+		if err := r.List(ctx, &nsList, client.MatchingLabels{TenantLabel: tenant}); err != nil {
+			logf.FromContext(ctx).Error(err, "Failed to list tenant namespaces", "tenant", tenant)
+			return nil
+		}
+		requests := make([]reconcile.Request, len(nsList.Items))
+		for i, ns := range nsList.Items {
+			requests[i] = reconcile.Request{NamespacedName: types.NamespacedName{Name: ns.Name}}
+		}
+		return requests
+	}
+
+	// enqueueForVirtualKey follows a tenant key as it is provisioned.
+	enqueueForVirtualKey := handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		if obj.GetNamespace() != r.Gateway.Namespace {
+			return nil
+		}
+		return enqueueTenantNamespaces(ctx, obj.GetLabels()[TenantLabel])
+	})
+
+	// enqueueForSecret follows the Secret of a tenant key, which changes
+	// when the key is regenerated, and repairs edited or deleted copies.
+	enqueueForSecret := handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		if obj.GetName() == TenantKeySecretName && obj.GetLabels()[TenantLabel] != "" {
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: obj.GetNamespace()}}}
+		}
+		owner := metav1.GetControllerOf(obj)
+		if obj.GetNamespace() != r.Gateway.Namespace || owner == nil || owner.Kind != "LiteLLMVirtualKey" {
+			return nil
+		}
+		var vk litellmv1alpha1.LiteLLMVirtualKey
+		if err := r.Get(ctx, types.NamespacedName{Namespace: obj.GetNamespace(), Name: owner.Name}, &vk); err != nil {
+			return nil
+		}
+		return enqueueTenantNamespaces(ctx, vk.Labels[TenantLabel])
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.Namespace{}, builder.WithPredicates(hasTenantLabel)).
+		Watches(&litellmv1alpha1.LiteLLMVirtualKey{}, enqueueForVirtualKey).
+		Watches(&corev1.Secret{}, enqueueForSecret).
+		Named("tenant").
+		Complete(r)
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	litellmv1alpha1 "github.com/agentic-layer/ai-gateway-litellm/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func tenantFixtures(t *testing.T, objs ...client.Object) (client.Client, *TenantReconciler) {
+	t.Helper()
+	s := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{litellmv1alpha1.AddToScheme, corev1.AddToScheme} {
+		if err := add(s); err != nil {
+			t.Fatalf("AddToScheme: %v", err)
+		}
+	}
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{TenantLabel: "acme"}}}
+	c := fake.NewClientBuilder().
+		WithScheme(s).
+		WithObjects(append(objs, ns)...).
+		WithStatusSubresource(&litellmv1alpha1.LiteLLMVirtualKey{}).
+		Build()
+	r := &TenantReconciler{Client: c, Gateway: types.NamespacedName{Namespace: "ai-gateway", Name: "central"}}
+	return c, r
+}
+
+var tenantRequest = ctrl.Request{NamespacedName: types.NamespacedName{Name: "team-a"}}
+
+// provisionTenantKey stands in for the LiteLLMVirtualKey controller.
+func provisionTenantKey(t *testing.T, c client.Client, apiKey string) {
+	t.Helper()
+	ctx := context.Background()
+	var vk litellmv1alpha1.LiteLLMVirtualKey
+	if err := c.Get(ctx, types.NamespacedName{Namespace: "ai-gateway", Name: "tenant-acme"}, &vk); err != nil {
+		t.Fatalf("get LiteLLMVirtualKey: %v", err)
+	}
+	vk.Status.SecretName = "tenant-acme"
+	if err := c.Status().Update(ctx, &vk); err != nil {
+		t.Fatalf("update LiteLLMVirtualKey status: %v", err)
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "tenant-acme", Namespace: "ai-gateway"},
+		Data: map[string][]byte{
+			VirtualKeySecretAPIKey:  []byte(apiKey),
+			VirtualKeySecretBaseURL: []byte("http://central.ai-gateway.svc.cluster.local:4000"),
+		},
+	}
+	err := c.Create(ctx, secret)
+	if apierrors.IsAlreadyExists(err) {
+		err = c.Update(ctx, secret)
+	}
+	if err != nil {
+		t.Fatalf("write key Secret: %v", err)
+	}
+}
+
+func TestTenantReconciler_ProvisionsAndSyncsKey(t *testing.T) {
+	c, r := tenantFixtures(t)
+	ctx := context.Background()
+
+	if _, err := r.Reconcile(ctx, tenantRequest); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	var vk litellmv1alpha1.LiteLLMVirtualKey
+	if err := c.Get(ctx, types.NamespacedName{Namespace: "ai-gateway", Name: "tenant-acme"}, &vk); err != nil {
+		t.Fatalf("get LiteLLMVirtualKey: %v", err)
+	}
+	if vk.Spec.AiGatewayRef.Name != "central" || vk.Labels[TenantLabel] != "acme" {
+		t.Fatalf("unexpected LiteLLMVirtualKey: %+v", vk)
+	}
+	// No Secret until the key is provisioned.
+	var copied corev1.Secret
+	secretKey := types.NamespacedName{Namespace: "team-a", Name: TenantKeySecretName}
+	if err := c.Get(ctx, secretKey, &copied); !apierrors.IsNotFound(err) {
+		t.Fatalf("expected no tenant Secret before provisioning, got %v", err)
+	}
+
+	provisionTenantKey(t, c, "sk-acme")
+	if _, err := r.Reconcile(ctx, tenantRequest); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if err := c.Get(ctx, secretKey, &copied); err != nil {
+		t.Fatalf("get tenant Secret: %v", err)
+	}
+	if string(copied.Data[VirtualKeySecretAPIKey]) != "sk-acme" || copied.Labels[TenantLabel] != "acme" {
+		t.Fatalf("unexpected tenant Secret: %+v", copied)
+	}
+
+	// A regenerated key reaches the copy.
+	provisionTenantKey(t, c, "sk-rotated")
+	if _, err := r.Reconcile(ctx, tenantRequest); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if err := c.Get(ctx, secretKey, &copied); err != nil {
+		t.Fatalf("get tenant Secret: %v", err)
+	}
+	if string(copied.Data[VirtualKeySecretAPIKey]) != "sk-rotated" {
+		t.Fatalf("tenant Secret not updated: %s", copied.Data[VirtualKeySecretAPIKey])
+	}
+
+	// Removing the label removes the copy and, with no namespace left for
+	// the tenant, its key.
+	var ns corev1.Namespace
+	if err := c.Get(ctx, tenantRequest.NamespacedName, &ns); err != nil {
+		t.Fatalf("get Namespace: %v", err)
+	}
+	delete(ns.Labels, TenantLabel)
+	if err := c.Update(ctx, &ns); err != nil {
+		t.Fatalf("update Namespace: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, tenantRequest); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if err := c.Get(ctx, secretKey, &copied); !apierrors.IsNotFound(err) {
+		t.Fatalf("expected tenant Secret to be deleted, got %v", err)
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(&vk), &vk); !apierrors.IsNotFound(err) {
+		t.Fatalf("expected LiteLLMVirtualKey to be deleted, got %v", err)
+	}
+}
+
+func TestTenantReconciler_LeavesForeignSecretAlone(t *testing.T) {
+	foreign := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: TenantKeySecretName, Namespace: "team-a"},
+		Data:       map[string][]byte{"token": []byte("mine")},
+	}
+	c, r := tenantFixtures(t, foreign)
+	ctx := context.Background()
+
+	if _, err := r.Reconcile(ctx, tenantRequest); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	provisionTenantKey(t, c, "sk-acme")
+	if _, err := r.Reconcile(ctx, tenantRequest); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	var got corev1.Secret
+	if err := c.Get(ctx, client.ObjectKeyFromObject(foreign), &got); err != nil {
+		t.Fatalf("get Secret: %v", err)
+	}
+	if string(got.Data["token"]) != "mine" || got.Data[VirtualKeySecretAPIKey] != nil {
+		t.Fatalf("foreign Secret was overwritten: %+v", got.Data)
+	}
+}
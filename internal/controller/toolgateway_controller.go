@@ -14,6 +14,7 @@ import (
 	"context"
 	stderrors "errors"
 	"fmt"
+	"time"
 
 	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
 	"github.com/agentic-layer/ai-gateway-litellm/internal/litellm"
@@ -26,7 +27,9 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -40,8 +43,9 @@ const ToolGatewayControllerName = "litellm.agentic-layer.ai/tool-gateway-litellm
 
 // Status condition types
 const (
-	ToolGatewayConfigured = "ToolGatewayConfigured"
-	ToolGatewayReady      = "ToolGatewayReady"
+	ToolGatewayConfigured  = "ToolGatewayConfigured"
+	ToolGatewayReady       = "ToolGatewayReady"
+	ToolGatewayProgressing = "ToolGatewayProgressing"
 )
 
 // Status condition reasons
@@ -57,6 +61,9 @@ const (
 	ReasonToolGatewayService              = "ServiceFailed"
 	ReasonToolGatewayWorkload             = "WorkloadFailed"
 	ReasonToolGatewayConfigPatchInvalid   = "ConfigPatchInvalid"
+	ReasonToolGatewayLogLevelInvalid      = "LogLevelInvalid"
+	ReasonToolGatewayResourceConflict     = "ResourceConflict"
+	ReasonToolGatewayEgressProxyInvalid   = "EgressProxyInvalid"
 )
 
 // PhaseError phase names for reconcile steps that translate user input.
@@ -72,6 +79,45 @@ const (
 type ToolGatewayReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+
+	// MaxConcurrentReconciles is the number of ToolGateways reconciled in
+	// parallel. Zero uses the controller-runtime default of one.
+	MaxConcurrentReconciles int
+
+	// RateLimiter paces requeues of failed reconciles. Nil uses the
+	// controller-runtime default.
+	RateLimiter workqueue.TypedRateLimiter[reconcile.Request]
+
+	// ResyncInterval re-reconciles a successfully reconciled gateway this
+	// often even without a triggering event, correcting drift of its
+	// workload. Zero disables it. Overridable per gateway via
+	// litellm.ResyncIntervalAnnotation.
+	ResyncInterval time.Duration
+
+	// APIReader reads owned objects the label-filtered cache does not hold
+	// (see litellm.ManagedSelector). Nil skips the lookup.
+	APIReader client.Reader
+
+	// RegistryMirror is the registry every image of the gateways is pulled
+	// through; see litellm.MirrorImage. Empty pulls from the images' own
+	// registries.
+	RegistryMirror string
+
+	// EgressProxy is the default egress proxy of the gateways, overridable
+	// per gateway with the litellm.HTTPProxyAnnotation family.
+	EgressProxy litellm.EgressProxy
+
+	// Image is the LiteLLM image of the gateways. Empty uses litellm.Image.
+	Image string
+
+	// RequestTimeout is the request_timeout of the generated config. Zero
+	// uses litellm.DefaultRequestTimeout.
+	RequestTimeout time.Duration
+
+	// DryRun logs the change each owned object would receive instead of
+	// applying it. Client must then be a dry-run client so status writes are
+	// discarded too.
+	DryRun bool
 }
 
 // +kubebuilder:rbac:groups=runtime.agentic-layer.ai,resources=toolgateways,verbs=get;list;watch;create;update;patch;delete
@@ -122,9 +168,10 @@ func (r *ToolGatewayReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		// trusting a stale Status.Url after the gateway breaks.
 		r.markAttachedRoutesDegraded(ctx, &toolGateway, err)
 		// Transient API failures (ListRoutes / ConfigMap / Secret / Deployment /
-		// Service) get requeued by controller-runtime's exponential backoff.
-		// Permanent failures (ConfigRender / Guardrails) wait for a spec edit —
-		// the resulting watch event re-reconciles us.
+		// Service, or a flaky read of a referenced object) get requeued by
+		// controller-runtime's exponential backoff. Permanent failures
+		// (ConfigRender / Guardrails) wait for a spec edit — the resulting watch
+		// event re-reconciles us.
 		if isTransientPhaseError(err) {
 			return ctrl.Result{}, err
 		}
@@ -150,6 +197,9 @@ func (r *ToolGatewayReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		log.Error(err, "Failed to get Deployment for rollout check")
 		return ctrl.Result{}, err
 	}
+	status, reason, msg := progressingCondition(deployment)
+	r.updateCondition(&toolGateway, ToolGatewayProgressing, status, reason, msg)
+
 	if rolledOut, msg := litellm.IsDeploymentRolledOut(deployment); rolledOut {
 		r.updateCondition(&toolGateway, ToolGatewayReady, metav1.ConditionTrue,
 			ReasonToolGatewayReady, "ToolGateway is ready and serving traffic")
@@ -160,10 +210,15 @@ func (r *ToolGatewayReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		toolGateway.Status.Url = ""
 	}
 
+	resync, err := litellm.ParseResyncInterval(toolGateway.Annotations, r.ResyncInterval)
+	if err != nil {
+		log.Error(err, "Ignoring resync-interval annotation")
+	}
+
 	if err := r.patchStatus(ctx, original, &toolGateway); err != nil {
 		return ctrl.Result{}, err
 	}
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: resync}, nil
 }
 
 const (
@@ -178,6 +233,15 @@ const (
 // returns a *litellm.PhaseError so applyWorkloadError can map it to a stable
 // status reason.
 func (r *ToolGatewayReconciler) reconcile(ctx context.Context, gw *gatewayv1alpha1.ToolGateway) ([]routeOutcome, error) {
+	logLevel, err := litellm.ParseLogLevel(gw.Annotations)
+	if err != nil {
+		return nil, err
+	}
+	egressProxy, err := litellm.ParseEgressProxy(gw.Annotations, r.EgressProxy)
+	if err != nil {
+		return nil, err
+	}
+
 	var routeList gatewayv1alpha1.ToolRouteList
 	if err := r.List(ctx, &routeList); err != nil {
 		return nil, &litellm.PhaseError{Phase: "ListRoutes", Err: err}
@@ -193,7 +257,7 @@ func (r *ToolGatewayReconciler) reconcile(ctx context.Context, gw *gatewayv1alph
 	cfg := litellm.LiteLLMConfig{
 		McpServers: servers,
 		LiteLLMSettings: litellm.LiteLLMSettings{
-			RequestTimeout: litellm.DefaultRequestTimeout,
+			RequestTimeout: requestTimeoutSeconds(r.RequestTimeout),
 			Callbacks:      []string{"otel", "prometheus"},
 		},
 		Guardrails: guardrails,
@@ -215,11 +279,16 @@ func (r *ToolGatewayReconciler) reconcile(ctx context.Context, gw *gatewayv1alph
 		Owner:          gw,
 		ContainerPort:  toolGatewayContainerPort,
 		ServicePort:    toolGatewayServicePort,
-		Env:            gw.Spec.Env,
+		Env:            append(litellm.EgressProxyEnvVars(egressProxy), gw.Spec.Env...),
 		EnvFrom:        gw.Spec.EnvFrom,
 		CommonMetadata: gw.Spec.CommonMetadata,
 		PodMetadata:    gw.Spec.PodMetadata,
 		ConfigYAML:     configYAML,
+		LogLevel:       logLevel,
+		DryRun:         r.DryRun,
+		APIReader:      r.APIReader,
+		Image:          r.Image,
+		RegistryMirror: r.RegistryMirror,
 	}
 	if err := litellm.ReconcileWorkload(ctx, r.Client, r.Scheme, workload); err != nil {
 		return nil, err
@@ -244,6 +313,10 @@ func (r *ToolGatewayReconciler) applyWorkloadError(gw *gatewayv1alpha1.ToolGatew
 			reason = ReasonToolGatewayGuardrails
 		case phaseConfigPatch:
 			reason = ReasonToolGatewayConfigPatchInvalid
+		case litellm.LogLevelPhase:
+			reason = ReasonToolGatewayLogLevelInvalid
+		case litellm.EgressProxyPhase:
+			reason = ReasonToolGatewayEgressProxyInvalid
 		case "ConfigMap":
 			reason = ReasonToolGatewayConfigMap
 		case "Secret":
@@ -254,6 +327,9 @@ func (r *ToolGatewayReconciler) applyWorkloadError(gw *gatewayv1alpha1.ToolGatew
 			reason = ReasonToolGatewayService
 		}
 	}
+	if _, ok := stderrors.AsType[*litellm.ConflictError](err); ok {
+		reason = ReasonToolGatewayResourceConflict
+	}
 
 	r.updateCondition(gw, ToolGatewayConfigured, metav1.ConditionFalse, reason, err.Error())
 	r.updateCondition(gw, ToolGatewayReady, metav1.ConditionFalse, reason, err.Error())
@@ -285,7 +361,7 @@ func (r *ToolGatewayReconciler) patchStatus(ctx context.Context, original, gw *g
 
 // SetupWithManager sets up the controller with the Manager. It wires watches so
 // that the reconciler re-runs whenever a ToolRoute, ToolServer, Guard,
-// GuardrailProvider, or the api-keys Secret changes.
+// GuardrailProvider, or a Secret the gateway container reads changes.
 func (r *ToolGatewayReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	const toolGatewayConfigPatchIndex = "metadata.annotations.config-patch"
 
@@ -305,6 +381,22 @@ func (r *ToolGatewayReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return fmt.Errorf("failed to register ToolGateway config-patch indexer: %w", err)
 	}
 
+	// Indexer key used to locate ToolGateways by the Secrets their container
+	// reads (api-key secret, spec.env / spec.envFrom references).
+	const toolGatewaySecretIndex = "spec.secretRefs"
+
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &gatewayv1alpha1.ToolGateway{}, toolGatewaySecretIndex,
+		func(obj client.Object) []string {
+			gw, ok := obj.(*gatewayv1alpha1.ToolGateway)
+			if !ok {
+				return nil
+			}
+			return litellm.ReferencedSecretNames(gw.Spec.Env, gw.Spec.EnvFrom)
+		},
+	); err != nil {
+		return fmt.Errorf("failed to register ToolGateway secret indexer: %w", err)
+	}
+
 	enqueueViaToolRoute := routeEventHandler()
 
 	// enqueueViaToolServer fans out via mapRouteToGateways for every route that
@@ -347,7 +439,7 @@ func (r *ToolGatewayReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	})
 
 	// enqueueGatewaysInNamespace enqueues all our ToolGateways in the namespace
-	// of the triggering object. Used for Guard / GuardrailProvider / Secret
+	// of the triggering object. Used for Guard / GuardrailProvider
 	// changes — same scoping as the AiGateway controller's existing watches.
 	enqueueGatewaysInNamespace := handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
 		log := logf.FromContext(ctx)
@@ -404,9 +496,28 @@ func (r *ToolGatewayReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return requests
 	})
 
+	// enqueueToolGatewaysForSecret enqueues exactly the ToolGateways in the
+	// namespace whose container reads the changed Secret.
+	enqueueToolGatewaysForSecret := handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		log := logf.FromContext(ctx)
+		var gwList gatewayv1alpha1.ToolGatewayList
+		if err := r.List(ctx, &gwList,
+			client.InNamespace(obj.GetNamespace()),
+			client.MatchingFields{toolGatewaySecretIndex: obj.GetName()},
+		); err != nil {
+			log.Error(err, "Failed to list ToolGateways for Secret watch", "namespace", obj.GetNamespace(), "secret", obj.GetName())
+			return nil
+		}
+		requests := make([]reconcile.Request, 0, len(gwList.Items))
+		for _, gw := range gwList.Items {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: gw.Name, Namespace: gw.Namespace}})
+		}
+		return requests
+	})
+
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&gatewayv1alpha1.ToolGateway{}).
-		Owns(&appsv1.Deployment{}).
+		For(&gatewayv1alpha1.ToolGateway{}, builder.WithPredicates(gatewayChangedPredicate())).
+		Owns(&appsv1.Deployment{}, builder.WithPredicates(deploymentChangedPredicate())).
 		Owns(&corev1.Service{}).
 		Owns(&corev1.ConfigMap{}).
 		Watches(&gatewayv1alpha1.ToolRoute{}, enqueueViaToolRoute).
@@ -415,24 +526,12 @@ func (r *ToolGatewayReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Watches(&corev1.ConfigMap{}, enqueueToolGatewaysForPatchConfigMap).
 		Watches(&gatewayv1alpha1.Guard{}, enqueueGatewaysInNamespace).
 		Watches(&gatewayv1alpha1.GuardrailProvider{}, enqueueGatewaysInNamespace).
-		Watches(
-			&corev1.Secret{},
-			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
-				if obj.GetName() != litellm.ApiKeySecretName {
-					return nil
-				}
-				var gwList gatewayv1alpha1.ToolGatewayList
-				if err := r.List(ctx, &gwList, client.InNamespace(obj.GetNamespace())); err != nil {
-					return nil
-				}
-				requests := make([]reconcile.Request, 0, len(gwList.Items))
-				for _, gw := range gwList.Items {
-					requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: gw.Name, Namespace: gw.Namespace}})
-				}
-				return requests
-			}),
-		).
+		Watches(&corev1.Secret{}, enqueueToolGatewaysForSecret).
 		Named(ToolGatewayControllerName).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: r.MaxConcurrentReconciles,
+			RateLimiter:             r.RateLimiter,
+		}).
 		Complete(r)
 }
 
@@ -496,21 +595,36 @@ func enqueueRouteRequests(route *gatewayv1alpha1.ToolRoute, q workqueue.TypedRat
 // isTransientPhaseError reports whether a workload reconcile error should be
 // requeued by controller-runtime. Phases that hit the apiserver are transient —
 // exponential backoff is the right recovery. Phases that translate user input
-// (ConfigRender, Guardrails) are permanent: they will not heal until the user
-// edits the spec, which fires its own watch event.
+// (ConfigRender, Guardrails, ConfigPatch, LogLevel) are permanent: they will not heal until the user
+// edits the spec, which fires its own watch event. The exception is a user-input
+// phase that failed on a transient apiserver error while reading a referenced
+// object (Guard, patch ConfigMap); no watch event would ever retry that.
 func isTransientPhaseError(err error) bool {
 	pe, ok := stderrors.AsType[*litellm.PhaseError](err)
 	if !ok {
 		return true
 	}
 	switch pe.Phase {
-	case phaseConfigRender, phaseGuardrails, phaseConfigPatch:
-		return false
+	case phaseConfigRender, phaseGuardrails, phaseConfigPatch, litellm.LogLevelPhase, litellm.RolloutStrategyPhase, litellm.UpstreamPhase, litellm.ModelDiscoveryPhase, litellm.ManagedCachePhase, litellm.DatabasePhase, litellm.PassThroughPhase, litellm.AdminUIPhase, litellm.ConfigHistoryPhase, litellm.GatewayTemplatePhase, litellm.AlertingPhase, litellm.FlaggerPhase, litellm.HostnamePhase, litellm.OTelCollectorPhase, litellm.LangfusePhase, litellm.VeleroPhase, litellm.ImagePolicyPhase, litellm.InferencePoolPhase, litellm.EgressProxyPhase, litellm.PreCallChecksPhase, litellm.CORSPhase, litellm.ClassConfigPhase:
+		return isTransientAPIError(pe.Err)
 	default:
 		return true
 	}
 }
 
+// isTransientAPIError reports whether err wraps an apiserver or transport
+// failure that can heal without a user edit. NotFound, Forbidden and
+// validation errors are not transient.
+func isTransientAPIError(err error) bool {
+	return apierrors.IsTimeout(err) ||
+		apierrors.IsServerTimeout(err) ||
+		apierrors.IsTooManyRequests(err) ||
+		apierrors.IsInternalError(err) ||
+		apierrors.IsServiceUnavailable(err) ||
+		apierrors.IsUnexpectedServerError(err) ||
+		stderrors.Is(err, context.DeadlineExceeded)
+}
+
 // markAttachedRoutesDegraded marks every ToolRoute that would attach to gw as
 // Ready=False/GatewayDegraded so consumers do not keep trusting a Status.Url
 // rendered before the gateway broke. List failures are logged and ignored —
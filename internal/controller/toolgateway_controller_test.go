@@ -19,6 +19,7 @@ package controller
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -76,6 +77,7 @@ func markDeploymentRolledOut(name, namespace string) {
 		desired = *d.Spec.Replicas
 	}
 	d.Status.Replicas = desired
+	d.Status.UpdatedReplicas = desired
 	d.Status.ReadyReplicas = desired
 	d.Status.AvailableReplicas = desired
 	Expect(k8sClient.Status().Update(ctx, d)).To(Succeed())
@@ -463,6 +465,13 @@ func TestIsTransientPhaseError(t *testing.T) {
 		{"ConfigRender is permanent", &litellm.PhaseError{Phase: "ConfigRender", Err: errors.New("yaml")}, false},
 		{"Guardrails is permanent", &litellm.PhaseError{Phase: "Guardrails", Err: errors.New("missing")}, false},
 		{"ConfigPatch is permanent", &litellm.PhaseError{Phase: "ConfigPatch", Err: errors.New("missing-cm")}, false},
+		{"LogLevel is permanent", &litellm.PhaseError{Phase: "LogLevel", Err: errors.New("TRACE")}, false},
+		{"ConfigPatch NotFound is permanent", &litellm.PhaseError{Phase: "ConfigPatch",
+			Err: fmt.Errorf("configmap %q: %w", "p", apierrors.NewNotFound(corev1.Resource("configmaps"), "p"))}, false},
+		{"ConfigPatch read timeout is transient", &litellm.PhaseError{Phase: "ConfigPatch",
+			Err: fmt.Errorf("configmap %q: %w", "p", apierrors.NewTimeoutError("get", 1))}, true},
+		{"Guardrails throttled is transient", &litellm.PhaseError{Phase: "Guardrails",
+			Err: apierrors.NewTooManyRequests("slow down", 1)}, true},
 		{"ListRoutes is transient", &litellm.PhaseError{Phase: "ListRoutes", Err: errors.New("api")}, true},
 		{"ConfigMap is transient", &litellm.PhaseError{Phase: "ConfigMap", Err: errors.New("api")}, true},
 		{"Secret is transient", &litellm.PhaseError{Phase: "Secret", Err: errors.New("api")}, true},
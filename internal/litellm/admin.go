@@ -0,0 +1,225 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// MasterKeyEnvVar is the env var LiteLLM reads its proxy master key from.
+// The operator looks it up on the gateway container to authenticate calls
+// against the proxy's management API.
+const MasterKeyEnvVar = "LITELLM_MASTER_KEY"
+
+// defaultAdminTimeout bounds a single management API call. /health fans out
+// to every configured provider, so it is deliberately generous.
+const defaultAdminTimeout = 60 * time.Second
+
+// ServiceURL returns the in-cluster base URL of a gateway Service.
+func ServiceURL(name, namespace string, port int32) string {
+	return fmt.Sprintf("http://%s.%s.svc.cluster.local:%d", name, namespace, port)
+}
+
+// AdminClient calls the management API of a running LiteLLM proxy.
+// MasterKey may be empty for proxies started without a master key.
+type AdminClient struct {
+	BaseURL    string
+	MasterKey  string
+	HTTPClient *http.Client
+}
+
+// HealthEndpoint is one entry of the healthy_endpoints / unhealthy_endpoints
+// lists returned by LiteLLM's /health endpoint.
+type HealthEndpoint struct {
+	Model string `json:"model"`
+	Error string `json:"error,omitempty"`
+}
+
+// HealthReport is the decoded response of LiteLLM's /health endpoint.
+type HealthReport struct {
+	HealthyEndpoints   []HealthEndpoint `json:"healthy_endpoints"`
+	UnhealthyEndpoints []HealthEndpoint `json:"unhealthy_endpoints"`
+	HealthyCount       int              `json:"healthy_count"`
+	UnhealthyCount     int              `json:"unhealthy_count"`
+}
+
+// Health calls GET /health, which makes a minimal request against every
+// deployment in model_list and reports which ones failed.
+func (a *AdminClient) Health(ctx context.Context) (*HealthReport, error) {
+	var report HealthReport
+	if err := a.do(ctx, http.MethodGet, "/health", nil, &report); err != nil {
+		return nil, err
+	}
+	// Older LiteLLM releases omit the counters; derive them from the lists.
+	if report.HealthyCount == 0 {
+		report.HealthyCount = len(report.HealthyEndpoints)
+	}
+	if report.UnhealthyCount == 0 {
+		report.UnhealthyCount = len(report.UnhealthyEndpoints)
+	}
+	return &report, nil
+}
+
+// StatusError is a non-2xx response of the management API. Body is
+// truncated to keep condition messages short.
+type StatusError struct {
+	Method, Path string
+	StatusCode   int
+	Body         string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s %s: unexpected status %d: %s", e.Method, e.Path, e.StatusCode, e.Body)
+}
+
+// IsNotFound reports whether err is a 404 response of the management API.
+func IsNotFound(err error) bool {
+	se, ok := errors.AsType[*StatusError](err)
+	return ok && se.StatusCode == http.StatusNotFound
+}
+
+// do issues a JSON request against the proxy and decodes the response into
+// out (when non-nil). Non-2xx responses are returned as errors carrying a
+// truncated body so the caller can surface them in a condition message.
+func (a *AdminClient) do(ctx context.Context, method, path string, body io.Reader, out any) error {
+	ctx, cancel := context.WithTimeout(ctx, defaultAdminTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(a.BaseURL, "/")+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if a.MasterKey != "" {
+		req.Header.Set("Authorization", "Bearer "+a.MasterKey)
+	}
+
+	httpClient := a.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("%s %s: reading response: %w", method, path, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg := strings.TrimSpace(string(raw))
+		if len(msg) > 256 {
+			msg = msg[:256] + "..."
+		}
+		return &StatusError{Method: method, Path: path, StatusCode: resp.StatusCode, Body: msg}
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(raw, out); err != nil {
+		return fmt.Errorf("%s %s: decoding response: %w", method, path, err)
+	}
+	return nil
+}
+
+// ResolveMasterKey returns the proxy master key configured on the gateway
+// container via LITELLM_MASTER_KEY in env. A literal value wins; otherwise
+// the referenced Secret key is read from namespace. Returns "" when the
+// gateway has no master key, which LiteLLM treats as an open proxy.
+func ResolveMasterKey(ctx context.Context, c client.Reader, namespace string, env []corev1.EnvVar) (string, error) {
+	for _, e := range env {
+		if e.Name != MasterKeyEnvVar {
+			continue
+		}
+		if e.Value != "" {
+			return e.Value, nil
+		}
+		if e.ValueFrom == nil || e.ValueFrom.SecretKeyRef == nil {
+			return "", nil
+		}
+		ref := e.ValueFrom.SecretKeyRef
+		secret := &corev1.Secret{}
+		if err := c.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: namespace}, secret); err != nil {
+			return "", fmt.Errorf("failed to get master key secret %s: %w", ref.Name, err)
+		}
+		return string(secret.Data[ref.Key]), nil
+	}
+	return "", nil
+}
+
+// GlobalSpend is the decoded response of LiteLLM's /global/spend endpoint.
+type GlobalSpend struct {
+	Spend     float64 `json:"spend"`
+	MaxBudget float64 `json:"max_budget"`
+}
+
+// ModelSpend is one entry of LiteLLM's /global/spend/models response.
+type ModelSpend struct {
+	Model      string  `json:"model"`
+	TotalSpend float64 `json:"total_spend"`
+}
+
+// GlobalSpend calls GET /global/spend. Spend tracking requires a
+// database-backed proxy; without DATABASE_URL LiteLLM answers with an error.
+func (a *AdminClient) GlobalSpend(ctx context.Context) (*GlobalSpend, error) {
+	var spend GlobalSpend
+	if err := a.do(ctx, http.MethodGet, "/global/spend", nil, &spend); err != nil {
+		return nil, err
+	}
+	return &spend, nil
+}
+
+// GlobalSpendByModel calls GET /global/spend/models and returns the spend of
+// the top limit models, most expensive first.
+func (a *AdminClient) GlobalSpendByModel(ctx context.Context, limit int) ([]ModelSpend, error) {
+	var spend []ModelSpend
+	if err := a.do(ctx, http.MethodGet, fmt.Sprintf("/global/spend/models?limit=%d", limit), nil, &spend); err != nil {
+		return nil, err
+	}
+	return spend, nil
+}
+
+// DatabaseURLEnvVar is the env var that switches LiteLLM into database mode.
+// Spend tracking, virtual keys and budgets are only available in that mode.
+const DatabaseURLEnvVar = "DATABASE_URL"
+
+// DatabaseModeEnabled reports whether env configures a proxy database, either
+// as a literal value or through a reference.
+func DatabaseModeEnabled(env []corev1.EnvVar) bool {
+	for _, e := range env {
+		if e.Name == DatabaseURLEnvVar {
+			return e.Value != "" || e.ValueFrom != nil
+		}
+	}
+	return false
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestAdminClient_Health_SendsMasterKeyAndDecodes(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/health" {
+			t.Errorf("path: got %q", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer sk-master" {
+			t.Errorf("Authorization: got %q", got)
+		}
+		_, _ = w.Write([]byte(`{
+			"healthy_endpoints": [{"model": "openai/gpt-4o"}],
+			"unhealthy_endpoints": [{"model": "anthropic/claude-3-opus", "error": "AuthenticationError"}]
+		}`))
+	}))
+	defer srv.Close()
+
+	a := &AdminClient{BaseURL: srv.URL, MasterKey: "sk-master"}
+	report, err := a.Health(context.Background())
+	if err != nil {
+		t.Fatalf("Health: %v", err)
+	}
+	if report.HealthyCount != 1 || report.UnhealthyCount != 1 {
+		t.Errorf("counts: got %d/%d, want 1/1", report.HealthyCount, report.UnhealthyCount)
+	}
+	if report.UnhealthyEndpoints[0].Error != "AuthenticationError" {
+		t.Errorf("error not decoded: %+v", report.UnhealthyEndpoints[0])
+	}
+}
+
+func TestAdminClient_NonSuccessStatusIsError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "invalid master key", http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	a := &AdminClient{BaseURL: srv.URL}
+	_, err := a.Health(context.Background())
+	if err == nil {
+		t.Fatal("want error on 401")
+	}
+	if !strings.Contains(err.Error(), "401") || !strings.Contains(err.Error(), "invalid master key") {
+		t.Errorf("error should carry status and body: %v", err)
+	}
+}
+
+func TestResolveMasterKey(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "litellm-master", Namespace: "ns"},
+		Data:       map[string][]byte{"key": []byte("sk-from-secret")},
+	}
+	c := fake.NewClientBuilder().WithObjects(secret).Build()
+
+	cases := []struct {
+		name string
+		env  []corev1.EnvVar
+		want string
+	}{
+		{name: "absent", env: nil, want: ""},
+		{name: "literal", env: []corev1.EnvVar{{Name: MasterKeyEnvVar, Value: "sk-literal"}}, want: "sk-literal"},
+		{name: "secretKeyRef", env: []corev1.EnvVar{{
+			Name: MasterKeyEnvVar,
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "litellm-master"},
+				Key:                  "key",
+			}},
+		}}, want: "sk-from-secret"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ResolveMasterKey(context.Background(), c, "ns", tc.env)
+			if err != nil {
+				t.Fatalf("ResolveMasterKey: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAdminClient_GlobalSpendByModel(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/global/spend":
+			_, _ = w.Write([]byte(`{"spend": 12.5, "max_budget": 100}`))
+		case "/global/spend/models":
+			if r.URL.Query().Get("limit") != "5" {
+				t.Errorf("limit: got %q", r.URL.Query().Get("limit"))
+			}
+			_, _ = w.Write([]byte(`[{"model": "gpt-4o", "total_spend": 10}, {"model": "claude-3-opus", "total_spend": 2.5}]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	a := &AdminClient{BaseURL: srv.URL}
+	total, err := a.GlobalSpend(context.Background())
+	if err != nil {
+		t.Fatalf("GlobalSpend: %v", err)
+	}
+	if total.Spend != 12.5 || total.MaxBudget != 100 {
+		t.Errorf("GlobalSpend: got %+v", total)
+	}
+	models, err := a.GlobalSpendByModel(context.Background(), 5)
+	if err != nil {
+		t.Fatalf("GlobalSpendByModel: %v", err)
+	}
+	if len(models) != 2 || models[0].Model != "gpt-4o" || models[1].TotalSpend != 2.5 {
+		t.Errorf("GlobalSpendByModel: got %+v", models)
+	}
+}
+
+func TestDatabaseModeEnabled(t *testing.T) {
+	cases := []struct {
+		name string
+		env  []corev1.EnvVar
+		want bool
+	}{
+		{name: "absent", env: []corev1.EnvVar{{Name: "OTHER", Value: "x"}}, want: false},
+		{name: "empty literal", env: []corev1.EnvVar{{Name: DatabaseURLEnvVar}}, want: false},
+		{name: "literal", env: []corev1.EnvVar{{Name: DatabaseURLEnvVar, Value: "postgresql://db"}}, want: true},
+		{name: "secretKeyRef", env: []corev1.EnvVar{{
+			Name: DatabaseURLEnvVar,
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "db"},
+				Key:                  "url",
+			}},
+		}}, want: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := DatabaseModeEnabled(tc.env); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
+	metav1ac "k8s.io/client-go/applyconfigurations/meta/v1"
+	networkingv1ac "k8s.io/client-go/applyconfigurations/networking/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// AdminUIAnnotation, set to "true", exposes the LiteLLM admin UI of the
+// gateway through a Service of its own and lets models added in the UI be
+// stored in the database.
+const AdminUIAnnotation = "ai-gateway-litellm.agentic-layer.ai/admin-ui"
+
+// AdminUICredentialsSecretAnnotation names a Secret in the gateway's
+// namespace holding the UI login under AdminUIUsernameKey and
+// AdminUIPasswordKey. Without it, LiteLLM accepts "admin" with the master
+// key.
+const AdminUICredentialsSecretAnnotation = "ai-gateway-litellm.agentic-layer.ai/admin-ui-credentials-secret"
+
+// AdminUIHostAnnotation sets the host of an Ingress routing to the admin UI
+// Service. Without it, no Ingress is created.
+const AdminUIHostAnnotation = "ai-gateway-litellm.agentic-layer.ai/admin-ui-host"
+
+// AdminUIIngressClassAnnotation sets the ingressClassName of the admin UI
+// Ingress. Defaults to the cluster's default IngressClass.
+const AdminUIIngressClassAnnotation = "ai-gateway-litellm.agentic-layer.ai/admin-ui-ingress-class"
+
+// Keys of the admin UI credentials Secret, and the env vars they reach the
+// LiteLLM container as.
+const (
+	AdminUIUsernameKey = "UI_USERNAME"
+	AdminUIPasswordKey = "UI_PASSWORD"
+)
+
+// AdminUIPhase tags admin UI failures.
+const AdminUIPhase = "AdminUI"
+
+// AdminUI is the admin UI requested through the admin-ui annotations.
+type AdminUI struct {
+	// CredentialsSecret holds the UI login; empty keeps LiteLLM's default.
+	CredentialsSecret string
+	// Host and IngressClass configure the Ingress; no Ingress without Host.
+	Host         string
+	IngressClass string
+}
+
+// ParseAdminUI returns the admin UI gw asks for, or nil when it asks for
+// none. Invalid annotations yield a *PhaseError. Whether the gateway has the
+// database the UI needs is checked by ResolveAdminUI.
+func ParseAdminUI(gw *gatewayv1alpha1.AiGateway) (*AdminUI, error) {
+	phaseErr := func(format string, args ...any) error {
+		return &PhaseError{Phase: AdminUIPhase, Err: fmt.Errorf(format, args...)}
+	}
+	v, ok := gw.Annotations[AdminUIAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	enabled, err := strconv.ParseBool(v)
+	if err != nil {
+		return nil, phaseErr("invalid %s annotation %q: must be \"true\" or \"false\"", AdminUIAnnotation, v)
+	}
+	if !enabled {
+		return nil, nil
+	}
+	ui := &AdminUI{
+		CredentialsSecret: gw.Annotations[AdminUICredentialsSecretAnnotation],
+		Host:              gw.Annotations[AdminUIHostAnnotation],
+		IngressClass:      gw.Annotations[AdminUIIngressClassAnnotation],
+	}
+	if ui.Host != "" {
+		if errs := validation.IsDNS1123Subdomain(ui.Host); len(errs) > 0 {
+			return nil, phaseErr("invalid %s annotation %q: %v", AdminUIHostAnnotation, ui.Host, errs)
+		}
+	}
+	return ui, nil
+}
+
+// ResolveAdminUI is ParseAdminUI for a gateway that must be database-backed,
+// since the UI stores its state in the database. A DATABASE_URL loaded
+// through spec.envFrom is resolved through c.
+func ResolveAdminUI(ctx context.Context, c client.Reader, gw *gatewayv1alpha1.AiGateway) (*AdminUI, error) {
+	ui, err := ParseAdminUI(gw)
+	if err != nil || ui == nil {
+		return ui, err
+	}
+	env, err := ResolveGatewayEnv(ctx, c, gw)
+	if err != nil {
+		return nil, err
+	}
+	if !DatabaseModeEnabled(env) {
+		return nil, &PhaseError{Phase: AdminUIPhase, Err: fmt.Errorf(
+			"%s needs a database-backed gateway: set %s in spec.env or spec.envFrom", AdminUIAnnotation, DatabaseURLEnvVar)}
+	}
+	return ui, nil
+}
+
+// AdminUIName is the name of the admin UI Service and Ingress of the
+// gateway name.
+func AdminUIName(name string) string {
+	return name + "-ui"
+}
+
+// AdminUIEnvVars returns the env vars loading the UI login of ui into the
+// LiteLLM container.
+func AdminUIEnvVars(ui *AdminUI) []corev1.EnvVar {
+	if ui == nil || ui.CredentialsSecret == "" {
+		return nil
+	}
+	envs := make([]corev1.EnvVar, 0, 2)
+	for _, key := range []string{AdminUIUsernameKey, AdminUIPasswordKey} {
+		envs = append(envs, corev1.EnvVar{
+			Name: key,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: ui.CredentialsSecret},
+					Key:                  key,
+				},
+			},
+		})
+	}
+	return envs
+}
+
+// ReconcileAdminUI applies the admin UI Service of w, and its Ingress when
+// ui sets a host. Objects no longer requested are deleted.
+//
+// On failure, the returned error is a *PhaseError tagged AdminUIPhase.
+func ReconcileAdminUI(ctx context.Context, c client.Client, scheme *runtime.Scheme, w GatewayWorkload, ui *AdminUI) error {
+	name := AdminUIName(w.Name)
+	phaseErr := func(err error) error { return &PhaseError{Phase: AdminUIPhase, Err: err} }
+
+	if ui == nil || ui.Host == "" {
+		if err := deleteOwned(ctx, c, w, &networkingv1.Ingress{}, name); err != nil {
+			return phaseErr(err)
+		}
+	}
+	if ui == nil {
+		if err := deleteOwned(ctx, c, w, &corev1.Service{}, name); err != nil {
+			return phaseErr(err)
+		}
+		return nil
+	}
+
+	ownerRef, err := controllerReference(w.Owner, scheme)
+	if err != nil {
+		return phaseErr(err)
+	}
+	service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: w.Namespace}}
+	if err := apply(ctx, c, w, BuildAdminUIService(w, ownerRef), service, "Service"); err != nil {
+		return phaseErr(err)
+	}
+	if ui.Host != "" {
+		ingress := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: w.Namespace}}
+		if err := apply(ctx, c, w, BuildAdminUIIngress(w, ownerRef, ui), ingress, "Ingress"); err != nil {
+			return phaseErr(err)
+		}
+	}
+	return nil
+}
+
+// BuildAdminUIService returns the desired state of the admin UI Service.
+// It selects the LiteLLM pods like the gateway Service, but can be exposed
+// on its own, apart from the model traffic. During a blue/green rollout it
+// reaches both colors; they share the database the UI works on.
+func BuildAdminUIService(w GatewayWorkload, ownerRef *metav1ac.OwnerReferenceApplyConfiguration) *corev1ac.ServiceApplyConfiguration {
+	return corev1ac.Service(AdminUIName(w.Name), w.Namespace).
+		WithOwnerReferences(ownerRef).
+		WithLabels(BuildResourceLabels(w.Name, w.CommonMetadata)).
+		WithAnnotations(BuildResourceAnnotations(w.CommonMetadata)).
+		WithSpec(corev1ac.ServiceSpec().
+			WithType(corev1.ServiceTypeClusterIP).
+			WithSelector(map[string]string{"app": w.Name}).
+			WithPorts(corev1ac.ServicePort().
+				WithName("http").
+				WithPort(w.ServicePort).
+				WithTargetPort(intstr.FromInt32(w.ContainerPort)).
+				WithProtocol(corev1.ProtocolTCP)))
+}
+
+// BuildAdminUIIngress returns the desired state of the admin UI Ingress.
+// The UI calls the proxy API on the same host, so every path is routed.
+func BuildAdminUIIngress(w GatewayWorkload, ownerRef *metav1ac.OwnerReferenceApplyConfiguration, ui *AdminUI) *networkingv1ac.IngressApplyConfiguration {
+	name := AdminUIName(w.Name)
+	spec := networkingv1ac.IngressSpec().
+		WithRules(networkingv1ac.IngressRule().
+			WithHost(ui.Host).
+			WithHTTP(networkingv1ac.HTTPIngressRuleValue().
+				WithPaths(networkingv1ac.HTTPIngressPath().
+					WithPath("/").
+					WithPathType(networkingv1.PathTypePrefix).
+					WithBackend(networkingv1ac.IngressBackend().
+						WithService(networkingv1ac.IngressServiceBackend().
+							WithName(name).
+							WithPort(networkingv1ac.ServiceBackendPort().WithName("http")))))))
+	if ui.IngressClass != "" {
+		spec = spec.WithIngressClassName(ui.IngressClass)
+	}
+	return networkingv1ac.Ingress(name, w.Namespace).
+		WithOwnerReferences(ownerRef).
+		WithLabels(BuildResourceLabels(w.Name, w.CommonMetadata)).
+		WithAnnotations(BuildResourceAnnotations(w.CommonMetadata)).
+		WithSpec(spec)
+}
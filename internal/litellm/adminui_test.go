@@ -0,0 +1,135 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestResolveAdminUI(t *testing.T) {
+	dbEnv := []corev1.EnvVar{{Name: DatabaseURLEnvVar, Value: "postgres://db"}}
+	for name, tc := range map[string]struct {
+		annotations map[string]string
+		env         []corev1.EnvVar
+		want        *AdminUI
+		wantErr     bool
+	}{
+		"absent":   {},
+		"disabled": {annotations: map[string]string{AdminUIAnnotation: "false"}},
+		"enabled": {
+			annotations: map[string]string{
+				AdminUIAnnotation:                  "true",
+				AdminUICredentialsSecretAnnotation: "ui-login",
+				AdminUIHostAnnotation:              "llm-admin.example.com",
+			},
+			env:  dbEnv,
+			want: &AdminUI{CredentialsSecret: "ui-login", Host: "llm-admin.example.com"},
+		},
+		"invalid value": {annotations: map[string]string{AdminUIAnnotation: "yes please"}, env: dbEnv, wantErr: true},
+		"no database":   {annotations: map[string]string{AdminUIAnnotation: "true"}, wantErr: true},
+		"invalid host": {
+			annotations: map[string]string{AdminUIAnnotation: "true", AdminUIHostAnnotation: "https://admin"},
+			env:         dbEnv,
+			wantErr:     true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gw := newOwner("gw", "default")
+			gw.Annotations = tc.annotations
+			gw.Spec.Env = tc.env
+			got, err := ResolveAdminUI(context.Background(), fake.NewClientBuilder().WithScheme(workloadScheme(t)).Build(), gw)
+			if tc.wantErr {
+				var pe *PhaseError
+				if !errors.As(err, &pe) || pe.Phase != AdminUIPhase {
+					t.Fatalf("want a %s PhaseError, got %v", AdminUIPhase, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveAdminUI: %v", err)
+			}
+			if (got == nil) != (tc.want == nil) || (got != nil && *got != *tc.want) {
+				t.Fatalf("ResolveAdminUI = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestReconcileAdminUI(t *testing.T) {
+	s := workloadScheme(t)
+	owner := newOwner("gw", "default")
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(owner).Build()
+	ctx := context.Background()
+	w := GatewayWorkload{Name: "gw", Namespace: "default", Owner: owner, ServicePort: 4000, ContainerPort: 4000}
+	key := types.NamespacedName{Name: "gw-ui", Namespace: "default"}
+
+	ui := &AdminUI{Host: "llm-admin.example.com", IngressClass: "nginx"}
+	if err := ReconcileAdminUI(ctx, c, s, w, ui); err != nil {
+		t.Fatalf("ReconcileAdminUI: %v", err)
+	}
+	var service corev1.Service
+	if err := c.Get(ctx, key, &service); err != nil {
+		t.Fatalf("get Service: %v", err)
+	}
+	if service.Spec.Selector["app"] != "gw" || service.Spec.Ports[0].Port != 4000 {
+		t.Errorf("unexpected Service spec: %+v", service.Spec)
+	}
+	var ingress networkingv1.Ingress
+	if err := c.Get(ctx, key, &ingress); err != nil {
+		t.Fatalf("get Ingress: %v", err)
+	}
+	if ingress.Spec.Rules[0].Host != ui.Host || *ingress.Spec.IngressClassName != "nginx" ||
+		ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Name != "gw-ui" {
+		t.Errorf("unexpected Ingress spec: %+v", ingress.Spec)
+	}
+
+	// Dropping the host removes the Ingress only.
+	if err := ReconcileAdminUI(ctx, c, s, w, &AdminUI{}); err != nil {
+		t.Fatalf("ReconcileAdminUI: %v", err)
+	}
+	if err := c.Get(ctx, key, &networkingv1.Ingress{}); !apierrors.IsNotFound(err) {
+		t.Errorf("Ingress: want NotFound, got %v", err)
+	}
+	if err := c.Get(ctx, key, &corev1.Service{}); err != nil {
+		t.Errorf("get Service: %v", err)
+	}
+
+	if err := ReconcileAdminUI(ctx, c, s, w, nil); err != nil {
+		t.Fatalf("ReconcileAdminUI: %v", err)
+	}
+	if err := c.Get(ctx, key, &corev1.Service{}); !apierrors.IsNotFound(err) {
+		t.Errorf("Service: want NotFound, got %v", err)
+	}
+}
+
+func TestAdminUIEnvVars(t *testing.T) {
+	if env := AdminUIEnvVars(&AdminUI{}); env != nil {
+		t.Errorf("want no env without a credentials Secret, got %v", env)
+	}
+	env := AdminUIEnvVars(&AdminUI{CredentialsSecret: "ui-login"})
+	if len(env) != 2 || env[0].Name != AdminUIUsernameKey || env[1].ValueFrom.SecretKeyRef.Name != "ui-login" {
+		t.Errorf("unexpected env: %+v", env)
+	}
+}
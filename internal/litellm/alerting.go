@@ -0,0 +1,356 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// AlertingAnnotation, set to "true", points LiteLLM's webhook alerting at
+// the operator's alert receiver, which turns budget and outage alerts into
+// Events and a condition on the gateway.
+const AlertingAnnotation = "ai-gateway-litellm.agentic-layer.ai/alerting"
+
+// Annotations sending a gateway's alerts to destinations of its own, and
+// selecting which alerts are sent.
+const (
+	// AlertingSlackSecretAnnotation names the Secret in the gateway's
+	// namespace holding a Slack incoming webhook URL under SlackWebhookURLKey.
+	AlertingSlackSecretAnnotation = "ai-gateway-litellm.agentic-layer.ai/alerting-slack-secret"
+	// AlertingWebhookSecretAnnotation names the Secret in the gateway's
+	// namespace holding a webhook URL under WebhookURLKey. It replaces the
+	// operator's receiver, so it cannot be combined with AlertingAnnotation.
+	AlertingWebhookSecretAnnotation = "ai-gateway-litellm.agentic-layer.ai/alerting-webhook-secret"
+	// AlertTypesAnnotation lists, comma-separated, the LiteLLM alert types
+	// sent. Defaults to AlertTypes.
+	AlertTypesAnnotation = "ai-gateway-litellm.agentic-layer.ai/alert-types"
+	// AlertingThresholdAnnotation is how long a request may take, as a
+	// duration, before LiteLLM alerts on it as slow or hanging.
+	AlertingThresholdAnnotation = "ai-gateway-litellm.agentic-layer.ai/alerting-threshold"
+)
+
+// AlertingPhase tags alerting failures.
+const AlertingPhase = "Alerting"
+
+// WebhookURLKey is the key of the alerting Secret holding the receiver URL,
+// and the env var LiteLLM reads its alert webhook from.
+const WebhookURLKey = "WEBHOOK_URL"
+
+// SlackWebhookURLKey is the key of the Slack alerting Secret, and the env var
+// LiteLLM reads its Slack webhook from.
+const SlackWebhookURLKey = "SLACK_WEBHOOK_URL"
+
+// alertTokenKey is the key of the alerting Secret holding the token that
+// authenticates the gateway's alerts.
+const alertTokenKey = "token"
+
+// AlertPathPrefix is the path the alert receiver serves. Each gateway posts
+// to <prefix><namespace>/<name>/<token>.
+const AlertPathPrefix = "/alerts/"
+
+// AlertTypes are the LiteLLM alert types sent to the receiver.
+var AlertTypes = []string{"budget_alerts", "outage_alerts", "region_outage_alerts"}
+
+// knownAlertTypes are the alert types LiteLLM sends.
+var knownAlertTypes = []string{
+	"budget_alerts", "cooldown_deployment", "daily_reports", "db_exceptions", "failed_tracking_spend",
+	"fallback_reports", "llm_exceptions", "llm_requests_hanging", "llm_too_slow", "new_model_added",
+	"outage_alerts", "region_outage_alerts", "spend_reports",
+}
+
+// Data keys of the alert ConfigMap.
+const (
+	AlertEventKey   = "event"
+	AlertGroupKey   = "eventGroup"
+	AlertMessageKey = "message"
+	AlertTimeKey    = "time"
+)
+
+// Alerting reports whether the gateway asks for alerting. Invalid values
+// yield a *PhaseError.
+func Alerting(annotations map[string]string) (bool, error) {
+	v, ok := annotations[AlertingAnnotation]
+	if !ok {
+		return false, nil
+	}
+	enabled, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, &PhaseError{Phase: AlertingPhase, Err: fmt.Errorf(
+			"invalid %s annotation %q: must be \"true\" or \"false\"", AlertingAnnotation, v)}
+	}
+	return enabled, nil
+}
+
+// AlertingSettings are the alert destinations a gateway sets up itself, and
+// what is sent to every destination.
+type AlertingSettings struct {
+	// SlackSecret and WebhookSecret name the Secrets of the destinations;
+	// empty for none.
+	SlackSecret   string
+	WebhookSecret string
+	// AlertTypes is empty for the default.
+	AlertTypes []string
+	// Threshold is the slow request threshold in seconds, 0 for LiteLLM's
+	// default.
+	Threshold int
+}
+
+// ParseAlertingSettings returns the alerting settings of a gateway with
+// annotations. Invalid values yield a *PhaseError.
+func ParseAlertingSettings(annotations map[string]string) (AlertingSettings, error) {
+	phaseErr := func(format string, args ...any) error {
+		return &PhaseError{Phase: AlertingPhase, Err: fmt.Errorf(format, args...)}
+	}
+	settings := AlertingSettings{
+		SlackSecret:   strings.TrimSpace(annotations[AlertingSlackSecretAnnotation]),
+		WebhookSecret: strings.TrimSpace(annotations[AlertingWebhookSecretAnnotation]),
+		AlertTypes:    splitList(annotations[AlertTypesAnnotation]),
+	}
+	for _, secret := range []struct{ annotation, name string }{
+		{AlertingSlackSecretAnnotation, settings.SlackSecret},
+		{AlertingWebhookSecretAnnotation, settings.WebhookSecret},
+	} {
+		if errs := validation.IsDNS1123Subdomain(secret.name); secret.name != "" && len(errs) > 0 {
+			return AlertingSettings{}, phaseErr("invalid %s annotation %q: %s", secret.annotation, secret.name, strings.Join(errs, ", "))
+		}
+	}
+	if settings.WebhookSecret != "" {
+		if enabled, _ := Alerting(annotations); enabled {
+			return AlertingSettings{}, phaseErr("%s cannot be combined with %s: both set the webhook URL",
+				AlertingWebhookSecretAnnotation, AlertingAnnotation)
+		}
+	}
+	for _, alertType := range settings.AlertTypes {
+		if !slices.Contains(knownAlertTypes, alertType) {
+			return AlertingSettings{}, phaseErr("invalid alert type %q in %s: must be one of %s",
+				alertType, AlertTypesAnnotation, strings.Join(knownAlertTypes, ", "))
+		}
+	}
+	if v, ok := annotations[AlertingThresholdAnnotation]; ok {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < time.Second {
+			return AlertingSettings{}, phaseErr("invalid %s annotation %q: must be a duration of at least 1s", AlertingThresholdAnnotation, v)
+		}
+		settings.Threshold = int(d.Round(time.Second) / time.Second)
+	}
+	return settings, nil
+}
+
+// Destinations returns the general_settings.alerting of a gateway with
+// settings, which sends its alerts to the operator's receiver if receiver
+// is set.
+func (s AlertingSettings) Destinations(receiver bool) []string {
+	var destinations []string
+	if receiver || s.WebhookSecret != "" {
+		destinations = append(destinations, "webhook")
+	}
+	if s.SlackSecret != "" {
+		destinations = append(destinations, "slack")
+	}
+	return destinations
+}
+
+// EnvVars returns the env vars loading the webhook URLs of the Secrets of s.
+// A missing Secret keeps the gateway from starting, as for any secretKeyRef.
+func (s AlertingSettings) EnvVars() []corev1.EnvVar {
+	var env []corev1.EnvVar
+	for _, v := range []struct{ secret, key string }{
+		{s.WebhookSecret, WebhookURLKey},
+		{s.SlackSecret, SlackWebhookURLKey},
+	} {
+		if v.secret == "" {
+			continue
+		}
+		env = append(env, corev1.EnvVar{
+			Name: v.key,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: v.secret},
+					Key:                  v.key,
+				},
+			},
+		})
+	}
+	return env
+}
+
+// AlertingSecretName is the name of the Secret holding the alert webhook URL
+// of the gateway name.
+func AlertingSecretName(name string) string {
+	return name + "-alerting"
+}
+
+// AlertsConfigMapName is the name of the ConfigMap the alert receiver keeps
+// the latest alert of the gateway name in.
+func AlertsConfigMapName(name string) string {
+	return name + "-alerts"
+}
+
+// AlertingEnvVar returns the env var loading the alert webhook URL into the
+// LiteLLM container of gateway name. It is optional, so the gateway starts
+// while the operator runs without an alert receiver.
+func AlertingEnvVar(name string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: WebhookURLKey,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: AlertingSecretName(name)},
+				Key:                  WebhookURLKey,
+				Optional:             &[]bool{true}[0],
+			},
+		},
+	}
+}
+
+// ReconcileAlerting writes the alerting Secret of w pointing at the alert
+// receiver at receiverURL, and deletes it when receiverURL is empty. The
+// token is generated once and kept, so only a new receiverURL rolls the
+// gateway.
+//
+// On failure, the returned error is a *PhaseError tagged AlertingPhase.
+func ReconcileAlerting(ctx context.Context, c client.Client, scheme *runtime.Scheme, w GatewayWorkload, receiverURL string) error {
+	phaseErr := func(err error) error { return &PhaseError{Phase: AlertingPhase, Err: err} }
+	name := AlertingSecretName(w.Name)
+	if receiverURL == "" {
+		if err := deleteOwned(ctx, c, w, &corev1.Secret{}, name); err != nil {
+			return phaseErr(err)
+		}
+		return nil
+	}
+
+	secret := &corev1.Secret{}
+	err := c.Get(ctx, client.ObjectKey{Namespace: w.Namespace, Name: name}, secret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return phaseErr(err)
+	}
+	if err == nil && !metav1.IsControlledBy(secret, w.Owner) {
+		return phaseErr(&ConflictError{Kind: "Secret", Namespace: w.Namespace, Name: name,
+			Reason: "is not controlled by the gateway", Controlled: metav1.GetControllerOfNoCopy(secret) != nil})
+	}
+	token := string(secret.Data[alertTokenKey])
+	if token == "" {
+		random := make([]byte, 24)
+		if _, err := rand.Read(random); err != nil {
+			return phaseErr(err)
+		}
+		token = hex.EncodeToString(random)
+	}
+	url := strings.TrimSuffix(receiverURL, "/") + AlertPathPrefix + w.Namespace + "/" + w.Name + "/" + token
+	if string(secret.Data[WebhookURLKey]) == url {
+		return nil
+	}
+
+	var opts []client.CreateOption
+	var updateOpts []client.UpdateOption
+	if w.DryRun {
+		opts = append(opts, client.DryRunAll)
+		updateOpts = append(updateOpts, client.DryRunAll)
+	}
+	secret.Data = map[string][]byte{alertTokenKey: []byte(token), WebhookURLKey: []byte(url)}
+	if secret.ResourceVersion != "" {
+		if err := c.Update(ctx, secret, updateOpts...); err != nil {
+			return phaseErr(err)
+		}
+		return nil
+	}
+	secret.ObjectMeta = metav1.ObjectMeta{
+		Name:      name,
+		Namespace: w.Namespace,
+		Labels:    BuildResourceLabels(w.Name, w.CommonMetadata),
+	}
+	if err := controllerutil.SetControllerReference(w.Owner, secret, scheme); err != nil {
+		return phaseErr(err)
+	}
+	if err := c.Create(ctx, secret, opts...); err != nil {
+		return phaseErr(err)
+	}
+	return nil
+}
+
+// VerifyAlertToken reports whether token is the alert token of the gateway
+// name in namespace.
+func VerifyAlertToken(ctx context.Context, c client.Reader, namespace, name, token string) (bool, error) {
+	var secret corev1.Secret
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: AlertingSecretName(name)}, &secret); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	want := secret.Data[alertTokenKey]
+	return len(want) > 0 && subtle.ConstantTimeCompare(want, []byte(token)) == 1, nil
+}
+
+// Alert is the latest alert of a gateway, as kept in its alert ConfigMap.
+type Alert struct {
+	Event   string
+	Group   string
+	Message string
+	Time    time.Time
+}
+
+// LoadAlert returns the latest alert of the gateway name, or nil when it
+// has none.
+func LoadAlert(ctx context.Context, c client.Reader, namespace, name string) (*Alert, error) {
+	var cm corev1.ConfigMap
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: AlertsConfigMapName(name)}, &cm); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	at, err := time.Parse(time.RFC3339, cm.Data[AlertTimeKey])
+	if err != nil {
+		return nil, fmt.Errorf("alert ConfigMap %s: invalid %s: %w", cm.Name, AlertTimeKey, err)
+	}
+	return &Alert{Event: cm.Data[AlertEventKey], Group: cm.Data[AlertGroupKey], Message: cm.Data[AlertMessageKey], Time: at}, nil
+}
+
+// StoreAlert writes alert to the alert ConfigMap of owner, controlled by it
+// so the AiGateway controller sees the change.
+func StoreAlert(ctx context.Context, c client.Client, scheme *runtime.Scheme, owner client.Object, alert Alert) error {
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: AlertsConfigMapName(owner.GetName()), Namespace: owner.GetNamespace()}}
+	_, err := controllerutil.CreateOrUpdate(ctx, c, cm, func() error {
+		cm.Labels = map[string]string{ManagedByLabel: FieldManager}
+		cm.Data = map[string]string{
+			AlertEventKey:   alert.Event,
+			AlertGroupKey:   alert.Group,
+			AlertMessageKey: alert.Message,
+			AlertTimeKey:    alert.Time.UTC().Format(time.RFC3339),
+		}
+		return controllerutil.SetControllerReference(owner, cm, scheme)
+	})
+	return err
+}
+
+// DeleteAlerts deletes the alert ConfigMap once alerting is turned off.
+func DeleteAlerts(ctx context.Context, c client.Client, w GatewayWorkload) error {
+	if err := deleteOwned(ctx, c, w, &corev1.ConfigMap{}, AlertsConfigMapName(w.Name)); err != nil {
+		return &PhaseError{Phase: AlertingPhase, Err: err}
+	}
+	return nil
+}
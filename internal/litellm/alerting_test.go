@@ -0,0 +1,188 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestAlerting(t *testing.T) {
+	for _, tc := range []struct {
+		annotations map[string]string
+		want        bool
+		wantErr     bool
+	}{
+		{annotations: nil},
+		{annotations: map[string]string{AlertingAnnotation: "true"}, want: true},
+		{annotations: map[string]string{AlertingAnnotation: "false"}},
+		{annotations: map[string]string{AlertingAnnotation: "yes please"}, wantErr: true},
+	} {
+		got, err := Alerting(tc.annotations)
+		if tc.wantErr {
+			var pe *PhaseError
+			if !errors.As(err, &pe) || pe.Phase != AlertingPhase {
+				t.Errorf("%v: want an %s PhaseError, got %v", tc.annotations, AlertingPhase, err)
+			}
+			continue
+		}
+		if err != nil || got != tc.want {
+			t.Errorf("%v: got %v, %v, want %v", tc.annotations, got, err, tc.want)
+		}
+	}
+}
+
+func TestParseAlertingSettings(t *testing.T) {
+	for _, tc := range []struct {
+		annotations map[string]string
+		want        AlertingSettings
+		wantErr     bool
+	}{
+		{annotations: nil},
+		{
+			annotations: map[string]string{
+				AlertingSlackSecretAnnotation: "slack",
+				AlertTypesAnnotation:          "budget_alerts, llm_too_slow",
+				AlertingThresholdAnnotation:   "2m",
+			},
+			want: AlertingSettings{SlackSecret: "slack", AlertTypes: []string{"budget_alerts", "llm_too_slow"}, Threshold: 120},
+		},
+		{
+			annotations: map[string]string{AlertingWebhookSecretAnnotation: "hook", AlertingAnnotation: "false"},
+			want:        AlertingSettings{WebhookSecret: "hook"},
+		},
+		{annotations: map[string]string{AlertingWebhookSecretAnnotation: "hook", AlertingAnnotation: "true"}, wantErr: true},
+		{annotations: map[string]string{AlertingSlackSecretAnnotation: "Not_A_Name"}, wantErr: true},
+		{annotations: map[string]string{AlertTypesAnnotation: "budget_alerts,everything"}, wantErr: true},
+		{annotations: map[string]string{AlertingThresholdAnnotation: "300"}, wantErr: true},
+		{annotations: map[string]string{AlertingThresholdAnnotation: "500ms"}, wantErr: true},
+	} {
+		got, err := ParseAlertingSettings(tc.annotations)
+		if tc.wantErr {
+			var pe *PhaseError
+			if !errors.As(err, &pe) || pe.Phase != AlertingPhase {
+				t.Errorf("%v: want an %s PhaseError, got %v", tc.annotations, AlertingPhase, err)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%v: got %+v, %v, want %+v", tc.annotations, got, err, tc.want)
+		}
+	}
+}
+
+func TestAlertingSettings_Destinations(t *testing.T) {
+	if got := (AlertingSettings{}).Destinations(false); got != nil {
+		t.Errorf("no destinations: got %v", got)
+	}
+	if got := (AlertingSettings{SlackSecret: "slack"}).Destinations(true); !reflect.DeepEqual(got, []string{"webhook", "slack"}) {
+		t.Errorf("receiver and Slack: got %v", got)
+	}
+	settings := AlertingSettings{WebhookSecret: "hook", SlackSecret: "slack"}
+	if got := settings.Destinations(false); !reflect.DeepEqual(got, []string{"webhook", "slack"}) {
+		t.Errorf("webhook and Slack: got %v", got)
+	}
+	env := settings.EnvVars()
+	if len(env) != 2 || env[0].Name != WebhookURLKey || env[0].ValueFrom.SecretKeyRef.Name != "hook" ||
+		env[1].Name != SlackWebhookURLKey || env[1].ValueFrom.SecretKeyRef.Key != SlackWebhookURLKey {
+		t.Errorf("env = %+v", env)
+	}
+}
+
+func TestReconcileAlerting(t *testing.T) {
+	s := workloadScheme(t)
+	owner := newOwner("gw", "default")
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(owner).Build()
+	ctx := context.Background()
+	w := GatewayWorkload{Name: "gw", Namespace: "default", Owner: owner}
+	key := types.NamespacedName{Name: "gw-alerting", Namespace: "default"}
+
+	if err := ReconcileAlerting(ctx, c, s, w, "http://receiver:8082/"); err != nil {
+		t.Fatalf("ReconcileAlerting: %v", err)
+	}
+	var secret corev1.Secret
+	if err := c.Get(ctx, key, &secret); err != nil {
+		t.Fatalf("get Secret: %v", err)
+	}
+	token := string(secret.Data[alertTokenKey])
+	if url := string(secret.Data[WebhookURLKey]); url != "http://receiver:8082/alerts/default/gw/"+token || len(token) != 48 {
+		t.Errorf("WEBHOOK_URL = %q", url)
+	}
+	if ok, err := VerifyAlertToken(ctx, c, "default", "gw", token); err != nil || !ok {
+		t.Errorf("VerifyAlertToken(token) = %v, %v", ok, err)
+	}
+	if ok, _ := VerifyAlertToken(ctx, c, "default", "gw", "guess"); ok {
+		t.Error("a wrong token must not verify")
+	}
+	if ok, _ := VerifyAlertToken(ctx, c, "default", "other", token); ok {
+		t.Error("the token must only verify for its gateway")
+	}
+
+	// A new receiver URL keeps the token.
+	if err := ReconcileAlerting(ctx, c, s, w, "http://alerts.example"); err != nil {
+		t.Fatalf("ReconcileAlerting: %v", err)
+	}
+	if err := c.Get(ctx, key, &secret); err != nil {
+		t.Fatalf("get Secret: %v", err)
+	}
+	if url := string(secret.Data[WebhookURLKey]); !strings.HasPrefix(url, "http://alerts.example/alerts/") || !strings.HasSuffix(url, token) {
+		t.Errorf("WEBHOOK_URL = %q, want the new receiver and the same token", url)
+	}
+
+	if err := ReconcileAlerting(ctx, c, s, w, ""); err != nil {
+		t.Fatalf("ReconcileAlerting: %v", err)
+	}
+	if err := c.Get(ctx, key, &corev1.Secret{}); !apierrors.IsNotFound(err) {
+		t.Errorf("Secret: want NotFound, got %v", err)
+	}
+}
+
+func TestStoreAndLoadAlert(t *testing.T) {
+	s := workloadScheme(t)
+	owner := newOwner("gw", "default")
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(owner).Build()
+	ctx := context.Background()
+
+	if alert, err := LoadAlert(ctx, c, "default", "gw"); err != nil || alert != nil {
+		t.Fatalf("want no alert, got %+v, %v", alert, err)
+	}
+	at := time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC)
+	want := Alert{Event: "budget_crossed", Group: "key", Message: "Budget crossed", Time: at}
+	if err := StoreAlert(ctx, c, s, owner, want); err != nil {
+		t.Fatalf("StoreAlert: %v", err)
+	}
+	got, err := LoadAlert(ctx, c, "default", "gw")
+	if err != nil || got == nil || *got != want {
+		t.Fatalf("LoadAlert = %+v, %v, want %+v", got, err, want)
+	}
+
+	if err := DeleteAlerts(ctx, c, GatewayWorkload{Name: "gw", Namespace: "default", Owner: owner}); err != nil {
+		t.Fatalf("DeleteAlerts: %v", err)
+	}
+	if alert, err := LoadAlert(ctx, c, "default", "gw"); err != nil || alert != nil {
+		t.Errorf("want the alert deleted, got %+v, %v", alert, err)
+	}
+}
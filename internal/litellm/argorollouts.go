@@ -0,0 +1,349 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Annotations tuning the ArgoRollouts rollout strategy.
+const (
+	// ArgoRolloutsStepsAnnotation lists the canary weights in percent,
+	// comma-separated and increasing, such as "20,50".
+	ArgoRolloutsStepsAnnotation = "ai-gateway-litellm.agentic-layer.ai/argo-rollouts-steps"
+	// ArgoRolloutsPauseAnnotation sets how long each canary weight is held
+	// and analysed before the next one.
+	ArgoRolloutsPauseAnnotation = "ai-gateway-litellm.agentic-layer.ai/argo-rollouts-pause"
+	// ArgoRolloutsMaxErrorRateAnnotation is the share of failed requests, 0
+	// to 1, above which the analysis aborts the rollout.
+	ArgoRolloutsMaxErrorRateAnnotation = "ai-gateway-litellm.agentic-layer.ai/argo-rollouts-max-error-rate"
+	// ArgoRolloutsPrometheusAnnotation is the URL of the Prometheus that
+	// scrapes the gateway's pods. It is required.
+	ArgoRolloutsPrometheusAnnotation = "ai-gateway-litellm.agentic-layer.ai/argo-rollouts-prometheus"
+)
+
+// Defaults of the ArgoRollouts rollout strategy.
+const (
+	DefaultArgoRolloutsSteps        = "20,50"
+	DefaultArgoRolloutsPause        = 2 * time.Minute
+	DefaultArgoRolloutsMaxErrorRate = 0.05
+)
+
+// ArgoRolloutsPhase tags failures applying the Rollout and AnalysisTemplate
+// of a gateway, including a cluster without the Argo Rollouts CRDs.
+const ArgoRolloutsPhase = "ArgoRollouts"
+
+// GVKs of the Argo Rollouts kinds the ArgoRollouts strategy creates.
+var (
+	ArgoRolloutGVK          = schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "Rollout"}
+	ArgoAnalysisTemplateGVK = schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "AnalysisTemplate"}
+)
+
+// argoRolloutAbortedReason is the reason of the Progressing condition of a
+// Rollout whose analysis failed.
+const argoRolloutAbortedReason = "RolloutAborted"
+
+// ArgoRollout configures an Argo Rollouts canary rollout of a GatewayWorkload.
+type ArgoRollout struct {
+	// Steps are the canary weights in percent, increasing.
+	Steps []int64
+	// Pause is how long each weight is held; zero moves on immediately.
+	Pause time.Duration
+	// MaxErrorRate is the highest share of failed canary requests the
+	// analysis accepts.
+	MaxErrorRate float64
+	// PrometheusAddress is where the analysis queries LiteLLM's metrics.
+	PrometheusAddress string
+}
+
+// ParseArgoRollout returns the canary settings requested via the
+// ArgoRollouts* annotations, or nil when RolloutStrategyAnnotation is not
+// ArgoRollouts. Invalid values yield a *PhaseError tagged
+// RolloutStrategyPhase.
+func ParseArgoRollout(annotations map[string]string) (*ArgoRollout, error) {
+	if annotations[RolloutStrategyAnnotation] != RolloutStrategyArgoRollouts {
+		return nil, nil
+	}
+	phaseErr := func(format string, args ...any) error {
+		return &PhaseError{Phase: RolloutStrategyPhase, Err: fmt.Errorf(format, args...)}
+	}
+
+	rollout := &ArgoRollout{Pause: DefaultArgoRolloutsPause, MaxErrorRate: DefaultArgoRolloutsMaxErrorRate}
+	raw, ok := annotations[ArgoRolloutsStepsAnnotation]
+	if !ok {
+		raw = DefaultArgoRolloutsSteps
+	}
+	for s := range strings.SplitSeq(raw, ",") {
+		weight, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+		if err != nil || weight < 1 || weight > 99 || (len(rollout.Steps) > 0 && weight <= rollout.Steps[len(rollout.Steps)-1]) {
+			return nil, phaseErr("invalid %s annotation %q: must be increasing percentages between 1 and 99, such as %q",
+				ArgoRolloutsStepsAnnotation, raw, DefaultArgoRolloutsSteps)
+		}
+		rollout.Steps = append(rollout.Steps, weight)
+	}
+	if raw, ok := annotations[ArgoRolloutsPauseAnnotation]; ok {
+		d, err := time.ParseDuration(raw)
+		if err != nil || d < 0 {
+			return nil, phaseErr("invalid %s annotation %q: must be a non-negative duration such as 2m", ArgoRolloutsPauseAnnotation, raw)
+		}
+		rollout.Pause = d
+	}
+	if raw, ok := annotations[ArgoRolloutsMaxErrorRateAnnotation]; ok {
+		rate, err := strconv.ParseFloat(raw, 64)
+		if err != nil || rate < 0 || rate > 1 {
+			return nil, phaseErr("invalid %s annotation %q: must be a number between 0 and 1", ArgoRolloutsMaxErrorRateAnnotation, raw)
+		}
+		rollout.MaxErrorRate = rate
+	}
+	address := annotations[ArgoRolloutsPrometheusAnnotation]
+	if u, err := url.Parse(address); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, phaseErr("%s needs the %s annotation set to the URL of the Prometheus scraping the gateway, got %q",
+			RolloutStrategyArgoRollouts, ArgoRolloutsPrometheusAnnotation, address)
+	}
+	rollout.PrometheusAddress = address
+	return rollout, nil
+}
+
+// ArgoAnalysisTemplateName is the name of the error-rate AnalysisTemplate of
+// the gateway name.
+func ArgoAnalysisTemplateName(name string) string {
+	return name + "-error-rate"
+}
+
+// ReconcileArgoRolloutWorkload is ReconcileWorkload for the ArgoRollouts
+// rollout strategy. The pod template ReconcileWorkload would put into the
+// Deployment goes into an Argo Rollout named like the gateway instead, whose
+// canary steps are analysed against the AnalysisTemplate
+// ArgoAnalysisTemplateName. The Service selects the pods of both, so the
+// Deployment of a preceding rolling update keeps serving until the Rollout
+// is rolled out and is deleted then. w.ArgoRollout must be set.
+//
+// The returned Deployment mirrors the spec and status of the Rollout, so it
+// can be passed to IsDeploymentRolledOut, DeployedConfigHash and
+// ArgoRolloutAborted. On failure, the returned error is a *PhaseError tagged
+// with which step failed; a cluster without Argo Rollouts is reported as
+// ArgoRolloutsPhase, and reconcile retries until the CRDs are installed.
+func ReconcileArgoRolloutWorkload(ctx context.Context, c client.Client, scheme *runtime.Scheme, w GatewayWorkload) (*appsv1.Deployment, error) {
+	phaseErr := func(err error) error { return &PhaseError{Phase: ArgoRolloutsPhase, Err: err} }
+	for _, gvk := range []schema.GroupVersionKind{ArgoRolloutGVK, ArgoAnalysisTemplateGVK} {
+		if _, err := c.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version); err != nil {
+			if apimeta.IsNoMatchError(err) {
+				err = fmt.Errorf("%s needs Argo Rollouts, but %s is not served by this cluster", RolloutStrategyArgoRollouts, gvk.GroupKind())
+			}
+			return nil, phaseErr(err)
+		}
+	}
+
+	if err := reconcileConfigMap(ctx, c, scheme, w); err != nil {
+		return nil, &PhaseError{Phase: "ConfigMap", Err: err}
+	}
+	secretHash, err := computeSecretHash(ctx, c, w.Namespace, ReferencedSecretNames(w.Env, w.EnvFrom))
+	if err != nil {
+		return nil, &PhaseError{Phase: "Secret", Err: err}
+	}
+
+	template, err := BuildArgoAnalysisTemplate(w, scheme)
+	if err != nil {
+		return nil, phaseErr(err)
+	}
+	if err := applyUnstructured(ctx, c, w, template); err != nil {
+		return nil, phaseErr(err)
+	}
+	rollout, err := BuildArgoRollout(w, scheme, hashYAML(w.ConfigYAML), secretHash)
+	if err != nil {
+		return nil, phaseErr(err)
+	}
+	if err := applyUnstructured(ctx, c, w, rollout); err != nil {
+		return nil, phaseErr(err)
+	}
+	if err := reconcileService(ctx, c, scheme, w); err != nil {
+		return nil, &PhaseError{Phase: "Service", Err: err}
+	}
+
+	applied := &unstructured.Unstructured{}
+	applied.SetGroupVersionKind(ArgoRolloutGVK)
+	if err := getOwned(ctx, c, w, client.ObjectKey{Namespace: w.Namespace, Name: w.Name}, applied); err != nil {
+		return nil, phaseErr(err)
+	}
+	if applied.GetResourceVersion() == "" {
+		// Only in dry-run mode, where the apply did not create it.
+		applied = rollout
+	}
+	view, err := argoRolloutDeployment(applied)
+	if err != nil {
+		return nil, phaseErr(err)
+	}
+
+	// The Service no longer needs the pods of a rolling update.
+	if rolledOut, _ := IsDeploymentRolledOut(view); rolledOut && !w.DryRun {
+		legacy := &appsv1.Deployment{}
+		if err := getOwned(ctx, c, w, client.ObjectKey{Namespace: w.Namespace, Name: w.Name}, legacy); err != nil {
+			return nil, &PhaseError{Phase: "Deployment", Err: err}
+		}
+		if legacy.ResourceVersion != "" && metav1.IsControlledBy(legacy, w.Owner) {
+			if err := c.Delete(ctx, legacy); client.IgnoreNotFound(err) != nil {
+				return nil, &PhaseError{Phase: "Deployment", Err: err}
+			}
+		}
+	}
+	return view, nil
+}
+
+// DeleteArgoRollout removes the Rollout and AnalysisTemplate left behind when
+// a gateway moves to another rollout strategy. Callers invoke it once the
+// Deployment of the new strategy is rolled out. It is a no-op on clusters
+// without Argo Rollouts.
+func DeleteArgoRollout(ctx context.Context, c client.Client, w GatewayWorkload) error {
+	if served, err := kindServed(c, ArgoRolloutGVK); err != nil || !served {
+		return err
+	}
+	rollout := &unstructured.Unstructured{}
+	rollout.SetGroupVersionKind(ArgoRolloutGVK)
+	if err := getOwned(ctx, c, w, client.ObjectKey{Namespace: w.Namespace, Name: w.Name}, rollout); err != nil {
+		return err
+	}
+	if rollout.GetResourceVersion() == "" {
+		return nil
+	}
+	// The AnalysisTemplate goes first, so it is never left without the
+	// Rollout that marks it for deletion.
+	template := &unstructured.Unstructured{}
+	template.SetGroupVersionKind(ArgoAnalysisTemplateGVK)
+	if err := deleteOwned(ctx, c, w, template, ArgoAnalysisTemplateName(w.Name)); err != nil {
+		return err
+	}
+	return deleteOwned(ctx, c, w, rollout, w.Name)
+}
+
+// ArgoRolloutAborted reports whether d, as returned by
+// ReconcileArgoRolloutWorkload, mirrors a Rollout whose analysis failed.
+// Argo Rollouts then scales the canary down and waits for a new pod template
+// or a manual retry. The second return is Argo Rollouts' message.
+func ArgoRolloutAborted(d *appsv1.Deployment) (bool, string) {
+	for _, c := range d.Status.Conditions {
+		if c.Type == appsv1.DeploymentProgressing && c.Status == corev1.ConditionFalse && c.Reason == argoRolloutAbortedReason {
+			return true, c.Message
+		}
+	}
+	return false, ""
+}
+
+// BuildArgoRollout returns the desired state of the gateway's Rollout: the
+// Deployment BuildDeployment would return, with a canary strategy of
+// w.ArgoRollout's steps. The error-rate analysis runs in the background from
+// the second step on, once the canary takes traffic.
+func BuildArgoRollout(w GatewayWorkload, scheme *runtime.Scheme, configHash, secretHash string) (*unstructured.Unstructured, error) {
+	ownerRef, err := controllerReference(w.Owner, scheme)
+	if err != nil {
+		return nil, err
+	}
+	deployment, err := buildDeployment(w, ownerRef, configHash, secretHash)
+	if err != nil {
+		return nil, err
+	}
+	template, err := runtime.DefaultUnstructuredConverter.ToUnstructured(deployment.Spec.Template)
+	if err != nil {
+		return nil, err
+	}
+	var steps []any
+	for _, weight := range w.ArgoRollout.Steps {
+		steps = append(steps, map[string]any{"setWeight": weight})
+		if w.ArgoRollout.Pause > 0 {
+			// A pause without a duration waits for a manual promotion.
+			steps = append(steps, map[string]any{"pause": map[string]any{
+				"duration": strconv.FormatInt(int64(w.ArgoRollout.Pause/time.Second), 10) + "s",
+			}})
+		}
+	}
+	rollout, err := ownedUnstructured(w, scheme, ArgoRolloutGVK, w.Name)
+	if err != nil {
+		return nil, err
+	}
+	rollout.Object["spec"] = map[string]any{
+		"selector": map[string]any{"matchLabels": map[string]any{"app": w.Name}},
+		"template": template,
+		"strategy": map[string]any{"canary": map[string]any{
+			"steps": steps,
+			"analysis": map[string]any{
+				"templates":    []any{map[string]any{"templateName": ArgoAnalysisTemplateName(w.Name)}},
+				"startingStep": int64(1),
+				"args": []any{map[string]any{
+					"name":      "canary-hash",
+					"valueFrom": map[string]any{"podTemplateHashValue": "Latest"},
+				}},
+			},
+		}},
+	}
+	return rollout, nil
+}
+
+// BuildArgoAnalysisTemplate returns the desired state of the gateway's
+// AnalysisTemplate. It measures the share of failed requests on the pods of
+// the canary, named after its pod template hash, from LiteLLM's Prometheus
+// metrics. A canary without traffic passes.
+func BuildArgoAnalysisTemplate(w GatewayWorkload, scheme *runtime.Scheme) (*unstructured.Unstructured, error) {
+	template, err := ownedUnstructured(w, scheme, ArgoAnalysisTemplateGVK, ArgoAnalysisTemplateName(w.Name))
+	if err != nil {
+		return nil, err
+	}
+	pods := fmt.Sprintf(`namespace=%q,pod=~"%s-{{args.canary-hash}}-.*"`, w.Namespace, w.Name)
+	query := fmt.Sprintf("(sum(rate(litellm_proxy_failed_requests_metric_total{%s}[2m])) or vector(0))"+
+		" / clamp_min(sum(rate(litellm_proxy_total_requests_metric_total{%s}[2m])) or vector(0), 1e-9)", pods, pods)
+	template.Object["spec"] = map[string]any{
+		"args": []any{map[string]any{"name": "canary-hash"}},
+		"metrics": []any{map[string]any{
+			"name":             "error-rate",
+			"interval":         "1m",
+			"successCondition": "result[0] <= " + strconv.FormatFloat(w.ArgoRollout.MaxErrorRate, 'g', -1, 64),
+			"provider": map[string]any{"prometheus": map[string]any{
+				"address": w.ArgoRollout.PrometheusAddress,
+				"query":   query,
+			}},
+		}},
+	}
+	return template, nil
+}
+
+// argoRolloutDeployment converts a Rollout to the Deployment it mirrors.
+// Both share replicas, selector, template and the replica counts and
+// conditions of their status; a Rollout's observedGeneration is a string.
+func argoRolloutDeployment(rollout *unstructured.Unstructured) (*appsv1.Deployment, error) {
+	obj := rollout.DeepCopy()
+	observed, _, _ := unstructured.NestedString(obj.Object, "status", "observedGeneration")
+	unstructured.RemoveNestedField(obj.Object, "status", "observedGeneration")
+	unstructured.RemoveNestedField(obj.Object, "spec", "strategy")
+	d := &appsv1.Deployment{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, d); err != nil {
+		return nil, fmt.Errorf("reading Rollout %s: %w", rollout.GetName(), err)
+	}
+	d.Status.ObservedGeneration, _ = strconv.ParseInt(observed, 10, 64)
+	return d, nil
+}
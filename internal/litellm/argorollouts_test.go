@@ -0,0 +1,318 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestParseArgoRollout(t *testing.T) {
+	const prometheus = "http://prometheus.monitoring:9090"
+	argo := func(extra map[string]string) map[string]string {
+		annotations := map[string]string{
+			RolloutStrategyAnnotation:        RolloutStrategyArgoRollouts,
+			ArgoRolloutsPrometheusAnnotation: prometheus,
+		}
+		for k, v := range extra {
+			annotations[k] = v
+		}
+		return annotations
+	}
+	cases := []struct {
+		annotations map[string]string
+		want        *ArgoRollout
+		wantErr     bool
+	}{
+		{annotations: nil},
+		{annotations: map[string]string{RolloutStrategyAnnotation: RolloutStrategyBlueGreen}},
+		{
+			annotations: argo(nil),
+			want:        &ArgoRollout{Steps: []int64{20, 50}, Pause: DefaultArgoRolloutsPause, MaxErrorRate: DefaultArgoRolloutsMaxErrorRate, PrometheusAddress: prometheus},
+		},
+		{
+			annotations: argo(map[string]string{ArgoRolloutsStepsAnnotation: "10, 30,60", ArgoRolloutsPauseAnnotation: "0s", ArgoRolloutsMaxErrorRateAnnotation: "0.01"}),
+			want:        &ArgoRollout{Steps: []int64{10, 30, 60}, MaxErrorRate: 0.01, PrometheusAddress: prometheus},
+		},
+		{annotations: map[string]string{RolloutStrategyAnnotation: RolloutStrategyArgoRollouts}, wantErr: true},
+		{annotations: argo(map[string]string{ArgoRolloutsPrometheusAnnotation: "prometheus:9090"}), wantErr: true},
+		{annotations: argo(map[string]string{ArgoRolloutsStepsAnnotation: "50,20"}), wantErr: true},
+		{annotations: argo(map[string]string{ArgoRolloutsStepsAnnotation: "20,100"}), wantErr: true},
+		{annotations: argo(map[string]string{ArgoRolloutsPauseAnnotation: "later"}), wantErr: true},
+		{annotations: argo(map[string]string{ArgoRolloutsMaxErrorRateAnnotation: "5%"}), wantErr: true},
+	}
+	for _, tc := range cases {
+		got, err := ParseArgoRollout(tc.annotations)
+		if tc.wantErr {
+			var pe *PhaseError
+			if !errors.As(err, &pe) || pe.Phase != RolloutStrategyPhase {
+				t.Errorf("%v: want a %s PhaseError, got %v", tc.annotations, RolloutStrategyPhase, err)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%v: got %+v, %v, want %+v", tc.annotations, got, err, tc.want)
+		}
+	}
+
+	if _, err := ParseRolloutStrategy(argo(nil)); err != nil {
+		t.Errorf("ParseRolloutStrategy must accept %s: %v", RolloutStrategyArgoRollouts, err)
+	}
+}
+
+func TestBuildArgoRollout(t *testing.T) {
+	s := workloadScheme(t)
+	w := GatewayWorkload{
+		Name: "gw", Namespace: "default", Owner: newOwner("gw", "default"),
+		ContainerPort: 4000, ConfigYAML: "model_list: []\n",
+		ArgoRollout: &ArgoRollout{Steps: []int64{20, 50}, Pause: time.Minute, MaxErrorRate: 0.05, PrometheusAddress: "http://prom:9090"},
+	}
+	rollout, err := BuildArgoRollout(w, s, "cfg-hash", "sec-hash")
+	if err != nil {
+		t.Fatalf("BuildArgoRollout: %v", err)
+	}
+	if rollout.GroupVersionKind() != ArgoRolloutGVK || rollout.GetName() != "gw" {
+		t.Errorf("got %s %s", rollout.GroupVersionKind(), rollout.GetName())
+	}
+	if refs := rollout.GetOwnerReferences(); len(refs) != 1 || refs[0].UID != "owner-uid-123" {
+		t.Errorf("owner references = %+v", refs)
+	}
+	if rollout.GetLabels()[ManagedByLabel] == "" {
+		t.Error("Rollout must carry the managed-by label")
+	}
+	hash, _, _ := unstructured.NestedString(rollout.Object, "spec", "template", "metadata", "annotations", configHashAnnotation)
+	if hash != "cfg-hash" {
+		t.Errorf("pod template config hash = %q", hash)
+	}
+	containers, _, _ := unstructured.NestedSlice(rollout.Object, "spec", "template", "spec", "containers")
+	if len(containers) != 1 || containers[0].(map[string]any)["name"] != ContainerName {
+		t.Errorf("containers = %v", containers)
+	}
+	steps, _, _ := unstructured.NestedSlice(rollout.Object, "spec", "strategy", "canary", "steps")
+	want := []any{
+		map[string]any{"setWeight": int64(20)},
+		map[string]any{"pause": map[string]any{"duration": "60s"}},
+		map[string]any{"setWeight": int64(50)},
+		map[string]any{"pause": map[string]any{"duration": "60s"}},
+	}
+	if !reflect.DeepEqual(steps, want) {
+		t.Errorf("steps = %v, want %v", steps, want)
+	}
+	templates, _, _ := unstructured.NestedSlice(rollout.Object, "spec", "strategy", "canary", "analysis", "templates")
+	if len(templates) != 1 || templates[0].(map[string]any)["templateName"] != "gw-error-rate" {
+		t.Errorf("analysis templates = %v", templates)
+	}
+	// DeepCopy panics on values that are not JSON-compatible, which the
+	// apply path would reject.
+	rollout.DeepCopy()
+}
+
+func TestBuildArgoAnalysisTemplate(t *testing.T) {
+	s := workloadScheme(t)
+	w := GatewayWorkload{
+		Name: "gw", Namespace: "team-a", Owner: newOwner("gw", "team-a"),
+		ArgoRollout: &ArgoRollout{Steps: []int64{20}, MaxErrorRate: 0.02, PrometheusAddress: "http://prom:9090"},
+	}
+	template, err := BuildArgoAnalysisTemplate(w, s)
+	if err != nil {
+		t.Fatalf("BuildArgoAnalysisTemplate: %v", err)
+	}
+	if template.GroupVersionKind() != ArgoAnalysisTemplateGVK || template.GetName() != "gw-error-rate" {
+		t.Errorf("got %s %s", template.GroupVersionKind(), template.GetName())
+	}
+	metrics, _, _ := unstructured.NestedSlice(template.Object, "spec", "metrics")
+	if len(metrics) != 1 {
+		t.Fatalf("metrics = %v", metrics)
+	}
+	metric := metrics[0].(map[string]any)
+	if metric["successCondition"] != "result[0] <= 0.02" {
+		t.Errorf("successCondition = %v", metric["successCondition"])
+	}
+	prometheus := metric["provider"].(map[string]any)["prometheus"].(map[string]any)
+	query := prometheus["query"].(string)
+	if prometheus["address"] != "http://prom:9090" ||
+		!strings.Contains(query, `namespace="team-a",pod=~"gw-{{args.canary-hash}}-.*"`) ||
+		!strings.Contains(query, "litellm_proxy_failed_requests_metric_total") {
+		t.Errorf("prometheus = %v", prometheus)
+	}
+}
+
+func TestArgoRolloutDeployment(t *testing.T) {
+	rollout := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "argoproj.io/v1alpha1",
+		"kind":       "Rollout",
+		"metadata":   map[string]any{"name": "gw", "generation": int64(3)},
+		"spec": map[string]any{
+			"replicas": int64(2),
+			"template": map[string]any{"metadata": map[string]any{
+				"annotations": map[string]any{configHashAnnotation: "abc"},
+			}},
+			"strategy": map[string]any{"canary": map[string]any{"steps": []any{}}},
+		},
+		"status": map[string]any{
+			"observedGeneration": "3",
+			"replicas":           int64(2),
+			"updatedReplicas":    int64(2),
+			"availableReplicas":  int64(2),
+			"phase":              "Healthy",
+			"conditions": []any{map[string]any{
+				"type": "Progressing", "status": "False", "reason": "RolloutAborted", "message": "analysis failed",
+			}},
+		},
+	}}
+	d, err := argoRolloutDeployment(rollout)
+	if err != nil {
+		t.Fatalf("argoRolloutDeployment: %v", err)
+	}
+	if rolledOut, msg := IsDeploymentRolledOut(d); !rolledOut {
+		t.Errorf("want rolled out, got %s", msg)
+	}
+	if DeployedConfigHash(d) != "abc" {
+		t.Errorf("config hash = %q", DeployedConfigHash(d))
+	}
+	if aborted, msg := ArgoRolloutAborted(d); !aborted || msg != "analysis failed" {
+		t.Errorf("aborted = %v, %q", aborted, msg)
+	}
+
+	unstructured.SetNestedField(rollout.Object, "2", "status", "observedGeneration")
+	if d, _ := argoRolloutDeployment(rollout); d.Status.ObservedGeneration != 2 {
+		t.Errorf("observedGeneration = %d", d.Status.ObservedGeneration)
+	} else if rolledOut, _ := IsDeploymentRolledOut(d); rolledOut {
+		t.Error("an unobserved generation must not count as rolled out")
+	}
+}
+
+func TestReconcileArgoRolloutWorkload_WithoutArgoRollouts(t *testing.T) {
+	s := workloadScheme(t)
+	owner := newOwner("gw", "default")
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(owner).Build()
+	w := GatewayWorkload{
+		Name: "gw", Namespace: "default", Owner: owner,
+		ArgoRollout: &ArgoRollout{Steps: []int64{20}, PrometheusAddress: "http://prom:9090"},
+	}
+
+	_, err := ReconcileArgoRolloutWorkload(context.Background(), c, s, w)
+	var pe *PhaseError
+	if !errors.As(err, &pe) || pe.Phase != ArgoRolloutsPhase || !strings.Contains(err.Error(), "Argo Rollouts") {
+		t.Fatalf("want a %s PhaseError naming Argo Rollouts, got %v", ArgoRolloutsPhase, err)
+	}
+	if err := DeleteArgoRollout(context.Background(), c, w); err != nil {
+		t.Errorf("DeleteArgoRollout without Argo Rollouts: %v", err)
+	}
+}
+
+func TestReconcileArgoRolloutWorkload(t *testing.T) {
+	ctx := context.Background()
+	s := workloadScheme(t)
+	owner := newOwner("gw", "default")
+	mapper := meta.NewDefaultRESTMapper(nil)
+	for gvk := range s.AllKnownTypes() {
+		mapper.Add(gvk, meta.RESTScopeNamespace)
+	}
+	mapper.Add(ArgoRolloutGVK, meta.RESTScopeNamespace)
+	mapper.Add(ArgoAnalysisTemplateGVK, meta.RESTScopeNamespace)
+	// The Deployment of a preceding rolling update.
+	legacy := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+		Name: "gw", Namespace: "default",
+		OwnerReferences: []metav1.OwnerReference{{
+			APIVersion: "runtime.agentic-layer.ai/v1alpha1", Kind: "AiGateway", Name: "gw", UID: owner.UID, Controller: ptr.To(true),
+		}},
+	}}
+	c := fake.NewClientBuilder().WithScheme(s).WithRESTMapper(mapper).WithObjects(owner, legacy).Build()
+	w := GatewayWorkload{
+		Name: "gw", Namespace: "default", Owner: owner, ContainerPort: 4000, ServicePort: 4000,
+		ConfigYAML:  "model_list: []\n",
+		ArgoRollout: &ArgoRollout{Steps: []int64{20}, PrometheusAddress: "http://prom:9090"},
+	}
+
+	view, err := ReconcileArgoRolloutWorkload(ctx, c, s, w)
+	if err != nil {
+		t.Fatalf("ReconcileArgoRolloutWorkload: %v", err)
+	}
+	if DeployedConfigHash(view) != ConfigHash(w.ConfigYAML) {
+		t.Errorf("view config hash = %q", DeployedConfigHash(view))
+	}
+	if rolledOut, _ := IsDeploymentRolledOut(view); rolledOut {
+		t.Fatal("a Rollout without status must not count as rolled out")
+	}
+	for _, obj := range []client.Object{&corev1.ConfigMap{}, &corev1.Service{}} {
+		name := "gw"
+		if _, ok := obj.(*corev1.ConfigMap); ok {
+			name = "gw-config"
+		}
+		if err := c.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, obj); err != nil {
+			t.Errorf("get %T: %v", obj, err)
+		}
+	}
+	template := &unstructured.Unstructured{}
+	template.SetGroupVersionKind(ArgoAnalysisTemplateGVK)
+	if err := c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "gw-error-rate"}, template); err != nil {
+		t.Fatalf("get AnalysisTemplate: %v", err)
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(legacy), &appsv1.Deployment{}); err != nil {
+		t.Fatalf("the Deployment must serve until the Rollout is rolled out: %v", err)
+	}
+
+	rollout := &unstructured.Unstructured{}
+	rollout.SetGroupVersionKind(ArgoRolloutGVK)
+	if err := c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "gw"}, rollout); err != nil {
+		t.Fatalf("get Rollout: %v", err)
+	}
+	rollout.Object["status"] = map[string]any{
+		"observedGeneration": "1",
+		"replicas":           int64(1),
+		"updatedReplicas":    int64(1),
+		"availableReplicas":  int64(1),
+	}
+	rollout.SetGeneration(1)
+	if err := c.Update(ctx, rollout); err != nil {
+		t.Fatalf("update Rollout status: %v", err)
+	}
+	view, err = ReconcileArgoRolloutWorkload(ctx, c, s, w)
+	if err != nil {
+		t.Fatalf("ReconcileArgoRolloutWorkload: %v", err)
+	}
+	if rolledOut, msg := IsDeploymentRolledOut(view); !rolledOut {
+		t.Fatalf("want rolled out, got %s", msg)
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(legacy), &appsv1.Deployment{}); !apierrors.IsNotFound(err) {
+		t.Errorf("the Deployment must be deleted once the Rollout is rolled out, got %v", err)
+	}
+
+	if err := DeleteArgoRollout(ctx, c, w); err != nil {
+		t.Fatalf("DeleteArgoRollout: %v", err)
+	}
+	for _, obj := range []*unstructured.Unstructured{rollout, template} {
+		if err := c.Get(ctx, client.ObjectKeyFromObject(obj), obj.DeepCopy()); !apierrors.IsNotFound(err) {
+			t.Errorf("%s must be deleted, got %v", obj.GetKind(), err)
+		}
+	}
+}
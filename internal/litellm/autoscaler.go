@@ -0,0 +1,155 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"context"
+	"strconv"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	appsv1ac "k8s.io/client-go/applyconfigurations/apps/v1"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
+	metav1ac "k8s.io/client-go/applyconfigurations/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// SafeToEvictAnnotation on a pod tells the cluster autoscaler whether it may
+// evict the pod to remove an underused node.
+const SafeToEvictAnnotation = "cluster-autoscaler.kubernetes.io/safe-to-evict"
+
+// OverprovisioningPhase tags failures applying or deleting the
+// overprovisioning Deployment.
+const OverprovisioningPhase = "Overprovisioning"
+
+// OverprovisioningImage runs the placeholder pods of the overprovisioning
+// Deployment.
+const OverprovisioningImage = "registry.k8s.io/pause:3.10"
+
+// ClusterAutoscaler keeps the cluster autoscaler from interrupting gateway
+// pods, which may be streaming long completions, when it scales nodes down.
+type ClusterAutoscaler struct {
+	// SafeToEvict, when set, is the SafeToEvictAnnotation of the gateway
+	// pods. False keeps the nodes they run on.
+	SafeToEvict *bool
+	// PriorityClassName of the gateway pods, unless their gateway template
+	// sets one.
+	PriorityClassName string
+	// OverprovisioningReplicas placeholder pods, each requesting what one
+	// gateway pod requests, keep room for as many new gateway pods. Gateway
+	// pods preempt them instead of waiting for a node. Zero disables them.
+	OverprovisioningReplicas int32
+	// OverprovisioningPriorityClassName is the priority class of the
+	// placeholder pods. It must be lower than the gateway pods', usually
+	// negative, so they are preempted first and never block a scale-down
+	// on their own.
+	OverprovisioningPriorityClassName string
+}
+
+// OverprovisioningName is the name of the overprovisioning Deployment of
+// the gateway name.
+func OverprovisioningName(name string) string {
+	return name + "-overprovisioning"
+}
+
+// clusterAutoscalerAnnotations returns the pod annotations of a.
+func clusterAutoscalerAnnotations(a *ClusterAutoscaler) map[string]string {
+	if a == nil || a.SafeToEvict == nil {
+		return nil
+	}
+	return map[string]string{SafeToEvictAnnotation: strconv.FormatBool(*a.SafeToEvict)}
+}
+
+// ReconcileOverprovisioning applies the overprovisioning Deployment of w
+// when w.ClusterAutoscaler asks for placeholder pods, and deletes it
+// otherwise.
+//
+// On failure, the returned error is a *PhaseError tagged
+// OverprovisioningPhase.
+func ReconcileOverprovisioning(ctx context.Context, c client.Client, scheme *runtime.Scheme, w GatewayWorkload) error {
+	name := OverprovisioningName(w.Name)
+	phaseErr := func(err error) error { return &PhaseError{Phase: OverprovisioningPhase, Err: err} }
+
+	if w.ClusterAutoscaler == nil || w.ClusterAutoscaler.OverprovisioningReplicas <= 0 {
+		if err := deleteOwned(ctx, c, w, &appsv1.Deployment{}, name); err != nil {
+			return phaseErr(err)
+		}
+		return nil
+	}
+	ownerRef, err := controllerReference(w.Owner, scheme)
+	if err != nil {
+		return phaseErr(err)
+	}
+	deployment, err := BuildOverprovisioningDeployment(w, ownerRef)
+	if err != nil {
+		return phaseErr(err)
+	}
+	existing := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: w.Namespace}}
+	if err := apply(ctx, c, w, deployment, existing, "Deployment"); err != nil {
+		return phaseErr(err)
+	}
+	return nil
+}
+
+// BuildOverprovisioningDeployment returns the desired state of the
+// overprovisioning Deployment of w. Its pods request the sum of the
+// requests of a gateway pod's containers, overrides included, and are
+// scheduled with the gateway pods' node selector, tolerations and
+// affinity, so the room they hold fits a gateway pod.
+func BuildOverprovisioningDeployment(w GatewayWorkload, ownerRef *metav1ac.OwnerReferenceApplyConfiguration) (*appsv1ac.DeploymentApplyConfiguration, error) {
+	gateway, err := buildDeployment(w, ownerRef, "", "")
+	if err != nil {
+		return nil, err
+	}
+	gatewayPod := gateway.Spec.Template.Spec
+	requests := corev1.ResourceList{}
+	for _, container := range gatewayPod.Containers {
+		if container.Resources == nil || container.Resources.Requests == nil {
+			continue
+		}
+		for resourceName, quantity := range *container.Resources.Requests {
+			sum := requests[resourceName]
+			sum.Add(quantity)
+			requests[resourceName] = sum
+		}
+	}
+
+	name := OverprovisioningName(w.Name)
+	podSpec := corev1ac.PodSpec().
+		WithContainers(corev1ac.Container().
+			WithName("pause").
+			WithImage(w.mirror(OverprovisioningImage)).
+			WithResources(corev1ac.ResourceRequirements().WithRequests(requests))).
+		WithPriorityClassName(w.ClusterAutoscaler.OverprovisioningPriorityClassName).
+		WithTerminationGracePeriodSeconds(0)
+	podSpec.NodeSelector, podSpec.Tolerations, podSpec.Affinity = gatewayPod.NodeSelector, gatewayPod.Tolerations, gatewayPod.Affinity
+
+	return appsv1ac.Deployment(name, w.Namespace).
+		WithOwnerReferences(ownerRef).
+		WithLabels(BuildResourceLabels(w.Name, w.CommonMetadata)).
+		WithAnnotations(BuildResourceAnnotations(w.CommonMetadata)).
+		WithSpec(appsv1ac.DeploymentSpec().
+			WithReplicas(w.ClusterAutoscaler.OverprovisioningReplicas).
+			WithSelector(metav1ac.LabelSelector().WithMatchLabels(map[string]string{"app": name})).
+			WithTemplate(corev1ac.PodTemplateSpec().
+				// The placeholders exist to be evicted.
+				WithLabels(map[string]string{"app": name}).
+				WithAnnotations(map[string]string{SafeToEvictAnnotation: "true"}).
+				WithSpec(podSpec))), nil
+}
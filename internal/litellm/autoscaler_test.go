@@ -0,0 +1,103 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"context"
+	"testing"
+
+	litellmv1alpha1 "github.com/agentic-layer/ai-gateway-litellm/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1ac "k8s.io/client-go/applyconfigurations/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestBuildDeployment_ClusterAutoscaler(t *testing.T) {
+	w := GatewayWorkload{
+		Name: "gw", Namespace: "default", ContainerPort: 4000,
+		ClusterAutoscaler: &ClusterAutoscaler{SafeToEvict: ptr.To(false), PriorityClassName: "gateways"},
+	}
+	tpl := BuildDeployment(w, metav1ac.OwnerReference().WithName("gw"), "c", "s").Spec.Template
+	if tpl.Annotations[SafeToEvictAnnotation] != "false" || *tpl.Spec.PriorityClassName != "gateways" {
+		t.Errorf("annotations = %v, priority class = %v", tpl.Annotations, tpl.Spec.PriorityClassName)
+	}
+
+	w.GatewayTemplate = &litellmv1alpha1.LiteLLMGatewayTemplateSpec{
+		PriorityClassName: "critical",
+		Metadata: &litellmv1alpha1.GatewayPodMetadata{
+			Annotations: map[string]string{SafeToEvictAnnotation: "true"},
+		},
+	}
+	tpl = BuildDeployment(w, metav1ac.OwnerReference().WithName("gw"), "c", "s").Spec.Template
+	if tpl.Annotations[SafeToEvictAnnotation] != "true" || *tpl.Spec.PriorityClassName != "critical" {
+		t.Errorf("the gateway template must win, got %v, %v", tpl.Annotations, *tpl.Spec.PriorityClassName)
+	}
+
+	tpl = BuildDeployment(GatewayWorkload{Name: "gw", Namespace: "default"}, metav1ac.OwnerReference().WithName("gw"), "c", "s").Spec.Template
+	if _, ok := tpl.Annotations[SafeToEvictAnnotation]; ok || tpl.Spec.PriorityClassName != nil {
+		t.Errorf("without ClusterAutoscaler: %v, %v", tpl.Annotations, tpl.Spec.PriorityClassName)
+	}
+}
+
+func TestReconcileOverprovisioning(t *testing.T) {
+	ctx := context.Background()
+	s := workloadScheme(t)
+	owner := newOwner("gw", "default")
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(owner).Build()
+	w := GatewayWorkload{
+		Name: "gw", Namespace: "default", Owner: owner, ContainerPort: 4000,
+		GatewayTemplate: &litellmv1alpha1.LiteLLMGatewayTemplateSpec{
+			NodeSelector: map[string]string{"pool": "gateways"},
+			Sidecars:     []corev1.Container{{Name: "envoy", Image: "envoyproxy/envoy:v1.31"}},
+		},
+		ClusterAutoscaler: &ClusterAutoscaler{OverprovisioningReplicas: 2, OverprovisioningPriorityClassName: "overprovisioning"},
+	}
+	w.GatewayTemplate.Sidecars[0].Resources.Requests = corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("50m")}
+
+	if err := ReconcileOverprovisioning(ctx, c, s, w); err != nil {
+		t.Fatalf("ReconcileOverprovisioning: %v", err)
+	}
+	var d appsv1.Deployment
+	key := client.ObjectKey{Namespace: "default", Name: OverprovisioningName("gw")}
+	if err := c.Get(ctx, key, &d); err != nil {
+		t.Fatalf("get overprovisioning Deployment: %v", err)
+	}
+	pod := d.Spec.Template.Spec
+	if *d.Spec.Replicas != 2 || pod.PriorityClassName != "overprovisioning" || pod.NodeSelector["pool"] != "gateways" {
+		t.Errorf("spec = %+v", d.Spec)
+	}
+	if d.Spec.Template.Labels["app"] == "gw" {
+		t.Error("the placeholder pods must not be selected by the gateway's Service")
+	}
+	// The litellm container requests 100m, the sidecar 50m.
+	if cpu := pod.Containers[0].Resources.Requests.Cpu().String(); cpu != "150m" {
+		t.Errorf("cpu request = %s, want the sum of the gateway pod's", cpu)
+	}
+
+	w.ClusterAutoscaler = nil
+	if err := ReconcileOverprovisioning(ctx, c, s, w); err != nil {
+		t.Fatalf("ReconcileOverprovisioning disabled: %v", err)
+	}
+	if err := c.Get(ctx, key, &d); !apierrors.IsNotFound(err) {
+		t.Errorf("the overprovisioning Deployment must be deleted, got %v", err)
+	}
+}
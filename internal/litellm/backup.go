@@ -0,0 +1,349 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	batchv1ac "k8s.io/client-go/applyconfigurations/batch/v1"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
+	metav1ac "k8s.io/client-go/applyconfigurations/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// BackupScheduleAnnotation enables scheduled dumps of a database-backed
+// gateway's LiteLLM database (virtual keys, teams, spend logs) to object
+// storage. The value is a CronJob schedule such as "0 2 * * *".
+const BackupScheduleAnnotation = "ai-gateway-litellm.agentic-layer.ai/backup-schedule"
+
+// BackupRetentionAnnotation sets how many dumps are kept in object storage;
+// older ones are deleted after each successful backup. Defaults to
+// DefaultBackupRetention.
+const BackupRetentionAnnotation = "ai-gateway-litellm.agentic-layer.ai/backup-retention"
+
+// BackupSecretAnnotation names the Secret, in the gateway's namespace, that
+// holds the object storage location and credentials as environment
+// variables: BackupURIEnvVar plus the usual AWS_* variables of the AWS CLI.
+// Defaults to "<name>-backup".
+const BackupSecretAnnotation = "ai-gateway-litellm.agentic-layer.ai/backup-secret"
+
+// RestoreAnnotation names a dump, relative to BackupURIEnvVar, to restore
+// into the gateway's database. Each distinct value runs one restore Job.
+const RestoreAnnotation = "ai-gateway-litellm.agentic-layer.ai/restore-from"
+
+// BackupURIEnvVar is the key of the backup Secret holding the S3 URI dumps
+// are written to, such as "s3://bucket/litellm/team-a".
+const BackupURIEnvVar = "BACKUP_S3_URI"
+
+// BackupPhase tags backup and restore annotation validation failures.
+const BackupPhase = "Backup"
+
+// DefaultBackupRetention is the number of dumps kept when
+// BackupRetentionAnnotation is unset.
+const DefaultBackupRetention = 7
+
+// Images of the backup and restore Jobs: pg_dump / pg_restore come from
+// the Postgres image, the upload and download from the AWS CLI image.
+const (
+	BackupPostgresImage = "postgres:17-alpine"
+	BackupAWSCLIImage   = "amazon/aws-cli:2.27.50"
+)
+
+// RestoreLabel marks the restore Jobs of a gateway with the gateway's name.
+const RestoreLabel = "ai-gateway-litellm.agentic-layer.ai/restore-of"
+
+// Backup configures the backup CronJob and restore Job of a gateway.
+type Backup struct {
+	// Schedule is the CronJob schedule; empty disables scheduled backups.
+	Schedule string
+	// Retention is the number of dumps kept.
+	Retention int
+	// SecretName is the Secret holding the object storage settings.
+	SecretName string
+	// RestoreFrom is the dump to restore; empty means no restore.
+	RestoreFrom string
+}
+
+// ParseBackup returns the backup settings of the gateway name from its
+// annotations, or nil when neither a schedule nor a restore is requested.
+// Invalid values yield a *PhaseError.
+func ParseBackup(name string, annotations map[string]string) (*Backup, error) {
+	b := &Backup{
+		Schedule:    strings.TrimSpace(annotations[BackupScheduleAnnotation]),
+		Retention:   DefaultBackupRetention,
+		SecretName:  annotations[BackupSecretAnnotation],
+		RestoreFrom: strings.TrimSpace(annotations[RestoreAnnotation]),
+	}
+	if b.Schedule == "" && b.RestoreFrom == "" {
+		return nil, nil
+	}
+	if b.SecretName == "" {
+		b.SecretName = name + "-backup"
+	}
+	if b.Schedule != "" && !strings.HasPrefix(b.Schedule, "@") && len(strings.Fields(b.Schedule)) != 5 {
+		return nil, &PhaseError{Phase: BackupPhase, Err: fmt.Errorf(
+			"invalid %s annotation %q: must be a cron schedule such as \"0 2 * * *\"", BackupScheduleAnnotation, b.Schedule)}
+	}
+	if raw, ok := annotations[BackupRetentionAnnotation]; ok {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 {
+			return nil, &PhaseError{Phase: BackupPhase, Err: fmt.Errorf(
+				"invalid %s annotation %q: must be a positive number of backups", BackupRetentionAnnotation, raw)}
+		}
+		b.Retention = n
+	}
+	if strings.Contains(b.RestoreFrom, "/") {
+		return nil, &PhaseError{Phase: BackupPhase, Err: fmt.Errorf(
+			"invalid %s annotation %q: must name a dump under %s", RestoreAnnotation, b.RestoreFrom, BackupURIEnvVar)}
+	}
+	return b, nil
+}
+
+// RestoreStatus is the state of the restore Job requested by a Backup.
+type RestoreStatus struct {
+	// Job is the restore Job, nil when no restore is requested or while it
+	// waits for the gateway to stop.
+	Job *batchv1.Job
+	// Waiting is set while the restore Job is held back until no gateway
+	// pod writes to the database anymore.
+	Waiting bool
+	// Complete and Failed mirror the Job's terminal conditions.
+	Complete, Failed bool
+}
+
+// RestoreJobName returns the name of the Job restoring b.RestoreFrom into
+// the database of the gateway name.
+func RestoreJobName(name string, b *Backup) string {
+	return fmt.Sprintf("%s-restore-%s", name, hashYAML(b.RestoreFrom)[:8])
+}
+
+// Restoring reports whether the gateway name in namespace has a restore
+// pending or running: b names a dump whose restore Job has not finished.
+// The gateway runs no pods meanwhile, so pg_restore does not race writes of
+// the proxy.
+func Restoring(ctx context.Context, c client.Reader, namespace, name string, b *Backup) (bool, error) {
+	if b == nil || b.RestoreFrom == "" {
+		return false, nil
+	}
+	var job batchv1.Job
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: RestoreJobName(name, b)}, &job); err != nil {
+		return apierrors.IsNotFound(err), client.IgnoreNotFound(err)
+	}
+	for _, cond := range job.Status.Conditions {
+		if cond.Status == corev1.ConditionTrue && (cond.Type == batchv1.JobComplete || cond.Type == batchv1.JobFailed) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// ReconcileBackup applies the backup CronJob <name>-backup of w when b has a
+// schedule, and deletes it otherwise. When b names a dump to restore it
+// creates the restore Job <name>-restore-<hash> once and reports its state;
+// restore Jobs for other dumps are deleted. The restore Job is only created
+// once the gateway Deployment has no pods left; see Restoring. databaseURL
+// is the gateway's DATABASE_URL env var, passed to pg_dump and pg_restore.
+// b may be nil.
+//
+// On failure, the returned error is a *PhaseError tagged with which step failed.
+func ReconcileBackup(ctx context.Context, c client.Client, scheme *runtime.Scheme, w GatewayWorkload, b *Backup, databaseURL corev1.EnvVar) (*RestoreStatus, error) {
+	ownerRef, err := controllerReference(w.Owner, scheme)
+	if err != nil {
+		return nil, &PhaseError{Phase: "CronJob", Err: err}
+	}
+
+	cronJobName := w.Name + "-backup"
+	if b != nil && b.Schedule != "" {
+		cronJob := BuildBackupCronJob(w, ownerRef, b, databaseURL)
+		existing := &batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: cronJobName, Namespace: w.Namespace}}
+		if err := apply(ctx, c, w, cronJob, existing, "CronJob"); err != nil {
+			return nil, &PhaseError{Phase: "CronJob", Err: err}
+		}
+	} else if err := deleteOwned(ctx, c, w, &batchv1.CronJob{}, cronJobName); err != nil {
+		return nil, &PhaseError{Phase: "CronJob", Err: err}
+	}
+
+	status := &RestoreStatus{}
+	restoreName := ""
+	if b != nil && b.RestoreFrom != "" {
+		restoreName = RestoreJobName(w.Name, b)
+	}
+	var jobs batchv1.JobList
+	if err := c.List(ctx, &jobs, client.InNamespace(w.Namespace), client.MatchingLabels{RestoreLabel: w.Name}); err != nil {
+		return nil, &PhaseError{Phase: "Job", Err: err}
+	}
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		if job.Name == restoreName {
+			status.Job = job
+			continue
+		}
+		if err := deleteOwned(ctx, c, w, &batchv1.Job{}, job.Name); err != nil {
+			return nil, &PhaseError{Phase: "Job", Err: err}
+		}
+	}
+	if restoreName == "" {
+		return status, nil
+	}
+	if status.Job == nil {
+		var deployment appsv1.Deployment
+		err := c.Get(ctx, types.NamespacedName{Namespace: w.Namespace, Name: w.Name}, &deployment)
+		if client.IgnoreNotFound(err) != nil {
+			return nil, &PhaseError{Phase: "Job", Err: err}
+		}
+		if err == nil && deployment.Status.Replicas > 0 {
+			status.Waiting = true
+			return status, nil
+		}
+		// The pod template of a Job is immutable, so the Job is applied once
+		// and left alone afterwards.
+		job := BuildRestoreJob(w, ownerRef, b, databaseURL, restoreName)
+		existing := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: restoreName, Namespace: w.Namespace}}
+		if err := apply(ctx, c, w, job, existing, "Job"); err != nil {
+			return nil, &PhaseError{Phase: "Job", Err: err}
+		}
+		status.Job = existing
+		return status, nil
+	}
+	for _, cond := range status.Job.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case batchv1.JobComplete:
+			status.Complete = true
+		case batchv1.JobFailed:
+			status.Failed = true
+		}
+	}
+	return status, nil
+}
+
+// BuildBackupCronJob returns the desired state of the backup CronJob. Its
+// pods dump the database with pg_dump into a shared volume, upload the dump
+// as litellm-<timestamp>.dump and delete all but the newest b.Retention
+// dumps.
+func BuildBackupCronJob(w GatewayWorkload, ownerRef *metav1ac.OwnerReferenceApplyConfiguration, b *Backup, databaseURL corev1.EnvVar) *batchv1ac.CronJobApplyConfiguration {
+	name := w.Name + "-backup"
+	dump := corev1ac.Container().
+		WithName("dump").
+		WithImage(w.mirror(BackupPostgresImage)).
+		WithEnv(envVarApplyConfiguration(databaseURL)).
+		WithCommand("sh", "-c", `pg_dump --format=custom --file=/backup/litellm.dump "$DATABASE_URL"`).
+		WithVolumeMounts(corev1ac.VolumeMount().WithName("backup").WithMountPath("/backup"))
+	upload := corev1ac.Container().
+		WithName("upload").
+		WithImage(w.mirror(BackupAWSCLIImage)).
+		WithEnv(corev1ac.EnvVar().WithName("BACKUP_RETENTION").WithValue(strconv.Itoa(b.Retention))).
+		WithEnvFrom(corev1ac.EnvFromSource().WithSecretRef(corev1ac.SecretEnvSource().WithName(b.SecretName))).
+		WithCommand("bash", "-c", backupScript).
+		WithVolumeMounts(corev1ac.VolumeMount().WithName("backup").WithMountPath("/backup").WithReadOnly(true))
+
+	return batchv1ac.CronJob(name, w.Namespace).
+		WithOwnerReferences(ownerRef).
+		WithLabels(BuildResourceLabels(w.Name, w.CommonMetadata)).
+		WithAnnotations(BuildResourceAnnotations(w.CommonMetadata)).
+		WithSpec(batchv1ac.CronJobSpec().
+			WithSchedule(b.Schedule).
+			WithConcurrencyPolicy(batchv1.ForbidConcurrent).
+			WithSuccessfulJobsHistoryLimit(3).
+			WithFailedJobsHistoryLimit(1).
+			WithJobTemplate(batchv1ac.JobTemplateSpec().
+				WithLabels(map[string]string{ManagedByLabel: FieldManager}).
+				WithSpec(batchv1ac.JobSpec().
+					WithBackoffLimit(2).
+					WithTemplate(jobPodTemplate(name, dump, upload)))))
+}
+
+// BuildRestoreJob returns the desired state of the restore Job name. Its pod
+// downloads b.RestoreFrom and loads it with pg_restore --clean, replacing the
+// tables the dump contains.
+func BuildRestoreJob(w GatewayWorkload, ownerRef *metav1ac.OwnerReferenceApplyConfiguration, b *Backup, databaseURL corev1.EnvVar, name string) *batchv1ac.JobApplyConfiguration {
+	download := corev1ac.Container().
+		WithName("download").
+		WithImage(w.mirror(BackupAWSCLIImage)).
+		WithEnv(corev1ac.EnvVar().WithName("RESTORE_FROM").WithValue(b.RestoreFrom)).
+		WithEnvFrom(corev1ac.EnvFromSource().WithSecretRef(corev1ac.SecretEnvSource().WithName(b.SecretName))).
+		WithCommand("bash", "-c", `aws s3 cp "${BACKUP_S3_URI%/}/$RESTORE_FROM" /backup/litellm.dump`).
+		WithVolumeMounts(corev1ac.VolumeMount().WithName("backup").WithMountPath("/backup"))
+	restore := corev1ac.Container().
+		WithName("restore").
+		WithImage(w.mirror(BackupPostgresImage)).
+		WithEnv(envVarApplyConfiguration(databaseURL)).
+		WithCommand("sh", "-c", `pg_restore --clean --if-exists --no-owner --dbname="$DATABASE_URL" /backup/litellm.dump`).
+		WithVolumeMounts(corev1ac.VolumeMount().WithName("backup").WithMountPath("/backup").WithReadOnly(true))
+
+	labels := BuildResourceLabels(w.Name, w.CommonMetadata)
+	labels[RestoreLabel] = w.Name
+	return batchv1ac.Job(name, w.Namespace).
+		WithOwnerReferences(ownerRef).
+		WithLabels(labels).
+		WithAnnotations(BuildResourceAnnotations(w.CommonMetadata)).
+		WithAnnotations(map[string]string{RestoreAnnotation: b.RestoreFrom}).
+		WithSpec(batchv1ac.JobSpec().
+			WithBackoffLimit(2).
+			WithTemplate(jobPodTemplate(name, download, restore)))
+}
+
+// backupScript uploads the dump and prunes old ones. Dump names sort by
+// time, so everything after the newest $BACKUP_RETENTION entries goes.
+const backupScript = `set -euo pipefail
+base="${BACKUP_S3_URI%/}"
+aws s3 cp /backup/litellm.dump "$base/litellm-$(date -u +%Y%m%dT%H%M%SZ).dump"
+aws s3 ls "$base/" | while read -r _ _ _ key; do
+  case "$key" in litellm-*.dump) echo "$key" ;; esac
+done | sort -r | tail -n "+$((BACKUP_RETENTION + 1))" | while read -r key; do
+  aws s3 rm "$base/$key"
+done
+`
+
+// jobPodTemplate runs first as an init container and second as the main
+// container, sharing an emptyDir mounted at /backup. The pods carry their
+// own app label so the gateway's Service never selects them.
+func jobPodTemplate(name string, first, second *corev1ac.ContainerApplyConfiguration) *corev1ac.PodTemplateSpecApplyConfiguration {
+	return corev1ac.PodTemplateSpec().
+		WithLabels(map[string]string{"app": name}).
+		WithSpec(corev1ac.PodSpec().
+			WithRestartPolicy(corev1.RestartPolicyNever).
+			WithInitContainers(first).
+			WithContainers(second).
+			WithVolumes(corev1ac.Volume().WithName("backup").WithEmptyDir(corev1ac.EmptyDirVolumeSource())))
+}
+
+// deleteOwned deletes the object name of obj's kind when w.Owner controls it.
+func deleteOwned(ctx context.Context, c client.Client, w GatewayWorkload, obj client.Object, name string) error {
+	if err := c.Get(ctx, client.ObjectKey{Namespace: w.Namespace, Name: name}, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if !metav1.IsControlledBy(obj, w.Owner) || w.DryRun {
+		return nil
+	}
+	return client.IgnoreNotFound(c.Delete(ctx, obj, client.PropagationPolicy(metav1.DeletePropagationBackground)))
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestParseBackup(t *testing.T) {
+	for _, tc := range []struct {
+		annotations map[string]string
+		want        *Backup
+		wantErr     bool
+	}{
+		{annotations: nil},
+		{annotations: map[string]string{BackupRetentionAnnotation: "3"}},
+		{
+			annotations: map[string]string{BackupScheduleAnnotation: "0 2 * * *"},
+			want:        &Backup{Schedule: "0 2 * * *", Retention: DefaultBackupRetention, SecretName: "gw-backup"},
+		},
+		{
+			annotations: map[string]string{BackupScheduleAnnotation: "@daily", BackupRetentionAnnotation: "30", BackupSecretAnnotation: "s3"},
+			want:        &Backup{Schedule: "@daily", Retention: 30, SecretName: "s3"},
+		},
+		{
+			annotations: map[string]string{RestoreAnnotation: "litellm-20261017T020000Z.dump"},
+			want:        &Backup{Retention: DefaultBackupRetention, SecretName: "gw-backup", RestoreFrom: "litellm-20261017T020000Z.dump"},
+		},
+		{annotations: map[string]string{BackupScheduleAnnotation: "daily"}, wantErr: true},
+		{annotations: map[string]string{BackupScheduleAnnotation: "@daily", BackupRetentionAnnotation: "0"}, wantErr: true},
+		{annotations: map[string]string{RestoreAnnotation: "../other/litellm.dump"}, wantErr: true},
+	} {
+		got, err := ParseBackup("gw", tc.annotations)
+		if tc.wantErr {
+			var pe *PhaseError
+			if !errors.As(err, &pe) || pe.Phase != BackupPhase {
+				t.Errorf("%v: want a %s PhaseError, got %v", tc.annotations, BackupPhase, err)
+			}
+			continue
+		}
+		if err != nil || (got == nil) != (tc.want == nil) || (got != nil && *got != *tc.want) {
+			t.Errorf("%v: got %+v, %v, want %+v", tc.annotations, got, err, tc.want)
+		}
+	}
+}
+
+func TestReconcileBackup(t *testing.T) {
+	s := workloadScheme(t)
+	owner := newOwner("gw", "default")
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "default"},
+		Status: appsv1.DeploymentStatus{Replicas: 1}}
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(owner, deployment).WithStatusSubresource(deployment).Build()
+	ctx := context.Background()
+	w := GatewayWorkload{Name: "gw", Namespace: "default", Owner: owner}
+	databaseURL := corev1.EnvVar{Name: DatabaseURLEnvVar, Value: "postgresql://litellm@db/litellm"}
+	b := &Backup{Schedule: "0 2 * * *", Retention: 7, SecretName: "gw-backup", RestoreFrom: "litellm-1.dump"}
+
+	if restoring, err := Restoring(ctx, c, "default", "gw", b); err != nil || !restoring {
+		t.Fatalf("Restoring before the Job: got %v, %v", restoring, err)
+	}
+	// The restore waits for the gateway pods to stop.
+	status, err := ReconcileBackup(ctx, c, s, w, b, databaseURL)
+	if err != nil {
+		t.Fatalf("ReconcileBackup: %v", err)
+	}
+	if !status.Waiting || status.Job != nil {
+		t.Fatalf("with gateway pods: got %+v, want a waiting restore", status)
+	}
+	deployment.Status.Replicas = 0
+	if err := c.Status().Update(ctx, deployment); err != nil {
+		t.Fatalf("update Deployment status: %v", err)
+	}
+
+	status, err = ReconcileBackup(ctx, c, s, w, b, databaseURL)
+	if err != nil {
+		t.Fatalf("ReconcileBackup: %v", err)
+	}
+	if status.Job == nil || status.Waiting || status.Complete || status.Failed {
+		t.Fatalf("first reconcile: got %+v, want a running restore", status)
+	}
+	var cronJob batchv1.CronJob
+	if err := c.Get(ctx, types.NamespacedName{Name: "gw-backup", Namespace: "default"}, &cronJob); err != nil {
+		t.Fatalf("get CronJob: %v", err)
+	}
+	pod := cronJob.Spec.JobTemplate.Spec.Template
+	if cronJob.Spec.Schedule != "0 2 * * *" || pod.Labels["app"] == "gw" ||
+		pod.Spec.InitContainers[0].Env[0] != databaseURL ||
+		pod.Spec.Containers[0].EnvFrom[0].SecretRef.Name != "gw-backup" {
+		t.Errorf("unexpected CronJob: %+v", cronJob.Spec)
+	}
+
+	// The restore Job reports completion and is not recreated.
+	var jobs batchv1.JobList
+	if err := c.List(ctx, &jobs, client.MatchingLabels{RestoreLabel: "gw"}); err != nil || len(jobs.Items) != 1 {
+		t.Fatalf("restore Jobs: %d, %v", len(jobs.Items), err)
+	}
+	job := jobs.Items[0]
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+	if err := c.Status().Update(ctx, &job); err != nil {
+		t.Fatalf("update Job status: %v", err)
+	}
+	if status, err = ReconcileBackup(ctx, c, s, w, b, databaseURL); err != nil || !status.Complete {
+		t.Fatalf("after completion: got %+v, %v", status, err)
+	}
+	if restoring, err := Restoring(ctx, c, "default", "gw", b); err != nil || restoring {
+		t.Errorf("Restoring after completion: got %v, %v", restoring, err)
+	}
+
+	// Dropping the schedule and the restore removes both.
+	if _, err = ReconcileBackup(ctx, c, s, w, nil, databaseURL); err != nil {
+		t.Fatalf("ReconcileBackup: %v", err)
+	}
+	if err := c.Get(ctx, types.NamespacedName{Name: "gw-backup", Namespace: "default"}, &cronJob); err == nil {
+		t.Errorf("CronJob still present after the schedule was removed")
+	}
+	if err := c.List(ctx, &jobs, client.MatchingLabels{RestoreLabel: "gw"}); err != nil || len(jobs.Items) != 0 {
+		t.Errorf("restore Jobs after the restore was removed: %d, %v", len(jobs.Items), err)
+	}
+}
@@ -0,0 +1,371 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	appsv1ac "k8s.io/client-go/applyconfigurations/apps/v1"
+	metav1ac "k8s.io/client-go/applyconfigurations/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// RolloutStrategyAnnotation selects how spec and config changes reach a
+// gateway's pods: "RollingUpdate" (the default) updates the Deployment in
+// place, "BlueGreen" brings up a second Deployment and switches the Service
+// over once it is fully rolled out, and "ArgoRollouts" hands the pods to an
+// Argo Rollout with analysed canary steps; see ParseArgoRollout.
+const RolloutStrategyAnnotation = "ai-gateway-litellm.agentic-layer.ai/rollout-strategy"
+
+// BlueGreenSoakAnnotation sets how long the previous color keeps running
+// after a blue/green switch, so traffic can be switched back by hand.
+const BlueGreenSoakAnnotation = "ai-gateway-litellm.agentic-layer.ai/blue-green-soak"
+
+// Values of RolloutStrategyAnnotation.
+const (
+	RolloutStrategyRollingUpdate = "RollingUpdate"
+	RolloutStrategyBlueGreen     = "BlueGreen"
+	RolloutStrategyArgoRollouts  = "ArgoRollouts"
+)
+
+// RolloutStrategyPhase tags rollout-strategy annotation validation
+// failures. The values come straight from user input, so callers treat them
+// as permanent errors.
+const RolloutStrategyPhase = "RolloutStrategy"
+
+// DefaultBlueGreenSoak is the soak time when BlueGreenSoakAnnotation is unset.
+const DefaultBlueGreenSoak = 10 * time.Minute
+
+// ColorLabel tells the pods of the two blue/green Deployments apart; the
+// Service selects the active color through it.
+const ColorLabel = "ai-gateway-litellm.agentic-layer.ai/color"
+
+// Blue/green colors.
+const (
+	ColorBlue  = "blue"
+	ColorGreen = "green"
+)
+
+const (
+	// templateHashAnnotation records on a color Deployment the pod template
+	// it was last applied with, so the active color can be compared with the
+	// current spec without re-reading its pods.
+	templateHashAnnotation = "ai-gateway-litellm.agentic-layer.ai/template-hash"
+	// switchedAtAnnotation records on the Service when it was last switched
+	// to another color; the soak time of the previous color counts from it.
+	switchedAtAnnotation = "ai-gateway-litellm.agentic-layer.ai/switched-at"
+)
+
+// BlueGreen configures a blue/green rollout of a GatewayWorkload.
+type BlueGreen struct {
+	// Soak is how long the previous color keeps its replicas after a switch.
+	Soak time.Duration
+}
+
+// ParseRolloutStrategy returns the blue/green settings requested via
+// RolloutStrategyAnnotation and BlueGreenSoakAnnotation, or nil for any
+// other strategy. Invalid values yield a *PhaseError.
+func ParseRolloutStrategy(annotations map[string]string) (*BlueGreen, error) {
+	switch strategy := annotations[RolloutStrategyAnnotation]; strategy {
+	case "", RolloutStrategyRollingUpdate, RolloutStrategyArgoRollouts:
+		return nil, nil
+	case RolloutStrategyBlueGreen:
+	default:
+		return nil, &PhaseError{Phase: RolloutStrategyPhase, Err: fmt.Errorf(
+			"invalid %s annotation %q: must be %s, %s or %s", RolloutStrategyAnnotation, strategy,
+			RolloutStrategyRollingUpdate, RolloutStrategyBlueGreen, RolloutStrategyArgoRollouts)}
+	}
+	bg := &BlueGreen{Soak: DefaultBlueGreenSoak}
+	if raw, ok := annotations[BlueGreenSoakAnnotation]; ok {
+		d, err := time.ParseDuration(raw)
+		if err != nil || d < 0 {
+			return nil, &PhaseError{Phase: RolloutStrategyPhase, Err: fmt.Errorf(
+				"invalid %s annotation %q: must be a non-negative duration such as 10m", BlueGreenSoakAnnotation, raw)}
+		}
+		bg.Soak = d
+	}
+	return bg, nil
+}
+
+// BlueGreenStatus is the outcome of ReconcileBlueGreenWorkload.
+type BlueGreenStatus struct {
+	// Serving is the Deployment the Service routes to: the active color, or
+	// the Deployment of a rolling update while the first color comes up. Nil
+	// only for a new gateway whose first color is not rolled out yet.
+	Serving *appsv1.Deployment
+	// Pending is the color being brought up with the current spec, or nil
+	// when Serving already runs it.
+	Pending *appsv1.Deployment
+	// RequeueAfter is when the soak time of the previous color ends.
+	RequeueAfter time.Duration
+}
+
+// ReconcileBlueGreenWorkload is ReconcileWorkload for the BlueGreen rollout
+// strategy. The gateway runs as two Deployments, <name>-blue and
+// <name>-green, each mounting its own copy of the config. A change to the
+// pod template is applied to the idle color only; once that color is fully
+// rolled out the Service selector is switched to it, and after w.BlueGreen.Soak
+// the previous color is scaled to zero. The Deployment of a preceding
+// rolling update is deleted after the first switch. w.BlueGreen must be set.
+//
+// On failure, the returned error is a *PhaseError tagged with which step failed.
+func ReconcileBlueGreenWorkload(ctx context.Context, c client.Client, scheme *runtime.Scheme, w GatewayWorkload) (*BlueGreenStatus, error) {
+	configHash := hashYAML(w.ConfigYAML)
+
+	if err := reconcileConfigMap(ctx, c, scheme, w); err != nil {
+		return nil, &PhaseError{Phase: "ConfigMap", Err: err}
+	}
+	secretHash, err := computeSecretHash(ctx, c, w.Namespace, ReferencedSecretNames(w.Env, w.EnvFrom))
+	if err != nil {
+		return nil, &PhaseError{Phase: "Secret", Err: err}
+	}
+	ownerRef, err := controllerReference(w.Owner, scheme)
+	if err != nil {
+		return nil, &PhaseError{Phase: "Deployment", Err: err}
+	}
+	deployment, err := buildDeployment(w, ownerRef, configHash, secretHash)
+	if err != nil {
+		return nil, &PhaseError{Phase: "Deployment", Err: err}
+	}
+	templateHash, err := podTemplateHash(deployment)
+	if err != nil {
+		return nil, &PhaseError{Phase: "Deployment", Err: err}
+	}
+
+	var svc corev1.Service
+	if err := getOwned(ctx, c, w, client.ObjectKey{Namespace: w.Namespace, Name: w.Name}, &svc); err != nil {
+		return nil, &PhaseError{Phase: "Service", Err: err}
+	}
+	active := svc.Spec.Selector[ColorLabel]
+	switchedAt := svc.Annotations[switchedAtAnnotation]
+	deployments := map[string]*appsv1.Deployment{}
+	for _, color := range []string{ColorBlue, ColorGreen, ""} {
+		var d appsv1.Deployment
+		if err := getOwned(ctx, c, w, client.ObjectKey{Namespace: w.Namespace, Name: colorName(w.Name, color)}, &d); err != nil {
+			return nil, &PhaseError{Phase: "Deployment", Err: err}
+		}
+		if d.ResourceVersion != "" {
+			deployments[color] = &d
+		}
+	}
+	legacy := deployments[""]
+
+	status := &BlueGreenStatus{Serving: deployments[active]}
+	if status.Serving == nil {
+		active = ""
+		status.Serving = legacy
+	}
+	target := active
+	if active == "" || status.Serving.Annotations[templateHashAnnotation] != templateHash {
+		target = otherColor(active)
+	}
+
+	// The new color starts with as many replicas as the one serving, so the
+	// switch does not change capacity.
+	replicas := int32(1)
+	if status.Serving != nil && status.Serving.Spec.Replicas != nil {
+		replicas = *status.Serving.Spec.Replicas
+	}
+	if target != active {
+		colorW := w
+		colorW.Name = colorName(w.Name, target)
+		if err := reconcileConfigMap(ctx, c, scheme, colorW); err != nil {
+			return nil, &PhaseError{Phase: "ConfigMap", Err: err}
+		}
+		pending, err := applyColorDeployment(ctx, c, w, ownerRef, target, configHash, secretHash, templateHash, replicas)
+		if err != nil {
+			return nil, &PhaseError{Phase: "Deployment", Err: err}
+		}
+		if rolledOut, _ := IsDeploymentRolledOut(pending); !rolledOut || w.DryRun {
+			status.Pending = pending
+			if err := applyColorService(ctx, c, w, ownerRef, active, switchedAt); err != nil {
+				return nil, &PhaseError{Phase: "Service", Err: err}
+			}
+			return status, nil
+		}
+		// Switch.
+		active, switchedAt = target, time.Now().UTC().Format(time.RFC3339)
+		status.Serving = pending
+	} else if _, err := applyColorDeployment(ctx, c, w, ownerRef, active, configHash, secretHash, templateHash, replicas); err != nil {
+		return nil, &PhaseError{Phase: "Deployment", Err: err}
+	}
+	if err := applyColorService(ctx, c, w, ownerRef, active, switchedAt); err != nil {
+		return nil, &PhaseError{Phase: "Service", Err: err}
+	}
+
+	// The Service no longer selects the pods of a rolling update.
+	if legacy != nil && !w.DryRun {
+		if err := c.Delete(ctx, legacy); client.IgnoreNotFound(err) != nil {
+			return nil, &PhaseError{Phase: "Deployment", Err: err}
+		}
+	}
+	status.RequeueAfter, err = scaleDownAfterSoak(ctx, c, w, deployments[otherColor(active)], switchedAt)
+	if err != nil {
+		return nil, &PhaseError{Phase: "Deployment", Err: err}
+	}
+	return status, nil
+}
+
+// scaleDownAfterSoak scales previous, the color the Service switched away
+// from at switchedAt, to zero once w.BlueGreen.Soak has passed. Until then
+// it returns the remaining soak time.
+func scaleDownAfterSoak(ctx context.Context, c client.Client, w GatewayWorkload, previous *appsv1.Deployment, switchedAt string) (time.Duration, error) {
+	if previous == nil || ptr.Deref(previous.Spec.Replicas, 1) == 0 {
+		return 0, nil
+	}
+	if t, err := time.Parse(time.RFC3339, switchedAt); err == nil {
+		if remaining := w.BlueGreen.Soak - time.Since(t); remaining > 0 {
+			return remaining, nil
+		}
+	}
+	if w.DryRun {
+		return 0, nil
+	}
+	patch := client.MergeFrom(previous.DeepCopy())
+	previous.Spec.Replicas = ptr.To[int32](0)
+	if err := c.Patch(ctx, previous, patch); err != nil {
+		return 0, fmt.Errorf("scaling down %s: %w", previous.Name, err)
+	}
+	return 0, nil
+}
+
+// DeleteBlueGreenWorkload removes the color Deployments and ConfigMaps left
+// behind when a gateway moves back to rolling updates. Callers invoke it
+// once the rolling-update Deployment is rolled out, which the Service then
+// already selects.
+func DeleteBlueGreenWorkload(ctx context.Context, c client.Client, w GatewayWorkload) error {
+	for _, color := range []string{ColorBlue, ColorGreen} {
+		name := colorName(w.Name, color)
+		for _, obj := range []client.Object{&appsv1.Deployment{}, &corev1.ConfigMap{}} {
+			key := client.ObjectKey{Namespace: w.Namespace, Name: name}
+			if _, ok := obj.(*corev1.ConfigMap); ok {
+				key.Name = name + "-config"
+			}
+			if err := c.Get(ctx, key, obj); err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return err
+			}
+			if !metav1.IsControlledBy(obj, w.Owner) || w.DryRun {
+				continue
+			}
+			if err := c.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// applyColorDeployment applies the Deployment of one color. It differs from
+// BuildDeployment in its name, the color in selector and pod labels, the
+// color's config ConfigMap and an explicit replica count: the idle color is
+// scaled independently of the serving one.
+func applyColorDeployment(ctx context.Context, c client.Client, w GatewayWorkload, ownerRef *metav1ac.OwnerReferenceApplyConfiguration,
+	color, configHash, secretHash, templateHash string, replicas int32) (*appsv1.Deployment, error) {
+	name := colorName(w.Name, color)
+	d, err := buildDeployment(w, ownerRef, configHash, secretHash)
+	if err != nil {
+		return nil, err
+	}
+	d.WithName(name).
+		WithLabels(map[string]string{ColorLabel: color}).
+		WithAnnotations(map[string]string{templateHashAnnotation: templateHash})
+	d.Spec.WithReplicas(replicas)
+	d.Spec.Selector.WithMatchLabels(map[string]string{ColorLabel: color})
+	d.Spec.Template.WithLabels(map[string]string{ColorLabel: color})
+	for i := range d.Spec.Template.Spec.Volumes {
+		if v := &d.Spec.Template.Spec.Volumes[i]; v.ConfigMap != nil {
+			v.ConfigMap.WithName(name + "-config")
+		}
+	}
+	existing := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: w.Namespace}}
+	if err := apply(ctx, c, w, d, existing, "Deployment"); err != nil {
+		return nil, err
+	}
+	applied := &appsv1.Deployment{}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(existing), applied); err != nil {
+		if w.DryRun && apierrors.IsNotFound(err) {
+			return existing, nil
+		}
+		return nil, err
+	}
+	return applied, nil
+}
+
+// applyColorService applies the Service, selecting the pods of color. An
+// empty color selects every pod of the gateway, as for a rolling update.
+func applyColorService(ctx context.Context, c client.Client, w GatewayWorkload, ownerRef *metav1ac.OwnerReferenceApplyConfiguration, color, switchedAt string) error {
+	service, err := buildService(w, ownerRef)
+	if err != nil {
+		return err
+	}
+	if color != "" {
+		service.Spec.WithSelector(map[string]string{ColorLabel: color})
+	}
+	if switchedAt != "" {
+		service.WithAnnotations(map[string]string{switchedAtAnnotation: switchedAt})
+	}
+	existing := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: w.Name, Namespace: w.Namespace}}
+	return apply(ctx, c, w, service, existing, "Service")
+}
+
+// getOwned reads key into obj, leaving obj empty when it does not exist.
+// Like apply, it falls back to w.APIReader for objects the cache misses.
+func getOwned(ctx context.Context, c client.Client, w GatewayWorkload, key client.ObjectKey, obj client.Object) error {
+	err := c.Get(ctx, key, obj)
+	if apierrors.IsNotFound(err) && w.APIReader != nil {
+		err = w.APIReader.Get(ctx, key, obj)
+	}
+	return client.IgnoreNotFound(err)
+}
+
+// podTemplateHash hashes the pod template of d, the part of a Deployment a
+// blue/green rollout moves to the idle color.
+func podTemplateHash(d *appsv1ac.DeploymentApplyConfiguration) (string, error) {
+	raw, err := json.Marshal(d.Spec.Template)
+	if err != nil {
+		return "", err
+	}
+	return hashYAML(string(raw)), nil
+}
+
+// colorName is the name of the Deployment of color; the empty color is the
+// Deployment of a rolling update.
+func colorName(name, color string) string {
+	if color == "" {
+		return name
+	}
+	return name + "-" + color
+}
+
+func otherColor(color string) string {
+	if color == ColorBlue {
+		return ColorGreen
+	}
+	return ColorBlue
+}
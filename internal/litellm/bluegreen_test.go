@@ -0,0 +1,201 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestParseRolloutStrategy(t *testing.T) {
+	for _, tc := range []struct {
+		annotations map[string]string
+		want        *BlueGreen
+		wantErr     bool
+	}{
+		{annotations: nil},
+		{annotations: map[string]string{RolloutStrategyAnnotation: RolloutStrategyRollingUpdate}},
+		{annotations: map[string]string{RolloutStrategyAnnotation: RolloutStrategyBlueGreen}, want: &BlueGreen{Soak: DefaultBlueGreenSoak}},
+		{annotations: map[string]string{RolloutStrategyAnnotation: RolloutStrategyBlueGreen, BlueGreenSoakAnnotation: "1h"}, want: &BlueGreen{Soak: time.Hour}},
+		{annotations: map[string]string{RolloutStrategyAnnotation: "Canary"}, wantErr: true},
+		{annotations: map[string]string{RolloutStrategyAnnotation: RolloutStrategyBlueGreen, BlueGreenSoakAnnotation: "soon"}, wantErr: true},
+	} {
+		got, err := ParseRolloutStrategy(tc.annotations)
+		if tc.wantErr {
+			var pe *PhaseError
+			if !errors.As(err, &pe) || pe.Phase != RolloutStrategyPhase {
+				t.Errorf("%v: want a %s PhaseError, got %v", tc.annotations, RolloutStrategyPhase, err)
+			}
+			continue
+		}
+		if err != nil || (got == nil) != (tc.want == nil) || (got != nil && *got != *tc.want) {
+			t.Errorf("%v: got %+v, %v, want %+v", tc.annotations, got, err, tc.want)
+		}
+	}
+}
+
+// markRolledOut makes the deployment-controller report d as fully rolled out.
+func markRolledOut(t *testing.T, c client.Client, name string) {
+	t.Helper()
+	var d appsv1.Deployment
+	if err := c.Get(context.Background(), types.NamespacedName{Name: name, Namespace: "default"}, &d); err != nil {
+		t.Fatalf("get Deployment %s: %v", name, err)
+	}
+	replicas := ptr.Deref(d.Spec.Replicas, 1)
+	d.Status = appsv1.DeploymentStatus{
+		ObservedGeneration: d.Generation,
+		Replicas:           replicas,
+		UpdatedReplicas:    replicas,
+		AvailableReplicas:  replicas,
+	}
+	if err := c.Status().Update(context.Background(), &d); err != nil {
+		t.Fatalf("update Deployment %s status: %v", name, err)
+	}
+}
+
+func TestReconcileBlueGreenWorkload_SwitchesAfterRollout(t *testing.T) {
+	s := workloadScheme(t)
+	owner := newOwner("gw", "default")
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(owner).Build()
+	ctx := context.Background()
+	w := GatewayWorkload{
+		Name:          "gw",
+		Namespace:     "default",
+		Owner:         owner,
+		ContainerPort: 4000,
+		ServicePort:   4000,
+		ConfigYAML:    "model_list: []\n",
+		BlueGreen:     &BlueGreen{Soak: time.Hour},
+	}
+	selectedColor := func() string {
+		t.Helper()
+		var svc corev1.Service
+		if err := c.Get(ctx, types.NamespacedName{Name: "gw", Namespace: "default"}, &svc); err != nil {
+			t.Fatalf("get Service: %v", err)
+		}
+		return svc.Spec.Selector[ColorLabel]
+	}
+
+	// A new gateway starts on blue; the Service switches once blue is up.
+	status, err := ReconcileBlueGreenWorkload(ctx, c, s, w)
+	if err != nil {
+		t.Fatalf("ReconcileBlueGreenWorkload: %v", err)
+	}
+	if status.Serving != nil || status.Pending == nil || status.Pending.Name != "gw-blue" {
+		t.Fatalf("first reconcile: got %+v, want blue pending", status)
+	}
+	if status.Pending.Spec.Template.Spec.Volumes[0].ConfigMap.Name != "gw-blue-config" {
+		t.Errorf("blue must mount its own config, got %+v", status.Pending.Spec.Template.Spec.Volumes[0])
+	}
+	markRolledOut(t, c, "gw-blue")
+	if status, err = ReconcileBlueGreenWorkload(ctx, c, s, w); err != nil {
+		t.Fatalf("ReconcileBlueGreenWorkload: %v", err)
+	}
+	if status.Pending != nil || status.Serving.Name != "gw-blue" || selectedColor() != ColorBlue {
+		t.Fatalf("after blue rollout: got %+v selecting %q", status, selectedColor())
+	}
+
+	// A config change goes to green while blue keeps serving.
+	w.ConfigYAML = "model_list: [{model_name: gpt-4o}]\n"
+	if status, err = ReconcileBlueGreenWorkload(ctx, c, s, w); err != nil {
+		t.Fatalf("ReconcileBlueGreenWorkload: %v", err)
+	}
+	if status.Pending == nil || status.Pending.Name != "gw-green" || selectedColor() != ColorBlue {
+		t.Fatalf("config change: got %+v selecting %q, want green pending behind blue", status, selectedColor())
+	}
+	var blueConfig corev1.ConfigMap
+	if err := c.Get(ctx, types.NamespacedName{Name: "gw-blue-config", Namespace: "default"}, &blueConfig); err != nil {
+		t.Fatalf("get blue ConfigMap: %v", err)
+	}
+	if blueConfig.Data["config.yaml"] != "model_list: []\n" {
+		t.Errorf("blue config must stay untouched until the switch, got %q", blueConfig.Data["config.yaml"])
+	}
+
+	// Once green is up traffic moves; blue soaks before it is scaled down.
+	markRolledOut(t, c, "gw-green")
+	if status, err = ReconcileBlueGreenWorkload(ctx, c, s, w); err != nil {
+		t.Fatalf("ReconcileBlueGreenWorkload: %v", err)
+	}
+	if status.Serving.Name != "gw-green" || selectedColor() != ColorGreen || status.RequeueAfter <= 0 {
+		t.Fatalf("after green rollout: got %+v selecting %q", status, selectedColor())
+	}
+	w.BlueGreen.Soak = 0
+	if _, err = ReconcileBlueGreenWorkload(ctx, c, s, w); err != nil {
+		t.Fatalf("ReconcileBlueGreenWorkload: %v", err)
+	}
+	var blue appsv1.Deployment
+	if err := c.Get(ctx, types.NamespacedName{Name: "gw-blue", Namespace: "default"}, &blue); err != nil {
+		t.Fatalf("get blue Deployment: %v", err)
+	}
+	if ptr.Deref(blue.Spec.Replicas, 1) != 0 {
+		t.Errorf("blue must be scaled down after the soak time, got %d replicas", ptr.Deref(blue.Spec.Replicas, 1))
+	}
+
+	// Moving back to rolling updates removes both colors.
+	if err := DeleteBlueGreenWorkload(ctx, c, w); err != nil {
+		t.Fatalf("DeleteBlueGreenWorkload: %v", err)
+	}
+	for _, name := range []string{"gw-blue", "gw-green"} {
+		if err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: "default"}, &appsv1.Deployment{}); !apierrors.IsNotFound(err) {
+			t.Errorf("Deployment %s: want NotFound, got %v", name, err)
+		}
+	}
+}
+
+func TestReconcileBlueGreenWorkload_ReplacesRollingUpdate(t *testing.T) {
+	s := workloadScheme(t)
+	owner := newOwner("gw", "default")
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(owner).Build()
+	ctx := context.Background()
+	w := GatewayWorkload{
+		Name:          "gw",
+		Namespace:     "default",
+		Owner:         owner,
+		ContainerPort: 4000,
+		ServicePort:   4000,
+		ConfigYAML:    "model_list: []\n",
+	}
+	if err := ReconcileWorkload(ctx, c, s, w); err != nil {
+		t.Fatalf("ReconcileWorkload: %v", err)
+	}
+
+	w.BlueGreen = &BlueGreen{}
+	status, err := ReconcileBlueGreenWorkload(ctx, c, s, w)
+	if err != nil {
+		t.Fatalf("ReconcileBlueGreenWorkload: %v", err)
+	}
+	if status.Serving == nil || status.Serving.Name != "gw" || status.Pending == nil {
+		t.Fatalf("got %+v, want the rolling-update Deployment serving while blue comes up", status)
+	}
+	markRolledOut(t, c, "gw-blue")
+	if _, err := ReconcileBlueGreenWorkload(ctx, c, s, w); err != nil {
+		t.Fatalf("ReconcileBlueGreenWorkload: %v", err)
+	}
+	if err := c.Get(ctx, types.NamespacedName{Name: "gw", Namespace: "default"}, &appsv1.Deployment{}); !apierrors.IsNotFound(err) {
+		t.Errorf("rolling-update Deployment must be deleted after the switch, got %v", err)
+	}
+}
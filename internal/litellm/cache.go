@@ -0,0 +1,60 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// ManagedByLabel marks every Deployment and Service the operator applies, so
+// the manager cache can be restricted to them with ManagedSelector.
+const ManagedByLabel = "app.kubernetes.io/managed-by"
+
+// ManagedSelector selects the objects labelled with ManagedByLabel.
+func ManagedSelector() labels.Selector {
+	return labels.SelectorFromSet(labels.Set{ManagedByLabel: FieldManager})
+}
+
+// TrimForCache is a cache transform dropping the bulkiest metadata nobody in
+// the operator reads: the kubectl last-applied-configuration annotation and
+// managedFields. managedFields are kept while a legacy field manager still
+// owns fields, because upgradeManagedFields needs the full list to migrate
+// them; after the migration the next cache update trims them too.
+func TrimForCache(obj any) (any, error) {
+	accessor, ok := obj.(metav1.Object)
+	if !ok {
+		return obj, nil
+	}
+	if annotations := accessor.GetAnnotations(); annotations != nil {
+		delete(annotations, corev1.LastAppliedConfigAnnotation)
+	}
+	if !hasLegacyManagedFields(accessor.GetManagedFields()) {
+		accessor.SetManagedFields(nil)
+	}
+	return obj, nil
+}
+
+func hasLegacyManagedFields(entries []metav1.ManagedFieldsEntry) bool {
+	for _, e := range entries {
+		if legacyFieldManagers.Has(e.Manager) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestTrimForCache(t *testing.T) {
+	newService := func(managers ...string) *corev1.Service {
+		svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+			Name: "gw",
+			Annotations: map[string]string{
+				corev1.LastAppliedConfigAnnotation: `{"kind":"Service"}`,
+				"team":                             "core",
+			},
+		}}
+		for _, m := range managers {
+			svc.ManagedFields = append(svc.ManagedFields, metav1.ManagedFieldsEntry{Manager: m})
+		}
+		return svc
+	}
+
+	migrated := newService(FieldManager, "kubectl")
+	if _, err := TrimForCache(migrated); err != nil {
+		t.Fatalf("TrimForCache: %v", err)
+	}
+	if migrated.ManagedFields != nil {
+		t.Errorf("managedFields not trimmed: %v", migrated.ManagedFields)
+	}
+	if _, ok := migrated.Annotations[corev1.LastAppliedConfigAnnotation]; ok {
+		t.Errorf("last-applied annotation not trimmed")
+	}
+	if migrated.Annotations["team"] != "core" {
+		t.Errorf("unrelated annotation dropped: %v", migrated.Annotations)
+	}
+
+	legacy := newService("manager", "kubectl")
+	if _, err := TrimForCache(legacy); err != nil {
+		t.Fatalf("TrimForCache: %v", err)
+	}
+	if len(legacy.ManagedFields) != 2 {
+		t.Errorf("managedFields of unmigrated object must be kept, got %v", legacy.ManagedFields)
+	}
+}
+
+func TestManagedSelector_MatchesResourceLabels(t *testing.T) {
+	if !ManagedSelector().Matches(labels.Set(BuildResourceLabels("gw", nil))) {
+		t.Errorf("ManagedSelector %q does not match BuildResourceLabels", ManagedSelector())
+	}
+}
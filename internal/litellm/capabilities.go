@@ -0,0 +1,233 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Capability is an optional integration that needs CRDs the cluster may not
+// have installed.
+type Capability string
+
+// Capabilities the operator detects.
+const (
+	CapabilityServiceMonitor        Capability = "ServiceMonitor"
+	CapabilityHTTPRoute             Capability = "HTTPRoute"
+	CapabilityRoute                 Capability = "Route"
+	CapabilityVerticalPodAutoscaler Capability = "VerticalPodAutoscaler"
+	CapabilityKEDA                  Capability = "KEDA"
+	CapabilityArgoRollouts          Capability = "ArgoRollouts"
+	CapabilityFlagger               Capability = "Flagger"
+	CapabilityCloudNativePG         Capability = "CloudNativePG"
+	CapabilityInferencePool         Capability = "InferencePool"
+)
+
+// capabilityKinds are the kinds each capability needs. A capability with
+// anyKind set needs one of its kinds, otherwise all of them.
+var capabilityKinds = []struct {
+	capability Capability
+	kinds      []schema.GroupVersionKind
+	anyKind    bool
+}{
+	{capability: CapabilityServiceMonitor, kinds: []schema.GroupVersionKind{
+		{Group: "monitoring.coreos.com", Version: "v1", Kind: "ServiceMonitor"}}},
+	{capability: CapabilityHTTPRoute, kinds: []schema.GroupVersionKind{
+		{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "HTTPRoute"}}},
+	{capability: CapabilityRoute, kinds: []schema.GroupVersionKind{
+		{Group: "route.openshift.io", Version: "v1", Kind: "Route"}}},
+	{capability: CapabilityVerticalPodAutoscaler, kinds: []schema.GroupVersionKind{
+		{Group: "autoscaling.k8s.io", Version: "v1", Kind: "VerticalPodAutoscaler"}}},
+	{capability: CapabilityKEDA, kinds: []schema.GroupVersionKind{
+		{Group: "keda.sh", Version: "v1alpha1", Kind: "ScaledObject"}}},
+	{capability: CapabilityArgoRollouts, kinds: []schema.GroupVersionKind{ArgoRolloutGVK, ArgoAnalysisTemplateGVK}},
+	{capability: CapabilityFlagger, kinds: []schema.GroupVersionKind{FlaggerCanaryGVK, FlaggerMetricTemplateGVK}},
+	{capability: CapabilityCloudNativePG, kinds: []schema.GroupVersionKind{CNPGClusterGVK}},
+	{capability: CapabilityInferencePool, kinds: inferencePoolGVKs, anyKind: true},
+}
+
+// CapabilityDetector finds out which capabilities the cluster serves, through
+// the discovery API, when it starts and every Interval after. It is a
+// manager Runnable, run by every replica. Until the first detection, no
+// capability is reported missing, so reconcilers behave as without a
+// detector. It is safe for concurrent use.
+type CapabilityDetector struct {
+	Discovery discovery.DiscoveryInterface
+	// Interval is how often detection repeats. Zero detects once.
+	Interval time.Duration
+
+	mu       sync.RWMutex
+	served   map[Capability]bool
+	detected bool
+	once     sync.Once
+	changes  chan event.GenericEvent
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable: every
+// replica reconciles with its own view of the cluster.
+func (d *CapabilityDetector) NeedLeaderElection() bool { return false }
+
+// Start detects the capabilities, then again every Interval until ctx is
+// done. Failed detections are logged and keep the previous result.
+func (d *CapabilityDetector) Start(ctx context.Context) error {
+	log := logf.FromContext(ctx).WithName("capabilities")
+	if err := d.Detect(); err != nil {
+		log.Error(err, "Failed to detect capabilities")
+	}
+	log.Info("Detected capabilities", "served", d.Served())
+	if d.Interval <= 0 {
+		return nil
+	}
+	ticker := time.NewTicker(d.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			before := d.Served()
+			if err := d.Detect(); err != nil {
+				log.Error(err, "Failed to detect capabilities")
+			}
+			if after := d.Served(); !slices.Equal(before, after) {
+				log.Info("Capabilities changed", "served", after)
+			}
+		}
+	}
+}
+
+// Detect asks the discovery API for the kinds of every capability. A group
+// version the cluster does not serve makes its capabilities missing; any
+// other failure keeps their previous state and is returned. Capabilities
+// are only reported missing after a detection without failures. When a
+// later detection changes the result, an event is sent on Changes.
+func (d *CapabilityDetector) Detect() error {
+	kinds := map[schema.GroupVersion]map[string]bool{}
+	var errs []error
+	for _, spec := range capabilityKinds {
+		for _, gvk := range spec.kinds {
+			gv := gvk.GroupVersion()
+			if _, ok := kinds[gv]; ok {
+				continue
+			}
+			resources, err := d.Discovery.ServerResourcesForGroupVersion(gv.String())
+			if err != nil && !apierrors.IsNotFound(err) {
+				errs = append(errs, fmt.Errorf("discovering %s: %w", gv, err))
+				continue
+			}
+			kinds[gv] = map[string]bool{}
+			if resources != nil {
+				for _, resource := range resources.APIResources {
+					kinds[gv][resource.Kind] = true
+				}
+			}
+		}
+	}
+
+	d.mu.Lock()
+	served := map[Capability]bool{}
+	for _, spec := range capabilityKinds {
+		found, known := 0, true
+		for _, gvk := range spec.kinds {
+			gvKinds, ok := kinds[gvk.GroupVersion()]
+			known = known && ok
+			if gvKinds[gvk.Kind] {
+				found++
+			}
+		}
+		switch {
+		case spec.anyKind && found > 0:
+			served[spec.capability] = true
+		case !known:
+			served[spec.capability] = d.served[spec.capability]
+		case spec.anyKind:
+			served[spec.capability] = false
+		default:
+			served[spec.capability] = found == len(spec.kinds)
+		}
+	}
+	changed := d.detected && !maps.Equal(served, d.served)
+	d.served, d.detected = served, d.detected || len(errs) == 0
+	d.mu.Unlock()
+
+	if changed {
+		// A pending event already covers this change.
+		select {
+		case d.changesChannel() <- event.GenericEvent{}:
+		default:
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Served returns the capabilities the cluster serves, sorted.
+func (d *CapabilityDetector) Served() []Capability {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	var served []Capability
+	for capability, ok := range d.served {
+		if ok {
+			served = append(served, capability)
+		}
+	}
+	slices.Sort(served)
+	return served
+}
+
+// Missing returns those of capabilities the cluster does not serve. A nil
+// detector, or one that has not detected yet, reports none missing.
+func (d *CapabilityDetector) Missing(capabilities ...Capability) []Capability {
+	if d == nil {
+		return nil
+	}
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if !d.detected {
+		return nil
+	}
+	var missing []Capability
+	for _, capability := range capabilities {
+		if !d.served[capability] {
+			missing = append(missing, capability)
+		}
+	}
+	return missing
+}
+
+// Changes returns the channel an event is sent on whenever the served
+// capabilities change, for a controller to re-reconcile what depends on
+// them. Events carry no object and are coalesced while one is pending.
+func (d *CapabilityDetector) Changes() <-chan event.GenericEvent {
+	return d.changesChannel()
+}
+
+func (d *CapabilityDetector) changesChannel() chan event.GenericEvent {
+	d.once.Do(func() { d.changes = make(chan event.GenericEvent, 1) })
+	return d.changes
+}
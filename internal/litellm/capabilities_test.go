@@ -0,0 +1,110 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+func resourceList(groupVersion string, kinds ...string) *metav1.APIResourceList {
+	list := &metav1.APIResourceList{GroupVersion: groupVersion}
+	for _, kind := range kinds {
+		list.APIResources = append(list.APIResources, metav1.APIResource{Kind: kind})
+	}
+	return list
+}
+
+func TestCapabilityDetector(t *testing.T) {
+	fake := &clienttesting.Fake{Resources: []*metav1.APIResourceList{
+		resourceList("argoproj.io/v1alpha1", "Rollout", "AnalysisTemplate"),
+		resourceList("flagger.app/v1beta1", "Canary"),
+		resourceList("inference.networking.x-k8s.io/v1alpha2", "InferencePool"),
+		resourceList("monitoring.coreos.com/v1", "ServiceMonitor", "PodMonitor"),
+	}}
+	d := &CapabilityDetector{Discovery: &fakediscovery.FakeDiscovery{Fake: fake}}
+
+	if missing := d.Missing(CapabilityFlagger); missing != nil {
+		t.Errorf("before detection: missing = %v", missing)
+	}
+	if err := d.Detect(); err != nil {
+		t.Fatalf("Detect: %v", err)
+	}
+	want := []Capability{CapabilityArgoRollouts, CapabilityInferencePool, CapabilityServiceMonitor}
+	if served := d.Served(); !reflect.DeepEqual(served, want) {
+		t.Errorf("served = %v, want %v", served, want)
+	}
+	// Flagger lacks its MetricTemplate kind.
+	if missing := d.Missing(CapabilityArgoRollouts, CapabilityFlagger, CapabilityCloudNativePG); !reflect.DeepEqual(missing,
+		[]Capability{CapabilityFlagger, CapabilityCloudNativePG}) {
+		t.Errorf("missing = %v", missing)
+	}
+	select {
+	case <-d.Changes():
+		t.Error("the first detection must not send a change")
+	default:
+	}
+
+	// Installing CloudNativePG is a change.
+	fake.Resources = append(fake.Resources, resourceList("postgresql.cnpg.io/v1", "Cluster"))
+	if err := d.Detect(); err != nil {
+		t.Fatalf("Detect: %v", err)
+	}
+	if missing := d.Missing(CapabilityCloudNativePG); missing != nil {
+		t.Errorf("CloudNativePG still missing: %v", missing)
+	}
+	select {
+	case <-d.Changes():
+	default:
+		t.Error("a change must be sent")
+	}
+
+	// A failing discovery keeps the previous result.
+	fake.PrependReactor("get", "resource", func(clienttesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("apiserver unavailable")
+	})
+	if err := d.Detect(); err == nil {
+		t.Error("want the discovery error")
+	}
+	if missing := d.Missing(CapabilityCloudNativePG, CapabilityArgoRollouts); missing != nil {
+		t.Errorf("after a failed detection: missing = %v", missing)
+	}
+}
+
+func TestCapabilityDetector_FailedFirstDetection(t *testing.T) {
+	fake := &clienttesting.Fake{}
+	fake.PrependReactor("get", "resource", func(clienttesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("apiserver unavailable")
+	})
+	d := &CapabilityDetector{Discovery: &fakediscovery.FakeDiscovery{Fake: fake}}
+	if err := d.Detect(); err == nil {
+		t.Error("want the discovery error")
+	}
+	if missing := d.Missing(CapabilityFlagger); missing != nil {
+		t.Errorf("nothing may be reported missing before a detection succeeded, got %v", missing)
+	}
+	var nilDetector *CapabilityDetector
+	if missing := nilDetector.Missing(CapabilityFlagger); missing != nil {
+		t.Errorf("nil detector: missing = %v", missing)
+	}
+}
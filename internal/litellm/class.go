@@ -34,9 +34,16 @@ const AiGatewayClassDefaultAnnotation = "aigatewayclass.kubernetes.io/is-default
 // Returns (false, nil) if the gateway is not ours; returns an error only when listing
 // AiGatewayClasses fails so callers can requeue rather than silently drop the object.
 func IsAiGatewayOwnedByController(ctx context.Context, c client.Reader, gw *gatewayv1alpha1.AiGateway, controllerName string) (bool, error) {
+	cls, err := AiGatewayClassOf(ctx, c, gw, controllerName)
+	return cls != nil, err
+}
+
+// AiGatewayClassOf returns the AiGatewayClass of controllerName that claims
+// gw, as decided by IsAiGatewayOwnedByController, or nil when none does.
+func AiGatewayClassOf(ctx context.Context, c client.Reader, gw *gatewayv1alpha1.AiGateway, controllerName string) (*gatewayv1alpha1.AiGatewayClass, error) {
 	var classList gatewayv1alpha1.AiGatewayClassList
 	if err := c.List(ctx, &classList); err != nil {
-		return false, err
+		return nil, err
 	}
 
 	owned := make([]gatewayv1alpha1.AiGatewayClass, 0, len(classList.Items))
@@ -47,20 +54,20 @@ func IsAiGatewayOwnedByController(ctx context.Context, c client.Reader, gw *gate
 	}
 
 	if className := gw.Spec.AiGatewayClassName; className != "" {
-		for _, cls := range owned {
-			if cls.Name == className {
-				return true, nil
+		for i := range owned {
+			if owned[i].Name == className {
+				return &owned[i], nil
 			}
 		}
-		return false, nil
+		return nil, nil
 	}
 
-	for _, cls := range owned {
-		if cls.Annotations[AiGatewayClassDefaultAnnotation] == "true" {
-			return true, nil
+	for i := range owned {
+		if owned[i].Annotations[AiGatewayClassDefaultAnnotation] == "true" {
+			return &owned[i], nil
 		}
 	}
-	return false, nil
+	return nil, nil
 }
 
 // ToolGatewayClassDefaultAnnotation marks a ToolGatewayClass as the default class.
@@ -0,0 +1,117 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	litellmv1alpha1 "github.com/agentic-layer/ai-gateway-litellm/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ClassConfigAnnotation, set on an AiGatewayClass, names the
+// LiteLLMClassConfig holding the defaults of its gateways.
+const ClassConfigAnnotation = "ai-gateway-litellm.agentic-layer.ai/class-config"
+
+// ClassConfigPhase tags failures resolving the class config. A missing or
+// invalid class config is permanent.
+const ClassConfigPhase = "ClassConfig"
+
+// ResolveClassConfig returns the spec of the LiteLLMClassConfig the class
+// of gw names, or nil when gw has no class of controllerName or its class
+// names none. See ClassConfig.
+func ResolveClassConfig(ctx context.Context, c client.Reader, gw *gatewayv1alpha1.AiGateway, controllerName string) (*litellmv1alpha1.LiteLLMClassConfigSpec, error) {
+	cls, err := AiGatewayClassOf(ctx, c, gw, controllerName)
+	if err != nil || cls == nil {
+		return nil, err
+	}
+	return ClassConfig(ctx, c, cls)
+}
+
+// ClassConfig returns the spec of the LiteLLMClassConfig cls names in its
+// ClassConfigAnnotation, or nil without one. A missing or invalid class
+// config is a *PhaseError tagged ClassConfigPhase.
+func ClassConfig(ctx context.Context, c client.Reader, cls *gatewayv1alpha1.AiGatewayClass) (*litellmv1alpha1.LiteLLMClassConfigSpec, error) {
+	name := cls.Annotations[ClassConfigAnnotation]
+	if name == "" {
+		return nil, nil
+	}
+	var config litellmv1alpha1.LiteLLMClassConfig
+	if err := c.Get(ctx, types.NamespacedName{Name: name}, &config); err != nil {
+		if apierrors.IsNotFound(err) {
+			err = fmt.Errorf("LiteLLMClassConfig %s not found", name)
+		}
+		return nil, &PhaseError{Phase: ClassConfigPhase, Err: err}
+	}
+	if err := ValidateClassConfig(&config.Spec); err != nil {
+		return nil, &PhaseError{Phase: ClassConfigPhase, Err: fmt.Errorf("LiteLLMClassConfig %s: %w", name, err)}
+	}
+	return &config.Spec, nil
+}
+
+// ValidateClassConfig rejects a class config whose litellmSettings is not
+// an object or that lists an empty callback.
+func ValidateClassConfig(spec *litellmv1alpha1.LiteLLMClassConfigSpec) error {
+	if _, err := ClassConfigPatch(spec); err != nil {
+		return err
+	}
+	for _, callback := range spec.Callbacks {
+		if callback == "" {
+			return fmt.Errorf("callbacks must not be empty")
+		}
+	}
+	return nil
+}
+
+// ClassConfigPatch returns the litellmSettings of spec as a patch of the
+// generated config, to be applied with ApplyPatch before the gateway's own
+// patch, or nil when spec sets none.
+func ClassConfigPatch(spec *litellmv1alpha1.LiteLLMClassConfigSpec) (map[string]any, error) {
+	if spec == nil || spec.LiteLLMSettings == nil || len(spec.LiteLLMSettings.Raw) == 0 {
+		return nil, nil
+	}
+	var settings map[string]any
+	if err := json.Unmarshal(spec.LiteLLMSettings.Raw, &settings); err != nil {
+		return nil, fmt.Errorf("litellmSettings must be an object: %w", err)
+	}
+	if len(settings) == 0 {
+		return nil, nil
+	}
+	return map[string]any{"litellm_settings": settings}, nil
+}
+
+// ApplyClassConfig adds the callbacks of spec that cfg does not list yet to
+// its litellm_settings, and returns the patch of the litellmSettings of
+// spec; see ClassConfigPatch. spec must have been validated.
+func ApplyClassConfig(cfg *LiteLLMConfig, spec *litellmv1alpha1.LiteLLMClassConfigSpec) map[string]any {
+	if spec == nil {
+		return nil
+	}
+	for _, callback := range spec.Callbacks {
+		if !slices.Contains(cfg.LiteLLMSettings.Callbacks, callback) {
+			cfg.LiteLLMSettings.Callbacks = append(cfg.LiteLLMSettings.Callbacks, callback)
+		}
+	}
+	patch, _ := ClassConfigPatch(spec)
+	return patch
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	litellmv1alpha1 "github.com/agentic-layer/ai-gateway-litellm/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	metav1ac "k8s.io/client-go/applyconfigurations/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newClassConfig(name string, spec litellmv1alpha1.LiteLLMClassConfigSpec) *litellmv1alpha1.LiteLLMClassConfig {
+	return &litellmv1alpha1.LiteLLMClassConfig{ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: spec}
+}
+
+func TestClassConfig(t *testing.T) {
+	s := classScheme(t)
+	if err := litellmv1alpha1.AddToScheme(s); err != nil {
+		t.Fatalf("AddToScheme: %v", err)
+	}
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(
+		newClassConfig("platform", litellmv1alpha1.LiteLLMClassConfigSpec{Image: "litellm:custom"}),
+		newClassConfig("broken", litellmv1alpha1.LiteLLMClassConfigSpec{LiteLLMSettings: &runtime.RawExtension{Raw: []byte(`["drop_params"]`)}}),
+	).Build()
+
+	cls := newClass(testController, false)
+	if got, err := ClassConfig(context.Background(), c, cls); err != nil || got != nil {
+		t.Errorf("no annotation: got %+v, %v, want nil", got, err)
+	}
+	cls.Annotations = map[string]string{ClassConfigAnnotation: "platform"}
+	if got, err := ClassConfig(context.Background(), c, cls); err != nil || got == nil || got.Image != "litellm:custom" {
+		t.Errorf("platform: got %+v, %v", got, err)
+	}
+	for _, name := range []string{"missing", "broken"} {
+		cls.Annotations[ClassConfigAnnotation] = name
+		_, err := ClassConfig(context.Background(), c, cls)
+		var pe *PhaseError
+		if !errors.As(err, &pe) || pe.Phase != ClassConfigPhase || !strings.Contains(err.Error(), name) {
+			t.Errorf("%s: got %v, want a %s PhaseError naming it", name, err, ClassConfigPhase)
+		}
+	}
+}
+
+func TestValidateClassConfig(t *testing.T) {
+	for name, tc := range map[string]struct {
+		spec    litellmv1alpha1.LiteLLMClassConfigSpec
+		wantErr bool
+	}{
+		"empty":          {},
+		"settings":       {spec: litellmv1alpha1.LiteLLMClassConfigSpec{LiteLLMSettings: &runtime.RawExtension{Raw: []byte(`{"drop_params":true}`)}}},
+		"settings list":  {spec: litellmv1alpha1.LiteLLMClassConfigSpec{LiteLLMSettings: &runtime.RawExtension{Raw: []byte(`[]`)}}, wantErr: true},
+		"callbacks":      {spec: litellmv1alpha1.LiteLLMClassConfigSpec{Callbacks: []string{"langsmith"}}},
+		"empty callback": {spec: litellmv1alpha1.LiteLLMClassConfigSpec{Callbacks: []string{""}}, wantErr: true},
+	} {
+		if err := ValidateClassConfig(&tc.spec); (err != nil) != tc.wantErr {
+			t.Errorf("%s: got %v, wantErr %v", name, err, tc.wantErr)
+		}
+	}
+}
+
+func TestApplyClassConfig_LayersBeforeGatewayPatch(t *testing.T) {
+	cfg := LiteLLMConfig{LiteLLMSettings: LiteLLMSettings{RequestTimeout: 600, Callbacks: []string{"otel", "prometheus"}}}
+	classPatch := ApplyClassConfig(&cfg, &litellmv1alpha1.LiteLLMClassConfigSpec{
+		LiteLLMSettings: &runtime.RawExtension{Raw: []byte(`{"request_timeout":1200,"drop_params":true}`)},
+		Callbacks:       []string{"otel", "langsmith"},
+	})
+	gatewayPatch := map[string]any{"litellm_settings": map[string]any{"drop_params": false}}
+
+	got, err := RenderConfigWithPatch(cfg, classPatch, gatewayPatch)
+	if err != nil {
+		t.Fatalf("RenderConfigWithPatch: %v", err)
+	}
+	for _, want := range []string{"request_timeout: 1200", "drop_params: false", "callbacks:\n        - otel\n        - prometheus\n        - langsmith\n"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("want %q in the config, got\n%s", want, got)
+		}
+	}
+	if ApplyClassConfig(&cfg, nil) != nil {
+		t.Error("nil class config: want no patch")
+	}
+}
+
+func TestBuildDeployment_ClassResources(t *testing.T) {
+	w := GatewayWorkload{
+		Name: "gw", Namespace: "default", ContainerPort: 4000,
+		Resources: &corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+		},
+	}
+	resources := BuildDeployment(w, metav1ac.OwnerReference().WithName("gw"), "c", "s").Spec.Template.Spec.Containers[0].Resources
+	if resources.Requests == nil || !(*resources.Requests)[corev1.ResourceCPU].Equal(resource.MustParse("1")) {
+		t.Errorf("requests: got %v, want cpu 1", resources.Requests)
+	}
+	if resources.Limits != nil {
+		t.Errorf("limits: got %v, want the operator's defaults replaced", *resources.Limits)
+	}
+}
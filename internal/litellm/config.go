@@ -28,6 +28,8 @@ type LiteLLMConfig struct {
 	McpServers      map[string]McpServer `yaml:"mcp_servers,omitempty"`
 	LiteLLMSettings LiteLLMSettings      `yaml:"litellm_settings,omitempty"`
 	Guardrails      []GuardrailConfig    `yaml:"guardrails,omitempty"`
+	GeneralSettings *GeneralSettings     `yaml:"general_settings,omitempty"`
+	RouterSettings  *RouterSettings      `yaml:"router_settings,omitempty"`
 }
 
 // ModelConfig is one entry under model_list.
@@ -38,8 +40,18 @@ type ModelConfig struct {
 
 // LiteLLMParams holds the litellm_params for a single model entry.
 type LiteLLMParams struct {
-	Model  string `yaml:"model"`
-	ApiKey string `yaml:"api_key,omitempty"`
+	Model   string `yaml:"model"`
+	ApiKey  string `yaml:"api_key,omitempty"`
+	ApiBase string `yaml:"api_base,omitempty"`
+	// MaxBudget and BudgetDuration set a deployment budget: LiteLLM stops
+	// routing to the entry once its spend in the period exceeds MaxBudget.
+	MaxBudget      *float64 `yaml:"max_budget,omitempty"`
+	BudgetDuration string   `yaml:"budget_duration,omitempty"`
+	// RPM, TPM and MaxParallelRequests limit the requests LiteLLM routes
+	// to the entry.
+	RPM                 *int64 `yaml:"rpm,omitempty"`
+	TPM                 *int64 `yaml:"tpm,omitempty"`
+	MaxParallelRequests *int64 `yaml:"max_parallel_requests,omitempty"`
 }
 
 // McpServer is one entry under mcp_servers, keyed by the controller-side
@@ -59,6 +71,49 @@ type McpServer struct {
 type LiteLLMSettings struct {
 	RequestTimeout int      `yaml:"request_timeout,omitempty"`
 	Callbacks      []string `yaml:"callbacks,omitempty"`
+	// SuccessCallback, FailureCallback and LangfuseDefaultTags log to
+	// Langfuse.
+	SuccessCallback     []string `yaml:"success_callback,omitempty"`
+	FailureCallback     []string `yaml:"failure_callback,omitempty"`
+	LangfuseDefaultTags []string `yaml:"langfuse_default_tags,omitempty"`
+	// MaxBudget and BudgetDuration set the proxy-wide budget.
+	MaxBudget      *float64 `yaml:"max_budget,omitempty"`
+	BudgetDuration string   `yaml:"budget_duration,omitempty"`
+	// Cache and CacheParams enable response caching.
+	Cache       bool         `yaml:"cache,omitempty"`
+	CacheParams *CacheParams `yaml:"cache_params,omitempty"`
+}
+
+// CacheParams is the litellm_settings.cache_params block.
+type CacheParams struct {
+	Type     string `yaml:"type"`
+	Host     string `yaml:"host,omitempty"`
+	Port     string `yaml:"port,omitempty"`
+	Password string `yaml:"password,omitempty"`
+}
+
+// GeneralSettings is the general_settings block.
+type GeneralSettings struct {
+	PassThroughEndpoints []PassThroughEndpoint `yaml:"pass_through_endpoints,omitempty"`
+	// StoreModelInDB keeps models added through the admin UI in the
+	// database, next to the model_list of the config.
+	StoreModelInDB bool `yaml:"store_model_in_db,omitempty"`
+	// Alerting and AlertTypes select where LiteLLM sends which alerts.
+	Alerting   []string `yaml:"alerting,omitempty"`
+	AlertTypes []string `yaml:"alert_types,omitempty"`
+	// AlertingThreshold is how many seconds a request may take before it
+	// is alerted on as slow.
+	AlertingThreshold int `yaml:"alerting_threshold,omitempty"`
+}
+
+// PassThroughEndpoint is one entry under general_settings.pass_through_endpoints.
+// Header values of the form "os.environ/<VAR>" or "bearer os.environ/<VAR>"
+// are resolved by LiteLLM from the container env.
+type PassThroughEndpoint struct {
+	Path           string            `yaml:"path"`
+	Target         string            `yaml:"target"`
+	Headers        map[string]string `yaml:"headers,omitempty"`
+	ForwardHeaders bool              `yaml:"forward_headers,omitempty"`
 }
 
 // GuardrailConfig is one entry under the top-level guardrails list.
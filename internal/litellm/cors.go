@@ -0,0 +1,122 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// CORS annotations of a gateway. Without them LiteLLM answers requests from
+// any origin.
+const (
+	// CORSAllowOriginsAnnotation lists, comma-separated, the origins browsers
+	// may call the gateway from, such as https://tools.example.com, or "*".
+	CORSAllowOriginsAnnotation = "ai-gateway-litellm.agentic-layer.ai/cors-allow-origins"
+	// CORSAllowHeadersAnnotation lists, comma-separated, the request headers
+	// those origins may send, or "*".
+	CORSAllowHeadersAnnotation = "ai-gateway-litellm.agentic-layer.ai/cors-allow-headers"
+)
+
+// CORSPhase tags invalid CORS annotations.
+const CORSPhase = "CORS"
+
+// The env vars LiteLLM reads its CORS settings from.
+const (
+	CORSAllowOriginsEnvVar = "LITELLM_CORS_ALLOW_ORIGINS"
+	CORSAllowHeadersEnvVar = "LITELLM_CORS_ALLOW_HEADERS"
+)
+
+// CORS is the cross-origin policy of a gateway. An empty list keeps
+// LiteLLM's default of allowing any.
+type CORS struct {
+	AllowOrigins []string
+	AllowHeaders []string
+}
+
+// ParseCORS returns the CORS policy the annotations ask for, or nil when
+// they set none. Invalid values yield a *PhaseError.
+func ParseCORS(annotations map[string]string) (*CORS, error) {
+	phaseErr := func(format string, args ...any) error {
+		return &PhaseError{Phase: CORSPhase, Err: fmt.Errorf(format, args...)}
+	}
+	cors := CORS{
+		AllowOrigins: splitList(annotations[CORSAllowOriginsAnnotation]),
+		AllowHeaders: splitList(annotations[CORSAllowHeadersAnnotation]),
+	}
+	for i, origin := range cors.AllowOrigins {
+		if origin == "*" {
+			if len(cors.AllowOrigins) > 1 {
+				return nil, phaseErr("invalid %s annotation: \"*\" cannot be combined with other origins", CORSAllowOriginsAnnotation)
+			}
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" ||
+			strings.TrimSuffix(u.Path, "/") != "" || u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+			return nil, phaseErr("invalid origin %q in %s: must be scheme://host[:port], such as https://tools.example.com",
+				origin, CORSAllowOriginsAnnotation)
+		}
+		// Browsers send the origin in this form, and it is matched as is.
+		cors.AllowOrigins[i] = u.Scheme + "://" + strings.ToLower(u.Host)
+	}
+	for _, header := range cors.AllowHeaders {
+		if header == "*" {
+			if len(cors.AllowHeaders) > 1 {
+				return nil, phaseErr("invalid %s annotation: \"*\" cannot be combined with other headers", CORSAllowHeadersAnnotation)
+			}
+			continue
+		}
+		if !validHeaderName(header) {
+			return nil, phaseErr("invalid header %q in %s", header, CORSAllowHeadersAnnotation)
+		}
+	}
+	if len(cors.AllowOrigins) == 0 && len(cors.AllowHeaders) == 0 {
+		return nil, nil
+	}
+	return &cors, nil
+}
+
+// validHeaderName reports whether name is an HTTP header name: a token of
+// RFC 9110.
+func validHeaderName(name string) bool {
+	for _, c := range name {
+		if c > 0x7f || !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || strings.ContainsRune("!#$%&'*+-.^_`|~", c)) {
+			return false
+		}
+	}
+	return name != ""
+}
+
+// CORSEnvVars returns the env vars setting the CORS policy of c in LiteLLM,
+// or none without a policy.
+func CORSEnvVars(c *CORS) []corev1.EnvVar {
+	if c == nil {
+		return nil
+	}
+	var env []corev1.EnvVar
+	if len(c.AllowOrigins) > 0 {
+		env = append(env, corev1.EnvVar{Name: CORSAllowOriginsEnvVar, Value: strings.Join(c.AllowOrigins, ",")})
+	}
+	if len(c.AllowHeaders) > 0 {
+		env = append(env, corev1.EnvVar{Name: CORSAllowHeadersEnvVar, Value: strings.Join(c.AllowHeaders, ",")})
+	}
+	return env
+}
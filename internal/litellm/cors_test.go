@@ -0,0 +1,77 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestParseCORS(t *testing.T) {
+	for _, tc := range []struct {
+		annotations map[string]string
+		want        *CORS
+		wantErr     bool
+	}{
+		{annotations: nil},
+		{annotations: map[string]string{CORSAllowOriginsAnnotation: " "}},
+		{
+			annotations: map[string]string{
+				CORSAllowOriginsAnnotation: "https://Tools.Example.com/, http://localhost:3000",
+				CORSAllowHeadersAnnotation: "Authorization, Content-Type,x-litellm-api-key",
+			},
+			want: &CORS{
+				AllowOrigins: []string{"https://tools.example.com", "http://localhost:3000"},
+				AllowHeaders: []string{"Authorization", "Content-Type", "x-litellm-api-key"},
+			},
+		},
+		{annotations: map[string]string{CORSAllowOriginsAnnotation: "*"}, want: &CORS{AllowOrigins: []string{"*"}}},
+		{annotations: map[string]string{CORSAllowHeadersAnnotation: "*"}, want: &CORS{AllowHeaders: []string{"*"}}},
+		{annotations: map[string]string{CORSAllowOriginsAnnotation: "*, https://tools.example.com"}, wantErr: true},
+		{annotations: map[string]string{CORSAllowOriginsAnnotation: "tools.example.com"}, wantErr: true},
+		{annotations: map[string]string{CORSAllowOriginsAnnotation: "ftp://tools.example.com"}, wantErr: true},
+		{annotations: map[string]string{CORSAllowOriginsAnnotation: "https://tools.example.com/app"}, wantErr: true},
+		{annotations: map[string]string{CORSAllowHeadersAnnotation: "X Custom"}, wantErr: true},
+		{annotations: map[string]string{CORSAllowHeadersAnnotation: "*, Authorization"}, wantErr: true},
+	} {
+		got, err := ParseCORS(tc.annotations)
+		if tc.wantErr {
+			var pe *PhaseError
+			if !errors.As(err, &pe) || pe.Phase != CORSPhase {
+				t.Errorf("%v: want a %s PhaseError, got %v", tc.annotations, CORSPhase, err)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%v: got %+v, %v, want %+v", tc.annotations, got, err, tc.want)
+		}
+	}
+}
+
+func TestCORSEnvVars(t *testing.T) {
+	if env := CORSEnvVars(nil); env != nil {
+		t.Errorf("no policy: got %v", env)
+	}
+	got := CORSEnvVars(&CORS{AllowOrigins: []string{"https://a.example.com", "https://b.example.com"}})
+	want := []corev1.EnvVar{{Name: CORSAllowOriginsEnvVar, Value: "https://a.example.com,https://b.example.com"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
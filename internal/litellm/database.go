@@ -0,0 +1,263 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DatabaseAnnotation provisions the LiteLLM database of a gateway. The only
+// value is "cnpg", which creates a CloudNativePG Cluster and wires its
+// connection string into DATABASE_URL.
+const DatabaseAnnotation = "ai-gateway-litellm.agentic-layer.ai/database"
+
+// DatabaseSizeAnnotation sets the volume size of each database instance.
+const DatabaseSizeAnnotation = "ai-gateway-litellm.agentic-layer.ai/database-size"
+
+// DatabaseInstancesAnnotation sets the number of database instances; more
+// than one adds streaming replicas for failover.
+const DatabaseInstancesAnnotation = "ai-gateway-litellm.agentic-layer.ai/database-instances"
+
+// DatabaseProviderCNPG is the DatabaseAnnotation value for CloudNativePG.
+const DatabaseProviderCNPG = "cnpg"
+
+// Defaults for a provisioned database.
+const (
+	DefaultDatabaseSize      = "1Gi"
+	DefaultDatabaseInstances = 1
+	maxDatabaseInstances     = 9
+)
+
+// DatabasePhase tags database provisioning failures.
+const DatabasePhase = "Database"
+
+// CNPGClusterGVK is the kind of a CloudNativePG Cluster.
+var CNPGClusterGVK = schema.GroupVersionKind{Group: "postgresql.cnpg.io", Version: "v1", Kind: "Cluster"}
+
+// Database configures the provisioned database of a gateway.
+type Database struct {
+	Size      resource.Quantity
+	Instances int64
+}
+
+// ParseDatabase returns the provisioned database settings of gw, or nil when
+// the database annotation is not set. Invalid values, and a DATABASE_URL in
+// spec.env next to the annotation, yield a *PhaseError.
+func ParseDatabase(gw *gatewayv1alpha1.AiGateway) (*Database, error) {
+	provider, ok := gw.Annotations[DatabaseAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	phaseErr := func(format string, args ...any) error {
+		return &PhaseError{Phase: DatabasePhase, Err: fmt.Errorf(format, args...)}
+	}
+	if provider != DatabaseProviderCNPG {
+		return nil, phaseErr("invalid %s annotation %q: must be %q", DatabaseAnnotation, provider, DatabaseProviderCNPG)
+	}
+	if DatabaseModeEnabled(gw.Spec.Env) {
+		return nil, phaseErr("%s conflicts with %s in spec.env; remove one of them", DatabaseAnnotation, DatabaseURLEnvVar)
+	}
+
+	db := &Database{Size: resource.MustParse(DefaultDatabaseSize), Instances: DefaultDatabaseInstances}
+	if v, ok := gw.Annotations[DatabaseSizeAnnotation]; ok {
+		size, err := resource.ParseQuantity(v)
+		if err != nil || size.Sign() <= 0 {
+			return nil, phaseErr("invalid %s annotation %q: must be a size such as %q", DatabaseSizeAnnotation, v, DefaultDatabaseSize)
+		}
+		db.Size = size
+	}
+	if v, ok := gw.Annotations[DatabaseInstancesAnnotation]; ok {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n < 1 || n > maxDatabaseInstances {
+			return nil, phaseErr("invalid %s annotation %q: must be between 1 and %d", DatabaseInstancesAnnotation, v, maxDatabaseInstances)
+		}
+		db.Instances = n
+	}
+	return db, nil
+}
+
+// DatabaseClusterName is the name of the CNPG Cluster of the gateway name.
+func DatabaseClusterName(name string) string {
+	return name + "-db"
+}
+
+// DatabaseSecretName is the Secret CNPG writes the application user's
+// credentials to, including the connection string under "uri".
+func DatabaseSecretName(name string) string {
+	return DatabaseClusterName(name) + "-app"
+}
+
+// GatewayEnv returns spec.env of gw plus the DATABASE_URL of a provisioned
+// database. Use it wherever database mode is detected, so a provisioned
+// database counts the same as one set in spec.env.
+func GatewayEnv(gw *gatewayv1alpha1.AiGateway) []corev1.EnvVar {
+	if db, err := ParseDatabase(gw); err != nil || db == nil {
+		return gw.Spec.Env
+	}
+	env := make([]corev1.EnvVar, 0, len(gw.Spec.Env)+1)
+	env = append(env, gw.Spec.Env...)
+	return append(env, corev1.EnvVar{
+		Name: DatabaseURLEnvVar,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: DatabaseSecretName(gw.Name)},
+				Key:                  "uri",
+			},
+		},
+	})
+}
+
+// ResolveGatewayEnv is GatewayEnv plus a DATABASE_URL that only a
+// spec.envFrom Secret or ConfigMap of gw supplies, as a reference to its
+// key. Use it to detect database mode, so a URL loaded through envFrom
+// counts like one in spec.env. Sources that are missing are skipped, like
+// the kubelet skips optional ones.
+func ResolveGatewayEnv(ctx context.Context, c client.Reader, gw *gatewayv1alpha1.AiGateway) ([]corev1.EnvVar, error) {
+	env := GatewayEnv(gw)
+	if DatabaseModeEnabled(env) {
+		return env, nil
+	}
+	var databaseURL *corev1.EnvVar
+	// Later sources win, as in the container.
+	for _, source := range gw.Spec.EnvFrom {
+		key, ok := strings.CutPrefix(DatabaseURLEnvVar, source.Prefix)
+		if !ok || key == "" {
+			continue
+		}
+		var obj client.Object
+		var name string
+		switch {
+		case source.SecretRef != nil:
+			obj, name = &corev1.Secret{}, source.SecretRef.Name
+		case source.ConfigMapRef != nil:
+			obj, name = &corev1.ConfigMap{}, source.ConfigMapRef.Name
+		default:
+			continue
+		}
+		if err := c.Get(ctx, types.NamespacedName{Namespace: gw.Namespace, Name: name}, obj); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("resolving %s from envFrom: %w", DatabaseURLEnvVar, err)
+		}
+		switch obj := obj.(type) {
+		case *corev1.Secret:
+			if _, ok := obj.Data[key]; ok {
+				databaseURL = &corev1.EnvVar{Name: DatabaseURLEnvVar, ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: name}, Key: key},
+				}}
+			}
+		case *corev1.ConfigMap:
+			if _, ok := obj.Data[key]; ok {
+				databaseURL = &corev1.EnvVar{Name: DatabaseURLEnvVar, ValueFrom: &corev1.EnvVarSource{
+					ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: name}, Key: key},
+				}}
+			}
+		}
+	}
+	if databaseURL == nil {
+		return env, nil
+	}
+	return append(slices.Clone(env), *databaseURL), nil
+}
+
+// ReconcileDatabase applies the CNPG Cluster of w when db is set. A Cluster
+// is never deleted here, since that deletes the keys and spend it holds:
+// removing the annotation leaves it in place, and it is garbage collected
+// with the gateway. When the CNPG CRDs are not installed, the error says so
+// and reconcile retries until they are.
+//
+// On failure, the returned error is a *PhaseError tagged DatabasePhase.
+func ReconcileDatabase(ctx context.Context, c client.Client, scheme *runtime.Scheme, w GatewayWorkload, db *Database) error {
+	if db == nil {
+		return nil
+	}
+	phaseErr := func(err error) error { return &PhaseError{Phase: DatabasePhase, Err: err} }
+
+	if _, err := c.RESTMapper().RESTMapping(CNPGClusterGVK.GroupKind(), CNPGClusterGVK.Version); err != nil {
+		if apimeta.IsNoMatchError(err) {
+			err = fmt.Errorf("%s needs CloudNativePG, but %s is not served by this cluster", DatabaseAnnotation, CNPGClusterGVK.GroupKind())
+		}
+		return phaseErr(err)
+	}
+
+	cluster, err := BuildDatabaseCluster(w, scheme, db)
+	if err != nil {
+		return phaseErr(err)
+	}
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(CNPGClusterGVK)
+	existing.SetName(cluster.GetName())
+	existing.SetNamespace(w.Namespace)
+	if err := apply(ctx, c, w, client.ApplyConfigurationFromUnstructured(cluster), existing, "Cluster"); err != nil {
+		return phaseErr(err)
+	}
+	return nil
+}
+
+// BuildDatabaseCluster returns the desired state of the CNPG Cluster. The
+// bootstrap creates a litellm database owned by a litellm user, whose
+// credentials CNPG writes to DatabaseSecretName.
+func BuildDatabaseCluster(w GatewayWorkload, scheme *runtime.Scheme, db *Database) (*unstructured.Unstructured, error) {
+	ownerRef, err := controllerReference(w.Owner, scheme)
+	if err != nil {
+		return nil, err
+	}
+	labels := map[string]any{}
+	for k, v := range BuildResourceLabels(w.Name, w.CommonMetadata) {
+		labels[k] = v
+	}
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": CNPGClusterGVK.GroupVersion().String(),
+		"kind":       CNPGClusterGVK.Kind,
+		"metadata": map[string]any{
+			"name":      DatabaseClusterName(w.Name),
+			"namespace": w.Namespace,
+			"labels":    labels,
+			"ownerReferences": []any{map[string]any{
+				"apiVersion":         *ownerRef.APIVersion,
+				"kind":               *ownerRef.Kind,
+				"name":               *ownerRef.Name,
+				"uid":                string(*ownerRef.UID),
+				"controller":         true,
+				"blockOwnerDeletion": true,
+			}},
+		},
+		"spec": map[string]any{
+			"instances": db.Instances,
+			"storage":   map[string]any{"size": db.Size.String()},
+			"bootstrap": map[string]any{
+				"initdb": map[string]any{"database": "litellm", "owner": "litellm"},
+			},
+		},
+	}}, nil
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestParseDatabase(t *testing.T) {
+	databaseURL := []corev1.EnvVar{{Name: DatabaseURLEnvVar, Value: "postgresql://db"}}
+	for _, tc := range []struct {
+		annotations map[string]string
+		env         []corev1.EnvVar
+		want        *Database
+		wantErr     bool
+	}{
+		{annotations: nil},
+		{annotations: nil, env: databaseURL},
+		{
+			annotations: map[string]string{DatabaseAnnotation: "cnpg"},
+			want:        &Database{Size: resource.MustParse("1Gi"), Instances: 1},
+		},
+		{
+			annotations: map[string]string{DatabaseAnnotation: "cnpg", DatabaseSizeAnnotation: "10Gi", DatabaseInstancesAnnotation: "3"},
+			want:        &Database{Size: resource.MustParse("10Gi"), Instances: 3},
+		},
+		{annotations: map[string]string{DatabaseAnnotation: "rds"}, wantErr: true},
+		{annotations: map[string]string{DatabaseAnnotation: "cnpg"}, env: databaseURL, wantErr: true},
+		{annotations: map[string]string{DatabaseAnnotation: "cnpg", DatabaseSizeAnnotation: "big"}, wantErr: true},
+		{annotations: map[string]string{DatabaseAnnotation: "cnpg", DatabaseInstancesAnnotation: "0"}, wantErr: true},
+	} {
+		gw := &gatewayv1alpha1.AiGateway{ObjectMeta: metav1.ObjectMeta{Name: "gw", Annotations: tc.annotations}}
+		gw.Spec.Env = tc.env
+		got, err := ParseDatabase(gw)
+		if tc.wantErr {
+			var pe *PhaseError
+			if !errors.As(err, &pe) || pe.Phase != DatabasePhase {
+				t.Errorf("%v: want a %s PhaseError, got %v", tc.annotations, DatabasePhase, err)
+			}
+			continue
+		}
+		if err != nil || (got == nil) != (tc.want == nil) ||
+			(got != nil && (got.Size.Cmp(tc.want.Size) != 0 || got.Instances != tc.want.Instances)) {
+			t.Errorf("%v: got %+v, %v, want %+v", tc.annotations, got, err, tc.want)
+		}
+	}
+}
+
+func TestGatewayEnv(t *testing.T) {
+	gw := &gatewayv1alpha1.AiGateway{ObjectMeta: metav1.ObjectMeta{Name: "gw"}}
+	gw.Spec.Env = []corev1.EnvVar{{Name: "OTHER", Value: "x"}}
+	if DatabaseModeEnabled(GatewayEnv(gw)) {
+		t.Error("gateway without a database must not be in database mode")
+	}
+
+	gw.Annotations = map[string]string{DatabaseAnnotation: DatabaseProviderCNPG}
+	env := GatewayEnv(gw)
+	if !DatabaseModeEnabled(env) {
+		t.Fatal("provisioned database must enable database mode")
+	}
+	ref := env[len(env)-1].ValueFrom.SecretKeyRef
+	if ref.Name != "gw-db-app" || ref.Key != "uri" {
+		t.Errorf("DATABASE_URL must come from gw-db-app/uri, got %s/%s", ref.Name, ref.Key)
+	}
+	if len(gw.Spec.Env) != 1 {
+		t.Error("GatewayEnv must not modify spec.env")
+	}
+}
+
+func TestResolveGatewayEnv(t *testing.T) {
+	objs := []client.Object{
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+			Data: map[string][]byte{"DATABASE_URL": []byte("postgresql://db")}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "prefixed", Namespace: "default"},
+			Data: map[string][]byte{"URL": []byte("postgresql://db")}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "default"},
+			Data: map[string]string{"LOG_LEVEL": "debug"}},
+	}
+	c := fake.NewClientBuilder().WithScheme(workloadScheme(t)).WithObjects(objs...).Build()
+	secretRef := func(name string) *corev1.SecretEnvSource {
+		return &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: name}}
+	}
+	for name, tc := range map[string]struct {
+		envFrom []corev1.EnvFromSource
+		wantRef string
+	}{
+		"no envFrom":       {},
+		"Secret":           {envFrom: []corev1.EnvFromSource{{SecretRef: secretRef("db")}}, wantRef: "db/DATABASE_URL"},
+		"prefixed Secret":  {envFrom: []corev1.EnvFromSource{{Prefix: "DATABASE_", SecretRef: secretRef("prefixed")}}, wantRef: "prefixed/URL"},
+		"missing Secret":   {envFrom: []corev1.EnvFromSource{{SecretRef: secretRef("missing")}}},
+		"unrelated source": {envFrom: []corev1.EnvFromSource{{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "settings"}}}}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gw := &gatewayv1alpha1.AiGateway{ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "default"}}
+			gw.Spec.EnvFrom = tc.envFrom
+			env, err := ResolveGatewayEnv(context.Background(), c, gw)
+			if err != nil {
+				t.Fatalf("ResolveGatewayEnv: %v", err)
+			}
+			if DatabaseModeEnabled(env) != (tc.wantRef != "") {
+				t.Fatalf("database mode: got %v, want %v", DatabaseModeEnabled(env), tc.wantRef != "")
+			}
+			if tc.wantRef == "" {
+				return
+			}
+			ref := env[len(env)-1].ValueFrom.SecretKeyRef
+			if got := ref.Name + "/" + ref.Key; got != tc.wantRef {
+				t.Errorf("DATABASE_URL from %s, want %s", got, tc.wantRef)
+			}
+		})
+	}
+}
+
+func TestReconcileDatabase_WithoutCNPG(t *testing.T) {
+	s := workloadScheme(t)
+	owner := newOwner("gw", "default")
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(owner).Build()
+	w := GatewayWorkload{Name: "gw", Namespace: "default", Owner: owner}
+
+	if err := ReconcileDatabase(context.Background(), c, s, w, nil); err != nil {
+		t.Fatalf("no database: %v", err)
+	}
+	db := &Database{Size: resource.MustParse("1Gi"), Instances: 1}
+	err := ReconcileDatabase(context.Background(), c, s, w, db)
+	var pe *PhaseError
+	if !errors.As(err, &pe) || pe.Phase != DatabasePhase || !strings.Contains(err.Error(), "CloudNativePG") {
+		t.Fatalf("want a %s PhaseError naming CloudNativePG, got %v", DatabasePhase, err)
+	}
+}
+
+func TestBuildDatabaseCluster(t *testing.T) {
+	s := workloadScheme(t)
+	w := GatewayWorkload{Name: "gw", Namespace: "default", Owner: newOwner("gw", "default")}
+	cluster, err := BuildDatabaseCluster(w, s, &Database{Size: resource.MustParse("5Gi"), Instances: 2})
+	if err != nil {
+		t.Fatalf("BuildDatabaseCluster: %v", err)
+	}
+	if cluster.GetName() != "gw-db" || cluster.GroupVersionKind() != CNPGClusterGVK {
+		t.Errorf("got %s %s", cluster.GroupVersionKind(), cluster.GetName())
+	}
+	if refs := cluster.GetOwnerReferences(); len(refs) != 1 || refs[0].UID != "owner-uid-123" || refs[0].Controller == nil || !*refs[0].Controller {
+		t.Errorf("owner references = %+v", refs)
+	}
+	spec := cluster.Object["spec"].(map[string]any)
+	if spec["instances"] != int64(2) || spec["storage"].(map[string]any)["size"] != "5Gi" {
+		t.Errorf("spec = %v", spec)
+	}
+	if cluster.GetLabels()[ManagedByLabel] == "" {
+		t.Error("Cluster must carry the managed-by label")
+	}
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// ModelServerLabel marks an in-cluster model server Service, such as an
+// Ollama or vLLM deployment, for discovery by gateways.
+const ModelServerLabel = "ai-gateway-litellm.agentic-layer.ai/model-server"
+
+// Annotations on a model server Service describing what it serves.
+const (
+	// ModelServerModelsAnnotation lists the served models, comma-separated.
+	ModelServerModelsAnnotation = "ai-gateway-litellm.agentic-layer.ai/models"
+	// ModelServerTypeAnnotation is the API the server speaks, one of the
+	// ModelServerType* values. Defaults to ModelServerTypeOpenAI.
+	ModelServerTypeAnnotation = "ai-gateway-litellm.agentic-layer.ai/model-server-type"
+	// ModelServerPortAnnotation names the Service port, by name or number.
+	// Defaults to the first port.
+	ModelServerPortAnnotation = "ai-gateway-litellm.agentic-layer.ai/model-server-port"
+	// ModelServerPathAnnotation is the API base path on the server. Defaults
+	// to "/v1" for OpenAI-compatible servers and "" otherwise.
+	ModelServerPathAnnotation = "ai-gateway-litellm.agentic-layer.ai/model-server-path"
+)
+
+// Values of ModelServerTypeAnnotation.
+const (
+	ModelServerTypeOllama = "ollama"
+	ModelServerTypeVLLM   = "vllm"
+	ModelServerTypeOpenAI = "openai"
+)
+
+// ModelDiscoveryAnnotation opts an AiGateway into model discovery. Its value
+// is a label selector narrowing the model server Services in the gateway's
+// namespace whose models are added; an empty value adds all of them.
+const ModelDiscoveryAnnotation = "ai-gateway-litellm.agentic-layer.ai/model-discovery"
+
+// ModelDiscoveryPhase tags model discovery failures.
+const ModelDiscoveryPhase = "ModelDiscovery"
+
+// modelServerProviders maps a server type to its LiteLLM provider prefix.
+var modelServerProviders = map[string]string{
+	ModelServerTypeOllama: "ollama",
+	ModelServerTypeVLLM:   "hosted_vllm",
+	ModelServerTypeOpenAI: "openai",
+}
+
+// ModelServerSelector selects Services labelled as model servers.
+func ModelServerSelector() labels.Selector {
+	return labels.SelectorFromSet(labels.Set{ModelServerLabel: "true"})
+}
+
+// ModelDiscoverySelector returns the selector of ModelDiscoveryAnnotation
+// and whether the annotation is set.
+func ModelDiscoverySelector(annotations map[string]string) (labels.Selector, bool, error) {
+	raw, ok := annotations[ModelDiscoveryAnnotation]
+	if !ok {
+		return nil, false, nil
+	}
+	selector, err := labels.Parse(raw)
+	if err != nil {
+		return nil, true, fmt.Errorf("invalid %s annotation %q: %w", ModelDiscoveryAnnotation, raw, err)
+	}
+	return selector, true, nil
+}
+
+// DiscoveredModels returns a model_list entry per model each Service serves,
+// in Service order. Models named in exclude (the gateway's own models) are
+// skipped. Entries for the same model on several Services share a
+// model_name, so LiteLLM balances load across them. Services with
+// unusable annotations are skipped and reported in the returned messages.
+func DiscoveredModels(services []corev1.Service, exclude []string) ([]ModelConfig, []string) {
+	var models []ModelConfig
+	var problems []string
+	for _, svc := range services {
+		apiBase, provider, err := modelServerEndpoint(&svc)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("Service %s: %v", svc.Name, err))
+			continue
+		}
+		for name := range strings.SplitSeq(svc.Annotations[ModelServerModelsAnnotation], ",") {
+			if name = strings.TrimSpace(name); name == "" || slices.Contains(exclude, name) {
+				continue
+			}
+			params := LiteLLMParams{Model: provider + "/" + name, ApiBase: apiBase}
+			if provider == "openai" {
+				// The OpenAI client refuses to send a request without a key;
+				// in-cluster servers ignore it.
+				params.ApiKey = "none"
+			}
+			models = append(models, ModelConfig{ModelName: name, LiteLLMParams: params})
+		}
+	}
+	return models, problems
+}
+
+// modelServerEndpoint returns the API base URL and LiteLLM provider of a
+// model server Service.
+func modelServerEndpoint(svc *corev1.Service) (string, string, error) {
+	serverType := svc.Annotations[ModelServerTypeAnnotation]
+	if serverType == "" {
+		serverType = ModelServerTypeOpenAI
+	}
+	provider, ok := modelServerProviders[serverType]
+	if !ok {
+		return "", "", fmt.Errorf("unknown %s %q", ModelServerTypeAnnotation, serverType)
+	}
+	if len(svc.Spec.Ports) == 0 {
+		return "", "", fmt.Errorf("no ports")
+	}
+	port := svc.Spec.Ports[0].Port
+	if want, ok := svc.Annotations[ModelServerPortAnnotation]; ok {
+		i := slices.IndexFunc(svc.Spec.Ports, func(p corev1.ServicePort) bool {
+			return p.Name == want || strconv.Itoa(int(p.Port)) == want
+		})
+		if i < 0 {
+			return "", "", fmt.Errorf("no port %q", want)
+		}
+		port = svc.Spec.Ports[i].Port
+	}
+	path, ok := svc.Annotations[ModelServerPathAnnotation]
+	if !ok && serverType != ModelServerTypeOllama {
+		path = "/v1"
+	}
+	return ServiceURL(svc.Name, svc.Namespace, port) + path, provider, nil
+}
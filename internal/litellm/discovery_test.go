@@ -0,0 +1,85 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newModelServer(name string, annotations map[string]string, ports ...corev1.ServicePort) corev1.Service {
+	return corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ai", Annotations: annotations},
+		Spec:       corev1.ServiceSpec{Ports: ports},
+	}
+}
+
+func TestDiscoveredModels(t *testing.T) {
+	services := []corev1.Service{
+		newModelServer("ollama", map[string]string{
+			ModelServerModelsAnnotation: "llama3, mistral",
+			ModelServerTypeAnnotation:   ModelServerTypeOllama,
+		}, corev1.ServicePort{Port: 11434}),
+		newModelServer("vllm", map[string]string{
+			ModelServerModelsAnnotation: "qwen",
+			ModelServerTypeAnnotation:   ModelServerTypeVLLM,
+			ModelServerPortAnnotation:   "http",
+		}, corev1.ServicePort{Name: "metrics", Port: 9090}, corev1.ServicePort{Name: "http", Port: 8000}),
+		newModelServer("compat", map[string]string{
+			ModelServerModelsAnnotation: "gpt-4o,phi",
+			ModelServerPathAnnotation:   "/openai/v1",
+		}, corev1.ServicePort{Port: 80}),
+		newModelServer("broken", map[string]string{
+			ModelServerModelsAnnotation: "x",
+			ModelServerTypeAnnotation:   "tgi",
+		}, corev1.ServicePort{Port: 80}),
+	}
+
+	models, problems := DiscoveredModels(services, []string{"gpt-4o"})
+	want := []LiteLLMParams{
+		{Model: "ollama/llama3", ApiBase: "http://ollama.ai.svc.cluster.local:11434"},
+		{Model: "ollama/mistral", ApiBase: "http://ollama.ai.svc.cluster.local:11434"},
+		{Model: "hosted_vllm/qwen", ApiBase: "http://vllm.ai.svc.cluster.local:8000/v1"},
+		{Model: "openai/phi", ApiBase: "http://compat.ai.svc.cluster.local:80/openai/v1", ApiKey: "none"},
+	}
+	if len(models) != len(want) {
+		t.Fatalf("got %d models, want %d: %+v", len(models), len(want), models)
+	}
+	for i, m := range models {
+		if m.LiteLLMParams != want[i] {
+			t.Errorf("model %d: got %+v, want %+v", i, m.LiteLLMParams, want[i])
+		}
+	}
+	if len(problems) != 1 {
+		t.Errorf("want one problem for the unknown server type, got %v", problems)
+	}
+}
+
+func TestModelDiscoverySelector(t *testing.T) {
+	if _, ok, err := ModelDiscoverySelector(nil); ok || err != nil {
+		t.Errorf("unset annotation: ok=%v err=%v", ok, err)
+	}
+	selector, ok, err := ModelDiscoverySelector(map[string]string{ModelDiscoveryAnnotation: ""})
+	if !ok || err != nil || !selector.Empty() {
+		t.Errorf("empty annotation must select every model server, got %v ok=%v err=%v", selector, ok, err)
+	}
+	if _, _, err := ModelDiscoverySelector(map[string]string{ModelDiscoveryAnnotation: "team in ("}); err == nil {
+		t.Errorf("expected an error for an invalid selector")
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"context"
+	"encoding/json"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// logDryRunDiff logs what a dry-run apply would have changed. before is the
+// live object (ignored when found is false), after the object the API server
+// returned for the dry-run request.
+func logDryRunDiff(ctx context.Context, kind string, before any, found bool, after any) {
+	log := logf.FromContext(ctx).WithValues("kind", kind)
+	if !found {
+		log.Info("Dry run: would create", "object", after)
+		return
+	}
+	patch, err := dryRunDiff(before, after)
+	if err != nil {
+		log.Error(err, "Dry run: failed to compute diff")
+		return
+	}
+	if patch == "" {
+		log.V(1).Info("Dry run: no change")
+		return
+	}
+	log.Info("Dry run: would update", "patch", patch)
+}
+
+// dryRunDiff returns the JSON merge patch from before to after, or "" when
+// they only differ in server-maintained bookkeeping (resourceVersion,
+// generation, managedFields, status).
+func dryRunDiff(before, after any) (string, error) {
+	b, err := comparableJSON(before)
+	if err != nil {
+		return "", err
+	}
+	a, err := comparableJSON(after)
+	if err != nil {
+		return "", err
+	}
+	patch, err := jsonpatch.CreateMergePatch(b, a)
+	if err != nil {
+		return "", err
+	}
+	if string(patch) == "{}" {
+		return "", nil
+	}
+	return string(patch), nil
+}
+
+func comparableJSON(obj any) ([]byte, error) {
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]any
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return nil, err
+	}
+	delete(m, "status")
+	if meta, ok := m["metadata"].(map[string]any); ok {
+		for _, k := range []string{"managedFields", "resourceVersion", "generation", "creationTimestamp", "uid"} {
+			delete(meta, k)
+		}
+	}
+	return json.Marshal(m)
+}
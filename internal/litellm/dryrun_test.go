@@ -0,0 +1,48 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDryRunDiff(t *testing.T) {
+	before := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "gw-config", ResourceVersion: "1", Generation: 1},
+		Data:       map[string]string{"config.yaml": "old"},
+	}
+
+	same := before.DeepCopy()
+	same.ResourceVersion = "2"
+	same.ManagedFields = []metav1.ManagedFieldsEntry{{Manager: FieldManager}}
+	if patch, err := dryRunDiff(before, same); err != nil || patch != "" {
+		t.Errorf("bookkeeping-only change: patch = %q, err = %v; want empty", patch, err)
+	}
+
+	changed := before.DeepCopy()
+	changed.Data["config.yaml"] = "new"
+	patch, err := dryRunDiff(before, changed)
+	if err != nil {
+		t.Fatalf("dryRunDiff: %v", err)
+	}
+	if want := `{"data":{"config.yaml":"new"}}`; patch != want {
+		t.Errorf("patch = %s, want %s", patch, want)
+	}
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EgressHostsAnnotation is set by the operator on a gateway to the hosts
+// outside the cluster its models are called at, comma-separated and sorted,
+// for generating egress policies. Users do not set it.
+const EgressHostsAnnotation = "ai-gateway-litellm.agentic-layer.ai/egress-hosts"
+
+// providerHosts are the hosts LiteLLM calls a provider at when its model
+// sets no api_base. Providers whose endpoint depends on the account or
+// region, such as azure and bedrock, are not listed.
+var providerHosts = map[string]string{
+	"ai21":         "api.ai21.com",
+	"anthropic":    "api.anthropic.com",
+	"anyscale":     "api.endpoints.anyscale.com",
+	"cerebras":     "api.cerebras.ai",
+	"clarifai":     "api.clarifai.com",
+	"codestral":    "codestral.mistral.ai",
+	"cohere":       "api.cohere.ai",
+	"cohere_chat":  "api.cohere.ai",
+	"deepinfra":    "api.deepinfra.com",
+	"deepseek":     "api.deepseek.com",
+	"fireworks_ai": "api.fireworks.ai",
+	"gemini":       "generativelanguage.googleapis.com",
+	"groq":         "api.groq.com",
+	"hyperbolic":   "api.hyperbolic.xyz",
+	"mistral":      "api.mistral.ai",
+	"novita":       "api.novita.ai",
+	"nvidia_nim":   "integrate.api.nvidia.com",
+	"openai":       "api.openai.com",
+	"openrouter":   "openrouter.ai",
+	"perplexity":   "api.perplexity.ai",
+	"replicate":    "api.replicate.com",
+	"sambanova":    "api.sambanova.ai",
+	"together_ai":  "api.together.xyz",
+	"voyage":       "api.voyageai.com",
+	"xai":          "api.x.ai",
+}
+
+// localProviders default to a server on the gateway's own host.
+var localProviders = []string{"lm_studio", "ollama", "ollama_chat"}
+
+// egressModel is the part of a model_list entry that decides where it is
+// called. It is read from the rendered config, so a config patch setting
+// api_base or a region is taken into account.
+type egressModel struct {
+	LiteLLMParams struct {
+		Model          string `yaml:"model"`
+		ApiBase        string `yaml:"api_base"`
+		AwsRegionName  string `yaml:"aws_region_name"`
+		VertexLocation string `yaml:"vertex_location"`
+	} `yaml:"litellm_params"`
+}
+
+// EgressHosts returns the hosts outside the cluster the models of a
+// rendered config are called at, and the providers of the models whose host
+// cannot be told from the config, both sorted and without duplicates. A host
+// is given with its port when its api_base names one. Models served inside
+// the cluster contribute no host.
+func EgressHosts(configYAML string) (hosts, unknown []string, err error) {
+	var config struct {
+		ModelList []egressModel `yaml:"model_list"`
+	}
+	if err := yaml.Unmarshal([]byte(configYAML), &config); err != nil {
+		return nil, nil, err
+	}
+	for _, m := range config.ModelList {
+		params := m.LiteLLMParams
+		provider, _, _ := strings.Cut(params.Model, "/")
+		host := ""
+		switch {
+		case params.ApiBase != "":
+			host = apiBaseHost(params.ApiBase)
+			if host == "" {
+				unknown = append(unknown, provider)
+				continue
+			}
+		case slices.Contains(localProviders, provider):
+			continue
+		case provider == "bedrock" && params.AwsRegionName != "":
+			host = "bedrock-runtime." + params.AwsRegionName + ".amazonaws.com"
+		case provider == "vertex_ai":
+			host = "aiplatform.googleapis.com"
+			if params.VertexLocation != "" && params.VertexLocation != "global" {
+				host = params.VertexLocation + "-" + host
+			}
+		default:
+			if host = providerHosts[provider]; host == "" {
+				unknown = append(unknown, provider)
+				continue
+			}
+		}
+		if !inCluster(host) {
+			hosts = append(hosts, host)
+		}
+	}
+	slices.Sort(hosts)
+	slices.Sort(unknown)
+	return slices.Compact(hosts), slices.Compact(unknown), nil
+}
+
+// apiBaseHost returns the host of an api_base URL, with its port if it names
+// one, or "" when it is read from an env var or is no URL.
+func apiBaseHost(apiBase string) string {
+	if strings.HasPrefix(apiBase, "os.environ/") {
+		return ""
+	}
+	u, err := url.Parse(apiBase)
+	if err != nil || u.Hostname() == "" {
+		return ""
+	}
+	return strings.ToLower(u.Host)
+}
+
+// inCluster reports whether host is a Service of the cluster, named in
+// full or by its single-label name, or the gateway's own host.
+func inCluster(host string) bool {
+	name := host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		name = h
+	}
+	name = strings.Trim(name, "[]")
+	if ip := net.ParseIP(name); ip != nil {
+		return ip.IsLoopback()
+	}
+	return !strings.Contains(name, ".") || strings.HasSuffix(name, ".svc") || strings.HasSuffix(name, ".cluster.local")
+}
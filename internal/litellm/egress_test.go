@@ -0,0 +1,70 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestEgressHosts(t *testing.T) {
+	config := `model_list:
+- model_name: gpt-4o
+  litellm_params: {model: openai/gpt-4o, api_key: os.environ/OPENAI_API_KEY}
+- model_name: gpt-4o-mini
+  litellm_params: {model: openai/gpt-4o-mini}
+- model_name: claude
+  litellm_params: {model: anthropic/claude-sonnet-4}
+- model_name: proxied
+  litellm_params: {model: openai/gpt-4o, api_base: "https://LLM.corp.example:8443/v1"}
+- model_name: titan
+  litellm_params: {model: bedrock/amazon.titan, aws_region_name: eu-central-1}
+- model_name: gemini-pro
+  litellm_params: {model: vertex_ai/gemini-pro, vertex_location: europe-west4}
+- model_name: llama
+  litellm_params: {model: hosted_vllm/llama, api_base: "http://vllm.models.svc.cluster.local:8000/v1"}
+- model_name: short
+  litellm_params: {model: hosted_vllm/short, api_base: "http://vllm:8000"}
+- model_name: local
+  litellm_params: {model: ollama/llama3}
+- model_name: deployment
+  litellm_params: {model: azure/gpt-4o}
+- model_name: from-env
+  litellm_params: {model: openai/gpt-4o, api_base: os.environ/OPENAI_API_BASE}
+`
+	hosts, unknown, err := EgressHosts(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantHosts := []string{
+		"api.anthropic.com",
+		"api.openai.com",
+		"bedrock-runtime.eu-central-1.amazonaws.com",
+		"europe-west4-aiplatform.googleapis.com",
+		"llm.corp.example:8443",
+	}
+	if !slices.Equal(hosts, wantHosts) {
+		t.Errorf("hosts = %v, want %v", hosts, wantHosts)
+	}
+	if want := []string{"azure", "openai"}; !slices.Equal(unknown, want) {
+		t.Errorf("unknown = %v, want %v", unknown, want)
+	}
+
+	if _, _, err := EgressHosts("model_list: 3"); err == nil {
+		t.Error("want an error for a malformed config")
+	}
+}
@@ -0,0 +1,228 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// FlaggerAnnotation prepares a gateway for progressive delivery by a Flagger
+// Canary: the operator emits the Services and MetricTemplates the Canary
+// uses, and routes the gateway's Service to Flagger's primary Deployment.
+const FlaggerAnnotation = "ai-gateway-litellm.agentic-layer.ai/flagger"
+
+// FlaggerPrometheusAnnotation is the URL of the Prometheus that scrapes the
+// gateway's pods, queried by the MetricTemplates. It is required with
+// FlaggerAnnotation.
+const FlaggerPrometheusAnnotation = "ai-gateway-litellm.agentic-layer.ai/flagger-prometheus"
+
+// FlaggerPhase tags invalid Flagger annotations and failures applying the
+// Flagger objects, including a cluster without the Flagger CRDs.
+const FlaggerPhase = "Flagger"
+
+// GVKs of the Flagger kinds the operator detects and creates.
+var (
+	FlaggerCanaryGVK         = schema.GroupVersionKind{Group: "flagger.app", Version: "v1beta1", Kind: "Canary"}
+	FlaggerMetricTemplateGVK = schema.GroupVersionKind{Group: "flagger.app", Version: "v1beta1", Kind: "MetricTemplate"}
+)
+
+// Flagger configures the Flagger objects of a GatewayWorkload.
+type Flagger struct {
+	// PrometheusAddress is where the MetricTemplates query LiteLLM's metrics.
+	PrometheusAddress string
+}
+
+// Names of the MetricTemplates of a gateway, relative to its name.
+const (
+	FlaggerErrorRateSuffix = "-error-rate"
+	FlaggerLatencySuffix   = "-latency"
+)
+
+// ParseFlagger returns the Flagger settings of the annotations, or nil when
+// FlaggerAnnotation is unset or false. Flagger replaces the rollout of the
+// gateway's Deployment, so it cannot be combined with another rollout
+// strategy. Invalid values yield a *PhaseError.
+func ParseFlagger(annotations map[string]string) (*Flagger, error) {
+	phaseErr := func(format string, args ...any) error {
+		return &PhaseError{Phase: FlaggerPhase, Err: fmt.Errorf(format, args...)}
+	}
+	v, ok := annotations[FlaggerAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	enabled, err := strconv.ParseBool(v)
+	if err != nil {
+		return nil, phaseErr("invalid %s annotation %q: must be \"true\" or \"false\"", FlaggerAnnotation, v)
+	}
+	if !enabled {
+		return nil, nil
+	}
+	if strategy := annotations[RolloutStrategyAnnotation]; strategy != "" && strategy != RolloutStrategyRollingUpdate {
+		return nil, phaseErr("%s conflicts with %s %s; Flagger needs the %s strategy",
+			FlaggerAnnotation, RolloutStrategyAnnotation, strategy, RolloutStrategyRollingUpdate)
+	}
+	address := annotations[FlaggerPrometheusAnnotation]
+	if u, err := url.Parse(address); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, phaseErr("%s needs the %s annotation set to the URL of the Prometheus scraping the gateway, got %q",
+			FlaggerAnnotation, FlaggerPrometheusAnnotation, address)
+	}
+	return &Flagger{PrometheusAddress: address}, nil
+}
+
+// FlaggerPrimaryName is the name Flagger gives the primary Deployment and
+// Service of the gateway name.
+func FlaggerPrimaryName(name string) string {
+	return name + "-primary"
+}
+
+// FlaggerCanaryName is the name Flagger gives the canary Service of the
+// gateway name.
+func FlaggerCanaryName(name string) string {
+	return name + "-canary"
+}
+
+// FlaggerPrimary returns the primary Deployment Flagger created from the
+// gateway's Deployment, or nil before a Canary initialized it.
+func FlaggerPrimary(ctx context.Context, c client.Client, w GatewayWorkload) (*appsv1.Deployment, error) {
+	primary := &appsv1.Deployment{}
+	if err := getOwned(ctx, c, w, client.ObjectKey{Namespace: w.Namespace, Name: FlaggerPrimaryName(w.Name)}, primary); err != nil {
+		return nil, err
+	}
+	if primary.ResourceVersion == "" {
+		return nil, nil
+	}
+	return primary, nil
+}
+
+// ReconcileFlagger applies the primary and canary Services and the
+// error-rate and latency MetricTemplates of w when w.Flagger is set, and
+// deletes them otherwise. The Services are the ones Flagger would generate,
+// so the operator and Flagger agree on them. When the Flagger CRDs are not
+// installed, the error says so and reconcile retries until they are.
+//
+// On failure, the returned error is a *PhaseError tagged FlaggerPhase.
+func ReconcileFlagger(ctx context.Context, c client.Client, scheme *runtime.Scheme, w GatewayWorkload) error {
+	phaseErr := func(err error) error { return &PhaseError{Phase: FlaggerPhase, Err: err} }
+
+	if w.Flagger == nil {
+		// The canary Service goes last, so it marks the MetricTemplates for
+		// deletion without a lookup of the Flagger CRDs on every reconcile.
+		canary := &corev1.Service{}
+		if err := getOwned(ctx, c, w, client.ObjectKey{Namespace: w.Namespace, Name: FlaggerCanaryName(w.Name)}, canary); err != nil {
+			return phaseErr(err)
+		}
+		if canary.ResourceVersion == "" {
+			return nil
+		}
+		if served, err := kindServed(c, FlaggerMetricTemplateGVK); err != nil {
+			return phaseErr(err)
+		} else if served {
+			for _, suffix := range []string{FlaggerErrorRateSuffix, FlaggerLatencySuffix} {
+				template := &unstructured.Unstructured{}
+				template.SetGroupVersionKind(FlaggerMetricTemplateGVK)
+				if err := deleteOwned(ctx, c, w, template, w.Name+suffix); err != nil {
+					return phaseErr(err)
+				}
+			}
+		}
+		for _, name := range []string{FlaggerPrimaryName(w.Name), FlaggerCanaryName(w.Name)} {
+			if err := deleteOwned(ctx, c, w, &corev1.Service{}, name); err != nil {
+				return phaseErr(err)
+			}
+		}
+		return nil
+	}
+
+	for _, gvk := range []schema.GroupVersionKind{FlaggerCanaryGVK, FlaggerMetricTemplateGVK} {
+		if _, err := c.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version); err != nil {
+			if apimeta.IsNoMatchError(err) {
+				err = fmt.Errorf("%s needs Flagger, but %s is not served by this cluster", FlaggerAnnotation, gvk.GroupKind())
+			}
+			return phaseErr(err)
+		}
+	}
+	ownerRef, err := controllerReference(w.Owner, scheme)
+	if err != nil {
+		return phaseErr(err)
+	}
+	for _, name := range []string{FlaggerPrimaryName(w.Name), FlaggerCanaryName(w.Name)} {
+		selector := w.Name
+		if name == FlaggerPrimaryName(w.Name) {
+			selector = name
+		}
+		// Only the gateway's own Service carries its hostname.
+		service, err := buildService(w, ownerRef)
+		if err != nil {
+			return phaseErr(err)
+		}
+		delete(service.Annotations, ExternalDNSHostnameAnnotation)
+		service.WithName(name).Spec.WithSelector(map[string]string{"app": selector})
+		existing := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: w.Namespace}}
+		if err := apply(ctx, c, w, service, existing, "Service"); err != nil {
+			return phaseErr(err)
+		}
+	}
+	templates, err := BuildFlaggerMetricTemplates(w, scheme)
+	if err != nil {
+		return phaseErr(err)
+	}
+	for _, template := range templates {
+		if err := applyUnstructured(ctx, c, w, template); err != nil {
+			return phaseErr(err)
+		}
+	}
+	return nil
+}
+
+// BuildFlaggerMetricTemplates returns the desired state of the gateway's
+// MetricTemplates: the percentage of failed requests and the p99 latency in
+// milliseconds on the canary pods, from LiteLLM's Prometheus metrics. The
+// pod pattern is Flagger's: it matches the pods of the Deployment named
+// {{ target }}, but not those of its primary.
+func BuildFlaggerMetricTemplates(w GatewayWorkload, scheme *runtime.Scheme) ([]*unstructured.Unstructured, error) {
+	pods := `namespace="{{ namespace }}",pod=~"{{ target }}-[0-9a-zA-Z]+(-[0-9a-zA-Z]+)"`
+	queries := map[string]string{
+		FlaggerErrorRateSuffix: fmt.Sprintf("100 * (sum(rate(litellm_proxy_failed_requests_metric_total{%s}[{{ interval }}])) or vector(0))"+
+			" / clamp_min(sum(rate(litellm_proxy_total_requests_metric_total{%s}[{{ interval }}])) or vector(0), 1e-9)", pods, pods),
+		FlaggerLatencySuffix: fmt.Sprintf("1000 * histogram_quantile(0.99, sum(rate(litellm_request_total_latency_metric_bucket{%s}[{{ interval }}])) by (le))", pods),
+	}
+	var templates []*unstructured.Unstructured
+	for _, suffix := range []string{FlaggerErrorRateSuffix, FlaggerLatencySuffix} {
+		template, err := ownedUnstructured(w, scheme, FlaggerMetricTemplateGVK, w.Name+suffix)
+		if err != nil {
+			return nil, err
+		}
+		template.Object["spec"] = map[string]any{
+			"provider": map[string]any{"type": "prometheus", "address": w.Flagger.PrometheusAddress},
+			"query":    queries[suffix],
+		}
+		templates = append(templates, template)
+	}
+	return templates, nil
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestParseFlagger(t *testing.T) {
+	const prometheus = "http://prometheus.monitoring:9090"
+	cases := []struct {
+		annotations map[string]string
+		want        *Flagger
+		wantErr     bool
+	}{
+		{annotations: nil},
+		{annotations: map[string]string{FlaggerAnnotation: "false"}},
+		{
+			annotations: map[string]string{FlaggerAnnotation: "true", FlaggerPrometheusAnnotation: prometheus},
+			want:        &Flagger{PrometheusAddress: prometheus},
+		},
+		{
+			annotations: map[string]string{FlaggerAnnotation: "true", FlaggerPrometheusAnnotation: prometheus, RolloutStrategyAnnotation: RolloutStrategyRollingUpdate},
+			want:        &Flagger{PrometheusAddress: prometheus},
+		},
+		{annotations: map[string]string{FlaggerAnnotation: "yes-please"}, wantErr: true},
+		{annotations: map[string]string{FlaggerAnnotation: "true"}, wantErr: true},
+		{annotations: map[string]string{FlaggerAnnotation: "true", FlaggerPrometheusAnnotation: prometheus, RolloutStrategyAnnotation: RolloutStrategyBlueGreen}, wantErr: true},
+	}
+	for _, tc := range cases {
+		got, err := ParseFlagger(tc.annotations)
+		if tc.wantErr {
+			var pe *PhaseError
+			if !errors.As(err, &pe) || pe.Phase != FlaggerPhase {
+				t.Errorf("%v: want a %s PhaseError, got %v", tc.annotations, FlaggerPhase, err)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%v: got %+v, %v, want %+v", tc.annotations, got, err, tc.want)
+		}
+	}
+}
+
+func TestBuildFlaggerMetricTemplates(t *testing.T) {
+	s := workloadScheme(t)
+	w := GatewayWorkload{Name: "gw", Namespace: "default", Owner: newOwner("gw", "default"), Flagger: &Flagger{PrometheusAddress: "http://prom:9090"}}
+	templates, err := BuildFlaggerMetricTemplates(w, s)
+	if err != nil {
+		t.Fatalf("BuildFlaggerMetricTemplates: %v", err)
+	}
+	if len(templates) != 2 || templates[0].GetName() != "gw-error-rate" || templates[1].GetName() != "gw-latency" {
+		t.Fatalf("templates = %v", templates)
+	}
+	for _, template := range templates {
+		if template.GroupVersionKind() != FlaggerMetricTemplateGVK || template.GetLabels()[ManagedByLabel] == "" {
+			t.Errorf("%s: kind %s, labels %v", template.GetName(), template.GroupVersionKind(), template.GetLabels())
+		}
+		address, _, _ := unstructured.NestedString(template.Object, "spec", "provider", "address")
+		query, _, _ := unstructured.NestedString(template.Object, "spec", "query")
+		if address != "http://prom:9090" || !strings.Contains(query, `pod=~"{{ target }}-[0-9a-zA-Z]+(-[0-9a-zA-Z]+)"`) {
+			t.Errorf("%s: address %q, query %q", template.GetName(), address, query)
+		}
+	}
+}
+
+func TestReconcileFlagger(t *testing.T) {
+	ctx := context.Background()
+	s := workloadScheme(t)
+	owner := newOwner("gw", "default")
+	c := fake.NewClientBuilder().WithScheme(s).WithRESTMapper(flaggerRESTMapper(s)).WithObjects(owner).Build()
+	w := GatewayWorkload{
+		Name: "gw", Namespace: "default", Owner: owner, ContainerPort: 4000, ServicePort: 4000,
+		ConfigYAML: "model_list: []\n", Flagger: &Flagger{PrometheusAddress: "http://prom:9090"},
+		Hostname: "llm.example.com",
+	}
+
+	if err := ReconcileWorkload(ctx, c, s, w); err != nil {
+		t.Fatalf("ReconcileWorkload: %v", err)
+	}
+	if err := ReconcileFlagger(ctx, c, s, w); err != nil {
+		t.Fatalf("ReconcileFlagger: %v", err)
+	}
+	for name, app := range map[string]string{"gw": "gw", "gw-primary": "gw-primary", "gw-canary": "gw"} {
+		svc := &corev1.Service{}
+		if err := c.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, svc); err != nil {
+			t.Fatalf("get Service %s: %v", name, err)
+		}
+		if svc.Spec.Selector["app"] != app {
+			t.Errorf("Service %s selects app=%s, want %s", name, svc.Spec.Selector["app"], app)
+		}
+		if hostname, ok := svc.Annotations[ExternalDNSHostnameAnnotation]; ok != (name == "gw") {
+			t.Errorf("Service %s has external-dns hostname %q", name, hostname)
+		}
+	}
+	templates := []*unstructured.Unstructured{}
+	for _, name := range []string{"gw-error-rate", "gw-latency"} {
+		template := &unstructured.Unstructured{}
+		template.SetGroupVersionKind(FlaggerMetricTemplateGVK)
+		if err := c.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, template); err != nil {
+			t.Fatalf("get MetricTemplate %s: %v", name, err)
+		}
+		templates = append(templates, template)
+	}
+
+	// Once the Canary created the primary, the gateway's Service routes to it.
+	primary := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "gw-primary", Namespace: "default"}}
+	if err := c.Create(ctx, primary); err != nil {
+		t.Fatalf("create primary: %v", err)
+	}
+	if err := ReconcileWorkload(ctx, c, s, w); err != nil {
+		t.Fatalf("ReconcileWorkload: %v", err)
+	}
+	svc := &corev1.Service{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "gw"}, svc); err != nil {
+		t.Fatalf("get Service: %v", err)
+	}
+	if svc.Spec.Selector["app"] != "gw-primary" {
+		t.Errorf("Service selects app=%s, want gw-primary", svc.Spec.Selector["app"])
+	}
+
+	w.Flagger = nil
+	if err := ReconcileFlagger(ctx, c, s, w); err != nil {
+		t.Fatalf("ReconcileFlagger disabled: %v", err)
+	}
+	for _, name := range []string{"gw-primary", "gw-canary"} {
+		if err := c.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, &corev1.Service{}); !apierrors.IsNotFound(err) {
+			t.Errorf("Service %s must be deleted, got %v", name, err)
+		}
+	}
+	for _, template := range templates {
+		if err := c.Get(ctx, client.ObjectKeyFromObject(template), template.DeepCopy()); !apierrors.IsNotFound(err) {
+			t.Errorf("MetricTemplate %s must be deleted, got %v", template.GetName(), err)
+		}
+	}
+}
+
+func TestReconcileFlagger_WithoutFlagger(t *testing.T) {
+	s := workloadScheme(t)
+	owner := newOwner("gw", "default")
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(owner).Build()
+	w := GatewayWorkload{Name: "gw", Namespace: "default", Owner: owner, Flagger: &Flagger{PrometheusAddress: "http://prom:9090"}}
+
+	err := ReconcileFlagger(context.Background(), c, s, w)
+	var pe *PhaseError
+	if !errors.As(err, &pe) || pe.Phase != FlaggerPhase || !strings.Contains(err.Error(), "needs Flagger") {
+		t.Fatalf("want a %s PhaseError naming Flagger, got %v", FlaggerPhase, err)
+	}
+	w.Flagger = nil
+	if err := ReconcileFlagger(context.Background(), c, s, w); err != nil {
+		t.Errorf("ReconcileFlagger disabled without Flagger: %v", err)
+	}
+}
+
+func TestKindServed(t *testing.T) {
+	s := workloadScheme(t)
+	mapper := flaggerRESTMapper(s)
+	c := fake.NewClientBuilder().WithScheme(s).WithRESTMapper(mapper).Build()
+
+	if served, err := kindServed(c, FlaggerCanaryGVK); err != nil || !served {
+		t.Errorf("Canary: served = %v, %v", served, err)
+	}
+	other := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}
+	if served, err := kindServed(c, other); err != nil || served {
+		t.Errorf("Widget: served = %v, %v", served, err)
+	}
+	// A missing kind is remembered, even once it is served.
+	mapper.Add(other, meta.RESTScopeNamespace)
+	if served, _ := kindServed(c, other); served {
+		t.Error("a missing kind must be remembered for a while")
+	}
+}
+
+// flaggerRESTMapper maps the kinds of s and Flagger's.
+func flaggerRESTMapper(s *runtime.Scheme) *meta.DefaultRESTMapper {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	for gvk := range s.AllKnownTypes() {
+		mapper.Add(gvk, meta.RESTScopeNamespace)
+	}
+	mapper.Add(FlaggerCanaryGVK, meta.RESTScopeNamespace)
+	mapper.Add(FlaggerMetricTemplateGVK, meta.RESTScopeNamespace)
+	return mapper
+}
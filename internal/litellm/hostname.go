@@ -0,0 +1,62 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// HostnameAnnotation publishes the gateway's Service under a DNS name
+// through external-dns. A single label is a name under the operator's DNS
+// domain; a name with dots is used as is.
+const HostnameAnnotation = "ai-gateway-litellm.agentic-layer.ai/hostname"
+
+// ExternalDNSHostnameAnnotation is the Service annotation external-dns
+// creates records for.
+const ExternalDNSHostnameAnnotation = "external-dns.alpha.kubernetes.io/hostname"
+
+// HostnamePhase tags an invalid hostname annotation.
+const HostnamePhase = "Hostname"
+
+// ParseHostname returns the fully qualified name HostnameAnnotation resolves
+// to under domain, or "" when the annotation is unset. Invalid names, and
+// single labels without a domain, yield a *PhaseError.
+func ParseHostname(annotations map[string]string, domain string) (string, error) {
+	phaseErr := func(format string, args ...any) error {
+		return &PhaseError{Phase: HostnamePhase, Err: fmt.Errorf(format, args...)}
+	}
+	v, ok := annotations[HostnameAnnotation]
+	if !ok {
+		return "", nil
+	}
+	fqdn := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(v)), ".")
+	if !strings.Contains(fqdn, ".") {
+		domain = strings.Trim(strings.ToLower(domain), ".")
+		if domain == "" {
+			return "", phaseErr("%s %q is a single label, but the operator has no DNS domain to put it under; "+
+				"use a fully qualified name or start the operator with --dns-domain", HostnameAnnotation, v)
+		}
+		fqdn += "." + domain
+	}
+	if errs := validation.IsDNS1123Subdomain(fqdn); len(errs) > 0 {
+		return "", phaseErr("invalid %s annotation %q: %s", HostnameAnnotation, v, strings.Join(errs, "; "))
+	}
+	return fqdn, nil
+}
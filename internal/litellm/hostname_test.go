@@ -0,0 +1,66 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"errors"
+	"testing"
+
+	metav1ac "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
+func TestParseHostname(t *testing.T) {
+	cases := []struct {
+		value, domain string
+		want          string
+		wantErr       bool
+	}{
+		{value: "llm.example.com", want: "llm.example.com"},
+		{value: "LLM.Example.com.", domain: "gateways.example.org", want: "llm.example.com"},
+		{value: "team-a", domain: "gateways.example.com.", want: "team-a.gateways.example.com"},
+		{value: "team-a", wantErr: true},
+		{value: "", domain: "gateways.example.com", wantErr: true},
+		{value: "llm_gw.example.com", wantErr: true},
+	}
+	for _, tc := range cases {
+		got, err := ParseHostname(map[string]string{HostnameAnnotation: tc.value}, tc.domain)
+		if tc.wantErr {
+			var pe *PhaseError
+			if !errors.As(err, &pe) || pe.Phase != HostnamePhase {
+				t.Errorf("%q under %q: want a %s PhaseError, got %q, %v", tc.value, tc.domain, HostnamePhase, got, err)
+			}
+			continue
+		}
+		if err != nil || got != tc.want {
+			t.Errorf("%q under %q: got %q, %v, want %q", tc.value, tc.domain, got, err, tc.want)
+		}
+	}
+	if got, err := ParseHostname(nil, "gateways.example.com"); got != "" || err != nil {
+		t.Errorf("unset: got %q, %v", got, err)
+	}
+}
+
+func TestBuildService_Hostname(t *testing.T) {
+	w := GatewayWorkload{Name: "gw", Namespace: "default", ServicePort: 4000, ContainerPort: 4000}
+	if _, ok := BuildService(w, metav1ac.OwnerReference()).Annotations[ExternalDNSHostnameAnnotation]; ok {
+		t.Error("a gateway without a hostname must not get the external-dns annotation")
+	}
+	w.Hostname = "llm.example.com"
+	if got := BuildService(w, metav1ac.OwnerReference()).Annotations[ExternalDNSHostnameAnnotation]; got != "llm.example.com" {
+		t.Errorf("external-dns hostname = %q, want llm.example.com", got)
+	}
+}
@@ -0,0 +1,411 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// ImagePolicyAnnotation, set on an AiGatewayClass, selects how the LiteLLM
+// image of its gateways is resolved. One of the ImagePolicy values; unset
+// means ImagePolicyTag.
+const ImagePolicyAnnotation = "ai-gateway-litellm.agentic-layer.ai/image-policy"
+
+// Image policies.
+const (
+	// ImagePolicyTag runs the image by tag, as the registry serves it when
+	// a pod is created.
+	ImagePolicyTag = "Tag"
+	// ImagePolicyDigest pins the tag to the digest it points at.
+	ImagePolicyDigest = "Digest"
+	// ImagePolicyPatchUpdates moves to the newest patch release within the
+	// tag's minor version, and pins it to its digest.
+	ImagePolicyPatchUpdates = "PatchUpdates"
+	// ImagePolicyMinorUpdates moves to the newest minor or patch release
+	// within the tag's major version, and pins it to its digest.
+	ImagePolicyMinorUpdates = "MinorUpdates"
+)
+
+// UpgradePolicyAnnotation, set on an AiGatewayClass, selects which newer
+// LiteLLM releases its gateways are upgraded to. One of the UpgradePolicy
+// values; unset means UpgradePolicyNone.
+const UpgradePolicyAnnotation = "ai-gateway-litellm.agentic-layer.ai/upgrade-policy"
+
+// Upgrade policies.
+const (
+	// UpgradePolicyNone leaves the release to the image policy.
+	UpgradePolicyNone = "None"
+	// UpgradePolicyPatch upgrades to new patch releases, as
+	// ImagePolicyPatchUpdates.
+	UpgradePolicyPatch = "Patch"
+	// UpgradePolicyMinor upgrades to new minor and patch releases, as
+	// ImagePolicyMinorUpdates.
+	UpgradePolicyMinor = "Minor"
+)
+
+// ImagePolicyPhase tags an invalid image policy.
+const ImagePolicyPhase = "ImagePolicy"
+
+// ParseImagePolicy returns the image policy of the class annotations: that
+// of UpgradePolicyAnnotation if it upgrades, else that of
+// ImagePolicyAnnotation. Invalid values yield a *PhaseError.
+func ParseImagePolicy(annotations map[string]string) (string, error) {
+	var policy string
+	switch v := annotations[ImagePolicyAnnotation]; v {
+	case "", ImagePolicyTag:
+		policy = ImagePolicyTag
+	case ImagePolicyDigest, ImagePolicyPatchUpdates, ImagePolicyMinorUpdates:
+		policy = v
+	default:
+		return "", &PhaseError{Phase: ImagePolicyPhase, Err: fmt.Errorf(
+			"invalid %s annotation %q: must be %s, %s, %s or %s", ImagePolicyAnnotation, v,
+			ImagePolicyTag, ImagePolicyDigest, ImagePolicyPatchUpdates, ImagePolicyMinorUpdates)}
+	}
+	switch v := annotations[UpgradePolicyAnnotation]; v {
+	case "":
+		return policy, nil
+	case UpgradePolicyNone:
+		if policy == ImagePolicyPatchUpdates || policy == ImagePolicyMinorUpdates {
+			return "", &PhaseError{Phase: ImagePolicyPhase, Err: fmt.Errorf(
+				"%s %s conflicts with %s %s", UpgradePolicyAnnotation, v, ImagePolicyAnnotation, policy)}
+		}
+		return policy, nil
+	case UpgradePolicyPatch:
+		if policy == ImagePolicyMinorUpdates {
+			return "", &PhaseError{Phase: ImagePolicyPhase, Err: fmt.Errorf(
+				"%s %s conflicts with %s %s", UpgradePolicyAnnotation, v, ImagePolicyAnnotation, policy)}
+		}
+		return ImagePolicyPatchUpdates, nil
+	case UpgradePolicyMinor:
+		return ImagePolicyMinorUpdates, nil
+	default:
+		return "", &PhaseError{Phase: ImagePolicyPhase, Err: fmt.Errorf(
+			"invalid %s annotation %q: must be %s, %s or %s", UpgradePolicyAnnotation, v,
+			UpgradePolicyNone, UpgradePolicyPatch, UpgradePolicyMinor)}
+	}
+}
+
+// ImageResolver resolves image tags to digests through the registry's
+// distribution API. Results are kept for Interval, so the registry is not
+// asked on every reconcile. It is safe for concurrent use.
+type ImageResolver struct {
+	// Client talks to the registries. Nil uses http.DefaultClient.
+	Client *http.Client
+	// Interval is how long a resolved image is used before the registry
+	// is asked again.
+	Interval time.Duration
+
+	mu       sync.Mutex
+	resolved map[string]resolvedImage
+}
+
+type resolvedImage struct {
+	image string
+	at    time.Time
+}
+
+// Resolve returns image, a reference with a tag, resolved according to
+// policy: as is for ImagePolicyTag, otherwise as repository:tag@digest. When
+// the registry cannot be asked, the error is returned along with the last
+// image resolved for the same input, or "" if there is none.
+func (r *ImageResolver) Resolve(ctx context.Context, image, policy string) (string, error) {
+	if policy == ImagePolicyTag {
+		return image, nil
+	}
+	key := policy + " " + image
+	r.mu.Lock()
+	last, ok := r.resolved[key]
+	r.mu.Unlock()
+	if ok && time.Since(last.at) < r.Interval {
+		return last.image, nil
+	}
+
+	resolved, err := r.resolve(ctx, image, policy)
+	if err != nil {
+		return last.image, fmt.Errorf("resolving %s: %w", image, err)
+	}
+	r.mu.Lock()
+	if r.resolved == nil {
+		r.resolved = map[string]resolvedImage{}
+	}
+	r.resolved[key] = resolvedImage{image: resolved, at: time.Now()}
+	r.mu.Unlock()
+	return resolved, nil
+}
+
+func (r *ImageResolver) resolve(ctx context.Context, image, policy string) (string, error) {
+	registry, repository, tag, err := parseImageReference(image)
+	if err != nil {
+		return "", err
+	}
+	c := &registryClient{http: r.Client, registry: registry, repository: repository}
+	if c.http == nil {
+		c.http = http.DefaultClient
+	}
+	if policy == ImagePolicyPatchUpdates || policy == ImagePolicyMinorUpdates {
+		tags, err := c.tags(ctx)
+		if err != nil {
+			return "", err
+		}
+		if tag, err = latestRelease(tag, tags, policy == ImagePolicyMinorUpdates); err != nil {
+			return "", err
+		}
+	}
+	digest, err := c.digest(ctx, tag)
+	if err != nil {
+		return "", err
+	}
+	name := image[:strings.LastIndex(image, ":")]
+	return name + ":" + tag + "@" + digest, nil
+}
+
+// parseImageReference splits a reference with a tag into the registry host,
+// the repository and the tag, applying Docker Hub's defaults.
+func parseImageReference(image string) (registry, repository, tag string, err error) {
+	if strings.Contains(image, "@") {
+		return "", "", "", fmt.Errorf("image %s is already pinned to a digest", image)
+	}
+	i := strings.LastIndex(image, ":")
+	if i < 0 || strings.Contains(image[i:], "/") {
+		return "", "", "", fmt.Errorf("image %s has no tag", image)
+	}
+	name, tag := image[:i], image[i+1:]
+	registry, repository = "registry-1.docker.io", name
+	if first, rest, ok := strings.Cut(name, "/"); ok && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		registry, repository = first, rest
+	}
+	if registry == "registry-1.docker.io" && !strings.Contains(repository, "/") {
+		repository = "library/" + repository
+	}
+	return registry, repository, tag, nil
+}
+
+// MirrorImage returns image as pulled through the registry mirror, a
+// registry host optionally followed by a path, e.g.
+// registry.example.com/mirror. The registry of image is replaced by mirror,
+// keeping the repository, tag and digest: ghcr.io/berriai/litellm:v1
+// becomes registry.example.com/mirror/berriai/litellm:v1, and Docker Hub's
+// redis:7 becomes registry.example.com/mirror/library/redis:7. An empty
+// mirror, or an image already under mirror, returns image unchanged.
+func MirrorImage(mirror, image string) string {
+	mirror = strings.TrimSuffix(mirror, "/")
+	if mirror == "" || strings.HasPrefix(image, mirror+"/") {
+		return image
+	}
+	repository := image
+	if first, rest, ok := strings.Cut(image, "/"); ok && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		repository = rest
+	} else if !ok {
+		repository = "library/" + image
+	}
+	return mirror + "/" + repository
+}
+
+// latestPatch returns the highest of tags with the major and minor version
+// of current, no lower than current. Only tags of the same channel count,
+// i.e. whose pre-release starts with the same identifier as current's, so a
+// -stable tag only moves to newer -stable tags.
+func latestPatch(current string, tags []string) (string, error) {
+	return latestRelease(current, tags, false)
+}
+
+// latestRelease is latestPatch, also moving to newer minor versions when
+// minor is set.
+func latestRelease(current string, tags []string, minor bool) (string, error) {
+	base, err := semver.NewVersion(current)
+	if err != nil {
+		return "", fmt.Errorf("tag %s is not a semantic version: %w", current, err)
+	}
+	channel := func(v *semver.Version) string {
+		first, _, _ := strings.Cut(v.Prerelease(), ".")
+		return first
+	}
+	best, bestTag := base, current
+	for _, tag := range tags {
+		v, err := semver.NewVersion(tag)
+		if err != nil || v.Major() != base.Major() || (!minor && v.Minor() != base.Minor()) || channel(v) != channel(base) {
+			continue
+		}
+		if v.GreaterThan(best) {
+			best, bestTag = v, tag
+		}
+	}
+	return bestTag, nil
+}
+
+// registryClient calls the distribution API of one repository, fetching an
+// anonymous bearer token when the registry asks for one.
+type registryClient struct {
+	http                 *http.Client
+	registry, repository string
+	token                string
+}
+
+// manifestMediaTypes are the manifest types asked for. Indexes come first,
+// so a multi-arch image resolves to the digest of its index.
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// digest returns the digest of the manifest tag points at.
+func (c *registryClient) digest(ctx context.Context, tag string) (string, error) {
+	resp, err := c.get(ctx, "/v2/"+c.repository+"/manifests/"+tag, strings.Join(manifestMediaTypes, ", "))
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if digest := resp.Header.Get("Docker-Content-Digest"); strings.HasPrefix(digest, "sha256:") {
+		return digest, nil
+	}
+	h := sha256.New()
+	if _, err := io.Copy(h, resp.Body); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("sha256:%x", h.Sum(nil)), nil
+}
+
+// tags returns every tag of the repository, following the pagination links.
+func (c *registryClient) tags(ctx context.Context) ([]string, error) {
+	var tags []string
+	path := "/v2/" + c.repository + "/tags/list?n=1000"
+	for path != "" {
+		resp, err := c.get(ctx, path, "application/json")
+		if err != nil {
+			return nil, err
+		}
+		var page struct {
+			Tags []string `json:"tags"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("decoding tags: %w", err)
+		}
+		tags = append(tags, page.Tags...)
+		path = nextLink(resp.Header.Get("Link"))
+	}
+	return tags, nil
+}
+
+// nextLink returns the target of a Link header with rel="next", or "".
+func nextLink(header string) string {
+	for link := range strings.SplitSeq(header, ",") {
+		target, params, ok := strings.Cut(strings.TrimSpace(link), ";")
+		if ok && strings.Contains(params, `rel="next"`) {
+			return strings.Trim(strings.TrimSpace(target), "<>")
+		}
+	}
+	return ""
+}
+
+// get returns the successful response to a GET of path, authenticating once
+// if the registry answers 401.
+func (c *registryClient) get(ctx context.Context, path, accept string) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://"+c.registry+path, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", accept)
+		if c.token != "" {
+			req.Header.Set("Authorization", "Bearer "+c.token)
+		}
+		resp, err := c.http.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode == http.StatusOK {
+			return resp, nil
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode == http.StatusUnauthorized && attempt == 0 {
+			if err := c.authenticate(ctx, resp.Header.Get("WWW-Authenticate")); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		return nil, fmt.Errorf("GET %s on %s: %s", path, c.registry, resp.Status)
+	}
+}
+
+// authenticate fetches an anonymous pull token from the realm of a Bearer
+// challenge.
+func (c *registryClient) authenticate(ctx context.Context, challenge string) error {
+	scheme, rest, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return fmt.Errorf("%s asks for %q authentication; only anonymous bearer tokens are supported", c.registry, scheme)
+	}
+	params := map[string]string{}
+	for param := range strings.SplitSeq(rest, ",") {
+		if k, v, ok := strings.Cut(strings.TrimSpace(param), "="); ok {
+			params[k] = strings.Trim(v, `"`)
+		}
+	}
+	realm, err := url.Parse(params["realm"])
+	if err != nil || realm.Scheme == "" {
+		return fmt.Errorf("%s sent an invalid token realm %q", c.registry, params["realm"])
+	}
+	query := realm.Query()
+	if params["service"] != "" {
+		query.Set("service", params["service"])
+	}
+	scope := params["scope"]
+	if scope == "" {
+		scope = "repository:" + c.repository + ":pull"
+	}
+	query.Set("scope", scope)
+	realm.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("fetching a token for %s: %s", c.registry, resp.Status)
+	}
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return fmt.Errorf("decoding the token of %s: %w", c.registry, err)
+	}
+	c.token = token.Token
+	if c.token == "" {
+		c.token = token.AccessToken
+	}
+	return nil
+}
@@ -0,0 +1,256 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1ac "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
+func TestParseImagePolicy(t *testing.T) {
+	for v, want := range map[string]string{"": ImagePolicyTag, "Digest": ImagePolicyDigest, "PatchUpdates": ImagePolicyPatchUpdates} {
+		if got, err := ParseImagePolicy(map[string]string{ImagePolicyAnnotation: v}); err != nil || got != want {
+			t.Errorf("%q: got %q, %v, want %q", v, got, err, want)
+		}
+	}
+	var pe *PhaseError
+	if _, err := ParseImagePolicy(map[string]string{ImagePolicyAnnotation: "Latest"}); !errors.As(err, &pe) || pe.Phase != ImagePolicyPhase {
+		t.Errorf("want a %s PhaseError, got %v", ImagePolicyPhase, err)
+	}
+}
+
+func TestParseImagePolicy_UpgradePolicy(t *testing.T) {
+	for _, tc := range []struct {
+		image, upgrade, want string
+	}{
+		{upgrade: "", want: ImagePolicyTag},
+		{upgrade: UpgradePolicyNone, want: ImagePolicyTag},
+		{image: ImagePolicyDigest, upgrade: UpgradePolicyNone, want: ImagePolicyDigest},
+		{upgrade: UpgradePolicyPatch, want: ImagePolicyPatchUpdates},
+		{image: ImagePolicyDigest, upgrade: UpgradePolicyMinor, want: ImagePolicyMinorUpdates},
+		{image: ImagePolicyPatchUpdates, upgrade: UpgradePolicyMinor, want: ImagePolicyMinorUpdates},
+		{image: ImagePolicyPatchUpdates, upgrade: UpgradePolicyNone},
+		{image: ImagePolicyMinorUpdates, upgrade: UpgradePolicyPatch},
+		{upgrade: "Major"},
+	} {
+		annotations := map[string]string{UpgradePolicyAnnotation: tc.upgrade}
+		if tc.image != "" {
+			annotations[ImagePolicyAnnotation] = tc.image
+		}
+		got, err := ParseImagePolicy(annotations)
+		if tc.want == "" {
+			var pe *PhaseError
+			if !errors.As(err, &pe) || pe.Phase != ImagePolicyPhase {
+				t.Errorf("%v: want a %s PhaseError, got %q, %v", annotations, ImagePolicyPhase, got, err)
+			}
+			continue
+		}
+		if err != nil || got != tc.want {
+			t.Errorf("%v: got %q, %v, want %q", annotations, got, err, tc.want)
+		}
+	}
+}
+
+func TestParseImageReference(t *testing.T) {
+	for image, want := range map[string][3]string{
+		"ghcr.io/berriai/litellm:v1.83.14-stable": {"ghcr.io", "berriai/litellm", "v1.83.14-stable"},
+		"registry.local:5000/litellm:v1":          {"registry.local:5000", "litellm", "v1"},
+		"redis:7.4-alpine":                        {"registry-1.docker.io", "library/redis", "7.4-alpine"},
+		"bitnami/redis:7":                         {"registry-1.docker.io", "bitnami/redis", "7"},
+	} {
+		registry, repository, tag, err := parseImageReference(image)
+		if err != nil || [3]string{registry, repository, tag} != want {
+			t.Errorf("%s: got %s %s %s, %v, want %v", image, registry, repository, tag, err, want)
+		}
+	}
+	for _, image := range []string{"registry.local:5000/litellm", "litellm@sha256:abc"} {
+		if _, _, _, err := parseImageReference(image); err == nil {
+			t.Errorf("%s: want an error", image)
+		}
+	}
+}
+
+func TestMirrorImage(t *testing.T) {
+	mirror := "registry.example.com/mirror/"
+	for image, want := range map[string]string{
+		"ghcr.io/berriai/litellm:v1":            "registry.example.com/mirror/berriai/litellm:v1",
+		"ghcr.io/berriai/litellm:v1@sha256:abc": "registry.example.com/mirror/berriai/litellm:v1@sha256:abc",
+		"registry.local:5000/litellm:v1":        "registry.example.com/mirror/litellm:v1",
+		"redis:7.4-alpine":                      "registry.example.com/mirror/library/redis:7.4-alpine",
+		"amazon/aws-cli:2":                      "registry.example.com/mirror/amazon/aws-cli:2",
+		"registry.example.com/mirror/redis:7":   "registry.example.com/mirror/redis:7",
+	} {
+		if got := MirrorImage(mirror, image); got != want {
+			t.Errorf("%s: got %s, want %s", image, got, want)
+		}
+	}
+	if got := MirrorImage("", "redis:7"); got != "redis:7" {
+		t.Errorf("without a mirror: got %s", got)
+	}
+}
+
+func TestGatewayWorkload_RegistryMirror(t *testing.T) {
+	w := GatewayWorkload{Name: "gw", Namespace: "default", ContainerPort: 4000, RegistryMirror: "mirror.local"}
+	ownerRef := metav1ac.OwnerReference().WithName("gw")
+	for name, image := range map[string]*string{
+		"litellm": BuildDeployment(w, ownerRef, "c", "s").Spec.Template.Spec.Containers[0].Image,
+		"redis":   BuildRedisDeployment(w, ownerRef, "p").Spec.Template.Spec.Containers[0].Image,
+		"warm-up": BuildWarmUpJob(w, ownerRef, "c", []string{"gpt"}, corev1.EnvVar{Name: "KEY"}).Spec.Template.Spec.Containers[0].Image,
+	} {
+		if !strings.HasPrefix(*image, "mirror.local/") {
+			t.Errorf("%s image = %s, want it pulled through the mirror", name, *image)
+		}
+	}
+
+	w.Image = "mirror.local/berriai/litellm:v1@sha256:abc"
+	if got := w.image(); got != w.Image {
+		t.Errorf("a resolved image under the mirror must be kept, got %s", got)
+	}
+}
+
+func TestLatestPatch(t *testing.T) {
+	tags := []string{
+		"v1.83.14-stable", "v1.83.14-stable.patch.2", "v1.83.14-stable.patch.10", "v1.83.15-stable",
+		"v1.83.16-nightly", "v1.83.17", "v1.84.0-stable", "main-latest",
+	}
+	for current, want := range map[string]string{
+		"v1.83.14-stable.patch.2": "v1.83.15-stable",
+		"v1.83.15-stable":         "v1.83.15-stable",
+		"v1.83.14":                "v1.83.17",
+		"v1.84.0-stable":          "v1.84.0-stable",
+	} {
+		if got, err := latestPatch(current, tags); err != nil || got != want {
+			t.Errorf("%s: got %s, %v, want %s", current, got, err, want)
+		}
+	}
+	if _, err := latestPatch("main-latest", tags); err == nil {
+		t.Error("a tag that is not a version must be an error")
+	}
+}
+
+func TestLatestRelease_Minor(t *testing.T) {
+	tags := []string{"v1.83.14-stable", "v1.83.15-stable", "v1.84.0-stable", "v1.84.2-stable", "v1.85.0-nightly", "v2.0.0-stable"}
+	for current, want := range map[string]string{
+		"v1.83.14-stable": "v1.84.2-stable",
+		"v1.84.2-stable":  "v1.84.2-stable",
+		"v2.0.0-stable":   "v2.0.0-stable",
+	} {
+		if got, err := latestRelease(current, tags, true); err != nil || got != want {
+			t.Errorf("%s: got %s, %v, want %s", current, got, err, want)
+		}
+	}
+}
+
+// fakeRegistry serves the tags and manifest digests of one repository
+// behind an anonymous token, two tags per page.
+func fakeRegistry(t *testing.T, repository string, digests map[string]string) (*httptest.Server, *int) {
+	t.Helper()
+	requests := 0
+	var srv *httptest.Server
+	srv = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path == "/token" {
+			if r.URL.Query().Get("scope") != "repository:"+repository+":pull" {
+				http.Error(w, "bad scope", http.StatusBadRequest)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]string{"token": "anonymous"})
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer anonymous" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="`+srv.URL+`/token",service="fake",scope="repository:`+repository+`:pull"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.URL.Path == "/v2/"+repository+"/tags/list":
+			var tags []string
+			for tag := range digests {
+				tags = append(tags, tag)
+			}
+			start := 0
+			if last := r.URL.Query().Get("last"); last != "" {
+				for i, tag := range tags {
+					if tag == last {
+						start = i + 1
+					}
+				}
+			}
+			end := min(start+2, len(tags))
+			if end < len(tags) {
+				w.Header().Set("Link", `</v2/`+repository+`/tags/list?n=2&last=`+tags[end-1]+`>; rel="next"`)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"name": repository, "tags": tags[start:end]})
+		case strings.HasPrefix(r.URL.Path, "/v2/"+repository+"/manifests/"):
+			digest, ok := digests[strings.TrimPrefix(r.URL.Path, "/v2/"+repository+"/manifests/")]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			w.Header().Set("Docker-Content-Digest", digest)
+			_, _ = w.Write([]byte("{}"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &requests
+}
+
+func TestImageResolver(t *testing.T) {
+	srv, requests := fakeRegistry(t, "berriai/litellm", map[string]string{
+		"v1.83.14-stable": "sha256:14", "v1.83.15-stable": "sha256:15", "v1.84.0-stable": "sha256:84",
+	})
+	registry := strings.TrimPrefix(srv.URL, "https://")
+	image := registry + "/berriai/litellm:v1.83.14-stable"
+	r := &ImageResolver{Client: srv.Client(), Interval: time.Hour}
+	ctx := context.Background()
+
+	if got, err := r.Resolve(ctx, image, ImagePolicyTag); err != nil || got != image {
+		t.Errorf("Tag: got %s, %v", got, err)
+	}
+	if got, err := r.Resolve(ctx, image, ImagePolicyDigest); err != nil || got != image+"@sha256:14" {
+		t.Errorf("Digest: got %s, %v", got, err)
+	}
+	want := registry + "/berriai/litellm:v1.83.15-stable@sha256:15"
+	if got, err := r.Resolve(ctx, image, ImagePolicyPatchUpdates); err != nil || got != want {
+		t.Errorf("PatchUpdates: got %s, %v, want %s", got, err, want)
+	}
+
+	// Within the interval, the registry is not asked again.
+	before := *requests
+	if got, _ := r.Resolve(ctx, image, ImagePolicyPatchUpdates); got != want || *requests != before {
+		t.Errorf("cached: got %s after %d requests", got, *requests-before)
+	}
+
+	// An unreachable registry keeps the last resolution.
+	r.Interval = 0
+	srv.Close()
+	got, err := r.Resolve(ctx, image, ImagePolicyPatchUpdates)
+	if err == nil || got != want {
+		t.Errorf("unreachable: got %s, %v, want %s and an error", got, err, want)
+	}
+}
@@ -0,0 +1,300 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// InferencePoolAnnotation, set to "true", exposes the gateway through the
+// Gateway API Inference Extension: an InferencePool selects its pods, so
+// HTTPRoutes of conformant gateways can route to it, and an InferenceModel
+// announces each of its models where that kind is served.
+const InferencePoolAnnotation = "ai-gateway-litellm.agentic-layer.ai/inference-pool"
+
+// InferencePoolEndpointPickerAnnotation names the Service of the endpoint
+// picker extension the InferencePool delegates endpoint selection to, as
+// name or name:port. It is required with InferencePoolAnnotation.
+const InferencePoolEndpointPickerAnnotation = "ai-gateway-litellm.agentic-layer.ai/inference-pool-endpoint-picker"
+
+// InferencePoolPhase tags invalid inference-pool annotations and failures
+// applying the inference extension objects, including a cluster without
+// the inference extension CRDs.
+const InferencePoolPhase = "InferencePool"
+
+// DefaultEndpointPickerPort is the gRPC port of the reference endpoint
+// picker, used when the annotation names no port.
+const DefaultEndpointPickerPort = 9002
+
+// GVKs of the inference extension kinds the operator detects and creates.
+// The InferencePool went GA in inference.networking.k8s.io; clusters with
+// an older release of the extension only serve the alpha version.
+var (
+	InferencePoolGVK      = schema.GroupVersionKind{Group: "inference.networking.k8s.io", Version: "v1", Kind: "InferencePool"}
+	InferencePoolAlphaGVK = schema.GroupVersionKind{Group: "inference.networking.x-k8s.io", Version: "v1alpha2", Kind: "InferencePool"}
+	InferenceModelGVK     = schema.GroupVersionKind{Group: "inference.networking.x-k8s.io", Version: "v1alpha2", Kind: "InferenceModel"}
+)
+
+// inferencePoolGVKs are the InferencePool kinds in order of preference.
+var inferencePoolGVKs = []schema.GroupVersionKind{InferencePoolGVK, InferencePoolAlphaGVK}
+
+// invalidObjectNameChars are the runs of characters a lowercased model name
+// cannot carry into an object name.
+var invalidObjectNameChars = regexp.MustCompile(`[^a-z0-9.-]+`)
+
+// inferenceModelHashChars is the length of the hash suffix of sanitized
+// InferenceModel names.
+const inferenceModelHashChars = 8
+
+// InferencePool configures the inference extension objects of a
+// GatewayWorkload.
+type InferencePool struct {
+	// EndpointPicker is the name of the endpoint picker Service.
+	EndpointPicker string
+	// EndpointPickerPort is the port the endpoint picker listens on.
+	EndpointPickerPort int32
+	// Models are the model names the gateway serves, one InferenceModel each.
+	Models []string
+}
+
+// ParseInferencePool returns the InferencePool the annotations ask for, or
+// nil when InferencePoolAnnotation is unset or false. The pool selects the
+// pods of the gateway's Deployment, so it cannot be combined with Flagger,
+// whose primary pods carry another label. Invalid values yield a
+// *PhaseError.
+func ParseInferencePool(annotations map[string]string) (*InferencePool, error) {
+	phaseErr := func(format string, args ...any) error {
+		return &PhaseError{Phase: InferencePoolPhase, Err: fmt.Errorf(format, args...)}
+	}
+	v, ok := annotations[InferencePoolAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	enabled, err := strconv.ParseBool(v)
+	if err != nil {
+		return nil, phaseErr("invalid %s annotation %q: must be \"true\" or \"false\"", InferencePoolAnnotation, v)
+	}
+	if !enabled {
+		return nil, nil
+	}
+	if flagger, _ := strconv.ParseBool(annotations[FlaggerAnnotation]); flagger {
+		return nil, phaseErr("%s conflicts with %s: the pool would select the canary pods only",
+			InferencePoolAnnotation, FlaggerAnnotation)
+	}
+	picker := annotations[InferencePoolEndpointPickerAnnotation]
+	if picker == "" {
+		return nil, phaseErr("%s needs the %s annotation naming the endpoint picker Service",
+			InferencePoolAnnotation, InferencePoolEndpointPickerAnnotation)
+	}
+	pool := &InferencePool{EndpointPicker: picker, EndpointPickerPort: DefaultEndpointPickerPort}
+	if name, port, ok := strings.Cut(picker, ":"); ok {
+		n, err := strconv.ParseInt(port, 10, 32)
+		if err != nil || n < 1 || n > 65535 {
+			return nil, phaseErr("invalid %s %q: the port must be a number between 1 and 65535",
+				InferencePoolEndpointPickerAnnotation, picker)
+		}
+		pool.EndpointPicker, pool.EndpointPickerPort = name, int32(n)
+	}
+	if errs := validation.IsDNS1035Label(pool.EndpointPicker); len(errs) > 0 {
+		return nil, phaseErr("invalid %s %q: %s", InferencePoolEndpointPickerAnnotation, picker, strings.Join(errs, "; "))
+	}
+	return pool, nil
+}
+
+// InferenceModelName is the name of the InferenceModel of model on the
+// gateway name. Model names that are not valid object names are sanitized
+// and suffixed with a hash of the original, so distinct models never share
+// an object.
+func InferenceModelName(name, model string) string {
+	sanitized := strings.Trim(invalidObjectNameChars.ReplaceAllString(strings.ToLower(model), "-"), ".-")
+	objectName := name + "-" + sanitized
+	if sanitized != model || len(objectName) > validation.DNS1123SubdomainMaxLength {
+		hash := fmt.Sprintf("%x", sha256.Sum256([]byte(model)))[:inferenceModelHashChars]
+		objectName = strings.TrimRight(objectName[:min(len(objectName), validation.DNS1123SubdomainMaxLength-len(hash)-1)], ".-")
+		objectName += "-" + hash
+	}
+	return objectName
+}
+
+// ReconcileInferencePool applies the InferencePool of w, named after the
+// gateway, and its InferenceModels when w.InferencePool is set, and deletes
+// them otherwise. The GA InferencePool is preferred over the alpha one.
+// InferenceModels are only applied where the cluster serves them; those of
+// models the gateway no longer serves are deleted. When no InferencePool
+// kind is installed, the error says so and reconcile retries until it is.
+//
+// On failure, the returned error is a *PhaseError tagged InferencePoolPhase.
+func ReconcileInferencePool(ctx context.Context, c client.Client, scheme *runtime.Scheme, w GatewayWorkload) error {
+	phaseErr := func(err error) error { return &PhaseError{Phase: InferencePoolPhase, Err: err} }
+
+	if w.InferencePool == nil {
+		// The InferenceModels go first, so a pool left behind by a failed
+		// cleanup still marks them for deletion.
+		for _, gvk := range inferencePoolGVKs {
+			served, err := kindServed(c, gvk)
+			if err != nil {
+				return phaseErr(err)
+			}
+			if !served {
+				continue
+			}
+			pool := &unstructured.Unstructured{}
+			pool.SetGroupVersionKind(gvk)
+			if err := getOwned(ctx, c, w, client.ObjectKey{Namespace: w.Namespace, Name: w.Name}, pool); err != nil {
+				return phaseErr(err)
+			}
+			if pool.GetResourceVersion() == "" {
+				continue
+			}
+			if err := pruneInferenceModels(ctx, c, w, nil); err != nil {
+				return phaseErr(err)
+			}
+			if err := deleteOwned(ctx, c, w, pool, w.Name); err != nil {
+				return phaseErr(err)
+			}
+		}
+		return nil
+	}
+
+	var poolGVK *schema.GroupVersionKind
+	for _, gvk := range inferencePoolGVKs {
+		if _, err := c.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version); err == nil {
+			poolGVK = &gvk
+			break
+		} else if !apimeta.IsNoMatchError(err) {
+			return phaseErr(err)
+		}
+	}
+	if poolGVK == nil {
+		return phaseErr(fmt.Errorf("%s needs the Gateway API Inference Extension, but no InferencePool kind is served by this cluster",
+			InferencePoolAnnotation))
+	}
+	pool, err := BuildInferencePool(w, scheme, *poolGVK)
+	if err != nil {
+		return phaseErr(err)
+	}
+	if err := applyUnstructured(ctx, c, w, pool); err != nil {
+		return phaseErr(err)
+	}
+
+	if served, err := kindServed(c, InferenceModelGVK); err != nil {
+		return phaseErr(err)
+	} else if !served {
+		return nil
+	}
+	models, err := BuildInferenceModels(w, scheme, *poolGVK)
+	if err != nil {
+		return phaseErr(err)
+	}
+	keep := map[string]bool{}
+	for _, model := range models {
+		if err := applyUnstructured(ctx, c, w, model); err != nil {
+			return phaseErr(err)
+		}
+		keep[model.GetName()] = true
+	}
+	if err := pruneInferenceModels(ctx, c, w, keep); err != nil {
+		return phaseErr(err)
+	}
+	return nil
+}
+
+// pruneInferenceModels deletes the InferenceModels of w not named in keep.
+func pruneInferenceModels(ctx context.Context, c client.Client, w GatewayWorkload, keep map[string]bool) error {
+	if served, err := kindServed(c, InferenceModelGVK); err != nil || !served {
+		return err
+	}
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(InferenceModelGVK.GroupVersion().WithKind(InferenceModelGVK.Kind + "List"))
+	if err := c.List(ctx, list, client.InNamespace(w.Namespace),
+		client.MatchingLabels{"app": w.Name, ManagedByLabel: FieldManager}); err != nil {
+		return err
+	}
+	for i := range list.Items {
+		model := &list.Items[i]
+		if keep[model.GetName()] || !metav1.IsControlledBy(model, w.Owner) {
+			continue
+		}
+		if err := deleteOwned(ctx, c, w, model, model.GetName()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BuildInferencePool returns the desired state of w's InferencePool of kind
+// gvk. It selects the gateway's pods on the LiteLLM port and fails open, so
+// the gateway stays reachable while the endpoint picker is down.
+func BuildInferencePool(w GatewayWorkload, scheme *runtime.Scheme, gvk schema.GroupVersionKind) (*unstructured.Unstructured, error) {
+	pool, err := ownedUnstructured(w, scheme, gvk, w.Name)
+	if err != nil {
+		return nil, err
+	}
+	if gvk == InferencePoolAlphaGVK {
+		pool.Object["spec"] = map[string]any{
+			"selector":         map[string]any{"app": w.Name},
+			"targetPortNumber": int64(w.ContainerPort),
+			"extensionRef": map[string]any{
+				"name":        w.InferencePool.EndpointPicker,
+				"portNumber":  int64(w.InferencePool.EndpointPickerPort),
+				"failureMode": "FailOpen",
+			},
+		}
+		return pool, nil
+	}
+	pool.Object["spec"] = map[string]any{
+		"selector":    map[string]any{"matchLabels": map[string]any{"app": w.Name}},
+		"targetPorts": []any{map[string]any{"number": int64(w.ContainerPort)}},
+		"endpointPickerRef": map[string]any{
+			"name":        w.InferencePool.EndpointPicker,
+			"port":        map[string]any{"number": int64(w.InferencePool.EndpointPickerPort)},
+			"failureMode": "FailOpen",
+		},
+	}
+	return pool, nil
+}
+
+// BuildInferenceModels returns the desired state of w's InferenceModels, one
+// per model, referencing the InferencePool of kind poolGVK.
+func BuildInferenceModels(w GatewayWorkload, scheme *runtime.Scheme, poolGVK schema.GroupVersionKind) ([]*unstructured.Unstructured, error) {
+	var models []*unstructured.Unstructured
+	for _, name := range w.InferencePool.Models {
+		model, err := ownedUnstructured(w, scheme, InferenceModelGVK, InferenceModelName(w.Name, name))
+		if err != nil {
+			return nil, err
+		}
+		model.Object["spec"] = map[string]any{
+			"modelName": name,
+			"poolRef":   map[string]any{"group": poolGVK.Group, "kind": poolGVK.Kind, "name": w.Name},
+		}
+		models = append(models, model)
+	}
+	return models, nil
+}
@@ -0,0 +1,224 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestParseInferencePool(t *testing.T) {
+	cases := []struct {
+		annotations map[string]string
+		want        *InferencePool
+		wantErr     bool
+	}{
+		{annotations: nil},
+		{annotations: map[string]string{InferencePoolAnnotation: "false"}},
+		{
+			annotations: map[string]string{InferencePoolAnnotation: "true", InferencePoolEndpointPickerAnnotation: "gw-epp"},
+			want:        &InferencePool{EndpointPicker: "gw-epp", EndpointPickerPort: DefaultEndpointPickerPort},
+		},
+		{
+			annotations: map[string]string{InferencePoolAnnotation: "true", InferencePoolEndpointPickerAnnotation: "gw-epp:9000"},
+			want:        &InferencePool{EndpointPicker: "gw-epp", EndpointPickerPort: 9000},
+		},
+		{annotations: map[string]string{InferencePoolAnnotation: "on"}, wantErr: true},
+		{annotations: map[string]string{InferencePoolAnnotation: "true"}, wantErr: true},
+		{annotations: map[string]string{InferencePoolAnnotation: "true", InferencePoolEndpointPickerAnnotation: "gw-epp:grpc"}, wantErr: true},
+		{annotations: map[string]string{InferencePoolAnnotation: "true", InferencePoolEndpointPickerAnnotation: "GW_EPP"}, wantErr: true},
+		{annotations: map[string]string{InferencePoolAnnotation: "true", InferencePoolEndpointPickerAnnotation: "gw-epp", FlaggerAnnotation: "true"}, wantErr: true},
+	}
+	for _, tc := range cases {
+		got, err := ParseInferencePool(tc.annotations)
+		if tc.wantErr {
+			var pe *PhaseError
+			if !errors.As(err, &pe) || pe.Phase != InferencePoolPhase {
+				t.Errorf("%v: want a %s PhaseError, got %v", tc.annotations, InferencePoolPhase, err)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%v: got %+v, %v, want %+v", tc.annotations, got, err, tc.want)
+		}
+	}
+}
+
+func TestInferenceModelName(t *testing.T) {
+	if got := InferenceModelName("gw", "gpt-4o"); got != "gw-gpt-4o" {
+		t.Errorf("valid name: got %s", got)
+	}
+	a, b := InferenceModelName("gw", "openai/gpt-4o"), InferenceModelName("gw", "openai_gpt-4o")
+	if a == b || !strings.HasPrefix(a, "gw-openai-gpt-4o-") {
+		t.Errorf("sanitized names must be distinct: %s, %s", a, b)
+	}
+	for _, model := range []string{"Claude 3 Opus", "/x/", strings.Repeat("m", 300)} {
+		if errs := validation.IsDNS1123Subdomain(InferenceModelName("gw", model)); len(errs) > 0 {
+			t.Errorf("%q: %v", model, errs)
+		}
+	}
+}
+
+func TestBuildInferencePool(t *testing.T) {
+	s := workloadScheme(t)
+	w := GatewayWorkload{
+		Name: "gw", Namespace: "default", Owner: newOwner("gw", "default"), ContainerPort: 4000,
+		InferencePool: &InferencePool{EndpointPicker: "gw-epp", EndpointPickerPort: 9002},
+	}
+	pool, err := BuildInferencePool(w, s, InferencePoolGVK)
+	if err != nil {
+		t.Fatalf("BuildInferencePool: %v", err)
+	}
+	selector, _, _ := unstructured.NestedStringMap(pool.Object, "spec", "selector", "matchLabels")
+	ports, _, _ := unstructured.NestedSlice(pool.Object, "spec", "targetPorts")
+	picker, _, _ := unstructured.NestedString(pool.Object, "spec", "endpointPickerRef", "name")
+	if selector["app"] != "gw" || len(ports) != 1 || picker != "gw-epp" {
+		t.Errorf("v1 spec = %v", pool.Object["spec"])
+	}
+
+	alpha, err := BuildInferencePool(w, s, InferencePoolAlphaGVK)
+	if err != nil {
+		t.Fatalf("BuildInferencePool alpha: %v", err)
+	}
+	port, _, _ := unstructured.NestedInt64(alpha.Object, "spec", "targetPortNumber")
+	extension, _, _ := unstructured.NestedString(alpha.Object, "spec", "extensionRef", "name")
+	if alpha.GroupVersionKind() != InferencePoolAlphaGVK || port != 4000 || extension != "gw-epp" {
+		t.Errorf("v1alpha2 %s spec = %v", alpha.GroupVersionKind(), alpha.Object["spec"])
+	}
+}
+
+func TestReconcileInferencePool(t *testing.T) {
+	ctx := context.Background()
+	s := workloadScheme(t)
+	owner := newOwner("gw", "default")
+	c := fake.NewClientBuilder().WithScheme(s).
+		WithRESTMapper(inferenceRESTMapper(s, InferencePoolGVK, InferenceModelGVK)).WithObjects(owner).Build()
+	w := GatewayWorkload{
+		Name: "gw", Namespace: "default", Owner: owner, ContainerPort: 4000,
+		InferencePool: &InferencePool{EndpointPicker: "gw-epp", EndpointPickerPort: 9002, Models: []string{"gpt-4o", "claude"}},
+	}
+
+	if err := ReconcileInferencePool(ctx, c, s, w); err != nil {
+		t.Fatalf("ReconcileInferencePool: %v", err)
+	}
+	pool := &unstructured.Unstructured{}
+	pool.SetGroupVersionKind(InferencePoolGVK)
+	if err := c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "gw"}, pool); err != nil {
+		t.Fatalf("get InferencePool: %v", err)
+	}
+	if names := inferenceModelNames(t, c); !reflect.DeepEqual(names, map[string]string{"gw-gpt-4o": "gpt-4o", "gw-claude": "claude"}) {
+		t.Errorf("InferenceModels = %v", names)
+	}
+
+	// A model dropped from the gateway loses its InferenceModel.
+	w.InferencePool.Models = []string{"claude"}
+	if err := ReconcileInferencePool(ctx, c, s, w); err != nil {
+		t.Fatalf("ReconcileInferencePool: %v", err)
+	}
+	if names := inferenceModelNames(t, c); !reflect.DeepEqual(names, map[string]string{"gw-claude": "claude"}) {
+		t.Errorf("InferenceModels after removing gpt-4o = %v", names)
+	}
+
+	w.InferencePool = nil
+	if err := ReconcileInferencePool(ctx, c, s, w); err != nil {
+		t.Fatalf("ReconcileInferencePool disabled: %v", err)
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(pool), pool.DeepCopy()); !apierrors.IsNotFound(err) {
+		t.Errorf("InferencePool must be deleted, got %v", err)
+	}
+	if names := inferenceModelNames(t, c); len(names) != 0 {
+		t.Errorf("InferenceModels must be deleted, got %v", names)
+	}
+}
+
+func TestReconcileInferencePool_AlphaOnly(t *testing.T) {
+	ctx := context.Background()
+	s := workloadScheme(t)
+	owner := newOwner("gw", "default")
+	c := fake.NewClientBuilder().WithScheme(s).
+		WithRESTMapper(inferenceRESTMapper(s, InferencePoolAlphaGVK)).WithObjects(owner).Build()
+	w := GatewayWorkload{
+		Name: "gw", Namespace: "default", Owner: owner, ContainerPort: 4000,
+		InferencePool: &InferencePool{EndpointPicker: "gw-epp", EndpointPickerPort: 9002, Models: []string{"gpt-4o"}},
+	}
+	if err := ReconcileInferencePool(ctx, c, s, w); err != nil {
+		t.Fatalf("ReconcileInferencePool: %v", err)
+	}
+	pool := &unstructured.Unstructured{}
+	pool.SetGroupVersionKind(InferencePoolAlphaGVK)
+	if err := c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "gw"}, pool); err != nil {
+		t.Fatalf("get alpha InferencePool: %v", err)
+	}
+}
+
+func TestReconcileInferencePool_WithoutExtension(t *testing.T) {
+	s := workloadScheme(t)
+	owner := newOwner("gw", "default")
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(owner).Build()
+	w := GatewayWorkload{Name: "gw", Namespace: "default", Owner: owner, InferencePool: &InferencePool{EndpointPicker: "gw-epp"}}
+
+	err := ReconcileInferencePool(context.Background(), c, s, w)
+	var pe *PhaseError
+	if !errors.As(err, &pe) || pe.Phase != InferencePoolPhase || !strings.Contains(err.Error(), "Inference Extension") {
+		t.Fatalf("want a %s PhaseError naming the extension, got %v", InferencePoolPhase, err)
+	}
+	w.InferencePool = nil
+	if err := ReconcileInferencePool(context.Background(), c, s, w); err != nil {
+		t.Errorf("ReconcileInferencePool disabled without the extension: %v", err)
+	}
+}
+
+// inferenceModelNames returns the model name of each InferenceModel by
+// object name.
+func inferenceModelNames(t *testing.T, c client.Client) map[string]string {
+	t.Helper()
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(InferenceModelGVK.GroupVersion().WithKind("InferenceModelList"))
+	if err := c.List(context.Background(), list, client.InNamespace("default")); err != nil {
+		t.Fatalf("list InferenceModels: %v", err)
+	}
+	names := map[string]string{}
+	for _, model := range list.Items {
+		names[model.GetName()], _, _ = unstructured.NestedString(model.Object, "spec", "modelName")
+	}
+	return names
+}
+
+// inferenceRESTMapper maps the kinds of s and the given inference extension
+// kinds.
+func inferenceRESTMapper(s *runtime.Scheme, gvks ...schema.GroupVersionKind) *meta.DefaultRESTMapper {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	for gvk := range s.AllKnownTypes() {
+		mapper.Add(gvk, meta.RESTScopeNamespace)
+	}
+	for _, gvk := range gvks {
+		mapper.Add(gvk, meta.RESTScopeNamespace)
+	}
+	return mapper
+}
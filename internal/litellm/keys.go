@@ -0,0 +1,89 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// KeySettings are the limits of a LiteLLM virtual key. Zero values leave the
+// corresponding limit unset: all models, no budget, no expiry, no rate
+// limits.
+type KeySettings struct {
+	Models         []string `json:"models,omitempty"`
+	MaxBudget      *float64 `json:"max_budget,omitempty"`
+	BudgetDuration string   `json:"budget_duration,omitempty"`
+	Duration       string   `json:"duration,omitempty"`
+	RateLimits
+}
+
+// GeneratedKey is the decoded response of LiteLLM's /key/generate endpoint.
+// Expires is nil for keys without a duration.
+type GeneratedKey struct {
+	Key     string     `json:"key"`
+	Expires *time.Time `json:"expires"`
+}
+
+// GenerateKey calls POST /key/generate, creating a virtual key named alias.
+// Virtual keys require a database-backed proxy.
+func (a *AdminClient) GenerateKey(ctx context.Context, alias string, settings KeySettings) (*GeneratedKey, error) {
+	body, err := json.Marshal(struct {
+		KeyAlias string `json:"key_alias"`
+		KeySettings
+	}{alias, settings})
+	if err != nil {
+		return nil, err
+	}
+	var key GeneratedKey
+	if err := a.do(ctx, http.MethodPost, "/key/generate", bytes.NewReader(body), &key); err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// UpdateKey calls POST /key/update, replacing the limits of key.
+func (a *AdminClient) UpdateKey(ctx context.Context, key string, settings KeySettings) (*GeneratedKey, error) {
+	body, err := json.Marshal(struct {
+		Key string `json:"key"`
+		KeySettings
+	}{key, settings})
+	if err != nil {
+		return nil, err
+	}
+	var updated GeneratedKey
+	if err := a.do(ctx, http.MethodPost, "/key/update", bytes.NewReader(body), &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// DeleteKeyByAlias calls POST /key/delete for the key named alias. A key
+// that does not exist is not an error.
+func (a *AdminClient) DeleteKeyByAlias(ctx context.Context, alias string) error {
+	body, err := json.Marshal(map[string][]string{"key_aliases": {alias}})
+	if err != nil {
+		return err
+	}
+	if err := a.do(ctx, http.MethodPost, "/key/delete", bytes.NewReader(body), nil); !IsNotFound(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"k8s.io/utils/ptr"
+)
+
+func TestAdminClient_GenerateKey(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/key/generate" {
+			t.Errorf("request: got %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decoding body: %v", err)
+		}
+		if body["key_alias"] != "ns/team-a" || body["max_budget"] != 10.5 || body["duration"] != "30d" {
+			t.Errorf("body: got %v", body)
+		}
+		if _, ok := body["budget_duration"]; ok {
+			t.Errorf("unset budget_duration must be omitted: %v", body)
+		}
+		_, _ = w.Write([]byte(`{"key": "sk-virtual", "expires": "2026-11-16T10:00:00Z"}`))
+	}))
+	defer srv.Close()
+
+	a := &AdminClient{BaseURL: srv.URL}
+	key, err := a.GenerateKey(context.Background(), "ns/team-a", KeySettings{
+		Models:    []string{"gpt-4o"},
+		MaxBudget: ptr.To(10.5),
+		Duration:  "30d",
+	})
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	if key.Key != "sk-virtual" || key.Expires == nil || key.Expires.Month() != 11 {
+		t.Errorf("GenerateKey: got %+v", key)
+	}
+}
+
+func TestAdminClient_DeleteKeyByAlias(t *testing.T) {
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string][]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decoding body: %v", err)
+		}
+		if r.URL.Path != "/key/delete" || len(body["key_aliases"]) != 1 || body["key_aliases"][0] != "ns/team-a" {
+			t.Errorf("request: got %s %v", r.URL.Path, body)
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	a := &AdminClient{BaseURL: srv.URL}
+	for _, status = range []int{http.StatusOK, http.StatusNotFound} {
+		if err := a.DeleteKeyByAlias(context.Background(), "ns/team-a"); err != nil {
+			t.Errorf("status %d: want no error, got %v", status, err)
+		}
+	}
+	status = http.StatusInternalServerError
+	if err := a.DeleteKeyByAlias(context.Background(), "ns/team-a"); err == nil {
+		t.Error("want error on 500")
+	}
+}
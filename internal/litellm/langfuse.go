@@ -0,0 +1,156 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// The Langfuse annotations bind a gateway to a Langfuse project. They are
+// read from the AiGateway and from the AiGatewayClass claiming it; each
+// one set on the gateway wins over the class.
+const (
+	// LangfuseHostAnnotation is the URL of the Langfuse server. Setting it
+	// enables the Langfuse callback; an empty value on the gateway opts out
+	// of the class's project.
+	LangfuseHostAnnotation = "ai-gateway-litellm.agentic-layer.ai/langfuse-host"
+	// LangfuseSecretAnnotation names a Secret in the gateway's namespace
+	// holding the project's keys under LangfusePublicKeyKey and
+	// LangfuseSecretKeyKey.
+	LangfuseSecretAnnotation = "ai-gateway-litellm.agentic-layer.ai/langfuse-secret"
+	// LangfuseReleaseAnnotation sets the release of every trace.
+	LangfuseReleaseAnnotation = "ai-gateway-litellm.agentic-layer.ai/langfuse-release"
+	// LangfuseEnvironmentAnnotation sets the environment of every trace.
+	LangfuseEnvironmentAnnotation = "ai-gateway-litellm.agentic-layer.ai/langfuse-environment"
+)
+
+// Keys of the Langfuse Secret, and the env vars they reach the LiteLLM
+// container as.
+const (
+	LangfusePublicKeyKey = "LANGFUSE_PUBLIC_KEY"
+	LangfuseSecretKeyKey = "LANGFUSE_SECRET_KEY"
+)
+
+// LangfusePhase tags invalid Langfuse annotations.
+const LangfusePhase = "Langfuse"
+
+// LangfuseCallback is the LiteLLM callback logging to Langfuse.
+const LangfuseCallback = "langfuse"
+
+// LangfuseDefaultTags are the litellm_settings.langfuse_default_tags. The
+// proxy_base_url tag names the gateway each trace passed.
+var LangfuseDefaultTags = []string{"proxy_base_url"}
+
+// langfuseEnvironmentPattern is Langfuse's rule for environment names.
+var langfuseEnvironmentPattern = regexp.MustCompile(`^[a-z0-9_-]{1,40}$`)
+
+// Langfuse is the Langfuse project a gateway logs to.
+type Langfuse struct {
+	Host        string
+	Secret      string
+	Release     string
+	Environment string
+}
+
+// ResolveLangfuse returns the Langfuse project of gw, from its annotations
+// and those of the AiGatewayClass of controllerName claiming it, or nil when
+// neither sets a host. Invalid annotations yield a *PhaseError.
+func ResolveLangfuse(ctx context.Context, c client.Reader, gw *gatewayv1alpha1.AiGateway, controllerName string) (*Langfuse, error) {
+	cls, err := AiGatewayClassOf(ctx, c, gw, controllerName)
+	if err != nil {
+		return nil, err
+	}
+	var classAnnotations map[string]string
+	if cls != nil {
+		classAnnotations = cls.Annotations
+	}
+	return ParseLangfuse(gw.Annotations, classAnnotations)
+}
+
+// ParseLangfuse returns the Langfuse project of the gateway and class
+// annotations, or nil when neither sets a host. Invalid values yield a
+// *PhaseError.
+func ParseLangfuse(gateway, class map[string]string) (*Langfuse, error) {
+	phaseErr := func(format string, args ...any) error {
+		return &PhaseError{Phase: LangfusePhase, Err: fmt.Errorf(format, args...)}
+	}
+	get := func(key string) string {
+		if v, ok := gateway[key]; ok {
+			return v
+		}
+		return class[key]
+	}
+	l := &Langfuse{
+		Host:        get(LangfuseHostAnnotation),
+		Secret:      get(LangfuseSecretAnnotation),
+		Release:     get(LangfuseReleaseAnnotation),
+		Environment: get(LangfuseEnvironmentAnnotation),
+	}
+	if l.Host == "" {
+		return nil, nil
+	}
+	if u, err := url.Parse(l.Host); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, phaseErr("invalid %s %q: must be an http or https URL", LangfuseHostAnnotation, l.Host)
+	}
+	if l.Secret == "" {
+		return nil, phaseErr("%s needs the %s annotation naming the Secret with the project's keys",
+			LangfuseHostAnnotation, LangfuseSecretAnnotation)
+	}
+	if l.Environment != "" && (!langfuseEnvironmentPattern.MatchString(l.Environment) || strings.HasPrefix(l.Environment, "langfuse")) {
+		return nil, phaseErr("invalid %s %q: must be up to 40 lowercase letters, digits, '-' or '_', and not start with \"langfuse\"",
+			LangfuseEnvironmentAnnotation, l.Environment)
+	}
+	return l, nil
+}
+
+// LangfuseEnvVars returns the env vars LiteLLM's Langfuse callback reads for
+// l, or none for a nil l. proxyBaseURL, when set, becomes PROXY_BASE_URL,
+// which LiteLLM tags every trace with.
+func LangfuseEnvVars(l *Langfuse, proxyBaseURL string) []corev1.EnvVar {
+	if l == nil {
+		return nil
+	}
+	secretKeyRef := func(key string) *corev1.EnvVarSource {
+		return &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: l.Secret},
+			Key:                  key,
+		}}
+	}
+	env := []corev1.EnvVar{
+		{Name: "LANGFUSE_HOST", Value: l.Host},
+		{Name: LangfusePublicKeyKey, ValueFrom: secretKeyRef(LangfusePublicKeyKey)},
+		{Name: LangfuseSecretKeyKey, ValueFrom: secretKeyRef(LangfuseSecretKeyKey)},
+	}
+	if l.Release != "" {
+		env = append(env, corev1.EnvVar{Name: "LANGFUSE_RELEASE", Value: l.Release})
+	}
+	if l.Environment != "" {
+		env = append(env, corev1.EnvVar{Name: "LANGFUSE_TRACING_ENVIRONMENT", Value: l.Environment})
+	}
+	if proxyBaseURL != "" {
+		env = append(env, corev1.EnvVar{Name: "PROXY_BASE_URL", Value: proxyBaseURL})
+	}
+	return env
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseLangfuse(t *testing.T) {
+	class := map[string]string{
+		LangfuseHostAnnotation:        "https://langfuse.example.com",
+		LangfuseSecretAnnotation:      "langfuse-keys",
+		LangfuseEnvironmentAnnotation: "production",
+	}
+	cases := []struct {
+		name           string
+		gateway, class map[string]string
+		want           *Langfuse
+		wantErr        bool
+	}{
+		{name: "unset"},
+		{
+			name:  "class",
+			class: class,
+			want:  &Langfuse{Host: "https://langfuse.example.com", Secret: "langfuse-keys", Environment: "production"},
+		},
+		{
+			name:    "gateway wins",
+			gateway: map[string]string{LangfuseSecretAnnotation: "team-a-keys", LangfuseReleaseAnnotation: "v2", LangfuseEnvironmentAnnotation: "staging"},
+			class:   class,
+			want:    &Langfuse{Host: "https://langfuse.example.com", Secret: "team-a-keys", Release: "v2", Environment: "staging"},
+		},
+		{name: "opt out", gateway: map[string]string{LangfuseHostAnnotation: ""}, class: class},
+		{name: "no secret", gateway: map[string]string{LangfuseHostAnnotation: "https://langfuse.example.com"}, wantErr: true},
+		{name: "invalid host", gateway: map[string]string{LangfuseHostAnnotation: "langfuse:3000"}, class: class, wantErr: true},
+		{name: "invalid environment", gateway: map[string]string{LangfuseEnvironmentAnnotation: "Prod"}, class: class, wantErr: true},
+		{name: "reserved environment", gateway: map[string]string{LangfuseEnvironmentAnnotation: "langfuse-prod"}, class: class, wantErr: true},
+	}
+	for _, tc := range cases {
+		got, err := ParseLangfuse(tc.gateway, tc.class)
+		if tc.wantErr {
+			var pe *PhaseError
+			if !errors.As(err, &pe) || pe.Phase != LangfusePhase {
+				t.Errorf("%s: want a %s PhaseError, got %v", tc.name, LangfusePhase, err)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: got %+v, %v, want %+v", tc.name, got, err, tc.want)
+		}
+	}
+}
+
+func TestLangfuseEnvVars(t *testing.T) {
+	if env := LangfuseEnvVars(nil, "http://gw.team-a.svc.cluster.local:4000"); env != nil {
+		t.Errorf("without Langfuse: %v", env)
+	}
+	env := LangfuseEnvVars(&Langfuse{Host: "https://langfuse.example.com", Secret: "keys", Release: "v2"}, "http://gw.team-a.svc.cluster.local:4000")
+	byName := map[string]string{}
+	for _, e := range env {
+		byName[e.Name] = e.Value
+		if e.ValueFrom != nil {
+			byName[e.Name] = e.ValueFrom.SecretKeyRef.Name + "/" + e.ValueFrom.SecretKeyRef.Key
+		}
+	}
+	want := map[string]string{
+		"LANGFUSE_HOST":      "https://langfuse.example.com",
+		LangfusePublicKeyKey: "keys/" + LangfusePublicKeyKey,
+		LangfuseSecretKeyKey: "keys/" + LangfuseSecretKeyKey,
+		"LANGFUSE_RELEASE":   "v2",
+		"PROXY_BASE_URL":     "http://gw.team-a.svc.cluster.local:4000",
+	}
+	if !reflect.DeepEqual(byName, want) {
+		t.Errorf("env = %v, want %v", byName, want)
+	}
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// LogLevelAnnotation sets the LiteLLM proxy log level for a single gateway,
+// so debug logging can be switched on temporarily without editing spec.env.
+const LogLevelAnnotation = "ai-gateway-litellm.agentic-layer.ai/log-level"
+
+// LogLevelEnvVar is the env var LiteLLM reads its log level from.
+const LogLevelEnvVar = "LITELLM_LOG"
+
+// LogLevelPhase tags log-level validation failures. The value comes straight
+// from user input, so callers treat it as a permanent error.
+const LogLevelPhase = "LogLevel"
+
+// LogLevels lists the accepted values of LogLevelAnnotation.
+var LogLevels = []string{"DEBUG", "INFO", "WARN", "ERROR"}
+
+// ParseLogLevel returns the normalized log level requested via
+// LogLevelAnnotation, or "" when the annotation is absent. Values are
+// case-insensitive; anything outside LogLevels yields a *PhaseError.
+func ParseLogLevel(annotations map[string]string) (string, error) {
+	raw, ok := annotations[LogLevelAnnotation]
+	if !ok {
+		return "", nil
+	}
+	level := strings.ToUpper(strings.TrimSpace(raw))
+	if !slices.Contains(LogLevels, level) {
+		return "", &PhaseError{Phase: LogLevelPhase, Err: fmt.Errorf(
+			"invalid %s annotation %q: must be one of %s", LogLevelAnnotation, raw, strings.Join(LogLevels, ", "))}
+	}
+	return level, nil
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseLogLevel(t *testing.T) {
+	cases := []struct {
+		name    string
+		value   *string
+		want    string
+		wantErr bool
+	}{
+		{name: "absent", value: nil, want: ""},
+		{name: "upper", value: new("DEBUG"), want: "DEBUG"},
+		{name: "lower and padded", value: new(" warn "), want: "WARN"},
+		{name: "unknown", value: new("TRACE"), wantErr: true},
+		{name: "empty", value: new(""), wantErr: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			annotations := map[string]string{}
+			if tc.value != nil {
+				annotations[LogLevelAnnotation] = *tc.value
+			}
+			got, err := ParseLogLevel(annotations)
+			if tc.wantErr {
+				var pe *PhaseError
+				if !errors.As(err, &pe) || pe.Phase != LogLevelPhase {
+					t.Fatalf("want PhaseError{%s}, got %v", LogLevelPhase, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
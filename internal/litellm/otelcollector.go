@@ -0,0 +1,174 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// OTelCollectorAnnotation, set to "true", runs an OpenTelemetry Collector
+// next to LiteLLM. LiteLLM's otel callback sends its traces to the sidecar,
+// which tags them with the gateway and forwards them to the cluster's OTLP
+// endpoint.
+const OTelCollectorAnnotation = "ai-gateway-litellm.agentic-layer.ai/otel-collector"
+
+// OTelCollectorEndpointAnnotation is the OTLP/HTTP endpoint the sidecar
+// exports to. It overrides the operator's default endpoint.
+const OTelCollectorEndpointAnnotation = "ai-gateway-litellm.agentic-layer.ai/otel-collector-endpoint"
+
+// OTelCollectorPhase tags invalid otel-collector annotations.
+const OTelCollectorPhase = "OTelCollector"
+
+// OTelCollectorImage is the image of the collector sidecar. The core
+// distribution has every component of the generated config.
+const OTelCollectorImage = "otel/opentelemetry-collector:0.129.1"
+
+// OTelCollectorContainerName is the name of the collector sidecar.
+const OTelCollectorContainerName = "otel-collector"
+
+// otelCollectorConfigEnvVar holds the collector config, read through the
+// collector's env config provider.
+const otelCollectorConfigEnvVar = "OTELCOL_CONFIG"
+
+// otelCollectorPort is the port the sidecar receives OTLP/HTTP on, on the
+// pod's loopback interface only.
+const otelCollectorPort = 4318
+
+// OTelCollector configures the collector sidecar of a GatewayWorkload.
+type OTelCollector struct {
+	// Endpoint is the OTLP/HTTP endpoint the sidecar exports to.
+	Endpoint string
+}
+
+// ParseOTelCollector returns the collector sidecar the annotations ask for,
+// or nil when OTelCollectorAnnotation is unset or false. defaultEndpoint is
+// used unless OTelCollectorEndpointAnnotation is set; one of them must be
+// an http or https URL. Invalid values yield a *PhaseError.
+func ParseOTelCollector(annotations map[string]string, defaultEndpoint string) (*OTelCollector, error) {
+	phaseErr := func(format string, args ...any) error {
+		return &PhaseError{Phase: OTelCollectorPhase, Err: fmt.Errorf(format, args...)}
+	}
+	v, ok := annotations[OTelCollectorAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	enabled, err := strconv.ParseBool(v)
+	if err != nil {
+		return nil, phaseErr("invalid %s annotation %q: must be \"true\" or \"false\"", OTelCollectorAnnotation, v)
+	}
+	if !enabled {
+		return nil, nil
+	}
+	endpoint := defaultEndpoint
+	if v, ok := annotations[OTelCollectorEndpointAnnotation]; ok {
+		endpoint = v
+	}
+	if endpoint == "" {
+		return nil, phaseErr("%s needs an OTLP endpoint: set the %s annotation or start the operator with --otlp-endpoint",
+			OTelCollectorAnnotation, OTelCollectorEndpointAnnotation)
+	}
+	if u, err := url.Parse(endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, phaseErr("invalid OTLP endpoint %q: must be an http or https URL", endpoint)
+	}
+	return &OTelCollector{Endpoint: endpoint}, nil
+}
+
+// OTelCollectorEnvVars returns the env vars pointing LiteLLM's otel callback
+// at the collector sidecar, or none without one.
+func OTelCollectorEnvVars(collector *OTelCollector) []corev1.EnvVar {
+	if collector == nil {
+		return nil
+	}
+	return []corev1.EnvVar{
+		{Name: "OTEL_EXPORTER", Value: "otlp_http"},
+		{Name: "OTEL_ENDPOINT", Value: fmt.Sprintf("http://127.0.0.1:%d/v1/traces", otelCollectorPort)},
+	}
+}
+
+// OTelCollectorConfig returns the config of w's collector sidecar. It
+// receives OTLP/HTTP on the loopback interface, adds resource attributes
+// naming the gateway and its pod, and exports to w.OTelCollector.Endpoint.
+func OTelCollectorConfig(w GatewayWorkload) string {
+	attribute := func(key, value string) map[string]any {
+		return map[string]any{"key": key, "value": value, "action": "upsert"}
+	}
+	config := map[string]any{
+		"receivers": map[string]any{
+			"otlp": map[string]any{"protocols": map[string]any{
+				"http": map[string]any{"endpoint": fmt.Sprintf("127.0.0.1:%d", otelCollectorPort)},
+			}},
+		},
+		"processors": map[string]any{
+			"memory_limiter": map[string]any{"check_interval": "1s", "limit_percentage": 80, "spike_limit_percentage": 20},
+			"resource": map[string]any{"attributes": []any{
+				attribute("k8s.namespace.name", w.Namespace),
+				attribute("k8s.deployment.name", w.Name),
+				attribute("k8s.pod.name", "${env:POD_NAME}"),
+				attribute("ai_gateway.name", w.Name),
+			}},
+			"batch": map[string]any{},
+		},
+		"exporters": map[string]any{
+			"otlphttp": map[string]any{"endpoint": w.OTelCollector.Endpoint},
+		},
+		"service": map[string]any{
+			"pipelines": map[string]any{
+				"traces": map[string]any{
+					"receivers":  []string{"otlp"},
+					"processors": []string{"memory_limiter", "resource", "batch"},
+					"exporters":  []string{"otlphttp"},
+				},
+			},
+		},
+	}
+	raw, err := yaml.Marshal(config)
+	if err != nil {
+		panic(fmt.Sprintf("marshalling collector config: %v", err))
+	}
+	return string(raw)
+}
+
+// buildOTelCollectorContainer returns the collector sidecar of w. Its config
+// is passed in an env var, so a change to it rolls the pods like any other
+// change to the pod template.
+func buildOTelCollectorContainer(w GatewayWorkload) *corev1ac.ContainerApplyConfiguration {
+	return corev1ac.Container().
+		WithName(OTelCollectorContainerName).
+		WithImage(w.mirror(OTelCollectorImage)).
+		WithArgs("--config=env:"+otelCollectorConfigEnvVar).
+		WithEnv(
+			corev1ac.EnvVar().WithName(otelCollectorConfigEnvVar).WithValue(OTelCollectorConfig(w)),
+			corev1ac.EnvVar().WithName("POD_NAME").WithValueFrom(corev1ac.EnvVarSource().
+				WithFieldRef(corev1ac.ObjectFieldSelector().WithFieldPath("metadata.name"))),
+		).
+		WithResources(corev1ac.ResourceRequirements().
+			WithRequests(corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("64Mi"),
+				corev1.ResourceCPU:    resource.MustParse("50m"),
+			}).
+			WithLimits(corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("256Mi"),
+				corev1.ResourceCPU:    resource.MustParse("200m"),
+			}))
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	metav1ac "k8s.io/client-go/applyconfigurations/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+func TestParseOTelCollector(t *testing.T) {
+	const cluster = "http://otel-collector.observability:4318"
+	cases := []struct {
+		annotations     map[string]string
+		defaultEndpoint string
+		want            *OTelCollector
+		wantErr         bool
+	}{
+		{annotations: nil, defaultEndpoint: cluster},
+		{annotations: map[string]string{OTelCollectorAnnotation: "false"}},
+		{annotations: map[string]string{OTelCollectorAnnotation: "true"}, defaultEndpoint: cluster, want: &OTelCollector{Endpoint: cluster}},
+		{
+			annotations:     map[string]string{OTelCollectorAnnotation: "true", OTelCollectorEndpointAnnotation: "https://otlp.example.com"},
+			defaultEndpoint: cluster,
+			want:            &OTelCollector{Endpoint: "https://otlp.example.com"},
+		},
+		{annotations: map[string]string{OTelCollectorAnnotation: "on"}, defaultEndpoint: cluster, wantErr: true},
+		{annotations: map[string]string{OTelCollectorAnnotation: "true"}, wantErr: true},
+		{annotations: map[string]string{OTelCollectorAnnotation: "true", OTelCollectorEndpointAnnotation: "otel:4317"}, defaultEndpoint: cluster, wantErr: true},
+	}
+	for _, tc := range cases {
+		got, err := ParseOTelCollector(tc.annotations, tc.defaultEndpoint)
+		if tc.wantErr {
+			var pe *PhaseError
+			if !errors.As(err, &pe) || pe.Phase != OTelCollectorPhase {
+				t.Errorf("%v: want a %s PhaseError, got %v", tc.annotations, OTelCollectorPhase, err)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%v: got %+v, %v, want %+v", tc.annotations, got, err, tc.want)
+		}
+	}
+}
+
+func TestBuildDeployment_OTelCollector(t *testing.T) {
+	w := GatewayWorkload{Name: "gw", Namespace: "team-a", ContainerPort: 4000, ConfigYAML: "model_list: []\n"}
+	dep := BuildDeployment(w, metav1ac.OwnerReference().WithName("gw"), "c", "s")
+	if n := len(dep.Spec.Template.Spec.Containers); n != 1 {
+		t.Fatalf("without a collector: %d containers, want 1", n)
+	}
+
+	w.OTelCollector = &OTelCollector{Endpoint: "http://otel:4318"}
+	dep = BuildDeployment(w, metav1ac.OwnerReference().WithName("gw"), "c", "s")
+	containers := dep.Spec.Template.Spec.Containers
+	if len(containers) != 2 || *containers[0].Name != ContainerName || *containers[1].Name != OTelCollectorContainerName {
+		t.Fatalf("containers = %v", containers)
+	}
+	var raw string
+	for _, e := range containers[1].Env {
+		if *e.Name == otelCollectorConfigEnvVar {
+			raw = *e.Value
+		}
+	}
+	var config struct {
+		Receivers struct {
+			OTLP struct {
+				Protocols struct {
+					HTTP struct{ Endpoint string } `json:"http"`
+				} `json:"protocols"`
+			} `json:"otlp"`
+		} `json:"receivers"`
+		Processors struct {
+			Resource struct {
+				Attributes []struct{ Key, Value string } `json:"attributes"`
+			} `json:"resource"`
+		} `json:"processors"`
+		Exporters struct {
+			OTLPHTTP struct{ Endpoint string } `json:"otlphttp"`
+		} `json:"exporters"`
+	}
+	if err := yaml.Unmarshal([]byte(raw), &config); err != nil {
+		t.Fatalf("collector config: %v\n%s", err, raw)
+	}
+	if config.Receivers.OTLP.Protocols.HTTP.Endpoint != "127.0.0.1:4318" || config.Exporters.OTLPHTTP.Endpoint != "http://otel:4318" {
+		t.Errorf("collector config:\n%s", raw)
+	}
+	attributes := map[string]string{}
+	for _, a := range config.Processors.Resource.Attributes {
+		attributes[a.Key] = a.Value
+	}
+	if attributes["ai_gateway.name"] != "gw" || attributes["k8s.namespace.name"] != "team-a" || attributes["k8s.pod.name"] != "${env:POD_NAME}" {
+		t.Errorf("resource attributes = %v", attributes)
+	}
+
+	env := map[string]string{}
+	for _, e := range OTelCollectorEnvVars(w.OTelCollector) {
+		env[e.Name] = e.Value
+	}
+	if env["OTEL_EXPORTER"] != "otlp_http" || env["OTEL_ENDPOINT"] != "http://127.0.0.1:4318/v1/traces" {
+		t.Errorf("LiteLLM env = %v", env)
+	}
+}
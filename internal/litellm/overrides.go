@@ -0,0 +1,224 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	litellmv1alpha1 "github.com/agentic-layer/ai-gateway-litellm/api/v1alpha1"
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	appsv1ac "k8s.io/client-go/applyconfigurations/apps/v1"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
+	metav1ac "k8s.io/client-go/applyconfigurations/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// OverridePhase tags failures applying the overrides of the gateway
+// template to the generated objects. They are permanent: the template or
+// the gateway must change.
+const OverridePhase = "Override"
+
+// configOverridesHashAnnotation on the pod template carries the hash of the
+// ConfigMap overrides, so changing them rolls the pods like a config change.
+const configOverridesHashAnnotation = "ai-gateway-litellm.agentic-layer.ai/config-overrides-hash"
+
+// overrideSchemas are the API types whose patch strategies a strategic-merge
+// override follows.
+var overrideSchemas = map[litellmv1alpha1.OverrideTarget]any{
+	litellmv1alpha1.OverrideTargetDeployment: &appsv1.Deployment{},
+	litellmv1alpha1.OverrideTargetService:    &corev1.Service{},
+	litellmv1alpha1.OverrideTargetConfigMap:  &corev1.ConfigMap{},
+}
+
+// ValidateOverrides rejects overrides that are not a patch of their type:
+// a YAML or JSON object for StrategicMerge, a list of operations for
+// JSONPatch. Whether a patch applies is only known once the objects are
+// rendered; see CheckOverrides.
+func ValidateOverrides(overrides []litellmv1alpha1.ResourceOverride) error {
+	for i, o := range overrides {
+		if _, ok := overrideSchemas[o.Target]; !ok {
+			return fmt.Errorf("override %d: unknown target %q", i, o.Target)
+		}
+		if _, err := parseOverride(o); err != nil {
+			return fmt.Errorf("override %d of the %s: %w", i, o.Target, err)
+		}
+	}
+	return nil
+}
+
+// parseOverride returns the patch of o as JSON.
+func parseOverride(o litellmv1alpha1.ResourceOverride) ([]byte, error) {
+	patch, err := yaml.YAMLToJSON([]byte(o.Patch))
+	if err != nil {
+		return nil, fmt.Errorf("parsing patch: %w", err)
+	}
+	switch o.Type {
+	case litellmv1alpha1.OverridePatchJSONPatch:
+		if _, err := jsonpatch.DecodePatch(patch); err != nil {
+			return nil, fmt.Errorf("parsing JSON patch: %w", err)
+		}
+	case "", litellmv1alpha1.OverridePatchStrategicMerge:
+		var object map[string]any
+		if err := json.Unmarshal(patch, &object); err != nil || object == nil {
+			return nil, fmt.Errorf("a strategic-merge patch must be an object")
+		}
+	default:
+		return nil, fmt.Errorf("unknown patch type %q", o.Type)
+	}
+	return patch, nil
+}
+
+// applyOverrides applies the overrides of w's gateway template for target
+// to obj, in order, and returns the patched copy. obj is not modified. A
+// patch may not rename the object, move it to another namespace or add
+// fields its kind does not have.
+func applyOverrides[T any](w GatewayWorkload, target litellmv1alpha1.OverrideTarget, obj *T) (*T, error) {
+	if w.GatewayTemplate == nil {
+		return obj, nil
+	}
+	var original, doc []byte
+	for i, o := range w.GatewayTemplate.Overrides {
+		if o.Target != target {
+			continue
+		}
+		if doc == nil {
+			var err error
+			if original, err = json.Marshal(obj); err != nil {
+				return nil, err
+			}
+			doc = original
+		}
+		patch, err := parseOverride(o)
+		if err == nil {
+			if o.Type == litellmv1alpha1.OverridePatchJSONPatch {
+				doc, err = applyJSONPatch(doc, patch)
+			} else {
+				doc, err = strategicpatch.StrategicMergePatch(doc, patch, overrideSchemas[target])
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("override %d of the %s: %w", i, target, err)
+		}
+	}
+	if doc == nil {
+		return obj, nil
+	}
+
+	var before, after struct {
+		Metadata struct{ Name, Namespace string } `json:"metadata"`
+	}
+	if err := json.Unmarshal(original, &before); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(doc, &after); err != nil {
+		return nil, err
+	}
+	if before.Metadata != after.Metadata {
+		return nil, fmt.Errorf("overrides of the %s may not change its name or namespace", target)
+	}
+	out := new(T)
+	decoder := json.NewDecoder(bytes.NewReader(doc))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(out); err != nil {
+		return nil, fmt.Errorf("overrides of the %s: %w", target, err)
+	}
+	return out, nil
+}
+
+func applyJSONPatch(doc, patch []byte) ([]byte, error) {
+	operations, err := jsonpatch.DecodePatch(patch)
+	if err != nil {
+		return nil, err
+	}
+	return operations.Apply(doc)
+}
+
+// configOverridesHash returns the hash of the ConfigMap overrides of t, or
+// "" without any.
+func configOverridesHash(t *litellmv1alpha1.LiteLLMGatewayTemplateSpec) string {
+	if t == nil {
+		return ""
+	}
+	var patches []litellmv1alpha1.ResourceOverride
+	for _, o := range t.Overrides {
+		if o.Target == litellmv1alpha1.OverrideTargetConfigMap {
+			patches = append(patches, o)
+		}
+	}
+	if len(patches) == 0 {
+		return ""
+	}
+	raw, err := json.Marshal(patches)
+	if err != nil {
+		panic(fmt.Sprintf("marshalling overrides: %v", err))
+	}
+	return hashYAML(string(raw))
+}
+
+// buildConfigMap is BuildConfigMap with the overrides of w applied.
+func buildConfigMap(w GatewayWorkload, ownerRef *metav1ac.OwnerReferenceApplyConfiguration) (*corev1ac.ConfigMapApplyConfiguration, error) {
+	return applyOverrides(w, litellmv1alpha1.OverrideTargetConfigMap, BuildConfigMap(w, ownerRef))
+}
+
+// buildDeployment is BuildDeployment with the overrides of w applied. A
+// suspended gateway gets zero replicas, whatever an override sets.
+func buildDeployment(w GatewayWorkload, ownerRef *metav1ac.OwnerReferenceApplyConfiguration,
+	configHash, secretHash string) (*appsv1ac.DeploymentApplyConfiguration, error) {
+	d, err := applyOverrides(w, litellmv1alpha1.OverrideTargetDeployment, BuildDeployment(w, ownerRef, configHash, secretHash))
+	if err != nil {
+		return nil, err
+	}
+	if w.Suspended {
+		if d.Spec == nil {
+			d.WithSpec(appsv1ac.DeploymentSpec())
+		}
+		d.Spec.WithReplicas(0)
+	}
+	return d, nil
+}
+
+// buildService is BuildService with the overrides of w applied.
+func buildService(w GatewayWorkload, ownerRef *metav1ac.OwnerReferenceApplyConfiguration) (*corev1ac.ServiceApplyConfiguration, error) {
+	return applyOverrides(w, litellmv1alpha1.OverrideTargetService, BuildService(w, ownerRef))
+}
+
+// CheckOverrides applies the overrides of w's gateway template to the
+// objects of a rolling update of w, so a patch that does not apply is
+// reported before anything is written. Failures are *PhaseError tagged
+// OverridePhase.
+func CheckOverrides(w GatewayWorkload) error {
+	if w.GatewayTemplate == nil || len(w.GatewayTemplate.Overrides) == 0 {
+		return nil
+	}
+	ownerRef := metav1ac.OwnerReference()
+	_, err := buildConfigMap(w, ownerRef)
+	if err == nil {
+		_, err = buildDeployment(w, ownerRef, hashYAML(w.ConfigYAML), "")
+	}
+	if err == nil {
+		_, err = buildService(w, ownerRef)
+	}
+	if err != nil {
+		return &PhaseError{Phase: OverridePhase, Err: err}
+	}
+	return nil
+}
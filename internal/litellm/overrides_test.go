@@ -0,0 +1,167 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	litellmv1alpha1 "github.com/agentic-layer/ai-gateway-litellm/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1ac "k8s.io/client-go/applyconfigurations/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func overrideWorkload(overrides ...litellmv1alpha1.ResourceOverride) GatewayWorkload {
+	return GatewayWorkload{
+		Name: "gw", Namespace: "default", ContainerPort: 4000, ServicePort: 4000, ConfigYAML: "model_list: []\n",
+		GatewayTemplate: &litellmv1alpha1.LiteLLMGatewayTemplateSpec{Overrides: overrides},
+	}
+}
+
+func TestApplyOverrides(t *testing.T) {
+	w := overrideWorkload(
+		litellmv1alpha1.ResourceOverride{Target: litellmv1alpha1.OverrideTargetDeployment, Patch: `
+spec:
+  template:
+    spec:
+      containers:
+      - name: litellm
+        resources:
+          limits:
+            memory: 4Gi
+      terminationGracePeriodSeconds: 90
+`},
+		litellmv1alpha1.ResourceOverride{Target: litellmv1alpha1.OverrideTargetDeployment, Type: litellmv1alpha1.OverridePatchJSONPatch,
+			Patch: `[{"op": "add", "path": "/spec/template/spec/containers/0/args", "value": ["--num_workers", "4"]}]`},
+		litellmv1alpha1.ResourceOverride{Target: litellmv1alpha1.OverrideTargetService, Patch: `{"spec": {"type": "LoadBalancer"}}`},
+	)
+	ownerRef := metav1ac.OwnerReference().WithName("gw")
+
+	original := BuildDeployment(w, ownerRef, "c", "s")
+	d, err := buildDeployment(w, ownerRef, "c", "s")
+	if err != nil {
+		t.Fatalf("buildDeployment: %v", err)
+	}
+	spec := d.Spec.Template.Spec
+	if len(spec.Containers) != 1 || *spec.Containers[0].Image != Image {
+		t.Fatalf("the container must be merged by name, got %+v", spec.Containers)
+	}
+	litellm := spec.Containers[0]
+	if litellm.Resources.Limits.Memory().String() != "4Gi" || litellm.Resources.Limits.Cpu().String() != "500m" {
+		t.Errorf("limits = %v", litellm.Resources.Limits)
+	}
+	if len(litellm.Args) != 2 || *spec.TerminationGracePeriodSeconds != 90 {
+		t.Errorf("args = %v, grace period = %v", litellm.Args, spec.TerminationGracePeriodSeconds)
+	}
+	if original.Spec.Template.Spec.TerminationGracePeriodSeconds != nil {
+		t.Error("the built Deployment must not be modified")
+	}
+
+	service, err := buildService(w, ownerRef)
+	if err != nil {
+		t.Fatalf("buildService: %v", err)
+	}
+	if *service.Spec.Type != corev1.ServiceTypeLoadBalancer || len(service.Spec.Ports) != 1 {
+		t.Errorf("service spec = %+v", service.Spec)
+	}
+	cm, err := buildConfigMap(w, ownerRef)
+	if err != nil || cm.Data["config.yaml"] != w.ConfigYAML {
+		t.Errorf("a ConfigMap without overrides must be left alone, got %v, %v", cm, err)
+	}
+}
+
+func TestApplyOverrides_Invalid(t *testing.T) {
+	for name, o := range map[string]litellmv1alpha1.ResourceOverride{
+		"missing path": {Target: litellmv1alpha1.OverrideTargetService, Type: litellmv1alpha1.OverridePatchJSONPatch,
+			Patch: `[{"op": "replace", "path": "/spec/loadBalancerIP/x", "value": "10.0.0.1"}]`},
+		"unknown field": {Target: litellmv1alpha1.OverrideTargetDeployment, Patch: `{"spec": {"replicaz": 2}}`},
+		"rename":        {Target: litellmv1alpha1.OverrideTargetService, Patch: `{"metadata": {"name": "other"}}`},
+	} {
+		err := CheckOverrides(overrideWorkload(o))
+		var pe *PhaseError
+		if !errors.As(err, &pe) || pe.Phase != OverridePhase {
+			t.Errorf("%s: want a %s PhaseError, got %v", name, OverridePhase, err)
+		}
+	}
+	if err := CheckOverrides(overrideWorkload()); err != nil {
+		t.Errorf("without overrides: %v", err)
+	}
+}
+
+func TestValidateOverrides(t *testing.T) {
+	for name, tc := range map[string]struct {
+		override litellmv1alpha1.ResourceOverride
+		wantErr  bool
+	}{
+		"strategic merge": {override: litellmv1alpha1.ResourceOverride{Target: litellmv1alpha1.OverrideTargetService, Patch: "spec:\n  type: NodePort\n"}},
+		"json patch": {override: litellmv1alpha1.ResourceOverride{Target: litellmv1alpha1.OverrideTargetConfigMap, Type: litellmv1alpha1.OverridePatchJSONPatch,
+			Patch: `- {op: remove, path: /metadata/labels}`}},
+		"list as strategic merge": {override: litellmv1alpha1.ResourceOverride{Target: litellmv1alpha1.OverrideTargetService, Patch: "- op: remove\n"}, wantErr: true},
+		"object as json patch": {override: litellmv1alpha1.ResourceOverride{Target: litellmv1alpha1.OverrideTargetService, Type: litellmv1alpha1.OverridePatchJSONPatch,
+			Patch: "spec: {}"}, wantErr: true},
+		"invalid yaml":   {override: litellmv1alpha1.ResourceOverride{Target: litellmv1alpha1.OverrideTargetService, Patch: "spec: ["}, wantErr: true},
+		"unknown target": {override: litellmv1alpha1.ResourceOverride{Target: "Ingress", Patch: "spec: {}"}, wantErr: true},
+	} {
+		if err := ValidateOverrides([]litellmv1alpha1.ResourceOverride{tc.override}); (err != nil) != tc.wantErr {
+			t.Errorf("%s: err = %v, wantErr %v", name, err, tc.wantErr)
+		}
+	}
+}
+
+func TestReconcileWorkload_AppliesOverrides(t *testing.T) {
+	ctx := context.Background()
+	s := workloadScheme(t)
+	owner := newOwner("gw", "default")
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(owner).Build()
+	w := overrideWorkload(litellmv1alpha1.ResourceOverride{Target: litellmv1alpha1.OverrideTargetConfigMap,
+		Type: litellmv1alpha1.OverridePatchJSONPatch, Patch: `[{"op": "add", "path": "/data/extra.yaml", "value": "x: 1"}]`})
+	w.Owner = owner
+
+	if err := ReconcileWorkload(ctx, c, s, w); err != nil {
+		t.Fatalf("ReconcileWorkload: %v", err)
+	}
+	var cm corev1.ConfigMap
+	if err := c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "gw-config"}, &cm); err != nil {
+		t.Fatalf("get ConfigMap: %v", err)
+	}
+	if cm.Data["extra.yaml"] != "x: 1" || cm.Data["config.yaml"] != w.ConfigYAML {
+		t.Errorf("ConfigMap data = %v", cm.Data)
+	}
+	var d appsv1.Deployment
+	if err := c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "gw"}, &d); err != nil {
+		t.Fatalf("get Deployment: %v", err)
+	}
+	hash := d.Spec.Template.Annotations[configOverridesHashAnnotation]
+	if hash == "" {
+		t.Fatal("ConfigMap overrides must be hashed into the pod template")
+	}
+
+	w.GatewayTemplate.Overrides[0].Patch = `[{"op": "add", "path": "/data/extra.yaml", "value": "x: 2"}]`
+	if err := ReconcileWorkload(ctx, c, s, w); err != nil {
+		t.Fatalf("ReconcileWorkload: %v", err)
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(&d), &d); err != nil {
+		t.Fatalf("get Deployment: %v", err)
+	}
+	if d.Spec.Template.Annotations[configOverridesHashAnnotation] == hash {
+		t.Error("changing a ConfigMap override must roll the pods")
+	}
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"slices"
+	"strings"
+)
+
+// PassThroughEndpointsAnnotation lists, comma-separated, the
+// LiteLLMPassThroughEndpoints in the gateway's namespace the gateway serves.
+const PassThroughEndpointsAnnotation = "ai-gateway-litellm.agentic-layer.ai/pass-through-endpoints"
+
+// PassThroughPhase tags failures resolving PassThroughEndpointsAnnotation.
+const PassThroughPhase = "PassThrough"
+
+// PassThroughEndpointNames returns the sorted, de-duplicated endpoint names
+// listed in PassThroughEndpointsAnnotation.
+func PassThroughEndpointNames(annotations map[string]string) []string {
+	var names []string
+	for name := range strings.SplitSeq(annotations[PassThroughEndpointsAnnotation], ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// PassThroughAuthEnvVar is the env var carrying the auth credential of the
+// named endpoint into the gateway container.
+func PassThroughAuthEnvVar(endpoint string) string {
+	name := strings.NewReplacer("-", "_", ".", "_").Replace(strings.ToUpper(endpoint))
+	return "PASS_THROUGH_" + name + "_AUTH"
+}
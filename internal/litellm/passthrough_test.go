@@ -0,0 +1,38 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPassThroughEndpointNames(t *testing.T) {
+	got := PassThroughEndpointNames(map[string]string{PassThroughEndpointsAnnotation: " fine-tuning,assistants,, assistants "})
+	if want := []string{"assistants", "fine-tuning"}; !slices.Equal(got, want) {
+		t.Fatalf("PassThroughEndpointNames = %v, want %v", got, want)
+	}
+	if got := PassThroughEndpointNames(nil); len(got) != 0 {
+		t.Fatalf("PassThroughEndpointNames(nil) = %v, want none", got)
+	}
+}
+
+func TestPassThroughAuthEnvVar(t *testing.T) {
+	if got := PassThroughAuthEnvVar("openai-files.v2"); got != "PASS_THROUGH_OPENAI_FILES_V2_AUTH" {
+		t.Fatalf("PassThroughAuthEnvVar = %q", got)
+	}
+}
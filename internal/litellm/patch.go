@@ -13,6 +13,7 @@ package litellm
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"gopkg.in/yaml.v3"
 	corev1 "k8s.io/api/core/v1"
@@ -67,7 +68,7 @@ const PatchYAMLKey = "patch.yaml"
 //
 // Errors are tagged with PhaseError{Phase: "ConfigPatch"} so callers can map
 // them to a stable status reason.
-func LoadPatch(ctx context.Context, c client.Client, ns, cmName string) (map[string]any, error) {
+func LoadPatch(ctx context.Context, c client.Reader, ns, cmName string) (map[string]any, error) {
 	if cmName == "" {
 		return nil, nil
 	}
@@ -89,27 +90,29 @@ func LoadPatch(ctx context.Context, c client.Client, ns, cmName string) (map[str
 	return parsed, nil
 }
 
-// RenderConfigWithPatch marshals cfg to YAML and, when patch is non-empty,
-// deep-merges patch on top using ApplyPatch. A nil or empty patch
+// RenderConfigWithPatch marshals cfg to YAML and deep-merges patches on top
+// using ApplyPatch, one after the other. When every patch is nil or empty it
 // short-circuits to RenderConfig so the no-op case is byte-identical to the
 // pre-patch render path. The result is a YAML string suitable for the
 // operator-owned ConfigMap consumed by the LiteLLM proxy.
-func RenderConfigWithPatch(cfg LiteLLMConfig, patch map[string]any) (string, error) {
-	if len(patch) == 0 {
+func RenderConfigWithPatch(cfg LiteLLMConfig, patches ...map[string]any) (string, error) {
+	if !slices.ContainsFunc(patches, func(patch map[string]any) bool { return len(patch) > 0 }) {
 		return RenderConfig(cfg)
 	}
 	raw, err := yaml.Marshal(cfg)
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal LiteLLM config: %w", err)
 	}
-	var base map[string]any
-	if err := yaml.Unmarshal(raw, &base); err != nil {
+	var merged map[string]any
+	if err := yaml.Unmarshal(raw, &merged); err != nil {
 		return "", fmt.Errorf("failed to round-trip LiteLLM config: %w", err)
 	}
-	if base == nil {
-		base = map[string]any{}
+	if merged == nil {
+		merged = map[string]any{}
+	}
+	for _, patch := range patches {
+		merged = ApplyPatch(merged, patch)
 	}
-	merged := ApplyPatch(base, patch)
 	out, err := yaml.Marshal(merged)
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal merged LiteLLM config: %w", err)
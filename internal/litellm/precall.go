@@ -0,0 +1,93 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Pre-call check annotations of a gateway.
+const (
+	// PreCallChecksAnnotation, set to "true", makes LiteLLM skip the
+	// deployments of a model a request does not fit: whose context window
+	// is smaller than the prompt, or whose rpm or tpm limit is reached.
+	PreCallChecksAnnotation = "ai-gateway-litellm.agentic-layer.ai/pre-call-checks"
+	// ContextWindowFallbacksAnnotation lists, separated by ";", the models
+	// a request that fits no deployment of a model goes to instead, as
+	// model=fallback,fallback.
+	ContextWindowFallbacksAnnotation = "ai-gateway-litellm.agentic-layer.ai/context-window-fallbacks"
+)
+
+// PreCallChecksPhase tags invalid pre-call check annotations.
+const PreCallChecksPhase = "PreCallChecks"
+
+// RouterSettings is the router_settings block.
+type RouterSettings struct {
+	EnablePreCallChecks bool `yaml:"enable_pre_call_checks,omitempty"`
+	// ContextWindowFallbacks maps, one model per entry, a model to the
+	// models tried in turn when a request exceeds its context window.
+	ContextWindowFallbacks []map[string][]string `yaml:"context_window_fallbacks,omitempty"`
+}
+
+// ParseRouterSettings returns the router_settings the pre-call check
+// annotations ask for, or nil when they ask for none. Fallbacks must name
+// models, the model names of the gateway. Invalid values yield a
+// *PhaseError.
+func ParseRouterSettings(annotations map[string]string, models []string) (*RouterSettings, error) {
+	phaseErr := func(format string, args ...any) error {
+		return &PhaseError{Phase: PreCallChecksPhase, Err: fmt.Errorf(format, args...)}
+	}
+	var settings RouterSettings
+	if v, ok := annotations[PreCallChecksAnnotation]; ok {
+		enabled, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, phaseErr("invalid %s annotation %q: must be \"true\" or \"false\"", PreCallChecksAnnotation, v)
+		}
+		settings.EnablePreCallChecks = enabled
+	}
+
+	var seen []string
+	for entry := range strings.SplitSeq(annotations[ContextWindowFallbacksAnnotation], ";") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		model, list, ok := strings.Cut(entry, "=")
+		model = strings.TrimSpace(model)
+		fallbacks := splitList(list)
+		if !ok || model == "" || len(fallbacks) == 0 {
+			return nil, phaseErr("invalid %s entry %q: must be model=fallback,fallback", ContextWindowFallbacksAnnotation, entry)
+		}
+		if slices.Contains(seen, model) {
+			return nil, phaseErr("invalid %s annotation: model %q is listed twice", ContextWindowFallbacksAnnotation, model)
+		}
+		seen = append(seen, model)
+		for _, name := range append([]string{model}, fallbacks...) {
+			if !slices.Contains(models, name) {
+				return nil, phaseErr("invalid %s annotation: the gateway has no model %q", ContextWindowFallbacksAnnotation, name)
+			}
+		}
+		settings.ContextWindowFallbacks = append(settings.ContextWindowFallbacks, map[string][]string{model: fallbacks})
+	}
+
+	if !settings.EnablePreCallChecks && len(settings.ContextWindowFallbacks) == 0 {
+		return nil, nil
+	}
+	return &settings, nil
+}
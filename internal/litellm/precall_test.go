@@ -0,0 +1,83 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseRouterSettings(t *testing.T) {
+	models := []string{"gpt-4o-mini", "gpt-4o", "gpt-4.1", "claude-haiku", "claude-sonnet"}
+	for _, tc := range []struct {
+		annotations map[string]string
+		want        *RouterSettings
+		wantErr     bool
+	}{
+		{annotations: nil},
+		{annotations: map[string]string{PreCallChecksAnnotation: "false"}},
+		{annotations: map[string]string{PreCallChecksAnnotation: "true"}, want: &RouterSettings{EnablePreCallChecks: true}},
+		{annotations: map[string]string{PreCallChecksAnnotation: "sometimes"}, wantErr: true},
+		{
+			annotations: map[string]string{
+				PreCallChecksAnnotation:          "true",
+				ContextWindowFallbacksAnnotation: "gpt-4o-mini=gpt-4o, gpt-4.1; claude-haiku=claude-sonnet;",
+			},
+			want: &RouterSettings{
+				EnablePreCallChecks: true,
+				ContextWindowFallbacks: []map[string][]string{
+					{"gpt-4o-mini": {"gpt-4o", "gpt-4.1"}},
+					{"claude-haiku": {"claude-sonnet"}},
+				},
+			},
+		},
+		{annotations: map[string]string{ContextWindowFallbacksAnnotation: "gpt-4o-mini"}, wantErr: true},
+		{annotations: map[string]string{ContextWindowFallbacksAnnotation: "gpt-4o-mini="}, wantErr: true},
+		{annotations: map[string]string{ContextWindowFallbacksAnnotation: "=gpt-4o"}, wantErr: true},
+		{annotations: map[string]string{ContextWindowFallbacksAnnotation: "gpt-4o-mini=gpt-5"}, wantErr: true},
+		{annotations: map[string]string{ContextWindowFallbacksAnnotation: "gpt-4o-mini=gpt-4o;gpt-4o-mini=gpt-4.1"}, wantErr: true},
+	} {
+		got, err := ParseRouterSettings(tc.annotations, models)
+		if tc.wantErr {
+			var pe *PhaseError
+			if !errors.As(err, &pe) || pe.Phase != PreCallChecksPhase {
+				t.Errorf("%v: want a %s PhaseError, got %v", tc.annotations, PreCallChecksPhase, err)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%v: got %+v, %v, want %+v", tc.annotations, got, err, tc.want)
+		}
+	}
+}
+
+func TestRenderConfig_RouterSettings(t *testing.T) {
+	config := LiteLLMConfig{RouterSettings: &RouterSettings{
+		EnablePreCallChecks:    true,
+		ContextWindowFallbacks: []map[string][]string{{"gpt-4o-mini": {"gpt-4o"}}},
+	}}
+	got, err := RenderConfigWithPatch(config, nil)
+	if err != nil {
+		t.Fatalf("RenderConfigWithPatch: %v", err)
+	}
+	want := "router_settings:\n    enable_pre_call_checks: true\n    context_window_fallbacks:\n        - gpt-4o-mini:\n            - gpt-4o\n"
+	if !strings.Contains(got, want) {
+		t.Errorf("config does not contain\n%s\ngot\n%s", want, got)
+	}
+}
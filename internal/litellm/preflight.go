@@ -0,0 +1,179 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PreflightAnnotation, set to "true", checks every provider of the gateway
+// with its API key before the gateway's config is rolled out: a cheap,
+// authenticated call such as listing the provider's models.
+const PreflightAnnotation = "ai-gateway-litellm.agentic-layer.ai/preflight"
+
+// Preflight reports whether PreflightAnnotation is set to "true".
+func Preflight(annotations map[string]string) bool {
+	return annotations[PreflightAnnotation] == "true"
+}
+
+// defaultPreflightTimeout bounds the check of one provider.
+const defaultPreflightTimeout = 10 * time.Second
+
+// preflightCheck is the endpoint a provider is checked against and how its
+// API key is sent.
+type preflightCheck struct {
+	url  string
+	auth func(h http.Header, apiKey string)
+}
+
+func bearer(h http.Header, apiKey string) { h.Set("Authorization", "Bearer "+apiKey) }
+
+// preflightChecks lists the providers with a known check. Each endpoint
+// needs a valid key and costs no tokens.
+var preflightChecks = map[string]preflightCheck{
+	"anthropic": {url: "https://api.anthropic.com/v1/models", auth: func(h http.Header, apiKey string) {
+		h.Set("X-Api-Key", apiKey)
+		h.Set("Anthropic-Version", "2023-06-01")
+	}},
+	"cerebras":     {url: "https://api.cerebras.ai/v1/models", auth: bearer},
+	"cohere":       {url: "https://api.cohere.com/v1/models", auth: bearer},
+	"deepinfra":    {url: "https://api.deepinfra.com/v1/openai/models", auth: bearer},
+	"deepseek":     {url: "https://api.deepseek.com/models", auth: bearer},
+	"fireworks_ai": {url: "https://api.fireworks.ai/inference/v1/models", auth: bearer},
+	"gemini": {url: "https://generativelanguage.googleapis.com/v1beta/models", auth: func(h http.Header, apiKey string) {
+		h.Set("X-Goog-Api-Key", apiKey)
+	}},
+	"groq":        {url: "https://api.groq.com/openai/v1/models", auth: bearer},
+	"mistral":     {url: "https://api.mistral.ai/v1/models", auth: bearer},
+	"openai":      {url: "https://api.openai.com/v1/models", auth: bearer},
+	"openrouter":  {url: "https://openrouter.ai/api/v1/key", auth: bearer},
+	"together_ai": {url: "https://api.together.xyz/v1/models", auth: bearer},
+	"xai":         {url: "https://api.x.ai/v1/models", auth: bearer},
+}
+
+// ProviderResult is the preflight check of one provider.
+type ProviderResult struct {
+	Provider string
+	// Skipped is set for providers without a known check.
+	Skipped bool
+	// Err is why the check failed, nil if it passed or was skipped.
+	Err error
+}
+
+// String is the result as listed in the preflight condition.
+func (r ProviderResult) String() string {
+	switch {
+	case r.Skipped:
+		return r.Provider + ": not checked"
+	case r.Err != nil:
+		return r.Provider + ": " + r.Err.Error()
+	default:
+		return r.Provider + ": ok"
+	}
+}
+
+// PreflightChecker checks providers before a gateway calls them.
+type PreflightChecker struct {
+	HTTPClient *http.Client
+	// URLs replaces the endpoint a provider is checked against, by provider.
+	URLs map[string]string
+}
+
+// Check calls the check endpoint of provider with apiKey. A missing key, a
+// rejected key and an unreachable provider all fail the check.
+func (c *PreflightChecker) Check(ctx context.Context, provider, apiKey string) ProviderResult {
+	result := ProviderResult{Provider: provider}
+	check, ok := preflightChecks[provider]
+	if !ok {
+		result.Skipped = true
+		return result
+	}
+	if apiKey == "" {
+		result.Err = errors.New("no API key")
+		return result
+	}
+	url := check.url
+	if u, ok := c.URLs[provider]; ok {
+		url = u
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, defaultPreflightTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	req.Header.Set("Accept", "application/json")
+	check.auth(req.Header, apiKey)
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		result.Err = fmt.Errorf("unreachable: %w", err)
+		return result
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<20))
+	_ = resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		result.Err = fmt.Errorf("API key rejected with status %d", resp.StatusCode)
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		result.Err = fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return result
+}
+
+// EnvValue returns the value of the env var name in env, reading a
+// secretKeyRef from namespace. A missing optional Secret or key, like an
+// unset env var, yields "".
+func EnvValue(ctx context.Context, c client.Reader, namespace string, env []corev1.EnvVar, name string) (string, error) {
+	for _, e := range env {
+		if e.Name != name {
+			continue
+		}
+		if e.ValueFrom == nil || e.ValueFrom.SecretKeyRef == nil {
+			return e.Value, nil
+		}
+		ref := e.ValueFrom.SecretKeyRef
+		secret := &corev1.Secret{}
+		if err := c.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: namespace}, secret); err != nil {
+			if apierrors.IsNotFound(err) && ref.Optional != nil && *ref.Optional {
+				return "", nil
+			}
+			return "", fmt.Errorf("failed to get secret %s: %w", ref.Name, err)
+		}
+		v, ok := secret.Data[ref.Key]
+		if !ok && (ref.Optional == nil || !*ref.Optional) {
+			return "", fmt.Errorf("secret %s has no key %s", ref.Name, ref.Key)
+		}
+		return string(v), nil
+	}
+	return "", nil
+}
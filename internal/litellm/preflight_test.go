@@ -0,0 +1,95 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestPreflightChecker_Check(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Header.Get("Authorization") == "Bearer good", r.Header.Get("X-Api-Key") == "good":
+			_, _ = w.Write([]byte(`{"data": []}`))
+		case r.URL.Path == "/down":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer srv.Close()
+	checker := &PreflightChecker{URLs: map[string]string{
+		"openai":    srv.URL + "/v1/models",
+		"anthropic": srv.URL + "/v1/models",
+		"mistral":   srv.URL + "/down",
+		"groq":      "http://127.0.0.1:1/v1/models",
+	}}
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		provider, apiKey, want string
+	}{
+		{"openai", "good", "openai: ok"},
+		{"anthropic", "good", "anthropic: ok"},
+		{"openai", "bad", "openai: API key rejected with status 401"},
+		{"openai", "", "openai: no API key"},
+		{"mistral", "bad", "mistral: unexpected status 503"},
+		{"perplexity", "", "perplexity: not checked"},
+	} {
+		if got := checker.Check(ctx, tc.provider, tc.apiKey).String(); got != tc.want {
+			t.Errorf("%s with %q: got %q, want %q", tc.provider, tc.apiKey, got, tc.want)
+		}
+	}
+	if result := checker.Check(ctx, "groq", "good"); result.Err == nil || result.Skipped {
+		t.Errorf("an unreachable provider must fail, got %v", result)
+	}
+}
+
+func TestEnvValue(t *testing.T) {
+	optional := true
+	ref := func(name, key string, optional *bool) *corev1.EnvVarSource {
+		return &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: name}, Key: key, Optional: optional}}
+	}
+	c := fake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "keys", Namespace: "ns"},
+		Data:       map[string][]byte{"OPENAI_API_KEY": []byte("sk-1")},
+	}).Build()
+	env := []corev1.EnvVar{
+		{Name: "LITERAL", Value: "v"},
+		{Name: "OPENAI_API_KEY", ValueFrom: ref("keys", "OPENAI_API_KEY", nil)},
+		{Name: "ANTHROPIC_API_KEY", ValueFrom: ref("keys", "ANTHROPIC_API_KEY", &optional)},
+		{Name: "GEMINI_API_KEY", ValueFrom: ref("missing", "GEMINI_API_KEY", &optional)},
+		{Name: "MISTRAL_API_KEY", ValueFrom: ref("missing", "MISTRAL_API_KEY", nil)},
+	}
+	ctx := context.Background()
+	for name, want := range map[string]string{"LITERAL": "v", "OPENAI_API_KEY": "sk-1", "ANTHROPIC_API_KEY": "", "GEMINI_API_KEY": "", "UNSET": ""} {
+		if got, err := EnvValue(ctx, c, "ns", env, name); err != nil || got != want {
+			t.Errorf("%s: got %q, %v, want %q", name, got, err, want)
+		}
+	}
+	if _, err := EnvValue(ctx, c, "ns", env, "MISTRAL_API_KEY"); err == nil {
+		t.Error("a missing required Secret must be an error")
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import "k8s.io/apimachinery/pkg/util/sets"
+
+// KnownProviders are the LiteLLM provider prefixes (the part before "/" in a
+// LiteLLM model string) supported by the pinned Image. Only prefixes that
+// form a valid env var name in "<PROVIDER>_API_KEY" are listed.
+var KnownProviders = sets.New(
+	"ai21",
+	"anthropic",
+	"anyscale",
+	"azure",
+	"azure_ai",
+	"bedrock",
+	"cerebras",
+	"clarifai",
+	"cloudflare",
+	"codestral",
+	"cohere",
+	"cohere_chat",
+	"custom_openai",
+	"dashscope",
+	"databricks",
+	"deepinfra",
+	"deepseek",
+	"fireworks_ai",
+	"friendliai",
+	"gemini",
+	"github",
+	"groq",
+	"hosted_vllm",
+	"huggingface",
+	"hyperbolic",
+	"lm_studio",
+	"mistral",
+	"moonshot",
+	"nebius",
+	"nlp_cloud",
+	"novita",
+	"nvidia_nim",
+	"ollama",
+	"ollama_chat",
+	"openai",
+	"openrouter",
+	"perplexity",
+	"predibase",
+	"replicate",
+	"sagemaker",
+	"sambanova",
+	"together_ai",
+	"vertex_ai",
+	"vllm",
+	"voyage",
+	"watsonx",
+	"xai",
+)
+
+// KeylessProviders serve models without an API key, so a missing
+// <PROVIDER>_API_KEY entry is expected for them.
+var KeylessProviders = sets.New("ollama", "ollama_chat", "hosted_vllm", "vllm", "lm_studio")
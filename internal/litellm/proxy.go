@@ -0,0 +1,137 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Egress proxy annotations of a gateway. Each overrides the operator's
+// default of the same setting; an empty value clears it.
+const (
+	// HTTPProxyAnnotation is the proxy plain HTTP requests leave through.
+	HTTPProxyAnnotation = "ai-gateway-litellm.agentic-layer.ai/http-proxy"
+	// HTTPSProxyAnnotation is the proxy HTTPS requests leave through.
+	HTTPSProxyAnnotation = "ai-gateway-litellm.agentic-layer.ai/https-proxy"
+	// NoProxyAnnotation lists, comma-separated, further hosts, domains and
+	// CIDRs reached directly.
+	NoProxyAnnotation = "ai-gateway-litellm.agentic-layer.ai/no-proxy"
+)
+
+// EgressProxyPhase tags invalid egress proxy settings.
+const EgressProxyPhase = "EgressProxy"
+
+// clusterNoProxy is always reached directly: the pod itself, where the
+// collector sidecar listens, and in-cluster Services such as model servers,
+// the managed cache and upstream gateways.
+var clusterNoProxy = []string{"localhost", "127.0.0.1", "::1", ".svc", ".cluster.local"}
+
+// EgressProxy is the proxy a gateway reaches providers through.
+type EgressProxy struct {
+	// HTTPProxy is the URL of the proxy for plain HTTP requests.
+	HTTPProxy string
+	// HTTPSProxy is the URL of the proxy for HTTPS requests.
+	HTTPSProxy string
+	// NoProxy lists the hosts, domains and CIDRs reached directly, such as
+	// the cluster's pod and Service CIDRs.
+	NoProxy []string
+}
+
+// ParseEgressProxy returns the egress proxy of a gateway with annotations:
+// defaults, with each setting its annotation sets replaced and the
+// NoProxyAnnotation entries added. It returns nil when neither proxy is
+// set. Invalid values yield a *PhaseError.
+func ParseEgressProxy(annotations map[string]string, defaults EgressProxy) (*EgressProxy, error) {
+	p := defaults
+	if v, ok := annotations[HTTPProxyAnnotation]; ok {
+		p.HTTPProxy = strings.TrimSpace(v)
+	}
+	if v, ok := annotations[HTTPSProxyAnnotation]; ok {
+		p.HTTPSProxy = strings.TrimSpace(v)
+	}
+	for _, proxy := range []string{p.HTTPProxy, p.HTTPSProxy} {
+		if err := validateProxyURL(proxy); err != nil {
+			return nil, &PhaseError{Phase: EgressProxyPhase, Err: err}
+		}
+	}
+	if p.HTTPProxy == "" && p.HTTPSProxy == "" {
+		return nil, nil
+	}
+	p.NoProxy = append(slices.Clone(p.NoProxy), SplitNoProxy(annotations[NoProxyAnnotation])...)
+	return &p, nil
+}
+
+// SplitNoProxy returns the entries of a comma-separated NO_PROXY list.
+func SplitNoProxy(list string) []string {
+	return splitList(list)
+}
+
+// splitList returns the non-empty, trimmed entries of a comma-separated list.
+func splitList(list string) []string {
+	var entries []string
+	for entry := range strings.SplitSeq(list, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+func validateProxyURL(proxy string) error {
+	if proxy == "" {
+		return nil
+	}
+	u, err := url.Parse(proxy)
+	if err != nil || u.Host == "" || !slices.Contains([]string{"http", "https", "socks5", "socks5h"}, u.Scheme) {
+		return fmt.Errorf("invalid proxy %q: must be an http, https or socks5 URL", proxy)
+	}
+	return nil
+}
+
+// EgressProxyEnvVars returns the proxy env vars of p, in upper and lower
+// case since clients disagree on which they read, or none without a proxy.
+// NO_PROXY holds p.NoProxy and the in-cluster names, without duplicates.
+func EgressProxyEnvVars(p *EgressProxy) []corev1.EnvVar {
+	if p == nil {
+		return nil
+	}
+	var noProxy []string
+	for _, entry := range append(slices.Clone(clusterNoProxy), p.NoProxy...) {
+		if !slices.Contains(noProxy, entry) {
+			noProxy = append(noProxy, entry)
+		}
+	}
+	var env []corev1.EnvVar
+	for _, v := range []struct{ name, value string }{
+		{"HTTP_PROXY", p.HTTPProxy},
+		{"HTTPS_PROXY", p.HTTPSProxy},
+		{"NO_PROXY", strings.Join(noProxy, ",")},
+	} {
+		if v.value == "" {
+			continue
+		}
+		env = append(env,
+			corev1.EnvVar{Name: v.name, Value: v.value},
+			corev1.EnvVar{Name: strings.ToLower(v.name), Value: v.value})
+	}
+	return env
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestParseEgressProxy(t *testing.T) {
+	defaults := EgressProxy{HTTPSProxy: "http://proxy.corp:3128", NoProxy: []string{"10.0.0.0/8"}}
+	cases := []struct {
+		name        string
+		annotations map[string]string
+		defaults    EgressProxy
+		want        *EgressProxy
+		wantErr     bool
+	}{
+		{name: "none"},
+		{name: "no-proxy only", annotations: map[string]string{NoProxyAnnotation: "example.com"}},
+		{name: "operator default", defaults: defaults, want: &defaults},
+		{
+			name:        "gateway override",
+			annotations: map[string]string{HTTPSProxyAnnotation: "socks5://egress.team:1080", NoProxyAnnotation: " .internal, ,db.corp"},
+			defaults:    defaults,
+			want:        &EgressProxy{HTTPSProxy: "socks5://egress.team:1080", NoProxy: []string{"10.0.0.0/8", ".internal", "db.corp"}},
+		},
+		{name: "gateway opt-out", annotations: map[string]string{HTTPSProxyAnnotation: ""}, defaults: defaults},
+		{name: "no scheme", annotations: map[string]string{HTTPProxyAnnotation: "proxy.corp:3128"}, wantErr: true},
+		{name: "ftp", annotations: map[string]string{HTTPSProxyAnnotation: "ftp://proxy.corp"}, wantErr: true},
+	}
+	for _, tc := range cases {
+		got, err := ParseEgressProxy(tc.annotations, tc.defaults)
+		if tc.wantErr {
+			var pe *PhaseError
+			if !errors.As(err, &pe) || pe.Phase != EgressProxyPhase {
+				t.Errorf("%s: want a %s PhaseError, got %v", tc.name, EgressProxyPhase, err)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: got %+v, %v, want %+v", tc.name, got, err, tc.want)
+		}
+	}
+	if defaults.NoProxy[0] != "10.0.0.0/8" || len(defaults.NoProxy) != 1 {
+		t.Errorf("the defaults must not be modified, got %v", defaults.NoProxy)
+	}
+}
+
+func TestEgressProxyEnvVars(t *testing.T) {
+	if env := EgressProxyEnvVars(nil); env != nil {
+		t.Errorf("without a proxy: %v", env)
+	}
+	env := EgressProxyEnvVars(&EgressProxy{HTTPSProxy: "http://proxy.corp:3128", NoProxy: []string{"10.0.0.0/8", ".svc"}})
+	want := []corev1.EnvVar{
+		{Name: "HTTPS_PROXY", Value: "http://proxy.corp:3128"},
+		{Name: "https_proxy", Value: "http://proxy.corp:3128"},
+		{Name: "NO_PROXY", Value: "localhost,127.0.0.1,::1,.svc,.cluster.local,10.0.0.0/8"},
+		{Name: "no_proxy", Value: "localhost,127.0.0.1,::1,.svc,.cluster.local,10.0.0.0/8"},
+	}
+	if !reflect.DeepEqual(env, want) {
+		t.Errorf("got %v, want %v", env, want)
+	}
+}
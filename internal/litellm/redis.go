@@ -0,0 +1,244 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	appsv1ac "k8s.io/client-go/applyconfigurations/apps/v1"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
+	metav1ac "k8s.io/client-go/applyconfigurations/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// ManagedCacheAnnotation, set to "true", makes the operator run a Redis for
+// the gateway and enable LiteLLM response caching against it.
+const ManagedCacheAnnotation = "ai-gateway-litellm.agentic-layer.ai/managed-cache"
+
+// ManagedCachePhase tags managed cache failures.
+const ManagedCachePhase = "ManagedCache"
+
+// RedisImage is the image of the managed Redis.
+const RedisImage = "redis:7.4-alpine"
+
+// RedisPort is the port of the managed Redis Service.
+const RedisPort = 6379
+
+// RedisPasswordKey is the key of the managed Redis Secret holding the
+// password, and the env var it reaches the LiteLLM container as.
+const RedisPasswordKey = "REDIS_PASSWORD"
+
+// RedisMaxMemory caps the managed Redis. Keys are evicted least recently
+// used first, so a full cache drops old responses instead of failing writes.
+const RedisMaxMemory = "200mb"
+
+// ManagedCache reports whether the gateway asks for a managed Redis cache.
+// Invalid values yield a *PhaseError.
+func ManagedCache(annotations map[string]string) (bool, error) {
+	v, ok := annotations[ManagedCacheAnnotation]
+	if !ok {
+		return false, nil
+	}
+	enabled, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, &PhaseError{Phase: ManagedCachePhase, Err: fmt.Errorf(
+			"invalid %s annotation %q: must be \"true\" or \"false\"", ManagedCacheAnnotation, v)}
+	}
+	return enabled, nil
+}
+
+// RedisName is the name of the managed Redis Deployment, Service and Secret
+// of the gateway name.
+func RedisName(name string) string {
+	return name + "-redis"
+}
+
+// RedisCacheSettings returns the litellm_settings cache parameters pointing
+// at the managed Redis of gateway name in namespace.
+func RedisCacheSettings(name, namespace string) *CacheParams {
+	return &CacheParams{
+		Type:     "redis",
+		Host:     fmt.Sprintf("%s.%s.svc.cluster.local", RedisName(name), namespace),
+		Port:     strconv.Itoa(RedisPort),
+		Password: "os.environ/" + RedisPasswordKey,
+	}
+}
+
+// RedisPasswordEnvVar returns the env var loading the managed Redis password
+// into the LiteLLM container of gateway name.
+func RedisPasswordEnvVar(name string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: RedisPasswordKey,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: RedisName(name)},
+				Key:                  RedisPasswordKey,
+			},
+		},
+	}
+}
+
+// ReconcileManagedCache applies the managed Redis of w when enabled, and
+// deletes it otherwise. The password Secret is generated once and kept
+// across reconciles, so the cache survives operator restarts.
+//
+// On failure, the returned error is a *PhaseError tagged ManagedCachePhase.
+func ReconcileManagedCache(ctx context.Context, c client.Client, scheme *runtime.Scheme, w GatewayWorkload, enabled bool) error {
+	name := RedisName(w.Name)
+	phaseErr := func(err error) error { return &PhaseError{Phase: ManagedCachePhase, Err: err} }
+
+	if !enabled {
+		for _, obj := range []client.Object{&appsv1.Deployment{}, &corev1.Service{}, &corev1.Secret{}} {
+			if err := deleteOwned(ctx, c, w, obj, name); err != nil {
+				return phaseErr(err)
+			}
+		}
+		return nil
+	}
+
+	password, err := ensureRedisSecret(ctx, c, scheme, w)
+	if err != nil {
+		return phaseErr(err)
+	}
+	ownerRef, err := controllerReference(w.Owner, scheme)
+	if err != nil {
+		return phaseErr(err)
+	}
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: w.Namespace}}
+	if err := apply(ctx, c, w, BuildRedisDeployment(w, ownerRef, hashYAML(password)), deployment, "Deployment"); err != nil {
+		return phaseErr(err)
+	}
+	service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: w.Namespace}}
+	if err := apply(ctx, c, w, BuildRedisService(w, ownerRef), service, "Service"); err != nil {
+		return phaseErr(err)
+	}
+	return nil
+}
+
+// ensureRedisSecret creates the password Secret of the managed Redis unless
+// it exists, and returns the password. A Secret the gateway does not control is reported as a
+// *ConflictError rather than used, since its password is not ours to trust.
+func ensureRedisSecret(ctx context.Context, c client.Client, scheme *runtime.Scheme, w GatewayWorkload) (string, error) {
+	name := RedisName(w.Name)
+	var existing corev1.Secret
+	err := c.Get(ctx, client.ObjectKey{Namespace: w.Namespace, Name: name}, &existing)
+	if err == nil {
+		if !metav1.IsControlledBy(&existing, w.Owner) {
+			return "", &ConflictError{Kind: "Secret", Namespace: w.Namespace, Name: name,
+				Reason: "is not controlled by the gateway", Controlled: metav1.GetControllerOfNoCopy(&existing) != nil}
+		}
+		return string(existing.Data[RedisPasswordKey]), nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return "", err
+	}
+
+	random := make([]byte, 24)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	password := hex.EncodeToString(random)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: w.Namespace,
+			Labels:    BuildResourceLabels(w.Name, w.CommonMetadata),
+		},
+		Data: map[string][]byte{RedisPasswordKey: []byte(password)},
+	}
+	if err := controllerutil.SetControllerReference(w.Owner, secret, scheme); err != nil {
+		return "", err
+	}
+	var opts []client.CreateOption
+	if w.DryRun {
+		opts = append(opts, client.DryRunAll)
+	}
+	return password, c.Create(ctx, secret, opts...)
+}
+
+// BuildRedisDeployment returns the desired state of the managed Redis
+// Deployment. Redis runs without persistence: the cache is rebuilt from
+// scratch after a restart. passwordHash rolls the pod when the password
+// Secret is regenerated.
+func BuildRedisDeployment(w GatewayWorkload, ownerRef *metav1ac.OwnerReferenceApplyConfiguration, passwordHash string) *appsv1ac.DeploymentApplyConfiguration {
+	name := RedisName(w.Name)
+	container := corev1ac.Container().
+		WithName("redis").
+		WithImage(w.mirror(RedisImage)).
+		WithArgs("--requirepass", "$("+RedisPasswordKey+")",
+			"--maxmemory", RedisMaxMemory, "--maxmemory-policy", "allkeys-lru",
+			"--save", "", "--appendonly", "no").
+		WithEnv(corev1ac.EnvVar().WithName(RedisPasswordKey).WithValueFrom(
+			corev1ac.EnvVarSource().WithSecretKeyRef(corev1ac.SecretKeySelector().
+				WithName(name).WithKey(RedisPasswordKey)))).
+		WithPorts(corev1ac.ContainerPort().
+			WithName("redis").WithContainerPort(RedisPort).WithProtocol(corev1.ProtocolTCP)).
+		WithResources(corev1ac.ResourceRequirements().
+			WithRequests(corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("64Mi"),
+				corev1.ResourceCPU:    resource.MustParse("50m"),
+			}).
+			WithLimits(corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("256Mi"),
+				corev1.ResourceCPU:    resource.MustParse("250m"),
+			})).
+		WithReadinessProbe(corev1ac.Probe().
+			WithTCPSocket(corev1ac.TCPSocketAction().WithPort(intstr.FromInt32(RedisPort))).
+			WithPeriodSeconds(10).WithTimeoutSeconds(5))
+
+	return appsv1ac.Deployment(name, w.Namespace).
+		WithOwnerReferences(ownerRef).
+		WithLabels(BuildResourceLabels(w.Name, w.CommonMetadata)).
+		WithAnnotations(BuildResourceAnnotations(w.CommonMetadata)).
+		WithSpec(appsv1ac.DeploymentSpec().
+			WithReplicas(1).
+			WithStrategy(appsv1ac.DeploymentStrategy().WithType(appsv1.RecreateDeploymentStrategyType)).
+			WithSelector(metav1ac.LabelSelector().WithMatchLabels(map[string]string{"app": name})).
+			WithTemplate(corev1ac.PodTemplateSpec().
+				WithLabels(map[string]string{"app": name}).
+				WithAnnotations(map[string]string{secretHashAnnotation: passwordHash}).
+				WithSpec(corev1ac.PodSpec().WithContainers(container))))
+}
+
+// BuildRedisService returns the desired state of the managed Redis Service.
+func BuildRedisService(w GatewayWorkload, ownerRef *metav1ac.OwnerReferenceApplyConfiguration) *corev1ac.ServiceApplyConfiguration {
+	name := RedisName(w.Name)
+	return corev1ac.Service(name, w.Namespace).
+		WithOwnerReferences(ownerRef).
+		WithLabels(BuildResourceLabels(w.Name, w.CommonMetadata)).
+		WithAnnotations(BuildResourceAnnotations(w.CommonMetadata)).
+		WithSpec(corev1ac.ServiceSpec().
+			WithType(corev1.ServiceTypeClusterIP).
+			WithSelector(map[string]string{"app": name}).
+			WithPorts(corev1ac.ServicePort().
+				WithName("redis").
+				WithPort(RedisPort).
+				WithTargetPort(intstr.FromString("redis")).
+				WithProtocol(corev1.ProtocolTCP)))
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestManagedCache(t *testing.T) {
+	for _, tc := range []struct {
+		annotations map[string]string
+		want        bool
+		wantErr     bool
+	}{
+		{annotations: nil},
+		{annotations: map[string]string{ManagedCacheAnnotation: "true"}, want: true},
+		{annotations: map[string]string{ManagedCacheAnnotation: "false"}},
+		{annotations: map[string]string{ManagedCacheAnnotation: "redis"}, wantErr: true},
+	} {
+		got, err := ManagedCache(tc.annotations)
+		if tc.wantErr {
+			var pe *PhaseError
+			if !errors.As(err, &pe) || pe.Phase != ManagedCachePhase {
+				t.Errorf("%v: want a %s PhaseError, got %v", tc.annotations, ManagedCachePhase, err)
+			}
+			continue
+		}
+		if err != nil || got != tc.want {
+			t.Errorf("%v: got %v, %v, want %v", tc.annotations, got, err, tc.want)
+		}
+	}
+}
+
+func TestReconcileManagedCache(t *testing.T) {
+	s := workloadScheme(t)
+	owner := newOwner("gw", "default")
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(owner).Build()
+	ctx := context.Background()
+	w := GatewayWorkload{Name: "gw", Namespace: "default", Owner: owner}
+	key := types.NamespacedName{Name: "gw-redis", Namespace: "default"}
+
+	if err := ReconcileManagedCache(ctx, c, s, w, true); err != nil {
+		t.Fatalf("ReconcileManagedCache: %v", err)
+	}
+	var secret corev1.Secret
+	if err := c.Get(ctx, key, &secret); err != nil {
+		t.Fatalf("get Secret: %v", err)
+	}
+	password := string(secret.Data[RedisPasswordKey])
+	if len(password) == 0 {
+		t.Fatal("Secret has no password")
+	}
+	var deployment appsv1.Deployment
+	if err := c.Get(ctx, key, &deployment); err != nil {
+		t.Fatalf("get Deployment: %v", err)
+	}
+	if got := deployment.Spec.Template.Labels["app"]; got != "gw-redis" {
+		t.Errorf("pod label app = %q, must not select the gateway pods", got)
+	}
+	var service corev1.Service
+	if err := c.Get(ctx, key, &service); err != nil {
+		t.Fatalf("get Service: %v", err)
+	}
+	if service.Spec.Ports[0].Port != RedisPort {
+		t.Errorf("Service port = %d", service.Spec.Ports[0].Port)
+	}
+
+	// The password is generated once.
+	if err := ReconcileManagedCache(ctx, c, s, w, true); err != nil {
+		t.Fatalf("ReconcileManagedCache: %v", err)
+	}
+	if err := c.Get(ctx, key, &secret); err != nil {
+		t.Fatalf("get Secret: %v", err)
+	}
+	if string(secret.Data[RedisPasswordKey]) != password {
+		t.Error("password changed on the second reconcile")
+	}
+
+	if err := ReconcileManagedCache(ctx, c, s, w, false); err != nil {
+		t.Fatalf("ReconcileManagedCache: %v", err)
+	}
+	for kind, err := range map[string]error{
+		"Deployment": c.Get(ctx, key, &appsv1.Deployment{}),
+		"Service":    c.Get(ctx, key, &corev1.Service{}),
+		"Secret":     c.Get(ctx, key, &corev1.Secret{}),
+	} {
+		if !apierrors.IsNotFound(err) {
+			t.Errorf("%s: want NotFound, got %v", kind, err)
+		}
+	}
+}
+
+func TestReconcileManagedCache_ForeignSecret(t *testing.T) {
+	s := workloadScheme(t)
+	owner := newOwner("gw", "default")
+	foreign := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "gw-redis", Namespace: "default"}}
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(owner, foreign).Build()
+	w := GatewayWorkload{Name: "gw", Namespace: "default", Owner: owner}
+
+	err := ReconcileManagedCache(context.Background(), c, s, w, true)
+	var conflict *ConflictError
+	if !errors.As(err, &conflict) || conflict.Kind != "Secret" {
+		t.Fatalf("want a Secret ConflictError, got %v", err)
+	}
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// RenderOnlyAnnotation, set to "true", makes the operator render the
+// ConfigMap, Deployment and Service of a gateway into the ConfigMap
+// <name>-rendered instead of applying them, so the manifests can be
+// reviewed or committed to a GitOps repository.
+const RenderOnlyAnnotation = "ai-gateway-litellm.agentic-layer.ai/render-only"
+
+// RenderedManifestsKey is the key of the rendered ConfigMap holding the
+// manifests as a multi-document YAML stream.
+const RenderedManifestsKey = "manifests.yaml"
+
+// RenderOnly reports whether RenderOnlyAnnotation is set to "true".
+func RenderOnly(annotations map[string]string) bool {
+	return annotations[RenderOnlyAnnotation] == "true"
+}
+
+// RenderedConfigMapName is the name of the ConfigMap ReconcileRenderOnly
+// writes for the workload name.
+func RenderedConfigMapName(name string) string {
+	return name + "-rendered"
+}
+
+// RenderWorkload returns the ConfigMap, Deployment and Service that
+// ReconcileWorkload would apply for w, as a multi-document YAML stream.
+func RenderWorkload(ctx context.Context, c client.Reader, scheme *runtime.Scheme, w GatewayWorkload) (string, error) {
+	ownerRef, err := controllerReference(w.Owner, scheme)
+	if err != nil {
+		return "", err
+	}
+	secretHash, err := computeSecretHash(ctx, c, w.Namespace, ReferencedSecretNames(w.Env, w.EnvFrom))
+	if err != nil {
+		return "", err
+	}
+	cm, err := buildConfigMap(w, ownerRef)
+	if err != nil {
+		return "", err
+	}
+	deployment, err := buildDeployment(w, ownerRef, hashYAML(w.ConfigYAML), secretHash)
+	if err != nil {
+		return "", err
+	}
+	service, err := buildService(w, ownerRef)
+	if err != nil {
+		return "", err
+	}
+	docs := make([]string, 0, 3)
+	for _, obj := range []any{cm, deployment, service} {
+		raw, err := yaml.Marshal(obj)
+		if err != nil {
+			return "", err
+		}
+		docs = append(docs, string(raw))
+	}
+	return strings.Join(docs, "---\n"), nil
+}
+
+// ReconcileRenderOnly renders the workload of w with RenderWorkload and
+// applies the result as the ConfigMap <name>-rendered, owned by w.Owner.
+// The workload itself is neither applied nor deleted. Failures are
+// *PhaseError tagged "Render".
+func ReconcileRenderOnly(ctx context.Context, c client.Client, scheme *runtime.Scheme, w GatewayWorkload) error {
+	manifests, err := RenderWorkload(ctx, c, scheme, w)
+	if err != nil {
+		return &PhaseError{Phase: "Render", Err: err}
+	}
+	ownerRef, err := controllerReference(w.Owner, scheme)
+	if err != nil {
+		return &PhaseError{Phase: "Render", Err: err}
+	}
+	name := RenderedConfigMapName(w.Name)
+	cm := corev1ac.ConfigMap(name, w.Namespace).
+		WithOwnerReferences(ownerRef).
+		WithLabels(BuildResourceLabels(w.Name, w.CommonMetadata)).
+		WithData(map[string]string{RenderedManifestsKey: manifests})
+	existing := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: w.Namespace}}
+	if err := apply(ctx, c, w, cm, existing, "ConfigMap"); err != nil {
+		return &PhaseError{Phase: "Render", Err: fmt.Errorf("writing %s: %w", name, err)}
+	}
+	return nil
+}
+
+// DeleteRenderedManifests deletes the ConfigMap written by
+// ReconcileRenderOnly once a gateway leaves render-only mode.
+func DeleteRenderedManifests(ctx context.Context, c client.Client, w GatewayWorkload) error {
+	return deleteOwned(ctx, c, w, &corev1.ConfigMap{}, RenderedConfigMapName(w.Name))
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconcileRenderOnly(t *testing.T) {
+	s := workloadScheme(t)
+	owner := newOwner("gw", "default")
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(owner).Build()
+	ctx := context.Background()
+	w := GatewayWorkload{
+		Name:          "gw",
+		Namespace:     "default",
+		Owner:         owner,
+		ContainerPort: 4000,
+		ServicePort:   4000,
+		ConfigYAML:    "model_list: []\n",
+	}
+
+	if err := ReconcileRenderOnly(ctx, c, s, w); err != nil {
+		t.Fatalf("ReconcileRenderOnly: %v", err)
+	}
+	var cm corev1.ConfigMap
+	if err := c.Get(ctx, types.NamespacedName{Name: "gw-rendered", Namespace: "default"}, &cm); err != nil {
+		t.Fatalf("get rendered ConfigMap: %v", err)
+	}
+	manifests := cm.Data[RenderedManifestsKey]
+	for _, want := range []string{"kind: ConfigMap", "kind: Deployment", "kind: Service", "name: gw-config", "model_list: []"} {
+		if !strings.Contains(manifests, want) {
+			t.Errorf("rendered manifests lack %q:\n%s", want, manifests)
+		}
+	}
+	if err := c.Get(ctx, types.NamespacedName{Name: "gw", Namespace: "default"}, &appsv1.Deployment{}); !apierrors.IsNotFound(err) {
+		t.Errorf("Deployment must not be applied in render-only mode, got %v", err)
+	}
+
+	if err := DeleteRenderedManifests(ctx, c, w); err != nil {
+		t.Fatalf("DeleteRenderedManifests: %v", err)
+	}
+	if err := c.Get(ctx, types.NamespacedName{Name: "gw-rendered", Namespace: "default"}, &cm); !apierrors.IsNotFound(err) {
+		t.Errorf("rendered ConfigMap still present: %v", err)
+	}
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	metav1ac "k8s.io/client-go/applyconfigurations/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ReplicateToAnnotation lists, comma-separated, Secrets in the operator's
+// replication namespace holding kubeconfigs of remote clusters the gateway's
+// ConfigMap, Deployment and Service are replicated to.
+const ReplicateToAnnotation = "ai-gateway-litellm.agentic-layer.ai/replicate-to"
+
+// ReplicatedToAnnotation records, comma-separated, the kubeconfig Secrets
+// of the clusters holding replicas of the gateway, so replicas of clusters
+// dropped from ReplicateToAnnotation are deleted. The operator maintains it.
+const ReplicatedToAnnotation = "ai-gateway-litellm.agentic-layer.ai/replicated-to"
+
+// ReplicaFinalizer holds a gateway until its replicas are deleted.
+const ReplicaFinalizer = "ai-gateway-litellm.agentic-layer.ai/replicas"
+
+// ReplicaOfLabel marks the objects of a replica with the name of the
+// gateway they were replicated from.
+const ReplicaOfLabel = "ai-gateway-litellm.agentic-layer.ai/replica-of"
+
+// KubeconfigKey is the key of a replication target Secret holding the
+// kubeconfig of the remote cluster.
+const KubeconfigKey = "kubeconfig"
+
+// ReplicationTargets returns the sorted, de-duplicated Secret names listed
+// in the comma-separated annotation key.
+func ReplicationTargets(annotations map[string]string, key string) []string {
+	var names []string
+	for name := range strings.SplitSeq(annotations[key], ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// RemoteClientFunc builds a client for the cluster of a kubeconfig.
+type RemoteClientFunc func(kubeconfig []byte, scheme *runtime.Scheme) (client.Client, error)
+
+// NewRemoteClient is the RemoteClientFunc used outside tests. Kubeconfigs
+// ValidateKubeconfig rejects are refused.
+func NewRemoteClient(kubeconfig []byte, scheme *runtime.Scheme) (client.Client, error) {
+	if err := ValidateKubeconfig(kubeconfig); err != nil {
+		return nil, err
+	}
+	config, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("parsing kubeconfig: %w", err)
+	}
+	return client.New(config, client.Options{Scheme: scheme})
+}
+
+// ValidateKubeconfig rejects kubeconfigs that make the client run commands
+// or read local files: exec credential plugins, auth providers, and token,
+// certificate and key files. Either would run or read inside the operator
+// pod, with its permissions.
+func ValidateKubeconfig(kubeconfig []byte) error {
+	config, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return fmt.Errorf("parsing kubeconfig: %w", err)
+	}
+	for name, user := range config.AuthInfos {
+		switch {
+		case user.Exec != nil:
+			return fmt.Errorf("user %q of the kubeconfig uses an exec credential plugin", name)
+		case user.AuthProvider != nil:
+			return fmt.Errorf("user %q of the kubeconfig uses an auth provider", name)
+		case user.TokenFile != "" || user.ClientCertificate != "" || user.ClientKey != "":
+			return fmt.Errorf("user %q of the kubeconfig reads credentials from files", name)
+		}
+	}
+	for name, cluster := range config.Clusters {
+		if cluster.CertificateAuthority != "" {
+			return fmt.Errorf("cluster %q of the kubeconfig reads its certificate authority from a file", name)
+		}
+	}
+	return nil
+}
+
+// ReplicateWorkload applies the ConfigMap, Deployment and Service of w to
+// the remote cluster behind remote, in the namespace of the same name. The
+// objects are the ones ReconcileWorkload applies locally, without owner
+// references and labelled ReplicaOfLabel. Objects not labelled as replicas
+// of w are left alone and reported as a *ConflictError. Blue/green rollouts
+// are replicated as rolling updates. The secret hash is taken of the
+// Secrets in the local cluster, read through c; the remote cluster must
+// hold Secrets of the same names.
+func ReplicateWorkload(ctx context.Context, c client.Reader, remote client.Client, w GatewayWorkload) error {
+	secretHash, err := computeSecretHash(ctx, c, w.Namespace, ReferencedSecretNames(w.Env, w.EnvFrom))
+	if err != nil {
+		return err
+	}
+	// The owner does not exist in the remote cluster.
+	noOwner := metav1ac.OwnerReference()
+	cm, err := buildConfigMap(w, noOwner)
+	if err != nil {
+		return err
+	}
+	deployment, err := buildDeployment(w, noOwner, hashYAML(w.ConfigYAML), secretHash)
+	if err != nil {
+		return err
+	}
+	service, err := buildService(w, noOwner)
+	if err != nil {
+		return err
+	}
+	replicaOf := map[string]string{ReplicaOfLabel: w.Name, ManagedByLabel: FieldManager}
+	cm.WithLabels(replicaOf).OwnerReferences = nil
+	deployment.WithLabels(replicaOf).OwnerReferences = nil
+	service.WithLabels(replicaOf).OwnerReferences = nil
+	// The hostname points at the primary cluster's Service only.
+	delete(service.Annotations, ExternalDNSHostnameAnnotation)
+
+	for _, obj := range []struct {
+		apply    runtime.ApplyConfiguration
+		existing client.Object
+		kind     string
+	}{
+		{cm, &corev1.ConfigMap{}, "ConfigMap"},
+		{deployment, &appsv1.Deployment{}, "Deployment"},
+		{service, &corev1.Service{}, "Service"},
+	} {
+		name := w.Name
+		if obj.kind == "ConfigMap" {
+			name = *cm.Name
+		}
+		err := remote.Get(ctx, client.ObjectKey{Namespace: w.Namespace, Name: name}, obj.existing)
+		switch {
+		case err == nil && obj.existing.GetLabels()[ReplicaOfLabel] != w.Name:
+			return &ConflictError{Kind: obj.kind, Namespace: w.Namespace, Name: name,
+				Reason: fmt.Sprintf("is not labelled %s=%s in the remote cluster", ReplicaOfLabel, w.Name)}
+		case err != nil && !apierrors.IsNotFound(err):
+			return err
+		}
+		if err := remote.Apply(ctx, obj.apply, client.FieldOwner(FieldManager), client.ForceOwnership); err != nil {
+			return fmt.Errorf("applying %s %s: %w", obj.kind, name, err)
+		}
+	}
+	return nil
+}
+
+// DeleteReplica deletes the objects ReplicateWorkload applied for w from
+// the remote cluster behind remote.
+func DeleteReplica(ctx context.Context, remote client.Client, w GatewayWorkload) error {
+	for _, obj := range []client.Object{
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: w.Name + "-config"}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: w.Name}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: w.Name}},
+	} {
+		if err := remote.Get(ctx, client.ObjectKey{Namespace: w.Namespace, Name: obj.GetName()}, obj); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if obj.GetLabels()[ReplicaOfLabel] != w.Name {
+			continue
+		}
+		if err := remote.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReplicationTargets(t *testing.T) {
+	got := ReplicationTargets(map[string]string{ReplicateToAnnotation: " us-east,eu-west,, us-east"}, ReplicateToAnnotation)
+	if want := []string{"eu-west", "us-east"}; !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestReplicateWorkload_LeavesForeignObjectsAlone(t *testing.T) {
+	s := workloadScheme(t)
+	owner := newOwner("gw", "default")
+	local := fake.NewClientBuilder().WithScheme(s).WithObjects(owner).Build()
+	foreign := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "default", Labels: map[string]string{"app": "gw"}}}
+	remote := fake.NewClientBuilder().WithScheme(s).WithObjects(foreign).Build()
+	w := GatewayWorkload{Name: "gw", Namespace: "default", Owner: owner, ContainerPort: 4000, ServicePort: 4000, ConfigYAML: "model_list: []\n"}
+
+	var conflict *ConflictError
+	if err := ReplicateWorkload(context.Background(), local, remote, w); !errors.As(err, &conflict) || conflict.Kind != "Deployment" {
+		t.Fatalf("got %v, want a Deployment conflict", err)
+	}
+	if err := DeleteReplica(context.Background(), remote, w); err != nil {
+		t.Fatalf("DeleteReplica: %v", err)
+	}
+	if err := remote.Get(context.Background(), client.ObjectKeyFromObject(foreign), &appsv1.Deployment{}); err != nil {
+		t.Errorf("foreign Deployment deleted: %v", err)
+	}
+}
+
+func TestValidateKubeconfig(t *testing.T) {
+	const kubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: eu-west
+  cluster:
+    server: https://eu-west.example.com
+%s
+users:
+- name: operator
+  user:
+%s
+contexts:
+- name: eu-west
+  context:
+    cluster: eu-west
+    user: operator
+current-context: eu-west
+`
+	for _, tt := range []struct {
+		name    string
+		cluster string
+		user    string
+		wantErr string
+	}{
+		{name: "token", user: "    token: secret"},
+		{name: "exec plugin", user: "    exec:\n      apiVersion: client.authentication.k8s.io/v1\n      command: sh\n      args: [-c, id]",
+			wantErr: "exec credential plugin"},
+		{name: "auth provider", user: "    auth-provider:\n      name: oidc", wantErr: "auth provider"},
+		{name: "token file", user: "    tokenFile: /var/run/secrets/kubernetes.io/serviceaccount/token", wantErr: "files"},
+		{name: "client key file", user: "    client-certificate: /tmp/tls.crt\n    client-key: /tmp/tls.key", wantErr: "files"},
+		{name: "certificate authority file", cluster: "    certificate-authority: /etc/ssl/ca.crt", user: "    token: secret",
+			wantErr: "certificate authority"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			config := []byte(fmt.Sprintf(kubeconfig, tt.cluster, tt.user))
+			err := ValidateKubeconfig(config)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("ValidateKubeconfig: %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("got %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewRemoteClient_RefusesExecPlugin(t *testing.T) {
+	marker := filepath.Join(t.TempDir(), "ran")
+	kubeconfig := fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- name: remote
+  cluster:
+    server: https://remote.example.com
+users:
+- name: plugin
+  user:
+    exec:
+      apiVersion: client.authentication.k8s.io/v1
+      command: touch
+      args: [%q]
+contexts:
+- name: remote
+  context:
+    cluster: remote
+    user: plugin
+current-context: remote
+`, marker)
+	if _, err := NewRemoteClient([]byte(kubeconfig), workloadScheme(t)); err == nil {
+		t.Fatal("a kubeconfig with an exec credential plugin must be refused")
+	}
+	if _, err := os.Stat(marker); !os.IsNotExist(err) {
+		t.Errorf("exec credential plugin ran: %v", err)
+	}
+}
@@ -0,0 +1,43 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"fmt"
+	"time"
+)
+
+// ResyncIntervalAnnotation overrides, for a single gateway, how often it is
+// re-reconciled without a triggering event, so drift from external mutation
+// of its workload is corrected sooner (or never, with "0s").
+const ResyncIntervalAnnotation = "ai-gateway-litellm.agentic-layer.ai/resync-interval"
+
+// ParseResyncInterval returns the interval requested via
+// ResyncIntervalAnnotation, or def when the annotation is absent. Values use
+// Go duration syntax ("30m", "2h"); "0s" disables the periodic re-reconcile.
+func ParseResyncInterval(annotations map[string]string, def time.Duration) (time.Duration, error) {
+	raw, ok := annotations[ResyncIntervalAnnotation]
+	if !ok {
+		return def, nil
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil || d < 0 {
+		return def, fmt.Errorf("invalid %s annotation %q: must be a non-negative duration such as 30m",
+			ResyncIntervalAnnotation, raw)
+	}
+	return d, nil
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseResyncInterval(t *testing.T) {
+	const def = time.Hour
+	cases := []struct {
+		name    string
+		ann     map[string]string
+		want    time.Duration
+		wantErr bool
+	}{
+		{name: "absent uses default", ann: nil, want: def},
+		{name: "override", ann: map[string]string{ResyncIntervalAnnotation: "15m"}, want: 15 * time.Minute},
+		{name: "zero disables", ann: map[string]string{ResyncIntervalAnnotation: "0s"}, want: 0},
+		{name: "garbage falls back", ann: map[string]string{ResyncIntervalAnnotation: "often"}, want: def, wantErr: true},
+		{name: "negative falls back", ann: map[string]string{ResyncIntervalAnnotation: "-5m"}, want: def, wantErr: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseResyncInterval(tc.ann, def)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
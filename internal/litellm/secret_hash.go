@@ -20,6 +20,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"fmt"
+	"hash"
 	"sort"
 
 	corev1 "k8s.io/api/core/v1"
@@ -28,19 +29,40 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-// computeSecretHash returns a deterministic short hash of the api-key secret in
-// the given namespace. If the secret does not exist, returns the hash of an
-// empty payload — this is intentional so the deployment can still be created
-// before the secret is set up. Errors are returned only for non-NotFound errors.
-func computeSecretHash(ctx context.Context, c client.Reader, namespace string) (string, error) {
+// computeSecretHash returns a deterministic short hash of the api-key secret
+// and of every other secret in names, all in the given namespace. A missing
+// secret contributes an empty payload — this is intentional so the deployment
+// can still be created before the secret is set up. Errors are returned only
+// for non-NotFound errors.
+//
+// The api-key secret is hashed exactly as before other secrets were tracked,
+// so a gateway that references no further secrets keeps its secret-hash (and
+// is not restarted) across operator upgrades.
+func computeSecretHash(ctx context.Context, c client.Reader, namespace string, names []string) (string, error) {
+	h := sha256.New()
+	if err := hashSecret(ctx, c, h, namespace, ApiKeySecretName); err != nil {
+		return "", err
+	}
+	for _, name := range names {
+		if name == ApiKeySecretName {
+			continue
+		}
+		h.Write([]byte(name))
+		if err := hashSecret(ctx, c, h, namespace, name); err != nil {
+			return "", err
+		}
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))[:16], nil
+}
+
+// hashSecret feeds the sorted keys and values of one secret into h.
+func hashSecret(ctx context.Context, c client.Reader, h hash.Hash, namespace, name string) error {
 	secret := &corev1.Secret{}
-	err := c.Get(ctx, types.NamespacedName{Name: ApiKeySecretName, Namespace: namespace}, secret)
-	if err != nil {
+	if err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, secret); err != nil {
 		if apierrors.IsNotFound(err) {
-			empty := sha256.Sum256(nil)
-			return fmt.Sprintf("%x", empty)[:16], nil
+			return nil
 		}
-		return "", fmt.Errorf("failed to get secret %s: %w", ApiKeySecretName, err)
+		return fmt.Errorf("failed to get secret %s: %w", name, err)
 	}
 
 	keys := make([]string, 0, len(secret.Data))
@@ -49,10 +71,32 @@ func computeSecretHash(ctx context.Context, c client.Reader, namespace string) (
 	}
 	sort.Strings(keys)
 
-	h := sha256.New()
 	for _, k := range keys {
 		h.Write([]byte(k))
 		h.Write(secret.Data[k])
 	}
-	return fmt.Sprintf("%x", h.Sum(nil))[:16], nil
+	return nil
+}
+
+// ReferencedSecretNames returns the sorted, de-duplicated names of the
+// secrets a gateway container reads: secretKeyRef entries in env, secretRef
+// entries in envFrom, and always the api-key secret.
+func ReferencedSecretNames(env []corev1.EnvVar, envFrom []corev1.EnvFromSource) []string {
+	names := map[string]struct{}{ApiKeySecretName: {}}
+	for _, e := range env {
+		if e.ValueFrom != nil && e.ValueFrom.SecretKeyRef != nil && e.ValueFrom.SecretKeyRef.Name != "" {
+			names[e.ValueFrom.SecretKeyRef.Name] = struct{}{}
+		}
+	}
+	for _, e := range envFrom {
+		if e.SecretRef != nil && e.SecretRef.Name != "" {
+			names[e.SecretRef.Name] = struct{}{}
+		}
+	}
+	out := make([]string, 0, len(names))
+	for n := range names {
+		out = append(out, n)
+	}
+	sort.Strings(out)
+	return out
 }
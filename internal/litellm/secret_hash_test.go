@@ -18,6 +18,7 @@ package litellm
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	corev1 "k8s.io/api/core/v1"
@@ -31,7 +32,7 @@ func TestSecretHash_MissingSecretReturnsEmptyHash(t *testing.T) {
 	_ = corev1.AddToScheme(s)
 	c := fake.NewClientBuilder().WithScheme(s).Build()
 
-	got, err := computeSecretHash(context.Background(), c, "default")
+	got, err := computeSecretHash(context.Background(), c, "default", nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -49,11 +50,11 @@ func TestSecretHash_DeterministicForSameData(t *testing.T) {
 	}
 	c := fake.NewClientBuilder().WithScheme(s).WithObjects(secret).Build()
 
-	first, err := computeSecretHash(context.Background(), c, "default")
+	first, err := computeSecretHash(context.Background(), c, "default", nil)
 	if err != nil {
 		t.Fatalf("first call: %v", err)
 	}
-	second, err := computeSecretHash(context.Background(), c, "default")
+	second, err := computeSecretHash(context.Background(), c, "default", nil)
 	if err != nil {
 		t.Fatalf("second call: %v", err)
 	}
@@ -71,7 +72,7 @@ func TestSecretHash_ChangesWhenDataChanges(t *testing.T) {
 			Data:       data,
 		}
 		c := fake.NewClientBuilder().WithScheme(s).WithObjects(secret).Build()
-		got, err := computeSecretHash(context.Background(), c, "default")
+		got, err := computeSecretHash(context.Background(), c, "default", nil)
 		if err != nil {
 			t.Fatalf("computeSecretHash: %v", err)
 		}
@@ -83,3 +84,57 @@ func TestSecretHash_ChangesWhenDataChanges(t *testing.T) {
 		t.Errorf("hash should change when data changes; got identical %q", a)
 	}
 }
+
+func TestSecretHash_TracksReferencedSecrets(t *testing.T) {
+	s := runtime.NewScheme()
+	_ = corev1.AddToScheme(s)
+	apiKeys := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: ApiKeySecretName, Namespace: "default"},
+		Data:       map[string][]byte{"OPENAI_API_KEY": []byte("sk-123")},
+	}
+	build := func(masterKey string) string {
+		master := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "litellm-master", Namespace: "default"},
+			Data:       map[string][]byte{"key": []byte(masterKey)},
+		}
+		c := fake.NewClientBuilder().WithScheme(s).WithObjects(apiKeys.DeepCopy(), master).Build()
+		got, err := computeSecretHash(context.Background(), c, "default", []string{ApiKeySecretName, "litellm-master"})
+		if err != nil {
+			t.Fatalf("computeSecretHash: %v", err)
+		}
+		return got
+	}
+	if build("sk-a") == build("sk-b") {
+		t.Error("hash should change when a referenced secret changes")
+	}
+
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(apiKeys.DeepCopy()).Build()
+	onlyAPIKeys, _ := computeSecretHash(context.Background(), c, "default", []string{ApiKeySecretName})
+	legacy, _ := computeSecretHash(context.Background(), c, "default", nil)
+	if onlyAPIKeys != legacy {
+		t.Errorf("referencing only the api-key secret must not change the hash: %q vs %q", onlyAPIKeys, legacy)
+	}
+}
+
+func TestReferencedSecretNames(t *testing.T) {
+	env := []corev1.EnvVar{
+		{Name: "PLAIN", Value: "x"},
+		{Name: "LITELLM_MASTER_KEY", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "litellm-master"}, Key: "key",
+		}}},
+		{Name: "OPENAI_API_KEY", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: ApiKeySecretName}, Key: "OPENAI_API_KEY",
+		}}},
+		{Name: "FROM_CM", ValueFrom: &corev1.EnvVarSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "not-a-secret"}, Key: "k",
+		}}},
+	}
+	envFrom := []corev1.EnvFromSource{
+		{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "db-credentials"}}},
+	}
+	got := ReferencedSecretNames(env, envFrom)
+	want := []string{ApiKeySecretName, "db-credentials", "litellm-master"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ConfigHistoryAnnotation sets how many known-good configs of a gateway are
+// kept as snapshot ConfigMaps <name>-config-<hash>. Unset or "0" keeps none.
+const ConfigHistoryAnnotation = "ai-gateway-litellm.agentic-layer.ai/config-history"
+
+// RollbackToAnnotation pins a gateway to the config of the snapshot with
+// the given config hash instead of the config generated from its spec.
+const RollbackToAnnotation = "ai-gateway-litellm.agentic-layer.ai/rollback-to"
+
+// ConfigSnapshotLabel marks a snapshot ConfigMap with the name of its
+// gateway, so the snapshots of a gateway can be listed.
+const ConfigSnapshotLabel = "ai-gateway-litellm.agentic-layer.ai/config-snapshot-of"
+
+// snapshotAtAnnotation records when a snapshot last became the serving
+// config; the oldest snapshots are pruned first.
+const snapshotAtAnnotation = "ai-gateway-litellm.agentic-layer.ai/snapshot-at"
+
+// ConfigHistoryPhase tags config-history and rollback failures. Invalid
+// annotations and missing snapshots are permanent errors.
+const ConfigHistoryPhase = "ConfigHistory"
+
+// configHashPattern matches the hashes ConfigHash returns.
+var configHashPattern = regexp.MustCompile(`^[0-9a-f]{16}$`)
+
+// ConfigHistory is the config-history settings of a gateway.
+type ConfigHistory struct {
+	// Keep is how many snapshots are kept; 0 records none.
+	Keep int
+	// RollbackTo is the config hash the gateway is pinned to, if any.
+	RollbackTo string
+}
+
+// ParseConfigHistory returns the settings requested via
+// ConfigHistoryAnnotation and RollbackToAnnotation. Invalid values yield a
+// *PhaseError.
+func ParseConfigHistory(annotations map[string]string) (ConfigHistory, error) {
+	var h ConfigHistory
+	if raw, ok := annotations[ConfigHistoryAnnotation]; ok {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 0 {
+			return h, &PhaseError{Phase: ConfigHistoryPhase, Err: fmt.Errorf(
+				"invalid %s annotation %q: must be a non-negative integer", ConfigHistoryAnnotation, raw)}
+		}
+		h.Keep = n
+	}
+	if raw := annotations[RollbackToAnnotation]; raw != "" {
+		if !configHashPattern.MatchString(raw) {
+			return h, &PhaseError{Phase: ConfigHistoryPhase, Err: fmt.Errorf(
+				"invalid %s annotation %q: must be a config hash of 16 hex digits", RollbackToAnnotation, raw)}
+		}
+		h.RollbackTo = raw
+	}
+	return h, nil
+}
+
+// ConfigSnapshotName is the name of the snapshot ConfigMap of the config
+// with hash of the workload name.
+func ConfigSnapshotName(name, hash string) string {
+	return fmt.Sprintf("%s-config-%s", name, hash)
+}
+
+// LoadConfigSnapshot returns the config of the snapshot with hash taken of
+// owner's config. Failures, including a missing snapshot, are *PhaseError
+// tagged ConfigHistoryPhase.
+func LoadConfigSnapshot(ctx context.Context, c client.Reader, owner client.Object, hash string) (string, error) {
+	var cm corev1.ConfigMap
+	name := ConfigSnapshotName(owner.GetName(), hash)
+	if err := c.Get(ctx, client.ObjectKey{Namespace: owner.GetNamespace(), Name: name}, &cm); err != nil {
+		return "", &PhaseError{Phase: ConfigHistoryPhase, Err: fmt.Errorf("loading config snapshot %s: %w", name, err)}
+	}
+	config, ok := cm.Data["config.yaml"]
+	if !metav1.IsControlledBy(&cm, owner) || !ok {
+		return "", &PhaseError{Phase: ConfigHistoryPhase, Err: fmt.Errorf("ConfigMap %s is not a config snapshot of %s", name, owner.GetName())}
+	}
+	return config, nil
+}
+
+// ReconcileConfigSnapshots records w.ConfigYAML as the newest snapshot of
+// the workload and prunes all but the keep newest. Callers invoke it once
+// the config is rolled out, so snapshots only hold configs that served
+// traffic. The snapshot of w.ConfigYAML is never pruned, so a gateway
+// rolled back to a snapshot keeps it even with keep 0.
+func ReconcileConfigSnapshots(ctx context.Context, c client.Client, scheme *runtime.Scheme, w GatewayWorkload, keep int) error {
+	var list corev1.ConfigMapList
+	if err := c.List(ctx, &list, client.InNamespace(w.Namespace), client.MatchingLabels{ConfigSnapshotLabel: w.Name}); err != nil {
+		return fmt.Errorf("listing config snapshots: %w", err)
+	}
+	snapshots := slices.DeleteFunc(list.Items, func(cm corev1.ConfigMap) bool {
+		return !metav1.IsControlledBy(&cm, w.Owner)
+	})
+	// Newest first.
+	slices.SortFunc(snapshots, func(a, b corev1.ConfigMap) int {
+		return -compareSnapshotAt(a, b)
+	})
+
+	current := ConfigSnapshotName(w.Name, hashYAML(w.ConfigYAML))
+	if keep > 0 && (len(snapshots) == 0 || snapshots[0].Name != current) {
+		ownerRef, err := controllerReference(w.Owner, scheme)
+		if err != nil {
+			return err
+		}
+		cm := corev1ac.ConfigMap(current, w.Namespace).
+			WithOwnerReferences(ownerRef).
+			WithLabels(map[string]string{ConfigSnapshotLabel: w.Name, ManagedByLabel: FieldManager}).
+			WithAnnotations(map[string]string{snapshotAtAnnotation: time.Now().UTC().Format(time.RFC3339)}).
+			WithData(map[string]string{"config.yaml": w.ConfigYAML})
+		existing := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: current, Namespace: w.Namespace}}
+		if err := apply(ctx, c, w, cm, existing, "ConfigMap"); err != nil {
+			return fmt.Errorf("recording config snapshot %s: %w", current, err)
+		}
+		snapshots = slices.DeleteFunc(snapshots, func(cm corev1.ConfigMap) bool { return cm.Name == current })
+		keep--
+	}
+
+	for i := range snapshots {
+		cm := &snapshots[i]
+		if i < keep || cm.Name == current || w.DryRun {
+			continue
+		}
+		if err := c.Delete(ctx, cm); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("pruning config snapshot %s: %w", cm.Name, err)
+		}
+	}
+	return nil
+}
+
+// compareSnapshotAt orders snapshots by the time they last became the
+// serving config, oldest first. Unparsable times sort as the oldest.
+func compareSnapshotAt(a, b corev1.ConfigMap) int {
+	ta, _ := time.Parse(time.RFC3339, a.Annotations[snapshotAtAnnotation])
+	tb, _ := time.Parse(time.RFC3339, b.Annotations[snapshotAtAnnotation])
+	return ta.Compare(tb)
+}
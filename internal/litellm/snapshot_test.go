@@ -0,0 +1,128 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestParseConfigHistory(t *testing.T) {
+	for name, tc := range map[string]struct {
+		annotations map[string]string
+		want        ConfigHistory
+		wantErr     bool
+	}{
+		"unset":        {},
+		"keep":         {annotations: map[string]string{ConfigHistoryAnnotation: "5"}, want: ConfigHistory{Keep: 5}},
+		"rollback":     {annotations: map[string]string{RollbackToAnnotation: "0123456789abcdef"}, want: ConfigHistory{RollbackTo: "0123456789abcdef"}},
+		"negative":     {annotations: map[string]string{ConfigHistoryAnnotation: "-1"}, wantErr: true},
+		"not a number": {annotations: map[string]string{ConfigHistoryAnnotation: "all"}, wantErr: true},
+		"bad hash":     {annotations: map[string]string{RollbackToAnnotation: "previous"}, wantErr: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseConfigHistory(tc.annotations)
+			if tc.wantErr {
+				if pe, ok := err.(*PhaseError); !ok || pe.Phase != ConfigHistoryPhase {
+					t.Fatalf("got error %v, want a %s PhaseError", err, ConfigHistoryPhase)
+				}
+				return
+			}
+			if err != nil || got != tc.want {
+				t.Errorf("got %+v, %v, want %+v", got, err, tc.want)
+			}
+		})
+	}
+}
+
+// snapshot returns a snapshot ConfigMap of owner recorded age ago.
+func snapshot(owner client.Object, config string, age time.Duration) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        ConfigSnapshotName(owner.GetName(), ConfigHash(config)),
+			Namespace:   owner.GetNamespace(),
+			Labels:      map[string]string{ConfigSnapshotLabel: owner.GetName()},
+			Annotations: map[string]string{snapshotAtAnnotation: time.Now().Add(-age).UTC().Format(time.RFC3339)},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "runtime.agentic-layer.ai/v1alpha1", Kind: "AiGateway",
+				Name: owner.GetName(), UID: owner.GetUID(), Controller: ptr.To(true),
+			}},
+		},
+		Data: map[string]string{"config.yaml": config},
+	}
+}
+
+func snapshotNames(t *testing.T, c client.Client) []string {
+	t.Helper()
+	var list corev1.ConfigMapList
+	if err := c.List(context.Background(), &list, client.MatchingLabels{ConfigSnapshotLabel: "gw"}); err != nil {
+		t.Fatalf("list snapshots: %v", err)
+	}
+	var names []string
+	for _, cm := range list.Items {
+		names = append(names, cm.Name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func TestReconcileConfigSnapshots(t *testing.T) {
+	s := workloadScheme(t)
+	owner := newOwner("gw", "default")
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(owner,
+		snapshot(owner, "old: 1\n", 3*time.Hour),
+		snapshot(owner, "old: 2\n", 2*time.Hour),
+		snapshot(owner, "old: 3\n", time.Hour),
+	).Build()
+	ctx := context.Background()
+	w := GatewayWorkload{Name: "gw", Namespace: "default", Owner: owner, ConfigYAML: "new: 1\n"}
+	name := func(config string) string { return ConfigSnapshotName("gw", ConfigHash(config)) }
+
+	if err := ReconcileConfigSnapshots(ctx, c, s, w, 2); err != nil {
+		t.Fatalf("ReconcileConfigSnapshots: %v", err)
+	}
+	want := []string{name("new: 1\n"), name("old: 3\n")}
+	slices.Sort(want)
+	if got := snapshotNames(t, c); !slices.Equal(got, want) {
+		t.Errorf("snapshots: got %v, want %v", got, want)
+	}
+
+	// Rolled back to the older snapshot: it is the one kept with keep 0.
+	config, err := LoadConfigSnapshot(ctx, c, owner, ConfigHash("old: 3\n"))
+	if err != nil || config != "old: 3\n" {
+		t.Fatalf("LoadConfigSnapshot: got %q, %v", config, err)
+	}
+	w.ConfigYAML = config
+	if err := ReconcileConfigSnapshots(ctx, c, s, w, 0); err != nil {
+		t.Fatalf("ReconcileConfigSnapshots: %v", err)
+	}
+	if got := snapshotNames(t, c); !slices.Equal(got, []string{name("old: 3\n")}) {
+		t.Errorf("snapshots with keep 0: got %v", got)
+	}
+
+	if _, err := LoadConfigSnapshot(ctx, c, owner, ConfigHash("old: 1\n")); err == nil {
+		t.Error("LoadConfigSnapshot of a pruned snapshot: want error")
+	}
+}
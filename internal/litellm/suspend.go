@@ -0,0 +1,27 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+// SuspendAnnotation, set to "true", scales the gateway's Deployment to zero
+// replicas. Its ConfigMap and Service stay, so removing the annotation
+// brings the gateway back with the config it had.
+const SuspendAnnotation = "ai-gateway-litellm.agentic-layer.ai/suspend"
+
+// Suspended reports whether SuspendAnnotation is set to "true".
+func Suspended(annotations map[string]string) bool {
+	return annotations[SuspendAnnotation] == "true"
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+)
+
+// Team is a LiteLLM team as accepted by /team/new and /team/update. Zero
+// values leave the corresponding limit unset.
+type Team struct {
+	ID             string   `json:"team_id"`
+	Alias          string   `json:"team_alias,omitempty"`
+	Models         []string `json:"models"`
+	MaxBudget      *float64 `json:"max_budget"`
+	BudgetDuration string   `json:"budget_duration,omitempty"`
+	RateLimits
+}
+
+// RateLimits are the request limits of a team or key. Nil limits are sent
+// as null, which lifts a limit set earlier.
+type RateLimits struct {
+	RPMLimit            *int64 `json:"rpm_limit"`
+	TPMLimit            *int64 `json:"tpm_limit"`
+	MaxParallelRequests *int64 `json:"max_parallel_requests"`
+}
+
+// TeamMember is one entry of a team's members_with_roles. A member is
+// identified by UserID or, for users not yet known to LiteLLM, UserEmail.
+type TeamMember struct {
+	UserID    string `json:"user_id,omitempty"`
+	UserEmail string `json:"user_email,omitempty"`
+	Role      string `json:"role"`
+}
+
+// TeamInfo is the state of a team as reported by /team/info.
+type TeamInfo struct {
+	Members        []TeamMember `json:"members_with_roles"`
+	MaxBudget      *float64     `json:"max_budget"`
+	BudgetDuration string       `json:"budget_duration"`
+	Spend          float64      `json:"spend"`
+	RateLimits
+}
+
+// TeamInfo calls GET /team/info. The error satisfies IsNotFound when the
+// team does not exist.
+func (a *AdminClient) TeamInfo(ctx context.Context, teamID string) (*TeamInfo, error) {
+	var resp struct {
+		TeamInfo TeamInfo `json:"team_info"`
+	}
+	if err := a.do(ctx, http.MethodGet, "/team/info?team_id="+url.QueryEscape(teamID), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.TeamInfo, nil
+}
+
+// NewTeam calls POST /team/new, creating team with members.
+func (a *AdminClient) NewTeam(ctx context.Context, team Team, members []TeamMember) error {
+	return a.postJSON(ctx, "/team/new", struct {
+		Team
+		Members []TeamMember `json:"members_with_roles,omitempty"`
+	}{team, members})
+}
+
+// UpdateTeam calls POST /team/update, replacing the limits of team.
+func (a *AdminClient) UpdateTeam(ctx context.Context, team Team) error {
+	return a.postJSON(ctx, "/team/update", team)
+}
+
+// AddTeamMember calls POST /team/member_add.
+func (a *AdminClient) AddTeamMember(ctx context.Context, teamID string, member TeamMember) error {
+	return a.postJSON(ctx, "/team/member_add", struct {
+		TeamID string     `json:"team_id"`
+		Member TeamMember `json:"member"`
+	}{teamID, member})
+}
+
+// RemoveTeamMember calls POST /team/member_delete.
+func (a *AdminClient) RemoveTeamMember(ctx context.Context, teamID string, member TeamMember) error {
+	return a.postJSON(ctx, "/team/member_delete", struct {
+		TeamID    string `json:"team_id"`
+		UserID    string `json:"user_id,omitempty"`
+		UserEmail string `json:"user_email,omitempty"`
+	}{teamID, member.UserID, member.UserEmail})
+}
+
+// DeleteTeam calls POST /team/delete. A team that does not exist is not an
+// error.
+func (a *AdminClient) DeleteTeam(ctx context.Context, teamID string) error {
+	if err := a.postJSON(ctx, "/team/delete", map[string][]string{"team_ids": {teamID}}); !IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// postJSON POSTs body as JSON and discards the response.
+func (a *AdminClient) postJSON(ctx context.Context, path string, body any) error {
+	raw, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	return a.do(ctx, http.MethodPost, path, bytes.NewReader(raw), nil)
+}
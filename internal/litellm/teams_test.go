@@ -0,0 +1,54 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAdminClient_TeamInfo(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/team/info" {
+			t.Errorf("request: got %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("team_id") != "ns/research" {
+			http.Error(w, "team not found", http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"team_id": "ns/research", "team_info": {"spend": 12.5, "max_budget": 100, "members_with_roles": [
+			{"user_id": "u1", "user_email": "lead@example.com", "role": "admin"}]}}`))
+	}))
+	defer srv.Close()
+
+	a := &AdminClient{BaseURL: srv.URL}
+	info, err := a.TeamInfo(context.Background(), "ns/research")
+	if err != nil {
+		t.Fatalf("TeamInfo: %v", err)
+	}
+	if len(info.Members) != 1 || info.Members[0] != (TeamMember{UserID: "u1", UserEmail: "lead@example.com", Role: "admin"}) {
+		t.Errorf("TeamInfo members: got %+v", info.Members)
+	}
+	if info.Spend != 12.5 || info.MaxBudget == nil || *info.MaxBudget != 100 {
+		t.Errorf("TeamInfo budget: got spend %v of %v", info.Spend, info.MaxBudget)
+	}
+	if _, err := a.TeamInfo(context.Background(), "ns/other"); !IsNotFound(err) {
+		t.Errorf("unknown team: want a not-found error, got %v", err)
+	}
+}
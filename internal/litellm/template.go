@@ -0,0 +1,107 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"fmt"
+
+	litellmv1alpha1 "github.com/agentic-layer/ai-gateway-litellm/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
+)
+
+// GatewayTemplateAnnotation names the LiteLLMGatewayTemplate, in the
+// gateway's namespace, layered onto the gateway's pod template.
+const GatewayTemplateAnnotation = "ai-gateway-litellm.agentic-layer.ai/gateway-template"
+
+// GatewayTemplatePhase tags failures resolving the gateway template. A
+// missing template or one clashing with the generated pod is permanent.
+const GatewayTemplatePhase = "GatewayTemplate"
+
+// ValidateGatewayTemplate rejects a template that would replace parts of
+// the generated pod: the litellm and otel-collector containers and the
+// config and Prometheus volumes and their mount paths. Its overrides must
+// parse; see ValidateOverrides.
+func ValidateGatewayTemplate(t *litellmv1alpha1.LiteLLMGatewayTemplateSpec) error {
+	for _, containers := range [][]corev1.Container{t.Sidecars, t.InitContainers} {
+		for _, c := range containers {
+			if c.Name == ContainerName {
+				return fmt.Errorf("container name %q is reserved for the LiteLLM container", ContainerName)
+			}
+			if c.Name == OTelCollectorContainerName {
+				return fmt.Errorf("container name %q is reserved for the collector sidecar", OTelCollectorContainerName)
+			}
+		}
+	}
+	for _, v := range t.Volumes {
+		if v.Name == "config" || v.Name == PrometheusMultiprocVolumeName {
+			return fmt.Errorf("volume name %q is reserved", v.Name)
+		}
+	}
+	for _, m := range t.VolumeMounts {
+		if m.MountPath == "/app/config" || m.MountPath == PrometheusMultiprocDir {
+			return fmt.Errorf("mount path %s is reserved", m.MountPath)
+		}
+	}
+	return ValidateOverrides(t.Overrides)
+}
+
+// applyContainerTemplate layers the container fields of t onto the litellm
+// container.
+func applyContainerTemplate(t *litellmv1alpha1.LiteLLMGatewayTemplateSpec, container *corev1ac.ContainerApplyConfiguration) {
+	for i := range t.VolumeMounts {
+		container.WithVolumeMounts(asApplyConfiguration[corev1ac.VolumeMountApplyConfiguration](t.VolumeMounts[i]))
+	}
+	if t.ContainerSecurityContext != nil {
+		container.WithSecurityContext(asApplyConfiguration[corev1ac.SecurityContextApplyConfiguration](t.ContainerSecurityContext))
+	}
+}
+
+// applyPodTemplate layers the pod fields of t onto spec. Sidecars are
+// appended, so the litellm container stays the pod's default container.
+func applyPodTemplate(t *litellmv1alpha1.LiteLLMGatewayTemplateSpec, spec *corev1ac.PodSpecApplyConfiguration) {
+	for i := range t.Sidecars {
+		spec.WithContainers(asApplyConfiguration[corev1ac.ContainerApplyConfiguration](t.Sidecars[i]))
+	}
+	for i := range t.InitContainers {
+		spec.WithInitContainers(asApplyConfiguration[corev1ac.ContainerApplyConfiguration](t.InitContainers[i]))
+	}
+	for i := range t.Volumes {
+		spec.WithVolumes(asApplyConfiguration[corev1ac.VolumeApplyConfiguration](t.Volumes[i]))
+	}
+	if t.SecurityContext != nil {
+		spec.WithSecurityContext(asApplyConfiguration[corev1ac.PodSecurityContextApplyConfiguration](t.SecurityContext))
+	}
+	if t.ServiceAccountName != "" {
+		spec.WithServiceAccountName(t.ServiceAccountName)
+	}
+	for i := range t.ImagePullSecrets {
+		spec.WithImagePullSecrets(corev1ac.LocalObjectReference().WithName(t.ImagePullSecrets[i].Name))
+	}
+	if len(t.NodeSelector) > 0 {
+		spec.WithNodeSelector(t.NodeSelector)
+	}
+	for i := range t.Tolerations {
+		spec.WithTolerations(asApplyConfiguration[corev1ac.TolerationApplyConfiguration](t.Tolerations[i]))
+	}
+	if t.Affinity != nil {
+		spec.WithAffinity(asApplyConfiguration[corev1ac.AffinityApplyConfiguration](t.Affinity))
+	}
+	if t.PriorityClassName != "" {
+		spec.WithPriorityClassName(t.PriorityClassName)
+	}
+}
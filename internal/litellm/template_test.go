@@ -0,0 +1,93 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"testing"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	litellmv1alpha1 "github.com/agentic-layer/ai-gateway-litellm/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1ac "k8s.io/client-go/applyconfigurations/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestBuildDeployment_LayersGatewayTemplate(t *testing.T) {
+	w := GatewayWorkload{
+		Name: "gw", Namespace: "default", ContainerPort: 4000,
+		PodMetadata: &gatewayv1alpha1.EmbeddedMetadata{Labels: map[string]string{"team": "gateway"}},
+		GatewayTemplate: &litellmv1alpha1.LiteLLMGatewayTemplateSpec{
+			Metadata: &litellmv1alpha1.GatewayPodMetadata{
+				Labels:      map[string]string{"team": "platform", "compliance": "pci"},
+				Annotations: map[string]string{"vault.hashicorp.com/agent-inject": "true"},
+			},
+			Sidecars:                 []corev1.Container{{Name: "envoy", Image: "envoyproxy/envoy:v1.31"}},
+			Volumes:                  []corev1.Volume{{Name: "ca", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "ca-bundle"}}}}},
+			VolumeMounts:             []corev1.VolumeMount{{Name: "ca", MountPath: "/etc/ssl/custom"}},
+			SecurityContext:          &corev1.PodSecurityContext{RunAsNonRoot: ptr.To(true)},
+			ContainerSecurityContext: &corev1.SecurityContext{ReadOnlyRootFilesystem: ptr.To(true)},
+			NodeSelector:             map[string]string{"pool": "gateways"},
+		},
+	}
+	dep := BuildDeployment(w, metav1ac.OwnerReference().WithName("gw"), "c", "s")
+	tpl := dep.Spec.Template
+	spec := tpl.Spec
+
+	if len(spec.Containers) != 2 || *spec.Containers[0].Name != ContainerName || *spec.Containers[1].Name != "envoy" {
+		t.Fatalf("containers: got %d, want litellm followed by the sidecar", len(spec.Containers))
+	}
+	litellm := spec.Containers[0]
+	if len(litellm.VolumeMounts) != 3 || *litellm.VolumeMounts[2].MountPath != "/etc/ssl/custom" {
+		t.Errorf("volume mounts: got %+v", litellm.VolumeMounts)
+	}
+	if litellm.SecurityContext == nil || !*litellm.SecurityContext.ReadOnlyRootFilesystem {
+		t.Errorf("container security context not layered: %+v", litellm.SecurityContext)
+	}
+	if len(spec.Volumes) != 3 || *spec.Volumes[2].Name != "ca" {
+		t.Errorf("volumes: got %d", len(spec.Volumes))
+	}
+	if spec.SecurityContext == nil || !*spec.SecurityContext.RunAsNonRoot || spec.NodeSelector["pool"] != "gateways" {
+		t.Errorf("pod settings not layered: %+v, %v", spec.SecurityContext, spec.NodeSelector)
+	}
+	if tpl.Labels["team"] != "gateway" || tpl.Labels["compliance"] != "pci" || tpl.Labels["app"] != "gw" {
+		t.Errorf("labels: got %v, want the gateway's own to win", tpl.Labels)
+	}
+	if tpl.Annotations["vault.hashicorp.com/agent-inject"] != "true" || tpl.Annotations[configHashAnnotation] != "c" {
+		t.Errorf("annotations: got %v", tpl.Annotations)
+	}
+}
+
+func TestValidateGatewayTemplate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		spec    litellmv1alpha1.LiteLLMGatewayTemplateSpec
+		wantErr bool
+	}{
+		"sidecar":              {spec: litellmv1alpha1.LiteLLMGatewayTemplateSpec{Sidecars: []corev1.Container{{Name: "envoy"}}}},
+		"litellm sidecar":      {spec: litellmv1alpha1.LiteLLMGatewayTemplateSpec{Sidecars: []corev1.Container{{Name: ContainerName}}}, wantErr: true},
+		"litellm init":         {spec: litellmv1alpha1.LiteLLMGatewayTemplateSpec{InitContainers: []corev1.Container{{Name: ContainerName}}}, wantErr: true},
+		"collector sidecar":    {spec: litellmv1alpha1.LiteLLMGatewayTemplateSpec{Sidecars: []corev1.Container{{Name: OTelCollectorContainerName}}}, wantErr: true},
+		"config volume":        {spec: litellmv1alpha1.LiteLLMGatewayTemplateSpec{Volumes: []corev1.Volume{{Name: "config"}}}, wantErr: true},
+		"config mount path":    {spec: litellmv1alpha1.LiteLLMGatewayTemplateSpec{VolumeMounts: []corev1.VolumeMount{{Name: "x", MountPath: "/app/config"}}}, wantErr: true},
+		"prometheus mount dir": {spec: litellmv1alpha1.LiteLLMGatewayTemplateSpec{VolumeMounts: []corev1.VolumeMount{{Name: "x", MountPath: PrometheusMultiprocDir}}}, wantErr: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if err := ValidateGatewayTemplate(&tc.spec); (err != nil) != tc.wantErr {
+				t.Errorf("got %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
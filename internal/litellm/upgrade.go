@@ -0,0 +1,65 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"encoding/json"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// UpgradeHistoryAnnotation is set by the operator on a gateway to the
+// LiteLLM images the image policy of its class resolved to, as a JSON list
+// of Upgrade, oldest first. Users do not set it.
+const UpgradeHistoryAnnotation = "ai-gateway-litellm.agentic-layer.ai/upgrade-history"
+
+// maxUpgradeHistory is how many upgrades a gateway keeps.
+const maxUpgradeHistory = 10
+
+// Upgrade is one change of a gateway's LiteLLM image.
+type Upgrade struct {
+	// From is empty for the first image recorded.
+	From string      `json:"from,omitempty"`
+	To   string      `json:"to"`
+	Time metav1.Time `json:"time"`
+}
+
+// UpgradeHistory returns the upgrades of a gateway with annotations. An
+// unreadable history is returned as empty, so it starts over.
+func UpgradeHistory(annotations map[string]string) []Upgrade {
+	var history []Upgrade
+	if err := json.Unmarshal([]byte(annotations[UpgradeHistoryAnnotation]), &history); err != nil {
+		return nil
+	}
+	return history
+}
+
+// RecordUpgrade returns history with a move to image at now appended, and
+// reports whether image is new. The oldest upgrades are dropped beyond
+// maxUpgradeHistory.
+func RecordUpgrade(history []Upgrade, image string, now time.Time) ([]Upgrade, bool) {
+	var from string
+	if len(history) > 0 {
+		from = history[len(history)-1].To
+	}
+	if from == image {
+		return history, false
+	}
+	history = append(history, Upgrade{From: from, To: image, Time: metav1.NewTime(now.UTC().Truncate(time.Second))})
+	return history[max(len(history)-maxUpgradeHistory, 0):], true
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestRecordUpgrade(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	history, changed := RecordUpgrade(nil, "litellm:v1.83.14@sha256:a", now)
+	if !changed || len(history) != 1 || history[0].From != "" || history[0].To != "litellm:v1.83.14@sha256:a" {
+		t.Fatalf("first image: got %+v, %v", history, changed)
+	}
+	if _, changed := RecordUpgrade(history, "litellm:v1.83.14@sha256:a", now); changed {
+		t.Error("the same image must not be recorded again")
+	}
+	history, _ = RecordUpgrade(history, "litellm:v1.83.15@sha256:b", now.Add(time.Hour))
+	if last := history[len(history)-1]; last.From != "litellm:v1.83.14@sha256:a" || last.To != "litellm:v1.83.15@sha256:b" {
+		t.Errorf("upgrade = %+v", last)
+	}
+
+	for i := range 2 * maxUpgradeHistory {
+		history, _ = RecordUpgrade(history, fmt.Sprintf("litellm:v1.84.%d", i), now)
+	}
+	if len(history) != maxUpgradeHistory || history[len(history)-1].To != fmt.Sprintf("litellm:v1.84.%d", 2*maxUpgradeHistory-1) {
+		t.Errorf("want the last %d upgrades, got %+v", maxUpgradeHistory, history)
+	}
+
+	raw, err := json.Marshal(history)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := UpgradeHistory(map[string]string{UpgradeHistoryAnnotation: string(raw)}); len(got) != maxUpgradeHistory || !got[0].Time.Equal(&history[0].Time) {
+		t.Errorf("round trip: got %+v", got)
+	}
+	if got := UpgradeHistory(map[string]string{UpgradeHistoryAnnotation: "not json"}); got != nil {
+		t.Errorf("unreadable history: got %+v", got)
+	}
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// UpstreamAnnotation names, as "<name>" or "<namespace>/<name>", another
+// AiGateway the annotated gateway sends all model traffic to instead of
+// calling providers itself, e.g. a central egress gateway.
+const UpstreamAnnotation = "ai-gateway-litellm.agentic-layer.ai/upstream"
+
+// UpstreamKeySecretAnnotation names the Secret in the gateway's namespace
+// holding the API key for the upstream gateway under UpstreamKeySecretKey.
+// Defaults to "<name>-upstream".
+const UpstreamKeySecretAnnotation = "ai-gateway-litellm.agentic-layer.ai/upstream-key-secret"
+
+// UpstreamKeySecretKey is the data key of the upstream key Secret. It matches
+// the Secret a LiteLLMVirtualKey writes, so a key issued on the upstream
+// gateway can be used as is.
+const UpstreamKeySecretKey = "LITELLM_API_KEY"
+
+// UpstreamAPIKeyEnvVar carries the upstream key into the gateway container.
+const UpstreamAPIKeyEnvVar = "LITELLM_UPSTREAM_API_KEY"
+
+// UpstreamPhase tags failures resolving UpstreamAnnotation.
+const UpstreamPhase = "Upstream"
+
+// maxUpstreamDepth bounds the walk along upstream references when looking
+// for cycles.
+const maxUpstreamDepth = 8
+
+// UpstreamRef returns the AiGateway named by UpstreamAnnotation on gw,
+// relative to gw's namespace, and whether the annotation is set.
+func UpstreamRef(gw *gatewayv1alpha1.AiGateway) (types.NamespacedName, bool) {
+	raw := strings.TrimSpace(gw.Annotations[UpstreamAnnotation])
+	if raw == "" {
+		return types.NamespacedName{}, false
+	}
+	if ns, name, ok := strings.Cut(raw, "/"); ok {
+		return types.NamespacedName{Namespace: ns, Name: name}, true
+	}
+	return types.NamespacedName{Namespace: gw.Namespace, Name: raw}, true
+}
+
+// UpstreamKeySecretName returns the Secret holding gw's upstream key.
+func UpstreamKeySecretName(gw *gatewayv1alpha1.AiGateway) string {
+	if name := gw.Annotations[UpstreamKeySecretAnnotation]; name != "" {
+		return name
+	}
+	return gw.Name + "-upstream"
+}
+
+// ResolveUpstream returns the upstream AiGateway of gw, or nil when gw has
+// none. The upstream must be served by controllerName, must serve every
+// model of gw, and must not lead back to gw through its own upstreams.
+// Errors are *PhaseError tagged UpstreamPhase.
+func ResolveUpstream(ctx context.Context, c client.Reader, gw *gatewayv1alpha1.AiGateway, controllerName string) (*gatewayv1alpha1.AiGateway, error) {
+	ref, ok := UpstreamRef(gw)
+	if !ok {
+		return nil, nil
+	}
+	phaseErr := func(format string, args ...any) error {
+		return &PhaseError{Phase: UpstreamPhase, Err: fmt.Errorf(format, args...)}
+	}
+
+	self := types.NamespacedName{Namespace: gw.Namespace, Name: gw.Name}
+	if ref == self {
+		return nil, phaseErr("AiGateway cannot be its own upstream")
+	}
+
+	var upstream gatewayv1alpha1.AiGateway
+	if err := c.Get(ctx, ref, &upstream); err != nil {
+		return nil, phaseErr("upstream AiGateway %s: %w", ref, err)
+	}
+	owned, err := IsAiGatewayOwnedByController(ctx, c, &upstream, controllerName)
+	if err != nil {
+		return nil, phaseErr("upstream AiGateway %s: %w", ref, err)
+	}
+	if !owned {
+		return nil, phaseErr("upstream AiGateway %s is not served by this operator", ref)
+	}
+	for _, model := range gw.Spec.AiModels {
+		if !slices.ContainsFunc(upstream.Spec.AiModels, func(m gatewayv1alpha1.AiModel) bool { return m.Name == model.Name }) {
+			return nil, phaseErr("upstream AiGateway %s does not serve model %s", ref, model.Name)
+		}
+	}
+
+	next := &upstream
+	for range maxUpstreamDepth {
+		nextRef, ok := UpstreamRef(next)
+		if !ok {
+			return &upstream, nil
+		}
+		if nextRef == self {
+			return nil, phaseErr("upstream AiGateway %s leads back to this gateway", ref)
+		}
+		next = &gatewayv1alpha1.AiGateway{}
+		if err := c.Get(ctx, nextRef, next); apierrors.IsNotFound(err) {
+			// A broken link further up is reported on that gateway.
+			return &upstream, nil
+		} else if err != nil {
+			return nil, phaseErr("upstream AiGateway %s: %w", nextRef, err)
+		}
+	}
+	return nil, phaseErr("upstream chain of AiGateway %s is longer than %d gateways", ref, maxUpstreamDepth)
+}
+
+// UpstreamModel returns the model_list entry that forwards model to the
+// LiteLLM proxy of upstream.
+func UpstreamModel(model gatewayv1alpha1.AiModel, upstream *gatewayv1alpha1.AiGateway) ModelConfig {
+	return ModelConfig{
+		ModelName: model.Name,
+		LiteLLMParams: LiteLLMParams{
+			Model:   "litellm_proxy/" + model.Name,
+			ApiBase: ServiceURL(upstream.Name, upstream.Namespace, upstream.Spec.Port),
+			ApiKey:  "os.environ/" + UpstreamAPIKeyEnvVar,
+		},
+	}
+}
+
+// UpstreamKeyEnvVar returns the env var mounting gw's upstream key.
+func UpstreamKeyEnvVar(gw *gatewayv1alpha1.AiGateway) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: UpstreamAPIKeyEnvVar,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: UpstreamKeySecretName(gw)},
+				Key:                  UpstreamKeySecretKey,
+			},
+		},
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newUpstreamGateway(ns, name, upstream string, models ...string) *gatewayv1alpha1.AiGateway {
+	gw := &gatewayv1alpha1.AiGateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: name},
+		Spec:       gatewayv1alpha1.AiGatewaySpec{Port: 4000},
+	}
+	if upstream != "" {
+		gw.Annotations = map[string]string{UpstreamAnnotation: upstream}
+	}
+	for _, m := range models {
+		gw.Spec.AiModels = append(gw.Spec.AiModels, gatewayv1alpha1.AiModel{Name: m, Provider: "openai"})
+	}
+	return gw
+}
+
+func TestUpstreamRef(t *testing.T) {
+	gw := newUpstreamGateway("team-a", "gw", "")
+	if _, ok := UpstreamRef(gw); ok {
+		t.Errorf("expected no upstream without annotation")
+	}
+
+	gw.Annotations = map[string]string{UpstreamAnnotation: "central"}
+	if ref, _ := UpstreamRef(gw); ref.String() != "team-a/central" {
+		t.Errorf("ref = %s, want team-a/central", ref)
+	}
+
+	gw.Annotations[UpstreamAnnotation] = "egress/central"
+	if ref, _ := UpstreamRef(gw); ref.String() != "egress/central" {
+		t.Errorf("ref = %s, want egress/central", ref)
+	}
+}
+
+func TestResolveUpstream(t *testing.T) {
+	s := classScheme(t)
+	central := newUpstreamGateway("egress", "central", "", "gpt-4o", "gpt-4o-mini")
+	cases := []struct {
+		name    string
+		gw      *gatewayv1alpha1.AiGateway
+		objs    []client.Object
+		wantErr bool
+	}{
+		{"valid", newUpstreamGateway("team-a", "gw", "egress/central", "gpt-4o"), []client.Object{central}, false},
+		{"missing", newUpstreamGateway("team-a", "gw", "egress/absent", "gpt-4o"), []client.Object{central}, true},
+		{"model not served", newUpstreamGateway("team-a", "gw", "egress/central", "claude"), []client.Object{central}, true},
+		{"cycle", newUpstreamGateway("team-a", "gw", "egress/loop", "gpt-4o"),
+			[]client.Object{newUpstreamGateway("egress", "loop", "team-a/gw", "gpt-4o")}, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			objs := append([]client.Object{newClass(testController, true)}, tc.objs...)
+			c := fake.NewClientBuilder().WithScheme(s).WithObjects(objs...).Build()
+
+			upstream, err := ResolveUpstream(context.Background(), c, tc.gw, testController)
+			if tc.wantErr {
+				var pe *PhaseError
+				if !errors.As(err, &pe) || pe.Phase != UpstreamPhase {
+					t.Fatalf("err = %v, want %s PhaseError", err, UpstreamPhase)
+				}
+				return
+			}
+			if err != nil || upstream == nil {
+				t.Fatalf("ResolveUpstream = %v, %v", upstream, err)
+			}
+			model := UpstreamModel(tc.gw.Spec.AiModels[0], upstream)
+			if model.LiteLLMParams.Model != "litellm_proxy/gpt-4o" ||
+				model.LiteLLMParams.ApiBase != ServiceURL("central", "egress", 4000) {
+				t.Errorf("unexpected model entry: %+v", model.LiteLLMParams)
+			}
+		})
+	}
+}
+
+func TestResolveUpstream_NotOwned(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(classScheme(t)).
+		WithObjects(newUpstreamGateway("egress", "central", "", "gpt-4o")).Build()
+	gw := newUpstreamGateway("team-a", "gw", "egress/central", "gpt-4o")
+	if _, err := ResolveUpstream(context.Background(), c, gw, testController); err == nil {
+		t.Fatalf("expected error for gateway not served by this operator")
+	}
+}
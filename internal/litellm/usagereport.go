@@ -0,0 +1,272 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	batchv1ac "k8s.io/client-go/applyconfigurations/batch/v1"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
+	metav1ac "k8s.io/client-go/applyconfigurations/meta/v1"
+	rbacv1ac "k8s.io/client-go/applyconfigurations/rbac/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// UsageReportScheduleAnnotation enables a periodic usage report of a
+// database-backed gateway: total spend, spend per model and spend per
+// virtual key. The value is a CronJob schedule such as "0 8 * * 1".
+const UsageReportScheduleAnnotation = "ai-gateway-litellm.agentic-layer.ai/usage-report-schedule"
+
+// UsageReportWebhookSecretAnnotation names a Secret whose
+// UsageReportWebhookURLKey holds a URL the report is POSTed to as JSON.
+const UsageReportWebhookSecretAnnotation = "ai-gateway-litellm.agentic-layer.ai/usage-report-webhook-secret"
+
+// UsageReportConfigMapAnnotation names a ConfigMap the report is written to
+// under UsageReportConfigMapKey.
+const UsageReportConfigMapAnnotation = "ai-gateway-litellm.agentic-layer.ai/usage-report-configmap"
+
+// UsageReportWebhookURLKey is the key of the webhook Secret holding the URL.
+const UsageReportWebhookURLKey = "USAGE_REPORT_WEBHOOK_URL"
+
+// UsageReportConfigMapKey is the key of the report ConfigMap holding the
+// latest report.
+const UsageReportConfigMapKey = "report.json"
+
+// UsageReportPhase tags usage report annotation validation failures.
+const UsageReportPhase = "UsageReport"
+
+// UsageReport configures the usage report CronJob of a gateway.
+type UsageReport struct {
+	Schedule      string
+	WebhookSecret string
+	ConfigMap     string
+}
+
+// ParseUsageReport returns the usage report settings from annotations, or
+// nil when no schedule is set. Invalid values yield a *PhaseError.
+func ParseUsageReport(annotations map[string]string) (*UsageReport, error) {
+	r := &UsageReport{
+		Schedule:      strings.TrimSpace(annotations[UsageReportScheduleAnnotation]),
+		WebhookSecret: annotations[UsageReportWebhookSecretAnnotation],
+		ConfigMap:     annotations[UsageReportConfigMapAnnotation],
+	}
+	if r.Schedule == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(r.Schedule, "@") && len(strings.Fields(r.Schedule)) != 5 {
+		return nil, &PhaseError{Phase: UsageReportPhase, Err: fmt.Errorf(
+			"invalid %s annotation %q: must be a cron schedule such as \"0 8 * * 1\"", UsageReportScheduleAnnotation, r.Schedule)}
+	}
+	if r.WebhookSecret == "" && r.ConfigMap == "" {
+		return nil, &PhaseError{Phase: UsageReportPhase, Err: fmt.Errorf(
+			"%s needs %s or %s to say where the report goes", UsageReportScheduleAnnotation,
+			UsageReportWebhookSecretAnnotation, UsageReportConfigMapAnnotation)}
+	}
+	return r, nil
+}
+
+// UsageReportName is the name of the CronJob, and of the ServiceAccount,
+// Role and RoleBinding writing to the report ConfigMap, of the gateway name.
+func UsageReportName(name string) string {
+	return name + "-usage-report"
+}
+
+// ReconcileUsageReport applies the usage report CronJob of w when r is set,
+// and deletes it otherwise. With a ConfigMap sink it also applies the
+// ConfigMap and a ServiceAccount allowed to patch only that ConfigMap.
+// masterKey is the gateway's LITELLM_MASTER_KEY env var, empty for an open
+// proxy.
+//
+// On failure, the returned error is a *PhaseError tagged UsageReportPhase.
+func ReconcileUsageReport(ctx context.Context, c client.Client, scheme *runtime.Scheme, w GatewayWorkload, r *UsageReport, masterKey corev1.EnvVar) error {
+	name := UsageReportName(w.Name)
+	phaseErr := func(err error) error { return &PhaseError{Phase: UsageReportPhase, Err: err} }
+
+	if r == nil || r.ConfigMap == "" {
+		for _, obj := range []client.Object{&rbacv1.RoleBinding{}, &rbacv1.Role{}, &corev1.ServiceAccount{}} {
+			if err := deleteOwned(ctx, c, w, obj, name); err != nil {
+				return phaseErr(err)
+			}
+		}
+	}
+	if r == nil {
+		return nil
+	}
+
+	ownerRef, err := controllerReference(w.Owner, scheme)
+	if err != nil {
+		return phaseErr(err)
+	}
+	if r.ConfigMap != "" {
+		labels := BuildResourceLabels(w.Name, w.CommonMetadata)
+		// The ConfigMap is applied without data, so the report the job
+		// patches in is not pruned by the next apply.
+		for _, obj := range []struct {
+			desired  runtime.ApplyConfiguration
+			existing client.Object
+			kind     string
+		}{
+			{
+				corev1ac.ConfigMap(r.ConfigMap, w.Namespace).WithOwnerReferences(ownerRef).WithLabels(labels),
+				&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: r.ConfigMap, Namespace: w.Namespace}},
+				"ConfigMap",
+			},
+			{
+				corev1ac.ServiceAccount(name, w.Namespace).WithOwnerReferences(ownerRef).WithLabels(labels),
+				&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: w.Namespace}},
+				"ServiceAccount",
+			},
+			{
+				rbacv1ac.Role(name, w.Namespace).WithOwnerReferences(ownerRef).WithLabels(labels).
+					WithRules(rbacv1ac.PolicyRule().
+						WithAPIGroups("").WithResources("configmaps").
+						WithResourceNames(r.ConfigMap).WithVerbs("get", "patch")),
+				&rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: w.Namespace}},
+				"Role",
+			},
+			{
+				rbacv1ac.RoleBinding(name, w.Namespace).WithOwnerReferences(ownerRef).WithLabels(labels).
+					WithRoleRef(rbacv1ac.RoleRef().WithAPIGroup(rbacv1.GroupName).WithKind("Role").WithName(name)).
+					WithSubjects(rbacv1ac.Subject().WithKind(rbacv1.ServiceAccountKind).WithName(name).WithNamespace(w.Namespace)),
+				&rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: w.Namespace}},
+				"RoleBinding",
+			},
+		} {
+			if err := apply(ctx, c, w, obj.desired, obj.existing, obj.kind); err != nil {
+				return phaseErr(err)
+			}
+		}
+	}
+
+	cronJob := BuildUsageReportCronJob(w, ownerRef, r, masterKey)
+	existing := &batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: w.Namespace}}
+	if err := apply(ctx, c, w, cronJob, existing, "CronJob"); err != nil {
+		return phaseErr(err)
+	}
+	return nil
+}
+
+// DeleteUsageReport deletes the usage report CronJob and its
+// ServiceAccount, Role and RoleBinding. The report ConfigMap is kept.
+func DeleteUsageReport(ctx context.Context, c client.Client, w GatewayWorkload) error {
+	name := UsageReportName(w.Name)
+	for _, obj := range []client.Object{&batchv1.CronJob{}, &rbacv1.RoleBinding{}, &rbacv1.Role{}, &corev1.ServiceAccount{}} {
+		if err := deleteOwned(ctx, c, w, obj, name); err != nil {
+			return &PhaseError{Phase: UsageReportPhase, Err: err}
+		}
+	}
+	return nil
+}
+
+// BuildUsageReportCronJob returns the desired state of the usage report
+// CronJob. It runs usageReportScript on the LiteLLM image, whose Python
+// needs nothing beyond the standard library for it.
+func BuildUsageReportCronJob(w GatewayWorkload, ownerRef *metav1ac.OwnerReferenceApplyConfiguration, r *UsageReport, masterKey corev1.EnvVar) *batchv1ac.CronJobApplyConfiguration {
+	name := UsageReportName(w.Name)
+	container := corev1ac.Container().
+		WithName("report").
+		WithImage(w.image()).
+		WithCommand("python", "-c", usageReportScript).
+		WithEnv(
+			corev1ac.EnvVar().WithName("GATEWAY").WithValue(w.Name),
+			corev1ac.EnvVar().WithName("LITELLM_BASE_URL").WithValue(ServiceURL(w.Name, w.Namespace, w.ServicePort)),
+		)
+	if masterKey.Name != "" {
+		container.WithEnv(envVarApplyConfiguration(masterKey))
+	}
+	if r.WebhookSecret != "" {
+		container.WithEnv(corev1ac.EnvVar().WithName(UsageReportWebhookURLKey).WithValueFrom(
+			corev1ac.EnvVarSource().WithSecretKeyRef(corev1ac.SecretKeySelector().
+				WithName(r.WebhookSecret).WithKey(UsageReportWebhookURLKey))))
+	}
+	if r.ConfigMap != "" {
+		container.WithEnv(corev1ac.EnvVar().WithName("USAGE_REPORT_CONFIGMAP").WithValue(r.ConfigMap))
+	}
+	pod := corev1ac.PodSpec().
+		WithRestartPolicy(corev1.RestartPolicyNever).
+		WithContainers(container).
+		WithAutomountServiceAccountToken(r.ConfigMap != "")
+	if r.ConfigMap != "" {
+		pod.WithServiceAccountName(name)
+	}
+
+	return batchv1ac.CronJob(name, w.Namespace).
+		WithOwnerReferences(ownerRef).
+		WithLabels(BuildResourceLabels(w.Name, w.CommonMetadata)).
+		WithAnnotations(BuildResourceAnnotations(w.CommonMetadata)).
+		WithSpec(batchv1ac.CronJobSpec().
+			WithSchedule(r.Schedule).
+			WithConcurrencyPolicy(batchv1.ForbidConcurrent).
+			WithSuccessfulJobsHistoryLimit(1).
+			WithFailedJobsHistoryLimit(1).
+			WithJobTemplate(batchv1ac.JobTemplateSpec().
+				WithLabels(map[string]string{ManagedByLabel: FieldManager}).
+				WithSpec(batchv1ac.JobSpec().
+					WithBackoffLimit(2).
+					WithTemplate(corev1ac.PodTemplateSpec().
+						WithLabels(map[string]string{"app": name}).
+						WithSpec(pod)))))
+}
+
+// usageReportScript reads the spend endpoints of the gateway and sends the
+// summary to the configured sinks.
+const usageReportScript = `import datetime, json, os, ssl, urllib.request
+
+base = os.environ["LITELLM_BASE_URL"]
+auth = {"Authorization": "Bearer " + os.environ.get("LITELLM_MASTER_KEY", "")}
+
+def get(path):
+    with urllib.request.urlopen(urllib.request.Request(base + path, headers=auth), timeout=60) as resp:
+        return json.load(resp)
+
+def spend(entry):
+    return entry.get("total_spend", entry.get("spend", 0))
+
+report = {
+    "gateway": os.environ["GATEWAY"],
+    "generatedAt": datetime.datetime.now(datetime.timezone.utc).isoformat(timespec="seconds"),
+    "totalSpend": get("/global/spend").get("spend", 0),
+    "models": [{"model": m.get("model"), "spend": spend(m)} for m in get("/global/spend/models?limit=100")],
+    "keys": [{"key": k.get("key_alias") or k.get("key_name") or k.get("api_key"), "spend": spend(k)}
+             for k in get("/global/spend/keys?limit=100")],
+}
+body = json.dumps(report, indent=2)
+
+url = os.environ.get("USAGE_REPORT_WEBHOOK_URL")
+if url:
+    urllib.request.urlopen(urllib.request.Request(
+        url, data=body.encode(), headers={"Content-Type": "application/json"}), timeout=60)
+
+configmap = os.environ.get("USAGE_REPORT_CONFIGMAP")
+if configmap:
+    sa = "/var/run/secrets/kubernetes.io/serviceaccount/"
+    namespace = open(sa + "namespace").read().strip()
+    token = open(sa + "token").read().strip()
+    patch = json.dumps({"data": {"report.json": body}}).encode()
+    urllib.request.urlopen(urllib.request.Request(
+        "https://kubernetes.default.svc/api/v1/namespaces/%s/configmaps/%s" % (namespace, configmap),
+        data=patch, method="PATCH",
+        headers={"Authorization": "Bearer " + token, "Content-Type": "application/merge-patch+json"}),
+        context=ssl.create_default_context(cafile=sa + "ca.crt"), timeout=60)
+`
@@ -0,0 +1,151 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestParseUsageReport(t *testing.T) {
+	for _, tc := range []struct {
+		annotations map[string]string
+		want        *UsageReport
+		wantErr     bool
+	}{
+		{annotations: nil},
+		{annotations: map[string]string{UsageReportConfigMapAnnotation: "usage"}},
+		{
+			annotations: map[string]string{UsageReportScheduleAnnotation: "0 8 * * 1", UsageReportConfigMapAnnotation: "usage"},
+			want:        &UsageReport{Schedule: "0 8 * * 1", ConfigMap: "usage"},
+		},
+		{
+			annotations: map[string]string{UsageReportScheduleAnnotation: "@weekly", UsageReportWebhookSecretAnnotation: "hook"},
+			want:        &UsageReport{Schedule: "@weekly", WebhookSecret: "hook"},
+		},
+		{annotations: map[string]string{UsageReportScheduleAnnotation: "weekly", UsageReportConfigMapAnnotation: "usage"}, wantErr: true},
+		{annotations: map[string]string{UsageReportScheduleAnnotation: "@weekly"}, wantErr: true},
+	} {
+		got, err := ParseUsageReport(tc.annotations)
+		if tc.wantErr {
+			var pe *PhaseError
+			if !errors.As(err, &pe) || pe.Phase != UsageReportPhase {
+				t.Errorf("%v: want a %s PhaseError, got %v", tc.annotations, UsageReportPhase, err)
+			}
+			continue
+		}
+		if err != nil || (got == nil) != (tc.want == nil) || (got != nil && *got != *tc.want) {
+			t.Errorf("%v: got %+v, %v, want %+v", tc.annotations, got, err, tc.want)
+		}
+	}
+}
+
+func TestReconcileUsageReport(t *testing.T) {
+	s := workloadScheme(t)
+	owner := newOwner("gw", "default")
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(owner).Build()
+	ctx := context.Background()
+	w := GatewayWorkload{Name: "gw", Namespace: "default", ServicePort: 4000, Owner: owner}
+	masterKey := corev1.EnvVar{Name: MasterKeyEnvVar, Value: "sk-master"}
+	r := &UsageReport{Schedule: "@weekly", WebhookSecret: "hook", ConfigMap: "usage"}
+
+	if err := ReconcileUsageReport(ctx, c, s, w, r, masterKey); err != nil {
+		t.Fatalf("ReconcileUsageReport: %v", err)
+	}
+	key := types.NamespacedName{Name: "gw-usage-report", Namespace: "default"}
+	var cronJob batchv1.CronJob
+	if err := c.Get(ctx, key, &cronJob); err != nil {
+		t.Fatalf("get CronJob: %v", err)
+	}
+	if cronJob.Spec.Schedule != "@weekly" {
+		t.Errorf("schedule = %q", cronJob.Spec.Schedule)
+	}
+	pod := cronJob.Spec.JobTemplate.Spec.Template.Spec
+	if pod.ServiceAccountName != "gw-usage-report" || pod.AutomountServiceAccountToken == nil || !*pod.AutomountServiceAccountToken {
+		t.Errorf("pod must run as gw-usage-report with a token, got %q/%v", pod.ServiceAccountName, pod.AutomountServiceAccountToken)
+	}
+	env := map[string]corev1.EnvVar{}
+	for _, e := range pod.Containers[0].Env {
+		env[e.Name] = e
+	}
+	if env["LITELLM_BASE_URL"].Value != "http://gw.default.svc.cluster.local:4000" {
+		t.Errorf("LITELLM_BASE_URL = %q", env["LITELLM_BASE_URL"].Value)
+	}
+	if env[MasterKeyEnvVar].Value != "sk-master" {
+		t.Errorf("master key not passed to the job")
+	}
+	if ref := env[UsageReportWebhookURLKey].ValueFrom; ref == nil || ref.SecretKeyRef.Name != "hook" {
+		t.Errorf("webhook URL must come from Secret hook, got %+v", ref)
+	}
+	if env["USAGE_REPORT_CONFIGMAP"].Value != "usage" {
+		t.Errorf("USAGE_REPORT_CONFIGMAP = %q", env["USAGE_REPORT_CONFIGMAP"].Value)
+	}
+
+	var cm corev1.ConfigMap
+	if err := c.Get(ctx, types.NamespacedName{Name: "usage", Namespace: "default"}, &cm); err != nil {
+		t.Fatalf("get ConfigMap: %v", err)
+	}
+	var role rbacv1.Role
+	if err := c.Get(ctx, key, &role); err != nil {
+		t.Fatalf("get Role: %v", err)
+	}
+	if len(role.Rules) != 1 || len(role.Rules[0].ResourceNames) != 1 || role.Rules[0].ResourceNames[0] != "usage" {
+		t.Errorf("Role must be limited to ConfigMap usage, got %+v", role.Rules)
+	}
+
+	// Webhook only: the ConfigMap access goes away, the ConfigMap stays.
+	r = &UsageReport{Schedule: "@weekly", WebhookSecret: "hook"}
+	if err := ReconcileUsageReport(ctx, c, s, w, r, masterKey); err != nil {
+		t.Fatalf("ReconcileUsageReport: %v", err)
+	}
+	for _, obj := range []struct {
+		name string
+		err  error
+	}{
+		{"ServiceAccount", c.Get(ctx, key, &corev1.ServiceAccount{})},
+		{"Role", c.Get(ctx, key, &rbacv1.Role{})},
+		{"RoleBinding", c.Get(ctx, key, &rbacv1.RoleBinding{})},
+	} {
+		if !apierrors.IsNotFound(obj.err) {
+			t.Errorf("%s: want NotFound, got %v", obj.name, obj.err)
+		}
+	}
+	if err := c.Get(ctx, key, &cronJob); err != nil {
+		t.Fatalf("get CronJob: %v", err)
+	}
+	if pod := cronJob.Spec.JobTemplate.Spec.Template.Spec; pod.ServiceAccountName != "" {
+		t.Errorf("webhook-only job must not use a ServiceAccount, got %q", pod.ServiceAccountName)
+	}
+
+	if err := DeleteUsageReport(ctx, c, w); err != nil {
+		t.Fatalf("DeleteUsageReport: %v", err)
+	}
+	if err := c.Get(ctx, key, &batchv1.CronJob{}); !apierrors.IsNotFound(err) {
+		t.Errorf("CronJob: want NotFound, got %v", err)
+	}
+	if err := c.Get(ctx, types.NamespacedName{Name: "usage", Namespace: "default"}, &corev1.ConfigMap{}); err != nil {
+		t.Errorf("report ConfigMap must be kept: %v", err)
+	}
+}
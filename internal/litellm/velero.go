@@ -0,0 +1,102 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strconv"
+	"time"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+)
+
+// VeleroAnnotation, set to "true", prepares the gateway's objects for
+// Velero backups: they are labelled VeleroBackupLabel, the regenerable
+// config ConfigMap is excluded, and database-backed pods get a pre-backup
+// hook letting queued spend reach the database.
+const VeleroAnnotation = "ai-gateway-litellm.agentic-layer.ai/velero"
+
+// VeleroBackupLabel marks the objects of gateways with the VeleroAnnotation,
+// for Velero Backups selecting them.
+const VeleroBackupLabel = "ai-gateway-litellm.agentic-layer.ai/velero-backup"
+
+// VeleroExcludeLabel excludes an object from Velero backups.
+const VeleroExcludeLabel = "velero.io/exclude-from-backup"
+
+// VeleroPhase tags an invalid velero annotation.
+const VeleroPhase = "Velero"
+
+// Velero pre-backup hook annotations on the pod template.
+const (
+	veleroHookContainerAnnotation = "pre.hook.backup.velero.io/container"
+	veleroHookCommandAnnotation   = "pre.hook.backup.velero.io/command"
+	veleroHookOnErrorAnnotation   = "pre.hook.backup.velero.io/on-error"
+	veleroHookTimeoutAnnotation   = "pre.hook.backup.velero.io/timeout"
+)
+
+// spendBatchWriteInterval is LiteLLM's default proxy_batch_write_at: how
+// often the proxy writes queued spend to the database. LiteLLM has no flush
+// endpoint, so the pre-backup hook waits this long and a bit.
+const spendBatchWriteInterval = 10 * time.Second
+
+// Velero reports whether VeleroAnnotation is set to "true". Invalid values
+// yield a *PhaseError.
+func Velero(annotations map[string]string) (bool, error) {
+	v, ok := annotations[VeleroAnnotation]
+	if !ok {
+		return false, nil
+	}
+	enabled, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, &PhaseError{Phase: VeleroPhase, Err: fmt.Errorf(
+			"invalid %s annotation %q: must be \"true\" or \"false\"", VeleroAnnotation, v)}
+	}
+	return enabled, nil
+}
+
+// VeleroCommonMetadata returns common with VeleroBackupLabel added, so every
+// object built from it carries the label. common is not modified.
+func VeleroCommonMetadata(common *gatewayv1alpha1.EmbeddedMetadata) *gatewayv1alpha1.EmbeddedMetadata {
+	out := &gatewayv1alpha1.EmbeddedMetadata{}
+	if common != nil {
+		out = common.DeepCopy()
+	}
+	out.Labels = maps.Clone(out.Labels)
+	if out.Labels == nil {
+		out.Labels = map[string]string{}
+	}
+	out.Labels[VeleroBackupLabel] = "true"
+	return out
+}
+
+// veleroHookAnnotations returns the pre-backup hook of the litellm
+// container: it waits for the proxy's next spend write.
+func veleroHookAnnotations() map[string]string {
+	wait := spendBatchWriteInterval + 5*time.Second
+	command, err := json.Marshal([]string{"/bin/sh", "-c", fmt.Sprintf("sleep %d", int(wait.Seconds()))})
+	if err != nil {
+		panic(fmt.Sprintf("marshalling hook command: %v", err))
+	}
+	return map[string]string{
+		veleroHookContainerAnnotation: ContainerName,
+		veleroHookCommandAnnotation:   string(command),
+		veleroHookOnErrorAnnotation:   "Continue",
+		veleroHookTimeoutAnnotation:   (2 * wait).String(),
+	}
+}
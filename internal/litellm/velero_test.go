@@ -0,0 +1,71 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"errors"
+	"testing"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1ac "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
+func TestVelero(t *testing.T) {
+	for v, want := range map[string]bool{"true": true, "false": false} {
+		if got, err := Velero(map[string]string{VeleroAnnotation: v}); err != nil || got != want {
+			t.Errorf("%q: got %v, %v", v, got, err)
+		}
+	}
+	var pe *PhaseError
+	if _, err := Velero(map[string]string{VeleroAnnotation: "always"}); !errors.As(err, &pe) || pe.Phase != VeleroPhase {
+		t.Errorf("want a %s PhaseError, got %v", VeleroPhase, err)
+	}
+}
+
+func TestVeleroCommonMetadata(t *testing.T) {
+	if got := VeleroCommonMetadata(nil).Labels[VeleroBackupLabel]; got != "true" {
+		t.Errorf("label on nil metadata = %q", got)
+	}
+	common := &gatewayv1alpha1.EmbeddedMetadata{Labels: map[string]string{"team": "a"}}
+	got := VeleroCommonMetadata(common)
+	if got.Labels["team"] != "a" || got.Labels[VeleroBackupLabel] != "true" {
+		t.Errorf("labels = %v", got.Labels)
+	}
+	if _, ok := common.Labels[VeleroBackupLabel]; ok {
+		t.Error("the gateway's metadata must not be modified")
+	}
+}
+
+func TestBuildWorkload_Velero(t *testing.T) {
+	w := GatewayWorkload{Name: "gw", Namespace: "default", ContainerPort: 4000, Velero: true}
+	if got := BuildConfigMap(w, metav1ac.OwnerReference()).Labels[VeleroExcludeLabel]; got != "true" {
+		t.Errorf("config ConfigMap %s = %q, want true", VeleroExcludeLabel, got)
+	}
+
+	// Without a database there is no spend to wait for.
+	dep := BuildDeployment(w, metav1ac.OwnerReference(), "c", "s")
+	if _, ok := dep.Spec.Template.Annotations[veleroHookCommandAnnotation]; ok {
+		t.Error("a gateway without a database must not get the pre-backup hook")
+	}
+	w.Env = []corev1.EnvVar{{Name: DatabaseURLEnvVar, Value: "postgresql://db"}}
+	annotations := BuildDeployment(w, metav1ac.OwnerReference(), "c", "s").Spec.Template.Annotations
+	if annotations[veleroHookContainerAnnotation] != ContainerName || annotations[veleroHookCommandAnnotation] != `["/bin/sh","-c","sleep 15"]` ||
+		annotations[veleroHookTimeoutAnnotation] != "30s" {
+		t.Errorf("pod template annotations = %v", annotations)
+	}
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	batchv1ac "k8s.io/client-go/applyconfigurations/batch/v1"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
+	metav1ac "k8s.io/client-go/applyconfigurations/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// WarmUpAnnotation, set to "true", runs a warm-up Job after every config
+// rollout. It sends a one-token completion to each model of the config
+// through the gateway, so broken provider credentials show before real
+// traffic hits them.
+const WarmUpAnnotation = "ai-gateway-litellm.agentic-layer.ai/warm-up"
+
+// WarmUpPhase tags warm-up Job failures.
+const WarmUpPhase = "WarmUp"
+
+// WarmUp reports whether WarmUpAnnotation is set to "true".
+func WarmUp(annotations map[string]string) bool {
+	return annotations[WarmUpAnnotation] == "true"
+}
+
+// WarmUpName is the name of the warm-up Job of the gateway name.
+func WarmUpName(name string) string {
+	return name + "-warm-up"
+}
+
+// WarmUpStatus is the outcome of the warm-up Job of the current config.
+type WarmUpStatus struct {
+	// Job is the warm-up Job of the current config, nil while a Job of an
+	// earlier config is being deleted.
+	Job      *batchv1.Job
+	Complete bool
+	Failed   bool
+}
+
+// ConfigModelNames returns the model_name of every model_list entry of a
+// rendered config.
+func ConfigModelNames(configYAML string) ([]string, error) {
+	var config LiteLLMConfig
+	if err := yaml.Unmarshal([]byte(configYAML), &config); err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(config.ModelList))
+	for _, m := range config.ModelList {
+		names = append(names, m.ModelName)
+	}
+	return names, nil
+}
+
+// ReconcileWarmUp runs the warm-up Job of w.ConfigYAML. Callers invoke it
+// once that config is rolled out. A Job is kept until the config changes;
+// the Job of an earlier config is then deleted and the next call starts a
+// new one. masterKey is the gateway's LITELLM_MASTER_KEY env var, empty for
+// an open proxy.
+//
+// On failure, the returned error is a *PhaseError tagged WarmUpPhase.
+func ReconcileWarmUp(ctx context.Context, c client.Client, scheme *runtime.Scheme, w GatewayWorkload, masterKey corev1.EnvVar) (*WarmUpStatus, error) {
+	phaseErr := func(err error) error { return &PhaseError{Phase: WarmUpPhase, Err: err} }
+	configHash := hashYAML(w.ConfigYAML)
+
+	job := &batchv1.Job{}
+	if err := getOwned(ctx, c, w, client.ObjectKey{Namespace: w.Namespace, Name: WarmUpName(w.Name)}, job); err != nil {
+		return nil, phaseErr(err)
+	}
+	if job.ResourceVersion != "" {
+		if !metav1.IsControlledBy(job, w.Owner) {
+			return nil, phaseErr(&ConflictError{Kind: "Job", Namespace: w.Namespace, Name: job.Name,
+				Reason: fmt.Sprintf("is not controlled by %s", w.Name)})
+		}
+		if job.Annotations[configHashAnnotation] == configHash {
+			return &WarmUpStatus{Job: job, Complete: jobConditionTrue(job, batchv1.JobComplete), Failed: jobConditionTrue(job, batchv1.JobFailed)}, nil
+		}
+		// The pod template of a Job is immutable.
+		if !w.DryRun {
+			if err := c.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+				return nil, phaseErr(err)
+			}
+		}
+		return &WarmUpStatus{}, nil
+	}
+
+	models, err := ConfigModelNames(w.ConfigYAML)
+	if err != nil {
+		return nil, phaseErr(fmt.Errorf("reading models from config: %w", err))
+	}
+	ownerRef, err := controllerReference(w.Owner, scheme)
+	if err != nil {
+		return nil, phaseErr(err)
+	}
+	desired := BuildWarmUpJob(w, ownerRef, configHash, models, masterKey)
+	existing := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: WarmUpName(w.Name), Namespace: w.Namespace}}
+	if err := apply(ctx, c, w, desired, existing, "Job"); err != nil {
+		return nil, phaseErr(err)
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(existing), job); err != nil {
+		if w.DryRun && apierrors.IsNotFound(err) {
+			return &WarmUpStatus{}, nil
+		}
+		return nil, phaseErr(err)
+	}
+	return &WarmUpStatus{Job: job}, nil
+}
+
+// DeleteWarmUp deletes the warm-up Job once the annotation is removed.
+func DeleteWarmUp(ctx context.Context, c client.Client, w GatewayWorkload) error {
+	if err := deleteOwned(ctx, c, w, &batchv1.Job{}, WarmUpName(w.Name)); err != nil {
+		return &PhaseError{Phase: WarmUpPhase, Err: err}
+	}
+	return nil
+}
+
+// BuildWarmUpJob returns the desired state of the warm-up Job for the
+// config with configHash. Like the usage report, it runs a standard-library
+// Python script on the LiteLLM image.
+func BuildWarmUpJob(w GatewayWorkload, ownerRef *metav1ac.OwnerReferenceApplyConfiguration, configHash string, models []string, masterKey corev1.EnvVar) *batchv1ac.JobApplyConfiguration {
+	name := WarmUpName(w.Name)
+	container := corev1ac.Container().
+		WithName("warm-up").
+		WithImage(w.image()).
+		WithCommand("python", "-c", warmUpScript).
+		WithEnv(
+			corev1ac.EnvVar().WithName("LITELLM_BASE_URL").WithValue(ServiceURL(w.Name, w.Namespace, w.ServicePort)),
+			corev1ac.EnvVar().WithName("MODELS").WithValue(strings.Join(models, ",")),
+		)
+	if masterKey.Name != "" {
+		container.WithEnv(envVarApplyConfiguration(masterKey))
+	}
+
+	return batchv1ac.Job(name, w.Namespace).
+		WithOwnerReferences(ownerRef).
+		WithLabels(BuildResourceLabels(w.Name, w.CommonMetadata)).
+		WithAnnotations(BuildResourceAnnotations(w.CommonMetadata)).
+		WithAnnotations(map[string]string{configHashAnnotation: configHash}).
+		WithSpec(batchv1ac.JobSpec().
+			WithBackoffLimit(1).
+			WithTemplate(corev1ac.PodTemplateSpec().
+				WithLabels(map[string]string{"app": name}).
+				WithSpec(corev1ac.PodSpec().
+					WithRestartPolicy(corev1.RestartPolicyNever).
+					WithAutomountServiceAccountToken(false).
+					WithContainers(container))))
+}
+
+func jobConditionTrue(job *batchv1.Job, conditionType batchv1.JobConditionType) bool {
+	for _, c := range job.Status.Conditions {
+		if c.Type == conditionType && c.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// warmUpScript sends a one-token completion to every model and fails when
+// any of them fails, listing the models and errors in the Job's log.
+const warmUpScript = `import json, os, sys, urllib.error, urllib.request
+
+base = os.environ["LITELLM_BASE_URL"]
+headers = {"Content-Type": "application/json",
+           "Authorization": "Bearer " + os.environ.get("LITELLM_MASTER_KEY", "")}
+failed = []
+for model in filter(None, os.environ["MODELS"].split(",")):
+    body = json.dumps({"model": model, "max_tokens": 1,
+                       "messages": [{"role": "user", "content": "ping"}]}).encode()
+    try:
+        urllib.request.urlopen(urllib.request.Request(
+            base + "/chat/completions", data=body, headers=headers), timeout=120).read()
+        print("ok", model)
+    except urllib.error.HTTPError as e:
+        failed.append(model)
+        print("failed", model, e.code, e.read().decode(errors="replace")[:500])
+    except Exception as e:
+        failed.append(model)
+        print("failed", model, e)
+if failed:
+    sys.exit("warm-up failed for: " + ", ".join(failed))
+`
@@ -0,0 +1,99 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package litellm
+
+import (
+	"context"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestConfigModelNames(t *testing.T) {
+	got, err := ConfigModelNames("model_list:\n- model_name: gpt-4o\n  litellm_params: {model: openai/gpt-4o}\n- model_name: claude\n")
+	if err != nil || len(got) != 2 || got[0] != "gpt-4o" || got[1] != "claude" {
+		t.Errorf("got %v, %v", got, err)
+	}
+	if _, err := ConfigModelNames("model_list: 3"); err == nil {
+		t.Error("want an error for a malformed config")
+	}
+}
+
+func TestReconcileWarmUp(t *testing.T) {
+	s := workloadScheme(t)
+	owner := newOwner("gw", "default")
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(owner).Build()
+	ctx := context.Background()
+	w := GatewayWorkload{Name: "gw", Namespace: "default", ServicePort: 4000, Owner: owner,
+		ConfigYAML: "model_list:\n- model_name: gpt-4o\n- model_name: claude\n"}
+	masterKey := corev1.EnvVar{Name: MasterKeyEnvVar, Value: "sk-master"}
+
+	status, err := ReconcileWarmUp(ctx, c, s, w, masterKey)
+	if err != nil {
+		t.Fatalf("ReconcileWarmUp: %v", err)
+	}
+	if status.Job == nil || status.Complete || status.Failed {
+		t.Fatalf("want a running Job, got %+v", status)
+	}
+	key := types.NamespacedName{Name: "gw-warm-up", Namespace: "default"}
+	var job batchv1.Job
+	if err := c.Get(ctx, key, &job); err != nil {
+		t.Fatalf("get Job: %v", err)
+	}
+	env := map[string]string{}
+	for _, e := range job.Spec.Template.Spec.Containers[0].Env {
+		env[e.Name] = e.Value
+	}
+	if env["MODELS"] != "gpt-4o,claude" || env[MasterKeyEnvVar] != "sk-master" ||
+		env["LITELLM_BASE_URL"] != "http://gw.default.svc.cluster.local:4000" {
+		t.Errorf("unexpected env %v", env)
+	}
+
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}}
+	if err := c.Status().Update(ctx, &job); err != nil {
+		t.Fatalf("update Job status: %v", err)
+	}
+	if status, err = ReconcileWarmUp(ctx, c, s, w, masterKey); err != nil || !status.Failed {
+		t.Fatalf("want a failed warm-up, got %+v, %v", status, err)
+	}
+
+	// A new config replaces the Job of the old one.
+	w.ConfigYAML = "model_list:\n- model_name: gpt-4o\n"
+	if status, err = ReconcileWarmUp(ctx, c, s, w, masterKey); err != nil || status.Job != nil {
+		t.Fatalf("want the old Job deleted, got %+v, %v", status, err)
+	}
+	if status, err = ReconcileWarmUp(ctx, c, s, w, masterKey); err != nil || status.Job == nil || status.Failed {
+		t.Fatalf("want a new running Job, got %+v, %v", status, err)
+	}
+	if err := c.Get(ctx, key, &job); err != nil {
+		t.Fatalf("get Job: %v", err)
+	}
+	if job.Annotations[configHashAnnotation] != hashYAML(w.ConfigYAML) {
+		t.Errorf("Job must be stamped with the new config hash")
+	}
+
+	if err := DeleteWarmUp(ctx, c, w); err != nil {
+		t.Fatalf("DeleteWarmUp: %v", err)
+	}
+	if err := c.Get(ctx, key, &batchv1.Job{}); !apierrors.IsNotFound(err) {
+		t.Errorf("Job: want NotFound, got %v", err)
+	}
+}
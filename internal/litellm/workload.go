@@ -22,18 +22,33 @@ package litellm
 import (
 	"context"
 	"crypto/sha256"
+	"encoding/json"
 	"fmt"
+	"slices"
 	"strconv"
+	"sync"
+	"time"
 
 	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	litellmv1alpha1 "github.com/agentic-layer/ai-gateway-litellm/api/v1alpha1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	appsv1ac "k8s.io/client-go/applyconfigurations/apps/v1"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
+	metav1ac "k8s.io/client-go/applyconfigurations/meta/v1"
+	"k8s.io/client-go/util/csaupgrade"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -50,16 +65,97 @@ const (
 // Env carries the caller's already-resolved env vars (user spec.env plus any
 // CRD-specific generated entries such as API-key references); ReconcileWorkload
 // passes it through MergeEnv before mounting on the container.
+// LogLevel, when non-empty, is injected as LITELLM_LOG and wins over any
+// LITELLM_LOG entry in Env. DryRun must be set when c is a dry-run client; each
+// object is then logged with the change the apply would have made.
+// APIReader, when set, is asked for objects the (label-filtered) cache behind
+// c does not know, so pre-existing objects are still checked for adoption.
+// BlueGreen is only read by ReconcileBlueGreenWorkload, ArgoRollout only by
+// ReconcileArgoRolloutWorkload. Flagger, when set, routes the Service to
+// Flagger's primary Deployment once it exists. Hostname, when set, is the
+// name external-dns publishes the Service under. OTelCollector, when set,
+// adds the collector sidecar. Velero excludes the config ConfigMap from
+// Velero backups and adds the pre-backup hook. Image, when set, replaces
+// the default LiteLLM image, e.g. with one pinned to a digest by an
+// ImageResolver. Resources, when set, replaces the default resources of
+// the litellm container. InferencePool is only read by
+// ReconcileInferencePool.
+// ClusterAutoscaler, when set, adds its annotation and priority class to
+// the pods; its placeholder pods are only applied by
+// ReconcileOverprovisioning.
+// GatewayTemplate, when set, is layered onto the generated pod template;
+// see ValidateGatewayTemplate. Its overrides patch the generated objects
+// before the rollout strategy adjusts them; see CheckOverrides.
 type GatewayWorkload struct {
-	Name, Namespace string
-	Owner           client.Object
-	ContainerPort   int32
-	ServicePort     int32
-	Env             []corev1.EnvVar
-	EnvFrom         []corev1.EnvFromSource
-	CommonMetadata  *gatewayv1alpha1.EmbeddedMetadata
-	PodMetadata     *gatewayv1alpha1.EmbeddedMetadata
-	ConfigYAML      string
+	Name, Namespace   string
+	Owner             client.Object
+	ContainerPort     int32
+	ServicePort       int32
+	Env               []corev1.EnvVar
+	EnvFrom           []corev1.EnvFromSource
+	CommonMetadata    *gatewayv1alpha1.EmbeddedMetadata
+	PodMetadata       *gatewayv1alpha1.EmbeddedMetadata
+	ConfigYAML        string
+	LogLevel          string
+	DryRun            bool
+	APIReader         client.Reader
+	BlueGreen         *BlueGreen
+	ArgoRollout       *ArgoRollout
+	Flagger           *Flagger
+	Hostname          string
+	OTelCollector     *OTelCollector
+	Velero            bool
+	Image             string
+	Resources         *corev1.ResourceRequirements
+	RegistryMirror    string
+	InferencePool     *InferencePool
+	ClusterAutoscaler *ClusterAutoscaler
+	GatewayTemplate   *litellmv1alpha1.LiteLLMGatewayTemplateSpec
+	Suspended         bool
+}
+
+// image returns the LiteLLM image of w.
+func (w GatewayWorkload) image() string {
+	if w.Image != "" {
+		return w.mirror(w.Image)
+	}
+	return w.mirror(Image)
+}
+
+// resources returns the resources of the litellm container of w.
+func (w GatewayWorkload) resources() *corev1ac.ResourceRequirementsApplyConfiguration {
+	if w.Resources == nil {
+		return corev1ac.ResourceRequirements().
+			WithRequests(corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("250M"),
+				corev1.ResourceCPU:    resource.MustParse("100m"),
+			}).
+			WithLimits(corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("2G"),
+				corev1.ResourceCPU:    resource.MustParse("500m"),
+			})
+	}
+	resources := corev1ac.ResourceRequirements()
+	if len(w.Resources.Requests) > 0 {
+		resources.WithRequests(w.Resources.Requests)
+	}
+	if len(w.Resources.Limits) > 0 {
+		resources.WithLimits(w.Resources.Limits)
+	}
+	for _, claim := range w.Resources.Claims {
+		c := corev1ac.ResourceClaim().WithName(claim.Name)
+		if claim.Request != "" {
+			c.WithRequest(claim.Request)
+		}
+		resources.WithClaims(c)
+	}
+	return resources
+}
+
+// mirror returns image as pulled through the registry mirror of w; see
+// MirrorImage.
+func (w GatewayWorkload) mirror(image string) string {
+	return MirrorImage(w.RegistryMirror, image)
 }
 
 // PhaseError tags a workload-reconcile failure with which step failed.
@@ -73,10 +169,13 @@ func (e *PhaseError) Error() string { return fmt.Sprintf("%s reconcile failed: %
 func (e *PhaseError) Unwrap() error { return e.Err }
 
 // IsDeploymentRolledOut reports whether the deployment-controller has applied
-// the latest spec and all desired replicas are available. The second return is
-// a human-readable reason callers can use as a status-condition message when
-// the rollout is still in progress. Callers should use this to gate a Ready=True
-// condition so consumers do not see Ready before pods are actually serving.
+// the latest spec and every desired replica runs and serves the current pod
+// template — and with it the current config-hash. Old pods still terminating
+// keep the rollout open, so Ready=True means no replica serves a stale config.
+// The second return is a human-readable reason callers can use as a
+// status-condition message when the rollout is still in progress. Callers
+// should use this to gate a Ready=True condition so consumers do not see
+// Ready before pods running the new config are actually serving.
 func IsDeploymentRolledOut(d *appsv1.Deployment) (bool, string) {
 	if d.Generation > d.Status.ObservedGeneration {
 		return false, fmt.Sprintf("Deployment generation %d not yet observed (last observed: %d)",
@@ -86,6 +185,14 @@ func IsDeploymentRolledOut(d *appsv1.Deployment) (bool, string) {
 	if d.Spec.Replicas != nil {
 		desired = *d.Spec.Replicas
 	}
+	if d.Status.UpdatedReplicas < desired {
+		return false, fmt.Sprintf("Deployment rollout in progress: %d/%d replicas updated",
+			d.Status.UpdatedReplicas, desired)
+	}
+	if d.Status.Replicas > d.Status.UpdatedReplicas {
+		return false, fmt.Sprintf("Deployment rollout in progress: %d old replicas pending termination",
+			d.Status.Replicas-d.Status.UpdatedReplicas)
+	}
 	if d.Status.AvailableReplicas < desired {
 		return false, fmt.Sprintf("Deployment rollout in progress: %d/%d replicas available",
 			d.Status.AvailableReplicas, desired)
@@ -93,11 +200,40 @@ func IsDeploymentRolledOut(d *appsv1.Deployment) (bool, string) {
 	return true, ""
 }
 
+// DeploymentProgressStalled reports whether the deployment-controller gave up
+// on the current rollout because it exceeded spec.progressDeadlineSeconds.
+// The second return is the deployment-controller's message.
+func DeploymentProgressStalled(d *appsv1.Deployment) (bool, string) {
+	if d.Generation > d.Status.ObservedGeneration {
+		return false, ""
+	}
+	for _, c := range d.Status.Conditions {
+		if c.Type == appsv1.DeploymentProgressing && c.Status == corev1.ConditionFalse &&
+			c.Reason == "ProgressDeadlineExceeded" {
+			return true, c.Message
+		}
+	}
+	return false, ""
+}
+
+// DeployedConfigHash returns the config-hash stamped on the Deployment's pod
+// template, i.e. the LiteLLM config the current rollout is bringing live.
+func DeployedConfigHash(d *appsv1.Deployment) string {
+	return d.Spec.Template.Annotations[configHashAnnotation]
+}
+
+// DeployedSecretHash returns the secret-hash stamped on the Deployment's pod
+// template, i.e. the Secrets the current rollout is bringing live.
+func DeployedSecretHash(d *appsv1.Deployment) string {
+	return d.Spec.Template.Annotations[secretHashAnnotation]
+}
+
 // ReconcileWorkload creates or updates the ConfigMap, Deployment, and Service that
 // run a LiteLLM proxy for a single gateway CR (the Owner). All three are reconciled
-// idempotently using controllerutil.CreateOrUpdate. The pod template carries
-// config-hash and secret-hash annotations so any change to ConfigYAML or the
-// api-keys secret triggers a rolling restart.
+// idempotently with server-side apply under FieldManager, so fields set by
+// other controllers (an HPA's replicas, injected sidecars) are left alone. The
+// pod template carries config-hash and secret-hash annotations so any change
+// to ConfigYAML or to any secret the container reads triggers a rolling restart.
 //
 // On failure, the returned error is a *PhaseError tagged with which step failed.
 func ReconcileWorkload(ctx context.Context, c client.Client, scheme *runtime.Scheme, w GatewayWorkload) error {
@@ -107,7 +243,7 @@ func ReconcileWorkload(ctx context.Context, c client.Client, scheme *runtime.Sch
 		return &PhaseError{Phase: "ConfigMap", Err: err}
 	}
 
-	secretHash, err := computeSecretHash(ctx, c, w.Namespace)
+	secretHash, err := computeSecretHash(ctx, c, w.Namespace, ReferencedSecretNames(w.Env, w.EnvFrom))
 	if err != nil {
 		return &PhaseError{Phase: "Secret", Err: err}
 	}
@@ -121,216 +257,428 @@ func ReconcileWorkload(ctx context.Context, c client.Client, scheme *runtime.Sch
 	return nil
 }
 
+// ConfigHash returns the hash of a rendered config that the pod template
+// carries in its config-hash annotation.
+func ConfigHash(configYAML string) string {
+	return hashYAML(configYAML)
+}
+
 func hashYAML(yaml string) string {
 	h := sha256.Sum256([]byte(yaml))
 	return fmt.Sprintf("%x", h)[:16]
 }
 
-// findContainer returns a pointer to the named container in containers, or nil.
-func findContainer(containers []corev1.Container, name string) *corev1.Container {
-	for i := range containers {
-		if containers[i].Name == name {
-			return &containers[i]
-		}
-	}
-	return nil
-}
+// FieldManager is the server-side apply field manager for every child object
+// the operator writes. It must stay stable: renaming it orphans ownership of
+// every field applied under the old name.
+const FieldManager = "ai-gateway-litellm-operator"
+
+// legacyFieldManagers are the managers that own fields on child objects
+// written before the switch to server-side apply (client-side Update under
+// the manager binary's user agent). Their entries are migrated to
+// FieldManager so fields the operator stops setting are actually pruned.
+var legacyFieldManagers = sets.New("manager")
 
 func reconcileConfigMap(ctx context.Context, c client.Client, scheme *runtime.Scheme, w GatewayWorkload) error {
-	log := logf.FromContext(ctx)
-	cm := &corev1.ConfigMap{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-config", w.Name),
-			Namespace: w.Namespace,
-		},
-	}
-	result, err := controllerutil.CreateOrUpdate(ctx, c, cm, func() error {
-		if err := controllerutil.SetControllerReference(w.Owner, cm, scheme); err != nil {
-			return err
-		}
-		if cm.Labels == nil {
-			cm.Labels = make(map[string]string)
-		}
-		cm.Labels["app"] = w.Name
-		cm.Data = map[string]string{"config.yaml": w.ConfigYAML}
-		return nil
-	})
+	ownerRef, err := controllerReference(w.Owner, scheme)
 	if err != nil {
 		return err
 	}
-	if result != controllerutil.OperationResultNone {
-		log.Info("ConfigMap reconciled", "name", cm.Name, "operation", result)
+	cm, err := buildConfigMap(w, ownerRef)
+	if err != nil {
+		return err
 	}
-	return nil
+	existing := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: *cm.Name, Namespace: w.Namespace}}
+	return apply(ctx, c, w, cm, existing, "ConfigMap")
 }
 
 func reconcileDeployment(ctx context.Context, c client.Client, scheme *runtime.Scheme, w GatewayWorkload, configHash, secretHash string) error {
-	log := logf.FromContext(ctx)
-
-	replicas := int32(1)
-	deploymentLabels := BuildResourceLabels(w.Name, w.CommonMetadata)
-	deploymentAnnotations := BuildResourceAnnotations(w.CommonMetadata)
-	podTemplateLabels := BuildPodTemplateLabels(w.Name, w.CommonMetadata, w.PodMetadata)
-	podTemplateAnnotations := BuildPodTemplateAnnotations(w.CommonMetadata, w.PodMetadata, configHash, secretHash)
-
-	deployment := &appsv1.Deployment{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      w.Name,
-			Namespace: w.Namespace,
-		},
+	ownerRef, err := controllerReference(w.Owner, scheme)
+	if err != nil {
+		return err
 	}
+	deployment, err := buildDeployment(w, ownerRef, configHash, secretHash)
+	if err != nil {
+		return err
+	}
+	existing := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: w.Name, Namespace: w.Namespace}}
+	return apply(ctx, c, w, deployment, existing, "Deployment")
+}
 
-	result, err := controllerutil.CreateOrUpdate(ctx, c, deployment, func() error {
-		if err := controllerutil.SetControllerReference(w.Owner, deployment, scheme); err != nil {
+func reconcileService(ctx context.Context, c client.Client, scheme *runtime.Scheme, w GatewayWorkload) error {
+	ownerRef, err := controllerReference(w.Owner, scheme)
+	if err != nil {
+		return err
+	}
+	service, err := buildService(w, ownerRef)
+	if err != nil {
+		return err
+	}
+	if w.Flagger != nil {
+		// Once a Canary initialized, Flagger routes the Service to its
+		// primary; selecting the gateway's pods again would undo that.
+		primary, err := FlaggerPrimary(ctx, c, w)
+		if err != nil {
 			return err
 		}
-		if deployment.Labels == nil {
-			deployment.Labels = make(map[string]string)
-		}
-		for k, v := range deploymentLabels {
-			deployment.Labels[k] = v
+		if primary != nil {
+			service.Spec.WithSelector(map[string]string{"app": FlaggerPrimaryName(w.Name)})
 		}
-		if deployment.Annotations == nil {
-			deployment.Annotations = make(map[string]string)
+	}
+	existing := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: w.Name, Namespace: w.Namespace}}
+	return apply(ctx, c, w, service, existing, "Service")
+}
+
+// apply server-side applies obj with FieldManager, forcing ownership of any
+// conflicting field: the operator is the single source of truth for every
+// field it sets. existing names the live object; when it is already present
+// it must be adoptable by w.Owner (see checkAdoptable) and has its legacy
+// managed fields migrated first.
+func apply(ctx context.Context, c client.Client, w GatewayWorkload, obj runtime.ApplyConfiguration, existing client.Object, kind string) error {
+	found := false
+	err := c.Get(ctx, client.ObjectKeyFromObject(existing), existing)
+	if apierrors.IsNotFound(err) && w.APIReader != nil {
+		// Objects created before ManagedByLabel existed are invisible to a
+		// cache filtered by ManagedSelector until the apply labels them.
+		err = w.APIReader.Get(ctx, client.ObjectKeyFromObject(existing), existing)
+	}
+	if err == nil {
+		found = true
+		if err := checkAdoptable(existing, kind, w); err != nil {
+			return err
 		}
-		for k, v := range deploymentAnnotations {
-			deployment.Annotations[k] = v
+		if err := upgradeManagedFields(ctx, c, existing); err != nil {
+			return fmt.Errorf("migrating managed fields: %w", err)
 		}
+	} else if !apierrors.IsNotFound(err) {
+		return err
+	}
+	if err := c.Apply(ctx, obj, client.FieldOwner(FieldManager), client.ForceOwnership); err != nil {
+		return err
+	}
+	if w.DryRun {
+		logDryRunDiff(ctx, kind, existing, found, obj)
+		return nil
+	}
+	logf.FromContext(ctx).V(1).Info(kind+" applied", "name", existing.GetName())
+	return nil
+}
 
-		deployment.Spec.Replicas = &replicas
-		deployment.Spec.Selector = &metav1.LabelSelector{
-			MatchLabels: map[string]string{"app": w.Name},
-		}
+// ownedUnstructured returns an object of kind gvk with the gateway's labels
+// and controller reference and no spec. It stands in for the typed objects
+// of optional CRDs, whose types the scheme does not know.
+func ownedUnstructured(w GatewayWorkload, scheme *runtime.Scheme, gvk schema.GroupVersionKind, name string) (*unstructured.Unstructured, error) {
+	ownerRef, err := controllerReference(w.Owner, scheme)
+	if err != nil {
+		return nil, err
+	}
+	labels := map[string]any{}
+	for k, v := range BuildResourceLabels(w.Name, w.CommonMetadata) {
+		labels[k] = v
+	}
+	annotations := map[string]any{}
+	for k, v := range BuildResourceAnnotations(w.CommonMetadata) {
+		annotations[k] = v
+	}
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": gvk.GroupVersion().String(),
+		"kind":       gvk.Kind,
+		"metadata": map[string]any{
+			"name":        name,
+			"namespace":   w.Namespace,
+			"labels":      labels,
+			"annotations": annotations,
+			"ownerReferences": []any{map[string]any{
+				"apiVersion":         *ownerRef.APIVersion,
+				"kind":               *ownerRef.Kind,
+				"name":               *ownerRef.Name,
+				"uid":                string(*ownerRef.UID),
+				"controller":         true,
+				"blockOwnerDeletion": true,
+			}},
+		},
+	}}, nil
+}
 
-		if deployment.Spec.Template.Labels == nil {
-			deployment.Spec.Template.Labels = make(map[string]string)
-		}
-		for k, v := range podTemplateLabels {
-			deployment.Spec.Template.Labels[k] = v
-		}
-		if deployment.Spec.Template.Annotations == nil {
-			deployment.Spec.Template.Annotations = make(map[string]string)
-		}
-		for k, v := range podTemplateAnnotations {
-			deployment.Spec.Template.Annotations[k] = v
-		}
+// applyUnstructured applies obj like apply does a typed object.
+func applyUnstructured(ctx context.Context, c client.Client, w GatewayWorkload, obj *unstructured.Unstructured) error {
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(obj.GroupVersionKind())
+	existing.SetName(obj.GetName())
+	existing.SetNamespace(obj.GetNamespace())
+	return apply(ctx, c, w, client.ApplyConfigurationFromUnstructured(obj), existing, obj.GetKind())
+}
 
-		container := findContainer(deployment.Spec.Template.Spec.Containers, ContainerName)
-		if container == nil {
-			deployment.Spec.Template.Spec.Containers = append(deployment.Spec.Template.Spec.Containers, corev1.Container{
-				Name: ContainerName,
-			})
-			container = &deployment.Spec.Template.Spec.Containers[len(deployment.Spec.Template.Spec.Containers)-1]
-		}
+// missingKindTTL is how long a kind found missing is assumed to stay
+// missing: every RESTMapper lookup of a missing kind is a discovery call.
+const missingKindTTL = 5 * time.Minute
 
-		container.Image = Image
-		container.Ports = []corev1.ContainerPort{
-			{Name: "http", ContainerPort: w.ContainerPort, Protocol: corev1.ProtocolTCP},
-		}
-		container.VolumeMounts = []corev1.VolumeMount{
-			{Name: "config", MountPath: "/app/config", ReadOnly: true},
-			{Name: PrometheusMultiprocVolumeName, MountPath: PrometheusMultiprocDir},
+var (
+	missingKindsMu sync.Mutex
+	missingKinds   = map[missingKind]time.Time{}
+)
+
+type missingKind struct {
+	mapper apimeta.RESTMapper
+	gvk    schema.GroupVersionKind
+}
+
+// kindServed reports whether the cluster behind c serves gvk. A missing kind
+// is remembered for missingKindTTL, so callers that only clean up after an
+// optional CRD can ask on every reconcile.
+func kindServed(c client.Client, gvk schema.GroupVersionKind) (bool, error) {
+	key := missingKind{mapper: c.RESTMapper(), gvk: gvk}
+	missingKindsMu.Lock()
+	defer missingKindsMu.Unlock()
+	if checked, ok := missingKinds[key]; ok && time.Since(checked) < missingKindTTL {
+		return false, nil
+	}
+	if _, err := c.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version); err != nil {
+		if apimeta.IsNoMatchError(err) {
+			missingKinds[key] = time.Now()
+			return false, nil
 		}
-		container.Command = []string{
-			"litellm", "--config", "/app/config/config.yaml",
-			"--port", strconv.Itoa(int(w.ContainerPort)),
+		return false, err
+	}
+	delete(missingKinds, key)
+	return true, nil
+}
+
+// ConflictError reports a child object that already exists under the name
+// the gateway needs but that the gateway may not take over.
+// Controlled is set when another object controls it; labelling the object
+// cannot resolve such a conflict.
+type ConflictError struct {
+	Kind, Namespace, Name string
+	Reason                string
+	Controlled            bool
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s %s/%s already exists and %s", e.Kind, e.Namespace, e.Name, e.Reason)
+}
+
+// checkAdoptable decides whether an existing object may be managed for
+// w.Owner. Objects the gateway already controls pass. Objects without a
+// controller are adopted when they carry the gateway's "app" selector label,
+// which is what a migrated Helm release or hand-written manifest for the same
+// LiteLLM instance looks like; the apply then adds the owner reference.
+// Anything else is left alone and reported as a *ConflictError.
+func checkAdoptable(existing client.Object, kind string, w GatewayWorkload) error {
+	conflict := &ConflictError{Kind: kind, Namespace: existing.GetNamespace(), Name: existing.GetName()}
+	if ref := metav1.GetControllerOfNoCopy(existing); ref != nil {
+		if ref.UID == w.Owner.GetUID() {
+			return nil
 		}
-		container.Env = MergeEnv(w.Env)
-		container.EnvFrom = w.EnvFrom
-		container.Resources = corev1.ResourceRequirements{
-			Requests: corev1.ResourceList{
-				corev1.ResourceMemory: resource.MustParse("250M"),
-				corev1.ResourceCPU:    resource.MustParse("100m"),
-			},
-			Limits: corev1.ResourceList{
-				corev1.ResourceMemory: resource.MustParse("2G"),
-				corev1.ResourceCPU:    resource.MustParse("500m"),
-			},
+		conflict.Reason = fmt.Sprintf("is controlled by %s %s", ref.Kind, ref.Name)
+		conflict.Controlled = true
+		return conflict
+	}
+	if existing.GetLabels()["app"] != w.Name {
+		conflict.Reason = fmt.Sprintf("has no controller and no label app=%s; label it to let the gateway adopt it", w.Name)
+		return conflict
+	}
+	return nil
+}
+
+// FindConflicts looks up the ConfigMap, Deployment and Service a gateway
+// named after owner would apply and returns those it may not adopt, so
+// admission can report them before the first reconcile fails on them.
+func FindConflicts(ctx context.Context, r client.Reader, owner client.Object) ([]*ConflictError, error) {
+	w := GatewayWorkload{Name: owner.GetName(), Namespace: owner.GetNamespace(), Owner: owner}
+	children := []struct {
+		kind string
+		obj  client.Object
+		name string
+	}{
+		{"ConfigMap", &corev1.ConfigMap{}, fmt.Sprintf("%s-config", w.Name)},
+		{"Deployment", &appsv1.Deployment{}, w.Name},
+		{"Service", &corev1.Service{}, w.Name},
+	}
+	var conflicts []*ConflictError
+	for _, child := range children {
+		err := r.Get(ctx, client.ObjectKey{Namespace: w.Namespace, Name: child.name}, child.obj)
+		if apierrors.IsNotFound(err) {
+			continue
 		}
-		container.LivenessProbe = &corev1.Probe{
-			ProbeHandler: corev1.ProbeHandler{
-				HTTPGet: &corev1.HTTPGetAction{
-					Path: "/health/liveliness", Port: intstr.FromInt32(w.ContainerPort), Scheme: corev1.URISchemeHTTP,
-				},
-			},
-			InitialDelaySeconds: 30, PeriodSeconds: 10, TimeoutSeconds: 5, SuccessThreshold: 1, FailureThreshold: 10,
+		if err != nil {
+			return nil, fmt.Errorf("getting %s %s: %w", child.kind, child.name, err)
 		}
-		container.ReadinessProbe = &corev1.Probe{
-			ProbeHandler: corev1.ProbeHandler{
-				HTTPGet: &corev1.HTTPGetAction{
-					Path: "/health/readiness", Port: intstr.FromInt32(w.ContainerPort), Scheme: corev1.URISchemeHTTP,
-				},
-			},
-			InitialDelaySeconds: 5, PeriodSeconds: 10, TimeoutSeconds: 5, SuccessThreshold: 1, FailureThreshold: 3,
+		if conflict, ok := checkAdoptable(child.obj, child.kind, w).(*ConflictError); ok {
+			conflicts = append(conflicts, conflict)
 		}
+	}
+	return conflicts, nil
+}
 
-		deployment.Spec.Template.Spec.Volumes = []corev1.Volume{
-			{
-				Name: "config",
-				VolumeSource: corev1.VolumeSource{
-					ConfigMap: &corev1.ConfigMapVolumeSource{
-						LocalObjectReference: corev1.LocalObjectReference{Name: fmt.Sprintf("%s-config", w.Name)},
-					},
-				},
-			},
-			{
-				Name:         PrometheusMultiprocVolumeName,
-				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
-			},
-		}
-		return nil
-	})
-	if err != nil {
+// upgradeManagedFields hands fields owned by legacyFieldManagers over to
+// FieldManager. It is a no-op when the object has already been migrated.
+func upgradeManagedFields(ctx context.Context, c client.Client, obj client.Object) error {
+	patch, err := csaupgrade.UpgradeManagedFieldsPatch(obj, legacyFieldManagers, FieldManager)
+	if err != nil || patch == nil {
 		return err
 	}
-	if result != controllerutil.OperationResultNone {
-		log.Info("Deployment reconciled", "name", deployment.Name, "operation", result)
+	return c.Patch(ctx, obj, client.RawPatch(types.JSONPatchType, patch))
+}
+
+// controllerReference builds the controller owner reference pointing at owner.
+func controllerReference(owner client.Object, scheme *runtime.Scheme) (*metav1ac.OwnerReferenceApplyConfiguration, error) {
+	gvk, err := apiutil.GVKForObject(owner, scheme)
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	return metav1ac.OwnerReference().
+		WithAPIVersion(gvk.GroupVersion().String()).
+		WithKind(gvk.Kind).
+		WithName(owner.GetName()).
+		WithUID(owner.GetUID()).
+		WithController(true).
+		WithBlockOwnerDeletion(true), nil
 }
 
-func reconcileService(ctx context.Context, c client.Client, scheme *runtime.Scheme, w GatewayWorkload) error {
-	log := logf.FromContext(ctx)
+// BuildConfigMap returns the desired state of the gateway's config ConfigMap.
+func BuildConfigMap(w GatewayWorkload, ownerRef *metav1ac.OwnerReferenceApplyConfiguration) *corev1ac.ConfigMapApplyConfiguration {
+	cm := corev1ac.ConfigMap(fmt.Sprintf("%s-config", w.Name), w.Namespace).
+		WithOwnerReferences(ownerRef).
+		WithLabels(map[string]string{"app": w.Name}).
+		WithData(map[string]string{"config.yaml": w.ConfigYAML})
+	if w.Velero {
+		// Regenerated from the AiGateway on restore.
+		cm.WithLabels(map[string]string{VeleroExcludeLabel: "true"})
+	}
+	return cm
+}
 
-	serviceLabels := BuildResourceLabels(w.Name, w.CommonMetadata)
-	serviceAnnotations := BuildResourceAnnotations(w.CommonMetadata)
+// BuildDeployment returns the desired state of the gateway's Deployment.
+// spec.replicas is deliberately left out so an HPA (or a human with kubectl
+// scale) can own it; the API server defaults it to 1 on create. Only a
+// suspended gateway sets it, to zero (see buildDeployment).
+func BuildDeployment(w GatewayWorkload, ownerRef *metav1ac.OwnerReferenceApplyConfiguration, configHash, secretHash string) *appsv1ac.DeploymentApplyConfiguration {
+	env := w.Env
+	if w.LogLevel != "" {
+		env = append(slices.Clone(env), corev1.EnvVar{Name: LogLevelEnvVar, Value: w.LogLevel})
+	}
 
-	service := &corev1.Service{
-		ObjectMeta: metav1.ObjectMeta{Name: w.Name, Namespace: w.Namespace},
+	container := corev1ac.Container().
+		WithName(ContainerName).
+		WithImage(w.image()).
+		WithPorts(corev1ac.ContainerPort().
+			WithName("http").WithContainerPort(w.ContainerPort).WithProtocol(corev1.ProtocolTCP)).
+		WithVolumeMounts(
+			corev1ac.VolumeMount().WithName("config").WithMountPath("/app/config").WithReadOnly(true),
+			corev1ac.VolumeMount().WithName(PrometheusMultiprocVolumeName).WithMountPath(PrometheusMultiprocDir),
+		).
+		WithCommand("litellm", "--config", "/app/config/config.yaml",
+			"--port", strconv.Itoa(int(w.ContainerPort))).
+		WithResources(w.resources()).
+		WithLivenessProbe(corev1ac.Probe().
+			WithHTTPGet(corev1ac.HTTPGetAction().
+				WithPath("/health/liveliness").WithPort(intstr.FromInt32(w.ContainerPort)).WithScheme(corev1.URISchemeHTTP)).
+			WithInitialDelaySeconds(30).WithPeriodSeconds(10).WithTimeoutSeconds(5).
+			WithSuccessThreshold(1).WithFailureThreshold(10)).
+		WithReadinessProbe(corev1ac.Probe().
+			WithHTTPGet(corev1ac.HTTPGetAction().
+				WithPath("/health/readiness").WithPort(intstr.FromInt32(w.ContainerPort)).WithScheme(corev1.URISchemeHTTP)).
+			WithInitialDelaySeconds(5).WithPeriodSeconds(10).WithTimeoutSeconds(5).
+			WithSuccessThreshold(1).WithFailureThreshold(3))
+	for _, e := range MergeEnv(env) {
+		container.WithEnv(envVarApplyConfiguration(e))
 	}
-	result, err := controllerutil.CreateOrUpdate(ctx, c, service, func() error {
-		if err := controllerutil.SetControllerReference(w.Owner, service, scheme); err != nil {
-			return err
-		}
-		if service.Labels == nil {
-			service.Labels = make(map[string]string)
-		}
-		for k, v := range serviceLabels {
-			service.Labels[k] = v
-		}
-		if service.Annotations == nil {
-			service.Annotations = make(map[string]string)
-		}
-		for k, v := range serviceAnnotations {
-			service.Annotations[k] = v
-		}
-		service.Spec.Selector = map[string]string{"app": w.Name}
-		service.Spec.Ports = []corev1.ServicePort{
-			{
-				Name:       "http",
-				Port:       w.ServicePort,
-				TargetPort: intstr.FromInt32(w.ContainerPort),
-				Protocol:   corev1.ProtocolTCP,
-			},
+	for _, e := range w.EnvFrom {
+		container.WithEnvFrom(envFromApplyConfiguration(e))
+	}
+	var podLabels, podAnnotations map[string]string
+	if t := w.GatewayTemplate; t != nil {
+		applyContainerTemplate(t, container)
+		if t.Metadata != nil {
+			podLabels, podAnnotations = t.Metadata.Labels, t.Metadata.Annotations
 		}
-		service.Spec.Type = corev1.ServiceTypeClusterIP
-		return nil
-	})
+	}
+	var hookAnnotations map[string]string
+	if w.Velero && DatabaseModeEnabled(env) {
+		hookAnnotations = veleroHookAnnotations()
+	}
+	var overrideAnnotations map[string]string
+	if hash := configOverridesHash(w.GatewayTemplate); hash != "" {
+		// The config hash only covers the generated config.
+		overrideAnnotations = map[string]string{configOverridesHashAnnotation: hash}
+	}
+	podSpec := corev1ac.PodSpec().
+		WithContainers(container).
+		WithVolumes(
+			corev1ac.Volume().WithName("config").
+				WithConfigMap(corev1ac.ConfigMapVolumeSource().WithName(fmt.Sprintf("%s-config", w.Name))),
+			corev1ac.Volume().WithName(PrometheusMultiprocVolumeName).
+				WithEmptyDir(corev1ac.EmptyDirVolumeSource()),
+		)
+	if w.OTelCollector != nil {
+		podSpec.WithContainers(buildOTelCollectorContainer(w))
+	}
+	if w.ClusterAutoscaler != nil && w.ClusterAutoscaler.PriorityClassName != "" {
+		// Set first, so the gateway template's wins.
+		podSpec.WithPriorityClassName(w.ClusterAutoscaler.PriorityClassName)
+	}
+	if w.GatewayTemplate != nil {
+		applyPodTemplate(w.GatewayTemplate, podSpec)
+	}
+
+	return appsv1ac.Deployment(w.Name, w.Namespace).
+		WithOwnerReferences(ownerRef).
+		WithLabels(BuildResourceLabels(w.Name, w.CommonMetadata)).
+		WithAnnotations(BuildResourceAnnotations(w.CommonMetadata)).
+		WithSpec(appsv1ac.DeploymentSpec().
+			WithSelector(metav1ac.LabelSelector().WithMatchLabels(map[string]string{"app": w.Name})).
+			WithTemplate(corev1ac.PodTemplateSpec().
+				// The template's metadata goes first so the gateway's own wins.
+				WithAnnotations(clusterAutoscalerAnnotations(w.ClusterAutoscaler)).
+				WithLabels(podLabels).
+				WithAnnotations(podAnnotations).
+				WithAnnotations(hookAnnotations).
+				WithAnnotations(overrideAnnotations).
+				WithLabels(BuildPodTemplateLabels(w.Name, w.CommonMetadata, w.PodMetadata)).
+				WithAnnotations(BuildPodTemplateAnnotations(w.CommonMetadata, w.PodMetadata, configHash, secretHash)).
+				WithSpec(podSpec)))
+}
+
+// BuildService returns the desired state of the gateway's ClusterIP Service.
+func BuildService(w GatewayWorkload, ownerRef *metav1ac.OwnerReferenceApplyConfiguration) *corev1ac.ServiceApplyConfiguration {
+	service := corev1ac.Service(w.Name, w.Namespace).
+		WithOwnerReferences(ownerRef).
+		WithLabels(BuildResourceLabels(w.Name, w.CommonMetadata)).
+		WithAnnotations(BuildResourceAnnotations(w.CommonMetadata))
+	if w.Hostname != "" {
+		service.WithAnnotations(map[string]string{ExternalDNSHostnameAnnotation: w.Hostname})
+	}
+	return service.WithSpec(corev1ac.ServiceSpec().
+		WithType(corev1.ServiceTypeClusterIP).
+		WithSelector(map[string]string{"app": w.Name}).
+		WithPorts(corev1ac.ServicePort().
+			WithName("http").
+			WithPort(w.ServicePort).
+			WithTargetPort(intstr.FromInt32(w.ContainerPort)).
+			WithProtocol(corev1.ProtocolTCP)))
+}
+
+// asApplyConfiguration converts an API value into its apply-configuration
+// counterpart through their shared JSON form. Used for user-supplied values
+// (env, envFrom) whose nested sources would otherwise need a hand-written
+// copy that silently drops fields added in later API versions.
+func asApplyConfiguration[T any](in any) *T {
+	raw, err := json.Marshal(in)
 	if err != nil {
-		return err
+		panic(fmt.Sprintf("marshalling %T: %v", in, err))
 	}
-	if result != controllerutil.OperationResultNone {
-		log.Info("Service reconciled", "name", service.Name, "operation", result)
+	out := new(T)
+	if err := json.Unmarshal(raw, out); err != nil {
+		panic(fmt.Sprintf("unmarshalling %T into %T: %v", in, out, err))
 	}
-	return nil
+	return out
+}
+
+func envVarApplyConfiguration(e corev1.EnvVar) *corev1ac.EnvVarApplyConfiguration {
+	return asApplyConfiguration[corev1ac.EnvVarApplyConfiguration](e)
+}
+
+func envFromApplyConfiguration(e corev1.EnvFromSource) *corev1ac.EnvFromSourceApplyConfiguration {
+	return asApplyConfiguration[corev1ac.EnvFromSourceApplyConfiguration](e)
 }
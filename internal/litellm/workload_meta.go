@@ -28,8 +28,8 @@ const (
 )
 
 // BuildResourceLabels builds labels for Deployment/Service ObjectMeta:
-// commonMetadata.Labels first, then the managed selector label ("app") which
-// always wins.
+// commonMetadata.Labels first, then the managed selector label ("app") and
+// ManagedByLabel which always win.
 func BuildResourceLabels(name string, common *gatewayv1alpha1.EmbeddedMetadata) map[string]string {
 	labels := make(map[string]string)
 	if common != nil {
@@ -38,6 +38,7 @@ func BuildResourceLabels(name string, common *gatewayv1alpha1.EmbeddedMetadata)
 		}
 	}
 	labels["app"] = name
+	labels[ManagedByLabel] = FieldManager
 	return labels
 }
 
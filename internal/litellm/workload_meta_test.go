@@ -27,7 +27,10 @@ func TestBuildResourceLabels_NilCommonMetadata(t *testing.T) {
 	if got["app"] != "gw" {
 		t.Errorf("missing app=gw selector label: %v", got)
 	}
-	if len(got) != 1 {
+	if got[ManagedByLabel] != FieldManager {
+		t.Errorf("missing %s label: %v", ManagedByLabel, got)
+	}
+	if len(got) != 2 {
 		t.Errorf("unexpected extra labels: %v", got)
 	}
 }
@@ -24,10 +24,17 @@ import (
 
 	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
+	metav1ac "k8s.io/client-go/applyconfigurations/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -43,6 +50,15 @@ func workloadScheme(t *testing.T) *runtime.Scheme {
 	if err := appsv1.AddToScheme(s); err != nil {
 		t.Fatalf("appsv1: %v", err)
 	}
+	if err := batchv1.AddToScheme(s); err != nil {
+		t.Fatalf("batchv1: %v", err)
+	}
+	if err := rbacv1.AddToScheme(s); err != nil {
+		t.Fatalf("rbacv1: %v", err)
+	}
+	if err := networkingv1.AddToScheme(s); err != nil {
+		t.Fatalf("networkingv1: %v", err)
+	}
 	return s
 }
 
@@ -225,3 +241,260 @@ func TestReconcileWorkload_PhaseErrorTagsConfigMapFailure(t *testing.T) {
 		t.Errorf("Phase: want ConfigMap, got %q", pe.Phase)
 	}
 }
+
+func TestReconcileWorkload_LogLevelOverridesUserEnv(t *testing.T) {
+	s := workloadScheme(t)
+	owner := newOwner("gw", "default")
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(owner).Build()
+
+	w := GatewayWorkload{
+		Name: "gw", Namespace: "default", Owner: owner,
+		ContainerPort: 4000, ServicePort: 4000,
+		Env:        []corev1.EnvVar{{Name: LogLevelEnvVar, Value: "INFO"}},
+		ConfigYAML: "model_list: []\n",
+		LogLevel:   "DEBUG",
+	}
+	if err := ReconcileWorkload(context.Background(), c, s, w); err != nil {
+		t.Fatalf("ReconcileWorkload: %v", err)
+	}
+
+	var dep appsv1.Deployment
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "gw", Namespace: "default"}, &dep); err != nil {
+		t.Fatalf("Deployment not found: %v", err)
+	}
+	var got []string
+	for _, e := range dep.Spec.Template.Spec.Containers[0].Env {
+		if e.Name == LogLevelEnvVar {
+			got = append(got, e.Value)
+		}
+	}
+	if len(got) != 1 || got[0] != "DEBUG" {
+		t.Errorf("%s: want exactly [DEBUG], got %v", LogLevelEnvVar, got)
+	}
+	if w.Env[0].Value != "INFO" {
+		t.Errorf("caller Env must not be mutated, got %v", w.Env)
+	}
+}
+
+func TestReconcileWorkload_LeavesReplicasToOtherManagers(t *testing.T) {
+	s := workloadScheme(t)
+	owner := newOwner("gw", "default")
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(owner).Build()
+	ctx := context.Background()
+
+	w := GatewayWorkload{
+		Name: "gw", Namespace: "default", Owner: owner,
+		ContainerPort: 4000, ServicePort: 80,
+		ConfigYAML: "model_list: []\n",
+	}
+	if err := ReconcileWorkload(ctx, c, s, w); err != nil {
+		t.Fatalf("ReconcileWorkload: %v", err)
+	}
+
+	key := types.NamespacedName{Name: "gw", Namespace: "default"}
+	var dep appsv1.Deployment
+	if err := c.Get(ctx, key, &dep); err != nil {
+		t.Fatalf("Deployment not found: %v", err)
+	}
+	scaled := dep.DeepCopy()
+	scaled.Spec.Replicas = ptr.To(int32(3))
+	if err := c.Patch(ctx, scaled, client.MergeFrom(&dep), client.FieldOwner("hpa")); err != nil {
+		t.Fatalf("scale: %v", err)
+	}
+
+	if err := ReconcileWorkload(ctx, c, s, w); err != nil {
+		t.Fatalf("second ReconcileWorkload: %v", err)
+	}
+	if err := c.Get(ctx, key, &dep); err != nil {
+		t.Fatalf("Deployment not found: %v", err)
+	}
+	if dep.Spec.Replicas == nil || *dep.Spec.Replicas != 3 {
+		t.Errorf("replicas: want 3 (owned by hpa), got %v", dep.Spec.Replicas)
+	}
+}
+
+func TestReconcileWorkload_SuspendScalesToZero(t *testing.T) {
+	s := workloadScheme(t)
+	owner := newOwner("gw", "default")
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(owner).Build()
+	ctx := context.Background()
+
+	w := GatewayWorkload{
+		Name: "gw", Namespace: "default", Owner: owner,
+		ContainerPort: 4000, ServicePort: 80,
+		ConfigYAML: "model_list: []\n",
+	}
+	if err := ReconcileWorkload(ctx, c, s, w); err != nil {
+		t.Fatalf("ReconcileWorkload: %v", err)
+	}
+	key := types.NamespacedName{Name: "gw", Namespace: "default"}
+	var dep appsv1.Deployment
+	if err := c.Get(ctx, key, &dep); err != nil {
+		t.Fatalf("Deployment not found: %v", err)
+	}
+	scaled := dep.DeepCopy()
+	scaled.Spec.Replicas = ptr.To(int32(3))
+	if err := c.Patch(ctx, scaled, client.MergeFrom(&dep), client.FieldOwner("hpa")); err != nil {
+		t.Fatalf("scale: %v", err)
+	}
+
+	w.Suspended = true
+	if err := ReconcileWorkload(ctx, c, s, w); err != nil {
+		t.Fatalf("suspended ReconcileWorkload: %v", err)
+	}
+	if err := c.Get(ctx, key, &dep); err != nil {
+		t.Fatalf("Deployment not found: %v", err)
+	}
+	if dep.Spec.Replicas == nil || *dep.Spec.Replicas != 0 {
+		t.Errorf("replicas: want 0 while suspended, got %v", dep.Spec.Replicas)
+	}
+	if err := c.Get(ctx, types.NamespacedName{Name: "gw-config", Namespace: "default"}, &corev1.ConfigMap{}); err != nil {
+		t.Errorf("ConfigMap must stay while suspended: %v", err)
+	}
+	if err := c.Get(ctx, key, &corev1.Service{}); err != nil {
+		t.Errorf("Service must stay while suspended: %v", err)
+	}
+}
+
+func TestBuildDeployment_CarriesEnvSources(t *testing.T) {
+	w := GatewayWorkload{
+		Name: "gw", Namespace: "default", ContainerPort: 4000,
+		Env: []corev1.EnvVar{{
+			Name: "OPENAI_API_KEY",
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: ApiKeySecretName},
+				Key:                  "OPENAI_API_KEY",
+				Optional:             ptr.To(true),
+			}},
+		}},
+		EnvFrom: []corev1.EnvFromSource{{
+			ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "extra"}},
+		}},
+	}
+	dep := BuildDeployment(w, metav1ac.OwnerReference().WithName("gw"), "c", "s")
+	container := dep.Spec.Template.Spec.Containers[0]
+
+	var ref *corev1ac.SecretKeySelectorApplyConfiguration
+	for _, e := range container.Env {
+		if *e.Name == "OPENAI_API_KEY" {
+			ref = e.ValueFrom.SecretKeyRef
+		}
+	}
+	if ref == nil || *ref.Name != ApiKeySecretName || *ref.Key != "OPENAI_API_KEY" || !*ref.Optional {
+		t.Errorf("secretKeyRef not carried over: %+v", ref)
+	}
+	if len(container.EnvFrom) != 1 || *container.EnvFrom[0].ConfigMapRef.Name != "extra" {
+		t.Errorf("envFrom not carried over: %+v", container.EnvFrom)
+	}
+	if dep.Spec.Replicas != nil {
+		t.Errorf("replicas must be left unset, got %d", *dep.Spec.Replicas)
+	}
+}
+
+func TestReconcileWorkload_Adoption(t *testing.T) {
+	otherOwner := metav1.OwnerReference{
+		APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "someone-else", UID: "other-uid", Controller: ptr.To(true),
+	}
+	cases := []struct {
+		name         string
+		labels       map[string]string
+		owners       []metav1.OwnerReference
+		wantConflict bool
+	}{
+		{name: "unowned with matching app label is adopted", labels: map[string]string{"app": "gw", "helm.sh/chart": "litellm"}},
+		{name: "unowned without app label conflicts", labels: map[string]string{"app.kubernetes.io/name": "litellm"}, wantConflict: true},
+		{name: "controlled by another owner conflicts", labels: map[string]string{"app": "gw"}, owners: []metav1.OwnerReference{otherOwner}, wantConflict: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := workloadScheme(t)
+			owner := newOwner("gw", "default")
+			existing := &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+				Name: "gw", Namespace: "default", Labels: tc.labels, OwnerReferences: tc.owners,
+			}}
+			c := fake.NewClientBuilder().WithScheme(s).WithObjects(owner, existing).Build()
+
+			w := GatewayWorkload{
+				Name: "gw", Namespace: "default", Owner: owner,
+				ContainerPort: 4000, ServicePort: 80,
+				ConfigYAML: "model_list: []\n",
+			}
+			err := ReconcileWorkload(context.Background(), c, s, w)
+
+			if tc.wantConflict {
+				ce, ok := errors.AsType[*ConflictError](err)
+				if !ok {
+					t.Fatalf("want *ConflictError, got %v", err)
+				}
+				if ce.Kind != "Service" || ce.Name != "gw" {
+					t.Errorf("ConflictError: got %+v", ce)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReconcileWorkload: %v", err)
+			}
+			var svc corev1.Service
+			if err := c.Get(context.Background(), types.NamespacedName{Name: "gw", Namespace: "default"}, &svc); err != nil {
+				t.Fatalf("Service not found: %v", err)
+			}
+			if ref := metav1.GetControllerOf(&svc); ref == nil || ref.UID != owner.UID {
+				t.Errorf("Service not adopted: owners %+v", svc.OwnerReferences)
+			}
+		})
+	}
+}
+
+func TestReconcileWorkload_ChecksUncachedObjectsViaAPIReader(t *testing.T) {
+	s := workloadScheme(t)
+	owner := newOwner("gw", "default")
+	// Unlabelled, so a cache filtered by ManagedSelector does not hold it.
+	existing := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "default"}}
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(owner).Build()
+	live := fake.NewClientBuilder().WithScheme(s).WithObjects(existing).Build()
+
+	w := GatewayWorkload{
+		Name: "gw", Namespace: "default", Owner: owner,
+		ContainerPort: 4000, ServicePort: 80,
+		ConfigYAML: "model_list: []\n",
+		APIReader:  live,
+	}
+	err := ReconcileWorkload(context.Background(), c, s, w)
+	if _, ok := errors.AsType[*ConflictError](err); !ok {
+		t.Fatalf("want *ConflictError for object only the API reader sees, got %v", err)
+	}
+}
+
+func TestIsDeploymentRolledOut(t *testing.T) {
+	deployment := func(replicas, updated, available int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Generation: 2},
+			Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(int32(2))},
+			Status: appsv1.DeploymentStatus{
+				ObservedGeneration: 2,
+				Replicas:           replicas,
+				UpdatedReplicas:    updated,
+				AvailableReplicas:  available,
+			},
+		}
+	}
+	cases := []struct {
+		name    string
+		d       *appsv1.Deployment
+		want    bool
+		wantMsg string
+	}{
+		{"old pods still serving", deployment(2, 1, 2), false, "1/2 replicas updated"},
+		{"old pods terminating", deployment(3, 2, 2), false, "1 old replicas pending termination"},
+		{"new pods not yet available", deployment(2, 2, 1), false, "1/2 replicas available"},
+		{"rolled out", deployment(2, 2, 2), true, ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, msg := IsDeploymentRolledOut(tc.d)
+			if got != tc.want || !strings.Contains(msg, tc.wantMsg) {
+				t.Errorf("got (%v, %q), want (%v, ...%q...)", got, msg, tc.want, tc.wantMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,282 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package operatorconfig loads the manager's configuration file, an
+// OperatorConfiguration, and keeps its feature gates current.
+package operatorconfig
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// APIVersion and Kind of the configuration file.
+const (
+	APIVersion = "config.litellm.agentic-layer.ai/v1alpha1"
+	Kind       = "OperatorConfiguration"
+)
+
+// Configuration is the manager's configuration file. Every setting except
+// FeatureGates stands for the manager flag named in its comment, and is
+// read once at startup; a flag given on the command line wins.
+type Configuration struct {
+	metav1.TypeMeta `json:",inline"`
+
+	Gateways          GatewayDefaults   `json:"gateways,omitempty"`
+	EgressProxy       EgressProxy       `json:"egressProxy,omitempty"`
+	ClusterAutoscaler ClusterAutoscaler `json:"clusterAutoscaler,omitempty"`
+	Controllers       Controllers       `json:"controllers,omitempty"`
+	Policy            Policy            `json:"policy,omitempty"`
+
+	// FeatureGates turns optional features on or off. Changes take effect
+	// without a restart; see Watcher.
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+}
+
+// GatewayDefaults are the defaults of every gateway.
+type GatewayDefaults struct {
+	// Image is --litellm-image.
+	Image string `json:"image,omitempty"`
+	// APIKeySecretName is --api-key-secret.
+	APIKeySecretName string `json:"apiKeySecretName,omitempty"`
+	// RequestTimeout is --request-timeout.
+	RequestTimeout *metav1.Duration `json:"requestTimeout,omitempty"`
+	// RegistryMirror is --registry-mirror.
+	RegistryMirror string `json:"registryMirror,omitempty"`
+	// ImageResolveInterval is --image-resolve-interval.
+	ImageResolveInterval *metav1.Duration `json:"imageResolveInterval,omitempty"`
+	// HealthCheckInterval is --health-check-interval.
+	HealthCheckInterval *metav1.Duration `json:"healthCheckInterval,omitempty"`
+	// SpendSyncInterval is --spend-sync-interval.
+	SpendSyncInterval *metav1.Duration `json:"spendSyncInterval,omitempty"`
+	// DNSDomain is --dns-domain.
+	DNSDomain string `json:"dnsDomain,omitempty"`
+	// OTLPEndpoint is --otlp-endpoint.
+	OTLPEndpoint string `json:"otlpEndpoint,omitempty"`
+	// PreflightInterval is --preflight-interval.
+	PreflightInterval *metav1.Duration `json:"preflightInterval,omitempty"`
+}
+
+// EgressProxy is the default egress proxy of the gateways.
+type EgressProxy struct {
+	// HTTPProxy is --http-proxy.
+	HTTPProxy string `json:"httpProxy,omitempty"`
+	// HTTPSProxy is --https-proxy.
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+	// NoProxy is --no-proxy.
+	NoProxy []string `json:"noProxy,omitempty"`
+	// ClusterCIDRs is --cluster-cidrs.
+	ClusterCIDRs []string `json:"clusterCIDRs,omitempty"`
+}
+
+// ClusterAutoscaler configures gateway pods for the cluster autoscaler.
+type ClusterAutoscaler struct {
+	// SafeToEvict is --safe-to-evict.
+	SafeToEvict *bool `json:"safeToEvict,omitempty"`
+	// PriorityClassName is --gateway-priority-class.
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+	// OverprovisioningReplicas is --overprovisioning-replicas.
+	OverprovisioningReplicas *int32 `json:"overprovisioningReplicas,omitempty"`
+	// OverprovisioningPriorityClassName is --overprovisioning-priority-class.
+	OverprovisioningPriorityClassName string `json:"overprovisioningPriorityClassName,omitempty"`
+}
+
+// Controllers configures what the controllers watch and how they pace
+// reconciles.
+type Controllers struct {
+	// WatchNamespace is --watch-namespace.
+	WatchNamespace string `json:"watchNamespace,omitempty"`
+	// GatewayLabelSelector is --gateway-label-selector.
+	GatewayLabelSelector string `json:"gatewayLabelSelector,omitempty"`
+	// MaxConcurrentReconciles is --max-concurrent-reconciles.
+	MaxConcurrentReconciles *int32 `json:"maxConcurrentReconciles,omitempty"`
+	// SyncPeriod is --sync-period.
+	SyncPeriod *metav1.Duration `json:"syncPeriod,omitempty"`
+	// ResyncInterval is --resync-interval.
+	ResyncInterval *metav1.Duration `json:"resyncInterval,omitempty"`
+	// CapabilityDetectionInterval is --capability-detection-interval.
+	CapabilityDetectionInterval *metav1.Duration `json:"capabilityDetectionInterval,omitempty"`
+	// DryRun is --dry-run.
+	DryRun *bool `json:"dryRun,omitempty"`
+	// ModelDiscovery is --enable-model-discovery.
+	ModelDiscovery *bool `json:"modelDiscovery,omitempty"`
+	// AgentIntegration is --enable-agent-integration.
+	AgentIntegration *bool `json:"agentIntegration,omitempty"`
+	// TenantGateway is --tenant-gateway.
+	TenantGateway string `json:"tenantGateway,omitempty"`
+	// ReplicationNamespace is --replication-namespace.
+	ReplicationNamespace string `json:"replicationNamespace,omitempty"`
+	// RateLimiter paces requeues of failed reconciles.
+	RateLimiter RateLimiter `json:"rateLimiter,omitempty"`
+	// KubeAPI limits the manager's requests to the API server.
+	KubeAPI KubeAPI `json:"kubeAPI,omitempty"`
+}
+
+// RateLimiter paces requeues of failed reconciles.
+type RateLimiter struct {
+	// BaseDelay is --rate-limiter-base-delay.
+	BaseDelay *metav1.Duration `json:"baseDelay,omitempty"`
+	// MaxDelay is --rate-limiter-max-delay.
+	MaxDelay *metav1.Duration `json:"maxDelay,omitempty"`
+	// QPS is --rate-limiter-qps.
+	QPS *float64 `json:"qps,omitempty"`
+	// Burst is --rate-limiter-burst.
+	Burst *int32 `json:"burst,omitempty"`
+}
+
+// KubeAPI limits the manager's requests to the API server.
+type KubeAPI struct {
+	// QPS is --kube-api-qps.
+	QPS *float64 `json:"qps,omitempty"`
+	// Burst is --kube-api-burst.
+	Burst *int32 `json:"burst,omitempty"`
+}
+
+// Policy is the admission policy of AiGateways.
+type Policy struct {
+	// MaxModels is --policy-max-models.
+	MaxModels *int32 `json:"maxModels,omitempty"`
+	// AllowedProviders is --policy-allowed-providers.
+	AllowedProviders []string `json:"allowedProviders,omitempty"`
+	// RequireBudget is --policy-require-budget.
+	RequireBudget *bool `json:"requireBudget,omitempty"`
+}
+
+// Load reads the configuration file at path. Unknown fields are errors, so
+// a misspelt setting is not silently ignored.
+func Load(path string) (*Configuration, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parse(data)
+}
+
+func parse(data []byte) (*Configuration, error) {
+	var c Configuration
+	if err := yaml.UnmarshalStrict(data, &c); err != nil {
+		return nil, fmt.Errorf("parsing the configuration file: %w", err)
+	}
+	if c.APIVersion != APIVersion || c.Kind != Kind {
+		return nil, fmt.Errorf("the configuration file must be a %s %s, got %s %s", APIVersion, Kind, c.APIVersion, c.Kind)
+	}
+	if _, err := (&FeatureGates{}).resolve(c.FeatureGates); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// Flags returns the manager flags c sets, by name.
+func (c *Configuration) Flags() map[string]string {
+	flags := map[string]string{}
+	str := func(name, v string) {
+		if v != "" {
+			flags[name] = v
+		}
+	}
+	list := func(name string, v []string) {
+		if len(v) > 0 {
+			flags[name] = strings.Join(v, ",")
+		}
+	}
+	duration := func(name string, v *metav1.Duration) {
+		if v != nil {
+			flags[name] = v.Duration.String()
+		}
+	}
+	str("litellm-image", c.Gateways.Image)
+	str("api-key-secret", c.Gateways.APIKeySecretName)
+	duration("request-timeout", c.Gateways.RequestTimeout)
+	str("registry-mirror", c.Gateways.RegistryMirror)
+	duration("image-resolve-interval", c.Gateways.ImageResolveInterval)
+	duration("health-check-interval", c.Gateways.HealthCheckInterval)
+	duration("spend-sync-interval", c.Gateways.SpendSyncInterval)
+	str("dns-domain", c.Gateways.DNSDomain)
+	str("otlp-endpoint", c.Gateways.OTLPEndpoint)
+	duration("preflight-interval", c.Gateways.PreflightInterval)
+
+	str("http-proxy", c.EgressProxy.HTTPProxy)
+	str("https-proxy", c.EgressProxy.HTTPSProxy)
+	list("no-proxy", c.EgressProxy.NoProxy)
+	list("cluster-cidrs", c.EgressProxy.ClusterCIDRs)
+
+	setFlag(flags, "safe-to-evict", c.ClusterAutoscaler.SafeToEvict)
+	str("gateway-priority-class", c.ClusterAutoscaler.PriorityClassName)
+	setFlag(flags, "overprovisioning-replicas", c.ClusterAutoscaler.OverprovisioningReplicas)
+	str("overprovisioning-priority-class", c.ClusterAutoscaler.OverprovisioningPriorityClassName)
+
+	str("watch-namespace", c.Controllers.WatchNamespace)
+	str("gateway-label-selector", c.Controllers.GatewayLabelSelector)
+	setFlag(flags, "max-concurrent-reconciles", c.Controllers.MaxConcurrentReconciles)
+	duration("sync-period", c.Controllers.SyncPeriod)
+	duration("resync-interval", c.Controllers.ResyncInterval)
+	duration("capability-detection-interval", c.Controllers.CapabilityDetectionInterval)
+	setFlag(flags, "dry-run", c.Controllers.DryRun)
+	setFlag(flags, "enable-model-discovery", c.Controllers.ModelDiscovery)
+	setFlag(flags, "enable-agent-integration", c.Controllers.AgentIntegration)
+	str("tenant-gateway", c.Controllers.TenantGateway)
+	str("replication-namespace", c.Controllers.ReplicationNamespace)
+	duration("rate-limiter-base-delay", c.Controllers.RateLimiter.BaseDelay)
+	duration("rate-limiter-max-delay", c.Controllers.RateLimiter.MaxDelay)
+	setFlag(flags, "rate-limiter-qps", c.Controllers.RateLimiter.QPS)
+	setFlag(flags, "rate-limiter-burst", c.Controllers.RateLimiter.Burst)
+	setFlag(flags, "kube-api-qps", c.Controllers.KubeAPI.QPS)
+	setFlag(flags, "kube-api-burst", c.Controllers.KubeAPI.Burst)
+
+	setFlag(flags, "policy-max-models", c.Policy.MaxModels)
+	list("policy-allowed-providers", c.Policy.AllowedProviders)
+	setFlag(flags, "policy-require-budget", c.Policy.RequireBudget)
+	return flags
+}
+
+// setFlag sets flags[name] to the value v points at, if any.
+func setFlag[T any](flags map[string]string, name string, v *T) {
+	if v != nil {
+		flags[name] = fmt.Sprint(*v)
+	}
+}
+
+// ApplyFlags sets the flags of fs that c sets and the command line did not,
+// so the command line wins over the file. fs must have been parsed.
+func ApplyFlags(fs *flag.FlagSet, c *Configuration) error {
+	onCommandLine := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { onCommandLine[f.Name] = true })
+	for name, value := range c.Flags() {
+		if onCommandLine[name] {
+			continue
+		}
+		if err := fs.Set(name, value); err != nil {
+			return fmt.Errorf("the configuration file sets --%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// equalIgnoringFeatureGates reports whether a and b differ only in their
+// feature gates, which take effect without a restart.
+func equalIgnoringFeatureGates(a, b *Configuration) bool {
+	aCopy, bCopy := *a, *b
+	aCopy.FeatureGates, bCopy.FeatureGates = nil, nil
+	aYAML, errA := yaml.Marshal(aCopy)
+	bYAML, errB := yaml.Marshal(bCopy)
+	return errA == nil && errB == nil && bytes.Equal(aYAML, bYAML)
+}
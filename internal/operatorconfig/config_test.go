@@ -0,0 +1,100 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operatorconfig
+
+import (
+	"flag"
+	"maps"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testConfig = `apiVersion: config.litellm.agentic-layer.ai/v1alpha1
+kind: OperatorConfiguration
+gateways:
+  image: registry.corp/litellm:v1
+  apiKeySecretName: provider-keys
+  requestTimeout: 2m
+egressProxy:
+  noProxy: [.corp, 10.0.0.0/8]
+controllers:
+  dryRun: true
+  rateLimiter:
+    qps: 2.5
+featureGates:
+  Canary: false
+`
+
+func TestParse(t *testing.T) {
+	c, err := parse([]byte(testConfig))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	want := map[string]string{
+		"litellm-image":    "registry.corp/litellm:v1",
+		"api-key-secret":   "provider-keys",
+		"request-timeout":  "2m0s",
+		"no-proxy":         ".corp,10.0.0.0/8",
+		"dry-run":          "true",
+		"rate-limiter-qps": "2.5",
+	}
+	if got := c.Flags(); !maps.Equal(got, want) {
+		t.Errorf("Flags() = %v, want %v", got, want)
+	}
+	if !maps.Equal(c.FeatureGates, map[string]bool{"Canary": false}) {
+		t.Errorf("FeatureGates = %v", c.FeatureGates)
+	}
+
+	for name, data := range map[string]string{
+		"unknown field": testConfig + "imagePolicy: latest\n",
+		"wrong kind":    strings.Replace(testConfig, "OperatorConfiguration", "Configuration", 1),
+		"unknown gate":  testConfig + "  ServiceMonitor: true\n",
+	} {
+		if _, err := parse([]byte(data)); err == nil {
+			t.Errorf("%s: want an error", name)
+		}
+	}
+}
+
+func TestApplyFlags(t *testing.T) {
+	fs := flag.NewFlagSet("manager", flag.ContinueOnError)
+	image := fs.String("litellm-image", "default", "")
+	secret := fs.String("api-key-secret", "api-key-secrets", "")
+	timeout := fs.Duration("request-timeout", 10*time.Minute, "")
+	dryRun := fs.Bool("dry-run", false, "")
+	fs.String("no-proxy", "", "")
+	fs.Float64("rate-limiter-qps", 10, "")
+	if err := fs.Parse([]string{"--litellm-image=cli"}); err != nil {
+		t.Fatal(err)
+	}
+	c, err := parse([]byte(testConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ApplyFlags(fs, c); err != nil {
+		t.Fatalf("ApplyFlags: %v", err)
+	}
+	if *image != "cli" || *secret != "provider-keys" || *timeout != 2*time.Minute || !*dryRun {
+		t.Errorf("got image %q, secret %q, timeout %v, dry run %v", *image, *secret, *timeout, *dryRun)
+	}
+
+	c.Controllers.TenantGateway = "team-a/gateway"
+	if err := ApplyFlags(fs, c); err == nil {
+		t.Error("a setting without its flag must be an error")
+	}
+}
@@ -0,0 +1,235 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operatorconfig
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Feature is an optional feature an operator may turn off.
+type Feature string
+
+// Features behind a gate. All are enabled by default.
+const (
+	// FeatureCanary allows progressive rollouts: blue/green, Argo Rollouts
+	// and Flagger. Without it, gateways roll out as a rolling update.
+	FeatureCanary Feature = "Canary"
+	// FeatureIngress allows the Ingress of the admin UI.
+	FeatureIngress Feature = "Ingress"
+	// FeatureInferencePool allows exposing gateways as InferencePools.
+	FeatureInferencePool Feature = "InferencePool"
+)
+
+// defaultFeatures are the known features and whether they are enabled by
+// default.
+var defaultFeatures = map[Feature]bool{
+	FeatureCanary:        true,
+	FeatureIngress:       true,
+	FeatureInferencePool: true,
+}
+
+// DefaultWatchInterval is how often a Watcher reads the configuration file.
+const DefaultWatchInterval = 10 * time.Second
+
+// FeatureGates holds which features are enabled. The zero value enables the
+// defaults. It is safe for concurrent use.
+type FeatureGates struct {
+	mu      sync.RWMutex
+	enabled map[Feature]bool
+	once    sync.Once
+	changes chan event.GenericEvent
+}
+
+// Enabled reports whether f is enabled. A nil FeatureGates enables the
+// defaults.
+func (g *FeatureGates) Enabled(f Feature) bool {
+	if g == nil {
+		return defaultFeatures[f]
+	}
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	if g.enabled == nil {
+		return defaultFeatures[f]
+	}
+	return g.enabled[f]
+}
+
+// Set enables the defaults overridden by each of gates in turn, keyed by
+// feature name. Unknown features are an error and leave g unchanged. When
+// the enabled features change, an event is sent on Changes.
+func (g *FeatureGates) Set(gates ...map[string]bool) error {
+	enabled, err := g.resolve(gates...)
+	if err != nil {
+		return err
+	}
+	g.mu.Lock()
+	changed := g.enabled != nil && !maps.Equal(enabled, g.enabled)
+	g.enabled = enabled
+	g.mu.Unlock()
+	if changed {
+		// A pending event already covers this change.
+		select {
+		case g.changesChannel() <- event.GenericEvent{}:
+		default:
+		}
+	}
+	return nil
+}
+
+func (g *FeatureGates) resolve(gates ...map[string]bool) (map[Feature]bool, error) {
+	enabled := maps.Clone(defaultFeatures)
+	for _, overrides := range gates {
+		for name, on := range overrides {
+			if _, ok := defaultFeatures[Feature(name)]; !ok {
+				return nil, fmt.Errorf("unknown feature gate %q: must be one of %s", name, strings.Join(knownFeatures(), ", "))
+			}
+			enabled[Feature(name)] = on
+		}
+	}
+	return enabled, nil
+}
+
+// String lists the features and whether they are enabled, sorted by name.
+func (g *FeatureGates) String() string {
+	var gates []string
+	for _, f := range knownFeatures() {
+		gates = append(gates, f+"="+strconv.FormatBool(g.Enabled(Feature(f))))
+	}
+	return strings.Join(gates, ",")
+}
+
+// Changes returns the channel an event is sent on whenever the enabled
+// features change, for a controller to re-reconcile what depends on them.
+// Events carry no object and are coalesced while one is pending.
+func (g *FeatureGates) Changes() <-chan event.GenericEvent {
+	return g.changesChannel()
+}
+
+func (g *FeatureGates) changesChannel() chan event.GenericEvent {
+	g.once.Do(func() { g.changes = make(chan event.GenericEvent, 1) })
+	return g.changes
+}
+
+func knownFeatures() []string {
+	var names []string
+	for f := range defaultFeatures {
+		names = append(names, string(f))
+	}
+	slices.Sort(names)
+	return names
+}
+
+// ParseFeatureGates parses a --feature-gates value, comma-separated
+// Feature=true|false pairs.
+func ParseFeatureGates(s string) (map[string]bool, error) {
+	gates := map[string]bool{}
+	for pair := range strings.SplitSeq(s, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(pair, "=")
+		on, err := strconv.ParseBool(value)
+		if !ok || err != nil {
+			return nil, fmt.Errorf("invalid feature gate %q: must be Feature=true or Feature=false", pair)
+		}
+		gates[strings.TrimSpace(name)] = on
+	}
+	if _, err := (&FeatureGates{}).resolve(gates); err != nil {
+		return nil, err
+	}
+	return gates, nil
+}
+
+// Watcher keeps Gates in line with the feature gates of the configuration
+// file at Path, read every Interval, with Overrides winning. Other changes
+// to the file are logged and take effect on restart. It is a manager
+// Runnable, run by every replica.
+type Watcher struct {
+	Path     string
+	Interval time.Duration
+	Gates    *FeatureGates
+	// Overrides are the --feature-gates of the command line.
+	Overrides map[string]bool
+	// Loaded is the configuration the manager started with.
+	Loaded *Configuration
+
+	last []byte
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable: every
+// replica reconciles with its own feature gates.
+func (w *Watcher) NeedLeaderElection() bool { return false }
+
+// Start reads the file every Interval until ctx is done. A file that cannot
+// be read or parsed is logged and keeps the current gates.
+func (w *Watcher) Start(ctx context.Context) error {
+	log := logf.FromContext(ctx).WithName("operatorconfig")
+	interval := w.Interval
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := w.reload(ctx); err != nil {
+				log.Error(err, "Failed to reload the configuration file", "path", w.Path)
+			}
+		}
+	}
+}
+
+// reload applies the feature gates of the file if it changed since the last
+// read.
+func (w *Watcher) reload(ctx context.Context) error {
+	data, err := os.ReadFile(w.Path)
+	if err != nil || bytes.Equal(data, w.last) {
+		return err
+	}
+	c, err := parse(data)
+	if err != nil {
+		return err
+	}
+	w.last = data
+	log := logf.FromContext(ctx).WithName("operatorconfig")
+	if w.Loaded != nil && !equalIgnoringFeatureGates(w.Loaded, c) {
+		log.Info("The configuration file changed; settings other than feature gates take effect on restart", "path", w.Path)
+	}
+	before := w.Gates.String()
+	if err := w.Gates.Set(c.FeatureGates, w.Overrides); err != nil {
+		return err
+	}
+	if after := w.Gates.String(); after != before {
+		log.Info("Feature gates changed", "featureGates", after)
+	}
+	return nil
+}
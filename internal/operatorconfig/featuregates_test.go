@@ -0,0 +1,108 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operatorconfig
+
+import (
+	"context"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseFeatureGates(t *testing.T) {
+	got, err := ParseFeatureGates(" Canary=false, Ingress=true,")
+	if err != nil || !maps.Equal(got, map[string]bool{"Canary": false, "Ingress": true}) {
+		t.Errorf("got %v, %v", got, err)
+	}
+	for _, s := range []string{"Canary", "Canary=maybe", "ServiceMonitor=true"} {
+		if _, err := ParseFeatureGates(s); err == nil {
+			t.Errorf("%q: want an error", s)
+		}
+	}
+}
+
+func TestFeatureGates_Set(t *testing.T) {
+	var nilGates *FeatureGates
+	if !nilGates.Enabled(FeatureCanary) {
+		t.Error("nil gates must enable the defaults")
+	}
+
+	gates := &FeatureGates{}
+	if err := gates.Set(map[string]bool{"Canary": false}); err != nil {
+		t.Fatal(err)
+	}
+	if gates.Enabled(FeatureCanary) || !gates.Enabled(FeatureIngress) {
+		t.Errorf("got %s", gates)
+	}
+	if len(gates.Changes()) != 0 {
+		t.Error("the first Set must not send a change")
+	}
+
+	// The later map wins.
+	if err := gates.Set(map[string]bool{"Canary": false, "Ingress": false}, map[string]bool{"Canary": true}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := gates.String(), "Canary=true,InferencePool=true,Ingress=false"; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+	if len(gates.Changes()) != 1 {
+		t.Error("a change must be sent")
+	}
+
+	if err := gates.Set(map[string]bool{"Unknown": true}); err == nil || gates.Enabled(FeatureIngress) {
+		t.Errorf("an unknown gate must be an error and keep the gates, got %v, %s", err, gates)
+	}
+}
+
+func TestWatcher_Reload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	write := func(data string) {
+		if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(testConfig)
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gates := &FeatureGates{}
+	overrides := map[string]bool{"InferencePool": false}
+	if err := gates.Set(loaded.FeatureGates, overrides); err != nil {
+		t.Fatal(err)
+	}
+	w := &Watcher{Path: path, Gates: gates, Overrides: overrides, Loaded: loaded}
+	ctx := context.Background()
+
+	write(strings.Replace(testConfig, "Canary: false", "Canary: true\n  InferencePool: true\n  Ingress: false", 1))
+	if err := w.reload(ctx); err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if got, want := gates.String(), "Canary=true,InferencePool=false,Ingress=false"; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+
+	write(testConfig + "  Unknown: true\n")
+	if err := w.reload(ctx); err == nil {
+		t.Error("an invalid file must be an error")
+	}
+	if !gates.Enabled(FeatureCanary) {
+		t.Error("an invalid file must keep the gates")
+	}
+}
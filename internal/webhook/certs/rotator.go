@@ -0,0 +1,398 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package certs issues and rotates the webhook serving certificate without
+// cert-manager.
+package certs
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Keys of the certificate Secret. The serving pair and CA use the keys
+// cert-manager writes, so the Secret can be mounted the same way.
+const (
+	CACertKey = "ca.crt"
+	CAKeyKey  = "ca.key"
+	// PreviousCACertKey holds the CA replaced by the last CA rotation. It
+	// stays in the CA bundle until it expires, so replicas still serving a
+	// certificate it signed are trusted.
+	PreviousCACertKey = "ca-previous.crt"
+)
+
+// Validity of the generated certificates. The serving certificate is
+// renewed once two thirds of its validity passed, the CA once it would
+// expire before a serving certificate issued now.
+const (
+	CAValidity   = 10 * 365 * 24 * time.Hour
+	CertValidity = 365 * 24 * time.Hour
+)
+
+// DefaultCheckInterval is how often a Rotator checks the Secret when its
+// CheckInterval is zero.
+const DefaultCheckInterval = time.Hour
+
+// retryInterval paces retries until the first certificate is loaded.
+const retryInterval = 10 * time.Second
+
+// +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=mutatingwebhookconfigurations;validatingwebhookconfigurations,verbs=get;list;watch;update;patch
+
+// Rotator keeps a self-signed CA and a serving certificate for Service in
+// the Secret, serves the certificate through GetCertificate, and injects the
+// CA into every webhook configuration calling Service. It is a manager
+// Runnable, run by every replica: each one loads the certificate from the
+// Secret, and whichever notices first renews it. Client must not be backed
+// by a cache that filters Secrets.
+type Rotator struct {
+	Client  client.Client
+	Secret  types.NamespacedName
+	Service types.NamespacedName
+	// CheckInterval is how often the Secret is checked; zero uses
+	// DefaultCheckInterval.
+	CheckInterval time.Duration
+	// Now returns the current time; nil uses time.Now.
+	Now func() time.Time
+
+	cert atomic.Pointer[tls.Certificate]
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable: every
+// replica serves webhooks.
+func (r *Rotator) NeedLeaderElection() bool { return false }
+
+// Start ensures the certificate, retrying until it is loaded, then checks
+// it again every CheckInterval until ctx is done.
+func (r *Rotator) Start(ctx context.Context) error {
+	log := logf.FromContext(ctx).WithName("webhook-certs")
+	interval := r.CheckInterval
+	if interval <= 0 {
+		interval = DefaultCheckInterval
+	}
+	for {
+		wait := interval
+		if err := r.Ensure(ctx); err != nil {
+			log.Error(err, "Failed to ensure the webhook certificate", "secret", r.Secret)
+			if r.cert.Load() == nil {
+				wait = retryInterval
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(wait):
+		}
+	}
+}
+
+// GetCertificate serves the loaded certificate, for tls.Config.
+func (r *Rotator) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	cert := r.cert.Load()
+	if cert == nil {
+		return nil, errors.New("webhook certificate not loaded yet")
+	}
+	return cert, nil
+}
+
+// ReadyCheck fails until a certificate is loaded, for a readiness probe.
+func (r *Rotator) ReadyCheck(*http.Request) error {
+	if r.cert.Load() == nil {
+		return errors.New("webhook certificate not loaded yet")
+	}
+	return nil
+}
+
+// Ensure creates or renews the certificates in the Secret as needed, loads
+// the serving certificate and injects the CA bundle into the webhook
+// configurations.
+func (r *Rotator) Ensure(ctx context.Context) error {
+	secret, err := r.ensureSecret(ctx)
+	if err != nil {
+		return err
+	}
+	cert, err := tls.X509KeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return fmt.Errorf("loading the serving certificate: %w", err)
+	}
+	r.cert.Store(&cert)
+	return r.injectCABundle(ctx, caBundle(secret, r.now()))
+}
+
+// ensureSecret returns the Secret, after creating or renewing what it holds.
+// A concurrent write by another replica is retried against its result.
+func (r *Rotator) ensureSecret(ctx context.Context) (*corev1.Secret, error) {
+	for attempt := 0; ; attempt++ {
+		secret := &corev1.Secret{}
+		err := r.Client.Get(ctx, r.Secret, secret)
+		switch {
+		case apierrors.IsNotFound(err):
+			secret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: r.Secret.Name, Namespace: r.Secret.Namespace},
+				Type:       corev1.SecretTypeTLS,
+			}
+		case err != nil:
+			return nil, err
+		}
+		changed, err := r.renew(secret)
+		if err != nil || !changed {
+			return secret, err
+		}
+		if secret.ResourceVersion == "" {
+			err = r.Client.Create(ctx, secret)
+		} else {
+			err = r.Client.Update(ctx, secret)
+		}
+		if err == nil {
+			logf.FromContext(ctx).Info("Issued a webhook certificate", "secret", r.Secret)
+			return secret, nil
+		}
+		if (!apierrors.IsAlreadyExists(err) && !apierrors.IsConflict(err)) || attempt == 2 {
+			return nil, fmt.Errorf("writing %s: %w", r.Secret, err)
+		}
+	}
+}
+
+// renew replaces the CA and serving certificate in secret when they are
+// missing, invalid or due for renewal, and reports whether it did.
+func (r *Rotator) renew(secret *corev1.Secret) (bool, error) {
+	now := r.now()
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	ca, caKey, err := parsePair(secret.Data[CACertKey], secret.Data[CAKeyKey])
+	caChanged := err != nil || now.Add(CertValidity).After(ca.NotAfter)
+	if caChanged {
+		if err == nil && now.Before(ca.NotAfter) {
+			secret.Data[PreviousCACertKey] = secret.Data[CACertKey]
+		}
+		ca, caKey, secret.Data[CACertKey], secret.Data[CAKeyKey], err = newCA(r.Service, now)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	cert, _, err := parsePair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+	if !caChanged && err == nil && cert.CheckSignatureFrom(ca) == nil &&
+		slices.Equal(cert.DNSNames, dnsNames(r.Service)) &&
+		now.Before(cert.NotBefore.Add(cert.NotAfter.Sub(cert.NotBefore)*2/3)) {
+		return false, nil
+	}
+	secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey], err = newServingCert(r.Service, ca, caKey, now)
+	return err == nil, err
+}
+
+// injectCABundle sets bundle on every webhook calling Service.
+func (r *Rotator) injectCABundle(ctx context.Context, bundle []byte) error {
+	var mutating admissionregistrationv1.MutatingWebhookConfigurationList
+	if err := r.Client.List(ctx, &mutating); err != nil {
+		return err
+	}
+	for i := range mutating.Items {
+		config := &mutating.Items[i]
+		original := config.DeepCopy()
+		changed := false
+		for j := range config.Webhooks {
+			changed = r.setCABundle(&config.Webhooks[j].ClientConfig, bundle) || changed
+		}
+		if err := r.patch(ctx, changed, config, original); err != nil {
+			return err
+		}
+	}
+	var validating admissionregistrationv1.ValidatingWebhookConfigurationList
+	if err := r.Client.List(ctx, &validating); err != nil {
+		return err
+	}
+	for i := range validating.Items {
+		config := &validating.Items[i]
+		original := config.DeepCopy()
+		changed := false
+		for j := range config.Webhooks {
+			changed = r.setCABundle(&config.Webhooks[j].ClientConfig, bundle) || changed
+		}
+		if err := r.patch(ctx, changed, config, original); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Rotator) setCABundle(config *admissionregistrationv1.WebhookClientConfig, bundle []byte) bool {
+	if config.Service == nil || config.Service.Name != r.Service.Name || config.Service.Namespace != r.Service.Namespace ||
+		bytes.Equal(config.CABundle, bundle) {
+		return false
+	}
+	config.CABundle = bundle
+	return true
+}
+
+func (r *Rotator) patch(ctx context.Context, changed bool, obj, original client.Object) error {
+	if !changed {
+		return nil
+	}
+	patch := client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{})
+	if err := r.Client.Patch(ctx, obj, patch); err != nil {
+		return fmt.Errorf("injecting the CA bundle into %s: %w", obj.GetName(), err)
+	}
+	return nil
+}
+
+func (r *Rotator) now() time.Time {
+	if r.Now != nil {
+		return r.Now()
+	}
+	return time.Now()
+}
+
+// caBundle returns the CA of secret, followed by the previous CA while it
+// is valid.
+func caBundle(secret *corev1.Secret, now time.Time) []byte {
+	bundle := slices.Clone(secret.Data[CACertKey])
+	if previous, err := parseCert(secret.Data[PreviousCACertKey]); err == nil && now.Before(previous.NotAfter) {
+		bundle = append(bundle, secret.Data[PreviousCACertKey]...)
+	}
+	return bundle
+}
+
+// dnsNames are the names Service is reached under.
+func dnsNames(service types.NamespacedName) []string {
+	return []string{
+		service.Name,
+		service.Name + "." + service.Namespace,
+		service.Name + "." + service.Namespace + ".svc",
+		service.Name + "." + service.Namespace + ".svc.cluster.local",
+	}
+}
+
+func newCA(service types.NamespacedName, now time.Time) (*x509.Certificate, *ecdsa.PrivateKey, []byte, []byte, error) {
+	template := &x509.Certificate{
+		Subject:               pkix.Name{CommonName: service.Name + "-ca"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(CAValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	certPEM, keyPEM, err := issue(template, nil, nil)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	ca, key, err := parsePair(certPEM, keyPEM)
+	return ca, key, certPEM, keyPEM, err
+}
+
+func newServingCert(service types.NamespacedName, ca *x509.Certificate, caKey *ecdsa.PrivateKey, now time.Time) ([]byte, []byte, error) {
+	names := dnsNames(service)
+	template := &x509.Certificate{
+		Subject:     pkix.Name{CommonName: names[2]},
+		DNSNames:    names,
+		NotBefore:   now.Add(-time.Hour),
+		NotAfter:    now.Add(CertValidity),
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	return issue(template, ca, caKey)
+}
+
+// issue signs template with a new key, by parent, or by itself when parent
+// is nil, and returns the PEM encoded certificate and key.
+func issue(template, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) ([]byte, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, err
+	}
+	template.SerialNumber = serial
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), nil
+}
+
+func parseCert(certPEM []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("no PEM encoded certificate")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+func parsePair(certPEM, keyPEM []byte) (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	cert, err := parseCert(certPEM)
+	if err != nil {
+		return nil, nil, err
+	}
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, nil, errors.New("no PEM encoded key")
+	}
+	key, err := x509.ParseECPrivateKey(block.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !key.PublicKey.Equal(cert.PublicKey) {
+		return nil, nil, errors.New("key does not match the certificate")
+	}
+	return cert, key, nil
+}
+
+// Namespace returns the namespace the operator runs in: POD_NAMESPACE, or
+// the namespace of the mounted service account token.
+func Namespace() (string, error) {
+	if ns := os.Getenv("POD_NAMESPACE"); ns != "" {
+		return ns, nil
+	}
+	raw, err := os.ReadFile("/var/run/secrets/kubernetes.io/serviceaccount/namespace")
+	if err != nil {
+		return "", fmt.Errorf("set POD_NAMESPACE: %w", err)
+	}
+	return strings.TrimSpace(string(raw)), nil
+}
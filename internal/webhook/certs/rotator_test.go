@@ -0,0 +1,157 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certs
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func webhookConfigs(service string) (*admissionregistrationv1.MutatingWebhookConfiguration, *admissionregistrationv1.ValidatingWebhookConfiguration) {
+	clientConfig := admissionregistrationv1.WebhookClientConfig{
+		Service: &admissionregistrationv1.ServiceReference{Namespace: "system", Name: service},
+	}
+	return &admissionregistrationv1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: service + "-mutating"},
+		Webhooks:   []admissionregistrationv1.MutatingWebhook{{Name: "m.example.com", ClientConfig: clientConfig}},
+	}, &admissionregistrationv1.ValidatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: service + "-validating"},
+		Webhooks:   []admissionregistrationv1.ValidatingWebhook{{Name: "v.example.com", ClientConfig: clientConfig}},
+	}
+}
+
+func TestRotator_Ensure(t *testing.T) {
+	ctx := context.Background()
+	s := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	mutating, validating := webhookConfigs("webhook")
+	otherMutating, _ := webhookConfigs("other")
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(mutating, validating, otherMutating).Build()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	r := &Rotator{
+		Client:  c,
+		Secret:  types.NamespacedName{Namespace: "system", Name: "webhook-cert"},
+		Service: types.NamespacedName{Namespace: "system", Name: "webhook"},
+		Now:     func() time.Time { return now },
+	}
+	if r.ReadyCheck(nil) == nil {
+		t.Error("the rotator must not be ready before the certificate is loaded")
+	}
+
+	ensure := func() (*corev1.Secret, []byte) {
+		t.Helper()
+		if err := r.Ensure(ctx); err != nil {
+			t.Fatalf("Ensure: %v", err)
+		}
+		var secret corev1.Secret
+		if err := c.Get(ctx, r.Secret, &secret); err != nil {
+			t.Fatalf("get Secret: %v", err)
+		}
+		if err := c.Get(ctx, client.ObjectKeyFromObject(mutating), mutating); err != nil {
+			t.Fatal(err)
+		}
+		if err := c.Get(ctx, client.ObjectKeyFromObject(validating), validating); err != nil {
+			t.Fatal(err)
+		}
+		bundle := mutating.Webhooks[0].ClientConfig.CABundle
+		if !bytes.Equal(validating.Webhooks[0].ClientConfig.CABundle, bundle) {
+			t.Error("both webhook configurations must get the same CA bundle")
+		}
+		return &secret, bundle
+	}
+	verify := func(bundle []byte) *x509.Certificate {
+		t.Helper()
+		cert, err := r.GetCertificate(nil)
+		if err != nil {
+			t.Fatalf("GetCertificate: %v", err)
+		}
+		leaf, err := x509.ParseCertificate(cert.Certificate[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		roots := x509.NewCertPool()
+		roots.AppendCertsFromPEM(bundle)
+		if _, err := leaf.Verify(x509.VerifyOptions{Roots: roots, DNSName: "webhook.system.svc", CurrentTime: now}); err != nil {
+			t.Errorf("the serving certificate must verify against the CA bundle: %v", err)
+		}
+		return leaf
+	}
+
+	secret, bundle := ensure()
+	if secret.Type != corev1.SecretTypeTLS || !bytes.Equal(bundle, secret.Data[CACertKey]) {
+		t.Errorf("secret type = %s, bundle = %q", secret.Type, bundle)
+	}
+	if err := r.ReadyCheck(nil); err != nil {
+		t.Errorf("ReadyCheck: %v", err)
+	}
+	leaf := verify(bundle)
+	if err := c.Get(ctx, client.ObjectKeyFromObject(otherMutating), otherMutating); err != nil {
+		t.Fatal(err)
+	}
+	if otherMutating.Webhooks[0].ClientConfig.CABundle != nil {
+		t.Error("webhooks of another Service must be left alone")
+	}
+
+	again, _ := ensure()
+	if again.ResourceVersion != secret.ResourceVersion {
+		t.Error("a valid certificate must not be reissued")
+	}
+
+	// Past two thirds of its lifetime, the serving certificate is renewed
+	// by the same CA.
+	now = now.Add(CertValidity * 3 / 4)
+	renewed, renewedBundle := ensure()
+	if !bytes.Equal(renewedBundle, bundle) || bytes.Equal(renewed.Data[corev1.TLSCertKey], secret.Data[corev1.TLSCertKey]) {
+		t.Error("the serving certificate must be renewed, the CA kept")
+	}
+	if verify(renewedBundle).SerialNumber.Cmp(leaf.SerialNumber) == 0 {
+		t.Error("the renewed certificate must be served")
+	}
+
+	// Within a serving certificate's lifetime of its expiry, the CA is
+	// replaced and the old one stays trusted until it expires.
+	now = now.Add(CAValidity - CertValidity)
+	rotated, rotatedBundle := ensure()
+	if bytes.Equal(rotated.Data[CACertKey], secret.Data[CACertKey]) ||
+		!bytes.Equal(rotated.Data[PreviousCACertKey], secret.Data[CACertKey]) {
+		t.Fatal("the CA must be rotated and the previous one kept")
+	}
+	if !bytes.Contains(rotatedBundle, rotated.Data[CACertKey]) || !bytes.Contains(rotatedBundle, secret.Data[CACertKey]) {
+		t.Error("the CA bundle must hold the new and the previous CA")
+	}
+	verify(rotatedBundle)
+
+	now = now.Add(CertValidity)
+	_, expiredBundle := ensure()
+	if bytes.Contains(expiredBundle, secret.Data[CACertKey]) {
+		t.Error("an expired previous CA must be dropped from the bundle")
+	}
+}
@@ -0,0 +1,552 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/controller"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/litellm"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var aigatewaylog = logf.Log.WithName("aigateway-resource")
+
+// SetupAiGatewayWebhookWithManager registers the webhook for AiGateway in the
+// manager, enforcing policy on top of the built-in checks. Provider API keys
+// are looked up in the Secret apiKeySecretName. Agents using a gateway are
+// looked up in namespaces, or in all namespaces when it is empty.
+func SetupAiGatewayWebhookWithManager(mgr ctrl.Manager, policy Policy, apiKeySecretName string,
+	namespaces []string) error {
+	return ctrl.NewWebhookManagedBy(mgr, &gatewayv1alpha1.AiGateway{}).
+		WithDefaulter(&AiGatewayCustomDefaulter{Client: mgr.GetClient()}).
+		WithValidator(&AiGatewayCustomValidator{
+			Client:           mgr.GetClient(),
+			APIReader:        mgr.GetAPIReader(),
+			Policy:           policy,
+			APIKeySecretName: apiKeySecretName,
+			Namespaces:       namespaces,
+		}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-runtime-agentic-layer-ai-v1alpha1-aigateway,mutating=true,failurePolicy=fail,sideEffects=None,groups=runtime.agentic-layer.ai,resources=aigateways,verbs=create;update,versions=v1alpha1,name=maigateway-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// AiGatewayCustomDefaulter sets default values on AiGateways handled by this
+// operator when they are created or updated.
+//
+// NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
+// as it is used only for temporary operations and does not need to be deeply copied.
+type AiGatewayCustomDefaulter struct {
+	// Client lists AiGatewayClasses to decide whether a gateway is ours.
+	Client client.Reader
+}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the Kind AiGateway.
+func (d *AiGatewayCustomDefaulter) Default(ctx context.Context, aigateway *gatewayv1alpha1.AiGateway) error {
+	aigatewaylog.Info("Defaulting for AiGateway", "name", aigateway.GetName())
+
+	owned, err := litellm.IsAiGatewayOwnedByController(ctx, d.Client, aigateway, controller.ControllerName)
+	if err != nil {
+		return fmt.Errorf("determining AiGateway class ownership: %w", err)
+	}
+	if owned {
+		d.applyDefaults(aigateway)
+	}
+	return nil
+}
+
+// applyDefaults applies default values to the AiGateway.
+func (d *AiGatewayCustomDefaulter) applyDefaults(aigateway *gatewayv1alpha1.AiGateway) {
+	aigateway.Spec.AiModels = normalizeModels(aigateway.Spec.AiModels)
+}
+
+// normalizeModels trims whitespace around providers and names, lowercases
+// providers and drops repeated entries, so specs that only differ in spelling
+// render the same config and config-hash. Model names keep their case: some
+// providers' model IDs are case-sensitive. LiteLLM-style "provider/model"
+// names without a provider field are split as well:
+// {name: openai/gpt-4o} becomes {provider: openai, name: gpt-4o}.
+func normalizeModels(models []gatewayv1alpha1.AiModel) []gatewayv1alpha1.AiModel {
+	if models == nil {
+		return nil
+	}
+	type modelKey struct{ provider, name string }
+	seen := make(map[modelKey]bool, len(models))
+	normalized := make([]gatewayv1alpha1.AiModel, 0, len(models))
+	for _, model := range models {
+		model.Provider = strings.ToLower(strings.TrimSpace(model.Provider))
+		model.Name = strings.TrimSpace(model.Name)
+		if model.Provider == "" {
+			if provider, name, ok := strings.Cut(model.Name, "/"); ok && name != "" &&
+				litellm.KnownProviders.Has(strings.ToLower(provider)) {
+				model.Provider, model.Name = strings.ToLower(provider), name
+			}
+		}
+		key := modelKey{model.Provider, model.Name}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		normalized = append(normalized, model)
+	}
+	return normalized
+}
+
+// NOTE: The 'path' attribute must follow a specific pattern and should not be modified directly here.
+// Modifying the path for an invalid path can cause API server errors; failing to locate the webhook.
+// +kubebuilder:webhook:path=/validate-runtime-agentic-layer-ai-v1alpha1-aigateway,mutating=false,failurePolicy=fail,sideEffects=None,groups=runtime.agentic-layer.ai,resources=aigateways,verbs=create;update;delete,versions=v1alpha1,name=vaigateway-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// AiGatewayCustomValidator validates AiGateways handled by this operator when
+// they are created or updated. Gateways of another controller's class are
+// admitted unchanged.
+//
+// NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
+// as this struct is used only for temporary operations and does not need to be deeply copied.
+type AiGatewayCustomValidator struct {
+	// Client lists AiGatewayClasses to decide whether a gateway is ours.
+	Client client.Reader
+
+	// APIReader looks up Agents and Namespaces, and the workload
+	// objects checked for conflicts. They are read uncached: the operator
+	// does not watch Agents or Namespaces, and its cache only holds workload
+	// objects it already manages.
+	APIReader client.Reader
+
+	// Policy is enforced on every create and update.
+	Policy Policy
+
+	// APIKeySecretName is the Secret provider API keys are read from. Empty
+	// uses litellm.ApiKeySecretName.
+	APIKeySecretName string
+
+	// Namespaces are the namespaces Agents are looked up in before a gateway
+	// is deleted, the --watch-namespace list. Empty looks in all namespaces.
+	Namespaces []string
+}
+
+// AllowUnknownProviderAnnotation set to "true" on an AiGateway admits
+// providers missing from litellm.KnownProviders, e.g. ones added in a newer
+// LiteLLM release than the pinned image, with a warning instead of an error.
+const AllowUnknownProviderAnnotation = "ai-gateway-litellm.agentic-layer.ai/allow-unknown-provider"
+
+// ProtectedAnnotation set to "true" on an AiGateway makes the webhook reject
+// its deletion until the annotation is removed.
+const ProtectedAnnotation = "ai-gateway-litellm.agentic-layer.ai/protected"
+
+// +kubebuilder:rbac:groups=runtime.agentic-layer.ai,resources=agents,verbs=get;list
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type AiGateway.
+func (v *AiGatewayCustomValidator) ValidateCreate(ctx context.Context, aigateway *gatewayv1alpha1.AiGateway) (admission.Warnings, error) {
+	aigatewaylog.Info("Validation for AiGateway upon creation", "name", aigateway.GetName())
+
+	return v.validateIfOwned(ctx, aigateway, true)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type AiGateway.
+func (v *AiGatewayCustomValidator) ValidateUpdate(ctx context.Context, oldAiGateway, newAiGateway *gatewayv1alpha1.AiGateway) (admission.Warnings, error) {
+	aigatewaylog.Info("Validation for AiGateway upon update", "name", newAiGateway.GetName())
+
+	if err := v.validateClassChange(ctx, oldAiGateway, newAiGateway); err != nil {
+		return nil, err
+	}
+	return v.validateIfOwned(ctx, newAiGateway, false)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type AiGateway.
+func (v *AiGatewayCustomValidator) ValidateDelete(ctx context.Context, aigateway *gatewayv1alpha1.AiGateway) (admission.Warnings, error) {
+	aigatewaylog.Info("Validation for AiGateway upon deletion", "name", aigateway.GetName())
+
+	owned, err := litellm.IsAiGatewayOwnedByController(ctx, v.Client, aigateway, controller.ControllerName)
+	if err != nil {
+		return nil, fmt.Errorf("determining AiGateway class ownership: %w", err)
+	}
+	if !owned {
+		return nil, nil
+	}
+	if aigateway.Annotations[ProtectedAnnotation] == "true" {
+		return nil, field.ErrorList{field.Forbidden(
+			field.NewPath("metadata", "annotations").Key(ProtectedAnnotation),
+			"the AiGateway is protected against deletion; remove the annotation first")}.ToAggregate()
+	}
+	return nil, v.validateNotInUse(ctx, aigateway)
+}
+
+// validateNotInUse rejects deleting a gateway that Agents still send model
+// traffic to. It gives way when the gateway's namespace is terminating, so
+// namespace deletion cannot get stuck on the order in which objects go. An
+// operator not allowed to read Namespaces, as in a namespace-scoped install
+// without the extra ClusterRole rule, takes the namespace as not terminating.
+func (v *AiGatewayCustomValidator) validateNotInUse(ctx context.Context, aigateway *gatewayv1alpha1.AiGateway) error {
+	var ns corev1.Namespace
+	err := v.APIReader.Get(ctx, client.ObjectKey{Name: aigateway.Namespace}, &ns)
+	switch {
+	case apierrors.IsForbidden(err):
+		aigatewaylog.V(1).Info("Cannot read namespace, assuming it is not terminating",
+			"namespace", aigateway.Namespace, "error", err.Error())
+	case err != nil:
+		return fmt.Errorf("getting namespace %s: %w", aigateway.Namespace, err)
+	case ns.DeletionTimestamp != nil:
+		return nil
+	}
+
+	agents, err := v.listAgents(ctx)
+	if err != nil {
+		return err
+	}
+	var users []string
+	for _, agent := range agents {
+		if agent.DeletionTimestamp != nil {
+			continue
+		}
+		if refersTo(agent.Spec.AiGatewayRef, agent.Namespace, aigateway) ||
+			refersTo(agent.Status.AiGatewayRef, agent.Namespace, aigateway) {
+			users = append(users, agent.Namespace+"/"+agent.Name)
+		}
+	}
+	if len(users) == 0 {
+		return nil
+	}
+	return apierrors.NewForbidden(gatewayv1alpha1.GroupVersion.WithResource("aigateways").GroupResource(),
+		aigateway.Name, fmt.Errorf("still used by Agents %s; point them to another AiGateway first",
+			strings.Join(users, ", ")))
+}
+
+// listAgents returns the Agents in v.Namespaces, or in all namespaces when
+// none are set.
+func (v *AiGatewayCustomValidator) listAgents(ctx context.Context) ([]gatewayv1alpha1.Agent, error) {
+	if len(v.Namespaces) == 0 {
+		var agents gatewayv1alpha1.AgentList
+		if err := v.APIReader.List(ctx, &agents); err != nil {
+			return nil, fmt.Errorf("listing Agents: %w", err)
+		}
+		return agents.Items, nil
+	}
+	var all []gatewayv1alpha1.Agent
+	for _, ns := range v.Namespaces {
+		var agents gatewayv1alpha1.AgentList
+		if err := v.APIReader.List(ctx, &agents, client.InNamespace(ns)); err != nil {
+			return nil, fmt.Errorf("listing Agents in namespace %s: %w", ns, err)
+		}
+		all = append(all, agents.Items...)
+	}
+	return all, nil
+}
+
+// refersTo reports whether ref, written in an object of namespace ns, names gw.
+func refersTo(ref *corev1.ObjectReference, ns string, gw *gatewayv1alpha1.AiGateway) bool {
+	if ref == nil || ref.Name != gw.Name {
+		return false
+	}
+	if ref.Namespace != "" {
+		ns = ref.Namespace
+	}
+	return ns == gw.Namespace
+}
+
+func (v *AiGatewayCustomValidator) validateIfOwned(ctx context.Context, aigateway *gatewayv1alpha1.AiGateway, create bool) (admission.Warnings, error) {
+	owned, err := litellm.IsAiGatewayOwnedByController(ctx, v.Client, aigateway, controller.ControllerName)
+	if err != nil {
+		return nil, fmt.Errorf("determining AiGateway class ownership: %w", err)
+	}
+	if !owned {
+		return nil, nil
+	}
+	warnings, err := ValidateAiGateway(aigateway)
+	if err != nil {
+		return warnings, err
+	}
+	if err := v.validatePolicy(ctx, aigateway); err != nil {
+		return warnings, err
+	}
+	conflictWarnings, err := v.validateNoConflicts(ctx, aigateway, create)
+	warnings = append(warnings, conflictWarnings...)
+	return append(warnings, v.secretWarnings(ctx, aigateway)...), err
+}
+
+// validateNoConflicts reports existing objects under the names the gateway's
+// workload would use that the reconciler cannot adopt (see
+// litellm.FindConflicts), e.g. a hand-written Service or the Deployment of a
+// ToolGateway of the same name. Creating a gateway is rejected when such an
+// object is controlled by something else; an unlabelled object, which the
+// user can label for adoption, and any conflict on update only warn.
+func (v *AiGatewayCustomValidator) validateNoConflicts(ctx context.Context, aigateway *gatewayv1alpha1.AiGateway, create bool) (admission.Warnings, error) {
+	conflicts, err := litellm.FindConflicts(ctx, v.APIReader, aigateway)
+	if err != nil {
+		return nil, fmt.Errorf("checking for conflicting objects: %w", err)
+	}
+	var warnings admission.Warnings
+	var allErrs field.ErrorList
+	for _, conflict := range conflicts {
+		if create && conflict.Controlled {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("metadata", "name"), conflict.Error()))
+			continue
+		}
+		warnings = append(warnings, conflict.Error())
+	}
+	return warnings, allErrs.ToAggregate()
+}
+
+// secretWarnings reports Secrets and Secret keys the gateway's env would read
+// but that do not exist. All references are optional or resolved at pod
+// start, so a typo would otherwise only surface as failing model calls or a
+// pod stuck in CreateContainerConfigError. Lookup errors other than NotFound
+// (e.g. a namespace outside the cache) are ignored.
+func (v *AiGatewayCustomValidator) secretWarnings(ctx context.Context, aigateway *gatewayv1alpha1.AiGateway) admission.Warnings {
+	type lookupResult struct {
+		secret *corev1.Secret
+		found  bool
+	}
+	secrets := map[string]lookupResult{}
+	lookup := func(name string) (*corev1.Secret, bool) {
+		if r, ok := secrets[name]; ok {
+			return r.secret, r.found
+		}
+		var r lookupResult
+		s := &corev1.Secret{}
+		if err := v.Client.Get(ctx, client.ObjectKey{Namespace: aigateway.Namespace, Name: name}, s); err == nil {
+			r = lookupResult{secret: s, found: true}
+		} else {
+			r.found = !apierrors.IsNotFound(err)
+		}
+		secrets[name] = r
+		return r.secret, r.found
+	}
+	hasKey := func(s *corev1.Secret, key string) bool {
+		if s == nil {
+			return true // lookup failed for another reason; do not guess
+		}
+		_, inData := s.Data[key]
+		_, inStringData := s.StringData[key]
+		return inData || inStringData
+	}
+
+	var warnings admission.Warnings
+	userEnv := sets.New[string]()
+	for i, e := range aigateway.Spec.Env {
+		userEnv.Insert(e.Name)
+		if e.ValueFrom == nil || e.ValueFrom.SecretKeyRef == nil {
+			continue
+		}
+		ref := e.ValueFrom.SecretKeyRef
+		path := field.NewPath("spec", "env").Index(i)
+		s, ok := lookup(ref.Name)
+		switch {
+		case !ok:
+			warnings = append(warnings, fmt.Sprintf("%s: Secret %s not found", path, ref.Name))
+		case !hasKey(s, ref.Key):
+			warnings = append(warnings, fmt.Sprintf("%s: Secret %s has no key %s", path, ref.Name, ref.Key))
+		}
+	}
+	for i, src := range aigateway.Spec.EnvFrom {
+		if src.SecretRef == nil {
+			continue
+		}
+		if _, ok := lookup(src.SecretRef.Name); !ok {
+			warnings = append(warnings, fmt.Sprintf("%s: Secret %s not found",
+				field.NewPath("spec", "envFrom").Index(i), src.SecretRef.Name))
+		}
+	}
+
+	apiKeySecretName := cmp.Or(v.APIKeySecretName, litellm.ApiKeySecretName)
+	// An invalid class config is reported on the gateway's status instead.
+	if classConfig, err := litellm.ResolveClassConfig(ctx, v.Client, aigateway, controller.ControllerName); err == nil && classConfig != nil {
+		apiKeySecretName = cmp.Or(classConfig.APIKeySecretName, apiKeySecretName)
+	}
+	var missingKeys []string
+	for _, model := range aigateway.Spec.AiModels {
+		key := strings.ToUpper(model.Provider) + "_API_KEY"
+		if litellm.KeylessProviders.Has(model.Provider) || userEnv.Has(key) || slices.Contains(missingKeys, key) {
+			continue
+		}
+		s, ok := lookup(apiKeySecretName)
+		if !ok {
+			return append(warnings, fmt.Sprintf(
+				"Secret %s not found; models will be called without API keys", apiKeySecretName))
+		}
+		if !hasKey(s, key) {
+			missingKeys = append(missingKeys, key)
+		}
+	}
+	if len(missingKeys) > 0 {
+		warnings = append(warnings, fmt.Sprintf("Secret %s has no key %s; models of these providers will be called without an API key",
+			apiKeySecretName, strings.Join(missingKeys, ", ")))
+	}
+	return warnings
+}
+
+// validateClassChange rejects a spec.aiGatewayClassName change that hands the
+// gateway to a different controller: the old controller would leave its
+// workload behind and the new one would find it owned by the same gateway.
+// Switching between classes of the same controller, or away from a class no
+// controller serves (e.g. a typo), is allowed.
+func (v *AiGatewayCustomValidator) validateClassChange(ctx context.Context, oldGw, newGw *gatewayv1alpha1.AiGateway) error {
+	if oldGw.Spec.AiGatewayClassName == newGw.Spec.AiGatewayClassName {
+		return nil
+	}
+	var classList gatewayv1alpha1.AiGatewayClassList
+	if err := v.Client.List(ctx, &classList); err != nil {
+		return fmt.Errorf("listing AiGatewayClasses: %w", err)
+	}
+	oldController := responsibleController(classList.Items, oldGw.Spec.AiGatewayClassName)
+	newController := responsibleController(classList.Items, newGw.Spec.AiGatewayClassName)
+	if oldController == "" || oldController == newController {
+		return nil
+	}
+	return field.ErrorList{field.Forbidden(field.NewPath("spec", "aiGatewayClassName"), fmt.Sprintf(
+		"cannot move the gateway from controller %s to %q; delete and recreate it with the new class instead",
+		oldController, newController))}.ToAggregate()
+}
+
+// responsibleController returns the controller serving className, or for an
+// empty className the controller of the default class (preferring this
+// operator when several classes are marked default). "" means no controller.
+func responsibleController(classes []gatewayv1alpha1.AiGatewayClass, className string) string {
+	fallback := ""
+	for _, cls := range classes {
+		if className != "" {
+			if cls.Name == className {
+				return cls.Spec.Controller
+			}
+			continue
+		}
+		if cls.Annotations[litellm.AiGatewayClassDefaultAnnotation] != "true" {
+			continue
+		}
+		if cls.Spec.Controller == controller.ControllerName {
+			return cls.Spec.Controller
+		}
+		if fallback == "" {
+			fallback = cls.Spec.Controller
+		}
+	}
+	return fallback
+}
+
+// ValidateAiGateway rejects specs the reconciler would fail on or LiteLLM
+// would not serve, and warns about env settings that are likely mistakes.
+// Unlike the validator, it needs no cluster access: policy, conflicts and
+// Secrets are not checked.
+func ValidateAiGateway(aigateway *gatewayv1alpha1.AiGateway) (admission.Warnings, error) {
+	var allErrs field.ErrorList
+	spec := field.NewPath("spec")
+
+	if p := aigateway.Spec.Port; p < 1 || p > 65535 {
+		allErrs = append(allErrs, field.Invalid(spec.Child("port"), p, "must be between 1 and 65535"))
+	}
+
+	var warnings admission.Warnings
+	allowUnknown := aigateway.Annotations[AllowUnknownProviderAnnotation] == "true"
+	type modelKey struct{ provider, name string }
+	seen := make(map[modelKey]int, len(aigateway.Spec.AiModels))
+	for i, model := range aigateway.Spec.AiModels {
+		path := spec.Child("aiModels").Index(i)
+		switch {
+		case litellm.KnownProviders.Has(model.Provider):
+		case allowUnknown:
+			warnings = append(warnings, fmt.Sprintf("%s: provider %q is not known to this operator; admitted because of the %s annotation",
+				path.Child("provider"), model.Provider, AllowUnknownProviderAnnotation))
+		default:
+			allErrs = append(allErrs, field.NotSupported(path.Child("provider"), model.Provider,
+				sets.List(litellm.KnownProviders)))
+		}
+		key := modelKey{model.Provider, model.Name}
+		if first, ok := seen[key]; ok {
+			allErrs = append(allErrs, field.Duplicate(path, fmt.Sprintf("%s/%s (same as aiModels[%d])",
+				model.Provider, model.Name, first)))
+			continue
+		}
+		seen[key] = i
+	}
+
+	if _, err := litellm.ParseLogLevel(aigateway.Annotations); err != nil {
+		allErrs = append(allErrs, field.Invalid(
+			field.NewPath("metadata", "annotations").Key(litellm.LogLevelAnnotation),
+			aigateway.Annotations[litellm.LogLevelAnnotation],
+			"must be one of "+strings.Join(litellm.LogLevels, ", ")))
+	}
+
+	warnings = append(warnings, envWarnings(aigateway.Spec.Env, aigateway.Annotations)...)
+
+	if len(allErrs) > 0 {
+		return warnings, allErrs.ToAggregate()
+	}
+	return warnings, nil
+}
+
+// credentialSuffixes mark env var names that hold credentials and belong in a
+// Secret rather than in plain text on the AiGateway.
+var credentialSuffixes = []string{"_API_KEY", "_SECRET", "_TOKEN", "_PASSWORD", "_MASTER_KEY"}
+
+// envWarnings flags env entries that are accepted but probably not what the
+// user meant.
+func envWarnings(env []corev1.EnvVar, annotations map[string]string) admission.Warnings {
+	var warnings admission.Warnings
+	seen := make(map[string]bool, len(env))
+	for i, e := range env {
+		path := field.NewPath("spec", "env").Index(i)
+		if seen[e.Name] {
+			warnings = append(warnings, fmt.Sprintf("%s: %s is set more than once; only the last value is used", path, e.Name))
+		}
+		seen[e.Name] = true
+
+		if e.Value != "" && isCredentialName(e.Name) {
+			warnings = append(warnings, fmt.Sprintf(
+				"%s: %s is set as a plain value; reference a Secret with valueFrom.secretKeyRef instead", path, e.Name))
+		}
+		switch e.Name {
+		case "PROMETHEUS_MULTIPROC_DIR":
+			if e.Value != litellm.PrometheusMultiprocDir {
+				warnings = append(warnings, fmt.Sprintf(
+					"%s: PROMETHEUS_MULTIPROC_DIR must stay %s, where the operator mounts the metrics volume",
+					path, litellm.PrometheusMultiprocDir))
+			}
+		case litellm.LogLevelEnvVar:
+			if _, ok := annotations[litellm.LogLevelAnnotation]; ok {
+				warnings = append(warnings, fmt.Sprintf("%s: %s is overridden by the %s annotation",
+					path, litellm.LogLevelEnvVar, litellm.LogLevelAnnotation))
+			}
+		}
+	}
+	return warnings
+}
+
+func isCredentialName(name string) bool {
+	upper := strings.ToUpper(name)
+	if upper == litellm.DatabaseURLEnvVar {
+		return true
+	}
+	for _, suffix := range credentialSuffixes {
+		if strings.HasSuffix(upper, suffix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,447 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/controller"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/litellm"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func newValidator(t *testing.T, classes ...client.Object) *AiGatewayCustomValidator {
+	t.Helper()
+	s := runtime.NewScheme()
+	if err := gatewayv1alpha1.AddToScheme(s); err != nil {
+		t.Fatalf("AddToScheme: %v", err)
+	}
+	if err := corev1.AddToScheme(s); err != nil {
+		t.Fatalf("corev1: %v", err)
+	}
+	if err := appsv1.AddToScheme(s); err != nil {
+		t.Fatalf("appsv1: %v", err)
+	}
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(classes...).Build()
+	return &AiGatewayCustomValidator{Client: c, APIReader: c}
+}
+
+func defaultClass() *gatewayv1alpha1.AiGatewayClass {
+	return &gatewayv1alpha1.AiGatewayClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "litellm",
+			Annotations: map[string]string{litellm.AiGatewayClassDefaultAnnotation: "true"},
+		},
+		Spec: gatewayv1alpha1.AiGatewayClassSpec{Controller: controller.ControllerName},
+	}
+}
+
+func apiKeySecret(keys ...string) *corev1.Secret {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: litellm.ApiKeySecretName, Namespace: "default"},
+		Data:       map[string][]byte{},
+	}
+	for _, k := range keys {
+		secret.Data[k] = []byte("sk-test")
+	}
+	return secret
+}
+
+func validGateway() *gatewayv1alpha1.AiGateway {
+	return &gatewayv1alpha1.AiGateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "default"},
+		Spec: gatewayv1alpha1.AiGatewaySpec{
+			Port: 80,
+			AiModels: []gatewayv1alpha1.AiModel{
+				{Provider: "openai", Name: "gpt-4o"},
+				{Provider: "anthropic", Name: "claude-sonnet-4"},
+			},
+		},
+	}
+}
+
+func TestAiGatewayValidator_AcceptsValidGateway(t *testing.T) {
+	v := newValidator(t, defaultClass(), apiKeySecret("OPENAI_API_KEY", "ANTHROPIC_API_KEY"))
+	warnings, err := v.ValidateCreate(context.Background(), validGateway())
+	if err != nil {
+		t.Fatalf("ValidateCreate: %v", err)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("unexpected warnings: %v", warnings)
+	}
+}
+
+func TestAiGatewayValidator_Rejects(t *testing.T) {
+	cases := []struct {
+		name   string
+		mutate func(*gatewayv1alpha1.AiGateway)
+		want   string
+	}{
+		{
+			name:   "unknown provider",
+			mutate: func(gw *gatewayv1alpha1.AiGateway) { gw.Spec.AiModels[0].Provider = "opneai" },
+			want:   `spec.aiModels[0].provider: Unsupported value: "opneai"`,
+		},
+		{
+			name:   "port out of range",
+			mutate: func(gw *gatewayv1alpha1.AiGateway) { gw.Spec.Port = 70000 },
+			want:   "spec.port: Invalid value: 70000",
+		},
+		{
+			name: "duplicate model",
+			mutate: func(gw *gatewayv1alpha1.AiGateway) {
+				gw.Spec.AiModels = append(gw.Spec.AiModels, gw.Spec.AiModels[0])
+			},
+			want: "spec.aiModels[2]: Duplicate value",
+		},
+		{
+			name: "invalid log level",
+			mutate: func(gw *gatewayv1alpha1.AiGateway) {
+				gw.Annotations = map[string]string{litellm.LogLevelAnnotation: "verbose"}
+			},
+			want: `metadata.annotations[` + litellm.LogLevelAnnotation + `]: Invalid value: "verbose"`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			gw := validGateway()
+			tc.mutate(gw)
+			_, err := newValidator(t, defaultClass()).ValidateUpdate(context.Background(), validGateway(), gw)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("err = %v, want it to contain %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestAiGatewayValidator_AllowUnknownProviderAnnotation(t *testing.T) {
+	gw := validGateway()
+	gw.Annotations = map[string]string{AllowUnknownProviderAnnotation: "true"}
+	gw.Spec.AiModels[0].Provider = "brand_new_ai"
+	v := newValidator(t, defaultClass(), apiKeySecret("BRAND_NEW_AI_API_KEY", "ANTHROPIC_API_KEY"))
+	warnings, err := v.ValidateCreate(context.Background(), gw)
+	if err != nil {
+		t.Fatalf("ValidateCreate: %v", err)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], `provider "brand_new_ai" is not known`) {
+		t.Errorf("want one unknown-provider warning, got %v", warnings)
+	}
+}
+
+func TestAiGatewayValidator_SkipsGatewaysOfOtherControllers(t *testing.T) {
+	gw := validGateway()
+	gw.Spec.AiModels[0].Provider = "in-house"
+	if _, err := newValidator(t).ValidateCreate(context.Background(), gw); err != nil {
+		t.Errorf("gateway without a class of this controller must be admitted, got %v", err)
+	}
+}
+
+func TestAiGatewayValidator_WarnsOnSuspiciousEnv(t *testing.T) {
+	gw := validGateway()
+	gw.Annotations = map[string]string{litellm.LogLevelAnnotation: "DEBUG"}
+	gw.Spec.Env = []corev1.EnvVar{
+		{Name: "OPENAI_API_KEY", Value: "sk-plain"},
+		{Name: "LITELLM_LOG", Value: "INFO"},
+		{Name: "PROMETHEUS_MULTIPROC_DIR", Value: "/tmp"},
+		{Name: "FEATURE", Value: "a"},
+		{Name: "FEATURE", Value: "b"},
+	}
+	warnings, err := newValidator(t, defaultClass()).ValidateCreate(context.Background(), gw)
+	if err != nil {
+		t.Fatalf("ValidateCreate: %v", err)
+	}
+	joined := strings.Join(warnings, "\n")
+	for _, want := range []string{
+		"spec.env[0]: OPENAI_API_KEY is set as a plain value",
+		"spec.env[1]: LITELLM_LOG is overridden",
+		"spec.env[2]: PROMETHEUS_MULTIPROC_DIR must stay",
+		"spec.env[4]: FEATURE is set more than once",
+	} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("missing warning %q in:\n%s", want, joined)
+		}
+	}
+}
+
+func TestAiGatewayValidator_ClassChange(t *testing.T) {
+	otherClass := &gatewayv1alpha1.AiGatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "other"},
+		Spec:       gatewayv1alpha1.AiGatewayClassSpec{Controller: "example.com/other-controller"},
+	}
+	secondOwnClass := &gatewayv1alpha1.AiGatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "litellm-large"},
+		Spec:       gatewayv1alpha1.AiGatewayClassSpec{Controller: controller.ControllerName},
+	}
+	cases := []struct {
+		name     string
+		from, to string
+		wantErr  bool
+	}{
+		{name: "default to explicit own class", from: "", to: "litellm"},
+		{name: "between own classes", from: "litellm", to: "litellm-large"},
+		{name: "away from a class nobody serves", from: "typo", to: "litellm"},
+		{name: "to another controller", from: "litellm", to: "other", wantErr: true},
+		{name: "from another controller", from: "other", to: "litellm", wantErr: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			oldGw, newGw := validGateway(), validGateway()
+			oldGw.Spec.AiGatewayClassName = tc.from
+			newGw.Spec.AiGatewayClassName = tc.to
+			v := newValidator(t, defaultClass(), secondOwnClass, otherClass)
+			_, err := v.ValidateUpdate(context.Background(), oldGw, newGw)
+			if tc.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "spec.aiGatewayClassName: Forbidden") {
+					t.Errorf("want Forbidden error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("ValidateUpdate: %v", err)
+			}
+		})
+	}
+}
+
+func TestAiGatewayValidator_WarnsOnMissingSecrets(t *testing.T) {
+	gw := validGateway()
+	gw.Spec.AiModels = append(gw.Spec.AiModels, gatewayv1alpha1.AiModel{Provider: "ollama", Name: "llama3"})
+	gw.Spec.Env = []corev1.EnvVar{{
+		Name: "LITELLM_MASTER_KEY",
+		ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: litellm.ApiKeySecretName},
+			Key:                  "MASTER_KEY",
+		}},
+	}}
+	gw.Spec.EnvFrom = []corev1.EnvFromSource{{
+		SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "extra"}},
+	}}
+
+	warnings, err := newValidator(t, defaultClass(), apiKeySecret("OPENAI_API_KEY")).ValidateCreate(context.Background(), gw)
+	if err != nil {
+		t.Fatalf("ValidateCreate: %v", err)
+	}
+	want := []string{
+		"spec.env[0]: Secret api-key-secrets has no key MASTER_KEY",
+		"spec.envFrom[0]: Secret extra not found",
+		"Secret api-key-secrets has no key ANTHROPIC_API_KEY; models of these providers will be called without an API key",
+	}
+	if strings.Join(warnings, "\n") != strings.Join(want, "\n") {
+		t.Errorf("warnings:\n%s\nwant:\n%s", strings.Join(warnings, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestAiGatewayValidator_Conflicts(t *testing.T) {
+	controlled := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+		Name: "gw", Namespace: "default",
+		OwnerReferences: []metav1.OwnerReference{{
+			APIVersion: "runtime.agentic-layer.ai/v1alpha1", Kind: "ToolGateway", Name: "gw",
+			UID: "other", Controller: ptr.To(true),
+		}},
+	}}
+	unlabelled := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "default"}}
+	adoptable := &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+		Name: "gw", Namespace: "default", Labels: map[string]string{"app": "gw"},
+	}}
+
+	tests := []struct {
+		name         string
+		object       client.Object
+		update       bool
+		wantErr      string
+		wantWarnings int
+	}{
+		{name: "controlled object rejects create", object: controlled, wantErr: "is controlled by ToolGateway gw"},
+		{name: "controlled object warns on update", object: controlled, update: true, wantWarnings: 1},
+		{name: "unlabelled object warns", object: unlabelled, wantWarnings: 1},
+		{name: "adoptable object passes", object: adoptable},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			v := newValidator(t, defaultClass(), apiKeySecret("OPENAI_API_KEY", "ANTHROPIC_API_KEY"), tc.object)
+			var warnings []string
+			var err error
+			if tc.update {
+				warnings, err = v.ValidateUpdate(context.Background(), validGateway(), validGateway())
+			} else {
+				warnings, err = v.ValidateCreate(context.Background(), validGateway())
+			}
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("want error containing %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(warnings) != tc.wantWarnings {
+				t.Errorf("want %d warnings, got %v", tc.wantWarnings, warnings)
+			}
+		})
+	}
+}
+
+func TestAiGatewayValidator_Delete(t *testing.T) {
+	agentUsing := func(name, refNamespace string) *gatewayv1alpha1.Agent {
+		return &gatewayv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "agents"},
+			Status: gatewayv1alpha1.AgentStatus{
+				AiGatewayRef: &corev1.ObjectReference{Name: "gw", Namespace: refNamespace},
+			},
+		}
+	}
+	ns := func(terminating bool) *corev1.Namespace {
+		n := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
+		if terminating {
+			now := metav1.Now()
+			n.DeletionTimestamp = &now
+			n.Finalizers = []string{"kubernetes"}
+		}
+		return n
+	}
+	cases := []struct {
+		name       string
+		protected  bool
+		namespaces []string
+		objects    []client.Object
+		want       string
+	}{
+		{name: "unused", objects: []client.Object{ns(false)}},
+		{name: "protected", protected: true, objects: []client.Object{ns(false)}, want: "protected against deletion"},
+		{name: "used by an agent", objects: []client.Object{ns(false), agentUsing("a1", "default")}, want: "still used by Agents agents/a1"},
+		{name: "agent refers to a same-named gateway elsewhere", objects: []client.Object{ns(false), agentUsing("a1", "")}},
+		{name: "namespace terminating", objects: []client.Object{ns(true), agentUsing("a1", "default")}},
+		{name: "agent in a watched namespace", namespaces: []string{"agents"},
+			objects: []client.Object{ns(false), agentUsing("a1", "default")}, want: "still used by Agents agents/a1"},
+		{name: "agent outside the watched namespaces", namespaces: []string{"default"},
+			objects: []client.Object{ns(false), agentUsing("a1", "default")}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			gw := validGateway()
+			if tc.protected {
+				gw.Annotations = map[string]string{ProtectedAnnotation: "true"}
+			}
+			v := newValidator(t, defaultClass())
+			v.APIReader = newValidator(t, tc.objects...).Client
+			v.Namespaces = tc.namespaces
+			_, err := v.ValidateDelete(context.Background(), gw)
+			if tc.want == "" {
+				if err != nil {
+					t.Errorf("ValidateDelete: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("err = %v, want it to contain %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestAiGatewayValidator_DeleteNamespaceForbidden(t *testing.T) {
+	// A namespace-scoped install may not read Namespaces: the gateway's
+	// namespace is then taken as not terminating and Agents are still checked.
+	forbidNamespaces := func(objects ...client.Object) client.Reader {
+		return interceptor.NewClient(newValidator(t, objects...).Client.(client.WithWatch), interceptor.Funcs{
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object,
+				opts ...client.GetOption) error {
+				if _, ok := obj.(*corev1.Namespace); ok {
+					return apierrors.NewForbidden(corev1.Resource("namespaces"), key.Name, errors.New("no ClusterRole"))
+				}
+				return c.Get(ctx, key, obj, opts...)
+			},
+		})
+	}
+	agent := &gatewayv1alpha1.Agent{
+		ObjectMeta: metav1.ObjectMeta{Name: "a1", Namespace: "default"},
+		Spec:       gatewayv1alpha1.AgentSpec{AiGatewayRef: &corev1.ObjectReference{Name: "gw"}},
+	}
+	v := newValidator(t, defaultClass())
+	v.Namespaces = []string{"default"}
+
+	v.APIReader = forbidNamespaces()
+	if _, err := v.ValidateDelete(context.Background(), validGateway()); err != nil {
+		t.Errorf("ValidateDelete: %v", err)
+	}
+
+	v.APIReader = forbidNamespaces(agent)
+	_, err := v.ValidateDelete(context.Background(), validGateway())
+	if err == nil || !strings.Contains(err.Error(), "still used by Agents default/a1") {
+		t.Errorf("err = %v, want the Agent check to run", err)
+	}
+}
+
+func TestAiGatewayDefaulter_SplitsProviderPrefix(t *testing.T) {
+	gw := validGateway()
+	gw.Spec.AiModels = []gatewayv1alpha1.AiModel{
+		{Name: "openai/gpt-4o"},
+		{Name: "meta-llama/Llama-3"},
+		{Provider: "huggingface", Name: "meta-llama/Llama-3"},
+	}
+	d := &AiGatewayCustomDefaulter{Client: newValidator(t, defaultClass()).Client}
+	if err := d.Default(context.Background(), gw); err != nil {
+		t.Fatalf("Default: %v", err)
+	}
+	want := []gatewayv1alpha1.AiModel{
+		{Provider: "openai", Name: "gpt-4o"},
+		{Name: "meta-llama/Llama-3"},
+		{Provider: "huggingface", Name: "meta-llama/Llama-3"},
+	}
+	for i := range want {
+		if gw.Spec.AiModels[i] != want[i] {
+			t.Errorf("aiModels[%d] = %+v, want %+v", i, gw.Spec.AiModels[i], want[i])
+		}
+	}
+}
+
+func TestAiGatewayDefaulter_NormalizesModels(t *testing.T) {
+	gw := validGateway()
+	gw.Spec.AiModels = []gatewayv1alpha1.AiModel{
+		{Provider: " OpenAI ", Name: " gpt-4o\t"},
+		{Provider: "openai", Name: "gpt-4o"},
+		{Name: "Anthropic/claude-sonnet-4"},
+		{Provider: "anthropic", Name: "claude-sonnet-4"},
+		{Provider: "huggingface", Name: "meta-llama/Llama-3"},
+	}
+	d := &AiGatewayCustomDefaulter{Client: newValidator(t, defaultClass()).Client}
+	if err := d.Default(context.Background(), gw); err != nil {
+		t.Fatalf("Default: %v", err)
+	}
+	want := []gatewayv1alpha1.AiModel{
+		{Provider: "openai", Name: "gpt-4o"},
+		{Provider: "anthropic", Name: "claude-sonnet-4"},
+		{Provider: "huggingface", Name: "meta-llama/Llama-3"},
+	}
+	if !slices.Equal(gw.Spec.AiModels, want) {
+		t.Errorf("aiModels = %+v, want %+v", gw.Spec.AiModels, want)
+	}
+}
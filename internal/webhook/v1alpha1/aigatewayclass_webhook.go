@@ -0,0 +1,110 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/controller"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/litellm"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var aigatewayclasslog = logf.Log.WithName("aigatewayclass-resource")
+
+// SetupAiGatewayClassWebhookWithManager registers the webhook for AiGatewayClass in the manager.
+func SetupAiGatewayClassWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &gatewayv1alpha1.AiGatewayClass{}).
+		WithValidator(&AiGatewayClassCustomValidator{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// NOTE: The 'path' attribute must follow a specific pattern and should not be modified directly here.
+// Modifying the path for an invalid path can cause API server errors; failing to locate the webhook.
+// failurePolicy=ignore because the install bundle creates the "litellm" class
+// before the manager serving this webhook is up.
+// +kubebuilder:webhook:path=/validate-runtime-agentic-layer-ai-v1alpha1-aigatewayclass,mutating=false,failurePolicy=ignore,sideEffects=None,groups=runtime.agentic-layer.ai,resources=aigatewayclasses,verbs=create;update,versions=v1alpha1,name=vaigatewayclass-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// AiGatewayClassCustomValidator validates AiGatewayClasses that name this
+// operator's controller, or a near miss of it. Classes of other controllers
+// are admitted unchanged.
+//
+// NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
+// as this struct is used only for temporary operations and does not need to be deeply copied.
+type AiGatewayClassCustomValidator struct {
+	// Client lists the other AiGatewayClasses to enforce a single default.
+	Client client.Reader
+}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type AiGatewayClass.
+func (v *AiGatewayClassCustomValidator) ValidateCreate(ctx context.Context, class *gatewayv1alpha1.AiGatewayClass) (admission.Warnings, error) {
+	aigatewayclasslog.Info("Validation for AiGatewayClass upon creation", "name", class.GetName())
+
+	return nil, v.validateAiGatewayClass(ctx, class)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type AiGatewayClass.
+func (v *AiGatewayClassCustomValidator) ValidateUpdate(ctx context.Context, _, newClass *gatewayv1alpha1.AiGatewayClass) (admission.Warnings, error) {
+	aigatewayclasslog.Info("Validation for AiGatewayClass upon update", "name", newClass.GetName())
+
+	return nil, v.validateAiGatewayClass(ctx, newClass)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type AiGatewayClass.
+func (v *AiGatewayClassCustomValidator) ValidateDelete(_ context.Context, _ *gatewayv1alpha1.AiGatewayClass) (admission.Warnings, error) {
+	// No validation needed on delete
+	return nil, nil
+}
+
+// validateAiGatewayClass rejects a controller string that differs from
+// controller.ControllerName only in case or surrounding whitespace — such a
+// class would silently be served by nobody — and a second default class:
+// with two, class-less gateways would be claimed by two controllers.
+func (v *AiGatewayClassCustomValidator) validateAiGatewayClass(ctx context.Context, class *gatewayv1alpha1.AiGatewayClass) error {
+	controllerPath := field.NewPath("spec", "controller")
+	if c := class.Spec.Controller; c != controller.ControllerName {
+		if strings.EqualFold(strings.TrimSpace(c), controller.ControllerName) {
+			return field.ErrorList{field.Invalid(controllerPath, c,
+				fmt.Sprintf("must be exactly %q", controller.ControllerName))}.ToAggregate()
+		}
+		return nil
+	}
+
+	if class.Annotations[litellm.AiGatewayClassDefaultAnnotation] != "true" {
+		return nil
+	}
+	var classList gatewayv1alpha1.AiGatewayClassList
+	if err := v.Client.List(ctx, &classList); err != nil {
+		return fmt.Errorf("listing AiGatewayClasses: %w", err)
+	}
+	for _, other := range classList.Items {
+		if other.Name != class.Name && other.Annotations[litellm.AiGatewayClassDefaultAnnotation] == "true" {
+			annotationPath := field.NewPath("metadata", "annotations").Key(litellm.AiGatewayClassDefaultAnnotation)
+			return field.ErrorList{field.Forbidden(annotationPath, fmt.Sprintf(
+				"AiGatewayClass %s (controller %s) is already the default class; remove its annotation first",
+				other.Name, other.Spec.Controller))}.ToAggregate()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/controller"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/litellm"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAiGatewayClassValidator(t *testing.T) {
+	otherDefault := &gatewayv1alpha1.AiGatewayClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "other",
+			Annotations: map[string]string{litellm.AiGatewayClassDefaultAnnotation: "true"},
+		},
+		Spec: gatewayv1alpha1.AiGatewayClassSpec{Controller: "example.com/other-controller"},
+	}
+	cases := []struct {
+		name     string
+		existing bool
+		class    func() *gatewayv1alpha1.AiGatewayClass
+		want     string
+	}{
+		{
+			name:  "own default class",
+			class: defaultClass,
+		},
+		{
+			name:     "second default class",
+			existing: true,
+			class:    defaultClass,
+			want:     "already the default class",
+		},
+		{
+			name:     "own non-default class next to another default",
+			existing: true,
+			class: func() *gatewayv1alpha1.AiGatewayClass {
+				cls := defaultClass()
+				cls.Annotations = nil
+				return cls
+			},
+		},
+		{
+			name: "near miss of the controller name",
+			class: func() *gatewayv1alpha1.AiGatewayClass {
+				cls := defaultClass()
+				cls.Spec.Controller = strings.ToUpper(controller.ControllerName) + " "
+				return cls
+			},
+			want: "spec.controller: Invalid value",
+		},
+		{
+			name:     "other controller's default class is not checked",
+			existing: true,
+			class: func() *gatewayv1alpha1.AiGatewayClass {
+				cls := otherDefault.DeepCopy()
+				cls.Name = "third"
+				return cls
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &AiGatewayClassCustomValidator{Client: newValidator(t).Client}
+			if tc.existing {
+				v.Client = newValidator(t, otherDefault).Client
+			}
+			_, err := v.ValidateCreate(context.Background(), tc.class())
+			if tc.want == "" {
+				if err != nil {
+					t.Errorf("ValidateCreate: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("err = %v, want it to contain %q", err, tc.want)
+			}
+		})
+	}
+}
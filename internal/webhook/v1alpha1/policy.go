@@ -0,0 +1,144 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	gatewayv1alpha1 "github.com/agentic-layer/agent-runtime-operator/api/v1alpha1"
+	"github.com/agentic-layer/ai-gateway-litellm/internal/litellm"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// AllowedProvidersAnnotation on a Namespace lists, comma-separated, the
+// providers AiGateways in that namespace may use. It narrows
+// Policy.AllowedProviders; namespaces are usually only writable by cluster
+// admins, so tenants cannot widen their own allowlist.
+const AllowedProvidersAnnotation = "ai-gateway-litellm.agentic-layer.ai/allowed-providers"
+
+// Policy is the operator-wide governance enforced on AiGateways at admission.
+// The zero value enforces nothing.
+type Policy struct {
+	// MaxModels caps the number of aiModels per gateway. Zero means no limit.
+	MaxModels int
+	// AllowedProviders restricts aiModels[].provider cluster-wide. Empty
+	// means every known provider is allowed.
+	AllowedProviders sets.Set[string]
+	// RequireBudget requires litellm_settings.max_budget to be set in the
+	// gateway's config patch, so every gateway has a spend limit.
+	RequireBudget bool
+}
+
+// ParseProviderList splits a comma-separated provider list as used by the
+// --policy-allowed-providers flag and AllowedProvidersAnnotation, ignoring
+// whitespace and empty entries.
+func ParseProviderList(s string) sets.Set[string] {
+	providers := sets.New[string]()
+	for p := range strings.SplitSeq(s, ",") {
+		if p = strings.ToLower(strings.TrimSpace(p)); p != "" {
+			providers.Insert(p)
+		}
+	}
+	return providers
+}
+
+// validatePolicy rejects a gateway that violates v.Policy or the allowlist
+// annotation of its namespace.
+func (v *AiGatewayCustomValidator) validatePolicy(ctx context.Context, aigateway *gatewayv1alpha1.AiGateway) error {
+	spec := field.NewPath("spec")
+	var allErrs field.ErrorList
+
+	if limit := v.Policy.MaxModels; limit > 0 && len(aigateway.Spec.AiModels) > limit {
+		allErrs = append(allErrs, field.TooMany(spec.Child("aiModels"), len(aigateway.Spec.AiModels), limit))
+	}
+
+	allowed, err := v.allowedProviders(ctx, aigateway.Namespace)
+	if err != nil {
+		return err
+	}
+	if allowed != nil {
+		for i, model := range aigateway.Spec.AiModels {
+			if !allowed.Has(model.Provider) {
+				allErrs = append(allErrs, field.NotSupported(spec.Child("aiModels").Index(i).Child("provider"),
+					model.Provider, sets.List(allowed)))
+			}
+		}
+	}
+
+	if v.Policy.RequireBudget {
+		if err := requireBudget(ctx, v.Client, aigateway); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+	return allErrs.ToAggregate()
+}
+
+// allowedProviders returns the providers gateways in ns may use: the
+// intersection of Policy.AllowedProviders and the namespace's
+// AllowedProvidersAnnotation, whichever of them is set. nil means no
+// restriction.
+func (v *AiGatewayCustomValidator) allowedProviders(ctx context.Context, ns string) (sets.Set[string], error) {
+	allowed := v.Policy.AllowedProviders
+	if len(allowed) == 0 {
+		allowed = nil
+	}
+	var namespace corev1.Namespace
+	if err := v.APIReader.Get(ctx, client.ObjectKey{Name: ns}, &namespace); client.IgnoreNotFound(err) != nil {
+		return nil, fmt.Errorf("getting namespace %s: %w", ns, err)
+	}
+	value, ok := namespace.Annotations[AllowedProvidersAnnotation]
+	if !ok {
+		return allowed, nil
+	}
+	nsAllowed := ParseProviderList(value)
+	if allowed == nil {
+		return nsAllowed, nil
+	}
+	return allowed.Intersection(nsAllowed), nil
+}
+
+// requireBudget reports a missing or non-positive litellm_settings.max_budget
+// in the gateway's config patch.
+func requireBudget(ctx context.Context, c client.Reader, aigateway *gatewayv1alpha1.AiGateway) *field.Error {
+	path := field.NewPath("metadata", "annotations").Key(litellm.ConfigPatchAnnotation)
+	cmName := aigateway.Annotations[litellm.ConfigPatchAnnotation]
+	if cmName == "" {
+		return field.Required(path, "a config patch setting litellm_settings.max_budget is required by policy")
+	}
+	patch, err := litellm.LoadPatch(ctx, c, aigateway.Namespace, cmName)
+	if err != nil {
+		return field.Invalid(path, cmName, err.Error())
+	}
+	settings, _ := patch["litellm_settings"].(map[string]any)
+	var budget float64
+	switch b := settings["max_budget"].(type) {
+	case int:
+		budget = float64(b)
+	case float64:
+		budget = b
+	}
+	if budget <= 0 {
+		return field.Invalid(path, cmName, fmt.Sprintf(
+			"%s must set litellm_settings.max_budget to a positive number, as required by policy", litellm.PatchYAMLKey))
+	}
+	return nil
+}
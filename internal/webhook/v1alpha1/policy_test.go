@@ -0,0 +1,115 @@
+/*
+Copyright 2026 Agentic Layer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/agentic-layer/ai-gateway-litellm/internal/litellm"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestParseProviderList(t *testing.T) {
+	got := ParseProviderList(" OpenAI, anthropic,,")
+	if !got.Equal(sets.New("openai", "anthropic")) {
+		t.Errorf("got %v", sets.List(got))
+	}
+}
+
+func TestAiGatewayValidator_Policy(t *testing.T) {
+	namespace := func(allowed string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name: "default", Annotations: map[string]string{AllowedProvidersAnnotation: allowed},
+		}}
+	}
+	patch := func(body string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "patch", Namespace: "default"},
+			Data:       map[string]string{litellm.PatchYAMLKey: body},
+		}
+	}
+
+	cases := []struct {
+		name      string
+		policy    Policy
+		objects   []client.Object
+		withPatch bool
+		want      string
+	}{
+		{name: "no policy"},
+		{name: "too many models", policy: Policy{MaxModels: 1}, want: "must have at most 1 item"},
+		{name: "within model limit", policy: Policy{MaxModels: 2}},
+		{
+			name:   "provider outside the operator allowlist",
+			policy: Policy{AllowedProviders: sets.New("openai")},
+			want:   `spec.aiModels[1].provider: Unsupported value: "anthropic"`,
+		},
+		{
+			name:    "namespace narrows the operator allowlist",
+			policy:  Policy{AllowedProviders: sets.New("openai", "anthropic")},
+			objects: []client.Object{namespace("openai")},
+			want:    `Unsupported value: "anthropic"`,
+		},
+		{
+			name:    "namespace cannot widen the operator allowlist",
+			policy:  Policy{AllowedProviders: sets.New("openai")},
+			objects: []client.Object{namespace("openai,anthropic")},
+			want:    `Unsupported value: "anthropic"`,
+		},
+		{name: "namespace allowlist alone", objects: []client.Object{namespace("openai, anthropic")}},
+		{name: "budget required without patch", policy: Policy{RequireBudget: true}, want: "is required by policy"},
+		{
+			name:      "budget missing in patch",
+			policy:    Policy{RequireBudget: true},
+			objects:   []client.Object{patch("general_settings: {}\n")},
+			withPatch: true,
+			want:      "must set litellm_settings.max_budget",
+		},
+		{
+			name:      "budget set",
+			policy:    Policy{RequireBudget: true},
+			objects:   []client.Object{patch("litellm_settings:\n  max_budget: 100\n")},
+			withPatch: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			objects := append([]client.Object{defaultClass(), apiKeySecret("OPENAI_API_KEY", "ANTHROPIC_API_KEY")}, tc.objects...)
+			v := newValidator(t, objects...)
+			v.Policy = tc.policy
+			gw := validGateway()
+			if tc.withPatch {
+				gw.Annotations = map[string]string{litellm.ConfigPatchAnnotation: "patch"}
+			}
+			_, err := v.ValidateCreate(context.Background(), gw)
+			if tc.want == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("want error containing %q, got %v", tc.want, err)
+			}
+		})
+	}
+}